@@ -11,6 +11,7 @@ import {
     mdiSourceBranchCheck,
     mdiSourceBranchRefresh,
     mdiSourceBranchSync,
+    mdiSourceMerge,
     mdiUpload,
     mdiUploadNetwork,
 } from '@mdi/js'
@@ -92,6 +93,8 @@ const PreviewAction: React.FunctionComponent<React.PropsWithChildren<PreviewActi
             return <PreviewActionArchive className={className} />
         case ChangesetSpecOperation.REATTACH:
             return <PreviewActionReattach className={className} />
+        case ChangesetSpecOperation.MERGE:
+            return <PreviewActionMerge className={className} />
         case ChangesetSpecOperation.SYNC:
         case ChangesetSpecOperation.SLEEP:
             // We don't want to expose these states.
@@ -399,6 +402,21 @@ export const PreviewActionReattach: React.FunctionComponent<React.PropsWithChild
     </div>
 )
 
+export const PreviewActionMerge: React.FunctionComponent<React.PropsWithChildren<{ className?: string }>> = ({
+    className,
+}) => (
+    <div className={classNames(className, iconClassNames)}>
+        <Tooltip content="This changeset will be merged automatically on its code host">
+            <Icon
+                aria-label="This changeset will be merged automatically on its code host"
+                className="text-muted mr-1"
+                svgPath={mdiSourceMerge}
+            />
+        </Tooltip>
+        <span aria-hidden={true}>Merge</span>
+    </div>
+)
+
 export enum NoActionReason {
     NO_ACCESS = 'no-access',
 }
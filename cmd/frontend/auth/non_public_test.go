@@ -16,7 +16,7 @@ import (
 )
 
 func TestAllowAnonymousRequest(t *testing.T) {
-	ui.InitRouter(database.NewMockDB())
+	ui.InitRouter(database.NewMockDB(), nil)
 	// Ensure auth.public is false (be robust against some other tests having side effects that
 	// change it, or changed defaults).
 	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{AuthPublic: false, AuthProviders: []schema.AuthProviders{{Builtin: &schema.BuiltinAuthProvider{}}}}})
@@ -54,7 +54,7 @@ func TestAllowAnonymousRequest(t *testing.T) {
 }
 
 func TestNewUserRequiredAuthzMiddleware(t *testing.T) {
-	ui.InitRouter(database.NewMockDB())
+	ui.InitRouter(database.NewMockDB(), nil)
 	// Ensure auth.public is false (be robust against some other tests having side effects that
 	// change it, or changed defaults).
 	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{AuthPublic: false, AuthProviders: []schema.AuthProviders{{Builtin: &schema.BuiltinAuthProvider{}}}}})
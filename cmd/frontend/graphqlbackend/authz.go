@@ -18,6 +18,10 @@ type AuthzResolver interface {
 	ScheduleUserPermissionsSync(ctx context.Context, args *UserPermissionsSyncArgs) (*EmptyResponse, error)
 	SetSubRepositoryPermissionsForUsers(ctx context.Context, args *SubRepoPermsArgs) (*EmptyResponse, error)
 	SetRepositoryPermissionsForBitbucketProject(ctx context.Context, args *RepoPermsBitbucketProjectArgs) (*EmptyResponse, error)
+	CreateSubRepositoryPermissionRule(ctx context.Context, args *CreateSubRepoPermissionRuleArgs) (SubRepoPermissionRuleResolver, error)
+	UpdateSubRepositoryPermissionRule(ctx context.Context, args *UpdateSubRepoPermissionRuleArgs) (SubRepoPermissionRuleResolver, error)
+	DeleteSubRepositoryPermissionRule(ctx context.Context, args *DeleteSubRepoPermissionRuleArgs) (*EmptyResponse, error)
+	ResyncRepositoryPermissions(ctx context.Context, args *ResyncRepositoryPermissionsArgs) (RepositoryPermissionsResyncResolver, error)
 
 	// Queries
 	AuthorizedUserRepositories(ctx context.Context, args *AuthorizedRepoArgs) (RepositoryConnectionResolver, error)
@@ -26,6 +30,9 @@ type AuthzResolver interface {
 	BitbucketProjectPermissionJobs(ctx context.Context, args *BitbucketProjectPermissionJobsArgs) (BitbucketProjectsPermissionJobsResolver, error)
 	AuthzProviderTypes(ctx context.Context) ([]string, error)
 	PermissionsSyncJobs(ctx context.Context, args *PermissionsSyncJobsArgs) (PermissionsSyncJobsConnection, error)
+	SubRepositoryPermissionRules(ctx context.Context, args *SubRepoPermissionRulesArgs) ([]SubRepoPermissionRuleResolver, error)
+	EvaluateSubRepositoryPermissionRules(ctx context.Context, args *EvaluateSubRepoPermissionRulesArgs) ([]SubRepoPathAccessResolver, error)
+	ExplainRepositoryPermissions(ctx context.Context, args *ExplainRepositoryPermissionsArgs) (RepositoryPermissionsExplanationResolver, error)
 
 	// Helpers
 	RepositoryPermissionsInfo(ctx context.Context, repoID graphql.ID) (PermissionsInfoResolver, error)
@@ -152,3 +159,89 @@ type PermissionsProviderStateResolver interface {
 	Status() string
 	Message() string
 }
+
+type SubRepoPermissionRuleInput struct {
+	RepositoryPattern string
+	User              *graphql.ID
+	Organization      *graphql.ID
+	Paths             []string
+}
+
+type CreateSubRepoPermissionRuleArgs struct {
+	Rule SubRepoPermissionRuleInput
+}
+
+type UpdateSubRepoPermissionRuleArgs struct {
+	ID   graphql.ID
+	Rule SubRepoPermissionRuleInput
+}
+
+type DeleteSubRepoPermissionRuleArgs struct {
+	ID graphql.ID
+}
+
+type SubRepoPermissionRulesArgs struct {
+	Repository *graphql.ID
+}
+
+type EvaluateSubRepoPermissionRulesArgs struct {
+	Repository graphql.ID
+	Path       string
+	DryRunRule *SubRepoPermissionRuleInput
+}
+
+type SubRepoPermissionRuleResolver interface {
+	ID() graphql.ID
+	RepositoryPattern() string
+	User(ctx context.Context) (*UserResolver, error)
+	Organization(ctx context.Context) (*OrgResolver, error)
+	Paths() []string
+	CreatedAt() gqlutil.DateTime
+	UpdatedAt() gqlutil.DateTime
+}
+
+type SubRepoPathAccessResolver interface {
+	User() *UserResolver
+	CanRead() bool
+	Rules() []SubRepoPermissionRuleResolver
+	MatchedByDryRunRule() bool
+}
+
+type ExplainRepositoryPermissionsArgs struct {
+	User       graphql.ID
+	Repository graphql.ID
+	Path       *string
+}
+
+type ResyncRepositoryPermissionsArgs struct {
+	User           graphql.ID
+	Repository     graphql.ID
+	Path           *string
+	TimeoutSeconds int32
+}
+
+type RepositoryPermissionsReasonResolver interface {
+	Mechanism() string
+	GrantsAccess() bool
+	Message() string
+	ProviderType() *string
+	ProviderID() *string
+	SyncedAt() *gqlutil.DateTime
+}
+
+type RepositoryPermissionsExplanationResolver interface {
+	User() *UserResolver
+	Repository() *RepositoryResolver
+	Path() *string
+	CanRead() bool
+	Reasons() []RepositoryPermissionsReasonResolver
+	RecentSyncJobs() []PermissionsSyncJobResolver
+}
+
+type RepositoryPermissionsResyncResolver interface {
+	Completed() bool
+	Before() RepositoryPermissionsExplanationResolver
+	After() RepositoryPermissionsExplanationResolver
+	AddedReasons() []RepositoryPermissionsReasonResolver
+	RemovedReasons() []RepositoryPermissionsReasonResolver
+}
@@ -54,6 +54,17 @@ type DeleteBatchChangeArgs struct {
 	BatchChange graphql.ID
 }
 
+type SetBatchChangeScheduleArgs struct {
+	BatchChange            graphql.ID
+	Cron                   string
+	AutoApply              string
+	MaxConsecutiveFailures int32
+}
+
+type DeleteBatchChangeScheduleArgs struct {
+	BatchChange graphql.ID
+}
+
 type SyncChangesetArgs struct {
 	Changeset graphql.ID
 }
@@ -267,6 +278,8 @@ type BatchChangesResolver interface {
 	CloseBatchChange(ctx context.Context, args *CloseBatchChangeArgs) (BatchChangeResolver, error)
 	MoveBatchChange(ctx context.Context, args *MoveBatchChangeArgs) (BatchChangeResolver, error)
 	DeleteBatchChange(ctx context.Context, args *DeleteBatchChangeArgs) (*EmptyResponse, error)
+	SetBatchChangeSchedule(ctx context.Context, args *SetBatchChangeScheduleArgs) (BatchChangeResolver, error)
+	DeleteBatchChangeSchedule(ctx context.Context, args *DeleteBatchChangeScheduleArgs) (BatchChangeResolver, error)
 	CreateBatchChangesCredential(ctx context.Context, args *CreateBatchChangesCredentialArgs) (BatchChangesCredentialResolver, error)
 	DeleteBatchChangesCredential(ctx context.Context, args *DeleteBatchChangesCredentialArgs) (*EmptyResponse, error)
 
@@ -495,6 +508,10 @@ type ChangesetSpecDeltaResolver interface {
 	CommitMessageChanged() bool
 	AuthorNameChanged() bool
 	AuthorEmailChanged() bool
+	ReviewersChanged() bool
+	LabelsChanged() bool
+	AssigneesChanged() bool
+	MilestoneChanged() bool
 }
 
 type ChangesetDescription interface {
@@ -657,6 +674,37 @@ type BatchChangeResolver interface {
 	CurrentSpec(ctx context.Context) (BatchSpecResolver, error)
 	BulkOperations(ctx context.Context, args *ListBatchChangeBulkOperationArgs) (BulkOperationConnectionResolver, error)
 	BatchSpecs(ctx context.Context, args *ListBatchSpecArgs) (BatchSpecConnectionResolver, error)
+	Schedule(ctx context.Context) (BatchChangeScheduleResolver, error)
+	ChangesetDependencies(ctx context.Context) ([]ChangesetDependencyResolver, error)
+}
+
+type ChangesetDependencyResolver interface {
+	Changeset() ExternalChangesetResolver
+	DependsOn() []ExternalChangesetResolver
+}
+
+type BatchChangeScheduleResolver interface {
+	Cron() string
+	Creator(ctx context.Context) (*UserResolver, error)
+	AutoApply() string
+	MaxConsecutiveFailures() int32
+	ConsecutiveFailures() int32
+	NextRunAt() *gqlutil.DateTime
+	PausedAt() *gqlutil.DateTime
+	Runs(ctx context.Context, args *ListBatchChangeScheduleRunsArgs) ([]BatchChangeScheduleRunResolver, error)
+}
+
+type ListBatchChangeScheduleRunsArgs struct {
+	First int32
+}
+
+type BatchChangeScheduleRunResolver interface {
+	State() string
+	BatchSpec(ctx context.Context) (BatchSpecResolver, error)
+	Applied() bool
+	FailureMessage() *string
+	StartedAt() gqlutil.DateTime
+	FinishedAt() *gqlutil.DateTime
 }
 
 type BatchChangesConnectionResolver interface {
@@ -792,6 +840,9 @@ type ExternalChangesetResolver interface {
 	ScheduleEstimateAt(ctx context.Context) (*gqlutil.DateTime, error)
 
 	CurrentSpec(ctx context.Context) (VisibleChangesetSpecResolver, error)
+
+	Blocked() bool
+	DependsOn(ctx context.Context) ([]ExternalChangesetResolver, error)
 }
 
 type ChangesetEventsConnectionResolver interface {
@@ -905,6 +956,7 @@ type BatchSpecWorkspaceStepResolver interface {
 	Number() int32
 	Run() string
 	Container() string
+	Uses() *string
 	IfCondition() *string
 	CachedResultFound() bool
 	Skipped() bool
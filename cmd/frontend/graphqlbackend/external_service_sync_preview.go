@@ -0,0 +1,167 @@
+package graphqlbackend
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// previewExternalServiceSyncTimeout bounds how long repo-updater may take to
+// list the repositories of a proposed configuration.
+const previewExternalServiceSyncTimeout = 30 * time.Second
+
+type previewExternalServiceSyncArgs struct {
+	ID     *graphql.ID
+	Kind   *string
+	Config string
+}
+
+func (r *schemaResolver) PreviewExternalServiceSync(ctx context.Context, args *previewExternalServiceSyncArgs) (*externalServiceSyncPreviewResolver, error) {
+	// 🚨 SECURITY: Only site admins may manage external services, and the
+	// preview lists repositories regardless of repository permissions.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	var req protocol.ExternalServiceSyncDryRunRequest
+	if args.ID != nil {
+		id, err := UnmarshalExternalServiceID(*args.ID)
+		if err != nil {
+			return nil, err
+		}
+		es, err := r.db.ExternalServices().GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		config, err := unredactExternalServiceConfig(ctx, es, args.Config)
+		if err != nil {
+			return nil, err
+		}
+		req = protocol.ExternalServiceSyncDryRunRequest{ExternalServiceID: id, Kind: es.Kind, Config: config}
+	} else {
+		if args.Kind == nil {
+			return nil, errors.New("kind is required for a new code host connection")
+		}
+		req = protocol.ExternalServiceSyncDryRunRequest{Kind: *args.Kind, Config: args.Config}
+	}
+
+	result, err := r.previewExternalServiceSync(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &externalServiceSyncPreviewResolver{result: result}, nil
+}
+
+// previewExternalServiceSync validates the configuration of req and then
+// computes the changes syncing it would make, without persisting anything.
+func (r *schemaResolver) previewExternalServiceSync(ctx context.Context, req protocol.ExternalServiceSyncDryRunRequest) (*protocol.ExternalServiceSyncDryRunResult, error) {
+	if _, err := database.ValidateExternalServiceConfig(ctx, r.db.ExternalServices(), database.ValidateExternalServiceConfigOptions{
+		ExternalServiceID: req.ExternalServiceID,
+		Kind:              req.Kind,
+		Config:            req.Config,
+		AuthProviders:     conf.Get().AuthProviders,
+	}); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, previewExternalServiceSyncTimeout)
+	defer cancel()
+
+	result, err := r.repoupdaterClient.SyncExternalServiceDryRun(ctx, req)
+	if err != nil {
+		return nil, &ErrExternalServiceSyncPreviewFailed{err: err}
+	}
+	return result, nil
+}
+
+// ErrExternalServiceSyncPreviewFailed is returned when the changes syncing a
+// configuration would make can't be computed, for example because the code
+// host can't be reached.
+type ErrExternalServiceSyncPreviewFailed struct {
+	err error
+}
+
+func (e *ErrExternalServiceSyncPreviewFailed) Error() string {
+	return "previewing the sync with this configuration failed: " + e.err.Error()
+}
+
+func (e *ErrExternalServiceSyncPreviewFailed) Unwrap() error {
+	return e.err
+}
+
+func (e *ErrExternalServiceSyncPreviewFailed) Extensions() map[string]any {
+	return map[string]any{"code": "ErrExternalServiceSyncPreviewFailed"}
+}
+
+// unredactExternalServiceConfig returns config, which was submitted for es and
+// may contain redacted secrets, with the secrets of es.
+func unredactExternalServiceConfig(ctx context.Context, es *types.ExternalService, config string) (string, error) {
+	proposed := &types.ExternalService{Kind: es.Kind, Config: extsvc.NewUnencryptedConfig(config)}
+	if err := proposed.UnredactConfig(ctx, es); err != nil {
+		return "", err
+	}
+	return proposed.Config.Decrypt(ctx)
+}
+
+// checkExternalServiceDeletions returns an error if syncing es with the given
+// unredacted config would delete more repositories than allowed without
+// confirmation, or if the config is invalid or the deletions can't be
+// previewed.
+func (r *schemaResolver) checkExternalServiceDeletions(ctx context.Context, es *types.ExternalService, config string) error {
+	threshold := conf.RepoDeletionConfirmationThreshold()
+	if threshold < 0 {
+		return nil
+	}
+
+	result, err := r.previewExternalServiceSync(ctx, protocol.ExternalServiceSyncDryRunRequest{
+		ExternalServiceID: es.ID,
+		Kind:              es.Kind,
+		Config:            config,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(result.Deleted) > threshold {
+		return errors.Errorf(
+			"saving this configuration would delete %d repositories, which is more than the %d allowed without confirmation (see the repoDeletionConfirmationThreshold site configuration). Preview the changes with previewExternalServiceSync and set confirmDeletions to save it anyway",
+			len(result.Deleted),
+			threshold,
+		)
+	}
+	return nil
+}
+
+type externalServiceSyncPreviewResolver struct {
+	result *protocol.ExternalServiceSyncDryRunResult
+}
+
+func (r *externalServiceSyncPreviewResolver) Added() []string {
+	return r.result.Added
+}
+
+func (r *externalServiceSyncPreviewResolver) Modified() []string {
+	return r.result.Modified
+}
+
+func (r *externalServiceSyncPreviewResolver) Deleted() []string {
+	return r.result.Deleted
+}
+
+func (r *externalServiceSyncPreviewResolver) UnmodifiedCount() int32 {
+	return int32(r.result.Unmodified)
+}
+
+func (r *externalServiceSyncPreviewResolver) RequiresConfirmation() bool {
+	threshold := conf.RepoDeletionConfirmationThreshold()
+	return threshold >= 0 && len(r.result.Deleted) > threshold
+}
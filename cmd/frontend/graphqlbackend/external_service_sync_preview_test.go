@@ -0,0 +1,168 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPreviewExternalServiceSync(t *testing.T) {
+	threshold := 1
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{RepoDeletionConfirmationThreshold: &threshold}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	var dryRuns []protocol.ExternalServiceSyncDryRunRequest
+	repoupdater.MockSyncExternalServiceDryRun = func(_ context.Context, req protocol.ExternalServiceSyncDryRunRequest) (*protocol.ExternalServiceSyncDryRunResult, error) {
+		dryRuns = append(dryRuns, req)
+		return &protocol.ExternalServiceSyncDryRunResult{
+			Added:      []string{"github.com/sourcegraph/added"},
+			Modified:   []string{},
+			Deleted:    []string{"github.com/sourcegraph/a", "github.com/sourcegraph/b"},
+			Unmodified: 3,
+		}, nil
+	}
+	t.Cleanup(func() { repoupdater.MockSyncExternalServiceDryRun = nil })
+
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int64) (*types.ExternalService, error) {
+		return &types.ExternalService{
+			ID:     id,
+			Kind:   extsvc.KindGitHub,
+			Config: extsvc.NewUnencryptedConfig(`{"url": "https://github.com", "repositoryQuery": ["affiliated"], "token": "secret"}`),
+		}, nil
+	})
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.ExternalServicesFunc.SetDefaultReturn(externalServices)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	newConfig := `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "` + types.RedactedSecret + `"}`
+
+	t.Run("preview", func(t *testing.T) {
+		dryRuns = nil
+		RunTest(t, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				query($config: String!) {
+					previewExternalServiceSync(id: "RXh0ZXJuYWxTZXJ2aWNlOjQ=", config: $config) {
+						added
+						modified
+						deleted
+						unmodifiedCount
+						requiresConfirmation
+					}
+				}
+			`,
+			Variables: map[string]any{"config": newConfig},
+			ExpectedResult: `
+				{
+					"previewExternalServiceSync": {
+						"added": ["github.com/sourcegraph/added"],
+						"modified": [],
+						"deleted": ["github.com/sourcegraph/a", "github.com/sourcegraph/b"],
+						"unmodifiedCount": 3,
+						"requiresConfirmation": true
+					}
+				}
+			`,
+		})
+
+		if len(dryRuns) != 1 {
+			t.Fatalf("expected one dry run, got %d", len(dryRuns))
+		}
+		// The redacted token must be replaced with the stored one.
+		if req := dryRuns[0]; req.ExternalServiceID != 4 || !strings.Contains(req.Config, `"secret"`) {
+			t.Fatalf("unexpected dry run request: %+v", req)
+		}
+	})
+
+	t.Run("update requires confirmation", func(t *testing.T) {
+		_, err := newSchemaResolver(db, nil).UpdateExternalService(ctx, &updateExternalServiceArgs{
+			Input: updateExternalServiceInput{
+				ID:     "RXh0ZXJuYWxTZXJ2aWNlOjQ=",
+				Config: &newConfig,
+			},
+		})
+		if err == nil || !strings.Contains(err.Error(), "would delete 2 repositories") {
+			t.Fatalf("expected confirmation error, got %v", err)
+		}
+		if len(externalServices.UpdateFunc.History()) != 0 {
+			t.Fatal("expected external service not to be updated")
+		}
+	})
+
+	t.Run("update fails if the preview fails", func(t *testing.T) {
+		mockDryRun := repoupdater.MockSyncExternalServiceDryRun
+		repoupdater.MockSyncExternalServiceDryRun = func(context.Context, protocol.ExternalServiceSyncDryRunRequest) (*protocol.ExternalServiceSyncDryRunResult, error) {
+			return nil, errors.New("code host unavailable")
+		}
+		t.Cleanup(func() { repoupdater.MockSyncExternalServiceDryRun = mockDryRun })
+
+		_, err := newSchemaResolver(db, nil).UpdateExternalService(ctx, &updateExternalServiceArgs{
+			Input: updateExternalServiceInput{
+				ID:     "RXh0ZXJuYWxTZXJ2aWNlOjQ=",
+				Config: &newConfig,
+			},
+		})
+		var previewErr *ErrExternalServiceSyncPreviewFailed
+		if !errors.As(err, &previewErr) || !strings.Contains(err.Error(), "code host unavailable") {
+			t.Fatalf("expected preview error, got %v", err)
+		}
+		if len(externalServices.UpdateFunc.History()) != 0 {
+			t.Fatal("expected external service not to be updated")
+		}
+	})
+
+	t.Run("update with an invalid config", func(t *testing.T) {
+		dryRuns = nil
+		invalidConfig := `{"url": "https://github.com", "token": "` + types.RedactedSecret + `", "repositoryQuery": ["none"], "exclude": [{"unknown": "field"}]}`
+		_, err := newSchemaResolver(db, nil).UpdateExternalService(ctx, &updateExternalServiceArgs{
+			Input: updateExternalServiceInput{
+				ID:     "RXh0ZXJuYWxTZXJ2aWNlOjQ=",
+				Config: &invalidConfig,
+			},
+		})
+		if err == nil || !strings.Contains(err.Error(), "Additional property unknown is not allowed") {
+			t.Fatalf("expected validation error, got %v", err)
+		}
+		if len(dryRuns) != 0 {
+			t.Fatal("expected no dry run for an invalid config")
+		}
+	})
+
+	t.Run("update with confirmation", func(t *testing.T) {
+		dryRuns = nil
+		confirm := true
+		_, err := newSchemaResolver(db, nil).UpdateExternalService(ctx, &updateExternalServiceArgs{
+			Input: updateExternalServiceInput{
+				ID:               "RXh0ZXJuYWxTZXJ2aWNlOjQ=",
+				Config:           &newConfig,
+				ConfirmDeletions: &confirm,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(dryRuns) != 0 {
+			t.Fatal("expected no dry run when deletions are confirmed")
+		}
+		if len(externalServices.UpdateFunc.History()) != 1 {
+			t.Fatal("expected external service to be updated")
+		}
+	})
+}
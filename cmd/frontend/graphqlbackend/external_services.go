@@ -89,9 +89,10 @@ type updateExternalServiceArgs struct {
 }
 
 type updateExternalServiceInput struct {
-	ID          graphql.ID
-	DisplayName *string
-	Config      *string
+	ID               graphql.ID
+	DisplayName      *string
+	Config           *string
+	ConfirmDeletions *bool
 }
 
 func (r *schemaResolver) UpdateExternalService(ctx context.Context, args *updateExternalServiceArgs) (*externalServiceResolver, error) {
@@ -128,6 +129,21 @@ func (r *schemaResolver) UpdateExternalService(ctx context.Context, args *update
 		return nil, err
 	}
 
+	// Protect against accidentally deleting lots of repositories, for example
+	// with a typo in a repository query.
+	if args.Input.Config != nil && (args.Input.ConfirmDeletions == nil || !*args.Input.ConfirmDeletions) {
+		var config string
+		config, err = unredactExternalServiceConfig(ctx, es, *args.Input.Config)
+		if err != nil {
+			return nil, err
+		}
+		if config != oldConfig {
+			if err = r.checkExternalServiceDeletions(ctx, es, config); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	ps := conf.Get().AuthProviders
 	update := &database.ExternalServiceUpdate{
 		DisplayName: args.Input.DisplayName,
@@ -22,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -143,6 +144,12 @@ func TestUpdateExternalService(t *testing.T) {
 		}
 	})
 
+	// The sync preview of the new configuration doesn't delete any repositories.
+	repoupdater.MockSyncExternalServiceDryRun = func(context.Context, protocol.ExternalServiceSyncDryRunRequest) (*protocol.ExternalServiceSyncDryRunResult, error) {
+		return &protocol.ExternalServiceSyncDryRunResult{}, nil
+	}
+	t.Cleanup(func() { repoupdater.MockSyncExternalServiceDryRun = nil })
+
 	var cachedUpdate *database.ExternalServiceUpdate
 
 	users := database.NewMockUserStore()
@@ -2,6 +2,8 @@ package graphqlbackend
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -32,6 +34,13 @@ func (f *FeatureFlagResolver) ToFeatureFlagRollout() (*FeatureFlagRolloutResolve
 	return nil, false
 }
 
+func (f *FeatureFlagResolver) ToFeatureFlagVariant() (*FeatureFlagVariantResolver, bool) {
+	if f.inner.Variant != nil {
+		return &FeatureFlagVariantResolver{f.inner}, true
+	}
+	return nil, false
+}
+
 type FeatureFlagBooleanResolver struct {
 	db database.DB
 	// Invariant: inner.Bool is non-nil
@@ -40,6 +49,9 @@ type FeatureFlagBooleanResolver struct {
 
 func (f *FeatureFlagBooleanResolver) Name() string { return f.inner.Name }
 func (f *FeatureFlagBooleanResolver) Value() bool  { return f.inner.Bool.Value }
+func (f *FeatureFlagBooleanResolver) Rules() []*FeatureFlagTargetingRuleResolver {
+	return rulesToResolvers(f.inner.Rules)
+}
 func (f *FeatureFlagBooleanResolver) Overrides(ctx context.Context) ([]*FeatureFlagOverrideResolver, error) {
 	overrides, err := f.db.FeatureFlags().GetOverridesForFlag(ctx, f.inner.Name)
 	if err != nil {
@@ -56,6 +68,9 @@ type FeatureFlagRolloutResolver struct {
 
 func (f *FeatureFlagRolloutResolver) Name() string              { return f.inner.Name }
 func (f *FeatureFlagRolloutResolver) RolloutBasisPoints() int32 { return f.inner.Rollout.Rollout }
+func (f *FeatureFlagRolloutResolver) Rules() []*FeatureFlagTargetingRuleResolver {
+	return rulesToResolvers(f.inner.Rules)
+}
 func (f *FeatureFlagRolloutResolver) Overrides(ctx context.Context) ([]*FeatureFlagOverrideResolver, error) {
 	overrides, err := f.db.FeatureFlags().GetOverridesForFlag(ctx, f.inner.Name)
 	if err != nil {
@@ -64,6 +79,107 @@ func (f *FeatureFlagRolloutResolver) Overrides(ctx context.Context) ([]*FeatureF
 	return overridesToResolvers(f.db, overrides), nil
 }
 
+type FeatureFlagVariantResolver struct {
+	// Invariant: inner.Variant is non-nil
+	inner *featureflag.FeatureFlag
+}
+
+func (f *FeatureFlagVariantResolver) Name() string { return f.inner.Name }
+func (f *FeatureFlagVariantResolver) Value() JSONValue {
+	return JSONValue{f.inner.Variant.Value}
+}
+func (f *FeatureFlagVariantResolver) Rules() []*FeatureFlagTargetingRuleResolver {
+	return rulesToResolvers(f.inner.Rules)
+}
+
+func rulesToResolvers(input []*featureflag.TargetingRule) []*FeatureFlagTargetingRuleResolver {
+	res := make([]*FeatureFlagTargetingRuleResolver, 0, len(input))
+	for _, rule := range input {
+		res = append(res, &FeatureFlagTargetingRuleResolver{rule})
+	}
+	return res
+}
+
+type FeatureFlagTargetingRuleResolver struct {
+	inner *featureflag.TargetingRule
+}
+
+func (r *FeatureFlagTargetingRuleResolver) Authenticated() *bool { return r.inner.Authenticated }
+func (r *FeatureFlagTargetingRuleResolver) SiteAdmin() *bool     { return r.inner.SiteAdmin }
+func (r *FeatureFlagTargetingRuleResolver) Users() []graphql.ID {
+	ids := make([]graphql.ID, 0, len(r.inner.UserIDs))
+	for _, id := range r.inner.UserIDs {
+		ids = append(ids, MarshalUserID(id))
+	}
+	return ids
+}
+func (r *FeatureFlagTargetingRuleResolver) Orgs() []graphql.ID {
+	ids := make([]graphql.ID, 0, len(r.inner.OrgIDs))
+	for _, id := range r.inner.OrgIDs {
+		ids = append(ids, MarshalOrgID(id))
+	}
+	return ids
+}
+func (r *FeatureFlagTargetingRuleResolver) EmailDomains() []string {
+	if r.inner.EmailDomains == nil {
+		return []string{}
+	}
+	return r.inner.EmailDomains
+}
+func (r *FeatureFlagTargetingRuleResolver) Value() JSONValue { return JSONValue{r.inner.Value} }
+
+type featureFlagTargetingRuleInput struct {
+	Authenticated *bool
+	SiteAdmin     *bool
+	Users         *[]graphql.ID
+	Orgs          *[]graphql.ID
+	EmailDomains  *[]string
+	Value         JSONValue
+}
+
+func unmarshalTargetingRules(input *[]featureFlagTargetingRuleInput) ([]*featureflag.TargetingRule, error) {
+	if input == nil {
+		return nil, nil
+	}
+	rules := make([]*featureflag.TargetingRule, 0, len(*input))
+	for _, in := range *input {
+		rule := &featureflag.TargetingRule{
+			Authenticated: in.Authenticated,
+			SiteAdmin:     in.SiteAdmin,
+		}
+		if in.Users != nil {
+			for _, id := range *in.Users {
+				userID, err := UnmarshalUserID(id)
+				if err != nil {
+					return nil, err
+				}
+				rule.UserIDs = append(rule.UserIDs, userID)
+			}
+		}
+		if in.Orgs != nil {
+			for _, id := range *in.Orgs {
+				orgID, err := UnmarshalOrgID(id)
+				if err != nil {
+					return nil, err
+				}
+				rule.OrgIDs = append(rule.OrgIDs, orgID)
+			}
+		}
+		if in.EmailDomains != nil {
+			for _, domain := range *in.EmailDomains {
+				rule.EmailDomains = append(rule.EmailDomains, strings.ToLower(strings.TrimPrefix(domain, "@")))
+			}
+		}
+		value, err := json.Marshal(in.Value.Value)
+		if err != nil {
+			return nil, err
+		}
+		rule.Value = value
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
 func overridesToResolvers(db database.DB, input []*featureflag.Override) []*FeatureFlagOverrideResolver {
 	res := make([]*FeatureFlagOverrideResolver, 0, len(input))
 	for _, flag := range input {
@@ -115,8 +231,9 @@ func unmarshalOverrideID(id graphql.ID) (spec overrideSpec, err error) {
 }
 
 type EvaluatedFeatureFlagResolver struct {
-	name  string
-	value bool
+	name    string
+	value   bool
+	variant json.RawMessage
 }
 
 func (e *EvaluatedFeatureFlagResolver) Name() string {
@@ -127,6 +244,13 @@ func (e *EvaluatedFeatureFlagResolver) Value() bool {
 	return e.value
 }
 
+func (e *EvaluatedFeatureFlagResolver) Variant() *JSONValue {
+	if e.variant == nil {
+		return nil
+	}
+	return &JSONValue{e.variant}
+}
+
 func (r *schemaResolver) EvaluateFeatureFlag(ctx context.Context, args *struct {
 	FlagName string
 }) *bool {
@@ -137,14 +261,29 @@ func (r *schemaResolver) EvaluateFeatureFlag(ctx context.Context, args *struct {
 	return nil
 }
 
+func (r *schemaResolver) EvaluateFeatureFlagVariant(ctx context.Context, args *struct {
+	FlagName string
+}) *JSONValue {
+	flagSet := featureflag.FromContext(ctx)
+	if v, ok := flagSet.GetVariant(args.FlagName); ok {
+		return &JSONValue{v}
+	}
+	return nil
+}
+
 func (r *schemaResolver) EvaluatedFeatureFlags(ctx context.Context) []*EvaluatedFeatureFlagResolver {
 	return evaluatedFlagsToResolvers(featureflag.GetEvaluatedFlagSet(ctx))
 }
 
-func evaluatedFlagsToResolvers(input map[string]bool) []*EvaluatedFeatureFlagResolver {
+func evaluatedFlagsToResolvers(input featureflag.EvaluatedFlagSet) []*EvaluatedFeatureFlagResolver {
 	res := make([]*EvaluatedFeatureFlagResolver, 0, len(input))
 	for k, v := range input {
-		res = append(res, &EvaluatedFeatureFlagResolver{name: k, value: v})
+		switch v := v.(type) {
+		case bool:
+			res = append(res, &EvaluatedFeatureFlagResolver{name: k, value: v})
+		case json.RawMessage:
+			res = append(res, &EvaluatedFeatureFlagResolver{name: k, variant: v})
+		}
 	}
 	return res
 }
@@ -218,27 +357,50 @@ func flagsToResolvers(db database.DB, flags []*featureflag.FeatureFlag) []*Featu
 	return res
 }
 
-func (r *schemaResolver) CreateFeatureFlag(ctx context.Context, args struct {
+type featureFlagArgs struct {
 	Name               string
 	Value              *bool
 	RolloutBasisPoints *int32
-}) (*FeatureFlagResolver, error) {
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
-		return nil, err
-	}
-
-	ff := r.db.FeatureFlags()
+	VariantValue       *JSONValue
+	Rules              *[]featureFlagTargetingRuleInput
+}
 
-	var res *featureflag.FeatureFlag
-	var err error
+func (args *featureFlagArgs) toFeatureFlag() (*featureflag.FeatureFlag, error) {
+	ff := &featureflag.FeatureFlag{Name: args.Name}
 	if args.Value != nil {
-		res, err = ff.CreateBool(ctx, args.Name, *args.Value)
+		ff.Bool = &featureflag.FeatureFlagBool{Value: *args.Value}
 	} else if args.RolloutBasisPoints != nil {
-		res, err = ff.CreateRollout(ctx, args.Name, *args.RolloutBasisPoints)
+		ff.Rollout = &featureflag.FeatureFlagRollout{Rollout: *args.RolloutBasisPoints}
+	} else if args.VariantValue != nil {
+		value, err := json.Marshal(args.VariantValue.Value)
+		if err != nil {
+			return nil, err
+		}
+		ff.Variant = &featureflag.FeatureFlagVariant{Value: value}
 	} else {
-		return nil, errors.Errorf("either 'value' or 'rolloutBasisPoints' must be set")
+		return nil, errors.Errorf("one of 'value', 'rolloutBasisPoints' or 'variantValue' must be set")
+	}
+
+	rules, err := unmarshalTargetingRules(args.Rules)
+	if err != nil {
+		return nil, err
 	}
+	ff.Rules = rules
 
+	return ff, ff.ValidateRules()
+}
+
+func (r *schemaResolver) CreateFeatureFlag(ctx context.Context, args featureFlagArgs) (*FeatureFlagResolver, error) {
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	ff, err := args.toFeatureFlag()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.db.FeatureFlags().CreateFeatureFlag(ctx, ff)
 	return &FeatureFlagResolver{r.db, res}, err
 }
 
@@ -251,21 +413,13 @@ func (r *schemaResolver) DeleteFeatureFlag(ctx context.Context, args struct {
 	return &EmptyResponse{}, r.db.FeatureFlags().DeleteFeatureFlag(ctx, args.Name)
 }
 
-func (r *schemaResolver) UpdateFeatureFlag(ctx context.Context, args struct {
-	Name               string
-	Value              *bool
-	RolloutBasisPoints *int32
-}) (*FeatureFlagResolver, error) {
+func (r *schemaResolver) UpdateFeatureFlag(ctx context.Context, args featureFlagArgs) (*FeatureFlagResolver, error) {
 	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
 		return nil, err
 	}
-	ff := &featureflag.FeatureFlag{Name: args.Name}
-	if args.Value != nil {
-		ff.Bool = &featureflag.FeatureFlagBool{Value: *args.Value}
-	} else if args.RolloutBasisPoints != nil {
-		ff.Rollout = &featureflag.FeatureFlagRollout{Rollout: *args.RolloutBasisPoints}
-	} else {
-		return nil, errors.Errorf("either 'value' or 'rolloutBasisPoints' must be set")
+	ff, err := args.toFeatureFlag()
+	if err != nil {
+		return nil, err
 	}
 
 	res, err := r.db.FeatureFlags().UpdateFeatureFlag(ctx, ff)
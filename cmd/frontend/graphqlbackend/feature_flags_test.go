@@ -2,9 +2,11 @@ package graphqlbackend
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -295,3 +297,129 @@ func TestEvaluateFeatureFlag(t *testing.T) {
 		})
 	})
 }
+
+func TestEvaluateFeatureFlagVariant(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	flags := database.NewMockFeatureFlagStore()
+	flags.GetUserFlagVariantsFunc.SetDefaultHook(func(ctx context.Context, uid int32) (map[string]json.RawMessage, error) {
+		return map[string]json.RawMessage{"tuning": json.RawMessage(`{"limit":10}`)}, nil
+	})
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.FeatureFlagsFunc.SetDefaultReturn(flags)
+	ctx = featureflag.WithFlags(ctx, flags)
+
+	RunTests(t, []*Test{
+		{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+			{
+				evaluateFeatureFlagVariant(flagName: "tuning")
+			}
+			`,
+			ExpectedResult: `
+				{
+					"evaluateFeatureFlagVariant": {"limit": 10}
+				}
+			`,
+		},
+		{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+			{
+				evaluateFeatureFlagVariant(flagName: "non-existing-flag")
+			}
+			`,
+			ExpectedResult: `
+				{
+					"evaluateFeatureFlagVariant": null
+				}
+			`,
+		},
+	})
+}
+
+func TestCreateFeatureFlagWithRules(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	flags := database.NewMockFeatureFlagStore()
+	flags.CreateFeatureFlagFunc.SetDefaultHook(func(ctx context.Context, flag *featureflag.FeatureFlag) (*featureflag.FeatureFlag, error) {
+		return flag, nil
+	})
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.FeatureFlagsFunc.SetDefaultReturn(flags)
+
+	RunTests(t, []*Test{
+		{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+			mutation {
+				createFeatureFlag(
+					name: "tuning",
+					variantValue: "default",
+					rules: [
+						{siteAdmin: true, value: "admins"},
+						{orgs: ["T3JnOjE="], emailDomains: ["@Contractor.com"], value: "contractors"}
+					]
+				) {
+					... on FeatureFlagVariant {
+						name
+						value
+						rules {
+							siteAdmin
+							orgs
+							emailDomains
+							value
+						}
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+				{
+					"createFeatureFlag": {
+						"name": "tuning",
+						"value": "default",
+						"rules": [
+							{"siteAdmin": true, "orgs": [], "emailDomains": [], "value": "admins"},
+							{"siteAdmin": null, "orgs": ["T3JnOjE="], "emailDomains": ["contractor.com"], "value": "contractors"}
+						]
+					}
+				}
+			`,
+		},
+		{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+			mutation {
+				createFeatureFlag(name: "bool", value: true, rules: [{authenticated: false, value: "nope"}]) {
+					... on FeatureFlagBoolean {
+						name
+					}
+				}
+			}
+			`,
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message: "rule 0: value must be a boolean",
+					Path:    []any{"createFeatureFlag"},
+				},
+			},
+		},
+	})
+}
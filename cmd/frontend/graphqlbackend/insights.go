@@ -31,6 +31,8 @@ type InsightsResolver interface {
 	DeleteInsightsDashboard(ctx context.Context, args *DeleteInsightsDashboardArgs) (*EmptyResponse, error)
 	RemoveInsightViewFromDashboard(ctx context.Context, args *RemoveInsightViewFromDashboardArgs) (InsightsDashboardPayloadResolver, error)
 	AddInsightViewToDashboard(ctx context.Context, args *AddInsightViewToDashboardArgs) (InsightsDashboardPayloadResolver, error)
+	CreateInsightsDashboardReportSubscription(ctx context.Context, args *CreateInsightsDashboardReportSubscriptionArgs) (InsightsDashboardReportSubscriptionResolver, error)
+	DeleteInsightsDashboardReportSubscription(ctx context.Context, args *DeleteInsightsDashboardReportSubscriptionArgs) (*EmptyResponse, error)
 
 	CreateLineChartSearchInsight(ctx context.Context, args *CreateLineChartSearchInsightArgs) (InsightViewPayloadResolver, error)
 	UpdateLineChartSearchInsight(ctx context.Context, args *UpdateLineChartSearchInsightArgs) (InsightViewPayloadResolver, error)
@@ -144,6 +146,32 @@ type InsightsDashboardResolver interface {
 	ID() graphql.ID
 	Views(ctx context.Context, args DashboardInsightViewConnectionArgs) InsightViewConnectionResolver
 	Grants() InsightsPermissionGrantsResolver
+	ReportSubscriptions(ctx context.Context) ([]InsightsDashboardReportSubscriptionResolver, error)
+}
+
+type InsightsDashboardReportSubscriptionResolver interface {
+	ID() graphql.ID
+	Cadence() string
+	Recipients() []graphql.ID
+	SlackWebhookURL(ctx context.Context) *string
+	CreatedAt() gqlutil.DateTime
+	LastSentAt() *gqlutil.DateTime
+	NextSendAt() gqlutil.DateTime
+}
+
+type CreateInsightsDashboardReportSubscriptionArgs struct {
+	Input CreateInsightsDashboardReportSubscriptionInput
+}
+
+type CreateInsightsDashboardReportSubscriptionInput struct {
+	DashboardID     graphql.ID
+	Cadence         string
+	Recipients      []graphql.ID
+	SlackWebhookURL *string
+}
+
+type DeleteInsightsDashboardReportSubscriptionArgs struct {
+	Id graphql.ID
 }
 
 type DashboardInsightViewConnectionArgs struct {
@@ -313,3 +313,8 @@ func (r *NodeResolver) ToPermissionsSyncJob() (PermissionsSyncJobResolver, bool)
 	n, ok := r.Node.(PermissionsSyncJobResolver)
 	return n, ok
 }
+
+func (r *NodeResolver) ToSubRepositoryPermissionRule() (SubRepoPermissionRuleResolver, bool) {
+	n, ok := r.Node.(SubRepoPermissionRuleResolver)
+	return n, ok
+}
@@ -4,6 +4,7 @@ import (
 	"strconv"
 	"sync/atomic"
 
+	"github.com/google/uuid"
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/kinds"
 	"github.com/graphql-go/graphql/language/parser"
@@ -362,21 +363,122 @@ func shouldCheckParam(p visitor.VisitFuncParams) bool {
 	return true
 }
 
+// IsSearchQuery returns whether query runs a search, i.e. whether one of its
+// queries selects the search field. Such requests count against the maximum
+// number of concurrent searches.
+func IsSearchQuery(query string) bool {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: query,
+	})
+	if err != nil {
+		return false
+	}
+
+	fragments := make(map[string]*ast.SelectionSet)
+	for _, def := range doc.Definitions {
+		if frag, ok := def.(*ast.FragmentDefinition); ok && frag.Name != nil {
+			fragments[frag.Name.Value] = frag.SelectionSet
+		}
+	}
+
+	// Fragments can reference each other, so we only visit each fragment once.
+	visited := make(map[string]struct{})
+	var selectsSearch func(set *ast.SelectionSet) bool
+	selectsSearch = func(set *ast.SelectionSet) bool {
+		if set == nil {
+			return false
+		}
+		for _, selection := range set.Selections {
+			switch s := selection.(type) {
+			case *ast.Field:
+				if s.Name != nil && s.Name.Value == "search" {
+					return true
+				}
+			case *ast.InlineFragment:
+				if selectsSearch(s.SelectionSet) {
+					return true
+				}
+			case *ast.FragmentSpread:
+				if _, ok := visited[s.Name.Value]; ok {
+					continue
+				}
+				visited[s.Name.Value] = struct{}{}
+				if selectsSearch(fragments[s.Name.Value]) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if ok && op.Operation == ast.OperationTypeQuery && selectsSearch(op.SelectionSet) {
+			return true
+		}
+	}
+	return false
+}
+
+// RateLimitKind is a kind of API request. Each kind of request is limited separately.
+type RateLimitKind string
+
+const (
+	// RateLimitKindGraphQL is the kind of GraphQL requests, which are limited by their estimated
+	// query cost. It is the default kind.
+	RateLimitKindGraphQL RateLimitKind = "graphql"
+	// RateLimitKindSearchStream is the kind of streaming search requests.
+	RateLimitKindSearchStream RateLimitKind = "search_stream"
+	// RateLimitKindGitHTTP is the kind of git requests over HTTP.
+	RateLimitKindGitHTTP RateLimitKind = "git_http"
+)
+
 type LimiterArgs struct {
 	IsIP          bool
 	Anonymous     bool
 	RequestName   string
 	RequestSource trace.SourceType
+
+	// Kind is the kind of the limited request. If empty, RateLimitKindGraphQL is used.
+	Kind RateLimitKind
+	// AccessToken identifies the access token the request was authenticated with, if any. See
+	// actor.Actor.AccessTokenKey.
+	AccessToken string
+}
+
+func (a LimiterArgs) kind() RateLimitKind {
+	if a.Kind == "" {
+		return RateLimitKindGraphQL
+	}
+	return a.Kind
 }
 
 type Limiter interface {
 	RateLimit(key string, quantity int, args LimiterArgs) (bool, throttled.RateLimitResult, error)
+	// LimitConcurrency registers a request for key that is in progress until release is called. If
+	// the maximum number of concurrent requests for key is reached, the request is not registered and
+	// limited is true.
+	LimitConcurrency(key string, args LimiterArgs) (release func(), limited bool, err error)
 }
 
 type LimitWatcher interface {
 	Get() (Limiter, bool)
 }
 
+// LimitWatchers is a LimitWatcher that returns the limiter of the first of its watchers that is
+// enabled.
+type LimitWatchers []LimitWatcher
+
+// Get returns the limiter of the first enabled watcher.
+func (ws LimitWatchers) Get() (Limiter, bool) {
+	for _, w := range ws {
+		if l, enabled := w.Get(); enabled {
+			return l, true
+		}
+	}
+	return nil, false
+}
+
 func NewBasicLimitWatcher(logger log.Logger, store throttled.GCRAStore) *BasicLimitWatcher {
 	basic := &BasicLimitWatcher{
 		store: store,
@@ -433,24 +535,32 @@ type BasicLimiter struct {
 // RateLimit limits unauthenticated requests to the GraphQL API with an equal
 // quantity of 1.
 func (bl *BasicLimiter) RateLimit(_ string, _ int, args LimiterArgs) (bool, throttled.RateLimitResult, error) {
-	if args.Anonymous && args.RequestName == "unknown" && args.RequestSource == trace.SourceOther && bl.GCRARateLimiter != nil {
+	if args.kind() == RateLimitKindGraphQL && args.Anonymous && args.RequestName == "unknown" && args.RequestSource == trace.SourceOther && bl.GCRARateLimiter != nil {
 		return bl.GCRARateLimiter.RateLimit("basic", 1)
 	}
 	return false, throttled.RateLimitResult{}, nil
 }
 
+// LimitConcurrency never limits requests, the BasicLimiter only limits the rate of requests.
+func (bl *BasicLimiter) LimitConcurrency(string, LimiterArgs) (func(), bool, error) {
+	return func() {}, false, nil
+}
+
 // RateLimitWatcher stores the currently configured rate limiter and whether or
 // not rate limiting is enabled.
 type RateLimitWatcher struct {
-	store throttled.GCRAStore
-	rl    atomic.Value // *RateLimiter
+	store       throttled.GCRAStore
+	concurrency ConcurrencyStore
+	rl          atomic.Value // *RateLimiter
 }
 
-// NewRateLimiteWatcher creates a new limiter with the provided store and starts
-// watching for config changes.
-func NewRateLimiteWatcher(logger log.Logger, store throttled.GCRAStore) *RateLimitWatcher {
+// NewRateLimiteWatcher creates a new limiter with the provided stores and starts
+// watching for config changes. If concurrency is nil, concurrent requests are
+// not limited.
+func NewRateLimiteWatcher(logger log.Logger, store throttled.GCRAStore, concurrency ConcurrencyStore) *RateLimitWatcher {
 	w := &RateLimitWatcher{
-		store: store,
+		store:       store,
+		concurrency: concurrency,
 	}
 
 	conf.Watch(func() {
@@ -470,100 +580,272 @@ func (w *RateLimitWatcher) Get() (Limiter, bool) {
 	return nil, false
 }
 
-func (w *RateLimitWatcher) updateFromConfig(logger log.Logger, rlc *schema.ApiRatelimit) {
-	// We can burst up to a max of 20% of limit
-	maxBurstPercentage := 0.2
+// rateLimitQuotas are the configured limits of a kind of request.
+type rateLimitQuotas struct {
+	perUser        int
+	perIP          int
+	perAccessToken int
+
+	maxConcurrentPerUser int
+	maxConcurrentPerIP   int
+}
 
+func (w *RateLimitWatcher) updateFromConfig(logger log.Logger, rlc *schema.ApiRatelimit) {
 	if rlc == nil || !rlc.Enabled {
 		w.rl.Store(&RateLimiter{enabled: false})
 		return
 	}
 
-	ipQuota := throttled.RateQuota{
-		MaxRate:  throttled.PerHour(rlc.PerIP),
-		MaxBurst: int(float64(rlc.PerIP) * maxBurstPercentage),
-	}
-	ipLimiter, err := throttled.NewGCRARateLimiter(w.store, ipQuota)
-	if err != nil {
-		logger.Warn("error creating ip rate limiter", log.Error(err))
-		return
+	quotas := map[RateLimitKind]rateLimitQuotas{
+		RateLimitKindGraphQL: {
+			perUser:        rlc.PerUser,
+			perIP:          rlc.PerIP,
+			perAccessToken: rlc.PerAccessToken,
+		},
 	}
-
-	userQuota := throttled.RateQuota{
-		MaxRate:  throttled.PerHour(rlc.PerUser),
-		MaxBurst: int(float64(rlc.PerUser) * maxBurstPercentage),
+	if s := rlc.SearchStreams; s != nil {
+		quotas[RateLimitKindSearchStream] = rateLimitQuotas{
+			perUser:              s.PerUser,
+			perIP:                s.PerIP,
+			perAccessToken:       s.PerAccessToken,
+			maxConcurrentPerUser: s.MaxConcurrentPerUser,
+			maxConcurrentPerIP:   s.MaxConcurrentPerIP,
+		}
 	}
-	userLimiter, err := throttled.NewGCRARateLimiter(w.store, userQuota)
-	if err != nil {
-		logger.Warn("error creating user rate limiter", log.Error(err))
-		return
+	if g := rlc.GitHTTP; g != nil {
+		quotas[RateLimitKindGitHTTP] = rateLimitQuotas{
+			perUser:        g.PerUser,
+			perIP:          g.PerIP,
+			perAccessToken: g.PerAccessToken,
+		}
 	}
 
-	overrides := make(map[string]limiter)
-	for _, o := range rlc.Overrides {
-		switch l := o.Limit.(type) {
-		case string:
-			if l == "blocked" {
-				overrides[o.Key] = &fixedLimiter{
-					limited: true,
-					result: throttled.RateLimitResult{
-						Limit:      0,
-						Remaining:  0,
-						ResetAfter: 0,
-						RetryAfter: 0,
-					},
-				}
-			} else if l == "unlimited" {
-				overrides[o.Key] = &fixedLimiter{
-					limited: false,
-					result: throttled.RateLimitResult{
-						Limit:      100000,
-						Remaining:  100000,
-						ResetAfter: 0,
-						RetryAfter: 0,
-					},
-				}
-			} else {
-				logger.Warn("unknown limit value", log.String("value", l))
-				return
-			}
-		case int:
-			rl, err := throttled.NewGCRARateLimiter(w.store, throttled.RateQuota{
-				MaxRate:  throttled.PerHour(l),
-				MaxBurst: int(float64(l) * maxBurstPercentage),
-			})
-			if err != nil {
-				logger.Warn("error creating override rate limiter", log.String("key", o.Key), log.Error(err))
-				return
-			}
-			overrides[o.Key] = rl
+	kinds := make(map[RateLimitKind]*kindLimiter, len(quotas))
+	for kind, q := range quotas {
+		kl, err := w.newKindLimiter(kind, q, rlc.Overrides)
+		if err != nil {
+			logger.Warn("error creating rate limiter", log.String("kind", string(kind)), log.Error(err))
+			return
 		}
+		kinds[kind] = kl
 	}
 
 	// Store the new limiter
 	w.rl.Store(&RateLimiter{
 		enabled:     true,
-		ipLimiter:   ipLimiter,
-		userLimiter: userLimiter,
-		overrides:   overrides,
+		kinds:       kinds,
+		concurrency: w.concurrency,
+	})
+}
+
+func (w *RateLimitWatcher) newKindLimiter(kind RateLimitKind, q rateLimitQuotas, overrides []*schema.Overrides) (_ *kindLimiter, err error) {
+	kl := &kindLimiter{
+		kind:                 kind,
+		overrides:            make(map[string]limiter),
+		maxConcurrentPerUser: q.maxConcurrentPerUser,
+		maxConcurrentPerIP:   q.maxConcurrentPerIP,
+		concurrencyOverrides: make(map[string]int),
+	}
+	if kl.ip, err = newHourlyLimiter(w.store, q.perIP); err != nil {
+		return nil, errors.Wrap(err, "creating ip rate limiter")
+	}
+	if kl.user, err = newHourlyLimiter(w.store, q.perUser); err != nil {
+		return nil, errors.Wrap(err, "creating user rate limiter")
+	}
+	if q.perAccessToken > 0 {
+		if kl.accessToken, err = newHourlyLimiter(w.store, q.perAccessToken); err != nil {
+			return nil, errors.Wrap(err, "creating access token rate limiter")
+		}
+	}
+
+	for _, o := range overrides {
+		value := o.Limit
+		switch kind {
+		case RateLimitKindSearchStream:
+			value = kindOverride(o.SearchStreams, o.Limit)
+			if o.MaxConcurrentSearchStreams > 0 {
+				kl.concurrencyOverrides[o.Key] = o.MaxConcurrentSearchStreams
+			}
+		case RateLimitKindGitHTTP:
+			value = kindOverride(o.GitHTTP, o.Limit)
+		}
+		if value == nil {
+			continue
+		}
+		l, err := w.newOverrideLimiter(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating override rate limiter for %q", o.Key)
+		}
+		kl.overrides[o.Key] = l
+	}
+	return kl, nil
+}
+
+// kindOverride returns the override of a kind of request other than GraphQL. The
+// GraphQL limit is a query cost, so it only applies to other kinds of requests if
+// it is "blocked" or "unlimited".
+func kindOverride(value, graphQLLimit any) any {
+	if value != nil {
+		return value
+	}
+	if l, ok := graphQLLimit.(string); ok {
+		return l
+	}
+	return nil
+}
+
+func (w *RateLimitWatcher) newOverrideLimiter(value any) (limiter, error) {
+	switch l := value.(type) {
+	case string:
+		switch l {
+		case "blocked":
+			return &fixedLimiter{
+				limited: true,
+				result: throttled.RateLimitResult{
+					Limit:      0,
+					Remaining:  0,
+					ResetAfter: 0,
+					RetryAfter: 0,
+				},
+			}, nil
+		case "unlimited":
+			return &fixedLimiter{
+				limited: false,
+				result: throttled.RateLimitResult{
+					Limit:      100000,
+					Remaining:  100000,
+					ResetAfter: 0,
+					RetryAfter: 0,
+				},
+			}, nil
+		}
+		return nil, errors.Newf("unknown limit value %q", l)
+	case int, float64:
+		// Limits decoded from the site configuration are float64.
+		limit, err := extractInt(l)
+		if err != nil {
+			return nil, err
+		}
+		return newHourlyLimiter(w.store, limit)
+	default:
+		return nil, errors.Newf("unknown limit type %T", value)
+	}
+}
+
+func newHourlyLimiter(store throttled.GCRAStore, perHour int) (*throttled.GCRARateLimiter, error) {
+	if perHour <= 0 {
+		return nil, errors.Newf("invalid limit %d, must be at least 1", perHour)
+	}
+
+	// We can burst up to a max of 20% of limit
+	maxBurstPercentage := 0.2
+
+	return throttled.NewGCRARateLimiter(store, throttled.RateQuota{
+		MaxRate:  throttled.PerHour(perHour),
+		MaxBurst: int(float64(perHour) * maxBurstPercentage),
 	})
 }
 
 type RateLimiter struct {
 	enabled     bool
-	ipLimiter   *throttled.GCRARateLimiter
-	userLimiter *throttled.GCRARateLimiter
+	kinds       map[RateLimitKind]*kindLimiter
+	concurrency ConcurrencyStore
+}
+
+// RateLimit limits the rate of requests of the kind in args. Kinds of requests
+// without configured limits are not limited.
+func (rl *RateLimiter) RateLimit(key string, quantity int, args LimiterArgs) (bool, throttled.RateLimitResult, error) {
+	kl, ok := rl.kinds[args.kind()]
+	if !ok {
+		return false, throttled.RateLimitResult{}, nil
+	}
+	return kl.rateLimit(key, quantity, args)
+}
+
+// LimitConcurrency limits the number of requests of the kind in args that are in
+// progress at the same time across all frontend instances.
+func (rl *RateLimiter) LimitConcurrency(key string, args LimiterArgs) (func(), bool, error) {
+	release := func() {}
+	kl, ok := rl.kinds[args.kind()]
+	if !ok || rl.concurrency == nil {
+		return release, false, nil
+	}
+	max := kl.maxConcurrent(key, args.IsIP)
+	if max <= 0 {
+		return release, false, nil
+	}
+
+	storeKey, id := kl.storeKey(key), uuid.NewString()
+	acquired, err := rl.concurrency.Acquire(storeKey, id, max)
+	if err != nil || !acquired {
+		return release, err == nil, err
+	}
+	return func() {
+		// If releasing fails, the request is released once its lease expires.
+		_ = rl.concurrency.Release(storeKey, id)
+	}, false, nil
+}
+
+// kindLimiter holds the limiters of a single kind of request.
+type kindLimiter struct {
+	kind        RateLimitKind
+	ip          limiter
+	user        limiter
+	accessToken limiter // nil if requests only count against the limit of their user
 	overrides   map[string]limiter
+
+	maxConcurrentPerUser int
+	maxConcurrentPerIP   int
+	concurrencyOverrides map[string]int
+}
+
+// storeKey returns the key that the limits of key are stored under. GraphQL keys
+// are stored unchanged so that existing limits carry over.
+func (kl *kindLimiter) storeKey(key string) string {
+	if kl.kind == RateLimitKindGraphQL {
+		return key
+	}
+	return string(kl.kind) + ":" + key
 }
 
-func (rl *RateLimiter) RateLimit(uid string, cost int, args LimiterArgs) (bool, throttled.RateLimitResult, error) {
-	if r, ok := rl.overrides[uid]; ok {
-		return r.RateLimit(uid, cost)
+func (kl *kindLimiter) rateLimit(key string, quantity int, args LimiterArgs) (bool, throttled.RateLimitResult, error) {
+	if r, ok := kl.overrides[key]; ok {
+		return r.RateLimit(kl.storeKey(key), quantity)
 	}
 	if args.IsIP {
-		return rl.ipLimiter.RateLimit(uid, cost)
+		return kl.ip.RateLimit(kl.storeKey(key), quantity)
+	}
+	if args.AccessToken == "" || kl.accessToken == nil {
+		return kl.user.RateLimit(kl.storeKey(key), quantity)
+	}
+
+	// Requests authenticated with an access token count against the limits of both
+	// the token and its user, and report the more restrictive result.
+	limited, result, err := kl.accessToken.RateLimit(kl.storeKey("token:"+args.AccessToken), quantity)
+	if err != nil || limited {
+		return limited, result, err
+	}
+	userLimited, userResult, err := kl.user.RateLimit(kl.storeKey(key), quantity)
+	if err != nil || userLimited || userResult.Remaining < result.Remaining {
+		return userLimited, userResult, err
+	}
+	return false, result, nil
+}
+
+// maxConcurrent returns the maximum number of concurrent requests for key, or 0 if
+// they are not limited.
+func (kl *kindLimiter) maxConcurrent(key string, isIP bool) int {
+	if max, ok := kl.concurrencyOverrides[key]; ok {
+		return max
+	}
+	if f, ok := kl.overrides[key].(*fixedLimiter); ok && !f.limited {
+		// Keys with unlimited requests can also run any number of them at once.
+		return 0
+	}
+	if isIP {
+		return kl.maxConcurrentPerIP
 	}
-	return rl.userLimiter.RateLimit(uid, cost)
+	return kl.maxConcurrentPerUser
 }
 
 type limiter interface {
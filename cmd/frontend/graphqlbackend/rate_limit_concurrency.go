@@ -0,0 +1,74 @@
+package graphqlbackend
+
+import (
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// ConcurrencyStore keeps track of the requests per key that are in progress.
+type ConcurrencyStore interface {
+	// Acquire registers the request id for key if fewer than max requests are
+	// registered for key, and returns whether it was registered.
+	Acquire(key, id string, max int) (bool, error)
+	// Release unregisters the request id for key.
+	Release(key, id string) error
+}
+
+// concurrencyLeaseTTL is how long a request stays registered if it is never
+// released, e.g. because the frontend instance serving it was terminated.
+const concurrencyLeaseTTL = 10 * time.Minute
+
+// NewRedisConcurrencyStore returns a ConcurrencyStore that is shared by all
+// frontend instances using the same Redis. The requests of each key are stored
+// in a sorted set, scored by the time they were registered at.
+func NewRedisConcurrencyStore(pool *redis.Pool, prefix string) ConcurrencyStore {
+	return &redisConcurrencyStore{
+		pool:   pool,
+		prefix: prefix,
+		now:    time.Now,
+	}
+}
+
+type redisConcurrencyStore struct {
+	pool   *redis.Pool
+	prefix string
+	now    func() time.Time
+}
+
+// acquireScript atomically drops expired requests, checks the number of requests
+// in progress and registers the new request.
+var acquireScript = redis.NewScript(1, `
+local key, now, expired, id, max, ttl = KEYS[1], ARGV[1], ARGV[2], ARGV[3], tonumber(ARGV[4]), ARGV[5]
+redis.call('ZREMRANGEBYSCORE', key, '-inf', expired)
+if redis.call('ZCARD', key) >= max then
+	return 0
+end
+redis.call('ZADD', key, now, id)
+redis.call('EXPIRE', key, ttl)
+return 1
+`)
+
+func (s *redisConcurrencyStore) Acquire(key, id string, max int) (bool, error) {
+	c := s.pool.Get()
+	defer c.Close()
+
+	now := s.now()
+	acquired, err := redis.Int(acquireScript.Do(c,
+		s.prefix+key,
+		now.UnixMilli(),
+		now.Add(-concurrencyLeaseTTL).UnixMilli(),
+		id,
+		max,
+		int(concurrencyLeaseTTL.Seconds()),
+	))
+	return acquired == 1, err
+}
+
+func (s *redisConcurrencyStore) Release(key, id string) error {
+	c := s.pool.Get()
+	defer c.Close()
+
+	_, err := c.Do("ZREM", s.prefix+key, id)
+	return err
+}
@@ -0,0 +1,167 @@
+package graphqlbackend
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/log"
+	"github.com/throttled/throttled/v2"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/cookie"
+)
+
+// unknownRateLimitKey is the key of requests that cannot be attributed to a user
+// or an IP.
+const unknownRateLimitKey = "unknown"
+
+// RateLimitKey returns the key that the rate limits of a request are tracked by:
+// the ID of the authenticated user, the anonymous user ID from the request cookie,
+// or the IP of the client.
+func RateLimitKey(r *http.Request) (key string, ip bool, anonymous bool) {
+	a := actor.FromContext(r.Context())
+	anonymous = !a.IsAuthenticated()
+	if !anonymous {
+		return a.UIDString(), false, anonymous
+	}
+	if uid, ok := cookie.AnonymousUID(r); ok && uid != "" {
+		return uid, false, anonymous
+	}
+	// The user is anonymous with no cookie, use IP
+	if ip := clientIP(r); ip != "" {
+		return ip, true, anonymous
+	}
+	return unknownRateLimitKey, false, anonymous
+}
+
+// clientIP returns the IP of the client that sent r. Clients can set the
+// X-Forwarded-For header to any value, so it is only honoured if the request was
+// sent by one of the configured trusted proxies.
+func clientIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	var trusted []string
+	if rlc := conf.Get().ApiRatelimit; rlc != nil {
+		trusted = rlc.TrustedProxies
+	}
+	if !isTrustedProxy(trusted, ip) {
+		return ip
+	}
+
+	// Each proxy appends the address it received the request from, so the client is
+	// the last address that was not appended by a trusted proxy.
+	forwarded := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addr := strings.TrimSpace(forwarded[i])
+		if addr == "" {
+			continue
+		}
+		ip = addr
+		if !isTrustedProxy(trusted, addr) {
+			break
+		}
+	}
+	return ip
+}
+
+// isTrustedProxy returns whether addr is one of the trusted IPs or in one of the
+// trusted CIDR ranges.
+func isTrustedProxy(trusted []string, addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, t := range trusted {
+		if strings.Contains(t, "/") {
+			if _, ipNet, err := net.ParseCIDR(t); err == nil && ipNet.Contains(ip) {
+				return true
+			}
+		} else if trustedIP := net.ParseIP(t); trustedIP != nil && trustedIP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRateLimitHeaders sets the RateLimit-Limit, RateLimit-Remaining and
+// RateLimit-Reset headers describing result, and Retry-After if the request was
+// limited.
+func SetRateLimitHeaders(h http.Header, limited bool, result throttled.RateLimitResult) {
+	if limited {
+		h.Set("Retry-After", strconv.Itoa(int(result.RetryAfter.Seconds())))
+	}
+	if result.Limit <= 0 && !limited {
+		// The request is not subject to a limit.
+		return
+	}
+	h.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(int(math.Ceil(result.ResetAfter.Seconds()))))
+}
+
+// RateLimitMiddleware applies the limits configured for the given kind of request
+// to the requests served by next. Each request counts as one request against the
+// rate limit and is registered as in progress until next returns. Requests of
+// internal actors and requests that cannot be attributed to a user or an IP are
+// not limited.
+func RateLimitMiddleware(logger log.Logger, rlw LimitWatcher, kind RateLimitKind, next http.Handler) http.Handler {
+	if rlw == nil {
+		return next
+	}
+	logger = logger.Scoped("rateLimit", "limits requests of "+string(kind))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rl, enabled := rlw.Get()
+		a := actor.FromContext(r.Context())
+		if !enabled || a.IsInternal() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		key, isIP, anonymous := RateLimitKey(r)
+		if key == unknownRateLimitKey {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		args := LimiterArgs{
+			IsIP:        isIP,
+			Anonymous:   anonymous,
+			Kind:        kind,
+			AccessToken: a.AccessTokenKey,
+		}
+		limited, result, err := rl.RateLimit(key, 1, args)
+		if err != nil {
+			// Fail open, an unavailable limiter must not make the API unavailable.
+			logger.Error("checking rate limit", log.Error(err))
+			next.ServeHTTP(w, r)
+			return
+		}
+		SetRateLimitHeaders(w.Header(), limited, result)
+		if limited {
+			http.Error(w, "API rate limit exceeded.", http.StatusTooManyRequests)
+			return
+		}
+
+		release, limited, err := rl.LimitConcurrency(key, args)
+		if err != nil {
+			logger.Error("checking concurrency limit", log.Error(err))
+			next.ServeHTTP(w, r)
+			return
+		}
+		if limited {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "Too many concurrent requests.", http.StatusTooManyRequests)
+			return
+		}
+		defer release()
+
+		next.ServeHTTP(w, r)
+	})
+}
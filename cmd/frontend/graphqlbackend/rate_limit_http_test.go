@@ -0,0 +1,126 @@
+package graphqlbackend
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/throttled/throttled/v2/store/memstore"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	store, err := memstore.New(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := logtest.Scoped(t)
+	rlw := NewRateLimiteWatcher(logger, store, fakeConcurrencyStore{})
+	rlw.updateFromConfig(logger, &schema.ApiRatelimit{
+		Enabled: true,
+		PerIP:   5000,
+		PerUser: 5000,
+		SearchStreams: &schema.SearchStreams{
+			PerIP:   1,
+			PerUser: 1,
+		},
+	})
+
+	handler := RateLimitMiddleware(logger, rlw, RateLimitKindSearchStream, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(ctx context.Context) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/search/stream?q=test", nil).WithContext(ctx)
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	userCtx := actor.WithActor(context.Background(), actor.FromUser(1))
+	rec := serve(userCtx)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d", rec.Code)
+	}
+	for header, want := range map[string]string{
+		"RateLimit-Limit":     "1",
+		"RateLimit-Remaining": "0",
+		"RateLimit-Reset":     "3600",
+	} {
+		if got := rec.Header().Get(header); got != want {
+			t.Errorf("%s: want %q, got %q", header, want, got)
+		}
+	}
+
+	rec = serve(userCtx)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("unexpected status %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("missing Retry-After header")
+	}
+
+	// Internal actors are not limited.
+	for i := 0; i < 2; i++ {
+		if rec := serve(actor.WithInternalActor(context.Background())); rec.Code != http.StatusOK {
+			t.Fatalf("internal: unexpected status %d", rec.Code)
+		}
+	}
+
+	// Anonymous requests without a cookie are limited by the IP of the connection.
+	if rec := serve(context.Background()); rec.Code != http.StatusOK {
+		t.Fatalf("anonymous: unexpected status %d", rec.Code)
+	}
+	if rec := serve(context.Background()); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("anonymous: unexpected status %d", rec.Code)
+	}
+}
+
+func TestRateLimitKey(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ApiRatelimit: &schema.ApiRatelimit{
+			TrustedProxies: []string{"10.0.0.0/8", "192.0.2.1"},
+		},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	for _, tc := range []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		want         string
+	}{
+		{name: "no proxy", remoteAddr: "203.0.113.7:1234", want: "203.0.113.7"},
+		{name: "untrusted proxy", remoteAddr: "203.0.113.7:1234", forwardedFor: "198.51.100.1", want: "203.0.113.7"},
+		{name: "trusted proxy", remoteAddr: "192.0.2.1:1234", forwardedFor: "198.51.100.1", want: "198.51.100.1"},
+		{name: "trusted proxy without header", remoteAddr: "192.0.2.1:1234", want: "192.0.2.1"},
+		{name: "chain of trusted proxies", remoteAddr: "10.0.0.1:1234", forwardedFor: "203.0.113.1, 198.51.100.1, 10.1.2.3", want: "198.51.100.1"},
+		{name: "only trusted proxies", remoteAddr: "10.0.0.1:1234", forwardedFor: "10.0.0.3, 10.0.0.2", want: "10.0.0.3"},
+		{name: "no remote address", want: unknownRateLimitKey},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/search/stream?q=test", nil)
+			req.RemoteAddr = tc.remoteAddr
+			if tc.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tc.forwardedFor)
+			}
+
+			key, isIP, anonymous := RateLimitKey(req)
+			if key != tc.want {
+				t.Errorf("want key %q, got %q", tc.want, key)
+			}
+			if wantIP := tc.want != unknownRateLimitKey; isIP != wantIP {
+				t.Errorf("want isIP %t, got %t", wantIP, isIP)
+			}
+			if !anonymous {
+				t.Error("request is not anonymous")
+			}
+		})
+	}
+}
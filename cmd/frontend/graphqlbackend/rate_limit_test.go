@@ -420,6 +420,64 @@ fragment UsableFields on Usable {
 	}
 }
 
+func TestIsSearchQuery(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{
+			name:  "search",
+			query: `query Search($query: String!) { search(query: $query) { results { matchCount } } }`,
+			want:  true,
+		},
+		{
+			name:  "aliased search",
+			query: `{ s: search(query: "test") { results { matchCount } } }`,
+			want:  true,
+		},
+		{
+			name: "search in fragment",
+			query: `
+query { ...SearchFields }
+fragment SearchFields on Query { ...Search }
+fragment Search on Query { search(query: "test") { results { matchCount } } }`,
+			want: true,
+		},
+		{
+			name:  "no search",
+			query: `{ currentUser { username } }`,
+			want:  false,
+		},
+		{
+			name:  "nested search field",
+			query: `{ currentUser { search { id } } }`,
+			want:  false,
+		},
+		{
+			name:  "mutation",
+			query: `mutation { search(query: "test") { alwaysNil } }`,
+			want:  false,
+		},
+		{
+			name:  "recursive fragments",
+			query: `query { ...A } fragment A on Query { ...B } fragment B on Query { ...A }`,
+			want:  false,
+		},
+		{
+			name:  "invalid query",
+			query: `{ search(`,
+			want:  false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsSearchQuery(tc.query); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestRatelimitFromConfig(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -561,7 +619,7 @@ func TestRatelimitFromConfig(t *testing.T) {
 
 			logger := logtest.Scoped(t)
 
-			rlw := NewRateLimiteWatcher(logger, store)
+			rlw := NewRateLimiteWatcher(logger, store, nil)
 
 			rlw.updateFromConfig(logger, tc.config)
 			rl, enabled := rlw.Get()
@@ -671,3 +729,195 @@ func TestBasicLimiter(t *testing.T) {
 		t.Fatalf("got %t, want true", limited)
 	}
 }
+
+func newTestRateLimiter(t *testing.T, config *schema.ApiRatelimit, concurrency ConcurrencyStore) Limiter {
+	t.Helper()
+
+	store, err := memstore.New(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := logtest.Scoped(t)
+
+	rlw := NewRateLimiteWatcher(logger, store, concurrency)
+	rlw.updateFromConfig(logger, config)
+	rl, enabled := rlw.Get()
+	if !enabled {
+		t.Fatal("rate limiter not enabled")
+	}
+	return rl
+}
+
+func TestRateLimiterKinds(t *testing.T) {
+	rl := newTestRateLimiter(t, &schema.ApiRatelimit{
+		Enabled: true,
+		PerIP:   5000,
+		PerUser: 5000,
+		SearchStreams: &schema.SearchStreams{
+			PerIP:   10,
+			PerUser: 10,
+		},
+		Overrides: []*schema.Overrides{
+			{Key: "blocked", Limit: "blocked"},
+			// Limits decoded from the site configuration are float64.
+			{Key: "vip", Limit: float64(100), SearchStreams: "unlimited"},
+		},
+	}, nil)
+
+	rateLimit := func(key string, kind RateLimitKind) (bool, throttled.RateLimitResult) {
+		t.Helper()
+		limited, result, err := rl.RateLimit(key, 1, LimiterArgs{Kind: kind})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return limited, result
+	}
+
+	t.Run("search streams are limited separately", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if limited, _ := rateLimit("1", RateLimitKindSearchStream); limited {
+				t.Fatalf("search %d limited", i)
+			}
+		}
+		if limited, _ := rateLimit("1", RateLimitKindSearchStream); !limited {
+			t.Fatal("4th search not limited")
+		}
+		if limited, result := rateLimit("1", RateLimitKindGraphQL); limited || result.Remaining != 1000 {
+			t.Fatalf("GraphQL request limited by search streams: %v %+v", limited, result)
+		}
+	})
+
+	t.Run("kinds without limits are not limited", func(t *testing.T) {
+		if limited, result := rateLimit("1", RateLimitKindGitHTTP); limited || result.Limit != 0 {
+			t.Fatalf("git request limited: %v %+v", limited, result)
+		}
+	})
+
+	t.Run("blocked applies to all kinds", func(t *testing.T) {
+		if limited, _ := rateLimit("blocked", RateLimitKindGraphQL); !limited {
+			t.Fatal("GraphQL request not blocked")
+		}
+		if limited, _ := rateLimit("blocked", RateLimitKindSearchStream); !limited {
+			t.Fatal("search not blocked")
+		}
+	})
+
+	t.Run("overrides per kind", func(t *testing.T) {
+		if _, result := rateLimit("vip", RateLimitKindGraphQL); result.Limit != 21 {
+			t.Fatalf("unexpected GraphQL limit %d", result.Limit)
+		}
+		if _, result := rateLimit("vip", RateLimitKindSearchStream); result.Limit != 100000 {
+			t.Fatalf("unexpected search limit %d", result.Limit)
+		}
+	})
+}
+
+func TestRateLimiterAccessToken(t *testing.T) {
+	rl := newTestRateLimiter(t, &schema.ApiRatelimit{
+		Enabled:        true,
+		PerIP:          50,
+		PerUser:        50,
+		PerAccessToken: 5,
+	}, nil)
+
+	tokenArgs := LimiterArgs{AccessToken: "abc"}
+	for i := 0; i < 2; i++ {
+		limited, result, err := rl.RateLimit("1", 1, tokenArgs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if limited {
+			t.Fatalf("request %d limited", i)
+		}
+		// The token limit is more restrictive than the user limit.
+		if result.Limit != 2 {
+			t.Fatalf("unexpected limit %d", result.Limit)
+		}
+	}
+	if limited, _, _ := rl.RateLimit("1", 1, tokenArgs); !limited {
+		t.Fatal("3rd request with access token not limited")
+	}
+
+	// Requests of the user without the token only count against the user limit.
+	limited, result, err := rl.RateLimit("1", 1, LimiterArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limited || result.Limit != 11 || result.Remaining != 8 {
+		t.Fatalf("unexpected user limit: %v %+v", limited, result)
+	}
+}
+
+type fakeConcurrencyStore map[string]map[string]struct{}
+
+func (s fakeConcurrencyStore) Acquire(key, id string, max int) (bool, error) {
+	if len(s[key]) >= max {
+		return false, nil
+	}
+	if s[key] == nil {
+		s[key] = map[string]struct{}{}
+	}
+	s[key][id] = struct{}{}
+	return true, nil
+}
+
+func (s fakeConcurrencyStore) Release(key, id string) error {
+	delete(s[key], id)
+	return nil
+}
+
+func TestRateLimiterConcurrency(t *testing.T) {
+	rl := newTestRateLimiter(t, &schema.ApiRatelimit{
+		Enabled: true,
+		PerIP:   5000,
+		PerUser: 5000,
+		SearchStreams: &schema.SearchStreams{
+			PerIP:                100,
+			PerUser:              100,
+			MaxConcurrentPerUser: 1,
+		},
+		Overrides: []*schema.Overrides{
+			{Key: "2", MaxConcurrentSearchStreams: 2},
+			{Key: "3", Limit: "unlimited"},
+		},
+	}, fakeConcurrencyStore{})
+
+	acquire := func(key string, kind RateLimitKind) (func(), bool) {
+		t.Helper()
+		release, limited, err := rl.LimitConcurrency(key, LimiterArgs{Kind: kind})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return release, limited
+	}
+
+	release, limited := acquire("1", RateLimitKindSearchStream)
+	if limited {
+		t.Fatal("1st search limited")
+	}
+	if _, limited := acquire("1", RateLimitKindSearchStream); !limited {
+		t.Fatal("2nd concurrent search not limited")
+	}
+	release()
+	if _, limited := acquire("1", RateLimitKindSearchStream); limited {
+		t.Fatal("search after release limited")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, limited := acquire("2", RateLimitKindSearchStream); limited {
+			t.Fatalf("search %d of user with override limited", i)
+		}
+	}
+	if _, limited := acquire("2", RateLimitKindSearchStream); !limited {
+		t.Fatal("3rd concurrent search of user with override not limited")
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, limited := acquire("3", RateLimitKindSearchStream); limited {
+			t.Fatalf("search %d of unlimited user limited", i)
+		}
+		if _, limited := acquire("1", RateLimitKindGraphQL); limited {
+			t.Fatalf("GraphQL request %d limited", i)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func (r *schemaResolver) Roles(ctx context.Context) ([]*roleResolver, error) {
+	// 🚨 SECURITY: Only site admins can list roles.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	roles, err := r.db.Roles().List(ctx, database.RolesListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*roleResolver, 0, len(roles))
+	for _, role := range roles {
+		resolvers = append(resolvers, &roleResolver{db: r.db, role: role})
+	}
+	return resolvers, nil
+}
+
+func (r *UserResolver) Roles(ctx context.Context) ([]*roleResolver, error) {
+	// 🚨 SECURITY: Only the user and admins are allowed to list the roles of the user.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, r.db, r.user.ID); err != nil {
+		return nil, err
+	}
+
+	userRoles, err := r.db.UserRoles().GetByUserID(ctx, database.GetUserRoleOpts{UserID: r.user.ID})
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*roleResolver, 0, len(userRoles))
+	for _, userRole := range userRoles {
+		role, err := r.db.Roles().GetByID(ctx, database.GetRoleOpts{ID: userRole.RoleID})
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, &roleResolver{db: r.db, role: role})
+	}
+	return resolvers, nil
+}
+
+type roleAssignmentArgs struct {
+	User graphql.ID
+	Role graphql.ID
+}
+
+func (r *schemaResolver) AssignRoleToUser(ctx context.Context, args *roleAssignmentArgs) (*EmptyResponse, error) {
+	return r.changeUserRole(ctx, args, true)
+}
+
+func (r *schemaResolver) RevokeRoleFromUser(ctx context.Context, args *roleAssignmentArgs) (*EmptyResponse, error) {
+	return r.changeUserRole(ctx, args, false)
+}
+
+// changeUserRole assigns the role to the user if assign is true, and revokes it
+// otherwise. Every attempt is recorded in the security event log.
+func (r *schemaResolver) changeUserRole(ctx context.Context, args *roleAssignmentArgs, assign bool) (_ *EmptyResponse, err error) {
+	userID, err := UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+	roleID, err := unmarshalRoleID(args.Role)
+	if err != nil {
+		return nil, err
+	}
+	currentUser, err := CurrentUser(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser == nil {
+		return nil, auth.ErrNotAuthenticated
+	}
+	// Until we know the role is visible to the current user, we identify it by
+	// its GraphQL ID in the security event log.
+	eventArgs := roleChangeEventArgs{By: currentUser.user.ID, For: userID, To: string(args.Role)}
+	if !assign {
+		eventArgs.From, eventArgs.To = string(args.Role), ""
+	}
+	eventName := database.SecurityEventNameRoleChangeDenied
+	defer logRoleChangeAttempt(ctx, r.db, &eventName, &eventArgs, &err)
+
+	// 🚨 SECURITY: Only site admins can change the roles of users, as roles can
+	// grant any permission.
+	if err = auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	role, err := r.db.Roles().GetByID(ctx, database.GetRoleOpts{ID: roleID})
+	if err != nil {
+		return nil, err
+	}
+	if assign {
+		eventArgs.To = role.Name
+	} else {
+		eventArgs.From = role.Name
+	}
+
+	if assign {
+		_, err = r.db.UserRoles().Create(ctx, database.CreateUserRoleOpts{UserID: userID, RoleID: roleID})
+	} else {
+		err = r.db.UserRoles().Delete(ctx, database.DeleteUserRoleOpts{UserID: userID, RoleID: roleID})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	eventName = database.SecurityEventNameRoleChangeGranted
+	return &EmptyResponse{}, nil
+}
+
+type roleResolver struct {
+	db   database.DB
+	role *types.Role
+}
+
+const roleIDKind = "Role"
+
+func marshalRoleID(id int32) graphql.ID { return relay.MarshalID(roleIDKind, id) }
+
+func unmarshalRoleID(id graphql.ID) (roleID int32, err error) {
+	if kind := relay.UnmarshalKind(id); kind != roleIDKind {
+		err = errors.Errorf("expected graphql ID to have kind %q; got %q", roleIDKind, kind)
+		return
+	}
+	err = relay.UnmarshalSpec(id, &roleID)
+	return
+}
+
+func (r *roleResolver) ID() graphql.ID {
+	return marshalRoleID(r.role.ID)
+}
+
+func (r *roleResolver) Name() string {
+	return r.role.Name
+}
+
+func (r *roleResolver) Readonly() bool {
+	return r.role.ReadOnly
+}
+
+func (r *roleResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.role.CreatedAt}
+}
+
+func (r *roleResolver) Permissions(ctx context.Context) ([]*permissionResolver, error) {
+	permissions, err := r.db.Permissions().ListForRole(ctx, r.role.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*permissionResolver, 0, len(permissions))
+	for _, p := range permissions {
+		resolvers = append(resolvers, &permissionResolver{permission: p})
+	}
+	return resolvers, nil
+}
+
+type permissionResolver struct {
+	permission *types.Permission
+}
+
+func (r *permissionResolver) ID() graphql.ID {
+	return relay.MarshalID("Permission", r.permission.ID)
+}
+
+func (r *permissionResolver) Namespace() string {
+	return r.permission.Namespace
+}
+
+func (r *permissionResolver) Action() string {
+	return r.permission.Action
+}
+
+func (r *permissionResolver) DisplayName() string {
+	return rbac.Permission{Namespace: r.permission.Namespace, Action: r.permission.Action}.String()
+}
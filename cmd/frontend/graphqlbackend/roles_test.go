@@ -0,0 +1,79 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestAssignRoleToUser(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultHook(func(ctx context.Context) (*types.User, error) {
+		a := actor.FromContext(ctx)
+		return &types.User{ID: a.UID, SiteAdmin: a.UID == 1}, nil
+	})
+	roles := database.NewMockRoleStore()
+	roles.GetByIDFunc.SetDefaultReturn(&types.Role{ID: 5, Name: "CODE_INTEL_OPERATOR"}, nil)
+	userRoles := database.NewMockUserRoleStore()
+	securityEventLogs := database.NewMockSecurityEventLogsStore()
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.RolesFunc.SetDefaultReturn(roles)
+	db.UserRolesFunc.SetDefaultReturn(userRoles)
+	db.SecurityEventLogsFunc.SetDefaultReturn(securityEventLogs)
+
+	args := &roleAssignmentArgs{User: MarshalUserID(3), Role: marshalRoleID(5)}
+	r := &schemaResolver{db: db}
+
+	t.Run("non site admin", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), actor.FromUser(2))
+		if _, err := r.AssignRoleToUser(ctx, args); err != auth.ErrMustBeSiteAdmin {
+			t.Fatalf("unexpected error. want=%v have=%v", auth.ErrMustBeSiteAdmin, err)
+		}
+		if len(userRoles.CreateFunc.History()) != 0 {
+			t.Fatal("expected role not to be assigned")
+		}
+		assertSecurityEvent(t, securityEventLogs, database.SecurityEventNameRoleChangeDenied)
+	})
+
+	t.Run("not a role ID", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+		if _, err := r.AssignRoleToUser(ctx, &roleAssignmentArgs{User: MarshalUserID(3), Role: MarshalUserID(5)}); err == nil {
+			t.Fatal("expected error for a user ID")
+		}
+		if len(userRoles.CreateFunc.History()) != 0 {
+			t.Fatal("expected role not to be assigned")
+		}
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+		if _, err := r.AssignRoleToUser(ctx, args); err != nil {
+			t.Fatal(err)
+		}
+		history := userRoles.CreateFunc.History()
+		if len(history) != 1 {
+			t.Fatalf("expected role to be assigned once, assigned %d times", len(history))
+		}
+		if opts := history[0].Arg1; opts.UserID != 3 || opts.RoleID != 5 {
+			t.Fatalf("unexpected assignment %+v", opts)
+		}
+		assertSecurityEvent(t, securityEventLogs, database.SecurityEventNameRoleChangeGranted)
+	})
+}
+
+func assertSecurityEvent(t *testing.T, store *database.MockSecurityEventLogsStore, want database.SecurityEventName) {
+	t.Helper()
+	history := store.LogEventFunc.History()
+	if len(history) == 0 {
+		t.Fatal("expected security event to be logged")
+	}
+	if have := history[len(history)-1].Arg1.Name; have != want {
+		t.Fatalf("unexpected security event. want=%s have=%s", want, have)
+	}
+}
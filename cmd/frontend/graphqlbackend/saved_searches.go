@@ -2,13 +2,18 @@ package graphqlbackend
 
 import (
 	"context"
+	"net/url"
+	"time"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -57,20 +62,24 @@ func (r *schemaResolver) savedSearchByID(ctx context.Context, id graphql.ID) (*s
 
 	savedSearch := &savedSearchResolver{
 		db: r.db,
-		s: types.SavedSearch{
-			ID:              intID,
-			Description:     ss.Config.Description,
-			Query:           ss.Config.Query,
-			Notify:          ss.Config.Notify,
-			NotifySlack:     ss.Config.NotifySlack,
-			UserID:          ss.Config.UserID,
-			OrgID:           ss.Config.OrgID,
-			SlackWebhookURL: ss.Config.SlackWebhookURL,
-		},
+		s:  savedSearchFromConfig(intID, ss.Config),
 	}
 	return savedSearch, nil
 }
 
+func savedSearchFromConfig(id int32, config api.ConfigSavedQuery) types.SavedSearch {
+	return types.SavedSearch{
+		ID:              id,
+		Description:     config.Description,
+		Query:           config.Query,
+		Notify:          config.Notify,
+		NotifySlack:     config.NotifySlack,
+		UserID:          config.UserID,
+		OrgID:           config.OrgID,
+		SlackWebhookURL: config.SlackWebhookURL,
+	}
+}
+
 func (r savedSearchResolver) ID() graphql.ID {
 	return marshalSavedSearchID(r.s.ID)
 }
@@ -107,6 +116,82 @@ func (r savedSearchResolver) Namespace(ctx context.Context) (*NamespaceResolver,
 
 func (r savedSearchResolver) SlackWebhookURL() *string { return r.s.SlackWebhookURL }
 
+func (r savedSearchResolver) Schedule(ctx context.Context) (*savedSearchScheduleResolver, error) {
+	schedule, err := r.db.SavedSearches().GetSchedule(ctx, r.s.ID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &savedSearchScheduleResolver{db: r.db, s: schedule}, nil
+}
+
+func (r savedSearchResolver) Runs(ctx context.Context, args *struct{ First int32 }) ([]*savedSearchRunResolver, error) {
+	runs, err := r.db.SavedSearches().ListRuns(ctx, r.s.ID, int(args.First))
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*savedSearchRunResolver, 0, len(runs))
+	for _, run := range runs {
+		resolvers = append(resolvers, &savedSearchRunResolver{run: run})
+	}
+	return resolvers, nil
+}
+
+type savedSearchScheduleResolver struct {
+	db database.DB
+	s  *types.SavedSearchSchedule
+}
+
+func (r *savedSearchScheduleResolver) IntervalMinutes() int32 {
+	return int32(r.s.Interval / time.Minute)
+}
+
+func (r *savedSearchScheduleResolver) User(ctx context.Context) (*UserResolver, error) {
+	user, err := UserByIDInt32(ctx, r.db, r.s.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *savedSearchScheduleResolver) NextRunAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.s.NextRunAt}
+}
+
+func (r *savedSearchScheduleResolver) NotifyEmail() bool { return r.s.NotifyEmail }
+
+func (r *savedSearchScheduleResolver) SlackWebhookURL() *string { return r.s.SlackWebhookURL }
+
+func (r *savedSearchScheduleResolver) WebhookURL() *string { return r.s.WebhookURL }
+
+type savedSearchRunResolver struct {
+	run *types.SavedSearchRun
+}
+
+func (r *savedSearchRunResolver) RanAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.run.RanAt}
+}
+
+func (r *savedSearchRunResolver) Query() string { return r.run.Query }
+
+func (r *savedSearchRunResolver) Permalink() string {
+	return "/search?" + url.Values{"q": []string{r.run.PermalinkQuery}}.Encode()
+}
+
+func (r *savedSearchRunResolver) ResultCount() int32 { return int32(r.run.ResultCount) }
+
+func (r *savedSearchRunResolver) LimitHit() bool { return r.run.LimitHit }
+
+func (r *savedSearchRunResolver) Added() []string { return r.run.Added }
+
+func (r *savedSearchRunResolver) AddedCount() int32 { return int32(r.run.AddedCount) }
+
+func (r *savedSearchRunResolver) Removed() []string { return r.run.Removed }
+
+func (r *savedSearchRunResolver) RemovedCount() int32 { return int32(r.run.RemovedCount) }
+
 func (r *schemaResolver) toSavedSearchResolver(entry types.SavedSearch) *savedSearchResolver {
 	return &savedSearchResolver{db: r.db, s: entry}
 }
@@ -269,6 +354,115 @@ func (r *schemaResolver) DeleteSavedSearch(ctx context.Context, args *struct {
 	return &EmptyResponse{}, nil
 }
 
+// minSavedSearchIntervalMinutes is the minimum interval between two runs of a
+// scheduled saved search.
+const minSavedSearchIntervalMinutes = 60
+
+func (r *schemaResolver) ScheduleSavedSearch(ctx context.Context, args *struct {
+	ID              graphql.ID
+	IntervalMinutes int32
+	NotifyEmail     bool
+	SlackWebhookURL *string
+	WebhookURL      *string
+}) (*savedSearchResolver, error) {
+	id, err := unmarshalSavedSearchID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	ss, err := r.db.SavedSearches().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Make sure the current user has permission to schedule the saved search, and determine
+	// whose permissions it is run with. Organization saved searches are run as the current user, who must
+	// be a member of the organization rather than just a site admin.
+	var userID int32
+	if ss.Config.UserID != nil {
+		if err := auth.CheckSiteAdminOrSameUser(ctx, r.db, *ss.Config.UserID); err != nil {
+			return nil, err
+		}
+		userID = *ss.Config.UserID
+	} else if ss.Config.OrgID != nil {
+		if err := auth.CheckOrgAccess(ctx, r.db, *ss.Config.OrgID); err != nil {
+			return nil, err
+		}
+		userID = actor.FromContext(ctx).UID
+	} else {
+		return nil, errors.New("failed to schedule saved search: no Org ID or User ID associated with saved search")
+	}
+
+	if args.IntervalMinutes < minSavedSearchIntervalMinutes {
+		return nil, errors.Newf("intervalMinutes must be at least %d", minSavedSearchIntervalMinutes)
+	}
+	slackWebhookURL, err := validateSavedSearchWebhookURL(args.SlackWebhookURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid slackWebhookURL")
+	}
+	webhookURL, err := validateSavedSearchWebhookURL(args.WebhookURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid webhookURL")
+	}
+
+	if _, err := r.db.SavedSearches().UpsertSchedule(ctx, &types.SavedSearchSchedule{
+		SavedSearchID:   id,
+		UserID:          userID,
+		Interval:        time.Duration(args.IntervalMinutes) * time.Minute,
+		NotifyEmail:     args.NotifyEmail,
+		SlackWebhookURL: slackWebhookURL,
+		WebhookURL:      webhookURL,
+	}); err != nil {
+		return nil, err
+	}
+
+	return r.toSavedSearchResolver(savedSearchFromConfig(id, ss.Config)), nil
+}
+
+func (r *schemaResolver) UnscheduleSavedSearch(ctx context.Context, args *struct {
+	ID graphql.ID
+}) (*savedSearchResolver, error) {
+	id, err := unmarshalSavedSearchID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	ss, err := r.db.SavedSearches().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Make sure the current user has permission to unschedule a saved search for the specified user or org.
+	if ss.Config.UserID != nil {
+		if err := auth.CheckSiteAdminOrSameUser(ctx, r.db, *ss.Config.UserID); err != nil {
+			return nil, err
+		}
+	} else if ss.Config.OrgID != nil {
+		if err := auth.CheckOrgAccessOrSiteAdmin(ctx, r.db, *ss.Config.OrgID); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, errors.New("failed to unschedule saved search: no Org ID or User ID associated with saved search")
+	}
+	if err := r.db.SavedSearches().DeleteSchedule(ctx, id); err != nil {
+		return nil, err
+	}
+	return r.toSavedSearchResolver(savedSearchFromConfig(id, ss.Config)), nil
+}
+
+// validateSavedSearchWebhookURL returns nil for an empty URL, and otherwise
+// checks that the URL is an absolute HTTP(S) URL.
+func validateSavedSearchWebhookURL(rawURL *string) (*string, error) {
+	if rawURL == nil || *rawURL == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(*rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.Errorf("%q is not an HTTP(S) URL", *rawURL)
+	}
+	return rawURL, nil
+}
+
 var patternType = lazyregexp.New(`(?i)\bpatternType:(literal|regexp|structural|standard)\b`)
 
 func queryHasPatternType(query string) bool {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
 	"github.com/graph-gophers/graphql-go"
@@ -419,3 +420,127 @@ func TestDeleteSavedSearch(t *testing.T) {
 
 	mockrequire.Called(t, ss.DeleteFunc)
 }
+
+func TestScheduleSavedSearch(t *testing.T) {
+	user1 := &types.User{ID: 42}
+	user2 := &types.User{ID: 43}
+	admin := &types.User{ID: 44, SiteAdmin: true}
+	org1 := &types.Org{ID: 42}
+	webhookURL := "https://example.com/hook"
+	invalidURL := "file:///etc/passwd"
+
+	cases := []struct {
+		name            string
+		execUser        *types.User
+		ssUserID        *int32
+		ssOrgID         *int32
+		webhookURL      *string
+		wantErr         bool
+		wantScheduledAs int32
+	}{{
+		name:            "user saved search",
+		execUser:        user1,
+		ssUserID:        &user1.ID,
+		webhookURL:      &webhookURL,
+		wantScheduledAs: user1.ID,
+	}, {
+		name:     "saved search of another user",
+		execUser: user1,
+		ssUserID: &user2.ID,
+		wantErr:  true,
+	}, {
+		name:            "site admin schedules a saved search of another user",
+		execUser:        admin,
+		ssUserID:        &user1.ID,
+		wantScheduledAs: user1.ID,
+	}, {
+		name:            "org saved search runs as the current member",
+		execUser:        user1,
+		ssOrgID:         &org1.ID,
+		wantScheduledAs: user1.ID,
+	}, {
+		name:     "site admins must be org members",
+		execUser: admin,
+		ssOrgID:  &org1.ID,
+		wantErr:  true,
+	}, {
+		name:       "invalid webhook URL",
+		execUser:   user1,
+		ssUserID:   &user1.ID,
+		webhookURL: &invalidURL,
+		wantErr:    true,
+	}}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := actor.WithActor(context.Background(), actor.FromUser(tt.execUser.ID))
+			users := database.NewMockUserStore()
+			users.GetByCurrentAuthUserFunc.SetDefaultHook(func(ctx context.Context) (*types.User, error) {
+				switch actor.FromContext(ctx).UID {
+				case user1.ID:
+					return user1, nil
+				case admin.ID:
+					return admin, nil
+				default:
+					panic("bad actor")
+				}
+			})
+
+			savedSearches := database.NewMockSavedSearchStore()
+			savedSearches.GetByIDFunc.SetDefaultReturn(&api.SavedQuerySpecAndConfig{
+				Config: api.ConfigSavedQuery{
+					UserID: tt.ssUserID,
+					OrgID:  tt.ssOrgID,
+				},
+			}, nil)
+			savedSearches.UpsertScheduleFunc.SetDefaultHook(func(_ context.Context, s *types.SavedSearchSchedule) (*types.SavedSearchSchedule, error) {
+				return s, nil
+			})
+
+			orgMembers := database.NewMockOrgMemberStore()
+			orgMembers.GetByOrgIDAndUserIDFunc.SetDefaultHook(func(_ context.Context, orgID int32, userID int32) (*types.OrgMembership, error) {
+				if orgID == userID {
+					return &types.OrgMembership{}, nil
+				}
+				return nil, &database.ErrOrgMemberNotFound{}
+			})
+
+			db := database.NewMockDB()
+			db.UsersFunc.SetDefaultReturn(users)
+			db.SavedSearchesFunc.SetDefaultReturn(savedSearches)
+			db.OrgMembersFunc.SetDefaultReturn(orgMembers)
+
+			_, err := newSchemaResolver(db, gitserver.NewClient(db)).ScheduleSavedSearch(ctx, &struct {
+				ID              graphql.ID
+				IntervalMinutes int32
+				NotifyEmail     bool
+				SlackWebhookURL *string
+				WebhookURL      *string
+			}{
+				ID:              marshalSavedSearchID(1),
+				IntervalMinutes: 60,
+				NotifyEmail:     true,
+				WebhookURL:      tt.webhookURL,
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+				mockrequire.NotCalled(t, savedSearches.UpsertScheduleFunc)
+				return
+			}
+			require.NoError(t, err)
+			mockrequire.CalledOnce(t, savedSearches.UpsertScheduleFunc)
+
+			schedule := savedSearches.UpsertScheduleFunc.History()[0].Arg1
+			require.Equal(t, tt.wantScheduledAs, schedule.UserID)
+			require.Equal(t, time.Hour, schedule.Interval)
+			require.Equal(t, tt.webhookURL, schedule.WebhookURL)
+		})
+	}
+}
+
+func TestSavedSearchRunPermalink(t *testing.T) {
+	r := &savedSearchRunResolver{run: &types.SavedSearchRun{
+		PermalinkQuery: `(repo:^github\.com/sourcegraph/sourcegraph$@abc) TODO patternType:standard`,
+	}}
+	require.Equal(t, "/search?q=%28repo%3A%5Egithub%5C.com%2Fsourcegraph%2Fsourcegraph%24%40abc%29+TODO+patternType%3Astandard", r.Permalink())
+}
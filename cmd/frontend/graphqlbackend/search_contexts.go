@@ -51,9 +51,20 @@ type SearchContextResolver interface {
 	ViewerHasAsDefault(ctx context.Context) bool
 	ViewerHasStarred(ctx context.Context) bool
 	Repositories(ctx context.Context) ([]SearchContextRepositoryRevisionsResolver, error)
+	QueryRepositoryChanges(ctx context.Context, args *SearchContextQueryRepositoryChangesArgs) ([]SearchContextRepositoryChangeResolver, error)
 	Query() string
 }
 
+type SearchContextQueryRepositoryChangesArgs struct {
+	First int32
+}
+
+type SearchContextRepositoryChangeResolver interface {
+	Repository() *RepositoryResolver
+	Added() bool
+	CreatedAt() gqlutil.DateTime
+}
+
 type SearchContextConnectionResolver interface {
 	Nodes() []SearchContextResolver
 	TotalCount() int32
@@ -0,0 +1,199 @@
+package graphqlbackend
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func marshalSiteConfigurationChangeID(id int32) graphql.ID {
+	return relay.MarshalID("SiteConfigurationChange", id)
+}
+
+func (r *siteConfigurationResolver) History(ctx context.Context, args *graphqlutil.ConnectionResolverArgs) (*graphqlutil.ConnectionResolver[siteConfigurationChangeResolver], error) {
+	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
+	// so only admins may view it.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+	return graphqlutil.NewConnectionResolver[siteConfigurationChangeResolver](&siteConfigurationChangeConnectionStore{db: r.db}, args, nil)
+}
+
+func (r *siteConfigurationResolver) Diff(ctx context.Context, args *struct {
+	From int32
+	To   int32
+}) ([]*siteConfigurationFieldDiffResolver, error) {
+	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
+	// so only admins may view it.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	from, err := r.db.Conf().SiteGetByID(ctx, args.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := r.db.Conf().SiteGetByID(ctx, args.To)
+	if err != nil {
+		return nil, err
+	}
+	return diffSiteConfigurations(from.Contents, to.Contents)
+}
+
+func diffSiteConfigurations(before, after string) ([]*siteConfigurationFieldDiffResolver, error) {
+	diffs, err := conf.DiffSiteConfigs(before, after)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*siteConfigurationFieldDiffResolver, 0, len(diffs))
+	for _, d := range diffs {
+		resolvers = append(resolvers, &siteConfigurationFieldDiffResolver{diff: d})
+	}
+	return resolvers, nil
+}
+
+type siteConfigurationChangeConnectionStore struct {
+	db database.DB
+}
+
+func (s *siteConfigurationChangeConnectionStore) ComputeTotal(ctx context.Context) (*int32, error) {
+	count, err := s.db.Conf().GetSiteConfigCount(ctx)
+	c := int32(count)
+	return &c, err
+}
+
+func (s *siteConfigurationChangeConnectionStore) ComputeNodes(ctx context.Context, args *database.PaginationArgs) ([]*siteConfigurationChangeResolver, error) {
+	configs, err := s.db.Conf().ListSiteConfigs(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*siteConfigurationChangeResolver, 0, len(configs))
+	for _, c := range configs {
+		resolvers = append(resolvers, &siteConfigurationChangeResolver{db: s.db, siteConfig: c})
+	}
+	return resolvers, nil
+}
+
+func (s *siteConfigurationChangeConnectionStore) MarshalCursor(node *siteConfigurationChangeResolver) (*string, error) {
+	cursor := strconv.Itoa(int(node.siteConfig.ID))
+	return &cursor, nil
+}
+
+func (s *siteConfigurationChangeConnectionStore) UnmarshalCursor(cursor string) (*int, error) {
+	id, err := strconv.Atoi(cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+	return &id, nil
+}
+
+type siteConfigurationChangeResolver struct {
+	db         database.DB
+	siteConfig *database.SiteConfig
+}
+
+func (r siteConfigurationChangeResolver) ID() graphql.ID {
+	return marshalSiteConfigurationChangeID(r.siteConfig.ID)
+}
+
+func (r siteConfigurationChangeResolver) Version() int32 {
+	return r.siteConfig.ID
+}
+
+func (r siteConfigurationChangeResolver) Author(ctx context.Context) (*UserResolver, error) {
+	if r.siteConfig.AuthorUserID == 0 {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, r.db, r.siteConfig.AuthorUserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r siteConfigurationChangeResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.siteConfig.CreatedAt}
+}
+
+func (r siteConfigurationChangeResolver) RedactedContents() (JSONCString, error) {
+	// 🚨 SECURITY: Past versions contain secrets just like the current one. The
+	// connection already verified the user is an admin, but secrets must never
+	// be served regardless.
+	redacted, err := conf.RedactSecrets(conftypes.RawUnified{Site: r.siteConfig.Contents})
+	return JSONCString(redacted.Site), err
+}
+
+func (r siteConfigurationChangeResolver) DiffFromPrevious(ctx context.Context) ([]*siteConfigurationFieldDiffResolver, error) {
+	first, after := 1, int(r.siteConfig.ID)
+	previous, err := r.db.Conf().ListSiteConfigs(ctx, &database.PaginationArgs{First: &first, After: &after})
+	if err != nil {
+		return nil, err
+	}
+	before := "{}"
+	if len(previous) > 0 {
+		before = previous[0].Contents
+	}
+	return diffSiteConfigurations(before, r.siteConfig.Contents)
+}
+
+type siteConfigurationFieldDiffResolver struct {
+	diff conf.FieldDiff
+}
+
+func (r *siteConfigurationFieldDiffResolver) Field() string { return r.diff.Field }
+
+func (r *siteConfigurationFieldDiffResolver) Before() *JSONValue {
+	return jsonValueOrNull(r.diff.Before)
+}
+
+func (r *siteConfigurationFieldDiffResolver) After() *JSONValue {
+	return jsonValueOrNull(r.diff.After)
+}
+
+func jsonValueOrNull(v json.RawMessage) *JSONValue {
+	if string(v) == "null" {
+		return nil
+	}
+	return &JSONValue{v}
+}
+
+func (r *schemaResolver) RestoreSiteConfiguration(ctx context.Context, args *struct {
+	LastID int32
+	ID     int32
+}) (bool, error) {
+	// 🚨 SECURITY: Restoring a revision overwrites the site configuration, which
+	// controls authentication and code host access, so only admins may do it.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return false, err
+	}
+	if !canUpdateSiteConfiguration() {
+		return false, errors.New("updating site configuration not allowed when using SITE_CONFIG_FILE")
+	}
+
+	siteConfig, err := r.db.Conf().SiteGetByID(ctx, args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	// The stored contents are not redacted, so they are written as is. The
+	// write goes through the same validation as updateSiteConfiguration.
+	prev := conf.Raw()
+	prev.Site = siteConfig.Contents
+	if err := globals.ConfigurationServerFrontendOnly.Write(ctx, prev, args.LastID); err != nil {
+		return false, err
+	}
+	return globals.ConfigurationServerFrontendOnly.NeedServerRestart(), nil
+}
@@ -16,9 +16,11 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
+	sglog "github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	uirouter "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/app/ui/router"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/routevar"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/search"
@@ -118,9 +120,9 @@ func Router() *mux.Router {
 // InitRouter create the router that serves pages for our web app
 // and assigns it to uirouter.Router.
 // The router can be accessed by calling Router().
-func InitRouter(db database.DB) {
+func InitRouter(db database.DB, rateLimitWatcher graphqlbackend.LimitWatcher) {
 	router := newRouter()
-	initRouter(db, router)
+	initRouter(db, router, rateLimitWatcher)
 }
 
 var mockServeRepo func(w http.ResponseWriter, r *http.Request)
@@ -228,7 +230,7 @@ func brandNameSubtitle(titles ...string) string {
 	return strings.Join(append(titles, globals.Branding().BrandName), " - ")
 }
 
-func initRouter(db database.DB, router *mux.Router) {
+func initRouter(db database.DB, router *mux.Router, rateLimitWatcher graphqlbackend.LimitWatcher) {
 	uirouter.Router = router // make accessible to other packages
 
 	brandedIndex := func(titles string) http.Handler {
@@ -315,7 +317,7 @@ func initRouter(db database.DB, router *mux.Router) {
 	}, nil, index)))
 
 	// streaming search
-	router.Get(routeSearchStream).Handler(search.StreamHandler(db))
+	router.Get(routeSearchStream).Handler(graphqlbackend.RateLimitMiddleware(sglog.Scoped("searchStream", "streaming search"), rateLimitWatcher, graphqlbackend.RateLimitKindSearchStream, search.StreamHandler(db)))
 
 	// search badge
 	router.Get(routeSearchBadge).Handler(searchBadgeHandler())
@@ -173,7 +173,11 @@ func Main(enterpriseSetupHook func(database.DB, conftypes.UnifiedWatchable) ente
 	if err != nil {
 		return errors.Wrap(err, "Failed to create sub-repo client")
 	}
-	ui.InitRouter(db)
+	rateLimitWatcher, err := makeRateLimitWatcher()
+	if err != nil {
+		return err
+	}
+	ui.InitRouter(db, rateLimitWatcher)
 
 	if len(os.Args) >= 2 {
 		switch os.Args[1] {
@@ -255,11 +259,6 @@ func Main(enterpriseSetupHook func(database.DB, conftypes.UnifiedWatchable) ente
 		return err
 	}
 
-	rateLimitWatcher, err := makeRateLimitWatcher()
-	if err != nil {
-		return err
-	}
-
 	server, err := makeExternalAPI(db, logger, schema, enterprise, rateLimitWatcher)
 	if err != nil {
 		return err
@@ -388,11 +387,17 @@ func isAllowedOrigin(origin string, allowedOrigins []string) bool {
 	return false
 }
 
-func makeRateLimitWatcher() (*graphqlbackend.BasicLimitWatcher, error) {
+// makeRateLimitWatcher returns the limiter configured by "api.ratelimit", or the
+// basic limiter of anonymous GraphQL requests if API rate limiting is disabled.
+func makeRateLimitWatcher() (graphqlbackend.LimitWatcher, error) {
 	ratelimitStore, err := redigostore.New(redispool.Cache, "gql:rl:", 0)
 	if err != nil {
 		return nil, err
 	}
+	concurrencyStore := graphqlbackend.NewRedisConcurrencyStore(redispool.Cache, "api:concurrency:")
 
-	return graphqlbackend.NewBasicLimitWatcher(sglog.Scoped("BasicLimitWatcher", "basic rate-limiter"), ratelimitStore), nil
+	return graphqlbackend.LimitWatchers{
+		graphqlbackend.NewRateLimiteWatcher(sglog.Scoped("RateLimitWatcher", "API rate-limiter"), ratelimitStore, concurrencyStore),
+		graphqlbackend.NewBasicLimitWatcher(sglog.Scoped("BasicLimitWatcher", "basic rate-limiter"), ratelimitStore),
+	}, nil
 }
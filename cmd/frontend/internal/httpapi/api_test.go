@@ -24,7 +24,7 @@ func newTest(t *testing.T) *httptestutil.Client {
 	logger := logtest.Scoped(t)
 	enterpriseServices := enterprise.DefaultServices()
 	rateLimitStore, _ := memstore.New(1024)
-	rateLimiter := graphqlbackend.NewRateLimiteWatcher(logger, rateLimitStore)
+	rateLimiter := graphqlbackend.NewRateLimiteWatcher(logger, rateLimitStore, nil)
 
 	db := database.NewMockDB()
 
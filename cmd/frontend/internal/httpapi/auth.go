@@ -1,6 +1,8 @@
 package httpapi
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -247,6 +249,7 @@ func AccessTokenAuthMiddleware(db database.DB, logger log.Logger, next http.Hand
 					&actor.Actor{
 						UID:                 actorUserID,
 						SourcegraphOperator: sourcegraphOperator,
+						AccessTokenKey:      accessTokenKey(token),
 					},
 				),
 			)
@@ -255,3 +258,9 @@ func AccessTokenAuthMiddleware(db database.DB, logger log.Logger, next http.Hand
 		next.ServeHTTP(w, r)
 	})
 }
+
+// accessTokenKey returns a stable identifier of the given access token that does not reveal it.
+func accessTokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
@@ -20,7 +19,6 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/audit"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
-	"github.com/sourcegraph/sourcegraph/internal/cookie"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -73,7 +71,7 @@ func serveGraphQL(logger sglog.Logger, schema *graphql.Schema, rlw graphqlbacken
 			recordAuditLog(r.Context(), logger, traceData)
 		}()
 
-		uid, isIP, anonymous := getUID(r)
+		uid, isIP, anonymous := graphqlbackend.RateLimitKey(r)
 		traceData.uid = uid
 		traceData.anonymous = anonymous
 
@@ -97,6 +95,8 @@ func serveGraphQL(logger sglog.Logger, schema *graphql.Schema, rlw graphqlbacken
 					Anonymous:     anonymous,
 					RequestName:   requestName,
 					RequestSource: requestSource,
+					Kind:          graphqlbackend.RateLimitKindGraphQL,
+					AccessToken:   actor.FromContext(r.Context()).AccessTokenKey,
 				})
 				if err != nil {
 					log15.Error("checking GraphQL rate limit", "error", err)
@@ -104,13 +104,36 @@ func serveGraphQL(logger sglog.Logger, schema *graphql.Schema, rlw graphqlbacken
 				} else {
 					traceData.limited = limited
 					traceData.limitResult = result
+					graphqlbackend.SetRateLimitHeaders(w.Header(), limited, result)
 					if limited {
-						w.Header().Set("Retry-After", strconv.Itoa(int(result.RetryAfter.Seconds())))
 						w.WriteHeader(http.StatusTooManyRequests)
 						return nil
 					}
 				}
 			}
+
+			// Searches share the concurrency limit of streaming searches, so that
+			// clients cannot run more searches at once by using the GraphQL API.
+			if rl, enabled := rlw.Get(); enabled && !isInternal && !actor.FromContext(r.Context()).IsInternal() && graphqlbackend.IsSearchQuery(params.Query) {
+				release, limited, err := rl.LimitConcurrency(uid, graphqlbackend.LimiterArgs{
+					IsIP:          isIP,
+					Anonymous:     anonymous,
+					RequestName:   requestName,
+					RequestSource: requestSource,
+					Kind:          graphqlbackend.RateLimitKindSearchStream,
+					AccessToken:   actor.FromContext(r.Context()).AccessTokenKey,
+				})
+				if err != nil {
+					log15.Error("checking GraphQL search concurrency limit", "error", err)
+					traceData.limitError = err
+				} else if limited {
+					traceData.limited = true
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(http.StatusTooManyRequests)
+					return nil
+				}
+				defer release()
+			}
 		}
 
 		traceData.execStart = time.Now()
@@ -152,22 +175,6 @@ type traceData struct {
 	limitResult throttled.RateLimitResult
 }
 
-func getUID(r *http.Request) (uid string, ip bool, anonymous bool) {
-	a := actor.FromContext(r.Context())
-	anonymous = !a.IsAuthenticated()
-	if !anonymous {
-		return a.UIDString(), false, anonymous
-	}
-	if uid, ok := cookie.AnonymousUID(r); ok && uid != "" {
-		return uid, false, anonymous
-	}
-	// The user is anonymous with no cookie, use IP
-	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
-		return ip, true, anonymous
-	}
-	return "unknown", false, anonymous
-}
-
 func recordAuditLog(ctx context.Context, logger sglog.Logger, data traceData) {
 	if !audit.IsEnabled(conf.SiteConfig(), audit.GraphQL) {
 		return
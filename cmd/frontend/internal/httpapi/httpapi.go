@@ -128,7 +128,7 @@ func NewHandler(
 
 	m.Get(apirouter.GraphQL).Handler(trace.Route(handler(serveGraphQL(logger, schema, rateLimiter, false))))
 
-	m.Get(apirouter.SearchStream).Handler(trace.Route(frontendsearch.StreamHandler(db)))
+	m.Get(apirouter.SearchStream).Handler(trace.Route(graphqlbackend.RateLimitMiddleware(logger, rateLimiter, graphqlbackend.RateLimitKindSearchStream, frontendsearch.StreamHandler(db))))
 
 	// Return the minimum src-cli version that's compatible with this instance
 	m.Get(apirouter.SrcCli).Handler(trace.Route(newSrcCliVersionHandler(logger)))
@@ -136,6 +136,14 @@ func NewHandler(
 	gsClient := gitserver.NewClient(db)
 	m.Get(apirouter.GitBlameStream).Handler(trace.Route(handleStreamBlame(logger, db, gsClient)))
 
+	repoGitService := &repoGitServiceHandler{
+		db:        db,
+		logger:    logger.Scoped("repoGitService", "git clones over HTTP"),
+		Gitserver: gsClient,
+	}
+	m.Get(apirouter.RepoGitInfoRefs).Handler(trace.Route(graphqlbackend.RateLimitMiddleware(logger, rateLimiter, graphqlbackend.RateLimitKindGitHTTP, handler(repoGitService.serveInfoRefs()))))
+	m.Get(apirouter.RepoGitUploadPack).Handler(trace.Route(graphqlbackend.RateLimitMiddleware(logger, rateLimiter, graphqlbackend.RateLimitKindGitHTTP, handler(repoGitService.serveGitUploadPack()))))
+
 	// Set up the src-cli version cache handler (this will effectively be a
 	// no-op anywhere other than dot-com).
 	m.Get(apirouter.SrcCliVersionCache).Handler(trace.Route(releasecache.NewHandler(logger)))
@@ -0,0 +1,92 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"path"
+
+	"github.com/gorilla/mux"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/handlerutil"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+// repoGitServiceHandler serves git clones over HTTP of the repositories the
+// actor has access to, by proxying the requests to the gitserver of the repo.
+// Unlike gitServiceHandler, which redirects internal clients to gitserver,
+// external clients can't reach gitserver themselves.
+type repoGitServiceHandler struct {
+	db        database.DB
+	logger    log.Logger
+	Gitserver interface {
+		AddrForRepo(context.Context, api.RepoName) (string, error)
+	}
+}
+
+func (s *repoGitServiceHandler) serveInfoRefs() func(http.ResponseWriter, *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		// Only fetching is supported.
+		if r.URL.Query().Get("service") != "git-upload-pack" {
+			http.Error(w, "only git-upload-pack is supported", http.StatusForbidden)
+			return nil
+		}
+		return s.proxyToGitServer(w, r, "/info/refs")
+	}
+}
+
+func (s *repoGitServiceHandler) serveGitUploadPack() func(http.ResponseWriter, *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		return s.proxyToGitServer(w, r, "/git-upload-pack")
+	}
+}
+
+func (s *repoGitServiceHandler) proxyToGitServer(w http.ResponseWriter, r *http.Request, gitPath string) error {
+	ctx := r.Context()
+
+	// 🚨 SECURITY: GetRepo only returns repositories the actor has access to.
+	repo, err := handlerutil.GetRepo(ctx, s.logger, s.db, mux.Vars(r))
+	if err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: A clone contains every path of the repository, so it would
+	// bypass sub-repo permissions.
+	enabled, err := authz.SubRepoEnabledForRepo(ctx, authz.DefaultSubRepoPermsChecker, repo.Name)
+	if err != nil {
+		return err
+	}
+	if enabled {
+		http.Error(w, "cloning repositories with sub-repository permissions is not supported", http.StatusForbidden)
+		return nil
+	}
+
+	addrForRepo, err := s.Gitserver.AddrForRepo(ctx, repo.Name)
+	if err != nil {
+		return err
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL = &url.URL{
+				Scheme:   "http",
+				Host:     addrForRepo,
+				Path:     path.Join("/git", string(repo.Name), gitPath),
+				RawQuery: r.URL.RawQuery,
+			}
+			req.Host = addrForRepo
+			// gitserver must not see the credentials of the user.
+			req.Header.Del("Authorization")
+			req.Header.Del("Cookie")
+		},
+		Transport: httpcli.InternalClient.Transport,
+	}
+	proxy.ServeHTTP(w, r)
+	return nil
+}
@@ -0,0 +1,56 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestRepoGitRateLimit(t *testing.T) {
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			ApiRatelimit: &schema.ApiRatelimit{
+				Enabled: true,
+				PerIP:   5000,
+				PerUser: 5000,
+				GitHTTP: &schema.GitHTTP{PerIP: 1, PerUser: 1},
+			},
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	c := newTest(t)
+
+	backend.Mocks.Repos.GetByName = func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		return nil, &database.RepoNotFoundErr{Name: name}
+	}
+	t.Cleanup(func() { backend.Mocks.Repos = backend.MockRepos{} })
+
+	infoRefs := func(ip string) int {
+		t.Helper()
+		req, _ := http.NewRequest("GET", "/git/github.com/gorilla/mux/info/refs?service=git-upload-pack", nil)
+		req.RemoteAddr = ip + ":1234"
+		resp, err := c.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode
+	}
+
+	if got := infoRefs("1.2.3.4"); got != http.StatusNotFound {
+		t.Fatalf("first request: got status %d, want %d", got, http.StatusNotFound)
+	}
+	if got := infoRefs("1.2.3.4"); got != http.StatusTooManyRequests {
+		t.Fatalf("second request: got status %d, want %d", got, http.StatusTooManyRequests)
+	}
+	if got := infoRefs("5.6.7.8"); got != http.StatusNotFound {
+		t.Fatalf("request from other IP: got status %d, want %d", got, http.StatusNotFound)
+	}
+}
@@ -23,9 +23,11 @@ const (
 
 	Registry = "registry"
 
-	RepoShield  = "repo.shield"
-	RepoRefresh = "repo.refresh"
-	Telemetry   = "telemetry"
+	RepoShield        = "repo.shield"
+	RepoRefresh       = "repo.refresh"
+	RepoGitInfoRefs   = "repo.git.info-refs"
+	RepoGitUploadPack = "repo.git.upload-pack"
+	Telemetry         = "telemetry"
 
 	Webhooks                = "webhooks"
 	GitHubWebhooks          = "github.webhooks"
@@ -78,6 +80,8 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/blame/" + routevar.Repo + routevar.RepoRevSuffix + "/stream/{Path:.*}").Methods("GET").Name(GitBlameStream)
 	base.Path("/src-cli/versions/{rest:.*}").Methods("GET", "POST").Name(SrcCliVersionCache)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCli)
+	base.Path("/git/" + routevar.Repo + "/info/refs").Methods("GET").Name(RepoGitInfoRefs)
+	base.Path("/git/" + routevar.Repo + "/git-upload-pack").Methods("POST").Name(RepoGitUploadPack)
 
 	// repo contains routes that are NOT specific to a revision. In these routes, the URL may not contain a revspec after the repo (that is, no "github.com/foo/bar@myrevspec").
 	repoPath := `/repos/` + routevar.Repo
@@ -1289,7 +1289,11 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, match
 
 	for _, rev := range args.Revisions {
 		// TODO add result to trace
-		if rev.RevSpec != "" {
+		if rr, isRange, err := gitdomain.ParseRevisionRange(rev.RevSpec); isRange && err == nil {
+			from, to := rr.Ends()
+			_ = s.ensureRevision(ctx, args.Repo, from, dir)
+			_ = s.ensureRevision(ctx, args.Repo, to, dir)
+		} else if rev.RevSpec != "" {
 			_ = s.ensureRevision(ctx, args.Repo, rev.RevSpec, dir)
 		} else if rev.RefGlob != "" {
 			_ = s.ensureRevision(ctx, args.Repo, rev.RefGlob, dir)
@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -36,7 +37,7 @@ type Server struct {
 	SourcegraphDotComMode bool
 	Scheduler             interface {
 		UpdateOnce(id api.RepoID, name api.RepoName)
-		ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult
+		ScheduleInfo(ctx context.Context, id api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error)
 	}
 	ChangesetSyncRegistry batches.ChangesetSyncRegistry
 	RateLimitSyncer       interface {
@@ -62,6 +63,7 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/repo-lookup", trace.WithRouteName("repo-lookup", s.handleRepoLookup))
 	mux.HandleFunc("/enqueue-repo-update", trace.WithRouteName("enqueue-repo-update", s.handleEnqueueRepoUpdate))
 	mux.HandleFunc("/sync-external-service", trace.WithRouteName("sync-external-service", s.handleExternalServiceSync))
+	mux.HandleFunc("/sync-external-service-dry-run", trace.WithRouteName("sync-external-service-dry-run", s.handleExternalServiceSyncDryRun))
 	mux.HandleFunc("/enqueue-changeset-sync", trace.WithRouteName("enqueue-changeset-sync", s.handleEnqueueChangesetSync))
 	mux.HandleFunc("/schedule-perms-sync", trace.WithRouteName("schedule-perms-sync", s.handleSchedulePermsSync))
 	return mux
@@ -74,7 +76,11 @@ func (s *Server) handleRepoUpdateSchedulerInfo(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	result := s.Scheduler.ScheduleInfo(args.ID)
+	result, err := s.Scheduler.ScheduleInfo(r.Context(), args.ID)
+	if err != nil {
+		s.respond(w, http.StatusInternalServerError, err)
+		return
+	}
 	s.respond(w, http.StatusOK, result)
 }
 
@@ -215,6 +221,54 @@ func (s *Server) handleExternalServiceSync(w http.ResponseWriter, r *http.Reques
 	s.respond(w, http.StatusOK, &protocol.ExternalServiceSyncResult{})
 }
 
+func (s *Server) handleExternalServiceSyncDryRun(w http.ResponseWriter, r *http.Request) {
+	var req protocol.ExternalServiceSyncDryRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	svc := &types.ExternalService{Kind: req.Kind}
+	if req.ExternalServiceID != 0 {
+		es, err := s.ExternalServiceStore().GetByID(r.Context(), req.ExternalServiceID)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				s.respond(w, http.StatusNotFound, err)
+			} else {
+				s.respond(w, http.StatusInternalServerError, err)
+			}
+			return
+		}
+		svc = es.Clone()
+	}
+	svc.Config = extsvc.NewUnencryptedConfig(req.Config)
+
+	diff, err := s.Syncer.DryRunExternalServiceSync(r.Context(), svc)
+	if err != nil {
+		if r.Context().Err() != nil {
+			// client is gone
+			return
+		}
+		switch {
+		case errcode.IsUnauthorized(err):
+			s.respond(w, http.StatusUnauthorized, err)
+		case errcode.IsForbidden(err):
+			s.respond(w, http.StatusForbidden, err)
+		default:
+			s.respond(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	result := &protocol.ExternalServiceSyncDryRunResult{
+		Added:      diff.Added.Names(),
+		Modified:   diff.Modified.Repos().Names(),
+		Deleted:    diff.Deleted.Names(),
+		Unmodified: len(diff.Unmodified),
+	}
+	s.respond(w, http.StatusOK, result)
+}
+
 func (s *Server) respond(w http.ResponseWriter, code int, v any) {
 	switch val := v.(type) {
 	case error:
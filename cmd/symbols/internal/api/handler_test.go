@@ -41,11 +41,14 @@ func TestHandler(t *testing.T) {
 					Name: "x",
 					Path: "a.js",
 					Line: 1, // ctags line numbers are 1-based
+					Kind: "variable",
 				},
 				{
-					Name: "y",
-					Path: "a.js",
-					Line: 2,
+					Name:   "y",
+					Path:   "a.js",
+					Line:   2,
+					Kind:   "method",
+					Parent: "Server",
 				},
 			},
 		}
@@ -75,8 +78,8 @@ func TestHandler(t *testing.T) {
 		HTTPClient: httpcli.InternalDoer,
 	}
 
-	x := result.Symbol{Name: "x", Path: "a.js", Line: 0, Character: 4}
-	y := result.Symbol{Name: "y", Path: "a.js", Line: 1, Character: 4}
+	x := result.Symbol{Name: "x", Path: "a.js", Line: 0, Character: 4, Kind: "variable"}
+	y := result.Symbol{Name: "y", Path: "a.js", Line: 1, Character: 4, Kind: "method", Parent: "Server"}
 
 	testCases := map[string]struct {
 		args     search.SymbolsParameters
@@ -122,6 +125,22 @@ func TestHandler(t *testing.T) {
 			args:     search.SymbolsParameters{ExcludePattern: "a.js", IsCaseSensitive: true, First: 10},
 			expected: nil,
 		},
+		"kind": {
+			args:     search.SymbolsParameters{SymbolFilter: search.SymbolFilter{IncludeKinds: []string{"method"}}, First: 1},
+			expected: []result.Symbol{y},
+		},
+		"excludekind": {
+			args:     search.SymbolsParameters{SymbolFilter: search.SymbolFilter{ExcludeKinds: []string{"method"}}, First: 10},
+			expected: []result.Symbol{x},
+		},
+		"parent": {
+			args:     search.SymbolsParameters{SymbolFilter: search.SymbolFilter{IncludeParentPatterns: []string{"^server$"}}, First: 10},
+			expected: []result.Symbol{y},
+		},
+		"casesensitivenoparentmatch": {
+			args:     search.SymbolsParameters{SymbolFilter: search.SymbolFilter{IncludeParentPatterns: []string{"^server$"}, IsCaseSensitive: true}, First: 10},
+			expected: nil,
+		},
 	}
 
 	for label, testCase := range testCases {
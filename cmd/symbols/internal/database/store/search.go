@@ -74,6 +74,7 @@ func makeSearchConditions(args search.SymbolsParameters) []*sqlf.Query {
 	for _, includePattern := range args.IncludePatterns {
 		conditions = append(conditions, makeSearchCondition("path", includePattern, args.IsCaseSensitive))
 	}
+	conditions = append(conditions, makeSymbolFilterConditions(args.SymbolFilter)...)
 
 	filtered := conditions[:0]
 	for _, condition := range conditions {
@@ -120,6 +121,42 @@ func makeSearchCondition(column string, regex string, isCaseSensitive bool) *sql
 	return sqlf.Sprintf(column+" REGEXP %s", regex)
 }
 
+// makeSymbolFilterConditions returns the conditions of the symbol.kind: and
+// symbol.parent: filters. Filtering in the query ensures that the kinds and
+// parents are taken into account before the result limit is applied.
+func makeSymbolFilterConditions(filter search.SymbolFilter) []*sqlf.Query {
+	kindsIn := func(kinds []string) *sqlf.Query {
+		if len(kinds) == 0 {
+			return nil
+		}
+		values := make([]*sqlf.Query, 0, len(kinds))
+		for _, kind := range kinds {
+			values = append(values, sqlf.Sprintf("%s", kind))
+		}
+		return sqlf.Sprintf("lower(kind) IN (%s)", sqlf.Join(values, ", "))
+	}
+
+	parentMatches := func(regex string) *sqlf.Query {
+		if regex == "" {
+			return nil
+		}
+		if !filter.IsCaseSensitive {
+			regex = "(?i:" + regex + ")"
+		}
+		return sqlf.Sprintf("parent REGEXP %s", regex)
+	}
+
+	conditions := []*sqlf.Query{
+		kindsIn(filter.IncludeKinds),
+		negate(kindsIn(filter.ExcludeKinds)),
+		negate(parentMatches(filter.ExcludeParentPattern)),
+	}
+	for _, includePattern := range filter.IncludeParentPatterns {
+		conditions = append(conditions, parentMatches(includePattern))
+	}
+	return conditions
+}
+
 // isLiteralEquality returns true if the given regex matches literal strings exactly.
 // If so, this function returns true along with the literal search query. If not, this
 // function returns false.
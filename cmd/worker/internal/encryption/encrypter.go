@@ -43,9 +43,19 @@ func (e *recordEncrypter) handleBatch(ctx context.Context, config database.Encry
 
 func (e *recordEncrypter) handleEncryptBatch(ctx context.Context, config database.EncryptionConfig) error {
 	count, err := e.store.EncryptBatch(ctx, config)
-	if err != nil || count == 0 {
+	if err != nil {
+		return err
+	}
+	// Records encrypted with an earlier version of a rotated key are
+	// re-encrypted with its current version.
+	reencrypted, err := e.store.ReencryptBatch(ctx, config)
+	if err != nil {
 		return err
 	}
+	count += reencrypted
+	if count == 0 {
+		return nil
+	}
 
 	e.metrics.numRecordsEncrypted.WithLabelValues(config.TableName).Add(float64(count))
 	e.logger.Debug("encrypted records", log.String("tableName", config.TableName), log.Int("count", count))
@@ -0,0 +1,102 @@
+package searchcontexts
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// batchSize is the maximum number of search contexts re-evaluated per run.
+const batchSize = 100
+
+type reposSyncer struct{}
+
+var _ job.Job = &reposSyncer{}
+
+func NewReposSyncer() job.Job {
+	return &reposSyncer{}
+}
+
+func (j *reposSyncer) Description() string {
+	return "searchcontexts.ReposSyncer re-evaluates the repositories matched by query-based search contexts when repository metadata changes, and records the changes."
+}
+
+func (j *reposSyncer) Config() []env.Config {
+	return nil
+}
+
+func (j *reposSyncer) Routines(startupCtx context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(context.Background(), "search.context-repos-syncer", "re-evaluates the repositories matched by query-based search contexts",
+			1*time.Minute, &handler{
+				db:     db,
+				logger: observationCtx.Logger,
+			},
+		),
+	}, nil
+}
+
+type handler struct {
+	db     database.DB
+	logger log.Logger
+}
+
+var (
+	_ goroutine.Handler      = &handler{}
+	_ goroutine.ErrorHandler = &handler{}
+)
+
+func (h *handler) Handle(ctx context.Context) (err error) {
+	// Search contexts are matched against all repositories, regardless of
+	// who owns them.
+	ctx = actor.WithInternalActor(ctx)
+
+	searchContexts, err := h.db.SearchContexts().SelectQuerySearchContextsForRepoSync(ctx, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, sc := range searchContexts {
+		repoIDs, queryErr := searchcontexts.QueryRepoIDs(ctx, h.db, sc.Query)
+		if queryErr != nil {
+			err = errors.Append(err, errors.Wrapf(queryErr, "evaluating query of search context %d", sc.ID))
+			continue
+		}
+
+		added, removed, setErr := h.db.SearchContexts().SetSearchContextQueryRepos(ctx, sc.ID, repoIDs)
+		if setErr != nil {
+			err = errors.Append(err, errors.Wrapf(setErr, "setting repositories of search context %d", sc.ID))
+			continue
+		}
+
+		if added > 0 || removed > 0 {
+			h.logger.Debug("search context repositories changed",
+				log.Int64("searchContextID", sc.ID),
+				log.Int("added", added),
+				log.Int("removed", removed),
+			)
+		}
+	}
+
+	return err
+}
+
+func (h *handler) HandleError(err error) {
+	h.logger.Error("error syncing search context repositories", log.Error(err))
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/gitserver"
 	workermigrations "github.com/sourcegraph/sourcegraph/cmd/worker/internal/migrations"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/repostatistics"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/searchcontexts"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/webhooks"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/zoektrepos"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
@@ -42,13 +43,14 @@ func Start(observationCtx *observation.Context, additionalJobs map[string]job.Jo
 	registerMigrators := oobmigration.ComposeRegisterMigratorsFuncs(migrations.RegisterOSSMigrators, registerEnterpriseMigrators)
 
 	builtins := map[string]job.Job{
-		"webhook-log-janitor":       webhooks.NewJanitor(),
-		"out-of-band-migrations":    workermigrations.NewMigrator(registerMigrators),
-		"codeintel-crates-syncer":   codeintel.NewCratesSyncerJob(),
-		"gitserver-metrics":         gitserver.NewMetricsJob(),
-		"record-encrypter":          encryption.NewRecordEncrypterJob(),
-		"repo-statistics-compactor": repostatistics.NewCompactor(),
-		"zoekt-repos-updater":       zoektrepos.NewUpdater(),
+		"webhook-log-janitor":         webhooks.NewJanitor(),
+		"out-of-band-migrations":      workermigrations.NewMigrator(registerMigrators),
+		"codeintel-crates-syncer":     codeintel.NewCratesSyncerJob(),
+		"gitserver-metrics":           gitserver.NewMetricsJob(),
+		"record-encrypter":            encryption.NewRecordEncrypterJob(),
+		"repo-statistics-compactor":   repostatistics.NewCompactor(),
+		"zoekt-repos-updater":         zoektrepos.NewUpdater(),
+		"search-context-repos-syncer": searchcontexts.NewReposSyncer(),
 	}
 
 	jobs := map[string]job.Job{}
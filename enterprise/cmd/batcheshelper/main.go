@@ -63,6 +63,11 @@ func doMain() error {
 			return err
 		}
 
+	case "run":
+		if err := execNative(ctx, stepIdx, executionInput, previousResult); err != nil {
+			return err
+		}
+
 	case "post":
 		if err := execPost(ctx, stepIdx, executionInput, previousResult); err != nil {
 			return err
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/comby"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/batches/git"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const repoDir = "repository"
+
+// execNative runs a built-in step in-process on the workspace. It takes the
+// place of the container for native steps, and writes the same stdout and
+// stderr logs so that the post step can build the step result exactly like it
+// does for container steps.
+func execNative(ctx context.Context, stepIdx int, executionInput batcheslib.WorkspacesExecutionInput, previousResult execution.AfterStepResult) error {
+	step := executionInput.Steps[stepIdx]
+	if !step.Native() {
+		return errors.Newf("step %d is not a built-in step", stepIdx)
+	}
+
+	changes, err := git.ChangesInDiff(previousResult.Diff)
+	if err != nil {
+		return errors.Wrap(err, "failed to compute changes")
+	}
+
+	outputs := previousResult.Outputs
+	if outputs == nil {
+		outputs = make(map[string]any)
+	}
+	stepContext := template.StepContext{
+		BatchChange: executionInput.BatchChangeAttributes,
+		Repository: template.Repository{
+			Name:        executionInput.Repository.Name,
+			Branch:      executionInput.Branch.Name,
+			FileMatches: executionInput.SearchResultPaths,
+		},
+		Outputs: outputs,
+		Steps: template.StepsContext{
+			Path:    executionInput.Path,
+			Changes: changes,
+		},
+		PreviousStep: previousResult,
+	}
+
+	var stdout bytes.Buffer
+
+	// Check if the step needs to be skipped.
+	cond, err := template.EvalStepCondition(step.IfCondition(), &stepContext)
+	if err != nil {
+		return errors.Wrap(err, "failed to evaluate step condition")
+	}
+	if cond {
+		with, err := template.RenderStepMap(step.With, &stepContext)
+		if err != nil {
+			return errors.Wrap(err, "failed to render step.with")
+		}
+
+		dir := filepath.Join(repoDir, executionInput.Path)
+		if err := runNativeStep(ctx, step.Uses, with, dir, io.MultiWriter(&stdout, os.Stdout)); err != nil {
+			return errors.Wrapf(err, "built-in step %q failed", step.Uses)
+		}
+	}
+
+	if err := os.WriteFile(fmt.Sprintf("stdout%d.log", stepIdx), stdout.Bytes(), 0644); err != nil {
+		return errors.Wrap(err, "failed to write stdout file")
+	}
+	if err := os.WriteFile(fmt.Sprintf("stderr%d.log", stepIdx), nil, 0644); err != nil {
+		return errors.Wrap(err, "failed to write stderr file")
+	}
+
+	return nil
+}
+
+// runNativeStep runs the built-in step in the workspace directory dir. The
+// paths of all files it changed are written to stdout, one per line.
+func runNativeStep(ctx context.Context, uses batcheslib.NativeStep, with map[string]string, dir string, stdout io.Writer) error {
+	var (
+		changed []string
+		err     error
+	)
+	switch uses {
+	case batcheslib.NativeStepReplace:
+		changed, err = runReplace(dir, with["pattern"], with["replacement"], batcheslib.NativeStepFilePatterns(with["files"]))
+	case batcheslib.NativeStepComby:
+		changed, err = runComby(ctx, dir, comby.Args{
+			MatchTemplate:   with["match"],
+			RewriteTemplate: with["rewrite"],
+			Rule:            with["rule"],
+			Matcher:         with["matcher"],
+			FilePatterns:    batcheslib.NativeStepFilePatterns(with["files"]),
+		})
+	case batcheslib.NativeStepWriteFile:
+		changed, err = runWriteFile(dir, with["path"], with["content"])
+	default:
+		return errors.Newf("unknown built-in step %q", uses)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, path := range changed {
+		if _, err := fmt.Fprintln(stdout, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runReplace replaces all matches of the regular expression pattern in the
+// files in dir. It uses the same regexp engine and expansion as the replace
+// command of compute, so replacement may refer to capture groups with $1 or
+// ${name}. The compute package itself depends on the database and gitserver,
+// which are not available to batcheshelper.
+func runReplace(dir, pattern, replacement string, filePatterns []string) ([]string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid pattern")
+	}
+
+	var changed []string
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || !matchesFilePatterns(path, filePatterns) {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if isBinary(content) {
+			return nil
+		}
+
+		newContent := re.ReplaceAll(content, []byte(replacement))
+		if bytes.Equal(content, newContent) {
+			return nil
+		}
+		if err := writeFilePreservingMode(path, newContent); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		changed = append(changed, rel)
+		return nil
+	})
+	return changed, err
+}
+
+// runComby rewrites the files in dir with comby. The input and result kind of
+// args are set by runComby.
+func runComby(ctx context.Context, dir string, args comby.Args) ([]string, error) {
+	if !comby.Exists() {
+		return nil, errors.New("comby is not installed")
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	args.Input = comby.DirPath(absDir)
+	args.ResultKind = comby.Replacement
+
+	replacements, err := comby.Replacements(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, r := range replacements {
+		path := r.URI
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(absDir, path)
+		}
+		rel, err := filepath.Rel(absDir, path)
+		if err != nil {
+			return nil, err
+		}
+		if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || strings.HasPrefix(rel, ".git"+string(filepath.Separator)) {
+			continue
+		}
+		if err := checkNoSymlinkEscape(absDir, path); err != nil {
+			return nil, err
+		}
+
+		if err := writeFilePreservingMode(path, []byte(r.Content)); err != nil {
+			return nil, err
+		}
+		changed = append(changed, rel)
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// runWriteFile writes content to the file at path in dir, creating any missing
+// parent directories.
+func runWriteFile(dir, path, content string) ([]string, error) {
+	p, err := batcheslib.WorkspaceFilePath(dir, path)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNoSymlinkEscape(dir, p); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return nil, errors.Wrap(err, "creating parent directories")
+	}
+	if err := writeFilePreservingMode(p, []byte(content)); err != nil {
+		return nil, err
+	}
+
+	rel, err := filepath.Rel(dir, p)
+	if err != nil {
+		return nil, err
+	}
+	return []string{rel}, nil
+}
+
+// checkNoSymlinkEscape returns an error if path, which lies inside dir, would
+// resolve to a location outside of dir by following symbolic links. Both the
+// parent directories and the file itself may be links in the repository.
+func checkNoSymlinkEscape(dir, path string) error {
+	resolvedDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	resolvedDir, err = filepath.Abs(resolvedDir)
+	if err != nil {
+		return err
+	}
+	resolved, err := evalExistingSymlinks(path)
+	if err != nil {
+		return err
+	}
+	resolved, err = filepath.Abs(resolved)
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(resolvedDir, resolved)
+	if err != nil {
+		return err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return errors.Newf("path %q resolves to a location outside of the workspace", path)
+	}
+	return nil
+}
+
+// evalExistingSymlinks resolves the symbolic links in the longest existing
+// prefix of path, and appends the remaining elements that don't exist yet.
+// A dangling link is an error, since writing to it would create its target.
+func evalExistingSymlinks(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err == nil {
+		return resolved, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+	if _, err := os.Lstat(path); err == nil {
+		return "", errors.Newf("path %q is a symbolic link to a missing file", path)
+	}
+
+	parent := filepath.Dir(path)
+	if parent == path {
+		return path, nil
+	}
+	resolvedParent, err := evalExistingSymlinks(parent)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(resolvedParent, filepath.Base(path)), nil
+}
+
+// writeFilePreservingMode writes content to path, keeping the file mode of an
+// existing file so that the diff doesn't contain mode changes.
+func writeFilePreservingMode(path string, content []byte) error {
+	mode := fs.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	return os.WriteFile(path, content, mode)
+}
+
+// matchesFilePatterns returns true if path ends with one of the given
+// patterns, or if there are no patterns. This matches how comby filters files.
+func matchesFilePatterns(path string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if strings.HasSuffix(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBinary uses the same heuristic as git: a file is binary if it contains a
+// NUL byte in its first 8000 bytes.
+func isBinary(content []byte) bool {
+	if len(content) > 8000 {
+		content = content[:8000]
+	}
+	return bytes.IndexByte(content, 0) != -1
+}
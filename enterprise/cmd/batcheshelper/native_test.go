@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/comby"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestRunNativeStep(t *testing.T) {
+	setup := func(t *testing.T) string {
+		dir := t.TempDir()
+		for path, content := range map[string]string{
+			"main.go":         "package main\n\nfunc main() { oldFunc(1); oldFunc(2) }\n",
+			"sub/util.go":     "package sub\n\nvar f = oldFunc\n",
+			"README.md":       "Call oldFunc(x) to do things.\n",
+			"binary.bin":      "oldFunc\x00",
+			".git/HEAD":       "oldFunc\n",
+			"script.sh":       "#!/bin/sh\noldFunc\n",
+			"unchanged/a.txt": "nothing to see here\n",
+		} {
+			p := filepath.Join(dir, path)
+			if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := os.Chmod(filepath.Join(dir, "script.sh"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+
+	readFile := func(t *testing.T, path string) string {
+		t.Helper()
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+
+	t.Run("replace", func(t *testing.T) {
+		dir := setup(t)
+
+		var stdout bytes.Buffer
+		err := runNativeStep(context.Background(), batcheslib.NativeStepReplace, map[string]string{
+			"pattern":     `oldFunc(\(\w*)`,
+			"replacement": "newFunc$1",
+		}, dir, &stdout)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff("README.md\nmain.go\n", stdout.String()); diff != "" {
+			t.Errorf("unexpected stdout (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff("package main\n\nfunc main() { newFunc(1); newFunc(2) }\n", readFile(t, filepath.Join(dir, "main.go"))); diff != "" {
+			t.Errorf("unexpected content (-want +got):\n%s", diff)
+		}
+		if have := readFile(t, filepath.Join(dir, ".git/HEAD")); have != "oldFunc\n" {
+			t.Errorf(".git was modified: %q", have)
+		}
+		if have := readFile(t, filepath.Join(dir, "binary.bin")); have != "oldFunc\x00" {
+			t.Errorf("binary file was modified: %q", have)
+		}
+	})
+
+	t.Run("replace with file patterns", func(t *testing.T) {
+		dir := setup(t)
+
+		var stdout bytes.Buffer
+		err := runNativeStep(context.Background(), batcheslib.NativeStepReplace, map[string]string{
+			"pattern":     "oldFunc",
+			"replacement": "newFunc",
+			"files":       ".go,.sh",
+		}, dir, &stdout)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff("main.go\nscript.sh\nsub/util.go\n", stdout.String()); diff != "" {
+			t.Errorf("unexpected stdout (-want +got):\n%s", diff)
+		}
+		info, err := os.Stat(filepath.Join(dir, "script.sh"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0755 {
+			t.Errorf("file mode not preserved: %s", info.Mode())
+		}
+	})
+
+	t.Run("replace with invalid pattern", func(t *testing.T) {
+		err := runNativeStep(context.Background(), batcheslib.NativeStepReplace, map[string]string{
+			"pattern": "oldFunc(",
+		}, setup(t), &bytes.Buffer{})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("write-file", func(t *testing.T) {
+		dir := setup(t)
+
+		var stdout bytes.Buffer
+		err := runNativeStep(context.Background(), batcheslib.NativeStepWriteFile, map[string]string{
+			"path":    "docs/new/file.md",
+			"content": "Hello World\n",
+		}, dir, &stdout)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff("docs/new/file.md\n", stdout.String()); diff != "" {
+			t.Errorf("unexpected stdout (-want +got):\n%s", diff)
+		}
+		if have := readFile(t, filepath.Join(dir, "docs/new/file.md")); have != "Hello World\n" {
+			t.Errorf("unexpected content: %q", have)
+		}
+	})
+
+	t.Run("write-file outside of workspace", func(t *testing.T) {
+		err := runNativeStep(context.Background(), batcheslib.NativeStepWriteFile, map[string]string{
+			"path":    "../escape.txt",
+			"content": "nope",
+		}, setup(t), &bytes.Buffer{})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("write-file through symbolic link", func(t *testing.T) {
+		outside := t.TempDir()
+
+		for name, tc := range map[string]struct {
+			target string
+			path   string
+		}{
+			"parent directory": {target: outside, path: "link/escape.txt"},
+			"dangling file":    {target: filepath.Join(outside, "missing.txt"), path: "link"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				dir := setup(t)
+				if err := os.Symlink(tc.target, filepath.Join(dir, "link")); err != nil {
+					t.Fatal(err)
+				}
+
+				err := runNativeStep(context.Background(), batcheslib.NativeStepWriteFile, map[string]string{
+					"path":    tc.path,
+					"content": "nope",
+				}, dir, &bytes.Buffer{})
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+			})
+		}
+
+		entries, err := os.ReadDir(outside)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 0 {
+			t.Fatalf("expected no files to be written outside of the workspace, got %d", len(entries))
+		}
+	})
+
+	t.Run("write-file through symbolic link inside workspace", func(t *testing.T) {
+		dir := setup(t)
+		if err := os.Symlink("sub", filepath.Join(dir, "link")); err != nil {
+			t.Fatal(err)
+		}
+
+		err := runNativeStep(context.Background(), batcheslib.NativeStepWriteFile, map[string]string{
+			"path":    "link/new.go",
+			"content": "package sub\n",
+		}, dir, &bytes.Buffer{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have := readFile(t, filepath.Join(dir, "sub/new.go")); have != "package sub\n" {
+			t.Errorf("unexpected content: %q", have)
+		}
+	})
+
+	t.Run("comby", func(t *testing.T) {
+		if !comby.Exists() {
+			t.Skip("comby is not installed")
+		}
+		dir := setup(t)
+
+		var stdout bytes.Buffer
+		err := runNativeStep(context.Background(), batcheslib.NativeStepComby, map[string]string{
+			"match":   "oldFunc(:[arg])",
+			"rewrite": "newFunc(:[arg], nil)",
+			"files":   ".go",
+		}, dir, &stdout)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff("main.go\n", stdout.String()); diff != "" {
+			t.Errorf("unexpected stdout (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff("package main\n\nfunc main() { newFunc(1, nil); newFunc(2, nil) }\n", readFile(t, filepath.Join(dir, "main.go"))); diff != "" {
+			t.Errorf("unexpected content (-want +got):\n%s", diff)
+		}
+	})
+}
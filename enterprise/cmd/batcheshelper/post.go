@@ -130,7 +130,7 @@ func execPost(ctx context.Context, stepIdx int, executionInput batcheslib.Worksp
 
 func runGitCmd(ctx context.Context, args ...string) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
-	cmd.Dir = "repository"
+	cmd.Dir = repoDir
 
 	return cmd.Output()
 }
@@ -83,8 +83,8 @@ func Init(
 
 		_, _, _, _, invalidConnections := eiauthz.ProvidersFromConfig(ctx, conf.Get(), extsvcStore, db)
 
-		// We currently support three types of authz providers: GitHub, GitLab and Bitbucket Server.
-		authzTypes := make(map[string]struct{}, 3)
+		// We currently support four types of authz providers: GitHub, GitLab, Bitbucket Server and Bitbucket Cloud.
+		authzTypes := make(map[string]struct{}, 4)
 		for _, conn := range invalidConnections {
 			authzTypes[conn] = struct{}{}
 		}
@@ -98,6 +98,8 @@ func Init(
 				authzNames = append(authzNames, "GitLab")
 			case extsvc.TypeBitbucketServer:
 				authzNames = append(authzNames, "Bitbucket Server")
+			case extsvc.TypeBitbucketCloud:
+				authzNames = append(authzNames, "Bitbucket Cloud")
 			default:
 				authzNames = append(authzNames, t)
 			}
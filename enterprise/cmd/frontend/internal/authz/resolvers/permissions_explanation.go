@@ -0,0 +1,593 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	mechanismSiteAdmin               = "SITE_ADMIN"
+	mechanismNoAuthzProviders        = "NO_AUTHZ_PROVIDERS"
+	mechanismPublic                  = "PUBLIC"
+	mechanismUnrestricted            = "UNRESTRICTED"
+	mechanismUnrestrictedCodeHost    = "UNRESTRICTED_CODE_HOST"
+	mechanismExplicitPermissions     = "EXPLICIT_PERMISSIONS"
+	mechanismPendingPermissions      = "PENDING_PERMISSIONS"
+	mechanismSubRepositoryPermission = "SUB_REPOSITORY_PERMISSIONS"
+)
+
+// maxRecentSyncJobs is the number of sync job records that are searched for
+// the ones of the explained user and repository.
+const maxRecentSyncJobs = 500
+
+// resyncPollInterval is how often ResyncRepositoryPermissions checks whether
+// the syncs it scheduled have completed.
+var resyncPollInterval = time.Second
+
+// maxResyncTimeoutSeconds bounds how long ResyncRepositoryPermissions holds
+// the request open. Syncs that take longer can be followed by polling
+// ExplainRepositoryPermissions.
+const maxResyncTimeoutSeconds = 10
+
+func (r *Resolver) ExplainRepositoryPermissions(ctx context.Context, args *graphqlbackend.ExplainRepositoryPermissionsArgs) (graphqlbackend.RepositoryPermissionsExplanationResolver, error) {
+	if err := r.checkLicense(licensing.FeatureACLs); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins can query repository permissions.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	user, repo, err := r.explanationSubjects(ctx, args.User, args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	return r.explainRepositoryPermissions(ctx, user, repo, args.Path)
+}
+
+func (r *Resolver) ResyncRepositoryPermissions(ctx context.Context, args *graphqlbackend.ResyncRepositoryPermissionsArgs) (graphqlbackend.RepositoryPermissionsResyncResolver, error) {
+	if err := r.checkLicense(licensing.FeatureACLs); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins can sync repository permissions.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	if args.TimeoutSeconds < 0 || args.TimeoutSeconds > maxResyncTimeoutSeconds {
+		return nil, errors.Errorf("timeoutSeconds must be between 0 and %d, got %d", maxResyncTimeoutSeconds, args.TimeoutSeconds)
+	}
+
+	user, repo, err := r.explanationSubjects(ctx, args.User, args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := r.explainRepositoryPermissions(ctx, user, repo, args.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only repositories with an authorization provider are synced repo-centric.
+	waitForRepo := !globals.PermissionsUserMapping().Enabled && providerForRepo(repo) != nil
+
+	// Sync times are truncated by the database, don't miss a sync that
+	// completes within the same microsecond.
+	scheduledAt := time.Now().Truncate(time.Microsecond)
+	err = r.repoupdaterClient.SchedulePermsSync(ctx, protocol.PermsSyncRequest{
+		UserIDs: []int32{user.ID},
+		RepoIDs: []api.RepoID{repo.ID},
+		Options: authz.FetchPermsOptions{InvalidateCaches: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	completed, err := r.waitForPermsSyncs(ctx, user.ID, repo.ID, waitForRepo, scheduledAt, time.Duration(args.TimeoutSeconds)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := r.explainRepositoryPermissions(ctx, user, repo, args.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositoryPermissionsResyncResolver{
+		completed:      completed,
+		before:         before,
+		after:          after,
+		addedReasons:   diffReasons(after.reasons, before.reasons),
+		removedReasons: diffReasons(before.reasons, after.reasons),
+	}, nil
+}
+
+// waitForPermsSyncs polls the permissions of the user, and of the repository
+// if waitForRepo is true, until they have been synced since the given time.
+// It returns false if that didn't happen before the timeout.
+func (r *Resolver) waitForPermsSyncs(ctx context.Context, userID int32, repoID api.RepoID, waitForRepo bool, since time.Time, timeout time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	userSynced, repoSynced := false, !waitForRepo
+	for {
+		if !userSynced {
+			p := &authz.UserPermissions{UserID: userID, Perm: authz.Read, Type: authz.PermRepos}
+			err := r.db.Perms().LoadUserPermissions(ctx, p)
+			if err != nil && err != authz.ErrPermsNotFound {
+				if ctx.Err() != nil {
+					return false, nil
+				}
+				return false, errors.Wrap(err, "load user permissions")
+			}
+			userSynced = err == nil && !p.SyncedAt.Before(since)
+		}
+		if !repoSynced {
+			p := &authz.RepoPermissions{RepoID: int32(repoID), Perm: authz.Read}
+			err := r.db.Perms().LoadRepoPermissions(ctx, p)
+			if err != nil && err != authz.ErrPermsNotFound {
+				if ctx.Err() != nil {
+					return false, nil
+				}
+				return false, errors.Wrap(err, "load repository permissions")
+			}
+			repoSynced = err == nil && !p.SyncedAt.Before(since)
+		}
+		if userSynced && repoSynced {
+			return true, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case <-time.After(resyncPollInterval):
+		}
+	}
+}
+
+// explanationSubjects returns the user and the repository with the given
+// GraphQL IDs.
+func (r *Resolver) explanationSubjects(ctx context.Context, userID, repoID graphql.ID) (*types.User, *types.Repo, error) {
+	uid, err := graphqlbackend.UnmarshalUserID(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := r.db.Users().GetByID(ctx, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rid, err := graphqlbackend.UnmarshalRepositoryID(repoID)
+	if err != nil {
+		return nil, nil, err
+	}
+	repo, err := r.db.Repos().Get(ctx, rid)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, repo, nil
+}
+
+// providerForRepo returns the authorization provider of the code host of the
+// given repository, or nil if there is none.
+func providerForRepo(repo *types.Repo) authz.Provider {
+	_, providers := authz.GetProviders()
+	for _, p := range providers {
+		if p.ServiceID() == repo.ExternalRepo.ServiceID {
+			return p
+		}
+	}
+	return nil
+}
+
+// explainRepositoryPermissions evaluates every mechanism that can grant or
+// deny the user access to the repository, the same way as
+// database.AuthzQueryConds and the sub-repository permissions checker do.
+func (r *Resolver) explainRepositoryPermissions(ctx context.Context, user *types.User, repo *types.Repo, path *string) (*repositoryPermissionsExplanationResolver, error) {
+	e := &repositoryPermissionsExplanationResolver{
+		user: graphqlbackend.NewUserResolver(r.db, user),
+		repo: graphqlbackend.NewRepositoryResolver(r.db, gitserver.NewClient(r.db), repo),
+		path: path,
+	}
+
+	authzAllowByDefault, providers := authz.GetProviders()
+	usePermissionsUserMapping := globals.PermissionsUserMapping().Enabled
+	if usePermissionsUserMapping {
+		authzAllowByDefault = false
+	}
+
+	if user.SiteAdmin {
+		if conf.Get().AuthzEnforceForSiteAdmins {
+			e.addReason(mechanismSiteAdmin, false, "The user is a site admin, but authz.enforceForSiteAdmins is set, so permissions are enforced for site admins.")
+		} else {
+			e.addReason(mechanismSiteAdmin, true, "The user is a site admin and site admins can access all repositories.")
+		}
+	}
+
+	if authzAllowByDefault && len(providers) == 0 {
+		e.addReason(mechanismNoAuthzProviders, true, "No authorization provider is configured, so all repositories are accessible.")
+	}
+
+	switch {
+	case usePermissionsUserMapping:
+		e.addReason(mechanismPublic, false, "Public repositories are not accessible to everyone when permissions.userMapping is enabled.")
+	case repo.Private:
+		e.addReason(mechanismPublic, false, "The repository is private.")
+	default:
+		e.addReason(mechanismPublic, true, "The repository is public.")
+	}
+
+	repoPerms := &authz.RepoPermissions{RepoID: int32(repo.ID), Perm: authz.Read}
+	if err := r.db.Perms().LoadRepoPermissions(ctx, repoPerms); err != nil && err != authz.ErrPermsNotFound {
+		return nil, errors.Wrap(err, "load repository permissions")
+	}
+	if repoPerms.Unrestricted {
+		e.addReason(mechanismUnrestricted, true, "The repository has been marked as unrestricted.")
+	} else {
+		e.addReason(mechanismUnrestricted, false, "The repository has not been marked as unrestricted.")
+	}
+
+	if !usePermissionsUserMapping {
+		if err := r.explainUnrestrictedCodeHost(ctx, e, repo); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.explainExplicitPermissions(ctx, e, user, repo, repoPerms, usePermissionsUserMapping); err != nil {
+		return nil, err
+	}
+
+	e.canRead = false
+	for _, reason := range e.reasons {
+		if reason.grantsAccess {
+			e.canRead = true
+			break
+		}
+	}
+
+	if err := r.explainPendingPermissions(ctx, e, user, repo); err != nil {
+		return nil, err
+	}
+
+	if path != nil {
+		if err := r.explainSubRepoPermissions(ctx, e, user, repo, *path); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.syncJobsRecords != nil {
+		records, err := r.syncJobsRecords.GetAll(ctx, maxRecentSyncJobs)
+		if err != nil {
+			return nil, errors.Wrap(err, "get sync jobs records")
+		}
+		sort.Slice(records, func(i, j int) bool { return records[i].Completed.After(records[j].Completed) })
+		for _, j := range records {
+			if (j.JobType == "user" && j.JobID == user.ID) || (j.JobType == "repo" && j.JobID == int32(repo.ID)) {
+				e.recentSyncJobs = append(e.recentSyncJobs, permissionsSyncJobResolver{j})
+			}
+		}
+	}
+
+	return e, nil
+}
+
+func (r *Resolver) explainUnrestrictedCodeHost(ctx context.Context, e *repositoryPermissionsExplanationResolver, repo *types.Repo) error {
+	ids := make([]int64, 0, len(repo.Sources))
+	for urn := range repo.Sources {
+		if _, id := extsvc.DecodeURN(urn); id != 0 {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	svcs, err := r.db.ExternalServices().List(ctx, database.ExternalServicesListOptions{IDs: ids})
+	if err != nil {
+		return errors.Wrap(err, "list external services")
+	}
+	for _, svc := range svcs {
+		if svc.Unrestricted {
+			e.addReason(mechanismUnrestrictedCodeHost, true, fmt.Sprintf("The repository belongs to the code host connection %q, which has unrestricted access.", svc.DisplayName))
+			return nil
+		}
+	}
+	e.addReason(mechanismUnrestrictedCodeHost, false, "None of the code host connections of the repository have unrestricted access.")
+	return nil
+}
+
+func (r *Resolver) explainExplicitPermissions(ctx context.Context, e *repositoryPermissionsExplanationResolver, user *types.User, repo *types.Repo, repoPerms *authz.RepoPermissions, usePermissionsUserMapping bool) error {
+	userPerms := &authz.UserPermissions{UserID: user.ID, Perm: authz.Read, Type: authz.PermRepos}
+	err := r.db.Perms().LoadUserPermissions(ctx, userPerms)
+	if err != nil && err != authz.ErrPermsNotFound {
+		return errors.Wrap(err, "load user permissions")
+	}
+	_, granted := userPerms.IDs[int32(repo.ID)]
+
+	// The most recent of the user-centric and repo-centric syncs.
+	syncedAt := userPerms.SyncedAt
+	if repoPerms.SyncedAt.After(syncedAt) {
+		syncedAt = repoPerms.SyncedAt
+	}
+
+	if usePermissionsUserMapping {
+		reason := e.addReason(mechanismExplicitPermissions, granted, "")
+		reason.providerType, reason.providerID = authz.SourcegraphServiceType, authz.SourcegraphServiceID
+		reason.syncedAt = syncedAt
+		if granted {
+			reason.message = "The user has been granted access through the permissions API."
+		} else {
+			reason.message = "The user has not been granted access through the permissions API."
+		}
+		return nil
+	}
+
+	provider := providerForRepo(repo)
+	if provider == nil {
+		reason := e.addReason(mechanismExplicitPermissions, granted, "")
+		if granted {
+			reason.message = "The user has been granted access explicitly."
+		} else {
+			reason.message = fmt.Sprintf("No authorization provider is configured for the code host %q of the repository.", repo.ExternalRepo.ServiceID)
+		}
+		return nil
+	}
+
+	reason := e.addReason(mechanismExplicitPermissions, granted, "")
+	reason.providerType, reason.providerID = provider.ServiceType(), provider.ServiceID()
+	reason.syncedAt = syncedAt
+	if granted {
+		reason.message = fmt.Sprintf("The code host %q granted the user access during the last permissions sync.", provider.ServiceID())
+		return nil
+	}
+
+	accounts, err := r.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		UserID:         user.ID,
+		ServiceType:    provider.ServiceType(),
+		ServiceID:      provider.ServiceID(),
+		ExcludeExpired: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "list external accounts")
+	}
+	if len(accounts) == 0 {
+		reason.message = fmt.Sprintf("The user has no external account on the code host %q, so their permissions to its repositories cannot be synced.", provider.ServiceID())
+	} else {
+		reason.message = fmt.Sprintf("The code host %q did not grant the user access during the last permissions sync.", provider.ServiceID())
+	}
+	return nil
+}
+
+// explainPendingPermissions reports access that has been granted to any of
+// the bind IDs of the user that database.AuthzStore.GrantPendingPermissions
+// considers, but that has not been granted to the user yet.
+func (r *Resolver) explainPendingPermissions(ctx context.Context, e *repositoryPermissionsExplanationResolver, user *types.User, repo *types.Repo) error {
+	var pending []*authz.UserPendingPermissions
+
+	accounts, err := r.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		UserID:         user.ID,
+		ExcludeExpired: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "list external accounts")
+	}
+	for _, acct := range accounts {
+		pending = append(pending, &authz.UserPendingPermissions{
+			ServiceType: acct.ServiceType,
+			ServiceID:   acct.ServiceID,
+			BindID:      acct.AccountID,
+		})
+	}
+
+	if globals.PermissionsUserMapping().Enabled {
+		switch globals.PermissionsUserMapping().BindID {
+		case "email":
+			emails, err := r.db.UserEmails().ListByUser(ctx, database.UserEmailsListOptions{
+				UserID:       user.ID,
+				OnlyVerified: true,
+			})
+			if err != nil {
+				return errors.Wrap(err, "list verified emails")
+			}
+			for _, email := range emails {
+				pending = append(pending, &authz.UserPendingPermissions{
+					ServiceType: authz.SourcegraphServiceType,
+					ServiceID:   authz.SourcegraphServiceID,
+					BindID:      email.Email,
+				})
+			}
+		case "username":
+			pending = append(pending, &authz.UserPendingPermissions{
+				ServiceType: authz.SourcegraphServiceType,
+				ServiceID:   authz.SourcegraphServiceID,
+				BindID:      user.Username,
+			})
+		}
+	}
+
+	for _, p := range pending {
+		p.Perm, p.Type = authz.Read, authz.PermRepos
+		err := r.db.Perms().LoadUserPendingPermissions(ctx, p)
+		if err == authz.ErrPermsNotFound {
+			continue
+		} else if err != nil {
+			return errors.Wrap(err, "load user pending permissions")
+		}
+		if _, ok := p.IDs[int32(repo.ID)]; !ok {
+			continue
+		}
+
+		reason := e.addReason(mechanismPendingPermissions, false, fmt.Sprintf("Access has been granted to %q, but has not been applied to the user yet. Pending permissions are applied when the user signs in or verifies an email address.", p.BindID))
+		reason.providerType, reason.providerID = p.ServiceType, p.ServiceID
+	}
+	return nil
+}
+
+func (r *Resolver) explainSubRepoPermissions(ctx context.Context, e *repositoryPermissionsExplanationResolver, user *types.User, repo *types.Repo, path string) error {
+	checker := authz.DefaultSubRepoPermsChecker
+	enabled, err := authz.SubRepoEnabledForRepoID(ctx, checker, repo.ID)
+	if err != nil {
+		return errors.Wrap(err, "check whether sub-repository permissions are enabled")
+	}
+	if !enabled {
+		return nil
+	}
+
+	perms, err := checker.Permissions(ctx, user.ID, authz.RepoContent{Repo: repo.Name, Path: path})
+	if err != nil {
+		return errors.Wrap(err, "get sub-repository permissions")
+	}
+	if perms.Include(authz.Read) {
+		e.addReason(mechanismSubRepositoryPermission, true, fmt.Sprintf("Sub-repository permissions allow the user to read %q.", path))
+		return nil
+	}
+
+	e.addReason(mechanismSubRepositoryPermission, false, fmt.Sprintf("Sub-repository permissions do not allow the user to read %q.", path))
+	e.canRead = false
+	return nil
+}
+
+// diffReasons returns the reasons of a that are not in b, ignoring sync times.
+func diffReasons(a, b []*repositoryPermissionsReasonResolver) []graphqlbackend.RepositoryPermissionsReasonResolver {
+	type key struct{ mechanism, message, providerType, providerID string }
+	keyOf := func(r *repositoryPermissionsReasonResolver) key {
+		return key{r.mechanism, r.message, r.providerType, r.providerID}
+	}
+
+	inB := make(map[key]bool, len(b))
+	for _, r := range b {
+		inB[keyOf(r)] = r.grantsAccess
+	}
+
+	diff := []graphqlbackend.RepositoryPermissionsReasonResolver{}
+	for _, r := range a {
+		if grants, ok := inB[keyOf(r)]; !ok || grants != r.grantsAccess {
+			diff = append(diff, r)
+		}
+	}
+	return diff
+}
+
+type repositoryPermissionsExplanationResolver struct {
+	user           *graphqlbackend.UserResolver
+	repo           *graphqlbackend.RepositoryResolver
+	path           *string
+	canRead        bool
+	reasons        []*repositoryPermissionsReasonResolver
+	recentSyncJobs []graphqlbackend.PermissionsSyncJobResolver
+}
+
+var _ graphqlbackend.RepositoryPermissionsExplanationResolver = &repositoryPermissionsExplanationResolver{}
+
+func (r *repositoryPermissionsExplanationResolver) addReason(mechanism string, grantsAccess bool, message string) *repositoryPermissionsReasonResolver {
+	reason := &repositoryPermissionsReasonResolver{
+		mechanism:    mechanism,
+		grantsAccess: grantsAccess,
+		message:      message,
+	}
+	r.reasons = append(r.reasons, reason)
+	return reason
+}
+
+func (r *repositoryPermissionsExplanationResolver) User() *graphqlbackend.UserResolver { return r.user }
+func (r *repositoryPermissionsExplanationResolver) Repository() *graphqlbackend.RepositoryResolver {
+	return r.repo
+}
+func (r *repositoryPermissionsExplanationResolver) Path() *string { return r.path }
+func (r *repositoryPermissionsExplanationResolver) CanRead() bool { return r.canRead }
+
+func (r *repositoryPermissionsExplanationResolver) Reasons() []graphqlbackend.RepositoryPermissionsReasonResolver {
+	reasons := make([]graphqlbackend.RepositoryPermissionsReasonResolver, 0, len(r.reasons))
+	for _, reason := range r.reasons {
+		reasons = append(reasons, reason)
+	}
+	return reasons
+}
+
+func (r *repositoryPermissionsExplanationResolver) RecentSyncJobs() []graphqlbackend.PermissionsSyncJobResolver {
+	if r.recentSyncJobs == nil {
+		return []graphqlbackend.PermissionsSyncJobResolver{}
+	}
+	return r.recentSyncJobs
+}
+
+type repositoryPermissionsReasonResolver struct {
+	mechanism    string
+	grantsAccess bool
+	message      string
+	providerType string
+	providerID   string
+	syncedAt     time.Time
+}
+
+var _ graphqlbackend.RepositoryPermissionsReasonResolver = &repositoryPermissionsReasonResolver{}
+
+func (r *repositoryPermissionsReasonResolver) Mechanism() string  { return r.mechanism }
+func (r *repositoryPermissionsReasonResolver) GrantsAccess() bool { return r.grantsAccess }
+func (r *repositoryPermissionsReasonResolver) Message() string    { return r.message }
+
+func (r *repositoryPermissionsReasonResolver) ProviderType() *string {
+	if r.providerType == "" {
+		return nil
+	}
+	return &r.providerType
+}
+
+func (r *repositoryPermissionsReasonResolver) ProviderID() *string {
+	if r.providerID == "" {
+		return nil
+	}
+	return &r.providerID
+}
+
+func (r *repositoryPermissionsReasonResolver) SyncedAt() *gqlutil.DateTime {
+	if r.syncedAt.IsZero() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: r.syncedAt}
+}
+
+type repositoryPermissionsResyncResolver struct {
+	completed      bool
+	before         *repositoryPermissionsExplanationResolver
+	after          *repositoryPermissionsExplanationResolver
+	addedReasons   []graphqlbackend.RepositoryPermissionsReasonResolver
+	removedReasons []graphqlbackend.RepositoryPermissionsReasonResolver
+}
+
+var _ graphqlbackend.RepositoryPermissionsResyncResolver = &repositoryPermissionsResyncResolver{}
+
+func (r *repositoryPermissionsResyncResolver) Completed() bool { return r.completed }
+func (r *repositoryPermissionsResyncResolver) Before() graphqlbackend.RepositoryPermissionsExplanationResolver {
+	return r.before
+}
+func (r *repositoryPermissionsResyncResolver) After() graphqlbackend.RepositoryPermissionsExplanationResolver {
+	return r.after
+}
+func (r *repositoryPermissionsResyncResolver) AddedReasons() []graphqlbackend.RepositoryPermissionsReasonResolver {
+	return r.addedReasons
+}
+func (r *repositoryPermissionsResyncResolver) RemovedReasons() []graphqlbackend.RepositoryPermissionsReasonResolver {
+	return r.removedReasons
+}
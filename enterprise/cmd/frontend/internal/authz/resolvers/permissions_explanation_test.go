@@ -0,0 +1,205 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/github"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type explainedReason struct {
+	mechanism    string
+	grantsAccess bool
+	providerID   string
+}
+
+func explainedReasons(reasons []graphqlbackend.RepositoryPermissionsReasonResolver) []explainedReason {
+	got := make([]explainedReason, 0, len(reasons))
+	for _, r := range reasons {
+		reason := explainedReason{mechanism: r.Mechanism(), grantsAccess: r.GrantsAccess()}
+		if id := r.ProviderID(); id != nil {
+			reason.providerID = *id
+		}
+		got = append(got, reason)
+	}
+	return got
+}
+
+func setupExplanationMocks(t *testing.T) (*edb.MockEnterpriseDB, *edb.MockPermsStore) {
+	t.Helper()
+
+	ghProvider := github.NewProvider("https://github.com", github.ProviderOptions{GitHubURL: mustURL(t, "https://github.com")})
+	authz.SetProviders(false, []authz.Provider{ghProvider})
+	t.Cleanup(func() { authz.SetProviders(true, nil) })
+
+	users := database.NewStrictMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+	users.GetByIDFunc.SetDefaultReturn(&types.User{ID: 2, Username: "alice"}, nil)
+
+	repos := database.NewStrictMockRepoStore()
+	repos.GetFunc.SetDefaultReturn(&types.Repo{
+		ID:      3,
+		Name:    "github.com/acme/secret",
+		Private: true,
+		ExternalRepo: api.ExternalRepoSpec{
+			ServiceType: extsvc.TypeGitHub,
+			ServiceID:   "https://github.com/",
+		},
+	}, nil)
+
+	externalAccounts := database.NewStrictMockUserExternalAccountsStore()
+	externalAccounts.ListFunc.SetDefaultReturn([]*extsvc.Account{{
+		UserID:      2,
+		AccountSpec: extsvc.AccountSpec{ServiceType: extsvc.TypeGitHub, ServiceID: "https://github.com/", AccountID: "alice"},
+	}}, nil)
+
+	perms := edb.NewStrictMockPermsStore()
+	perms.LoadRepoPermissionsFunc.SetDefaultReturn(authz.ErrPermsNotFound)
+	perms.LoadUserPendingPermissionsFunc.SetDefaultReturn(authz.ErrPermsNotFound)
+
+	db := edb.NewStrictMockEnterpriseDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.UserExternalAccountsFunc.SetDefaultReturn(externalAccounts)
+	db.PermsFunc.SetDefaultReturn(perms)
+	return db, perms
+}
+
+func TestResolver_ExplainRepositoryPermissions(t *testing.T) {
+	t.Cleanup(licensing.TestingSkipFeatureChecks())
+
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		users := database.NewStrictMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{}, nil)
+
+		db := edb.NewStrictMockEnterpriseDB()
+		db.UsersFunc.SetDefaultReturn(users)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&Resolver{db: db}).ExplainRepositoryPermissions(ctx, &graphqlbackend.ExplainRepositoryPermissionsArgs{})
+		if want := auth.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+		if result != nil {
+			t.Errorf("result: want nil but got %v", result)
+		}
+	})
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	args := &graphqlbackend.ExplainRepositoryPermissionsArgs{
+		User:       graphqlbackend.MarshalUserID(2),
+		Repository: graphqlbackend.MarshalRepositoryID(3),
+	}
+
+	t.Run("granted by a permissions sync", func(t *testing.T) {
+		db, perms := setupExplanationMocks(t)
+		perms.LoadUserPermissionsFunc.SetDefaultHook(func(_ context.Context, p *authz.UserPermissions) error {
+			p.IDs = map[int32]struct{}{3: {}}
+			p.SyncedAt = time.Now()
+			return nil
+		})
+
+		result, err := (&Resolver{db: db}).ExplainRepositoryPermissions(ctx, args)
+		require.NoError(t, err)
+		assert.True(t, result.CanRead())
+		assert.Equal(t, []explainedReason{
+			{mechanism: mechanismPublic},
+			{mechanism: mechanismUnrestricted},
+			{mechanism: mechanismExplicitPermissions, grantsAccess: true, providerID: "https://github.com/"},
+		}, explainedReasons(result.Reasons()))
+		assert.NotNil(t, result.Reasons()[2].SyncedAt())
+	})
+
+	t.Run("pending permissions", func(t *testing.T) {
+		db, perms := setupExplanationMocks(t)
+		perms.LoadUserPermissionsFunc.SetDefaultReturn(authz.ErrPermsNotFound)
+		perms.LoadUserPendingPermissionsFunc.SetDefaultHook(func(_ context.Context, p *authz.UserPendingPermissions) error {
+			p.IDs = map[int32]struct{}{3: {}}
+			return nil
+		})
+
+		result, err := (&Resolver{db: db}).ExplainRepositoryPermissions(ctx, args)
+		require.NoError(t, err)
+		assert.False(t, result.CanRead())
+		assert.Equal(t, []explainedReason{
+			{mechanism: mechanismPublic},
+			{mechanism: mechanismUnrestricted},
+			{mechanism: mechanismExplicitPermissions, providerID: "https://github.com/"},
+			{mechanism: mechanismPendingPermissions, providerID: "https://github.com/"},
+		}, explainedReasons(result.Reasons()))
+	})
+}
+
+func TestResolver_ResyncRepositoryPermissions(t *testing.T) {
+	t.Cleanup(licensing.TestingSkipFeatureChecks())
+
+	db, perms := setupExplanationMocks(t)
+
+	// The user gains access with the sync scheduled by the resolver.
+	var scheduled *protocol.PermsSyncRequest
+	var syncedAt time.Time
+	perms.LoadUserPermissionsFunc.SetDefaultHook(func(_ context.Context, p *authz.UserPermissions) error {
+		if scheduled == nil {
+			return authz.ErrPermsNotFound
+		}
+		p.IDs = map[int32]struct{}{3: {}}
+		p.SyncedAt = syncedAt
+		return nil
+	})
+	perms.LoadRepoPermissionsFunc.SetDefaultHook(func(_ context.Context, p *authz.RepoPermissions) error {
+		if scheduled == nil {
+			return authz.ErrPermsNotFound
+		}
+		p.UserIDs = map[int32]struct{}{2: {}}
+		p.SyncedAt = syncedAt
+		return nil
+	})
+
+	r := &Resolver{
+		db: db,
+		repoupdaterClient: &fakeRepoupdaterClient{
+			mockSchedulePermsSync: func(_ context.Context, args protocol.PermsSyncRequest) error {
+				scheduled = &args
+				syncedAt = time.Now()
+				return nil
+			},
+		},
+	}
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	result, err := r.ResyncRepositoryPermissions(ctx, &graphqlbackend.ResyncRepositoryPermissionsArgs{
+		User:           graphqlbackend.MarshalUserID(2),
+		Repository:     graphqlbackend.MarshalRepositoryID(3),
+		TimeoutSeconds: 5,
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, scheduled)
+	assert.Equal(t, []int32{2}, scheduled.UserIDs)
+	assert.Equal(t, []api.RepoID{3}, scheduled.RepoIDs)
+
+	assert.True(t, result.Completed())
+	assert.False(t, result.Before().CanRead())
+	assert.True(t, result.After().CanRead())
+	assert.Equal(t, []explainedReason{
+		{mechanism: mechanismExplicitPermissions, grantsAccess: true, providerID: "https://github.com/"},
+	}, explainedReasons(result.AddedReasons()))
+	assert.Equal(t, []explainedReason{
+		{mechanism: mechanismExplicitPermissions, providerID: "https://github.com/"},
+	}, explainedReasons(result.RemovedReasons()))
+}
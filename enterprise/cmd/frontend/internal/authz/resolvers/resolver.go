@@ -664,6 +664,7 @@ func (r *Resolver) PermissionsSyncJobs(ctx context.Context, args *graphqlbackend
 
 func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
 	return map[string]graphqlbackend.NodeByIDFunc{
-		permissionsSyncJobKind: getPermissionsSyncJobByIDFunc(r),
+		permissionsSyncJobKind:    getPermissionsSyncJobByIDFunc(r),
+		subRepoPermissionRuleKind: getSubRepoPermissionRuleByIDFunc(r),
 	}
 }
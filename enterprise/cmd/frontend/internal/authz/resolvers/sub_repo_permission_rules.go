@@ -0,0 +1,380 @@
+package resolvers
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const subRepoPermissionRuleKind = "SubRepositoryPermissionRule"
+
+func marshalSubRepoPermissionRuleID(id int32) graphql.ID {
+	return relay.MarshalID(subRepoPermissionRuleKind, id)
+}
+
+func unmarshalSubRepoPermissionRuleID(id graphql.ID) (ruleID int32, err error) {
+	if kind := relay.UnmarshalKind(id); kind != subRepoPermissionRuleKind {
+		return 0, errors.Errorf("expected graphql ID to have kind %q; got %q", subRepoPermissionRuleKind, kind)
+	}
+	err = relay.UnmarshalSpec(id, &ruleID)
+	return ruleID, err
+}
+
+func getSubRepoPermissionRuleByIDFunc(r *Resolver) graphqlbackend.NodeByIDFunc {
+	return func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
+		// 🚨 SECURITY: Only site admins can query sub-repository permission rules.
+		if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+			return nil, err
+		}
+
+		ruleID, err := unmarshalSubRepoPermissionRuleID(id)
+		if err != nil {
+			return nil, err
+		}
+		rule, err := r.db.SubRepoPermissionRules().GetByID(ctx, ruleID)
+		if err != nil {
+			return nil, err
+		}
+		return &subRepoPermissionRuleResolver{db: r.db, rule: rule}, nil
+	}
+}
+
+// checkSubRepoPermissionRulesAccess returns an error if the current user
+// cannot manage sub-repository permission rules.
+func (r *Resolver) checkSubRepoPermissionRulesAccess(ctx context.Context) error {
+	if err := r.checkLicense(licensing.FeatureExplicitPermissionsAPI); err != nil {
+		return err
+	}
+	if envvar.SourcegraphDotComMode() {
+		return errDisabledSourcegraphDotCom
+	}
+
+	// 🚨 SECURITY: Only site admins can manage sub-repository permission rules.
+	return auth.CheckCurrentUserIsSiteAdmin(ctx, r.db)
+}
+
+// subRepoPermissionRuleFromInput validates the given input and converts it
+// into a rule, normalizing its paths the same way as
+// SetSubRepositoryPermissionsForUsers does.
+func subRepoPermissionRuleFromInput(input graphqlbackend.SubRepoPermissionRuleInput) (*types.SubRepoPermissionRule, error) {
+	if input.RepositoryPattern == "" {
+		return nil, errors.New("repositoryPattern must not be empty")
+	}
+	if input.User != nil && input.Organization != nil {
+		return nil, errors.New("at most one of user and organization can be set")
+	}
+	if len(input.Paths) == 0 {
+		return nil, errors.New("paths must not be empty")
+	}
+
+	rule := &types.SubRepoPermissionRule{
+		RepoPattern: input.RepositoryPattern,
+		Paths:       make([]string, 0, len(input.Paths)),
+	}
+
+	var err error
+	if input.User != nil {
+		if rule.UserID, err = graphqlbackend.UnmarshalUserID(*input.User); err != nil {
+			return nil, err
+		}
+	}
+	if input.Organization != nil {
+		if rule.OrgID, err = graphqlbackend.UnmarshalOrgID(*input.Organization); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, path := range input.Paths {
+		if strings.HasPrefix(path, "-") {
+			if !strings.HasPrefix(path, "-/") {
+				path = "-/" + strings.TrimPrefix(path, "-")
+			}
+		} else if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		rule.Paths = append(rule.Paths, path)
+	}
+	if _, err := authz.NewFilePermissionsFunc(rule.Paths); err != nil {
+		return nil, errors.Wrap(err, "invalid paths")
+	}
+
+	return rule, nil
+}
+
+func (r *Resolver) CreateSubRepositoryPermissionRule(ctx context.Context, args *graphqlbackend.CreateSubRepoPermissionRuleArgs) (graphqlbackend.SubRepoPermissionRuleResolver, error) {
+	if err := r.checkSubRepoPermissionRulesAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	rule, err := subRepoPermissionRuleFromInput(args.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.db.SubRepoPermissionRules().Create(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subRepoPermissionRuleResolver{db: r.db, rule: created}, nil
+}
+
+func (r *Resolver) UpdateSubRepositoryPermissionRule(ctx context.Context, args *graphqlbackend.UpdateSubRepoPermissionRuleArgs) (graphqlbackend.SubRepoPermissionRuleResolver, error) {
+	if err := r.checkSubRepoPermissionRulesAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	rule, err := subRepoPermissionRuleFromInput(args.Rule)
+	if err != nil {
+		return nil, err
+	}
+	if rule.ID, err = unmarshalSubRepoPermissionRuleID(args.ID); err != nil {
+		return nil, err
+	}
+
+	updated, err := r.db.SubRepoPermissionRules().Update(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subRepoPermissionRuleResolver{db: r.db, rule: updated}, nil
+}
+
+func (r *Resolver) DeleteSubRepositoryPermissionRule(ctx context.Context, args *graphqlbackend.DeleteSubRepoPermissionRuleArgs) (*graphqlbackend.EmptyResponse, error) {
+	if err := r.checkSubRepoPermissionRulesAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalSubRepoPermissionRuleID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.SubRepoPermissionRules().Delete(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) SubRepositoryPermissionRules(ctx context.Context, args *graphqlbackend.SubRepoPermissionRulesArgs) ([]graphqlbackend.SubRepoPermissionRuleResolver, error) {
+	if err := r.checkSubRepoPermissionRulesAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	var opts database.SubRepoPermissionRulesListOptions
+	if args.Repository != nil {
+		repoID, err := graphqlbackend.UnmarshalRepositoryID(*args.Repository)
+		if err != nil {
+			return nil, err
+		}
+		repo, err := r.db.Repos().Get(ctx, repoID)
+		if err != nil {
+			return nil, err
+		}
+		opts.RepoName = repo.Name
+	}
+
+	rules, err := r.db.SubRepoPermissionRules().List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.SubRepoPermissionRuleResolver, 0, len(rules))
+	for _, rule := range rules {
+		resolvers = append(resolvers, &subRepoPermissionRuleResolver{db: r.db, rule: rule})
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) EvaluateSubRepositoryPermissionRules(ctx context.Context, args *graphqlbackend.EvaluateSubRepoPermissionRulesArgs) ([]graphqlbackend.SubRepoPathAccessResolver, error) {
+	if err := r.checkSubRepoPermissionRulesAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := r.db.Repos().Get(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range database.SubRepoSupportedCodeHostTypes {
+		if repo.ExternalRepo.ServiceType == t {
+			return nil, errors.Errorf("sub-repository permissions of %q are managed by its code host", repo.Name)
+		}
+	}
+
+	rules, err := r.db.SubRepoPermissionRules().List(ctx, database.SubRepoPermissionRulesListOptions{RepoName: repo.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	var dryRunRule *types.SubRepoPermissionRule
+	if args.DryRunRule != nil {
+		rule, err := subRepoPermissionRuleFromInput(*args.DryRunRule)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid dryRunRule")
+		}
+		// Rules are matched by Postgres when permissions are read, so the
+		// dry-run rule is matched the same way.
+		matches, err := r.db.SubRepoPermissionRules().MatchRepoPattern(ctx, rule.RepoPattern, repo.Name)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid dryRunRule")
+		}
+		if matches {
+			dryRunRule = rule
+		}
+	}
+
+	accesses := make(map[int32]*subRepoPathAccessResolver)
+	addRule := func(rule *types.SubRepoPermissionRule, dryRun bool) error {
+		userIDs, err := r.db.SubRepoPermissionRules().ListSubjectUserIDs(ctx, rule)
+		if err != nil {
+			return err
+		}
+		for _, id := range userIDs {
+			a, ok := accesses[id]
+			if !ok {
+				a = &subRepoPathAccessResolver{}
+				accesses[id] = a
+			}
+			a.paths = append(a.paths, rule.Paths...)
+			if dryRun {
+				a.matchedByDryRunRule = true
+			} else {
+				a.rules = append(a.rules, &subRepoPermissionRuleResolver{db: r.db, rule: rule})
+			}
+		}
+		return nil
+	}
+	for _, rule := range rules {
+		if err := addRule(rule, false); err != nil {
+			return nil, err
+		}
+	}
+	if dryRunRule != nil {
+		if err := addRule(dryRunRule, true); err != nil {
+			return nil, err
+		}
+	}
+	if len(accesses) == 0 {
+		return []graphqlbackend.SubRepoPathAccessResolver{}, nil
+	}
+
+	userIDs := make([]int32, 0, len(accesses))
+	for id := range accesses {
+		userIDs = append(userIDs, id)
+	}
+	sort.Slice(userIDs, func(i, j int) bool { return userIDs[i] < userIDs[j] })
+
+	users, err := r.db.Users().List(ctx, &database.UsersListOptions{UserIDs: userIDs})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	enforceForSiteAdmins := conf.Get().AuthzEnforceForSiteAdmins
+	resolvers := make([]graphqlbackend.SubRepoPathAccessResolver, 0, len(users))
+	for _, user := range users {
+		// Sub-repository permissions are not enforced for site admins unless
+		// configured to, see SubRepoPermsStore.GetByUser.
+		if user.SiteAdmin && !enforceForSiteAdmins {
+			continue
+		}
+
+		a := accesses[user.ID]
+		permsFunc, err := authz.NewFilePermissionsFunc(a.paths)
+		if err != nil {
+			return nil, err
+		}
+		perms, err := permsFunc(args.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		a.user = graphqlbackend.NewUserResolver(r.db, user)
+		a.canRead = perms.Include(authz.Read)
+		resolvers = append(resolvers, a)
+	}
+	return resolvers, nil
+}
+
+type subRepoPermissionRuleResolver struct {
+	db   database.DB
+	rule *types.SubRepoPermissionRule
+}
+
+var _ graphqlbackend.SubRepoPermissionRuleResolver = &subRepoPermissionRuleResolver{}
+
+func (r *subRepoPermissionRuleResolver) ID() graphql.ID {
+	return marshalSubRepoPermissionRuleID(r.rule.ID)
+}
+
+func (r *subRepoPermissionRuleResolver) RepositoryPattern() string {
+	return r.rule.RepoPattern
+}
+
+func (r *subRepoPermissionRuleResolver) User(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.rule.UserID == 0 {
+		return nil, nil
+	}
+	return graphqlbackend.UserByIDInt32(ctx, r.db, r.rule.UserID)
+}
+
+func (r *subRepoPermissionRuleResolver) Organization(ctx context.Context) (*graphqlbackend.OrgResolver, error) {
+	if r.rule.OrgID == 0 {
+		return nil, nil
+	}
+	return graphqlbackend.OrgByIDInt32(ctx, r.db, r.rule.OrgID)
+}
+
+func (r *subRepoPermissionRuleResolver) Paths() []string {
+	return r.rule.Paths
+}
+
+func (r *subRepoPermissionRuleResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.rule.CreatedAt}
+}
+
+func (r *subRepoPermissionRuleResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.rule.UpdatedAt}
+}
+
+type subRepoPathAccessResolver struct {
+	user                *graphqlbackend.UserResolver
+	canRead             bool
+	rules               []graphqlbackend.SubRepoPermissionRuleResolver
+	matchedByDryRunRule bool
+
+	// paths are the paths of every rule that applies to the user, in
+	// evaluation order.
+	paths []string
+}
+
+var _ graphqlbackend.SubRepoPathAccessResolver = &subRepoPathAccessResolver{}
+
+func (r *subRepoPathAccessResolver) User() *graphqlbackend.UserResolver { return r.user }
+func (r *subRepoPathAccessResolver) CanRead() bool                      { return r.canRead }
+func (r *subRepoPathAccessResolver) Rules() []graphqlbackend.SubRepoPermissionRuleResolver {
+	if r.rules == nil {
+		return []graphqlbackend.SubRepoPermissionRuleResolver{}
+	}
+	return r.rules
+}
+func (r *subRepoPathAccessResolver) MatchedByDryRunRule() bool { return r.matchedByDryRunRule }
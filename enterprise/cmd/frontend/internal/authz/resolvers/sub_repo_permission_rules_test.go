@@ -0,0 +1,212 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestResolver_CreateSubRepositoryPermissionRule(t *testing.T) {
+	t.Cleanup(licensing.TestingSkipFeatureChecks())
+
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		users := database.NewStrictMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{}, nil)
+
+		db := edb.NewStrictMockEnterpriseDB()
+		db.UsersFunc.SetDefaultReturn(users)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&Resolver{db: db}).CreateSubRepositoryPermissionRule(ctx, &graphqlbackend.CreateSubRepoPermissionRuleArgs{})
+		if want := auth.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+		if result != nil {
+			t.Errorf("result: want nil but got %v", result)
+		}
+	})
+
+	users := database.NewStrictMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	t.Run("invalid input", func(t *testing.T) {
+		db := edb.NewStrictMockEnterpriseDB()
+		db.UsersFunc.SetDefaultReturn(users)
+
+		orgID := graphqlbackend.MarshalOrgID(1)
+		userID := graphqlbackend.MarshalUserID(1)
+		for name, input := range map[string]graphqlbackend.SubRepoPermissionRuleInput{
+			"empty pattern":   {Paths: []string{"/**"}},
+			"invalid pattern": {RepositoryPattern: "(", Paths: []string{"/**"}},
+			"two subjects":    {RepositoryPattern: "^monorepo$", User: &userID, Organization: &orgID, Paths: []string{"/**"}},
+			"no paths":        {RepositoryPattern: "^monorepo$"},
+			"invalid path":    {RepositoryPattern: "^monorepo$", Paths: []string{"/[a"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := (&Resolver{db: db}).CreateSubRepositoryPermissionRule(ctx, &graphqlbackend.CreateSubRepoPermissionRuleArgs{Rule: input})
+				assert.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("create", func(t *testing.T) {
+		rules := database.NewStrictMockSubRepoPermissionRuleStore()
+		rules.CreateFunc.SetDefaultHook(func(_ context.Context, rule *types.SubRepoPermissionRule) (*types.SubRepoPermissionRule, error) {
+			created := *rule
+			created.ID = 7
+			return &created, nil
+		})
+
+		db := edb.NewStrictMockEnterpriseDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.SubRepoPermissionRulesFunc.SetDefaultReturn(rules)
+
+		orgID := graphqlbackend.MarshalOrgID(3)
+		result, err := (&Resolver{db: db}).CreateSubRepositoryPermissionRule(ctx, &graphqlbackend.CreateSubRepoPermissionRuleArgs{
+			Rule: graphqlbackend.SubRepoPermissionRuleInput{
+				RepositoryPattern: "^github\\.com/acme/monorepo$",
+				Organization:      &orgID,
+				Paths:             []string{"legal/**", "-legal/contracts/**"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, marshalSubRepoPermissionRuleID(7), result.ID())
+
+		require.Len(t, rules.CreateFunc.History(), 1)
+		assert.Equal(t, &types.SubRepoPermissionRule{
+			RepoPattern: "^github\\.com/acme/monorepo$",
+			OrgID:       3,
+			Paths:       []string{"/legal/**", "-/legal/contracts/**"},
+		}, rules.CreateFunc.History()[0].Arg1)
+	})
+}
+
+func TestResolver_EvaluateSubRepositoryPermissionRules(t *testing.T) {
+	t.Cleanup(licensing.TestingSkipFeatureChecks())
+
+	users := database.NewStrictMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+	users.ListFunc.SetDefaultHook(func(_ context.Context, opts *database.UsersListOptions) ([]*types.User, error) {
+		all := map[int32]*types.User{
+			1: {ID: 1, Username: "admin", SiteAdmin: true},
+			2: {ID: 2, Username: "alice"},
+			3: {ID: 3, Username: "bob"},
+		}
+		var users []*types.User
+		for _, id := range opts.UserIDs {
+			users = append(users, all[id])
+		}
+		return users, nil
+	})
+
+	repos := database.NewStrictMockRepoStore()
+	repos.GetFunc.SetDefaultHook(func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		serviceType := extsvc.TypeOther
+		if id == 2 {
+			serviceType = extsvc.TypePerforce
+		}
+		return &types.Repo{
+			ID:           id,
+			Name:         "github.com/acme/monorepo",
+			ExternalRepo: api.ExternalRepoSpec{ServiceType: serviceType},
+		}, nil
+	})
+
+	everyone := &types.SubRepoPermissionRule{ID: 1, RepoPattern: "monorepo", Paths: []string{"/**", "-/legal/**"}}
+	legal := &types.SubRepoPermissionRule{ID: 2, RepoPattern: "monorepo", OrgID: 1, Paths: []string{"/legal/**"}}
+
+	rules := database.NewStrictMockSubRepoPermissionRuleStore()
+	rules.ListFunc.SetDefaultReturn([]*types.SubRepoPermissionRule{everyone, legal}, nil)
+	rules.ListSubjectUserIDsFunc.SetDefaultHook(func(_ context.Context, rule *types.SubRepoPermissionRule) ([]int32, error) {
+		switch {
+		case rule.UserID != 0:
+			return []int32{rule.UserID}, nil
+		case rule.OrgID != 0:
+			return []int32{2}, nil
+		default:
+			return []int32{1, 2, 3}, nil
+		}
+	})
+
+	db := edb.NewStrictMockEnterpriseDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.SubRepoPermissionRulesFunc.SetDefaultReturn(rules)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	type access struct {
+		username string
+		canRead  bool
+		rules    int
+		dryRun   bool
+	}
+	evaluate := func(t *testing.T, args *graphqlbackend.EvaluateSubRepoPermissionRulesArgs) []access {
+		t.Helper()
+
+		result, err := (&Resolver{db: db}).EvaluateSubRepositoryPermissionRules(ctx, args)
+		require.NoError(t, err)
+
+		var got []access
+		for _, r := range result {
+			got = append(got, access{
+				username: r.User().Username(),
+				canRead:  r.CanRead(),
+				rules:    len(r.Rules()),
+				dryRun:   r.MatchedByDryRunRule(),
+			})
+		}
+		return got
+	}
+
+	t.Run("restricted path", func(t *testing.T) {
+		got := evaluate(t, &graphqlbackend.EvaluateSubRepoPermissionRulesArgs{
+			Repository: graphqlbackend.MarshalRepositoryID(1),
+			Path:       "legal/nda.md",
+		})
+		// Site admins are not subject to sub-repo permissions by default.
+		assert.Equal(t, []access{
+			{username: "alice", canRead: true, rules: 2},
+			{username: "bob", canRead: false, rules: 1},
+		}, got)
+	})
+
+	t.Run("dry-run rule", func(t *testing.T) {
+		bob := graphqlbackend.MarshalUserID(3)
+		got := evaluate(t, &graphqlbackend.EvaluateSubRepoPermissionRulesArgs{
+			Repository: graphqlbackend.MarshalRepositoryID(1),
+			Path:       "legal/nda.md",
+			DryRunRule: &graphqlbackend.SubRepoPermissionRuleInput{
+				RepositoryPattern: "^github\\.com/acme/",
+				User:              &bob,
+				Paths:             []string{"legal/nda.md"},
+			},
+		})
+		assert.Equal(t, []access{
+			{username: "alice", canRead: true, rules: 2},
+			{username: "bob", canRead: true, rules: 1, dryRun: true},
+		}, got)
+	})
+
+	t.Run("repository of a code host with sub-repo permissions", func(t *testing.T) {
+		_, err := (&Resolver{db: db}).EvaluateSubRepositoryPermissionRules(ctx, &graphqlbackend.EvaluateSubRepoPermissionRulesArgs{
+			Repository: graphqlbackend.MarshalRepositoryID(2),
+			Path:       "legal/nda.md",
+		})
+		assert.EqualError(t, err, `sub-repository permissions of "github.com/acme/monorepo" are managed by its code host`)
+	})
+}
@@ -257,6 +257,10 @@ type ChangesetSpecDelta struct {
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
 	AuthorEmailChanged   bool
+	ReviewersChanged     bool
+	LabelsChanged        bool
+	AssigneesChanged     bool
+	MilestoneChanged     bool
 }
 
 type ChangesetSpec struct {
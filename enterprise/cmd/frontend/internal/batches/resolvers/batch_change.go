@@ -277,6 +277,52 @@ func (r *batchChangeResolver) CurrentSpec(ctx context.Context) (graphqlbackend.B
 	return &batchSpecResolver{store: r.store, batchSpec: batchSpec}, nil
 }
 
+func (r *batchChangeResolver) Schedule(ctx context.Context) (graphqlbackend.BatchChangeScheduleResolver, error) {
+	schedule, err := r.store.GetBatchChangeSchedule(ctx, store.GetBatchChangeScheduleOpts{
+		BatchChangeID: r.batchChange.ID,
+	})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &batchChangeScheduleResolver{store: r.store, schedule: schedule}, nil
+}
+
+func (r *batchChangeResolver) ChangesetDependencies(ctx context.Context) ([]graphqlbackend.ChangesetDependencyResolver, error) {
+	deps, err := service.New(r.store).ListChangesetDependencies(ctx, r.batchChange.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var cs btypes.Changesets
+	for _, dep := range deps {
+		cs = append(cs, dep.Changeset)
+		cs = append(cs, dep.DependsOn...)
+	}
+	// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	reposByID, err := r.store.Repos().GetReposSetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetDependencyResolver, 0, len(deps))
+	for _, dep := range deps {
+		repo, ok := reposByID[dep.Changeset.RepoID]
+		if !ok {
+			continue
+		}
+		resolvers = append(resolvers, &changesetDependencyResolver{
+			changeset: NewChangesetResolver(r.store, r.gitserverClient, dep.Changeset, repo),
+			dependsOn: externalChangesetResolvers(r.store, r.gitserverClient, dep.DependsOn, reposByID),
+		})
+	}
+	return resolvers, nil
+}
+
 func (r *batchChangeResolver) BulkOperations(
 	ctx context.Context,
 	args *graphqlbackend.ListBatchChangeBulkOperationArgs,
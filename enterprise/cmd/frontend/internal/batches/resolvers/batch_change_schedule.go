@@ -0,0 +1,125 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+var _ graphqlbackend.BatchChangeScheduleResolver = &batchChangeScheduleResolver{}
+
+type batchChangeScheduleResolver struct {
+	store    *store.Store
+	schedule *btypes.BatchChangeSchedule
+}
+
+func (r *batchChangeScheduleResolver) Cron() string {
+	return r.schedule.Cron
+}
+
+func (r *batchChangeScheduleResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.schedule.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *batchChangeScheduleResolver) AutoApply() string {
+	return string(r.schedule.AutoApply)
+}
+
+func (r *batchChangeScheduleResolver) MaxConsecutiveFailures() int32 {
+	return r.schedule.MaxConsecutiveFailures
+}
+
+func (r *batchChangeScheduleResolver) ConsecutiveFailures() int32 {
+	return r.schedule.ConsecutiveFailures
+}
+
+func (r *batchChangeScheduleResolver) NextRunAt() *gqlutil.DateTime {
+	if r.schedule.Paused() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: r.schedule.NextRunAt}
+}
+
+func (r *batchChangeScheduleResolver) PausedAt() *gqlutil.DateTime {
+	if !r.schedule.Paused() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: r.schedule.PausedAt}
+}
+
+func (r *batchChangeScheduleResolver) Runs(ctx context.Context, args *graphqlbackend.ListBatchChangeScheduleRunsArgs) ([]graphqlbackend.BatchChangeScheduleRunResolver, error) {
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+
+	runs, err := r.store.ListBatchChangeScheduleRuns(ctx, store.ListBatchChangeScheduleRunsOpts{
+		ScheduleID: r.schedule.ID,
+		Limit:      int(args.First),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BatchChangeScheduleRunResolver, 0, len(runs))
+	for _, run := range runs {
+		resolvers = append(resolvers, &batchChangeScheduleRunResolver{store: r.store, run: run})
+	}
+	return resolvers, nil
+}
+
+var _ graphqlbackend.BatchChangeScheduleRunResolver = &batchChangeScheduleRunResolver{}
+
+type batchChangeScheduleRunResolver struct {
+	store *store.Store
+	run   *btypes.BatchChangeScheduleRun
+}
+
+func (r *batchChangeScheduleRunResolver) State() string {
+	return r.run.State.ToGraphQL()
+}
+
+func (r *batchChangeScheduleRunResolver) BatchSpec(ctx context.Context) (graphqlbackend.BatchSpecResolver, error) {
+	if r.run.BatchSpecID == 0 {
+		return nil, nil
+	}
+
+	batchSpec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: r.run.BatchSpecID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &batchSpecResolver{store: r.store, batchSpec: batchSpec}, nil
+}
+
+func (r *batchChangeScheduleRunResolver) Applied() bool {
+	return r.run.Applied
+}
+
+func (r *batchChangeScheduleRunResolver) FailureMessage() *string {
+	if r.run.FailureMessage == "" {
+		return nil
+	}
+	return &r.run.FailureMessage
+}
+
+func (r *batchChangeScheduleRunResolver) StartedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.run.StartedAt}
+}
+
+func (r *batchChangeScheduleRunResolver) FinishedAt() *gqlutil.DateTime {
+	if r.run.FinishedAt.IsZero() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: r.run.FinishedAt}
+}
@@ -38,6 +38,14 @@ func (r *batchSpecWorkspaceStepV1Resolver) Container() string {
 	return r.step.Container
 }
 
+func (r *batchSpecWorkspaceStepV1Resolver) Uses() *string {
+	if !r.step.Native() {
+		return nil
+	}
+	uses := string(r.step.Uses)
+	return &uses
+}
+
 func (r *batchSpecWorkspaceStepV1Resolver) IfCondition() *string {
 	cond := r.step.IfCondition()
 	if cond == "" {
@@ -194,6 +202,14 @@ func (r *batchSpecWorkspaceStepV2Resolver) Container() string {
 	return r.step.Container
 }
 
+func (r *batchSpecWorkspaceStepV2Resolver) Uses() *string {
+	if !r.step.Native() {
+		return nil
+	}
+	uses := string(r.step.Uses)
+	return &uses
+}
+
 func (r *batchSpecWorkspaceStepV2Resolver) IfCondition() *string {
 	cond := r.step.IfCondition()
 	if cond == "" {
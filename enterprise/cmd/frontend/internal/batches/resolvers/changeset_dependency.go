@@ -0,0 +1,81 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+var _ graphqlbackend.ChangesetDependencyResolver = &changesetDependencyResolver{}
+
+type changesetDependencyResolver struct {
+	changeset graphqlbackend.ExternalChangesetResolver
+	dependsOn []graphqlbackend.ExternalChangesetResolver
+}
+
+func (r *changesetDependencyResolver) Changeset() graphqlbackend.ExternalChangesetResolver {
+	return r.changeset
+}
+
+func (r *changesetDependencyResolver) DependsOn() []graphqlbackend.ExternalChangesetResolver {
+	return r.dependsOn
+}
+
+// externalChangesetResolvers returns resolvers for the given changesets,
+// skipping the ones in repositories the user can't access.
+func externalChangesetResolvers(s *store.Store, gitserverClient gitserver.Client, cs []*btypes.Changeset, reposByID map[api.RepoID]*types.Repo) []graphqlbackend.ExternalChangesetResolver {
+	resolvers := make([]graphqlbackend.ExternalChangesetResolver, 0, len(cs))
+	for _, c := range cs {
+		if repo, ok := reposByID[c.RepoID]; ok {
+			resolvers = append(resolvers, NewChangesetResolver(s, gitserverClient, c, repo))
+		}
+	}
+	return resolvers
+}
+
+func (r *changesetResolver) Blocked() bool {
+	return r.changeset.Blocked
+}
+
+func (r *changesetResolver) DependsOn(ctx context.Context) ([]graphqlbackend.ExternalChangesetResolver, error) {
+	if r.changeset.CurrentSpecID == 0 || r.changeset.OwnedByBatchChangeID == 0 {
+		return []graphqlbackend.ExternalChangesetResolver{}, nil
+	}
+	spec, err := r.computeSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(spec.DependsOn) == 0 {
+		return []graphqlbackend.ExternalChangesetResolver{}, nil
+	}
+
+	cs, _, err := r.store.ListChangesets(ctx, store.ListChangesetsOpts{
+		OwnedByBatchChangeID: r.changeset.OwnedByBatchChangeID,
+		DependencyNames:      spec.DependsOn,
+		EnforceAuthz:         true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prerequisites := make(btypes.Changesets, 0, len(cs))
+	for _, c := range cs {
+		if c.RepoID != r.changeset.RepoID && !c.ArchivedIn(r.changeset.OwnedByBatchChangeID) {
+			prerequisites = append(prerequisites, c)
+		}
+	}
+
+	// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	reposByID, err := r.store.Repos().GetReposSetByIDs(ctx, prerequisites.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	return externalChangesetResolvers(r.store, r.gitserverClient, prerequisites, reposByID), nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/graph-gophers/graphql-go"
 
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
@@ -345,7 +346,7 @@ func (r *Resolver) batchChangesUserCredentialByID(ctx context.Context, id int64)
 
 func (r *Resolver) batchChangesSiteCredentialByID(ctx context.Context, id int64) (batchChangesCredentialResolver, error) {
 	// Todo: Is this required? Should everyone be able to see there are _some_ credentials?
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB()); err != nil {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesSiteCredentialsManage); err != nil {
 		return nil, err
 	}
 
@@ -723,6 +724,67 @@ func (r *Resolver) DeleteBatchChange(ctx context.Context, args *graphqlbackend.D
 	return &graphqlbackend.EmptyResponse{}, err
 }
 
+func (r *Resolver) SetBatchChangeSchedule(ctx context.Context, args *graphqlbackend.SetBatchChangeScheduleArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetBatchChangeSchedule", fmt.Sprintf("BatchChange: %q, Cron: %q", args.BatchChange, args.Cron))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: SetBatchChangeSchedule checks whether current user is authorized.
+	if _, err := svc.SetBatchChangeSchedule(ctx, service.SetBatchChangeScheduleOpts{
+		BatchChangeID:          batchChangeID,
+		Cron:                   args.Cron,
+		AutoApply:              btypes.BatchChangeScheduleAutoApply(args.AutoApply),
+		MaxConsecutiveFailures: args.MaxConsecutiveFailures,
+	}); err != nil {
+		return nil, err
+	}
+
+	return r.batchChangeByID(ctx, marshalBatchChangeID(batchChangeID))
+}
+
+func (r *Resolver) DeleteBatchChangeSchedule(ctx context.Context, args *graphqlbackend.DeleteBatchChangeScheduleArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteBatchChangeSchedule", fmt.Sprintf("BatchChange: %q", args.BatchChange))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: DeleteBatchChangeSchedule checks whether current user is authorized.
+	if err := svc.DeleteBatchChangeSchedule(ctx, batchChangeID); err != nil {
+		return nil, err
+	}
+
+	return r.batchChangeByID(ctx, marshalBatchChangeID(batchChangeID))
+}
+
 func (r *Resolver) BatchChanges(ctx context.Context, args *graphqlbackend.ListBatchChangesArgs) (graphqlbackend.BatchChangesConnectionResolver, error) {
 	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
@@ -1129,8 +1191,8 @@ func (r *Resolver) createBatchChangesUserCredential(ctx context.Context, externa
 
 func (r *Resolver) createBatchChangesSiteCredential(ctx context.Context, externalServiceURL, externalServiceType string, credential string, username *string) (graphqlbackend.BatchChangesCredentialResolver, error) {
 	// 🚨 SECURITY: Check that a site credential can only be created
-	// by a site-admin.
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB()); err != nil {
+	// by a site-admin or a user with permission to manage site credentials.
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesSiteCredentialsManage); err != nil {
 		return nil, err
 	}
 
@@ -1255,7 +1317,7 @@ func (r *Resolver) deleteBatchChangesUserCredential(ctx context.Context, credent
 
 func (r *Resolver) deleteBatchChangesSiteCredential(ctx context.Context, credentialDBID int64) (*graphqlbackend.EmptyResponse, error) {
 	// 🚨 SECURITY: Check that the requesting user may delete the credential.
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB()); err != nil {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesSiteCredentialsManage); err != nil {
 		return nil, err
 	}
 
@@ -245,30 +245,44 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 				return apiclient.Job{}, err
 			}
 
-			dockerSteps = append(dockerSteps, apiclient.DockerStep{
-				Key:   fmt.Sprintf("step.%d.pre", i),
-				Image: helperImage,
-				Env:   secretEnvVars,
-				Dir:   ".",
-				Commands: []string{
-					// TODO: This doesn't handle skipped steps right, it assumes
-					// there are outputs from i-1 present at all times.
-					shellquote.Join("batcheshelper", "pre", strconv.Itoa(i)),
-				},
-			})
+			if step.Native() {
+				// Built-in steps don't need a container: the helper runs them
+				// in-process on the workspace and writes the same stdout and
+				// stderr files as a container step would.
+				dockerSteps = append(dockerSteps, apiclient.DockerStep{
+					Key:   fmt.Sprintf("step.%d.run", i),
+					Image: helperImage,
+					Dir:   ".",
+					Commands: []string{
+						shellquote.Join("batcheshelper", "run", strconv.Itoa(i)),
+					},
+				})
+			} else {
+				dockerSteps = append(dockerSteps, apiclient.DockerStep{
+					Key:   fmt.Sprintf("step.%d.pre", i),
+					Image: helperImage,
+					Env:   secretEnvVars,
+					Dir:   ".",
+					Commands: []string{
+						// TODO: This doesn't handle skipped steps right, it assumes
+						// there are outputs from i-1 present at all times.
+						shellquote.Join("batcheshelper", "pre", strconv.Itoa(i)),
+					},
+				})
 
-			dockerSteps = append(dockerSteps, apiclient.DockerStep{
-				Key:   fmt.Sprintf("step.%d.run", i),
-				Image: step.Container,
-				Dir:   runDir,
-				// Invoke the script file but also write stdout and stderr to separate files, which will then be
-				// consumed by the post step to build the AfterStepResult.
-				Commands: []string{
-					// Hide commands from stderr.
-					"{ set +x; } 2>/dev/null",
-					fmt.Sprintf(`(exec "%s/step%d.sh" | tee %s/stdout%d.log) 3>&1 1>&2 2>&3 | tee %s/stderr%d.log`, runDirToScriptDir, i, runDirToScriptDir, i, runDirToScriptDir, i),
-				},
-			})
+				dockerSteps = append(dockerSteps, apiclient.DockerStep{
+					Key:   fmt.Sprintf("step.%d.run", i),
+					Image: step.Container,
+					Dir:   runDir,
+					// Invoke the script file but also write stdout and stderr to separate files, which will then be
+					// consumed by the post step to build the AfterStepResult.
+					Commands: []string{
+						// Hide commands from stderr.
+						"{ set +x; } 2>/dev/null",
+						fmt.Sprintf(`(exec "%s/step%d.sh" | tee %s/stdout%d.log) 3>&1 1>&2 2>&3 | tee %s/stderr%d.log`, runDirToScriptDir, i, runDirToScriptDir, i, runDirToScriptDir, i),
+					},
+				})
+			}
 
 			// This step gets the diff, reads stdout and stderr, renders the outputs and builds the AfterStepResult.
 			dockerSteps = append(dockerSteps, apiclient.DockerStep{
@@ -284,6 +298,14 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 			aj.DockerSteps = dockerSteps
 		}
 	} else {
+		// src-cli doesn't know about built-in steps, only batcheshelper can
+		// run them.
+		for i, step := range batchSpec.Spec.Steps {
+			if step.Native() {
+				return apiclient.Job{}, errors.Newf("step %d uses the built-in step %q, which requires native execution", i+1, step.Uses)
+			}
+		}
+
 		commands := []string{
 			"batch",
 			"exec",
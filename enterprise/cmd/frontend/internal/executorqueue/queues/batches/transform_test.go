@@ -395,3 +395,93 @@ steps:
 		mockassert.CalledN(t, sal.CreateFunc, 5)
 	})
 }
+
+func TestTransformRecord_NativeSteps(t *testing.T) {
+	db := database.NewMockDB()
+	repos := database.NewMockRepoStore()
+	repos.GetFunc.SetDefaultHook(func(ctx context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id, Name: "github.com/sourcegraph/sourcegraph"}, nil
+	})
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.ExecutorSecretsFunc.SetDefaultReturn(database.NewMockExecutorSecretStore())
+	db.ExecutorSecretAccessLogsFunc.SetDefaultReturn(database.NewMockExecutorSecretAccessLogStore())
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExternalURL:                    "https://test.io",
+		ExecutorsBatcheshelperImage:    "sourcegraph/batcheshelper",
+		ExecutorsBatcheshelperImageTag: "test",
+	}})
+	t.Cleanup(func() {
+		conf.Mock(nil)
+	})
+
+	spec := batcheslib.BatchSpec{}
+	err := yaml.Unmarshal([]byte(`
+steps:
+  - uses: write-file
+    with:
+      path: README.md
+      content: Hello World
+  - run: echo lol >> README.md
+    container: alpine:3
+`), &spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batchSpec := &btypes.BatchSpec{RandID: "abc", UserID: 123, NamespaceUserID: 123, Spec: &spec}
+	workspace := &btypes.BatchSpecWorkspace{
+		RepoID: 5678,
+		Branch: "refs/heads/base-branch",
+		Commit: "d34db33f",
+	}
+
+	store := NewMockBatchesStore()
+	store.GetBatchSpecFunc.SetDefaultReturn(batchSpec, nil)
+	store.GetBatchSpecWorkspaceFunc.SetDefaultReturn(workspace, nil)
+	store.DatabaseDBFunc.SetDefaultReturn(db)
+
+	t.Run("native execution", func(t *testing.T) {
+		job, err := transformRecord(context.Background(), logtest.Scoped(t), store, &btypes.BatchSpecWorkspaceExecutionJob{ID: 42, UserID: 123, Version: 2}, "0.0.0-dev")
+		if err != nil {
+			t.Fatalf("unexpected error transforming record: %s", err)
+		}
+
+		type step struct {
+			Key, Image, Dir string
+			Commands        []string
+		}
+		var have []step
+		for _, s := range job.DockerSteps {
+			st := step{Key: s.Key, Image: s.Image, Dir: s.Dir}
+			// Only compare the commands of the built-in step, the others are
+			// covered elsewhere.
+			if s.Key == "step.0.run" {
+				st.Commands = s.Commands
+			}
+			have = append(have, st)
+		}
+
+		helperImage := "sourcegraph/batcheshelper:test"
+		want := []step{
+			{Key: "step.0.run", Image: helperImage, Dir: ".", Commands: []string{"batcheshelper run 0"}},
+			{Key: "step.0.post", Image: helperImage, Dir: "."},
+			{Key: "step.1.pre", Image: helperImage, Dir: "."},
+			{Key: "step.1.run", Image: "alpine:3", Dir: "repository"},
+			{Key: "step.1.post", Image: helperImage, Dir: "."},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Errorf("unexpected docker steps (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("src-cli execution", func(t *testing.T) {
+		_, err := transformRecord(context.Background(), logtest.Scoped(t), store, &btypes.BatchSpecWorkspaceExecutionJob{ID: 42, UserID: 123}, "0.0.0-dev")
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if want := `step 1 uses the built-in step "write-file", which requires native execution`; err.Error() != want {
+			t.Fatalf("wrong error: have=%q want=%q", err.Error(), want)
+		}
+	})
+}
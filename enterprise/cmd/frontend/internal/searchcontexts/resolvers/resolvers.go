@@ -513,6 +513,49 @@ func (r *searchContextResolver) Repositories(ctx context.Context) ([]graphqlback
 	return searchContextRepositories, nil
 }
 
+func (r *searchContextResolver) QueryRepositoryChanges(ctx context.Context, args *graphqlbackend.SearchContextQueryRepositoryChangesArgs) ([]graphqlbackend.SearchContextRepositoryChangeResolver, error) {
+	if searchcontexts.IsAutoDefinedSearchContext(r.sc) || r.sc.Query == "" || args.First <= 0 {
+		return []graphqlbackend.SearchContextRepositoryChangeResolver{}, nil
+	}
+
+	changes, err := r.db.SearchContexts().ListSearchContextQueryRepoChanges(ctx, r.sc.ID, int(args.First))
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return []graphqlbackend.SearchContextRepositoryChangeResolver{}, nil
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(changes))
+	for _, change := range changes {
+		repoIDs = append(repoIDs, change.RepoID)
+	}
+
+	// 🚨 SECURITY: Repos().GetByIDs only returns the repositories the viewer has access to.
+	repos, err := r.db.Repos().GetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+	reposByID := make(map[api.RepoID]*types.Repo, len(repos))
+	for _, repo := range repos {
+		reposByID[repo.ID] = repo
+	}
+
+	gsClient := gitserver.NewClient(r.db)
+	resolvers := make([]graphqlbackend.SearchContextRepositoryChangeResolver, 0, len(changes))
+	for _, change := range changes {
+		repo, ok := reposByID[change.RepoID]
+		if !ok {
+			continue
+		}
+		resolvers = append(resolvers, &searchContextRepositoryChangeResolver{
+			repository: graphqlbackend.NewRepositoryResolver(r.db, gsClient, repo),
+			change:     change,
+		})
+	}
+	return resolvers, nil
+}
+
 func (r *searchContextResolver) Query() string {
 	return r.sc.Query
 }
@@ -552,3 +595,20 @@ func (r *searchContextRepositoryRevisionsResolver) Repository() *graphqlbackend.
 func (r *searchContextRepositoryRevisionsResolver) Revisions() []string {
 	return r.revisions
 }
+
+type searchContextRepositoryChangeResolver struct {
+	repository *graphqlbackend.RepositoryResolver
+	change     *types.SearchContextRepoChange
+}
+
+func (r *searchContextRepositoryChangeResolver) Repository() *graphqlbackend.RepositoryResolver {
+	return r.repository
+}
+
+func (r *searchContextRepositoryChangeResolver) Added() bool {
+	return r.change.Added
+}
+
+func (r *searchContextRepositoryChangeResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.change.CreatedAt}
+}
@@ -127,6 +127,57 @@ func TestSearchContexts(t *testing.T) {
 	}
 }
 
+func TestSearchContextQueryRepositoryChanges(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	sc := database.NewMockSearchContextsStore()
+	sc.ListSearchContextQueryRepoChangesFunc.SetDefaultReturn([]*types.SearchContextRepoChange{
+		{ID: 3, SearchContextID: 1, RepoID: 3, Added: true},
+		{ID: 2, SearchContextID: 1, RepoID: 2, Added: false},
+		{ID: 1, SearchContextID: 1, RepoID: 1, Added: true},
+	}, nil)
+
+	// Repository 2 is not visible to the viewer.
+	repos := database.NewMockRepoStore()
+	repos.GetByIDsFunc.SetDefaultReturn([]*types.Repo{
+		{ID: 1, Name: "github.com/org/a"},
+		{ID: 3, Name: "github.com/org/c"},
+	}, nil)
+
+	db := database.NewMockDB()
+	db.SearchContextsFunc.SetDefaultReturn(sc)
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	resolver := &searchContextResolver{sc: &types.SearchContext{ID: 1, Name: "ctx", Query: "repo:has.tag(github.topic:payments)"}, db: db}
+	changes, err := resolver.QueryRepositoryChanges(ctx, &graphqlbackend.SearchContextQueryRepositoryChangesArgs{First: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type change struct {
+		Name  string
+		Added bool
+	}
+	var have []change
+	for _, c := range changes {
+		have = append(have, change{Name: c.Repository().Name(), Added: c.Added()})
+	}
+	want := []change{
+		{Name: "github.com/org/c", Added: true},
+		{Name: "github.com/org/a", Added: true},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected changes (-want +got):\n%s", diff)
+	}
+
+	if _, err := (&searchContextResolver{sc: &types.SearchContext{ID: 2, Name: "static"}, db: db}).QueryRepositoryChanges(ctx, &graphqlbackend.SearchContextQueryRepositoryChangesArgs{First: 10}); err != nil {
+		t.Fatal(err)
+	}
+	mockrequire.CalledOnce(t, sc.ListSearchContextQueryRepoChangesFunc)
+}
+
 func TestSearchContextsStarDefaultPermissions(t *testing.T) {
 	t.Parallel()
 
@@ -16,6 +16,8 @@ type Config struct {
 	WorkerBudget          int64
 	MaximumRuntimePerJob  time.Duration
 	LSIFUploadStoreConfig *lsifuploadstore.Config
+
+	SymbolCountRegressionThreshold float64
 }
 
 func (c *Config) Load() {
@@ -26,6 +28,7 @@ func (c *Config) Load() {
 	c.WorkerConcurrency = c.GetInt("PRECISE_CODE_INTEL_WORKER_CONCURRENCY", "1", "The maximum number of indexes that can be processed concurrently.")
 	c.WorkerBudget = int64(c.GetInt("PRECISE_CODE_INTEL_WORKER_BUDGET", "0", "The amount of compressed input data (in bytes) a worker can process concurrently. Zero acts as an infinite budget."))
 	c.MaximumRuntimePerJob = c.GetInterval("PRECISE_CODE_INTEL_WORKER_MAXIMUM_RUNTIME_PER_JOB", "25m", "The maximum time a single LSIF processing job can take.")
+	c.SymbolCountRegressionThreshold = float64(c.GetPercent("PRECISE_CODE_INTEL_WORKER_SYMBOL_COUNT_REGRESSION_THRESHOLD", "0", "The percentage of the previous upload's symbol count below which a new upload for the same repository, root, and indexer is reported as a regression. Zero disables the check.")) / 100
 }
 
 func (c *Config) Validate() error {
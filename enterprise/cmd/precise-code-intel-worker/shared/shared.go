@@ -118,6 +118,7 @@ func Main() {
 		config.WorkerBudget,
 		config.WorkerPollInterval,
 		config.MaximumRuntimePerJob,
+		config.SymbolCountRegressionThreshold,
 	)
 
 	// Initialize health server
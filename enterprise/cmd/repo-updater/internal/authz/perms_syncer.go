@@ -706,6 +706,17 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 		return providerStates, errors.Wrap(s.permsStore.TouchRepoPermissions(ctx, int32(repoID)), "touch repository permissions")
 	}
 
+	// Bitbucket Cloud only exposes repository permissions to administrators of the
+	// workspace. Like above, we don't want the scheduler to keep trying to fetch
+	// permissions of this same repository.
+	if provider.ServiceType() == extsvc.TypeBitbucketCloud && errcode.IsForbidden(err) {
+		logger.Warn("ignoreForbiddenAPIError",
+			log.Error(err),
+			log.String("suggestion", "Bitbucket Cloud connection user must be an administrator of the workspace to read repository permissions"),
+		)
+		return providerStates, errors.Wrap(s.permsStore.TouchRepoPermissions(ctx, int32(repoID)), "touch repository permissions")
+	}
+
 	// Skip repo if unimplemented
 	if errors.Is(err, &authz.ErrUnimplemented{}) {
 		logger.Debug("unimplemented", log.Error(err))
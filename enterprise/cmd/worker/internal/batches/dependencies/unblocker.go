@@ -0,0 +1,125 @@
+package dependencies
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/reconciler"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const unblockerInterval = 1 * time.Minute
+
+// NewUnblocker creates a new goroutine.PeriodicGoroutine that re-enqueues
+// blocked changesets whose prerequisites have all been merged, so that the
+// reconciler publishes or undrafts them, and the ones with closed
+// prerequisites, so that the reconciler fails them.
+func NewUnblocker(ctx context.Context, logger log.Logger, bstore *store.Store) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		"batchchanges.dependency-unblocker", "enqueues changesets whose prerequisites have been merged",
+		unblockerInterval,
+		goroutine.HandlerFunc(func(ctx context.Context) error {
+			return unblock(ctx, logger, bstore)
+		}),
+	)
+}
+
+func unblock(ctx context.Context, logger log.Logger, bstore *store.Store) error {
+	// Changesets that are queued or processing re-evaluate their
+	// prerequisites in the reconciler anyway.
+	blocked, _, err := bstore.ListChangesets(ctx, store.ListChangesetsOpts{
+		OnlyBlocked:      true,
+		ReconcilerStates: []btypes.ReconcilerState{btypes.ReconcilerStateCompleted},
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing blocked changesets")
+	}
+
+	specIDs := make([]int64, 0, len(blocked))
+	for _, ch := range blocked {
+		if ch.CurrentSpecID != 0 {
+			specIDs = append(specIDs, ch.CurrentSpecID)
+		}
+	}
+	if len(specIDs) == 0 {
+		return nil
+	}
+	specs, _, err := bstore.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{IDs: specIDs})
+	if err != nil {
+		return errors.Wrap(err, "loading specs of blocked changesets")
+	}
+	specsByID := make(map[int64]*btypes.ChangesetSpec, len(specs))
+	for _, spec := range specs {
+		specsByID[spec.ID] = spec
+	}
+
+	var errs error
+	unblockedBatchChanges := make(map[int64]struct{})
+	for _, ch := range blocked {
+		spec, ok := specsByID[ch.CurrentSpecID]
+		if !ok {
+			continue
+		}
+
+		// Prerequisites that were closed in the meantime fail the changeset
+		// when it is reconciled, which surfaces the reason to the user.
+		pending, err := reconciler.PrerequisitesPending(ctx, bstore, ch, spec)
+		closed := errors.HasType(err, reconciler.ErrPrerequisitesClosed{})
+		if err != nil && !closed {
+			errs = errors.Append(errs, errors.Wrapf(err, "checking prerequisites of changeset %d", ch.ID))
+			continue
+		}
+		if pending {
+			continue
+		}
+
+		if err := bstore.EnqueueChangeset(ctx, ch, btypes.ReconcilerStateQueued, btypes.ReconcilerStateCompleted); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "enqueueing changeset %d", ch.ID))
+			continue
+		}
+		if closed {
+			logger.Info("prerequisites closed, enqueued changeset", log.Int64("changeset", ch.ID))
+			continue
+		}
+		logger.Info("prerequisites merged, enqueued changeset", log.Int64("changeset", ch.ID))
+		unblockedBatchChanges[ch.OwnedByBatchChangeID] = struct{}{}
+	}
+
+	for batchChangeID := range unblockedBatchChanges {
+		if err := runScheduleNow(ctx, bstore, batchChangeID); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "starting schedule of batch change %d", batchChangeID))
+		}
+	}
+
+	return errs
+}
+
+// runScheduleNow makes the schedule of the batch change due, if it has one
+// that applies its results automatically. Steps that template the state of the
+// prerequisites, like the version of a library that was just released, are
+// then re-run, and the unblocked changesets are updated with the new diff.
+func runScheduleNow(ctx context.Context, bstore *store.Store, batchChangeID int64) error {
+	sched, err := bstore.GetBatchChangeSchedule(ctx, store.GetBatchChangeScheduleOpts{BatchChangeID: batchChangeID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil
+		}
+		return err
+	}
+	if sched.Paused() || sched.AutoApply == btypes.BatchChangeScheduleAutoApplyNever {
+		return nil
+	}
+
+	now := bstore.Clock()()
+	if !sched.NextRunAt.After(now) {
+		return nil
+	}
+	sched.NextRunAt = now
+	return bstore.UpdateBatchChangeSchedule(ctx, sched)
+}
@@ -0,0 +1,65 @@
+package recurring
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const runnerInterval = 1 * time.Minute
+
+// NewRunner creates a new goroutine.PeriodicGoroutine that starts the runs of
+// due batch change schedules, and moves the runs in progress forward.
+func NewRunner(ctx context.Context, logger log.Logger, bstore *store.Store) goroutine.BackgroundRoutine {
+	svc := service.New(bstore)
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		"batchchanges.schedule-runner", "runs batch changes on their schedule",
+		runnerInterval,
+		goroutine.HandlerFunc(func(ctx context.Context) error {
+			var errs error
+
+			// Advance the runs in progress first, so that a run that just
+			// finished doesn't cause the next one to be skipped.
+			runs, err := bstore.ListBatchChangeScheduleRuns(ctx, store.ListBatchChangeScheduleRunsOpts{
+				States: []btypes.BatchChangeScheduleRunState{
+					btypes.BatchChangeScheduleRunStateResolving,
+					btypes.BatchChangeScheduleRunStateExecuting,
+				},
+			})
+			if err != nil {
+				return errors.Wrap(err, "listing active schedule runs")
+			}
+			for _, run := range runs {
+				if err := svc.AdvanceBatchChangeScheduleRun(ctx, run); err != nil {
+					errs = errors.Append(errs, errors.Wrapf(err, "advancing run %d", run.ID))
+				}
+			}
+
+			schedules, err := bstore.ListDueBatchChangeSchedules(ctx)
+			if err != nil {
+				return errors.Append(errs, errors.Wrap(err, "listing due schedules"))
+			}
+			for _, sched := range schedules {
+				run, err := svc.StartBatchChangeScheduleRun(ctx, sched)
+				if err != nil {
+					errs = errors.Append(errs, errors.Wrapf(err, "starting run of schedule %d", sched.ID))
+					continue
+				}
+				if run == nil {
+					logger.Info("skipped scheduled run, previous run still in progress", log.Int64("batchChangeID", sched.BatchChangeID))
+				}
+			}
+
+			return errs
+		}),
+	)
+}
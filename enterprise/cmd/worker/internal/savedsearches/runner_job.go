@@ -0,0 +1,37 @@
+package savedsearches
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/savedsearches"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type runnerJob struct{}
+
+func NewRunnerJob() job.Job {
+	return &runnerJob{}
+}
+
+func (j *runnerJob) Description() string {
+	return "savedsearches.Runner runs scheduled saved searches and notifies about the matches added or removed since their previous run."
+}
+
+func (j *runnerJob) Config() []env.Config {
+	return nil
+}
+
+func (j *runnerJob) Routines(startupCtx context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []goroutine.BackgroundRoutine{
+		savedsearches.NewRunJob(context.Background(), db),
+	}, nil
+}
@@ -127,7 +127,7 @@ func TestHandlerLoadsEvents(t *testing.T) {
 			t.Fatal(err)
 		}
 	})
-	flags := make(map[string]bool)
+	flags := make(featureflag.EvaluatedFlagSet)
 	flags["testflag"] = true
 
 	ptr := func(s string) *string {
@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/executors"
 	workerinsights "github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/insights"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/permissions"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/savedsearches"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/telemetry"
 	eiauthz "github.com/sourcegraph/sourcegraph/enterprise/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
@@ -35,6 +36,7 @@ var AdditionalJobs = map[string]job.Job{
 	"executors-janitor":             executors.NewJanitorJob(),
 	"executors-metricsserver":       executors.NewMetricsServerJob(),
 	"codemonitors-job":              codemonitors.NewCodeMonitorJob(),
+	"saved-searches-runner":         savedsearches.NewRunnerJob(),
 	"bitbucket-project-permissions": permissions.NewBitbucketProjectPermissionsJob(),
 	"export-usage-telemetry":        telemetry.NewTelemetryJob(),
 	"webhook-build-job":             repos.NewWebhookBuildJob(),
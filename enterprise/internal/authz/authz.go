@@ -9,6 +9,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/github"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/gitlab"
@@ -60,6 +61,7 @@ func ProvidersFromConfig(
 			extsvc.KindGitHub,
 			extsvc.KindGitLab,
 			extsvc.KindBitbucketServer,
+			extsvc.KindBitbucketCloud,
 			extsvc.KindPerforce,
 		},
 		LimitOffset: &database.LimitOffset{
@@ -71,6 +73,7 @@ func ProvidersFromConfig(
 		gitHubConns          []*github.ExternalConnection
 		gitLabConns          []*types.GitLabConnection
 		bitbucketServerConns []*types.BitbucketServerConnection
+		bitbucketCloudConns  []*types.BitbucketCloudConnection
 		perforceConns        []*types.PerforceConnection
 	)
 	for {
@@ -116,6 +119,11 @@ func ProvidersFromConfig(
 					URN:                       svc.URN(),
 					BitbucketServerConnection: c,
 				})
+			case *schema.BitbucketCloudConnection:
+				bitbucketCloudConns = append(bitbucketCloudConns, &types.BitbucketCloudConnection{
+					URN:                      svc.URN(),
+					BitbucketCloudConnection: c,
+				})
 			case *schema.PerforceConnection:
 				perforceConns = append(perforceConns, &types.PerforceConnection{
 					URN:                svc.URN(),
@@ -162,6 +170,14 @@ func ProvidersFromConfig(
 		invalidConnections = append(invalidConnections, bbsInvalidConnections...)
 	}
 
+	if len(bitbucketCloudConns) > 0 {
+		bbcloudProviders, bbcloudProblems, bbcloudWarnings, bbcloudInvalidConnections := bitbucketcloud.NewAuthzProviders(bitbucketCloudConns)
+		providers = append(providers, bbcloudProviders...)
+		seriousProblems = append(seriousProblems, bbcloudProblems...)
+		warnings = append(warnings, bbcloudWarnings...)
+		invalidConnections = append(invalidConnections, bbcloudInvalidConnections...)
+	}
+
 	if len(perforceConns) > 0 {
 		pfProviders, pfProblems, pfWarnings, pfInvalidConnections := perforce.NewAuthzProviders(perforceConns, db)
 		providers = append(providers, pfProviders...)
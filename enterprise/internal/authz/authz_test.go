@@ -85,6 +85,7 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 		cfg                          conf.Unified
 		gitlabConnections            []*schema.GitLabConnection
 		bitbucketServerConnections   []*schema.BitbucketServerConnection
+		bitbucketCloudConnections    []*schema.BitbucketCloudConnection
 		expAuthzAllowAccessByDefault bool
 		expAuthzProviders            func(*testing.T, []authz.Provider)
 		expSeriousProblems           []string
@@ -396,6 +397,47 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 				}
 			},
 		},
+		{
+			description: "1 Bitbucket Cloud connection with authz disabled",
+			bitbucketCloudConnections: []*schema.BitbucketCloudConnection{
+				{
+					Authorization: nil,
+					Url:           "https://bitbucket.org",
+					Username:      "admin",
+					AppPassword:   "secret-password",
+				},
+			},
+			expAuthzAllowAccessByDefault: true,
+			expAuthzProviders:            providersEqual(),
+		},
+		{
+			description: "Bitbucket Cloud username identity",
+			cfg:         conf.Unified{},
+			bitbucketCloudConnections: []*schema.BitbucketCloudConnection{
+				{
+					Authorization: &schema.BitbucketCloudAuthorization{
+						IdentityProvider: schema.BitbucketCloudIdentityProvider{
+							Username: &schema.BitbucketCloudUsernameIdentity{
+								Type: "username",
+							},
+						},
+					},
+					Url:         "https://bitbucket.org",
+					Username:    "admin",
+					AppPassword: "secret-password",
+				},
+			},
+			expAuthzAllowAccessByDefault: true,
+			expAuthzProviders: func(t *testing.T, have []authz.Provider) {
+				if len(have) == 0 {
+					t.Fatalf("no providers")
+				}
+
+				if have[0].ServiceType() != extsvc.TypeBitbucketCloud {
+					t.Fatalf("no Bitbucket Cloud authz provider returned")
+				}
+			},
+		},
 
 		// For Sourcegraph authz provider
 		{
@@ -489,6 +531,13 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 								Config: extsvc.NewUnencryptedConfig(mustMarshalJSONString(bbs)),
 							})
 						}
+					case extsvc.KindBitbucketCloud:
+						for _, bbcloud := range test.bitbucketCloudConnections {
+							svcs = append(svcs, &types.ExternalService{
+								Kind:   kind,
+								Config: extsvc.NewUnencryptedConfig(mustMarshalJSONString(bbcloud)),
+							})
+						}
 					case extsvc.KindGitHub, extsvc.KindPerforce:
 					default:
 						return nil, errors.Errorf("unexpected kind: %s", kind)
@@ -519,6 +568,7 @@ func TestAuthzProvidersEnabledACLsDisabled(t *testing.T) {
 		cfg                        conf.Unified
 		gitlabConnections          []*schema.GitLabConnection
 		bitbucketServerConnections []*schema.BitbucketServerConnection
+		bitbucketCloudConnections  []*schema.BitbucketCloudConnection
 		githubConnections          []*schema.GitHubConnection
 		perforceConnections        []*schema.PerforceConnection
 
@@ -600,6 +650,26 @@ func TestAuthzProvidersEnabledACLsDisabled(t *testing.T) {
 			expSeriousProblems:    []string{"failed"},
 			expInvalidConnections: []string{"bitbucketServer"},
 		},
+		{
+			description: "Bitbucket Cloud connection with authz enabled but missing license for ACLs",
+			cfg:         conf.Unified{},
+			bitbucketCloudConnections: []*schema.BitbucketCloudConnection{
+				{
+					Authorization: &schema.BitbucketCloudAuthorization{
+						IdentityProvider: schema.BitbucketCloudIdentityProvider{
+							Username: &schema.BitbucketCloudUsernameIdentity{
+								Type: "username",
+							},
+						},
+					},
+					Url:         "https://bitbucket.org",
+					Username:    "admin",
+					AppPassword: "secret-password",
+				},
+			},
+			expSeriousProblems:    []string{"failed"},
+			expInvalidConnections: []string{"bitbucketCloud"},
+		},
 		{
 			description: "Perforce connection with authz enabled but missing license for ACLs",
 			cfg:         conf.Unified{},
@@ -647,6 +717,13 @@ func TestAuthzProvidersEnabledACLsDisabled(t *testing.T) {
 								Config: extsvc.NewUnencryptedConfig(mustMarshalJSONString(bbs)),
 							})
 						}
+					case extsvc.KindBitbucketCloud:
+						for _, bbcloud := range test.bitbucketCloudConnections {
+							svcs = append(svcs, &types.ExternalService{
+								Kind:   kind,
+								Config: extsvc.NewUnencryptedConfig(mustMarshalJSONString(bbcloud)),
+							})
+						}
 					case extsvc.KindGitHub:
 						for _, gh := range test.githubConnections {
 							svcs = append(svcs, &types.ExternalService{
@@ -0,0 +1,92 @@
+package bitbucketcloud
+
+import (
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewAuthzProviders returns the set of Bitbucket Cloud authz providers derived from the connections.
+//
+// It also returns any simple validation problems with the config, separating these into "serious problems"
+// and "warnings". "Serious problems" are those that should make Sourcegraph set authz.allowAccessByDefault
+// to false. "Warnings" are all other validation problems.
+//
+// This constructor does not and should not directly check connectivity to external services - if
+// desired, callers should use `(*Provider).ValidateConnection` directly to get warnings related
+// to connection issues.
+func NewAuthzProviders(
+	conns []*types.BitbucketCloudConnection,
+) (ps []authz.Provider, problems []string, warnings []string, invalidConnections []string) {
+	// Authorization (i.e., permissions) providers
+	for _, c := range conns {
+		p, err := newAuthzProvider(c)
+		if err != nil {
+			invalidConnections = append(invalidConnections, extsvc.TypeBitbucketCloud)
+			problems = append(problems, err.Error())
+		} else if p != nil {
+			ps = append(ps, p)
+		}
+	}
+
+	return ps, problems, warnings, invalidConnections
+}
+
+func newAuthzProvider(c *types.BitbucketCloudConnection) (authz.Provider, error) {
+	if c.Authorization == nil {
+		return nil, nil
+	}
+
+	if errLicense := licensing.Check(licensing.FeatureACLs); errLicense != nil {
+		return nil, errLicense
+	}
+
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, errors.Errorf("Could not parse URL for Bitbucket Cloud instance %q: %s", c.Url, err)
+	}
+
+	cli, err := bitbucketcloud.NewClient(c.URN, c.BitbucketCloudConnection, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Authorization.IdentityProvider.Username == nil {
+		return nil, errors.New("No identityProvider was specified")
+	}
+
+	return NewProvider(cli, ProviderOptions{
+		URN:        c.URN,
+		BaseURL:    baseURL,
+		Workspaces: workspaces(c.BitbucketCloudConnection),
+	}), nil
+}
+
+// workspaces returns the workspaces whose repositories are mirrored by the
+// given connection: the personal workspace of the configured user, followed by
+// every configured team.
+func workspaces(c *schema.BitbucketCloudConnection) []string {
+	seen := map[string]struct{}{}
+	var ws []string
+	for _, w := range append([]string{c.Username}, c.Teams...) {
+		if _, ok := seen[w]; ok || w == "" {
+			continue
+		}
+		seen[w] = struct{}{}
+		ws = append(ws, w)
+	}
+	return ws
+}
+
+// ValidateAuthz validates the authorization fields of the given Bitbucket
+// Cloud external service config.
+func ValidateAuthz(c *schema.BitbucketCloudConnection) error {
+	_, err := newAuthzProvider(&types.BitbucketCloudConnection{BitbucketCloudConnection: c})
+	return err
+}
@@ -0,0 +1,251 @@
+// Package bitbucketcloud contains an authorization provider for Bitbucket Cloud.
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Provider is an implementation of AuthzProvider that provides repository permissions as
+// determined from the Bitbucket Cloud API.
+type Provider struct {
+	urn        string
+	client     bitbucketcloud.Client
+	codeHost   *extsvc.CodeHost
+	workspaces []string
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// ProviderOptions contains the options of a Bitbucket Cloud authorization
+// provider.
+type ProviderOptions struct {
+	URN     string
+	BaseURL *url.URL
+
+	// Workspaces are the workspaces whose repositories and members are
+	// considered when computing permissions. The client must be authenticated
+	// as an administrator of each of them.
+	Workspaces []string
+}
+
+// NewProvider returns a new Bitbucket Cloud authorization provider that uses
+// the given bitbucketcloud.Client to talk to the Bitbucket Cloud API that is
+// the source of truth for permissions.
+func NewProvider(cli bitbucketcloud.Client, opts ProviderOptions) *Provider {
+	return &Provider{
+		urn:        opts.URN,
+		client:     cli,
+		codeHost:   extsvc.NewCodeHost(opts.BaseURL, extsvc.TypeBitbucketCloud),
+		workspaces: opts.Workspaces,
+	}
+}
+
+// ValidateConnection validates that the Provider can read the members of every
+// workspace it is configured with, which requires administrator access.
+func (p *Provider) ValidateConnection(ctx context.Context) (problems []string) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := p.client.Ping(ctx); err != nil {
+		return []string{err.Error()}
+	}
+
+	for _, ws := range p.workspaces {
+		rs, err := p.client.WorkspaceMembers(ws)
+		if err == nil {
+			_, err = rs.WithPageLength(1).Next(ctx)
+		}
+		if err != nil {
+			problems = append(problems, errors.Wrapf(err, "listing members of workspace %q", ws).Error())
+		}
+	}
+
+	return problems
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the absolute URL that identifies the Bitbucket Cloud instance
+// this provider is configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "bitbucketCloud".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount satisfies the authz.Provider interface. It looks for a member of
+// the configured workspaces whose nickname is the username of the given user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account, _ []string) (acct *extsvc.Account, err error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	tr, ctx := trace.New(ctx, "bitbucketcloud.authz.provider.FetchAccount", "")
+	defer func() {
+		tr.LogFields(
+			otlog.String("user.name", user.Username),
+			otlog.Int32("user.id", user.ID),
+		)
+
+		if err != nil {
+			tr.SetError(err)
+		}
+
+		tr.Finish()
+	}()
+
+	bitbucketUser, err := p.member(ctx, user.Username)
+	if err != nil || bitbucketUser == nil {
+		return nil, err
+	}
+
+	accountData, err := json.Marshal(bitbucketUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   bitbucketUser.UUID,
+		},
+		AccountData: extsvc.AccountData{
+			Data: extsvc.NewUnencryptedData(accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms returns a list of repository UUIDs (on code host) that the given account
+// has read access on the code host. The repository ID has the same value as it would be
+// used as api.ExternalRepoSpec.ID.
+// The workspace permissions of the account are read with the credentials of
+// the connection.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-get
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	ids := make(map[extsvc.RepoID]struct{})
+	var errs error
+	for _, ws := range p.workspaces {
+		rs, err := p.client.UserRepoPermissions(ws, account.AccountID)
+		if err != nil {
+			return nil, err
+		}
+
+		perms, err := rs.All(ctx)
+		if err != nil {
+			// The connection credentials are used here, so the error must not be
+			// mistaken for a revoked token of the account: the cause is not wrapped.
+			errs = errors.Append(errs, errors.Errorf("listing permissions in workspace %q: %s", ws, err))
+			continue
+		}
+		for _, perm := range perms {
+			ids[extsvc.RepoID(perm.(*bitbucketcloud.RepoPermission).Repo.UUID)] = struct{}{}
+		}
+	}
+
+	return &authz.ExternalUserPermissions{
+		Exacts: sortedKeys(ids),
+	}, errs
+}
+
+// FetchRepoPerms returns a list of user UUIDs (on code host) who have read access to
+// the given repo on the code host. The user ID has the same value as it would
+// be used as extsvc.Account.AccountID. The returned list includes both direct access
+// and access inherited from workspace, project and group permissions.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-repo-slug-get
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	switch {
+	case repo == nil:
+		return nil, errors.New("no repo provided")
+	case !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec):
+		return nil, errors.Errorf("not a code host of the repo: want %q but have %q",
+			p.codeHost.ServiceID, repo.ServiceID)
+	}
+
+	// The repository UUID is all we know about the repository, and the API
+	// accepts an empty workspace UUID when the repository is given by UUID.
+	bbRepo, err := p.client.Repo(ctx, "{}", repo.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting repository")
+	}
+
+	ws, err := bbRepo.Namespace()
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := p.client.RepoPermissions(ws, bbRepo.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	perms, err := rs.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]extsvc.AccountID, 0, len(perms))
+	for _, perm := range perms {
+		ids = append(ids, extsvc.AccountID(perm.(*bitbucketcloud.RepoPermission).User.UUID))
+	}
+
+	return ids, nil
+}
+
+// member returns the member of the configured workspaces whose nickname is
+// the given username, or nil if there is none.
+func (p *Provider) member(ctx context.Context, username string) (*bitbucketcloud.Account, error) {
+	for _, ws := range p.workspaces {
+		m, err := p.client.WorkspaceMember(ctx, ws, username)
+		if err != nil {
+			// Members of other workspaces may still match.
+			if errcode.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "looking up member of workspace %q", ws)
+		}
+		if m != nil {
+			return m, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func sortedKeys(ids map[extsvc.RepoID]struct{}) []extsvc.RepoID {
+	keys := make([]extsvc.RepoID, 0, len(ids))
+	for id := range ids {
+		keys = append(keys, id)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
@@ -0,0 +1,208 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"flag"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/httptestutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var update = flag.Bool("update", false, "update testdata")
+
+const (
+	aliceUUID  = "{2d0bb4e5-0c2a-4a63-9b0b-5b8b0c9e7d1a}"
+	srcCLIUUID = "{b090a669-ac7b-44cd-9610-02d027cb39f3}"
+	sgUUID     = "{c9514714-1168-4b80-8de1-d3d8359cc293}"
+)
+
+func TestProvider_FetchAccount(t *testing.T) {
+	ctx := context.Background()
+	p := newProvider(newRecordingClient(t, "FetchAccount"))
+
+	acct, err := p.FetchAccount(ctx, &types.User{ID: 42, Username: "alice"}, nil, nil)
+	require.NoError(t, err)
+	require.NotNil(t, acct)
+	assert.Equal(t, int32(42), acct.UserID)
+	assert.Equal(t, extsvc.AccountSpec{
+		ServiceType: extsvc.TypeBitbucketCloud,
+		ServiceID:   "https://bitbucket.org/",
+		AccountID:   aliceUUID,
+	}, acct.AccountSpec)
+
+	acct, err = p.FetchAccount(ctx, &types.User{ID: 43, Username: "mallory"}, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, acct)
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nil account", func(t *testing.T) {
+		p := newProvider(newClient(t, nil))
+		_, err := p.FetchUserPerms(ctx, nil, authz.FetchPermsOptions{})
+		assert.EqualError(t, err, "no account provided")
+	})
+
+	t.Run("not the code host of the account", func(t *testing.T) {
+		p := newProvider(newClient(t, nil))
+		_, err := p.FetchUserPerms(ctx, &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitHub,
+				ServiceID:   "https://github.com/",
+				AccountID:   "alice",
+			},
+		}, authz.FetchPermsOptions{})
+		assert.EqualError(t, err, `not a code host of the account: want "https://bitbucket.org/" but have "https://github.com/"`)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		p := newProvider(newRecordingClient(t, "FetchUserPerms"))
+
+		perms, err := p.FetchUserPerms(ctx, account(aliceUUID), authz.FetchPermsOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []extsvc.RepoID{srcCLIUUID, sgUUID}, perms.Exacts)
+	})
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &extsvc.Repository{
+		URI: "bitbucket.org/sourcegraph-testing/src-cli",
+		ExternalRepoSpec: api.ExternalRepoSpec{
+			ID:          srcCLIUUID,
+			ServiceType: extsvc.TypeBitbucketCloud,
+			ServiceID:   "https://bitbucket.org/",
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		p := newProvider(newRecordingClient(t, "FetchRepoPerms"))
+
+		ids, err := p.FetchRepoPerms(ctx, repo, authz.FetchPermsOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []extsvc.AccountID{"{4b85b785-1433-4092-8512-20302f4a03be}", aliceUUID}, ids)
+	})
+
+	t.Run("not a workspace administrator", func(t *testing.T) {
+		p := newProvider(newRecordingClient(t, "FetchRepoPerms-forbidden"))
+
+		_, err := p.FetchRepoPerms(ctx, repo, authz.FetchPermsOptions{})
+		assert.True(t, errcode.IsForbidden(err))
+	})
+}
+
+func TestNewAuthzProviders(t *testing.T) {
+	licensing.MockCheckFeatureError("")
+	t.Cleanup(func() { licensing.MockCheckFeatureError("") })
+
+	ps, problems, warnings, invalid := NewAuthzProviders([]*types.BitbucketCloudConnection{
+		{
+			URN: "extsvc:bitbucketcloud:1",
+			BitbucketCloudConnection: &schema.BitbucketCloudConnection{
+				Url:         "https://bitbucket.org",
+				Username:    "sourcegraph-testing",
+				AppPassword: "secret",
+				Teams:       []string{"sourcegraph-testing", "other-team"},
+				Authorization: &schema.BitbucketCloudAuthorization{
+					IdentityProvider: schema.BitbucketCloudIdentityProvider{
+						Username: &schema.BitbucketCloudUsernameIdentity{Type: "username"},
+					},
+				},
+			},
+		},
+		{
+			URN: "extsvc:bitbucketcloud:2",
+			BitbucketCloudConnection: &schema.BitbucketCloudConnection{
+				Url:         "https://bitbucket.org",
+				Username:    "sourcegraph-testing",
+				AppPassword: "secret",
+			},
+		},
+	})
+	assert.Empty(t, problems)
+	assert.Empty(t, warnings)
+	assert.Empty(t, invalid)
+	require.Len(t, ps, 1)
+
+	p := ps[0].(*Provider)
+	assert.Equal(t, "extsvc:bitbucketcloud:1", p.URN())
+	assert.Equal(t, []string{"sourcegraph-testing", "other-team"}, p.workspaces)
+}
+
+func account(uuid string) *extsvc.Account {
+	return &extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: extsvc.TypeBitbucketCloud,
+			ServiceID:   "https://bitbucket.org/",
+			AccountID:   uuid,
+		},
+	}
+}
+
+func newProvider(cli bitbucketcloud.Client) *Provider {
+	baseURL, _ := url.Parse("https://bitbucket.org")
+	return NewProvider(cli, ProviderOptions{
+		URN:        "urn",
+		BaseURL:    baseURL,
+		Workspaces: []string{"sourcegraph-testing"},
+	})
+}
+
+// newRecordingClient returns a bitbucketcloud.Client that records its
+// interactions to testdata/vcr/{name}.
+func newRecordingClient(t *testing.T, name string) bitbucketcloud.Client {
+	t.Helper()
+
+	rec, err := httptestutil.NewRecorder(filepath.Join("testdata/vcr/", name), *update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := rec.Stop(); err != nil {
+			t.Errorf("failed to update test data: %s", err)
+		}
+	})
+
+	hc, err := httpcli.NewFactory(nil, httptestutil.NewRecorderOpt(rec)).Doer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return newClient(t, hc)
+}
+
+func newClient(t *testing.T, hc httpcli.Doer) bitbucketcloud.Client {
+	t.Helper()
+
+	username := os.Getenv("BITBUCKET_CLOUD_USERNAME")
+	if username == "" {
+		username = "sourcegraph-testing"
+	}
+
+	cli, err := bitbucketcloud.NewClient("urn", &schema.BitbucketCloudConnection{
+		ApiURL:      "https://api.bitbucket.org",
+		Username:    username,
+		AppPassword: os.Getenv("BITBUCKET_CLOUD_APP_PASSWORD"),
+	}, hc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -39,6 +40,7 @@ func executePlan(ctx context.Context, logger log.Logger, client gitserver.Client
 		tx:                tx,
 		ch:                plan.Changeset,
 		spec:              plan.ChangesetSpec,
+		autoMerge:         plan.AutoMerge,
 	}
 
 	return e.Run(ctx, plan)
@@ -52,6 +54,7 @@ type executor struct {
 	tx                *store.Store
 	ch                *btypes.Changeset
 	spec              *btypes.ChangesetSpec
+	autoMerge         btypes.AutoMergeAction
 
 	// targetRepo represents the repo where the changeset should be opened.
 	targetRepo *types.Repo
@@ -104,7 +107,7 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 			err = e.reopenChangeset(ctx)
 
 		case btypes.ReconcilerOperationUpdate:
-			err = e.updateChangeset(ctx)
+			err = e.updateChangeset(ctx, plan.Delta)
 
 		case btypes.ReconcilerOperationUndraft:
 			err = e.undraftChangeset(ctx)
@@ -124,6 +127,9 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		case btypes.ReconcilerOperationReattach:
 			e.reattachChangeset()
 
+		case btypes.ReconcilerOperationMerge:
+			err = e.mergeChangeset(ctx)
+
 		default:
 			err = errors.Errorf("executor operation %q not implemented", op)
 		}
@@ -225,13 +231,18 @@ func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err erro
 	}
 
 	cs := &sources.Changeset{
-		Title:      e.spec.Title,
-		Body:       body,
-		BaseRef:    e.spec.BaseRef,
-		HeadRef:    e.spec.HeadRef,
-		RemoteRepo: remoteRepo,
-		TargetRepo: e.targetRepo,
-		Changeset:  e.ch,
+		Title:         e.spec.Title,
+		Body:          body,
+		BaseRef:       e.spec.BaseRef,
+		HeadRef:       e.spec.HeadRef,
+		Reviewers:     e.spec.Reviewers,
+		TeamReviewers: e.spec.TeamReviewers,
+		Labels:        e.spec.Labels,
+		Assignees:     e.spec.Assignees,
+		Milestone:     e.spec.Milestone,
+		RemoteRepo:    remoteRepo,
+		TargetRepo:    e.targetRepo,
+		Changeset:     e.ch,
 	}
 
 	var exists bool
@@ -322,7 +333,7 @@ func (e *executor) loadChangeset(ctx context.Context) error {
 
 // updateChangeset updates the given changeset's attribute on the code host
 // according to its ChangesetSpec and the delta previously computed.
-func (e *executor) updateChangeset(ctx context.Context) (err error) {
+func (e *executor) updateChangeset(ctx context.Context, delta *ChangesetSpecDelta) (err error) {
 	// Depending on the changeset, we may want to add to the body (for example,
 	// to add a backlink to Sourcegraph).
 	body, err := e.decorateChangesetBody(ctx)
@@ -351,6 +362,22 @@ func (e *executor) updateChangeset(ctx context.Context) (err error) {
 		TargetRepo: e.targetRepo,
 		Changeset:  e.ch,
 	}
+	// Only the reviewers, labels, assignees and milestone that changed in the
+	// spec are sent to the code host, so that updating the title of a
+	// changeset doesn't request reviews again or re-add labels that were
+	// removed on the code host.
+	if delta == nil || delta.ReviewersChanged {
+		cs.Reviewers, cs.TeamReviewers = e.spec.Reviewers, e.spec.TeamReviewers
+	}
+	if delta == nil || delta.LabelsChanged {
+		cs.Labels = e.spec.Labels
+	}
+	if delta == nil || delta.AssigneesChanged {
+		cs.Assignees = e.spec.Assignees
+	}
+	if delta == nil || delta.MilestoneChanged {
+		cs.Milestone = e.spec.Milestone
+	}
 
 	if err := css.UpdateChangeset(ctx, &cs); err != nil {
 		if errcode.IsArchived(err) {
@@ -452,6 +479,84 @@ func (e *executor) closeChangeset(ctx context.Context) (err error) {
 	return nil
 }
 
+var errRebaseMergeUnsupported = errcode.MakeNonRetryable(errors.New("the code host does not support rebase merges"))
+
+// mergeChangeset merges the changeset on its code host according to the
+// autoMerge policy of its spec, and records the merge as a changeset event.
+func (e *executor) mergeChangeset(ctx context.Context) (err error) {
+	policy := e.spec.AutoMerge
+	if policy == nil {
+		return nil
+	}
+
+	css, err := e.changesetSource(ctx)
+	if err != nil {
+		return err
+	}
+
+	remoteRepo, err := e.remoteRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	cs := &sources.Changeset{
+		Changeset:  e.ch,
+		RemoteRepo: remoteRepo,
+		TargetRepo: e.targetRepo,
+	}
+
+	// The head of the changeset changes when it's merged with a squash or
+	// rebase, so we need to remember it beforehand.
+	headRefOid, err := e.ch.HeadRefOid()
+	if err != nil {
+		return err
+	}
+
+	amcss, supportsAutoMerge := css.(sources.AutoMergeChangesetSource)
+	native := e.autoMerge == btypes.AutoMergeActionNative && supportsAutoMerge
+	switch {
+	case native:
+		err = amcss.EnableAutoMerge(ctx, cs, policy.MergeMethod)
+	case supportsAutoMerge:
+		err = amcss.MergeChangesetWithMethod(ctx, cs, policy.MergeMethod)
+	case policy.MergeMethod == batcheslib.AutoMergeMethodRebase:
+		return errRebaseMergeUnsupported
+	default:
+		err = css.MergeChangeset(ctx, cs, policy.MergeMethod == batcheslib.AutoMergeMethodSquash)
+	}
+	if err != nil {
+		if !errors.HasType(err, sources.ChangesetNotMergeableError{}) {
+			return errors.Wrap(err, "merging changeset")
+		}
+		// The code host may have merged the changeset in the meantime, if we
+		// enabled its auto-merge earlier. That's not an error.
+		if loadErr := css.LoadChangeset(ctx, cs); loadErr != nil {
+			return errors.Wrap(err, "merging changeset")
+		}
+		events, eventsErr := e.ch.Events()
+		if eventsErr != nil {
+			return errors.Wrap(err, "merging changeset")
+		}
+		state.SetDerivedState(ctx, e.tx.Repos(), e.client, e.ch, events)
+		if e.ch.ExternalState != btypes.ChangesetExternalStateMerged {
+			return errors.Wrap(err, "merging changeset")
+		}
+		return nil
+	}
+
+	return e.tx.UpsertChangesetEvents(ctx, &btypes.ChangesetEvent{
+		ChangesetID: e.ch.ID,
+		Kind:        btypes.ChangesetEventKindAutoMerged,
+		Key:         (&btypes.AutoMergedEvent{HeadRefOid: headRefOid, Native: native}).Key(),
+		Metadata: &btypes.AutoMergedEvent{
+			MergeMethod: policy.MergeMethod,
+			Native:      native,
+			HeadRefOid:  headRefOid,
+			CreatedAt:   e.tx.Clock()(),
+		},
+	})
+}
+
 // undraftChangeset marks the given changeset on its code host as ready for review.
 func (e *executor) undraftChangeset(ctx context.Context) (err error) {
 	css, err := e.changesetSource(ctx)
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -83,6 +84,7 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 	type testCase struct {
 		changeset      bt.TestChangesetOpts
 		hasCurrentSpec bool
+		specLabels     []string
 		plan           *Plan
 
 		sourcerMetadata any
@@ -103,6 +105,8 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 		wantReopenOnCodeHost      bool
 
 		wantGitserverCommit bool
+		// The labels passed to CreateChangeset/UpdateChangeset
+		wantLabels []string
 
 		wantChangeset       bt.ChangesetAssertions
 		wantNonRetryableErr bool
@@ -283,6 +287,61 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 				Body:  githubPR.Body,
 			},
 		},
+		"update with unchanged labels": {
+			hasCurrentSpec: true,
+			specLabels:     []string{"batch-change"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalID:       "12345",
+				ExternalBranch:   "head-ref-on-github",
+			},
+
+			plan: &Plan{
+				Ops:   Operations{btypes.ReconcilerOperationUpdate},
+				Delta: &ChangesetSpecDelta{TitleChanged: true},
+			},
+
+			wantUpdateOnCodeHost: true,
+			// The labels aren't added again, since they didn't change.
+			wantLabels: nil,
+
+			wantChangeset: bt.ChangesetAssertions{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalID:       githubPR.ID,
+				ExternalBranch:   githubHeadRef,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				DiffStat:         state.DiffStat,
+				Title:            githubPR.Title,
+				Body:             githubPR.Body,
+			},
+		},
+		"update with changed labels": {
+			hasCurrentSpec: true,
+			specLabels:     []string{"batch-change"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalID:       "12345",
+				ExternalBranch:   "head-ref-on-github",
+			},
+
+			plan: &Plan{
+				Ops:   Operations{btypes.ReconcilerOperationUpdate},
+				Delta: &ChangesetSpecDelta{LabelsChanged: true},
+			},
+
+			wantUpdateOnCodeHost: true,
+			wantLabels:           []string{"batch-change"},
+
+			wantChangeset: bt.ChangesetAssertions{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalID:       githubPR.ID,
+				ExternalBranch:   githubHeadRef,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				DiffStat:         state.DiffStat,
+				Title:            githubPR.Title,
+				Body:             githubPR.Body,
+			},
+		},
 		"update to archived repo": {
 			hasCurrentSpec: true,
 			changeset: bt.TestChangesetOpts{
@@ -576,6 +635,7 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 					Repo:      repo.ID,
 					BatchSpec: batchSpec.ID,
 					Typ:       btypes.ChangesetSpecTypeBranch,
+					Labels:    tc.specLabels,
 				}
 				changesetSpec = bt.CreateChangesetSpec(t, ctx, bstore, specOpts)
 			}
@@ -731,6 +791,9 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 				if !strings.Contains(rcs.Body, "Created by Sourcegraph batch change") {
 					t.Errorf("did not find backlink in body: %q", rcs.Body)
 				}
+				if diff := cmp.Diff(tc.wantLabels, rcs.Labels, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("wrong labels (-want +got):\n%s", diff)
+				}
 			}
 
 			// Ensure the detached_at timestamp is set when the operation is detach
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -24,6 +25,7 @@ var operationPrecedence = map[btypes.ReconcilerOperation]int{
 	btypes.ReconcilerOperationUpdate:       4,
 	btypes.ReconcilerOperationSleep:        5,
 	btypes.ReconcilerOperationSync:         6,
+	btypes.ReconcilerOperationMerge:        7,
 }
 
 type Operations []btypes.ReconcilerOperation
@@ -98,6 +100,10 @@ type Plan struct {
 	// The Delta between a possible previous ChangesetSpec and the current
 	// ChangesetSpec.
 	Delta *ChangesetSpecDelta
+
+	// AutoMerge is how the changeset is merged if the plan contains the merge
+	// operation.
+	AutoMerge btypes.AutoMergeAction
 }
 
 func (p *Plan) AddOp(op btypes.ReconcilerOperation) { p.Ops = append(p.Ops, op) }
@@ -245,13 +251,68 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 			}
 		}
 
+		// Merge the changeset if its synced state satisfies the autoMerge
+		// policy of the spec. We only do that once nothing else needs to be
+		// done, so that we never merge a changeset that is out of date.
+		if pl.Ops.IsNone() {
+			action, err := wantedChangeset.AutoMergeAction(currentSpec.AutoMerge, timeutil.Now())
+			if err != nil {
+				return pl, err
+			}
+			if action != btypes.AutoMergeActionNone {
+				pl.AutoMerge = action
+				pl.AddOp(btypes.ReconcilerOperationMerge)
+			}
+		}
+
 	default:
 		return pl, errors.Errorf("unknown changeset publication state: %s", wantedChangeset.PublicationState)
 	}
 
+	if wantedChangeset.Blocked {
+		pl.holdBackForDependencies()
+	}
+
 	return pl, nil
 }
 
+// holdBackForDependencies rewrites the plan of a changeset whose prerequisites
+// haven't been merged yet: instead of being published, it is published as a
+// draft if the code host supports it and left unpublished otherwise. A blocked
+// changeset is never undrafted or merged, but its commit and its attributes
+// are kept up to date.
+func (p *Plan) holdBackForDependencies() {
+	supportsDraft := p.Changeset.SupportsDraft()
+	publishing := false
+	for _, op := range p.Ops {
+		if op == btypes.ReconcilerOperationPublish {
+			publishing = true
+		}
+	}
+
+	ops := make(Operations, 0, len(p.Ops))
+	for _, op := range p.Ops {
+		switch op {
+		case btypes.ReconcilerOperationPublish:
+			if supportsDraft {
+				ops = append(ops, btypes.ReconcilerOperationPublishDraft)
+			}
+		case btypes.ReconcilerOperationPush:
+			// Without a draft to push to, the commit is pushed once the
+			// changeset is published.
+			if !publishing || supportsDraft {
+				ops = append(ops, op)
+			}
+		case btypes.ReconcilerOperationUndraft, btypes.ReconcilerOperationMerge:
+			// These have to wait for the prerequisites.
+		default:
+			ops = append(ops, op)
+		}
+	}
+	p.Ops = ops
+	p.AutoMerge = btypes.AutoMergeActionNone
+}
+
 func reopenAfterDetach(ch *btypes.Changeset) bool {
 	closed := ch.ExternalState == btypes.ChangesetExternalStateClosed ||
 		ch.ExternalState == btypes.ChangesetExternalStateReadOnly
@@ -290,6 +351,18 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 	if previous.BaseRef != current.BaseRef {
 		delta.BaseRefChanged = true
 	}
+	if !stringSetsEqual(previous.Reviewers, current.Reviewers) || !stringSetsEqual(previous.TeamReviewers, current.TeamReviewers) {
+		delta.ReviewersChanged = true
+	}
+	if !stringSetsEqual(previous.Labels, current.Labels) {
+		delta.LabelsChanged = true
+	}
+	if !stringSetsEqual(previous.Assignees, current.Assignees) {
+		delta.AssigneesChanged = true
+	}
+	if previous.Milestone != current.Milestone {
+		delta.MilestoneChanged = true
+	}
 
 	// If was set to "draft" and now "true", need to undraft the changeset.
 	// We currently ignore going from "true" to "draft".
@@ -339,6 +412,10 @@ type ChangesetSpecDelta struct {
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
 	AuthorEmailChanged   bool
+	ReviewersChanged     bool
+	LabelsChanged        bool
+	AssigneesChanged     bool
+	MilestoneChanged     bool
 }
 
 func (d *ChangesetSpecDelta) String() string { return fmt.Sprintf("%#v", d) }
@@ -348,7 +425,30 @@ func (d *ChangesetSpecDelta) NeedCommitUpdate() bool {
 }
 
 func (d *ChangesetSpecDelta) NeedCodeHostUpdate() bool {
-	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged
+	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged || d.MetadataChanged()
+}
+
+// MetadataChanged returns true if the reviewers, labels, assignees or
+// milestone of the changeset need to be updated on the code host.
+func (d *ChangesetSpecDelta) MetadataChanged() bool {
+	return d.ReviewersChanged || d.LabelsChanged || d.AssigneesChanged || d.MilestoneChanged
+}
+
+// stringSetsEqual returns true if a and b contain the same strings, ignoring
+// their order.
+func stringSetsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string{}, a...), append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
 func (d *ChangesetSpecDelta) AttributesChanged() bool {
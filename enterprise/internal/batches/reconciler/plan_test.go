@@ -6,6 +6,7 @@ import (
 	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
 func TestDetermineReconcilerPlan(t *testing.T) {
@@ -229,6 +230,52 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 			// We expect a no-op here.
 			wantOperations: Operations{},
 		},
+		{
+			name:         "reviewers changed on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice", "bob"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "team reviewers changed on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true},
+			currentSpec:  &bt.TestSpecOpts{Published: true, TeamReviewers: []string{"sourcegraph/batch-changes"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "labels reordered on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, Labels: []string{"a", "b"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Labels: []string{"b", "a"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "labels, assignees and milestone changed on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, Labels: []string{"a"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Labels: []string{"b"}, Assignees: []string{"alice"}, Milestone: "4.3"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "milestone changed on merged changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, Milestone: "4.2"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Milestone: "4.3"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateMerged,
+			},
+			wantOperations: Operations{},
+		},
 		{
 			name:         "commit diff changed on published changeset",
 			previousSpec: &bt.TestSpecOpts{Published: true, CommitDiff: []byte("testDiff")},
@@ -432,6 +479,123 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 				btypes.ReconcilerOperationImport,
 			},
 		},
+		{
+			name:         "auto-merge approved changeset with passed checks",
+			previousSpec: &bt.TestSpecOpts{Published: true, AutoMerge: autoMergePolicy},
+			currentSpec:  &bt.TestSpecOpts{Published: true, AutoMerge: autoMergePolicy},
+			changeset: bt.TestChangesetOpts{
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+				ExternalState:       btypes.ChangesetExternalStateOpen,
+				ExternalReviewState: btypes.ChangesetReviewStateApproved,
+				ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationMerge},
+		},
+		{
+			name:         "auto-merge approved changeset with pending checks on code host with native auto-merge",
+			previousSpec: &bt.TestSpecOpts{Published: true, AutoMerge: autoMergePolicy},
+			currentSpec:  &bt.TestSpecOpts{Published: true, AutoMerge: autoMergePolicy},
+			changeset: bt.TestChangesetOpts{
+				ExternalServiceType: extsvc.TypeGitHub,
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+				ExternalState:       btypes.ChangesetExternalStateOpen,
+				ExternalReviewState: btypes.ChangesetReviewStateApproved,
+				ExternalCheckState:  btypes.ChangesetCheckStatePending,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationMerge},
+		},
+		{
+			name:         "auto-merge approved changeset with pending checks on code host without native auto-merge",
+			previousSpec: &bt.TestSpecOpts{Published: true, AutoMerge: autoMergePolicy},
+			currentSpec:  &bt.TestSpecOpts{Published: true, AutoMerge: autoMergePolicy},
+			changeset: bt.TestChangesetOpts{
+				ExternalServiceType: extsvc.TypeBitbucketServer,
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+				ExternalState:       btypes.ChangesetExternalStateOpen,
+				ExternalReviewState: btypes.ChangesetReviewStateApproved,
+				ExternalCheckState:  btypes.ChangesetCheckStatePending,
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "auto-merge changeset with pending review",
+			previousSpec: &bt.TestSpecOpts{Published: true, AutoMerge: autoMergePolicy},
+			currentSpec:  &bt.TestSpecOpts{Published: true, AutoMerge: autoMergePolicy},
+			changeset: bt.TestChangesetOpts{
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+				ExternalState:       btypes.ChangesetExternalStateOpen,
+				ExternalReviewState: btypes.ChangesetReviewStatePending,
+				ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "auto-merge changeset that needs to be updated first",
+			previousSpec: &bt.TestSpecOpts{Published: true, Title: "Before", AutoMerge: autoMergePolicy},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Title: "After", AutoMerge: autoMergePolicy},
+			changeset: bt.TestChangesetOpts{
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+				ExternalState:       btypes.ChangesetExternalStateOpen,
+				ExternalReviewState: btypes.ChangesetReviewStateApproved,
+				ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "auto-merge draft changeset",
+			previousSpec: &bt.TestSpecOpts{Published: "draft", AutoMerge: autoMergePolicy},
+			currentSpec:  &bt.TestSpecOpts{Published: "draft", AutoMerge: autoMergePolicy},
+			changeset: bt.TestChangesetOpts{
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+				ExternalState:       btypes.ChangesetExternalStateDraft,
+				ExternalReviewState: btypes.ChangesetReviewStateApproved,
+				ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:        "blocked changeset is published as draft",
+			currentSpec: &bt.TestSpecOpts{Published: true},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStateUnpublished,
+				Blocked:          true,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublishDraft},
+		},
+		{
+			name:        "blocked changeset without draft support stays unpublished",
+			currentSpec: &bt.TestSpecOpts{Published: true},
+			changeset: bt.TestChangesetOpts{
+				ExternalServiceType: extsvc.TypeBitbucketServer,
+				PublicationState:    btypes.ChangesetPublicationStateUnpublished,
+				Blocked:             true,
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "blocked draft is updated but not undrafted",
+			previousSpec: &bt.TestSpecOpts{Published: "draft", Title: "Before"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Title: "After"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateDraft,
+				Blocked:          true,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "blocked changeset is not auto-merged",
+			previousSpec: &bt.TestSpecOpts{Published: true, AutoMerge: autoMergePolicy},
+			currentSpec:  &bt.TestSpecOpts{Published: true, AutoMerge: autoMergePolicy},
+			changeset: bt.TestChangesetOpts{
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+				ExternalState:       btypes.ChangesetExternalStateOpen,
+				ExternalReviewState: btypes.ChangesetReviewStateApproved,
+				ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+				Blocked:             true,
+			},
+			wantOperations: Operations{},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -460,6 +624,12 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 	}
 }
 
+var autoMergePolicy = &batcheslib.AutoMergePolicy{
+	MergeMethod:         batcheslib.AutoMergeMethodSquash,
+	RequiredReviewState: batcheslib.AutoMergeReviewStateApproved,
+	RequiredCheckState:  batcheslib.AutoMergeCheckStatePassed,
+}
+
 func uiPublicationStatePtr(state btypes.ChangesetUiPublicationState) *btypes.ChangesetUiPublicationState {
 	return &state
 }
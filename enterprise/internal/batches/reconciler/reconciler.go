@@ -3,15 +3,19 @@ package reconciler
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // Reconciler processes changesets and reconciles their current state — in
@@ -73,6 +77,17 @@ func (r *Reconciler) process(ctx context.Context, logger log.Logger, tx *store.S
 		return nil
 	}
 
+	blocked, err := PrerequisitesPending(ctx, tx, ch, curr)
+	if err != nil {
+		return err
+	}
+	if blocked != ch.Blocked {
+		ch.Blocked = blocked
+		if err := tx.UpdateChangesetBlocked(ctx, ch); err != nil {
+			return err
+		}
+	}
+
 	// Pass nil since there is no "current" changeset. The changeset has already been updated in the DB to the wanted
 	// state. Current changeset is only (at the moment) used for previewing.
 	plan, err := DeterminePlan(prev, curr, nil, ch)
@@ -108,3 +123,73 @@ func loadChangesetSpecs(ctx context.Context, tx *store.Store, ch *btypes.Changes
 	}
 	return
 }
+
+// PrerequisitesPending returns true if the current changeset spec of ch
+// depends on changesets owned by the same batch change that haven't been
+// merged yet. Prerequisites are selected by the name of their repository or by
+// a name their current spec provides. Names without a changeset in the batch
+// change, changesets the batch change archived, and changesets in the
+// repository of ch don't block.
+//
+// A closed or deleted prerequisite can't be merged anymore, so instead of
+// blocking forever an ErrPrerequisitesClosed is returned, which fails the
+// changeset until the prerequisite is reopened or the dependency is removed.
+func PrerequisitesPending(ctx context.Context, tx *store.Store, ch *btypes.Changeset, curr *btypes.ChangesetSpec) (bool, error) {
+	if curr == nil || len(curr.DependsOn) == 0 || ch.OwnedByBatchChangeID == 0 {
+		return false, nil
+	}
+
+	prerequisites, _, err := tx.ListChangesets(ctx, store.ListChangesetsOpts{
+		OwnedByBatchChangeID: ch.OwnedByBatchChangeID,
+		DependencyNames:      curr.DependsOn,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "listing prerequisite changesets")
+	}
+
+	pending := false
+	var closedRepoIDs []api.RepoID
+	for _, p := range prerequisites {
+		if p.RepoID == ch.RepoID || p.ArchivedIn(ch.OwnedByBatchChangeID) {
+			continue
+		}
+
+		switch p.ExternalState {
+		case btypes.ChangesetExternalStateMerged:
+		case btypes.ChangesetExternalStateClosed, btypes.ChangesetExternalStateDeleted, btypes.ChangesetExternalStateReadOnly:
+			closedRepoIDs = append(closedRepoIDs, p.RepoID)
+		default:
+			pending = true
+		}
+	}
+
+	if len(closedRepoIDs) > 0 {
+		repos, err := tx.Repos().GetReposSetByIDs(ctx, closedRepoIDs...)
+		if err != nil {
+			return false, errors.Wrap(err, "loading repositories of closed prerequisite changesets")
+		}
+		closedErr := ErrPrerequisitesClosed{}
+		for _, repo := range repos {
+			closedErr.RepoNames = append(closedErr.RepoNames, string(repo.Name))
+		}
+		sort.Strings(closedErr.RepoNames)
+		return false, closedErr
+	}
+
+	return pending, nil
+}
+
+// ErrPrerequisitesClosed is returned by PrerequisitesPending if changesets
+// that a changeset depends on were closed or deleted on the code host.
+// It is a terminal error that won't be fixed by retrying to reconcile the
+// changeset until the prerequisites are reopened.
+type ErrPrerequisitesClosed struct{ RepoNames []string }
+
+func (e ErrPrerequisitesClosed) Error() string {
+	return fmt.Sprintf(
+		"the changesets this changeset depends on in %s were closed or deleted: reopen them, or remove them from changesetTemplate.dependsOn, and retry the changeset",
+		strings.Join(e.RepoNames, ", "),
+	)
+}
+
+func (e ErrPrerequisitesClosed) NonRetryable() bool { return true }
@@ -176,3 +176,129 @@ func TestReconcilerProcess_IntegrationTest(t *testing.T) {
 		bt.TruncateTables(t, db, "changeset_events", "changesets", "batch_changes", "batch_specs", "changeset_specs")
 	}
 }
+
+func TestPrerequisitesPending(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	store := store.New(db, &observation.TestContext, nil)
+
+	admin := bt.CreateTestUser(t, db, true)
+	rs, _ := bt.CreateTestRepos(t, ctx, db, 5)
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, store, "dependencies", admin.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, store, "dependencies", admin.ID, batchSpec.ID)
+
+	ch := bt.CreateChangeset(t, ctx, store, bt.TestChangesetOpts{
+		Repo:               rs[0].ID,
+		BatchChange:        batchChange.ID,
+		OwnedByBatchChange: batchChange.ID,
+	})
+	librarySpec := bt.CreateChangesetSpec(t, ctx, store, bt.TestSpecOpts{
+		Repo:      rs[1].ID,
+		BatchSpec: batchSpec.ID,
+		Typ:       btypes.ChangesetSpecTypeBranch,
+		Provides:  []string{"library"},
+	})
+	open := bt.CreateChangeset(t, ctx, store, bt.TestChangesetOpts{
+		Repo:               rs[1].ID,
+		BatchChange:        batchChange.ID,
+		OwnedByBatchChange: batchChange.ID,
+		CurrentSpec:        librarySpec.ID,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalState:      btypes.ChangesetExternalStateOpen,
+	})
+	// In the same repository as ch.
+	bt.CreateChangeset(t, ctx, store, bt.TestChangesetOpts{
+		Repo:               rs[0].ID,
+		ExternalID:         "same-repo",
+		BatchChange:        batchChange.ID,
+		OwnedByBatchChange: batchChange.ID,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalState:      btypes.ChangesetExternalStateOpen,
+	})
+	// Only tracked by the batch change.
+	bt.CreateChangeset(t, ctx, store, bt.TestChangesetOpts{
+		Repo:             rs[2].ID,
+		BatchChange:      batchChange.ID,
+		PublicationState: btypes.ChangesetPublicationStatePublished,
+		ExternalState:    btypes.ChangesetExternalStateOpen,
+	})
+	// Removed from the batch change.
+	bt.CreateChangeset(t, ctx, store, bt.TestChangesetOpts{
+		Repo:               rs[3].ID,
+		BatchChange:        batchChange.ID,
+		OwnedByBatchChange: batchChange.ID,
+		IsArchived:         true,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalState:      btypes.ChangesetExternalStateClosed,
+	})
+	bt.CreateChangeset(t, ctx, store, bt.TestChangesetOpts{
+		Repo:               rs[4].ID,
+		BatchChange:        batchChange.ID,
+		OwnedByBatchChange: batchChange.ID,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalState:      btypes.ChangesetExternalStateClosed,
+	})
+
+	for name, tc := range map[string]struct {
+		dependsOn   []string
+		wantPending bool
+		wantErr     error
+	}{
+		"open prerequisite": {
+			dependsOn:   []string{string(rs[1].Name)},
+			wantPending: true,
+		},
+		"provided name": {
+			dependsOn:   []string{"library"},
+			wantPending: true,
+		},
+		"same repository": {
+			dependsOn: []string{string(rs[0].Name)},
+		},
+		"tracked and archived changesets": {
+			dependsOn: []string{string(rs[2].Name), string(rs[3].Name)},
+		},
+		"closed prerequisite": {
+			dependsOn: []string{string(rs[1].Name), string(rs[4].Name)},
+			wantErr:   ErrPrerequisitesClosed{RepoNames: []string{string(rs[4].Name)}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pending, err := PrerequisitesPending(ctx, store, ch, &btypes.ChangesetSpec{DependsOn: tc.dependsOn})
+			if tc.wantErr != nil {
+				if err == nil || err.Error() != tc.wantErr.Error() {
+					t.Fatalf("wrong error: have=%v want=%v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pending != tc.wantPending {
+				t.Fatalf("wrong pending: have=%t want=%t", pending, tc.wantPending)
+			}
+		})
+	}
+
+	t.Run("merged prerequisite", func(t *testing.T) {
+		open.ExternalState = btypes.ChangesetExternalStateMerged
+		if err := store.UpdateChangeset(ctx, open); err != nil {
+			t.Fatal(err)
+		}
+
+		pending, err := PrerequisitesPending(ctx, store, ch, &btypes.ChangesetSpec{DependsOn: []string{string(rs[1].Name)}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pending {
+			t.Fatal("expected prerequisites not to be pending")
+		}
+	})
+}
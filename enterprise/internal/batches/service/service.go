@@ -88,6 +88,11 @@ type operations struct {
 	applyBatchChange                     *observation.Operation
 	reconcileBatchChange                 *observation.Operation
 	validateChangesetSpecs               *observation.Operation
+	setBatchChangeSchedule               *observation.Operation
+	deleteBatchChangeSchedule            *observation.Operation
+	startBatchChangeScheduleRun          *observation.Operation
+	advanceBatchChangeScheduleRun        *observation.Operation
+	listChangesetDependencies            *observation.Operation
 }
 
 var (
@@ -138,6 +143,11 @@ func newOperations(observationCtx *observation.Context) *operations {
 			applyBatchChange:                     op("ApplyBatchChange"),
 			reconcileBatchChange:                 op("ReconcileBatchChange"),
 			validateChangesetSpecs:               op("ValidateChangesetSpecs"),
+			setBatchChangeSchedule:               op("SetBatchChangeSchedule"),
+			deleteBatchChangeSchedule:            op("DeleteBatchChangeSchedule"),
+			startBatchChangeScheduleRun:          op("StartBatchChangeScheduleRun"),
+			advanceBatchChangeScheduleRun:        op("AdvanceBatchChangeScheduleRun"),
+			listChangesetDependencies:            op("ListChangesetDependencies"),
 		}
 	})
 
@@ -1220,7 +1230,8 @@ func (s *Service) CreateChangesetJobs(ctx context.Context, batchChangeID int64,
 }
 
 // ValidateChangesetSpecs checks whether the given BachSpec has ChangesetSpecs
-// that would publish to the same branch in the same repository.
+// that would publish to the same branch in the same repository, or whose
+// dependencies form a cycle.
 // If the return value is nil, then the BatchSpec is valid.
 func (s *Service) ValidateChangesetSpecs(ctx context.Context, batchSpecID int64) error {
 	// We don't use `err` here to distinguish between errors we want to trace
@@ -1236,6 +1247,14 @@ func (s *Service) ValidateChangesetSpecs(ctx context.Context, batchSpecID int64)
 	}
 
 	if len(conflicts) == 0 {
+		var cycle []string
+		cycle, nonValidationErr = s.changesetSpecDependencyCycle(ctx, batchSpecID)
+		if nonValidationErr != nil {
+			return nonValidationErr
+		}
+		if len(cycle) > 0 {
+			return changesetSpecDependencyCycleErr(cycle)
+		}
 		return nil
 	}
 
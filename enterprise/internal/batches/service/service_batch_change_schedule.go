@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrScheduleDraftBatchChange is returned by SetBatchChangeSchedule if the
+// batch change never had a batch spec applied, so there is nothing to re-run.
+var ErrScheduleDraftBatchChange = errors.New("cannot schedule a batch change that has no applied batch spec")
+
+// ErrScheduleClosedBatchChange is returned by SetBatchChangeSchedule if the
+// batch change is closed.
+var ErrScheduleClosedBatchChange = errors.New("cannot schedule a closed batch change")
+
+type SetBatchChangeScheduleOpts struct {
+	BatchChangeID int64
+	Cron          string
+	AutoApply     btypes.BatchChangeScheduleAutoApply
+	// MaxConsecutiveFailures defaults to
+	// btypes.DefaultBatchChangeScheduleMaxConsecutiveFailures if zero.
+	MaxConsecutiveFailures int32
+}
+
+// SetBatchChangeSchedule creates or replaces the schedule of the given batch
+// change. Replacing a schedule resumes it if it was paused.
+//
+// Scheduled runs act on behalf of the user who set the schedule, as long as
+// they can still administer the batch change.
+func (s *Service) SetBatchChangeSchedule(ctx context.Context, opts SetBatchChangeScheduleOpts) (sched *btypes.BatchChangeSchedule, err error) {
+	ctx, _, endObservation := s.operations.setBatchChangeSchedule.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("BatchChangeID", int(opts.BatchChangeID)),
+		log.String("Cron", opts.Cron),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: opts.BatchChangeID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch change")
+	}
+
+	if batchChange.IsDraft() || batchChange.LastApplierID == 0 {
+		return nil, ErrScheduleDraftBatchChange
+	}
+	if batchChange.Closed() {
+		return nil, ErrScheduleClosedBatchChange
+	}
+
+	// 🚨 SECURITY: Scheduled runs are executed on behalf of the user setting
+	// the schedule, so it must be set by a user who can administer the batch
+	// change.
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() || a.IsInternal() {
+		return nil, auth.ErrNotAuthenticated
+	}
+	if err := s.CheckNamespaceAccess(ctx, batchChange.NamespaceUserID, batchChange.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+
+	cron, err := btypes.ParseCronSchedule(opts.Cron)
+	if err != nil {
+		return nil, err
+	}
+	next := cron.Next(s.clock())
+	if next.IsZero() {
+		return nil, errors.Newf("cron expression %q never matches", opts.Cron)
+	}
+
+	if opts.AutoApply == "" {
+		opts.AutoApply = btypes.BatchChangeScheduleAutoApplyNever
+	}
+	if !opts.AutoApply.Valid() {
+		return nil, errors.Newf("invalid auto-apply policy %q", opts.AutoApply)
+	}
+
+	if opts.MaxConsecutiveFailures == 0 {
+		opts.MaxConsecutiveFailures = btypes.DefaultBatchChangeScheduleMaxConsecutiveFailures
+	}
+	if opts.MaxConsecutiveFailures < 0 {
+		return nil, errors.New("maxConsecutiveFailures must be positive")
+	}
+
+	sched = &btypes.BatchChangeSchedule{
+		BatchChangeID:          batchChange.ID,
+		UserID:                 a.UID,
+		Cron:                   opts.Cron,
+		AutoApply:              opts.AutoApply,
+		MaxConsecutiveFailures: opts.MaxConsecutiveFailures,
+		NextRunAt:              next,
+	}
+	if err := s.store.UpsertBatchChangeSchedule(ctx, sched); err != nil {
+		return nil, err
+	}
+	return sched, nil
+}
+
+// DeleteBatchChangeSchedule deletes the schedule of the given batch change,
+// including its run history. Runs that are in progress are not canceled, but
+// their batch specs won't be applied.
+func (s *Service) DeleteBatchChangeSchedule(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteBatchChangeSchedule.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("BatchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		return errors.Wrap(err, "getting batch change")
+	}
+
+	// 🚨 SECURITY: Everyone who can administer the batch change can stop its
+	// scheduled runs.
+	if err := s.CheckNamespaceAccess(ctx, batchChange.NamespaceUserID, batchChange.NamespaceOrgID); err != nil {
+		return err
+	}
+
+	return s.store.DeleteBatchChangeSchedule(ctx, batchChangeID)
+}
+
+var activeBatchChangeScheduleRunStates = []btypes.BatchChangeScheduleRunState{
+	btypes.BatchChangeScheduleRunStateResolving,
+	btypes.BatchChangeScheduleRunStateExecuting,
+}
+
+// StartBatchChangeScheduleRun starts a run of the given due schedule by
+// creating a new batch spec from the raw spec that was last applied to the
+// batch change, which enqueues the resolution of its workspaces. The next run
+// of the schedule is skipped if the previous one is still in progress.
+//
+// It returns the created run, or nil if the run was skipped.
+func (s *Service) StartBatchChangeScheduleRun(ctx context.Context, sched *btypes.BatchChangeSchedule) (run *btypes.BatchChangeScheduleRun, err error) {
+	ctx, _, endObservation := s.operations.startBatchChangeScheduleRun.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ScheduleID", int(sched.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	now := s.clock()
+
+	cron, err := btypes.ParseCronSchedule(sched.Cron)
+	if err != nil {
+		return nil, err
+	}
+	sched.NextRunAt = cron.Next(now)
+	if sched.NextRunAt.IsZero() {
+		sched.NextRunAt = now
+		sched.PausedAt = now
+	}
+
+	active, err := s.store.ListBatchChangeScheduleRuns(ctx, store.ListBatchChangeScheduleRunsOpts{
+		ScheduleID: sched.ID,
+		States:     activeBatchChangeScheduleRunStates,
+		Limit:      1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(active) > 0 {
+		return nil, s.store.UpdateBatchChangeSchedule(ctx, sched)
+	}
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: sched.BatchChangeID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch change")
+	}
+
+	run = &btypes.BatchChangeScheduleRun{
+		ScheduleID:    sched.ID,
+		BatchChangeID: batchChange.ID,
+		State:         btypes.BatchChangeScheduleRunStateResolving,
+		StartedAt:     now,
+	}
+
+	spec, err := s.createScheduledBatchSpec(ctx, sched, batchChange)
+	if err != nil {
+		run.State = btypes.BatchChangeScheduleRunStateFailed
+		run.FailureMessage = err.Error()
+		run.FinishedAt = now
+		sched.RecordRun(true, now)
+	} else {
+		run.BatchSpecID = spec.ID
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.CreateBatchChangeScheduleRun(ctx, run); err != nil {
+		return nil, err
+	}
+	if err := tx.UpdateBatchChangeSchedule(ctx, sched); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (s *Service) createScheduledBatchSpec(ctx context.Context, sched *btypes.BatchChangeSchedule, batchChange *btypes.BatchChange) (*btypes.BatchSpec, error) {
+	lastSpec, err := s.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting last applied batch spec")
+	}
+
+	// 🚨 SECURITY: The batch spec is created on behalf of the user who set the
+	// schedule, so that their namespace and repository permissions apply.
+	userCtx, err := s.scheduleRunActor(ctx, sched, batchChange)
+	if err != nil {
+		return nil, err
+	}
+	return s.CreateBatchSpecFromRaw(userCtx, CreateBatchSpecFromRawOpts{
+		RawSpec:          lastSpec.RawSpec,
+		NamespaceUserID:  batchChange.NamespaceUserID,
+		NamespaceOrgID:   batchChange.NamespaceOrgID,
+		AllowIgnored:     lastSpec.AllowIgnored,
+		AllowUnsupported: lastSpec.AllowUnsupported,
+		BatchChange:      batchChange.ID,
+	})
+}
+
+// AdvanceBatchChangeScheduleRun moves the given active run forward: once the
+// workspaces are resolved it enqueues their execution, and once the execution
+// finished it applies the batch spec if the auto-apply policy of the schedule
+// allows it.
+func (s *Service) AdvanceBatchChangeScheduleRun(ctx context.Context, run *btypes.BatchChangeScheduleRun) (err error) {
+	ctx, _, endObservation := s.operations.advanceBatchChangeScheduleRun.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("RunID", int(run.ID)),
+		log.String("State", string(run.State)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	sched, err := s.store.GetBatchChangeSchedule(ctx, store.GetBatchChangeScheduleOpts{ID: run.ScheduleID})
+	if err != nil {
+		return errors.Wrap(err, "getting schedule")
+	}
+
+	if run.BatchSpecID == 0 {
+		return s.finishBatchChangeScheduleRun(ctx, sched, run, "the batch spec of the run was deleted")
+	}
+
+	spec, err := s.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: run.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "getting batch spec")
+	}
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: run.BatchChangeID})
+	if err != nil {
+		return errors.Wrap(err, "getting batch change")
+	}
+
+	switch run.State {
+	case btypes.BatchChangeScheduleRunStateResolving:
+		resolutionJob, err := s.store.GetBatchSpecResolutionJob(ctx, store.GetBatchSpecResolutionJobOpts{BatchSpecID: spec.ID})
+		if err != nil {
+			return errors.Wrap(err, "getting resolution job")
+		}
+
+		switch resolutionJob.State {
+		case btypes.BatchSpecResolutionJobStateFailed:
+			return s.finishBatchChangeScheduleRun(ctx, sched, run, ErrBatchSpecResolutionErrored{resolutionJob.FailureMessage}.Error())
+
+		case btypes.BatchSpecResolutionJobStateCompleted:
+			// 🚨 SECURITY: Re-check that the user who set the schedule can
+			// still administer the batch change before acting on their behalf.
+			userCtx, err := s.scheduleRunActor(ctx, sched, batchChange)
+			if err != nil {
+				return s.finishBatchChangeScheduleRun(ctx, sched, run, err.Error())
+			}
+			if _, err := s.ExecuteBatchSpec(userCtx, ExecuteBatchSpecOpts{BatchSpecRandID: spec.RandID}); err != nil {
+				return s.finishBatchChangeScheduleRun(ctx, sched, run, err.Error())
+			}
+			run.State = btypes.BatchChangeScheduleRunStateExecuting
+			return s.store.UpdateBatchChangeScheduleRun(ctx, run)
+
+		default:
+			// Still resolving, or errored and about to be retried.
+			return nil
+		}
+
+	case btypes.BatchChangeScheduleRunStateExecuting:
+		stats, err := s.LoadBatchSpecStats(ctx, spec)
+		if err != nil {
+			return err
+		}
+		state := btypes.ComputeBatchSpecState(spec, stats)
+		if !state.Finished() {
+			return nil
+		}
+
+		var failureMessage string
+		switch state {
+		case btypes.BatchSpecStateCompleted:
+		case btypes.BatchSpecStateFailed:
+			failureMessage = fmt.Sprintf("execution failed in %d of %d workspaces", stats.Failed, stats.Executions)
+		default:
+			failureMessage = fmt.Sprintf("execution %s", state)
+		}
+
+		apply := false
+		switch sched.AutoApply {
+		case btypes.BatchChangeScheduleAutoApplyOnSuccess:
+			apply = failureMessage == ""
+		case btypes.BatchChangeScheduleAutoApplyAlways:
+			apply = state.FinishedAndNotCanceled()
+		}
+		if apply {
+			// 🚨 SECURITY: Re-check that the user who set the schedule can
+			// still administer the batch change before acting on their behalf.
+			userCtx, err := s.scheduleRunActor(ctx, sched, batchChange)
+			if err == nil {
+				_, err = s.ApplyBatchChange(userCtx, ApplyBatchChangeOpts{
+					BatchSpecRandID:     spec.RandID,
+					EnsureBatchChangeID: batchChange.ID,
+				})
+			}
+			if err != nil {
+				if failureMessage != "" {
+					failureMessage += "; "
+				}
+				failureMessage += "applying batch spec: " + err.Error()
+			} else {
+				run.Applied = true
+			}
+		}
+		return s.finishBatchChangeScheduleRun(ctx, sched, run, failureMessage)
+	}
+
+	return nil
+}
+
+// finishBatchChangeScheduleRun marks the run as finished, and records the
+// outcome on its schedule. The run failed if failureMessage is not empty.
+func (s *Service) finishBatchChangeScheduleRun(ctx context.Context, sched *btypes.BatchChangeSchedule, run *btypes.BatchChangeScheduleRun, failureMessage string) (err error) {
+	now := s.clock()
+
+	run.FinishedAt = now
+	run.FailureMessage = failureMessage
+	if failureMessage != "" {
+		run.State = btypes.BatchChangeScheduleRunStateFailed
+	} else {
+		run.State = btypes.BatchChangeScheduleRunStateCompleted
+	}
+	sched.RecordRun(failureMessage != "", now)
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.UpdateBatchChangeScheduleRun(ctx, run); err != nil {
+		return err
+	}
+	return tx.UpdateBatchChangeSchedule(ctx, sched)
+}
+
+// scheduleRunActor returns a context acting as the user who set the given
+// schedule. It returns an error if that user can no longer administer the
+// batch change, for example because they left its organization.
+func (s *Service) scheduleRunActor(ctx context.Context, sched *btypes.BatchChangeSchedule, batchChange *btypes.BatchChange) (context.Context, error) {
+	userCtx := actor.WithActor(ctx, actor.FromUser(sched.UserID))
+	if err := s.CheckNamespaceAccess(userCtx, batchChange.NamespaceUserID, batchChange.NamespaceOrgID); err != nil {
+		return nil, errors.Wrap(err, "the user who set the schedule can no longer administer the batch change")
+	}
+	return userCtx, nil
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+)
+
+func TestServiceBatchChangeSchedules(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := actor.WithInternalActor(context.Background())
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	user := bt.CreateTestUser(t, db, false)
+	user2 := bt.CreateTestUser(t, db, false)
+
+	userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+	user2Ctx := actor.WithActor(context.Background(), actor.FromUser(user2.ID))
+
+	now := timeutil.Now()
+	clock := func() time.Time { return now }
+
+	s := store.NewWithClock(db, &observation.TestContext, nil, clock)
+	svc := NewWithClock(s, clock)
+
+	spec, err := btypes.NewBatchSpecFromRaw(bt.TestRawBatchSpecYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec.UserID = user.ID
+	spec.NamespaceUserID = user.ID
+	if err := s.CreateBatchSpec(ctx, spec); err != nil {
+		t.Fatal(err)
+	}
+	batchChange := bt.CreateBatchChange(t, ctx, s, spec.Spec.Name, user.ID, spec.ID)
+
+	var sched *btypes.BatchChangeSchedule
+
+	t.Run("SetBatchChangeSchedule", func(t *testing.T) {
+		t.Run("no access to the batch change", func(t *testing.T) {
+			_, err := svc.SetBatchChangeSchedule(user2Ctx, SetBatchChangeScheduleOpts{
+				BatchChangeID: batchChange.ID,
+				Cron:          "@daily",
+			})
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+
+		t.Run("draft batch change", func(t *testing.T) {
+			draft := testDraftBatchChange(user.ID)
+			if err := s.CreateBatchChange(ctx, draft); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := svc.SetBatchChangeSchedule(userCtx, SetBatchChangeScheduleOpts{
+				BatchChangeID: draft.ID,
+				Cron:          "@daily",
+			})
+			if err != ErrScheduleDraftBatchChange {
+				t.Fatalf("unexpected error: have=%v want=%v", err, ErrScheduleDraftBatchChange)
+			}
+		})
+
+		t.Run("invalid options", func(t *testing.T) {
+			for name, opts := range map[string]SetBatchChangeScheduleOpts{
+				"cron":                   {Cron: "*/5 * * * *"},
+				"never matching cron":    {Cron: "0 0 30 2 *"},
+				"auto-apply policy":      {Cron: "@daily", AutoApply: "SOMETIMES"},
+				"maxConsecutiveFailures": {Cron: "@daily", MaxConsecutiveFailures: -1},
+			} {
+				t.Run(name, func(t *testing.T) {
+					opts.BatchChangeID = batchChange.ID
+					if _, err := svc.SetBatchChangeSchedule(userCtx, opts); err == nil {
+						t.Fatal("expected error, got nil")
+					}
+				})
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			sched, err = svc.SetBatchChangeSchedule(userCtx, SetBatchChangeScheduleOpts{
+				BatchChangeID: batchChange.ID,
+				Cron:          "@daily",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cron, err := btypes.ParseCronSchedule("@daily")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := cron.Next(now); !sched.NextRunAt.Equal(want) {
+				t.Fatalf("wrong next run: have=%s want=%s", sched.NextRunAt, want)
+			}
+			if sched.AutoApply != btypes.BatchChangeScheduleAutoApplyNever {
+				t.Fatalf("wrong auto-apply policy: %s", sched.AutoApply)
+			}
+			if sched.MaxConsecutiveFailures != btypes.DefaultBatchChangeScheduleMaxConsecutiveFailures {
+				t.Fatalf("wrong max consecutive failures: %d", sched.MaxConsecutiveFailures)
+			}
+			if sched.UserID != user.ID {
+				t.Fatalf("wrong user: have=%d want=%d", sched.UserID, user.ID)
+			}
+		})
+	})
+
+	var run *btypes.BatchChangeScheduleRun
+
+	t.Run("StartBatchChangeScheduleRun", func(t *testing.T) {
+		run, err = svc.StartBatchChangeScheduleRun(ctx, sched)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if run == nil {
+			t.Fatal("run not started")
+		}
+		if run.State != btypes.BatchChangeScheduleRunStateResolving {
+			t.Fatalf("wrong run state: %s", run.State)
+		}
+
+		newSpec, err := s.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: run.BatchSpecID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if newSpec.RawSpec != spec.RawSpec || newSpec.UserID != user.ID || newSpec.BatchChangeID != batchChange.ID || !newSpec.CreatedFromRaw {
+			t.Fatalf("unexpected batch spec: %+v", newSpec)
+		}
+		if _, err := s.GetBatchSpecResolutionJob(ctx, store.GetBatchSpecResolutionJobOpts{BatchSpecID: newSpec.ID}); err != nil {
+			t.Fatalf("resolution job not created: %s", err)
+		}
+
+		// The next run is skipped while this one is in progress.
+		skipped, err := svc.StartBatchChangeScheduleRun(ctx, sched)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if skipped != nil {
+			t.Fatalf("expected run to be skipped, got %+v", skipped)
+		}
+	})
+
+	t.Run("AdvanceBatchChangeScheduleRun", func(t *testing.T) {
+		// The resolution job is still queued.
+		if err := svc.AdvanceBatchChangeScheduleRun(ctx, run); err != nil {
+			t.Fatal(err)
+		}
+		if run.State != btypes.BatchChangeScheduleRunStateResolving {
+			t.Fatalf("wrong run state: %s", run.State)
+		}
+
+		if err := s.Exec(ctx, sqlf.Sprintf(
+			"UPDATE batch_spec_resolution_jobs SET state = 'failed', failure_message = 'boom' WHERE batch_spec_id = %s",
+			run.BatchSpecID,
+		)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := svc.AdvanceBatchChangeScheduleRun(ctx, run); err != nil {
+			t.Fatal(err)
+		}
+		if run.State != btypes.BatchChangeScheduleRunStateFailed || run.FinishedAt.IsZero() {
+			t.Fatalf("expected run to be failed: %+v", run)
+		}
+
+		have, err := s.GetBatchChangeSchedule(ctx, store.GetBatchChangeScheduleOpts{ID: sched.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.ConsecutiveFailures != 1 || have.Paused() {
+			t.Fatalf("unexpected schedule: %+v", have)
+		}
+
+		t.Run("pauses after too many failures", func(t *testing.T) {
+			have.ConsecutiveFailures = have.MaxConsecutiveFailures - 1
+			if err := s.UpdateBatchChangeSchedule(ctx, have); err != nil {
+				t.Fatal(err)
+			}
+
+			run, err := svc.StartBatchChangeScheduleRun(ctx, have)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Exec(ctx, sqlf.Sprintf(
+				"UPDATE batch_spec_resolution_jobs SET state = 'failed' WHERE batch_spec_id = %s",
+				run.BatchSpecID,
+			)); err != nil {
+				t.Fatal(err)
+			}
+			if err := svc.AdvanceBatchChangeScheduleRun(ctx, run); err != nil {
+				t.Fatal(err)
+			}
+
+			paused, err := s.GetBatchChangeSchedule(ctx, store.GetBatchChangeScheduleOpts{ID: sched.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !paused.Paused() {
+				t.Fatalf("expected schedule to be paused: %+v", paused)
+			}
+		})
+	})
+
+	t.Run("StartBatchChangeScheduleRun without access", func(t *testing.T) {
+		// The user who set the schedule can no longer administer the batch
+		// change.
+		if err := s.Exec(ctx, sqlf.Sprintf("UPDATE batch_change_schedules SET user_id = %s, paused_at = NULL WHERE id = %s", user2.ID, sched.ID)); err != nil {
+			t.Fatal(err)
+		}
+		have, err := s.GetBatchChangeSchedule(ctx, store.GetBatchChangeScheduleOpts{ID: sched.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		run, err := svc.StartBatchChangeScheduleRun(ctx, have)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if run.State != btypes.BatchChangeScheduleRunStateFailed || run.BatchSpecID != 0 {
+			t.Fatalf("expected run to fail: %+v", run)
+		}
+	})
+
+	t.Run("DeleteBatchChangeSchedule", func(t *testing.T) {
+		if err := svc.DeleteBatchChangeSchedule(user2Ctx, batchChange.ID); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if err := svc.DeleteBatchChangeSchedule(userCtx, batchChange.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.GetBatchChangeSchedule(ctx, store.GetBatchChangeScheduleOpts{BatchChangeID: batchChange.ID}); err != store.ErrNoResults {
+			t.Fatalf("unexpected error: have=%v want=%v", err, store.ErrNoResults)
+		}
+	})
+}
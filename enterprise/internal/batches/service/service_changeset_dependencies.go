@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// ChangesetDependency is an edge of the dependency graph of a batch change:
+// Changeset is held back until all of DependsOn are merged.
+type ChangesetDependency struct {
+	Changeset *btypes.Changeset
+	DependsOn []*btypes.Changeset
+}
+
+// ListChangesetDependencies returns the dependency graph of the changesets
+// owned by the given batch change. Prerequisites are selected by the name of
+// their repository or by a name their spec provides. Changesets without
+// dependencies are omitted, and so are the changesets in repositories the user
+// can't access.
+func (s *Service) ListChangesetDependencies(ctx context.Context, batchChangeID int64) (deps []ChangesetDependency, err error) {
+	ctx, _, endObservation := s.operations.listChangesetDependencies.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	cs, _, err := s.store.ListChangesets(ctx, store.ListChangesetsOpts{
+		BatchChangeID:        batchChangeID,
+		OwnedByBatchChangeID: batchChangeID,
+		EnforceAuthz:         true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	specIDs := make([]int64, 0, len(cs))
+	repoIDs := make([]api.RepoID, 0, len(cs))
+	for _, c := range cs {
+		if c.CurrentSpecID != 0 {
+			specIDs = append(specIDs, c.CurrentSpecID)
+		}
+		repoIDs = append(repoIDs, c.RepoID)
+	}
+	if len(specIDs) == 0 {
+		return nil, nil
+	}
+
+	specs, _, err := s.store.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{IDs: specIDs})
+	if err != nil {
+		return nil, err
+	}
+	specsByID := make(map[int64]*btypes.ChangesetSpec, len(specs))
+	for _, spec := range specs {
+		specsByID[spec.ID] = spec
+	}
+
+	// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter under
+	// the hood and filters out repositories that the user doesn't have access
+	// to.
+	repos, err := s.store.Repos().GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string][]*btypes.Changeset)
+	for _, c := range cs {
+		repo, ok := repos[c.RepoID]
+		if !ok {
+			continue
+		}
+		byName[string(repo.Name)] = append(byName[string(repo.Name)], c)
+		if spec, ok := specsByID[c.CurrentSpecID]; ok {
+			for _, name := range spec.Provides {
+				byName[name] = append(byName[name], c)
+			}
+		}
+	}
+
+	for _, c := range cs {
+		spec, ok := specsByID[c.CurrentSpecID]
+		if !ok || len(spec.DependsOn) == 0 {
+			continue
+		}
+		dep := ChangesetDependency{Changeset: c}
+		seen := make(map[int64]struct{})
+		for _, name := range spec.DependsOn {
+			for _, prerequisite := range byName[name] {
+				if _, ok := seen[prerequisite.ID]; ok || prerequisite.RepoID == c.RepoID {
+					continue
+				}
+				seen[prerequisite.ID] = struct{}{}
+				dep.DependsOn = append(dep.DependsOn, prerequisite)
+			}
+		}
+		if len(dep.DependsOn) > 0 {
+			deps = append(deps, dep)
+		}
+	}
+	return deps, nil
+}
+
+// changesetSpecDependencyCycle returns the repository names that form a cycle
+// in the dependencies of the changeset specs of the given batch spec, or nil
+// if there is none.
+func (s *Service) changesetSpecDependencyCycle(ctx context.Context, batchSpecID int64) ([]string, error) {
+	specs, _, err := s.store.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{
+		BatchSpecID: batchSpecID,
+		Type:        batcheslib.ChangesetSpecDescriptionTypeBranch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(specs))
+	for _, spec := range specs {
+		if len(spec.DependsOn) > 0 || len(spec.Provides) > 0 {
+			repoIDs = append(repoIDs, spec.BaseRepoID)
+		}
+	}
+	if len(repoIDs) == 0 {
+		return nil, nil
+	}
+
+	// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter under
+	// the hood and filters out repositories that the user doesn't have access
+	// to.
+	repos, err := s.store.Repos().GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// A name that a changeset depends on also selects the repositories of the
+	// changesets that provide it.
+	providers := make(map[string][]string)
+	for _, spec := range specs {
+		if repo, ok := repos[spec.BaseRepoID]; ok {
+			for _, name := range spec.Provides {
+				providers[name] = append(providers[name], string(repo.Name))
+			}
+		}
+	}
+
+	graph := make(map[string][]string)
+	for _, spec := range specs {
+		repo, ok := repos[spec.BaseRepoID]
+		if !ok {
+			continue
+		}
+		repoName := string(repo.Name)
+		for _, name := range spec.DependsOn {
+			for _, dep := range append([]string{name}, providers[name]...) {
+				// Changesets in the same repository never depend on each other.
+				if dep != repoName {
+					graph[repoName] = append(graph[repoName], dep)
+				}
+			}
+		}
+	}
+	return findDependencyCycle(graph), nil
+}
+
+// findDependencyCycle returns the first cycle found in the graph, which maps
+// each node to the nodes it depends on. The returned cycle starts and ends
+// with the same node. Nodes are visited in lexicographical order so that the
+// result is stable.
+func findDependencyCycle(graph map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(graph))
+	var path []string
+
+	var visit func(node string) []string
+	visit = func(node string) []string {
+		switch state[node] {
+		case visited:
+			return nil
+		case visiting:
+			for i, n := range path {
+				if n == node {
+					return append(append([]string{}, path[i:]...), node)
+				}
+			}
+		}
+
+		state[node] = visiting
+		path = append(path, node)
+		deps := append([]string{}, graph[node]...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[node] = visited
+		return nil
+	}
+
+	nodes := make([]string, 0, len(graph))
+	for node := range graph {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		if cycle := visit(node); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// changesetSpecDependencyCycleErr is returned by ValidateChangesetSpecs if the
+// dependencies between changeset specs form a cycle, in which case none of
+// the changesets in the cycle could ever be published.
+type changesetSpecDependencyCycleErr []string
+
+func (e changesetSpecDependencyCycleErr) Error() string {
+	return fmt.Sprintf("Validating changeset specs resulted in an error:\n* changeset dependencies form a cycle: %s\n", strings.Join(e, " -> "))
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindDependencyCycle(t *testing.T) {
+	for name, tc := range map[string]struct {
+		graph map[string][]string
+		want  []string
+	}{
+		"empty": {
+			graph: map[string][]string{},
+		},
+		"chain": {
+			graph: map[string][]string{
+				"consumer-a": {"lib"},
+				"consumer-b": {"lib", "consumer-a"},
+				"lib":        {"unknown"},
+			},
+		},
+		"self-reference": {
+			graph: map[string][]string{"lib": {"lib"}},
+			want:  []string{"lib", "lib"},
+		},
+		"cycle": {
+			graph: map[string][]string{
+				"a": {"b"},
+				"b": {"c"},
+				"c": {"d", "b"},
+				"d": {},
+			},
+			want: []string{"b", "c", "b"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, findDependencyCycle(tc.graph)); diff != "" {
+				t.Fatalf("wrong cycle (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
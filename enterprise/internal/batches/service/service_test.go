@@ -1979,6 +1979,66 @@ changesetTemplate:
 		}
 	})
 
+	t.Run("ValidateChangesetSpecs dependency cycle", func(t *testing.T) {
+		batchSpec := bt.CreateBatchSpec(t, ctx, s, "matching-batch-spec", admin.ID, 0)
+		for i, opts := range []bt.TestSpecOpts{
+			{Repo: rs[0].ID, DependsOn: []string{string(rs[1].Name)}},
+			{Repo: rs[1].ID, DependsOn: []string{string(rs[2].Name)}},
+			{Repo: rs[2].ID},
+		} {
+			opts.HeadRef = fmt.Sprintf("refs/heads/dependencies-%d", i)
+			opts.Typ = btypes.ChangesetSpecTypeBranch
+			opts.BatchSpec = batchSpec.ID
+			bt.CreateChangesetSpec(t, ctx, s, opts)
+		}
+		if err := svc.ValidateChangesetSpecs(ctx, batchSpec.ID); err != nil {
+			t.Fatalf("unexpected error for acyclic dependencies: %s", err)
+		}
+
+		bt.CreateChangesetSpec(t, ctx, s, bt.TestSpecOpts{
+			HeadRef:   "refs/heads/dependencies-3",
+			Typ:       btypes.ChangesetSpecTypeBranch,
+			Repo:      rs[2].ID,
+			BatchSpec: batchSpec.ID,
+			DependsOn: []string{string(rs[0].Name)},
+		})
+		err := svc.ValidateChangesetSpecs(ctx, batchSpec.ID)
+		if err == nil {
+			t.Fatal("expected error, but got none")
+		}
+
+		want := `Validating changeset specs resulted in an error:
+* changeset dependencies form a cycle: repo-1-1 -> repo-1-2 -> repo-1-3 -> repo-1-1
+`
+		if diff := cmp.Diff(want, err.Error()); diff != "" {
+			t.Fatalf("wrong error message: %s", diff)
+		}
+	})
+
+	t.Run("ValidateChangesetSpecs dependency cycle through provided names", func(t *testing.T) {
+		batchSpec := bt.CreateBatchSpec(t, ctx, s, "matching-batch-spec", admin.ID, 0)
+		for i, opts := range []bt.TestSpecOpts{
+			{Repo: rs[0].ID, Provides: []string{"library"}, DependsOn: []string{string(rs[1].Name)}},
+			{Repo: rs[1].ID, DependsOn: []string{"library"}},
+		} {
+			opts.HeadRef = fmt.Sprintf("refs/heads/provided-dependencies-%d", i)
+			opts.Typ = btypes.ChangesetSpecTypeBranch
+			opts.BatchSpec = batchSpec.ID
+			bt.CreateChangesetSpec(t, ctx, s, opts)
+		}
+		err := svc.ValidateChangesetSpecs(ctx, batchSpec.ID)
+		if err == nil {
+			t.Fatal("expected error, but got none")
+		}
+
+		want := `Validating changeset specs resulted in an error:
+* changeset dependencies form a cycle: repo-1-1 -> repo-1-2 -> repo-1-1
+`
+		if diff := cmp.Diff(want, err.Error()); diff != "" {
+			t.Fatalf("wrong error message: %s", diff)
+		}
+	})
+
 	t.Run("ComputeBatchSpecState", func(t *testing.T) {
 		t.Run("success", func(t *testing.T) {
 			spec := testBatchSpec(admin.ID)
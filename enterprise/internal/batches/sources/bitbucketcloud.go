@@ -313,5 +313,26 @@ func (s BitbucketCloudSource) changesetToPullRequestInput(cs *Changeset) bitbuck
 		opts.SourceRepo = cs.RemoteRepo.Metadata.(*bitbucketcloud.Repo)
 	}
 
+	// Bitbucket Cloud replaces the reviewers of the pull request, so we have
+	// to include the current ones to only add to them. Reviewers have to be
+	// given as UUIDs or account IDs, since Bitbucket Cloud doesn't allow
+	// looking up users by their name. Labels, assignees and milestones are not
+	// supported by Bitbucket Cloud.
+	if len(cs.Reviewers) != 0 {
+		seen := map[string]struct{}{}
+		if pr, ok := cs.Metadata.(*bbcs.AnnotatedPullRequest); ok && pr.PullRequest != nil {
+			for _, r := range pr.Reviewers {
+				seen[r.UUID] = struct{}{}
+				opts.Reviewers = append(opts.Reviewers, r.UUID)
+			}
+		}
+		for _, r := range cs.Reviewers {
+			if _, ok := seen[r]; !ok {
+				seen[r] = struct{}{}
+				opts.Reviewers = append(opts.Reviewers, r)
+			}
+		}
+	}
+
 	return opts
 }
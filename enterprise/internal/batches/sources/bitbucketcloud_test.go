@@ -426,6 +426,24 @@ func TestBitbucketCloudSource_UpdateChangeset(t *testing.T) {
 		assert.Nil(t, err)
 		assertChangesetMatchesPullRequest(t, cs, pr)
 	})
+
+	t.Run("success with reviewers", func(t *testing.T) {
+		cs, _, bbRepo := mockBitbucketCloudChangeset()
+		s, client := mockBitbucketCloudSource()
+		mockAnnotatePullRequestSuccess(client)
+
+		cs.Reviewers = []string{"{existing}", "{new}"}
+		pr := mockBitbucketCloudPullRequest(bbRepo)
+		pr.Reviewers = []bitbucketcloud.Account{{UUID: "{existing}"}}
+		client.UpdatePullRequestFunc.SetDefaultHook(func(ctx context.Context, r *bitbucketcloud.Repo, i int64, pri bitbucketcloud.PullRequestInput) (*bitbucketcloud.PullRequest, error) {
+			assert.Equal(t, []string{"{existing}", "{new}"}, pri.Reviewers)
+			return pr, nil
+		})
+
+		annotateChangesetWithPullRequest(cs, pr)
+		err := s.UpdateChangeset(ctx, cs)
+		assert.Nil(t, err)
+	})
 }
 
 func TestBitbucketCloudSource_CreateComment(t *testing.T) {
@@ -101,6 +101,9 @@ func (s BitbucketServerSource) CreateChangeset(ctx context.Context, c *Changeset
 	targetRepo := c.TargetRepo.Metadata.(*bitbucketserver.Repo)
 
 	pr := &bitbucketserver.PullRequest{Title: c.Title, Description: c.Body}
+	for _, name := range c.Reviewers {
+		pr.Reviewers = append(pr.Reviewers, bitbucketserver.Reviewer{User: &bitbucketserver.User{Name: name}})
+	}
 
 	pr.ToRef.Repository.Slug = targetRepo.Slug
 	pr.ToRef.Repository.ID = targetRepo.ID
@@ -134,9 +137,36 @@ func (s BitbucketServerSource) CreateChangeset(ctx context.Context, c *Changeset
 		return false, errors.Wrap(err, "setting changeset metadata")
 	}
 
+	// Reviewers are only requested when the pull request is created, so we
+	// have to add them to a pull request that already existed.
+	if exists && len(missingReviewers(pr, c.Reviewers)) != 0 {
+		if err := s.UpdateChangeset(ctx, c); err != nil {
+			return exists, errors.Wrap(err, "adding reviewers")
+		}
+	}
+
 	return exists, nil
 }
 
+// missingReviewers returns the names of the users that are not yet reviewers
+// of the given pull request.
+func missingReviewers(pr *bitbucketserver.PullRequest, names []string) []string {
+	existing := make(map[string]struct{}, len(pr.Reviewers))
+	for _, r := range pr.Reviewers {
+		if r.User != nil {
+			existing[r.User.Name] = struct{}{}
+		}
+	}
+
+	var missing []string
+	for _, name := range names {
+		if _, ok := existing[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
 // CloseChangeset closes the given *Changeset on the code host and updates the
 // Metadata column in the *batches.Changeset to the newly closed pull request.
 func (s BitbucketServerSource) CloseChangeset(ctx context.Context, c *Changeset) error {
@@ -212,6 +242,20 @@ func (s BitbucketServerSource) UpdateChangeset(ctx context.Context, c *Changeset
 	update.ToRef.Repository.Slug = pr.ToRef.Repository.Slug
 	update.ToRef.Repository.Project.Key = pr.ToRef.Repository.Project.Key
 
+	// Bitbucket Server replaces the reviewers of the pull request, so we have
+	// to include the current ones to only add to them. Labels, assignees and
+	// milestones are not supported by Bitbucket Server.
+	if missing := missingReviewers(pr, c.Reviewers); len(missing) != 0 {
+		for _, r := range pr.Reviewers {
+			if r.User != nil {
+				update.Reviewers = append(update.Reviewers, bitbucketserver.NewNamedReviewer(r.User.Name))
+			}
+		}
+		for _, name := range missing {
+			update.Reviewers = append(update.Reviewers, bitbucketserver.NewNamedReviewer(name))
+		}
+	}
+
 	updated, err := s.client.UpdatePullRequest(ctx, update)
 	if err != nil {
 		if !bitbucketserver.IsPullRequestOutOfDate(err) {
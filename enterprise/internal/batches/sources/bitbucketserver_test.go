@@ -855,3 +855,25 @@ func TestBitbucketServerSource_GetUserFork(t *testing.T) {
 }
 
 func strPtr(s string) *string { return &s }
+
+func TestMissingReviewers(t *testing.T) {
+	pr := &bitbucketserver.PullRequest{
+		Reviewers: []bitbucketserver.Reviewer{
+			{User: &bitbucketserver.User{Name: "alice"}},
+			{User: nil},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		names []string
+		want  []string
+	}{
+		"none":          {names: nil, want: nil},
+		"all existing":  {names: []string{"alice"}, want: nil},
+		"some existing": {names: []string{"alice", "bob", "carol"}, want: []string{"bob", "carol"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, missingReviewers(pr, tc.names))
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
 // ChangesetNotFoundError is returned by LoadChangeset if the changeset
@@ -48,6 +49,21 @@ type DraftChangesetSource interface {
 	UndraftChangeset(context.Context, *Changeset) error
 }
 
+// An AutoMergeChangesetSource can merge changesets with any merge method of an
+// autoMerge policy, and can have the code host merge a changeset once its
+// checks pass.
+type AutoMergeChangesetSource interface {
+	ChangesetSource
+
+	// MergeChangesetWithMethod merges a Changeset on the code host with the
+	// given merge method, if in a mergeable state. If the changeset cannot be
+	// merged, ChangesetNotMergeableError must be returned.
+	MergeChangesetWithMethod(ctx context.Context, ch *Changeset, method batcheslib.AutoMergeMethod) error
+	// EnableAutoMerge makes the code host merge the Changeset with the given
+	// merge method once its checks pass.
+	EnableAutoMerge(ctx context.Context, ch *Changeset, method batcheslib.AutoMergeMethod) error
+}
+
 type ForkableChangesetSource interface {
 	ChangesetSource
 
@@ -121,6 +137,17 @@ type Changeset struct {
 	HeadRef string
 	BaseRef string
 
+	// Reviewers and TeamReviewers are requested to review the changeset,
+	// Labels and Assignees are added to it and it is put into Milestone when
+	// it is created or updated. When updating, only the fields that changed
+	// in the spec are set. Sources ignore the fields their code host doesn't
+	// support, and never remove existing reviewers, labels or assignees.
+	Reviewers     []string
+	TeamReviewers []string
+	Labels        []string
+	Assignees     []string
+	Milestone     string
+
 	// RemoteRepo is the repository the branch will be pushed to. This must be
 	// the same as TargetRepo if forking is not in use.
 	RemoteRepo *types.Repo
@@ -131,6 +158,13 @@ type Changeset struct {
 	*btypes.Changeset
 }
 
+// HasMetadata returns true if any reviewers, labels, assignees or a milestone
+// are set on the Changeset.
+func (c *Changeset) HasMetadata() bool {
+	return len(c.Reviewers) != 0 || len(c.TeamReviewers) != 0 || len(c.Labels) != 0 ||
+		len(c.Assignees) != 0 || c.Milestone != ""
+}
+
 // IsOutdated returns true when the attributes of the nested
 // batches.Changeset do not match the attributes (title, body, ...) set on
 // the Changeset.
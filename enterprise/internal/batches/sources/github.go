@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -164,6 +165,10 @@ func (s GithubSource) createChangeset(ctx context.Context, c *Changeset, prInput
 		exists = true
 	}
 
+	if err := s.addPullRequestMetadata(ctx, c, pr); err != nil {
+		return exists, err
+	}
+
 	if err := c.SetMetadata(pr); err != nil {
 		return false, errors.Wrap(err, "setting changeset metadata")
 	}
@@ -171,6 +176,29 @@ func (s GithubSource) createChangeset(ctx context.Context, c *Changeset, prInput
 	return exists, nil
 }
 
+// addPullRequestMetadata requests reviews from the reviewers of the changeset
+// and adds its labels, assignees and milestone to the pull request.
+func (s GithubSource) addPullRequestMetadata(ctx context.Context, c *Changeset, pr *github.PullRequest) error {
+	if !c.HasMetadata() {
+		return nil
+	}
+
+	repo := c.TargetRepo.Metadata.(*github.Repository)
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "getting repo owner and name")
+	}
+
+	err = s.client.AddPullRequestMetadata(ctx, owner, name, pr, &github.PullRequestMetadataInput{
+		Reviewers:     c.Reviewers,
+		TeamReviewers: c.TeamReviewers,
+		Labels:        c.Labels,
+		Assignees:     c.Assignees,
+		Milestone:     c.Milestone,
+	})
+	return errors.Wrap(err, "adding reviewers, labels, assignees and milestone")
+}
+
 // CloseChangeset closes the given *Changeset on the code host and updates the
 // Metadata column in the *batches.Changeset to the newly closed pull request.
 func (s GithubSource) CloseChangeset(ctx context.Context, c *Changeset) error {
@@ -247,6 +275,10 @@ func (s GithubSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
 		return err
 	}
 
+	if err := s.addPullRequestMetadata(ctx, c, updated); err != nil {
+		return err
+	}
+
 	return c.Changeset.SetMetadata(updated)
 }
 
@@ -293,6 +325,50 @@ func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset, squash b
 	return c.Changeset.SetMetadata(pr)
 }
 
+// MergeChangesetWithMethod merges a Changeset on the code host with the given
+// merge method, if in a mergeable state.
+func (s GithubSource) MergeChangesetWithMethod(ctx context.Context, c *Changeset, method batcheslib.AutoMergeMethod) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	if err := s.client.MergePullRequestWithMethod(ctx, pr, githubMergeMethod(method)); err != nil {
+		if github.IsNotMergeable(err) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return err
+	}
+
+	return c.Changeset.SetMetadata(pr)
+}
+
+// EnableAutoMerge enables auto-merge on the pull request, so that GitHub merges
+// it with the given merge method once its checks pass.
+func (s GithubSource) EnableAutoMerge(ctx context.Context, c *Changeset, method batcheslib.AutoMergeMethod) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	if err := s.client.EnablePullRequestAutoMerge(ctx, pr, githubMergeMethod(method)); err != nil {
+		return errors.Wrap(err, "enabling auto-merge on GitHub pull request")
+	}
+
+	return c.Changeset.SetMetadata(pr)
+}
+
+func githubMergeMethod(method batcheslib.AutoMergeMethod) github.PullRequestMergeMethod {
+	switch method {
+	case batcheslib.AutoMergeMethodSquash:
+		return github.PullRequestMergeMethodSquash
+	case batcheslib.AutoMergeMethodRebase:
+		return github.PullRequestMergeMethodRebase
+	default:
+		return github.PullRequestMergeMethodMerge
+	}
+}
+
 // GetNamespaceFork returns a repo pointing to a fork of the given repo in
 // the given namespace, ensuring that the fork exists and is a fork of the
 // target repo.
@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -143,6 +144,11 @@ func (s *GitLabSource) CreateChangeset(ctx context.Context, c *Changeset) (bool,
 		}
 	}
 
+	mr, err = s.addMergeRequestMetadata(ctx, targetProject, mr, c)
+	if err != nil {
+		return exists, err
+	}
+
 	// These additional API calls can go away once we can use the GraphQL API.
 	if err := s.decorateMergeRequestData(ctx, targetProject, mr); err != nil {
 		return exists, errors.Wrapf(err, "retrieving additional data for merge request %d", mr.IID)
@@ -479,6 +485,11 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 		return errors.Wrap(err, "updating GitLab merge request")
 	}
 
+	updated, err = s.addMergeRequestMetadata(ctx, project, updated, c)
+	if err != nil {
+		return err
+	}
+
 	// These additional API calls can go away once we can use the GraphQL API.
 	if err := s.decorateMergeRequestData(ctx, project, mr); err != nil {
 		return errors.Wrapf(err, "retrieving additional data for merge request %d", mr.IID)
@@ -487,6 +498,71 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 	return c.Changeset.SetMetadata(updated)
 }
 
+// addMergeRequestMetadata adds the reviewers, labels, assignees and milestone
+// of the changeset to the merge request and returns the updated merge request.
+// Team reviewers are not supported by GitLab and ignored.
+func (s *GitLabSource) addMergeRequestMetadata(ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest, c *Changeset) (*gitlab.MergeRequest, error) {
+	if len(c.Reviewers) == 0 && len(c.Labels) == 0 && len(c.Assignees) == 0 && c.Milestone == "" {
+		return mr, nil
+	}
+
+	opts := gitlab.UpdateMergeRequestOpts{
+		AddLabels: strings.Join(c.Labels, ","),
+	}
+
+	// GitLab replaces the reviewers and assignees of the merge request, so we
+	// have to include the current ones to only add to them.
+	var err error
+	if len(c.Reviewers) != 0 {
+		if opts.ReviewerIDs, err = s.appendUserIDs(ctx, mr.Reviewers, c.Reviewers); err != nil {
+			return nil, errors.Wrap(err, "looking up reviewers")
+		}
+	}
+	if len(c.Assignees) != 0 {
+		if opts.AssigneeIDs, err = s.appendUserIDs(ctx, mr.Assignees, c.Assignees); err != nil {
+			return nil, errors.Wrap(err, "looking up assignees")
+		}
+	}
+
+	if c.Milestone != "" {
+		milestone, err := s.client.GetProjectMilestoneByTitle(ctx, project, c.Milestone)
+		if err != nil {
+			return nil, errors.Wrap(err, "looking up milestone")
+		}
+		opts.MilestoneID = milestone.ID
+	}
+
+	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "adding reviewers, labels, assignees and milestone")
+	}
+	return updated, nil
+}
+
+// appendUserIDs returns the IDs of the given users followed by the IDs of the
+// users with the given usernames that are not already among them.
+func (s *GitLabSource) appendUserIDs(ctx context.Context, users []gitlab.User, usernames []string) ([]int32, error) {
+	ids := make([]int32, 0, len(users)+len(usernames))
+	seen := make(map[string]struct{}, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+		seen[u.Username] = struct{}{}
+	}
+
+	for _, username := range usernames {
+		if _, ok := seen[username]; ok {
+			continue
+		}
+		u, err := s.client.GetUserByUsername(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, u.ID)
+		seen[username] = struct{}{}
+	}
+	return ids, nil
+}
+
 // UndraftChangeset marks the changeset as *not* work in progress anymore.
 func (s *GitLabSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
 	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
@@ -544,6 +620,44 @@ func (s *GitLabSource) MergeChangeset(ctx context.Context, c *Changeset, squash
 	return c.Changeset.SetMetadata(updated)
 }
 
+// MergeChangesetWithMethod merges a Changeset on the code host with the given
+// merge method, if in a mergeable state. GitLab configures rebase merges per
+// project, so they cannot be requested for a single merge request.
+func (s *GitLabSource) MergeChangesetWithMethod(ctx context.Context, c *Changeset, method batcheslib.AutoMergeMethod) error {
+	if method == batcheslib.AutoMergeMethodRebase {
+		return ChangesetNotMergeableError{ErrorMsg: "GitLab does not support rebase merges of a single merge request"}
+	}
+	return s.MergeChangeset(ctx, c, method == batcheslib.AutoMergeMethodSquash)
+}
+
+// EnableAutoMerge makes GitLab merge the merge request with the given merge
+// method once its pipeline succeeds.
+func (s *GitLabSource) EnableAutoMerge(ctx context.Context, c *Changeset, method batcheslib.AutoMergeMethod) error {
+	if method == batcheslib.AutoMergeMethodRebase {
+		return ChangesetNotMergeableError{ErrorMsg: "GitLab does not support rebase merges of a single merge request"}
+	}
+
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	updated, err := s.client.MergeMergeRequestWhenPipelineSucceeds(ctx, project, mr, method == batcheslib.AutoMergeMethodSquash)
+	if err != nil {
+		if errors.Is(err, gitlab.ErrNotMergeable) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "enabling merge when pipeline succeeds on GitLab merge request")
+	}
+
+	if err := s.decorateMergeRequestData(ctx, project, updated); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for merge request %d", updated.IID)
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
 // GetNamespaceFork returns a repo pointing to a fork of the given repo in
 // the given namespace, ensuring that the fork exists and is a fork of the
 // target repo.
@@ -192,6 +192,61 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 				t.Errorf("unexpected metadata: have %+v; want %+v", p.changeset.Changeset.Metadata, p.mr)
 			}
 		})
+
+		t.Run("merge request with reviewers, labels, assignees and milestone", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Reviewers = []string{"alice", "bob"}
+			p.changeset.TeamReviewers = []string{"ignored"}
+			p.changeset.Labels = []string{"batch change", "dependencies"}
+			p.changeset.Assignees = []string{"alice"}
+			p.changeset.Milestone = "4.3"
+
+			p.mr.Reviewers = []gitlab.User{{ID: 2, Username: "bob"}}
+			updated := &gitlab.MergeRequest{IID: p.mr.IID}
+
+			p.mockCreateMergeRequest(gitlab.CreateMergeRequestOpts{
+				SourceBranch: p.mr.SourceBranch,
+				TargetBranch: p.mr.TargetBranch,
+			}, p.mr, nil)
+			p.mockGetMergeRequestNotes(p.mr.IID, nil, 20, nil)
+			p.mockGetMergeRequestResourceStateEvents(p.mr.IID, nil, 20, nil)
+			p.mockGetMergeRequestPipelines(p.mr.IID, nil, 20, nil)
+
+			gitlab.MockGetUserByUsername = func(c *gitlab.Client, ctx context.Context, username string) (*gitlab.User, error) {
+				if username != "alice" {
+					t.Errorf("unexpected username looked up: %q", username)
+				}
+				return &gitlab.User{ID: 1, Username: username}, nil
+			}
+			gitlab.MockGetProjectMilestoneByTitle = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, title string) (*gitlab.Milestone, error) {
+				p.testCommonParams(ctx, c, project)
+				if have, want := title, "4.3"; have != want {
+					t.Errorf("unexpected milestone title: have %q; want %q", have, want)
+				}
+				return &gitlab.Milestone{ID: 42, Title: title}, nil
+			}
+			gitlab.MockUpdateMergeRequest = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest, opts gitlab.UpdateMergeRequestOpts) (*gitlab.MergeRequest, error) {
+				p.testCommonParams(ctx, c, project)
+				want := gitlab.UpdateMergeRequestOpts{
+					AddLabels:   "batch change,dependencies",
+					ReviewerIDs: []int32{2, 1},
+					AssigneeIDs: []int32{1},
+					MilestoneID: 42,
+				}
+				if diff := cmp.Diff(want, opts); diff != "" {
+					t.Errorf("unexpected opts (-want +got):\n%s", diff)
+				}
+				return updated, nil
+			}
+
+			if _, err := p.source.CreateChangeset(p.ctx, p.changeset); err != nil {
+				t.Errorf("unexpected non-nil err: %+v", err)
+			}
+
+			if p.changeset.Changeset.Metadata != updated {
+				t.Errorf("unexpected metadata: have %+v; want %+v", p.changeset.Changeset.Metadata, updated)
+			}
+		})
 	})
 
 	t.Run("CloseChangeset", func(t *testing.T) {
@@ -1170,6 +1225,8 @@ func (p *gitLabChangesetSourceTestProvider) unmock() {
 	gitlab.MockGetOpenMergeRequestByRefs = nil
 	gitlab.MockUpdateMergeRequest = nil
 	gitlab.MockCreateMergeRequestNote = nil
+	gitlab.MockGetUserByUsername = nil
+	gitlab.MockGetProjectMilestoneByTitle = nil
 
 	versions.MockGetVersions = nil
 }
@@ -3622,15 +3622,27 @@ type MockBitbucketCloudClient struct {
 	// RepoFunc is an instance of a mock function object controlling the
 	// behavior of the method Repo.
 	RepoFunc *BitbucketCloudClientRepoFunc
+	// RepoPermissionsFunc is an instance of a mock function object
+	// controlling the behavior of the method RepoPermissions.
+	RepoPermissionsFunc *BitbucketCloudClientRepoPermissionsFunc
 	// ReposFunc is an instance of a mock function object controlling the
 	// behavior of the method Repos.
 	ReposFunc *BitbucketCloudClientReposFunc
 	// UpdatePullRequestFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdatePullRequest.
 	UpdatePullRequestFunc *BitbucketCloudClientUpdatePullRequestFunc
+	// UserRepoPermissionsFunc is an instance of a mock function object
+	// controlling the behavior of the method UserRepoPermissions.
+	UserRepoPermissionsFunc *BitbucketCloudClientUserRepoPermissionsFunc
 	// WithAuthenticatorFunc is an instance of a mock function object
 	// controlling the behavior of the method WithAuthenticator.
 	WithAuthenticatorFunc *BitbucketCloudClientWithAuthenticatorFunc
+	// WorkspaceMemberFunc is an instance of a mock function object
+	// controlling the behavior of the method WorkspaceMember.
+	WorkspaceMemberFunc *BitbucketCloudClientWorkspaceMemberFunc
+	// WorkspaceMembersFunc is an instance of a mock function object
+	// controlling the behavior of the method WorkspaceMembers.
+	WorkspaceMembersFunc *BitbucketCloudClientWorkspaceMembersFunc
 }
 
 // NewMockBitbucketCloudClient creates a new mock of the Client interface.
@@ -3692,6 +3704,11 @@ func NewMockBitbucketCloudClient() *MockBitbucketCloudClient {
 				return
 			},
 		},
+		RepoPermissionsFunc: &BitbucketCloudClientRepoPermissionsFunc{
+			defaultHook: func(string, string) (r0 *bitbucketcloud.PaginatedResultSet, r1 error) {
+				return
+			},
+		},
 		ReposFunc: &BitbucketCloudClientReposFunc{
 			defaultHook: func(context.Context, *bitbucketcloud.PageToken, string) (r0 []*bitbucketcloud.Repo, r1 *bitbucketcloud.PageToken, r2 error) {
 				return
@@ -3702,11 +3719,26 @@ func NewMockBitbucketCloudClient() *MockBitbucketCloudClient {
 				return
 			},
 		},
+		UserRepoPermissionsFunc: &BitbucketCloudClientUserRepoPermissionsFunc{
+			defaultHook: func(string, string) (r0 *bitbucketcloud.PaginatedResultSet, r1 error) {
+				return
+			},
+		},
 		WithAuthenticatorFunc: &BitbucketCloudClientWithAuthenticatorFunc{
 			defaultHook: func(auth.Authenticator) (r0 bitbucketcloud.Client) {
 				return
 			},
 		},
+		WorkspaceMemberFunc: &BitbucketCloudClientWorkspaceMemberFunc{
+			defaultHook: func(context.Context, string, string) (r0 *bitbucketcloud.Account, r1 error) {
+				return
+			},
+		},
+		WorkspaceMembersFunc: &BitbucketCloudClientWorkspaceMembersFunc{
+			defaultHook: func(string) (r0 *bitbucketcloud.PaginatedResultSet, r1 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -3769,6 +3801,11 @@ func NewStrictMockBitbucketCloudClient() *MockBitbucketCloudClient {
 				panic("unexpected invocation of MockBitbucketCloudClient.Repo")
 			},
 		},
+		RepoPermissionsFunc: &BitbucketCloudClientRepoPermissionsFunc{
+			defaultHook: func(string, string) (*bitbucketcloud.PaginatedResultSet, error) {
+				panic("unexpected invocation of MockBitbucketCloudClient.RepoPermissions")
+			},
+		},
 		ReposFunc: &BitbucketCloudClientReposFunc{
 			defaultHook: func(context.Context, *bitbucketcloud.PageToken, string) ([]*bitbucketcloud.Repo, *bitbucketcloud.PageToken, error) {
 				panic("unexpected invocation of MockBitbucketCloudClient.Repos")
@@ -3779,11 +3816,26 @@ func NewStrictMockBitbucketCloudClient() *MockBitbucketCloudClient {
 				panic("unexpected invocation of MockBitbucketCloudClient.UpdatePullRequest")
 			},
 		},
+		UserRepoPermissionsFunc: &BitbucketCloudClientUserRepoPermissionsFunc{
+			defaultHook: func(string, string) (*bitbucketcloud.PaginatedResultSet, error) {
+				panic("unexpected invocation of MockBitbucketCloudClient.UserRepoPermissions")
+			},
+		},
 		WithAuthenticatorFunc: &BitbucketCloudClientWithAuthenticatorFunc{
 			defaultHook: func(auth.Authenticator) bitbucketcloud.Client {
 				panic("unexpected invocation of MockBitbucketCloudClient.WithAuthenticator")
 			},
 		},
+		WorkspaceMemberFunc: &BitbucketCloudClientWorkspaceMemberFunc{
+			defaultHook: func(context.Context, string, string) (*bitbucketcloud.Account, error) {
+				panic("unexpected invocation of MockBitbucketCloudClient.WorkspaceMember")
+			},
+		},
+		WorkspaceMembersFunc: &BitbucketCloudClientWorkspaceMembersFunc{
+			defaultHook: func(string) (*bitbucketcloud.PaginatedResultSet, error) {
+				panic("unexpected invocation of MockBitbucketCloudClient.WorkspaceMembers")
+			},
+		},
 	}
 }
 
@@ -3825,15 +3877,27 @@ func NewMockBitbucketCloudClientFrom(i bitbucketcloud.Client) *MockBitbucketClou
 		RepoFunc: &BitbucketCloudClientRepoFunc{
 			defaultHook: i.Repo,
 		},
+		RepoPermissionsFunc: &BitbucketCloudClientRepoPermissionsFunc{
+			defaultHook: i.RepoPermissions,
+		},
 		ReposFunc: &BitbucketCloudClientReposFunc{
 			defaultHook: i.Repos,
 		},
 		UpdatePullRequestFunc: &BitbucketCloudClientUpdatePullRequestFunc{
 			defaultHook: i.UpdatePullRequest,
 		},
+		UserRepoPermissionsFunc: &BitbucketCloudClientUserRepoPermissionsFunc{
+			defaultHook: i.UserRepoPermissions,
+		},
 		WithAuthenticatorFunc: &BitbucketCloudClientWithAuthenticatorFunc{
 			defaultHook: i.WithAuthenticator,
 		},
+		WorkspaceMemberFunc: &BitbucketCloudClientWorkspaceMemberFunc{
+			defaultHook: i.WorkspaceMember,
+		},
+		WorkspaceMembersFunc: &BitbucketCloudClientWorkspaceMembersFunc{
+			defaultHook: i.WorkspaceMembers,
+		},
 	}
 }
 
@@ -5066,6 +5130,117 @@ func (c BitbucketCloudClientRepoFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// BitbucketCloudClientRepoPermissionsFunc describes the behavior when the
+// RepoPermissions method of the parent MockBitbucketCloudClient instance is
+// invoked.
+type BitbucketCloudClientRepoPermissionsFunc struct {
+	defaultHook func(string, string) (*bitbucketcloud.PaginatedResultSet, error)
+	hooks       []func(string, string) (*bitbucketcloud.PaginatedResultSet, error)
+	history     []BitbucketCloudClientRepoPermissionsFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoPermissions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockBitbucketCloudClient) RepoPermissions(v0 string, v1 string) (*bitbucketcloud.PaginatedResultSet, error) {
+	r0, r1 := m.RepoPermissionsFunc.nextHook()(v0, v1)
+	m.RepoPermissionsFunc.appendCall(BitbucketCloudClientRepoPermissionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RepoPermissions
+// method of the parent MockBitbucketCloudClient instance is invoked and the
+// hook queue is empty.
+func (f *BitbucketCloudClientRepoPermissionsFunc) SetDefaultHook(hook func(string, string) (*bitbucketcloud.PaginatedResultSet, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoPermissions method of the parent MockBitbucketCloudClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *BitbucketCloudClientRepoPermissionsFunc) PushHook(hook func(string, string) (*bitbucketcloud.PaginatedResultSet, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *BitbucketCloudClientRepoPermissionsFunc) SetDefaultReturn(r0 *bitbucketcloud.PaginatedResultSet, r1 error) {
+	f.SetDefaultHook(func(string, string) (*bitbucketcloud.PaginatedResultSet, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *BitbucketCloudClientRepoPermissionsFunc) PushReturn(r0 *bitbucketcloud.PaginatedResultSet, r1 error) {
+	f.PushHook(func(string, string) (*bitbucketcloud.PaginatedResultSet, error) {
+		return r0, r1
+	})
+}
+
+func (f *BitbucketCloudClientRepoPermissionsFunc) nextHook() func(string, string) (*bitbucketcloud.PaginatedResultSet, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BitbucketCloudClientRepoPermissionsFunc) appendCall(r0 BitbucketCloudClientRepoPermissionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of BitbucketCloudClientRepoPermissionsFuncCall
+// objects describing the invocations of this function.
+func (f *BitbucketCloudClientRepoPermissionsFunc) History() []BitbucketCloudClientRepoPermissionsFuncCall {
+	f.mutex.Lock()
+	history := make([]BitbucketCloudClientRepoPermissionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BitbucketCloudClientRepoPermissionsFuncCall is an object that describes
+// an invocation of method RepoPermissions on an instance of
+// MockBitbucketCloudClient.
+type BitbucketCloudClientRepoPermissionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 string
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *bitbucketcloud.PaginatedResultSet
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c BitbucketCloudClientRepoPermissionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BitbucketCloudClientRepoPermissionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // BitbucketCloudClientReposFunc describes the behavior when the Repos
 // method of the parent MockBitbucketCloudClient instance is invoked.
 type BitbucketCloudClientReposFunc struct {
@@ -5298,6 +5473,118 @@ func (c BitbucketCloudClientUpdatePullRequestFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// BitbucketCloudClientUserRepoPermissionsFunc describes the behavior when
+// the UserRepoPermissions method of the parent MockBitbucketCloudClient
+// instance is invoked.
+type BitbucketCloudClientUserRepoPermissionsFunc struct {
+	defaultHook func(string, string) (*bitbucketcloud.PaginatedResultSet, error)
+	hooks       []func(string, string) (*bitbucketcloud.PaginatedResultSet, error)
+	history     []BitbucketCloudClientUserRepoPermissionsFuncCall
+	mutex       sync.Mutex
+}
+
+// UserRepoPermissions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockBitbucketCloudClient) UserRepoPermissions(v0 string, v1 string) (*bitbucketcloud.PaginatedResultSet, error) {
+	r0, r1 := m.UserRepoPermissionsFunc.nextHook()(v0, v1)
+	m.UserRepoPermissionsFunc.appendCall(BitbucketCloudClientUserRepoPermissionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UserRepoPermissions
+// method of the parent MockBitbucketCloudClient instance is invoked and the
+// hook queue is empty.
+func (f *BitbucketCloudClientUserRepoPermissionsFunc) SetDefaultHook(hook func(string, string) (*bitbucketcloud.PaginatedResultSet, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UserRepoPermissions method of the parent MockBitbucketCloudClient
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *BitbucketCloudClientUserRepoPermissionsFunc) PushHook(hook func(string, string) (*bitbucketcloud.PaginatedResultSet, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *BitbucketCloudClientUserRepoPermissionsFunc) SetDefaultReturn(r0 *bitbucketcloud.PaginatedResultSet, r1 error) {
+	f.SetDefaultHook(func(string, string) (*bitbucketcloud.PaginatedResultSet, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *BitbucketCloudClientUserRepoPermissionsFunc) PushReturn(r0 *bitbucketcloud.PaginatedResultSet, r1 error) {
+	f.PushHook(func(string, string) (*bitbucketcloud.PaginatedResultSet, error) {
+		return r0, r1
+	})
+}
+
+func (f *BitbucketCloudClientUserRepoPermissionsFunc) nextHook() func(string, string) (*bitbucketcloud.PaginatedResultSet, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BitbucketCloudClientUserRepoPermissionsFunc) appendCall(r0 BitbucketCloudClientUserRepoPermissionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// BitbucketCloudClientUserRepoPermissionsFuncCall objects describing the
+// invocations of this function.
+func (f *BitbucketCloudClientUserRepoPermissionsFunc) History() []BitbucketCloudClientUserRepoPermissionsFuncCall {
+	f.mutex.Lock()
+	history := make([]BitbucketCloudClientUserRepoPermissionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BitbucketCloudClientUserRepoPermissionsFuncCall is an object that
+// describes an invocation of method UserRepoPermissions on an instance of
+// MockBitbucketCloudClient.
+type BitbucketCloudClientUserRepoPermissionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 string
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *bitbucketcloud.PaginatedResultSet
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c BitbucketCloudClientUserRepoPermissionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BitbucketCloudClientUserRepoPermissionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // BitbucketCloudClientWithAuthenticatorFunc describes the behavior when the
 // WithAuthenticator method of the parent MockBitbucketCloudClient instance
 // is invoked.
@@ -5403,3 +5690,226 @@ func (c BitbucketCloudClientWithAuthenticatorFuncCall) Args() []interface{} {
 func (c BitbucketCloudClientWithAuthenticatorFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// BitbucketCloudClientWorkspaceMemberFunc describes the behavior when the
+// WorkspaceMember method of the parent MockBitbucketCloudClient instance is
+// invoked.
+type BitbucketCloudClientWorkspaceMemberFunc struct {
+	defaultHook func(context.Context, string, string) (*bitbucketcloud.Account, error)
+	hooks       []func(context.Context, string, string) (*bitbucketcloud.Account, error)
+	history     []BitbucketCloudClientWorkspaceMemberFuncCall
+	mutex       sync.Mutex
+}
+
+// WorkspaceMember delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockBitbucketCloudClient) WorkspaceMember(v0 context.Context, v1 string, v2 string) (*bitbucketcloud.Account, error) {
+	r0, r1 := m.WorkspaceMemberFunc.nextHook()(v0, v1, v2)
+	m.WorkspaceMemberFunc.appendCall(BitbucketCloudClientWorkspaceMemberFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the WorkspaceMember
+// method of the parent MockBitbucketCloudClient instance is invoked and the
+// hook queue is empty.
+func (f *BitbucketCloudClientWorkspaceMemberFunc) SetDefaultHook(hook func(context.Context, string, string) (*bitbucketcloud.Account, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WorkspaceMember method of the parent MockBitbucketCloudClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *BitbucketCloudClientWorkspaceMemberFunc) PushHook(hook func(context.Context, string, string) (*bitbucketcloud.Account, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *BitbucketCloudClientWorkspaceMemberFunc) SetDefaultReturn(r0 *bitbucketcloud.Account, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string) (*bitbucketcloud.Account, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *BitbucketCloudClientWorkspaceMemberFunc) PushReturn(r0 *bitbucketcloud.Account, r1 error) {
+	f.PushHook(func(context.Context, string, string) (*bitbucketcloud.Account, error) {
+		return r0, r1
+	})
+}
+
+func (f *BitbucketCloudClientWorkspaceMemberFunc) nextHook() func(context.Context, string, string) (*bitbucketcloud.Account, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BitbucketCloudClientWorkspaceMemberFunc) appendCall(r0 BitbucketCloudClientWorkspaceMemberFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of BitbucketCloudClientWorkspaceMemberFuncCall
+// objects describing the invocations of this function.
+func (f *BitbucketCloudClientWorkspaceMemberFunc) History() []BitbucketCloudClientWorkspaceMemberFuncCall {
+	f.mutex.Lock()
+	history := make([]BitbucketCloudClientWorkspaceMemberFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BitbucketCloudClientWorkspaceMemberFuncCall is an object that describes
+// an invocation of method WorkspaceMember on an instance of
+// MockBitbucketCloudClient.
+type BitbucketCloudClientWorkspaceMemberFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *bitbucketcloud.Account
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c BitbucketCloudClientWorkspaceMemberFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BitbucketCloudClientWorkspaceMemberFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// BitbucketCloudClientWorkspaceMembersFunc describes the behavior when the
+// WorkspaceMembers method of the parent MockBitbucketCloudClient instance
+// is invoked.
+type BitbucketCloudClientWorkspaceMembersFunc struct {
+	defaultHook func(string) (*bitbucketcloud.PaginatedResultSet, error)
+	hooks       []func(string) (*bitbucketcloud.PaginatedResultSet, error)
+	history     []BitbucketCloudClientWorkspaceMembersFuncCall
+	mutex       sync.Mutex
+}
+
+// WorkspaceMembers delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockBitbucketCloudClient) WorkspaceMembers(v0 string) (*bitbucketcloud.PaginatedResultSet, error) {
+	r0, r1 := m.WorkspaceMembersFunc.nextHook()(v0)
+	m.WorkspaceMembersFunc.appendCall(BitbucketCloudClientWorkspaceMembersFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the WorkspaceMembers
+// method of the parent MockBitbucketCloudClient instance is invoked and the
+// hook queue is empty.
+func (f *BitbucketCloudClientWorkspaceMembersFunc) SetDefaultHook(hook func(string) (*bitbucketcloud.PaginatedResultSet, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WorkspaceMembers method of the parent MockBitbucketCloudClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *BitbucketCloudClientWorkspaceMembersFunc) PushHook(hook func(string) (*bitbucketcloud.PaginatedResultSet, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *BitbucketCloudClientWorkspaceMembersFunc) SetDefaultReturn(r0 *bitbucketcloud.PaginatedResultSet, r1 error) {
+	f.SetDefaultHook(func(string) (*bitbucketcloud.PaginatedResultSet, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *BitbucketCloudClientWorkspaceMembersFunc) PushReturn(r0 *bitbucketcloud.PaginatedResultSet, r1 error) {
+	f.PushHook(func(string) (*bitbucketcloud.PaginatedResultSet, error) {
+		return r0, r1
+	})
+}
+
+func (f *BitbucketCloudClientWorkspaceMembersFunc) nextHook() func(string) (*bitbucketcloud.PaginatedResultSet, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BitbucketCloudClientWorkspaceMembersFunc) appendCall(r0 BitbucketCloudClientWorkspaceMembersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// BitbucketCloudClientWorkspaceMembersFuncCall objects describing the
+// invocations of this function.
+func (f *BitbucketCloudClientWorkspaceMembersFunc) History() []BitbucketCloudClientWorkspaceMembersFuncCall {
+	f.mutex.Lock()
+	history := make([]BitbucketCloudClientWorkspaceMembersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BitbucketCloudClientWorkspaceMembersFuncCall is an object that describes
+// an invocation of method WorkspaceMembers on an instance of
+// MockBitbucketCloudClient.
+type BitbucketCloudClientWorkspaceMembersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *bitbucketcloud.PaginatedResultSet
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c BitbucketCloudClientWorkspaceMembersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BitbucketCloudClientWorkspaceMembersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -57,6 +58,7 @@ type FakeChangesetSource struct {
 	AuthenticatedUsernameCalled bool
 	ValidateAuthenticatorCalled bool
 	MergeChangesetCalled        bool
+	EnableAutoMergeCalled       bool
 	IsArchivedPushErrorCalled   bool
 
 	// The Changeset.HeadRef to be expected in CreateChangeset/UpdateChangeset calls.
@@ -103,12 +105,17 @@ type FakeChangesetSource struct {
 
 	// IsArchivedPushErrorTrue is returned when IsArchivedPushError is invoked.
 	IsArchivedPushErrorTrue bool
+
+	// MergeMethod is the merge method that was passed to
+	// MergeChangesetWithMethod or EnableAutoMerge.
+	MergeMethod batcheslib.AutoMergeMethod
 }
 
 var (
 	_ sources.ChangesetSource           = &FakeChangesetSource{}
 	_ sources.ArchivableChangesetSource = &FakeChangesetSource{}
 	_ sources.DraftChangesetSource      = &FakeChangesetSource{}
+	_ sources.AutoMergeChangesetSource  = &FakeChangesetSource{}
 )
 
 func (s *FakeChangesetSource) CreateDraftChangeset(ctx context.Context, c *sources.Changeset) (bool, error) {
@@ -316,6 +323,18 @@ func (s *FakeChangesetSource) MergeChangeset(ctx context.Context, c *sources.Cha
 	return s.Err
 }
 
+func (s *FakeChangesetSource) MergeChangesetWithMethod(ctx context.Context, c *sources.Changeset, method batcheslib.AutoMergeMethod) error {
+	s.MergeChangesetCalled = true
+	s.MergeMethod = method
+	return s.Err
+}
+
+func (s *FakeChangesetSource) EnableAutoMerge(ctx context.Context, c *sources.Changeset, method batcheslib.AutoMergeMethod) error {
+	s.EnableAutoMergeCalled = true
+	s.MergeMethod = method
+	return s.Err
+}
+
 func (s *FakeChangesetSource) IsArchivedPushError(output string) bool {
 	s.IsArchivedPushErrorCalled = true
 	return s.IsArchivedPushErrorTrue
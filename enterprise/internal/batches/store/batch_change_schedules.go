@@ -0,0 +1,451 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+var batchChangeScheduleColumns = SQLColumns{
+	"batch_change_schedules.id",
+	"batch_change_schedules.batch_change_id",
+	"batch_change_schedules.user_id",
+	"batch_change_schedules.cron",
+	"batch_change_schedules.auto_apply",
+	"batch_change_schedules.max_consecutive_failures",
+	"batch_change_schedules.consecutive_failures",
+	"batch_change_schedules.next_run_at",
+	"batch_change_schedules.paused_at",
+	"batch_change_schedules.created_at",
+	"batch_change_schedules.updated_at",
+}
+
+// UpsertBatchChangeSchedule creates the schedule of a batch change, or
+// replaces the existing one.
+func (s *Store) UpsertBatchChangeSchedule(ctx context.Context, sched *btypes.BatchChangeSchedule) (err error) {
+	ctx, _, endObservation := s.operations.upsertBatchChangeSchedule.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("BatchChangeID", int(sched.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if sched.CreatedAt.IsZero() {
+		sched.CreatedAt = s.now()
+	}
+	sched.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		upsertBatchChangeScheduleQueryFmtstr,
+		sched.BatchChangeID,
+		sched.UserID,
+		sched.Cron,
+		sched.AutoApply,
+		sched.MaxConsecutiveFailures,
+		sched.ConsecutiveFailures,
+		sched.NextRunAt,
+		dbutil.NullTimeColumn(sched.PausedAt),
+		sched.CreatedAt,
+		sched.UpdatedAt,
+		sqlf.Join(batchChangeScheduleColumns.ToSqlf(), ", "),
+	)
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchChangeSchedule(sched, sc)
+	})
+}
+
+var upsertBatchChangeScheduleQueryFmtstr = `
+INSERT INTO batch_change_schedules (
+	batch_change_id,
+	user_id,
+	cron,
+	auto_apply,
+	max_consecutive_failures,
+	consecutive_failures,
+	next_run_at,
+	paused_at,
+	created_at,
+	updated_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (batch_change_id) DO UPDATE SET
+	user_id = EXCLUDED.user_id,
+	cron = EXCLUDED.cron,
+	auto_apply = EXCLUDED.auto_apply,
+	max_consecutive_failures = EXCLUDED.max_consecutive_failures,
+	consecutive_failures = EXCLUDED.consecutive_failures,
+	next_run_at = EXCLUDED.next_run_at,
+	paused_at = EXCLUDED.paused_at,
+	updated_at = EXCLUDED.updated_at
+RETURNING %s
+`
+
+// UpdateBatchChangeSchedule updates the given schedule.
+func (s *Store) UpdateBatchChangeSchedule(ctx context.Context, sched *btypes.BatchChangeSchedule) (err error) {
+	ctx, _, endObservation := s.operations.updateBatchChangeSchedule.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(sched.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	sched.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateBatchChangeScheduleQueryFmtstr,
+		sched.Cron,
+		sched.AutoApply,
+		sched.MaxConsecutiveFailures,
+		sched.ConsecutiveFailures,
+		sched.NextRunAt,
+		dbutil.NullTimeColumn(sched.PausedAt),
+		sched.UpdatedAt,
+		sched.ID,
+		sqlf.Join(batchChangeScheduleColumns.ToSqlf(), ", "),
+	)
+
+	updated := &btypes.BatchChangeSchedule{}
+	if err := s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchChangeSchedule(updated, sc)
+	}); err != nil {
+		return err
+	}
+	if updated.ID == 0 {
+		return ErrNoResults
+	}
+	*sched = *updated
+	return nil
+}
+
+var updateBatchChangeScheduleQueryFmtstr = `
+UPDATE batch_change_schedules
+SET
+	cron = %s,
+	auto_apply = %s,
+	max_consecutive_failures = %s,
+	consecutive_failures = %s,
+	next_run_at = %s,
+	paused_at = %s,
+	updated_at = %s
+WHERE id = %s
+RETURNING %s
+`
+
+// DeleteBatchChangeSchedule deletes the schedule of the given batch change,
+// including its run history.
+func (s *Store) DeleteBatchChangeSchedule(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteBatchChangeSchedule.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("BatchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(deleteBatchChangeScheduleQueryFmtstr, batchChangeID))
+	if err != nil {
+		return err
+	}
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+var deleteBatchChangeScheduleQueryFmtstr = `
+DELETE FROM batch_change_schedules WHERE batch_change_id = %s
+`
+
+// GetBatchChangeScheduleOpts captures the query options needed for getting a
+// BatchChangeSchedule.
+type GetBatchChangeScheduleOpts struct {
+	ID            int64
+	BatchChangeID int64
+}
+
+// GetBatchChangeSchedule gets a BatchChangeSchedule matching the given options.
+func (s *Store) GetBatchChangeSchedule(ctx context.Context, opts GetBatchChangeScheduleOpts) (sched *btypes.BatchChangeSchedule, err error) {
+	ctx, _, endObservation := s.operations.getBatchChangeSchedule.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(opts.ID)),
+		log.Int("BatchChangeID", int(opts.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_schedules.id = %s", opts.ID))
+	}
+	if opts.BatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_schedules.batch_change_id = %s", opts.BatchChangeID))
+	}
+
+	q := sqlf.Sprintf(
+		getBatchChangeScheduleQueryFmtstr,
+		sqlf.Join(batchChangeScheduleColumns.ToSqlf(), ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	var c btypes.BatchChangeSchedule
+	if err := s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchChangeSchedule(&c, sc)
+	}); err != nil {
+		return nil, err
+	}
+	if c.ID == 0 {
+		return nil, ErrNoResults
+	}
+	return &c, nil
+}
+
+var getBatchChangeScheduleQueryFmtstr = `
+SELECT %s FROM batch_change_schedules
+WHERE %s
+LIMIT 1
+`
+
+// ListDueBatchChangeSchedules lists the schedules that aren't paused and whose
+// next run is due. Schedules of closed batch changes and of batch changes that
+// never had a batch spec applied are skipped.
+func (s *Store) ListDueBatchChangeSchedules(ctx context.Context) (cs []*btypes.BatchChangeSchedule, err error) {
+	ctx, _, endObservation := s.operations.listDueBatchChangeSchedules.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listDueBatchChangeSchedulesQueryFmtstr,
+		sqlf.Join(batchChangeScheduleColumns.ToSqlf(), ", "),
+		s.now(),
+	)
+
+	cs = make([]*btypes.BatchChangeSchedule, 0)
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var c btypes.BatchChangeSchedule
+		if err := scanBatchChangeSchedule(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+	return cs, err
+}
+
+var listDueBatchChangeSchedulesQueryFmtstr = `
+SELECT %s FROM batch_change_schedules
+JOIN batch_changes ON batch_changes.id = batch_change_schedules.batch_change_id
+WHERE
+	batch_change_schedules.paused_at IS NULL
+AND
+	batch_change_schedules.next_run_at <= %s
+AND
+	batch_changes.closed_at IS NULL
+AND
+	batch_changes.batch_spec_id IS NOT NULL
+AND
+	batch_changes.last_applier_id IS NOT NULL
+ORDER BY batch_change_schedules.next_run_at ASC
+`
+
+func scanBatchChangeSchedule(c *btypes.BatchChangeSchedule, sc dbutil.Scanner) error {
+	return sc.Scan(
+		&c.ID,
+		&c.BatchChangeID,
+		&c.UserID,
+		&c.Cron,
+		&c.AutoApply,
+		&c.MaxConsecutiveFailures,
+		&c.ConsecutiveFailures,
+		&c.NextRunAt,
+		&dbutil.NullTime{Time: &c.PausedAt},
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+}
+
+var batchChangeScheduleRunColumns = SQLColumns{
+	"batch_change_schedule_runs.id",
+	"batch_change_schedule_runs.schedule_id",
+	"batch_change_schedule_runs.batch_change_id",
+	"batch_change_schedule_runs.batch_spec_id",
+	"batch_change_schedule_runs.state",
+	"batch_change_schedule_runs.failure_message",
+	"batch_change_schedule_runs.applied",
+	"batch_change_schedule_runs.started_at",
+	"batch_change_schedule_runs.finished_at",
+	"batch_change_schedule_runs.created_at",
+	"batch_change_schedule_runs.updated_at",
+}
+
+// CreateBatchChangeScheduleRun creates the given run.
+func (s *Store) CreateBatchChangeScheduleRun(ctx context.Context, run *btypes.BatchChangeScheduleRun) (err error) {
+	ctx, _, endObservation := s.operations.createBatchChangeScheduleRun.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ScheduleID", int(run.ScheduleID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if run.CreatedAt.IsZero() {
+		run.CreatedAt = s.now()
+	}
+	if run.UpdatedAt.IsZero() {
+		run.UpdatedAt = run.CreatedAt
+	}
+	if run.StartedAt.IsZero() {
+		run.StartedAt = run.CreatedAt
+	}
+
+	q := sqlf.Sprintf(
+		createBatchChangeScheduleRunQueryFmtstr,
+		run.ScheduleID,
+		run.BatchChangeID,
+		dbutil.NullInt64Column(run.BatchSpecID),
+		run.State,
+		dbutil.NewNullString(run.FailureMessage),
+		run.Applied,
+		run.StartedAt,
+		dbutil.NullTimeColumn(run.FinishedAt),
+		run.CreatedAt,
+		run.UpdatedAt,
+		sqlf.Join(batchChangeScheduleRunColumns.ToSqlf(), ", "),
+	)
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchChangeScheduleRun(run, sc)
+	})
+}
+
+var createBatchChangeScheduleRunQueryFmtstr = `
+INSERT INTO batch_change_schedule_runs (
+	schedule_id,
+	batch_change_id,
+	batch_spec_id,
+	state,
+	failure_message,
+	applied,
+	started_at,
+	finished_at,
+	created_at,
+	updated_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// UpdateBatchChangeScheduleRun updates the state of the given run.
+func (s *Store) UpdateBatchChangeScheduleRun(ctx context.Context, run *btypes.BatchChangeScheduleRun) (err error) {
+	ctx, _, endObservation := s.operations.updateBatchChangeScheduleRun.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(run.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	run.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateBatchChangeScheduleRunQueryFmtstr,
+		dbutil.NullInt64Column(run.BatchSpecID),
+		run.State,
+		dbutil.NewNullString(run.FailureMessage),
+		run.Applied,
+		dbutil.NullTimeColumn(run.FinishedAt),
+		run.UpdatedAt,
+		run.ID,
+		sqlf.Join(batchChangeScheduleRunColumns.ToSqlf(), ", "),
+	)
+
+	updated := &btypes.BatchChangeScheduleRun{}
+	if err := s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchChangeScheduleRun(updated, sc)
+	}); err != nil {
+		return err
+	}
+	if updated.ID == 0 {
+		return ErrNoResults
+	}
+	*run = *updated
+	return nil
+}
+
+var updateBatchChangeScheduleRunQueryFmtstr = `
+UPDATE batch_change_schedule_runs
+SET
+	batch_spec_id = %s,
+	state = %s,
+	failure_message = %s,
+	applied = %s,
+	finished_at = %s,
+	updated_at = %s
+WHERE id = %s
+RETURNING %s
+`
+
+// ListBatchChangeScheduleRunsOpts captures the query options needed for
+// listing the runs of batch change schedules.
+type ListBatchChangeScheduleRunsOpts struct {
+	ScheduleID int64
+	States     []btypes.BatchChangeScheduleRunState
+	Limit      int
+}
+
+// ListBatchChangeScheduleRuns lists runs matching the given options, newest
+// first.
+func (s *Store) ListBatchChangeScheduleRuns(ctx context.Context, opts ListBatchChangeScheduleRunsOpts) (rs []*btypes.BatchChangeScheduleRun, err error) {
+	ctx, _, endObservation := s.operations.listBatchChangeScheduleRuns.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ScheduleID", int(opts.ScheduleID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.ScheduleID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_schedule_runs.schedule_id = %s", opts.ScheduleID))
+	}
+	if len(opts.States) > 0 {
+		states := make([]string, 0, len(opts.States))
+		for _, st := range opts.States {
+			states = append(states, string(st))
+		}
+		preds = append(preds, sqlf.Sprintf("batch_change_schedule_runs.state = ANY(%s)", pq.Array(states)))
+	}
+
+	limit := &sqlf.Query{}
+	if opts.Limit > 0 {
+		limit = sqlf.Sprintf("LIMIT %s", opts.Limit)
+	}
+
+	q := sqlf.Sprintf(
+		listBatchChangeScheduleRunsQueryFmtstr,
+		sqlf.Join(batchChangeScheduleRunColumns.ToSqlf(), ", "),
+		sqlf.Join(preds, "\n AND "),
+		limit,
+	)
+
+	rs = make([]*btypes.BatchChangeScheduleRun, 0)
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var r btypes.BatchChangeScheduleRun
+		if err := scanBatchChangeScheduleRun(&r, sc); err != nil {
+			return err
+		}
+		rs = append(rs, &r)
+		return nil
+	})
+	return rs, err
+}
+
+var listBatchChangeScheduleRunsQueryFmtstr = `
+SELECT %s FROM batch_change_schedule_runs
+WHERE %s
+ORDER BY batch_change_schedule_runs.id DESC
+%s
+`
+
+func scanBatchChangeScheduleRun(r *btypes.BatchChangeScheduleRun, sc dbutil.Scanner) error {
+	return sc.Scan(
+		&r.ID,
+		&r.ScheduleID,
+		&r.BatchChangeID,
+		&dbutil.NullInt64{N: &r.BatchSpecID},
+		&r.State,
+		&dbutil.NullString{S: &r.FailureMessage},
+		&r.Applied,
+		&r.StartedAt,
+		&dbutil.NullTime{Time: &r.FinishedAt},
+		&r.CreatedAt,
+		&r.UpdatedAt,
+	)
+}
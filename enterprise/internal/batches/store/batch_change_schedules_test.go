@@ -0,0 +1,219 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func testStoreBatchChangeSchedules(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	spec := bt.CreateBatchSpec(t, ctx, s, "scheduled", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "scheduled", user.ID, spec.ID)
+
+	closedSpec := bt.CreateBatchSpec(t, ctx, s, "closed", user.ID, 0)
+	closedBatchChange := bt.BuildBatchChange(s, "closed", user.ID, closedSpec.ID)
+	closedBatchChange.ClosedAt = clock.Now()
+	if err := s.CreateBatchChange(ctx, closedBatchChange); err != nil {
+		t.Fatal(err)
+	}
+
+	otherUser := bt.CreateTestUser(t, s.DatabaseDB(), false)
+
+	sched := &btypes.BatchChangeSchedule{
+		BatchChangeID:          batchChange.ID,
+		UserID:                 user.ID,
+		Cron:                   "@daily",
+		AutoApply:              btypes.BatchChangeScheduleAutoApplyOnSuccess,
+		MaxConsecutiveFailures: 3,
+		NextRunAt:              clock.Now().Add(-time.Minute),
+	}
+	closedSched := &btypes.BatchChangeSchedule{
+		BatchChangeID:          closedBatchChange.ID,
+		UserID:                 user.ID,
+		Cron:                   "@daily",
+		AutoApply:              btypes.BatchChangeScheduleAutoApplyNever,
+		MaxConsecutiveFailures: 3,
+		NextRunAt:              clock.Now().Add(-time.Minute),
+	}
+
+	t.Run("Upsert", func(t *testing.T) {
+		for _, sc := range []*btypes.BatchChangeSchedule{sched, closedSched} {
+			if err := s.UpsertBatchChangeSchedule(ctx, sc); err != nil {
+				t.Fatal(err)
+			}
+			if sc.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+			if !sc.CreatedAt.Equal(clock.Now()) {
+				t.Fatalf("wrong CreatedAt: %s", sc.CreatedAt)
+			}
+		}
+
+		// Upserting again replaces the schedule instead of creating a new one.
+		replaced := &btypes.BatchChangeSchedule{
+			BatchChangeID:          batchChange.ID,
+			UserID:                 otherUser.ID,
+			Cron:                   "0 9 * * mon",
+			AutoApply:              btypes.BatchChangeScheduleAutoApplyAlways,
+			MaxConsecutiveFailures: 5,
+			NextRunAt:              clock.Now().Add(-time.Minute),
+		}
+		if err := s.UpsertBatchChangeSchedule(ctx, replaced); err != nil {
+			t.Fatal(err)
+		}
+		if replaced.ID != sched.ID {
+			t.Fatalf("expected schedule %d to be replaced, got new schedule %d", sched.ID, replaced.ID)
+		}
+		if replaced.UserID != otherUser.ID {
+			t.Fatalf("expected schedule to be replaced by user %d, got %d", otherUser.ID, replaced.UserID)
+		}
+		sched = replaced
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		have, err := s.GetBatchChangeSchedule(ctx, GetBatchChangeScheduleOpts{BatchChangeID: batchChange.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, sched); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, err = s.GetBatchChangeSchedule(ctx, GetBatchChangeScheduleOpts{ID: closedSched.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, closedSched); diff != "" {
+			t.Fatal(diff)
+		}
+
+		if _, err := s.GetBatchChangeSchedule(ctx, GetBatchChangeScheduleOpts{ID: 0xdeadbeef}); err != ErrNoResults {
+			t.Fatalf("unexpected error: have=%v want=%v", err, ErrNoResults)
+		}
+	})
+
+	t.Run("ListDue", func(t *testing.T) {
+		have, err := s.ListDueBatchChangeSchedules(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, []*btypes.BatchChangeSchedule{sched}); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		sched.ConsecutiveFailures = 3
+		sched.PausedAt = clock.Now()
+		if err := s.UpdateBatchChangeSchedule(ctx, sched); err != nil {
+			t.Fatal(err)
+		}
+		if sched.ConsecutiveFailures != 3 || !sched.PausedAt.Equal(clock.Now()) {
+			t.Fatalf("schedule not updated: %+v", sched)
+		}
+
+		// Paused schedules are not due.
+		have, err := s.ListDueBatchChangeSchedules(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("expected no due schedules, got %d", len(have))
+		}
+	})
+
+	var runs []*btypes.BatchChangeScheduleRun
+	t.Run("CreateRun", func(t *testing.T) {
+		for _, state := range []btypes.BatchChangeScheduleRunState{
+			btypes.BatchChangeScheduleRunStateFailed,
+			btypes.BatchChangeScheduleRunStateResolving,
+		} {
+			run := &btypes.BatchChangeScheduleRun{
+				ScheduleID:    sched.ID,
+				BatchChangeID: batchChange.ID,
+				BatchSpecID:   spec.ID,
+				State:         state,
+			}
+			if err := s.CreateBatchChangeScheduleRun(ctx, run); err != nil {
+				t.Fatal(err)
+			}
+			if run.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+			if !run.StartedAt.Equal(clock.Now()) {
+				t.Fatalf("wrong StartedAt: %s", run.StartedAt)
+			}
+			runs = append(runs, run)
+		}
+	})
+
+	t.Run("ListRuns", func(t *testing.T) {
+		have, err := s.ListBatchChangeScheduleRuns(ctx, ListBatchChangeScheduleRunsOpts{ScheduleID: sched.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Newest first.
+		if diff := cmp.Diff(have, []*btypes.BatchChangeScheduleRun{runs[1], runs[0]}); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, err = s.ListBatchChangeScheduleRuns(ctx, ListBatchChangeScheduleRunsOpts{
+			States: []btypes.BatchChangeScheduleRunState{btypes.BatchChangeScheduleRunStateResolving},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, []*btypes.BatchChangeScheduleRun{runs[1]}); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, err = s.ListBatchChangeScheduleRuns(ctx, ListBatchChangeScheduleRunsOpts{ScheduleID: sched.ID, Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 {
+			t.Fatalf("expected 1 run, got %d", len(have))
+		}
+	})
+
+	t.Run("UpdateRun", func(t *testing.T) {
+		run := runs[1]
+		run.State = btypes.BatchChangeScheduleRunStateCompleted
+		run.Applied = true
+		run.FinishedAt = clock.Now()
+		if err := s.UpdateBatchChangeScheduleRun(ctx, run); err != nil {
+			t.Fatal(err)
+		}
+		if run.State != btypes.BatchChangeScheduleRunStateCompleted || !run.Applied || !run.FinishedAt.Equal(clock.Now()) {
+			t.Fatalf("run not updated: %+v", run)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.DeleteBatchChangeSchedule(ctx, batchChange.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.GetBatchChangeSchedule(ctx, GetBatchChangeScheduleOpts{BatchChangeID: batchChange.ID}); err != ErrNoResults {
+			t.Fatalf("unexpected error: have=%v want=%v", err, ErrNoResults)
+		}
+
+		// The run history is deleted with the schedule.
+		have, err := s.ListBatchChangeScheduleRuns(ctx, ListBatchChangeScheduleRunsOpts{ScheduleID: sched.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("expected no runs, got %d", len(have))
+		}
+
+		if err := s.DeleteBatchChangeSchedule(ctx, batchChange.ID); err != ErrNoResults {
+			t.Fatalf("unexpected error: have=%v want=%v", err, ErrNoResults)
+		}
+	})
+}
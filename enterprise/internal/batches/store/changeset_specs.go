@@ -45,6 +45,14 @@ var changesetSpecInsertColumns = []string{
 	"commit_author_name",
 	"commit_author_email",
 	"type",
+	"auto_merge",
+	"reviewers",
+	"team_reviewers",
+	"labels",
+	"assignees",
+	"milestone",
+	"depends_on",
+	"provides",
 }
 
 // changesetSpecColumns are used by the changeset spec related Store methods to
@@ -72,6 +80,14 @@ var changesetSpecColumns = SQLColumns{
 	"changeset_specs.commit_author_name",
 	"changeset_specs.commit_author_email",
 	"changeset_specs.type",
+	"changeset_specs.auto_merge",
+	"changeset_specs.reviewers",
+	"changeset_specs.team_reviewers",
+	"changeset_specs.labels",
+	"changeset_specs.assignees",
+	"changeset_specs.milestone",
+	"changeset_specs.depends_on",
+	"changeset_specs.provides",
 }
 
 var oneGigabyte = 1000000000
@@ -107,6 +123,14 @@ func (s *Store) CreateChangesetSpec(ctx context.Context, cs ...*btypes.Changeset
 				}
 			}
 
+			var autoMerge []byte
+			if c.AutoMerge != nil {
+				autoMerge, err = json.Marshal(c.AutoMerge)
+				if err != nil {
+					return err
+				}
+			}
+
 			// We check if the resulting diff is greater than 1GB, since the limit
 			// for the diff column (which is bytea) is 1GB
 			if len(c.Diff) > oneGigabyte {
@@ -137,6 +161,14 @@ func (s *Store) CreateChangesetSpec(ctx context.Context, cs ...*btypes.Changeset
 				dbutil.NewNullString(c.CommitAuthorName),
 				dbutil.NewNullString(c.CommitAuthorEmail),
 				c.Type,
+				autoMerge,
+				pq.Array(c.Reviewers),
+				pq.Array(c.TeamReviewers),
+				pq.Array(c.Labels),
+				pq.Array(c.Assignees),
+				dbutil.NewNullString(c.Milestone),
+				pq.Array(c.DependsOn),
+				pq.Array(c.Provides),
 			); err != nil {
 				return err
 			}
@@ -537,7 +569,7 @@ func deleteChangesetSpecsQuery(opts *DeleteChangesetSpecsOpts) *sqlf.Query {
 }
 
 func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
-	var published []byte
+	var published, autoMerge []byte
 	var typ string
 	err := s.Scan(
 		&c.ID,
@@ -562,6 +594,14 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 		&dbutil.NullString{S: &c.CommitAuthorName},
 		&dbutil.NullString{S: &c.CommitAuthorEmail},
 		&typ,
+		&autoMerge,
+		pq.Array(&c.Reviewers),
+		pq.Array(&c.TeamReviewers),
+		pq.Array(&c.Labels),
+		pq.Array(&c.Assignees),
+		&dbutil.NullString{S: &c.Milestone},
+		pq.Array(&c.DependsOn),
+		pq.Array(&c.Provides),
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset spec")
@@ -575,6 +615,12 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 		}
 	}
 
+	if len(autoMerge) != 0 {
+		if err := json.Unmarshal(autoMerge, &c.AutoMerge); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -68,6 +68,7 @@ var changesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.closing"),
 	sqlf.Sprintf("changesets.syncer_error"),
 	sqlf.Sprintf("changesets.detached_at"),
+	sqlf.Sprintf("changesets.blocked"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -105,6 +106,7 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("num_failures"),
 	sqlf.Sprintf("closing"),
 	sqlf.Sprintf("syncer_error"),
+	sqlf.Sprintf("blocked"),
 	// We additionally store the result of changeset.Title() in a column, so
 	// the business logic for determining it is in one place and the field is
 	// indexable for searching.
@@ -187,6 +189,7 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		c.NumFailures,
 		c.Closing,
 		c.SyncErrorMessage,
+		c.Blocked,
 		dbutil.NullStringColumn(title),
 	}
 
@@ -230,7 +233,7 @@ func (s *Store) CreateChangeset(ctx context.Context, c *btypes.Changeset) (err e
 
 var createChangesetQueryFmtstr = `
 INSERT INTO changesets (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -526,6 +529,10 @@ type ListChangesetsOpts struct {
 	TextSearch           []search.TextSearchTerm
 	EnforceAuthz         bool
 	RepoIDs              []api.RepoID
+	// DependencyNames only includes the changesets in a repository of one of
+	// the names, or whose current spec provides one of them.
+	DependencyNames      []string
+	OnlyBlocked          bool
 	BitbucketCloudCommit string
 }
 
@@ -618,6 +625,16 @@ func listChangesetsQuery(opts *ListChangesetsOpts, authzConds *sqlf.Query) *sqlf
 	if len(opts.RepoIDs) > 0 {
 		preds = append(preds, sqlf.Sprintf("repo.id = ANY (%s)", pq.Array(opts.RepoIDs)))
 	}
+	if len(opts.DependencyNames) > 0 {
+		preds = append(preds, sqlf.Sprintf(
+			"(repo.name = ANY (%s) OR EXISTS (SELECT 1 FROM changeset_specs provider WHERE provider.id = changesets.current_spec_id AND provider.provides && %s))",
+			pq.Array(opts.DependencyNames),
+			pq.Array(opts.DependencyNames),
+		))
+	}
+	if opts.OnlyBlocked {
+		preds = append(preds, sqlf.Sprintf("changesets.blocked"))
+	}
 	if len(opts.BitbucketCloudCommit) >= 12 {
 		// Bitbucket Cloud commit hashes in PR objects are generally truncated
 		// to 12 characters, but this isn't actually documented in the API
@@ -734,7 +751,7 @@ func (s *Store) UpdateChangeset(ctx context.Context, cs *btypes.Changeset) (err
 
 var updateChangesetQueryFmtstr = `
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -768,6 +785,18 @@ func (s *Store) UpdateChangesetUiPublicationState(ctx context.Context, cs *btype
 	return s.updateChangesetColumn(ctx, cs, "ui_publication_state", uiPublicationState)
 }
 
+// UpdateChangesetBlocked updates only the `blocked` & `updated_at` columns of
+// the given Changeset.
+func (s *Store) UpdateChangesetBlocked(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, _, endObservation := s.operations.updateChangesetBlocked.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+		log.Bool("blocked", cs.Blocked),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.updateChangesetColumn(ctx, cs, "blocked", cs.Blocked)
+}
+
 // updateChangesetColumn updates the column with the given name, setting it to
 // the given value, and updating the updated_at column.
 func (s *Store) updateChangesetColumn(ctx context.Context, cs *btypes.Changeset, name string, val any) error {
@@ -1143,6 +1172,7 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		&t.Closing,
 		&dbutil.NullString{S: &syncErrorMessage},
 		&dbutil.NullTime{Time: &t.DetachedAt},
+		&t.Blocked,
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -768,6 +768,47 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock bt.C
 				assert.NoError(t, err)
 				assert.ElementsMatch(t, []*btypes.Changeset{}, have)
 			})
+
+			t.Run("dependency names", func(t *testing.T) {
+				have, _, err := s.ListChangesets(ctx, ListChangesetsOpts{
+					DependencyNames: []string{string(otherRepo.Name), string(gitlabRepo.Name)},
+				})
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []*btypes.Changeset{otherChangeset, gitlabChangeset}, have)
+
+				spec := &btypes.ChangesetSpec{
+					BaseRepoID: repo.ID,
+					Type:       btypes.ChangesetSpecTypeBranch,
+					Provides:   []string{"library"},
+				}
+				require.NoError(t, s.CreateChangesetSpec(ctx, spec))
+				t.Cleanup(func() { s.DeleteChangesetSpec(ctx, spec.ID) })
+
+				provider := changesets[0].Clone()
+				provider.ExternalID = "provider"
+				provider.CurrentSpecID = spec.ID
+				require.NoError(t, s.CreateChangeset(ctx, provider))
+				t.Cleanup(func() { s.DeleteChangeset(ctx, provider.ID) })
+
+				have, _, err = s.ListChangesets(ctx, ListChangesetsOpts{
+					DependencyNames: []string{"library", string(gitlabRepo.Name)},
+				})
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []*btypes.Changeset{provider, gitlabChangeset}, have)
+			})
+		})
+
+		t.Run("OnlyBlocked", func(t *testing.T) {
+			c := changesets[0].Clone()
+			c.RepoID = otherRepo.ID
+			c.ExternalID = "blocked"
+			c.Blocked = true
+			require.NoError(t, s.CreateChangeset(ctx, c))
+			t.Cleanup(func() { s.DeleteChangeset(ctx, c.ID) })
+
+			have, _, err := s.ListChangesets(ctx, ListChangesetsOpts{OnlyBlocked: true})
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, []*btypes.Changeset{c}, have)
 		})
 
 		statePublished := btypes.ChangesetPublicationStatePublished
@@ -1598,6 +1639,29 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock bt.C
 			t.Fatalf("invalid changeset: %s", diff)
 		}
 	})
+
+	t.Run("UpdateChangesetBlocked", func(t *testing.T) {
+		c1 := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			ReconcilerState:  btypes.ReconcilerStateCompleted,
+			PublicationState: btypes.ChangesetPublicationStateUnpublished,
+			Repo:             repo.ID,
+		})
+
+		c1.Blocked = true
+		want := c1.Clone()
+
+		// Other columns should not be updated in the DB.
+		c1.ReconcilerState = btypes.ReconcilerStateErrored
+
+		if err := s.UpdateChangesetBlocked(ctx, c1); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		have := c1
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatalf("invalid changeset: %s", diff)
+		}
+	})
 }
 
 func testStoreListChangesetSyncData(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
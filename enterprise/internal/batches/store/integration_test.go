@@ -44,6 +44,7 @@ func TestIntegration(t *testing.T) {
 		t.Run("BatchSpecWorkspaces", storeTest(db, nil, testStoreBatchSpecWorkspaces))
 		t.Run("BatchSpecWorkspaceExecutionJobs", storeTest(db, nil, testStoreBatchSpecWorkspaceExecutionJobs))
 		t.Run("BatchSpecResolutionJobs", storeTest(db, nil, testStoreBatchSpecResolutionJobs))
+		t.Run("BatchChangeSchedules", storeTest(db, nil, testStoreBatchChangeSchedules))
 		t.Run("BatchSpecExecutionCacheEntries", storeTest(db, nil, testStoreBatchSpecExecutionCacheEntries))
 
 		for name, key := range map[string]encryption.Key{
@@ -237,6 +237,7 @@ type operations struct {
 	updateChangeset                   *observation.Operation
 	updateChangesetBatchChanges       *observation.Operation
 	updateChangesetUIPublicationState *observation.Operation
+	updateChangesetBlocked            *observation.Operation
 	updateChangesetCodeHostState      *observation.Operation
 	getChangesetExternalIDs           *observation.Operation
 	cancelQueuedBatchChangeChangesets *observation.Operation
@@ -277,6 +278,15 @@ type operations struct {
 	getBatchSpecResolutionJob    *observation.Operation
 	listBatchSpecResolutionJobs  *observation.Operation
 
+	upsertBatchChangeSchedule    *observation.Operation
+	updateBatchChangeSchedule    *observation.Operation
+	deleteBatchChangeSchedule    *observation.Operation
+	getBatchChangeSchedule       *observation.Operation
+	listDueBatchChangeSchedules  *observation.Operation
+	createBatchChangeScheduleRun *observation.Operation
+	updateBatchChangeScheduleRun *observation.Operation
+	listBatchChangeScheduleRuns  *observation.Operation
+
 	listBatchSpecExecutionCacheEntries     *observation.Operation
 	markUsedBatchSpecExecutionCacheEntries *observation.Operation
 	createBatchSpecExecutionCacheEntry     *observation.Operation
@@ -381,6 +391,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 			updateChangeset:                   op("UpdateChangeset"),
 			updateChangesetBatchChanges:       op("UpdateChangesetBatchChanges"),
 			updateChangesetUIPublicationState: op("UpdateChangesetUIPublicationState"),
+			updateChangesetBlocked:            op("UpdateChangesetBlocked"),
 			updateChangesetCodeHostState:      op("UpdateChangesetCodeHostState"),
 			getChangesetExternalIDs:           op("GetChangesetExternalIDs"),
 			cancelQueuedBatchChangeChangesets: op("CancelQueuedBatchChangeChangesets"),
@@ -421,6 +432,15 @@ func newOperations(observationCtx *observation.Context) *operations {
 			getBatchSpecResolutionJob:    op("GetBatchSpecResolutionJob"),
 			listBatchSpecResolutionJobs:  op("ListBatchSpecResolutionJobs"),
 
+			upsertBatchChangeSchedule:    op("UpsertBatchChangeSchedule"),
+			updateBatchChangeSchedule:    op("UpdateBatchChangeSchedule"),
+			deleteBatchChangeSchedule:    op("DeleteBatchChangeSchedule"),
+			getBatchChangeSchedule:       op("GetBatchChangeSchedule"),
+			listDueBatchChangeSchedules:  op("ListDueBatchChangeSchedules"),
+			createBatchChangeScheduleRun: op("CreateBatchChangeScheduleRun"),
+			updateBatchChangeScheduleRun: op("UpdateBatchChangeScheduleRun"),
+			listBatchChangeScheduleRuns:  op("ListBatchChangeScheduleRuns"),
+
 			listBatchSpecExecutionCacheEntries:     op("ListBatchSpecExecutionCacheEntries"),
 			markUsedBatchSpecExecutionCacheEntries: op("MarkUsedBatchSpecExecutionCacheEntries"),
 			createBatchSpecExecutionCacheEntry:     op("CreateBatchSpecExecutionCacheEntry"),
@@ -10,6 +10,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
@@ -501,6 +502,10 @@ func (s *changesetSyncer) SyncChangeset(ctx context.Context, id int64) error {
 // SyncChangeset refreshes the metadata of the given changeset and
 // updates them in the database.
 func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Client, source sources.ChangesetSource, repo *types.Repo, c *btypes.Changeset) (err error) {
+	// Remember the state before syncing, so we can tell if the changeset only
+	// now satisfies its autoMerge policy.
+	previous := c.Clone()
+
 	repoChangeset := &sources.Changeset{TargetRepo: repo, Changeset: c}
 	if err := source.LoadChangeset(ctx, repoChangeset); err != nil {
 		if !errors.HasType(err, sources.ChangesetNotFoundError{}) {
@@ -538,5 +543,41 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 		return err
 	}
 
-	return tx.UpsertChangesetEvents(ctx, events...)
+	if err := tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		return err
+	}
+
+	return enqueueAutoMerge(ctx, tx, previous, c)
+}
+
+// enqueueAutoMerge enqueues the changeset for the reconciler, if its synced
+// state satisfies the autoMerge policy of its current changeset spec and didn't
+// at the previous sync. The reconciler then merges the changeset.
+func enqueueAutoMerge(ctx context.Context, tx *store.Store, previous, c *btypes.Changeset) error {
+	if c.CurrentSpecID == 0 || c.ReconcilerState != btypes.ReconcilerStateCompleted {
+		return nil
+	}
+
+	spec, err := tx.GetChangesetSpecByID(ctx, c.CurrentSpecID)
+	if err != nil {
+		return errors.Wrap(err, "loading changeset spec")
+	}
+	if spec.AutoMerge == nil {
+		return nil
+	}
+
+	action, err := c.AutoMergeAction(spec.AutoMerge, tx.Clock()())
+	if err != nil || action == btypes.AutoMergeActionNone {
+		// An invalid policy is reported by the reconciler when the spec is
+		// applied, there's nothing for us to do here.
+		return nil
+	}
+	// previous.UpdatedAt is the time of the previous sync, so a merge window
+	// that opened since then counts as a change as well.
+	previousAction, err := previous.AutoMergeAction(spec.AutoMerge, previous.UpdatedAt)
+	if err == nil && previousAction == action {
+		return nil
+	}
+
+	return tx.EnqueueChangeset(ctx, c, global.DefaultReconcilerEnqueueState(), btypes.ReconcilerStateCompleted)
 }
@@ -47,6 +47,7 @@ type TestChangesetOpts struct {
 	Closing    bool
 	IsArchived bool
 	Archive    bool
+	Blocked    bool
 
 	Metadata any
 }
@@ -95,6 +96,7 @@ func BuildChangeset(opts TestChangesetOpts) *btypes.Changeset {
 		OwnedByBatchChangeID: opts.OwnedByBatchChange,
 
 		Closing: opts.Closing,
+		Blocked: opts.Blocked,
 
 		ReconcilerState: opts.ReconcilerState,
 		NumFailures:     opts.NumFailures,
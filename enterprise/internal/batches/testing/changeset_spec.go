@@ -39,6 +39,16 @@ type TestSpecOpts struct {
 	BaseRev string
 	BaseRef string
 
+	AutoMerge *batches.AutoMergePolicy
+
+	Reviewers     []string
+	TeamReviewers []string
+	Labels        []string
+	Assignees     []string
+	Milestone     string
+	DependsOn     []string
+	Provides      []string
+
 	Typ btypes.ChangesetSpecType
 }
 
@@ -75,6 +85,14 @@ func BuildChangesetSpec(t *testing.T, opts TestSpecOpts) *btypes.ChangesetSpec {
 		DiffStatAdded:     TestChangsetSpecDiffStat.Added,
 		DiffStatDeleted:   TestChangsetSpecDiffStat.Deleted,
 		Type:              opts.Typ,
+		AutoMerge:         opts.AutoMerge,
+		Reviewers:         opts.Reviewers,
+		TeamReviewers:     opts.TeamReviewers,
+		Labels:            opts.Labels,
+		Assignees:         opts.Assignees,
+		Milestone:         opts.Milestone,
+		DependsOn:         opts.DependsOn,
+		Provides:          opts.Provides,
 	}
 
 	return spec
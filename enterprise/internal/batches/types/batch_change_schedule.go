@@ -0,0 +1,284 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// BatchChangeScheduleAutoApply defines whether the batch spec produced by a
+// scheduled run is applied to the batch change automatically.
+type BatchChangeScheduleAutoApply string
+
+const (
+	// BatchChangeScheduleAutoApplyNever leaves the batch spec to be previewed
+	// and applied manually.
+	BatchChangeScheduleAutoApplyNever BatchChangeScheduleAutoApply = "NEVER"
+	// BatchChangeScheduleAutoApplyOnSuccess applies the batch spec if every
+	// workspace executed successfully.
+	BatchChangeScheduleAutoApplyOnSuccess BatchChangeScheduleAutoApply = "ON_SUCCESS"
+	// BatchChangeScheduleAutoApplyAlways applies the batch spec even if some
+	// workspaces failed, publishing the changesets of the ones that succeeded.
+	BatchChangeScheduleAutoApplyAlways BatchChangeScheduleAutoApply = "ALWAYS"
+)
+
+// Valid returns true if the given policy is known.
+func (a BatchChangeScheduleAutoApply) Valid() bool {
+	switch a {
+	case BatchChangeScheduleAutoApplyNever,
+		BatchChangeScheduleAutoApplyOnSuccess,
+		BatchChangeScheduleAutoApplyAlways:
+		return true
+	}
+	return false
+}
+
+// DefaultBatchChangeScheduleMaxConsecutiveFailures is the number of
+// consecutive failed runs after which a schedule is paused, if not set
+// otherwise.
+const DefaultBatchChangeScheduleMaxConsecutiveFailures = 3
+
+// A BatchChangeSchedule re-runs the last applied batch spec of a batch change
+// on a cron schedule.
+type BatchChangeSchedule struct {
+	ID            int64
+	BatchChangeID int64
+	// UserID is the user who set the schedule. Scheduled runs act on their
+	// behalf.
+	UserID int32
+
+	Cron      string
+	AutoApply BatchChangeScheduleAutoApply
+
+	MaxConsecutiveFailures int32
+	ConsecutiveFailures    int32
+
+	NextRunAt time.Time
+	PausedAt  time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a BatchChangeSchedule.
+func (s *BatchChangeSchedule) Clone() *BatchChangeSchedule {
+	cc := *s
+	return &cc
+}
+
+// Paused returns true when the schedule has been paused after too many
+// consecutive failures.
+func (s *BatchChangeSchedule) Paused() bool { return !s.PausedAt.IsZero() }
+
+// RecordRun updates the failure counter after a run finished, and pauses the
+// schedule if it reached MaxConsecutiveFailures.
+func (s *BatchChangeSchedule) RecordRun(failed bool, now time.Time) {
+	if !failed {
+		s.ConsecutiveFailures = 0
+		return
+	}
+	s.ConsecutiveFailures++
+	if s.ConsecutiveFailures >= s.MaxConsecutiveFailures {
+		s.PausedAt = now
+	}
+}
+
+// BatchChangeScheduleRunState defines the possible states of a
+// BatchChangeScheduleRun.
+type BatchChangeScheduleRunState string
+
+const (
+	BatchChangeScheduleRunStateResolving BatchChangeScheduleRunState = "RESOLVING"
+	BatchChangeScheduleRunStateExecuting BatchChangeScheduleRunState = "EXECUTING"
+	BatchChangeScheduleRunStateCompleted BatchChangeScheduleRunState = "COMPLETED"
+	BatchChangeScheduleRunStateFailed    BatchChangeScheduleRunState = "FAILED"
+)
+
+// Active returns true if the run hasn't finished yet.
+func (s BatchChangeScheduleRunState) Active() bool {
+	return s == BatchChangeScheduleRunStateResolving || s == BatchChangeScheduleRunStateExecuting
+}
+
+// ToGraphQL returns the GraphQL representation of the state.
+func (s BatchChangeScheduleRunState) ToGraphQL() string { return string(s) }
+
+// A BatchChangeScheduleRun is a single run of a BatchChangeSchedule.
+type BatchChangeScheduleRun struct {
+	ID            int64
+	ScheduleID    int64
+	BatchChangeID int64
+	BatchSpecID   int64
+
+	State          BatchChangeScheduleRunState
+	FailureMessage string
+	Applied        bool
+
+	StartedAt  time.Time
+	FinishedAt time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CronSchedule is a parsed cron expression. Expressions have five fields
+// (minute, hour, day of month, month and day of week) and are evaluated in
+// UTC.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar record whether the day fields were "*", since the
+	// day matches either field if both of them are restricted.
+	domStar, dowStar bool
+}
+
+var cronMacros = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+var (
+	cronMonthNames = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	cronDayNames   = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+// ParseCronSchedule parses the given cron expression. Since every run
+// executes the batch spec server-side, the minute field has to be a single
+// value, which limits schedules to at most one run per hour.
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if m, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = m
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.Newf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+	if _, err := strconv.Atoi(fields[0]); err != nil {
+		return nil, errors.Newf("invalid cron expression %q: the minute field must be a single number", expr)
+	}
+
+	var (
+		s   CronSchedule
+		err error
+	)
+	if s.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, errors.Wrapf(err, "invalid minute in cron expression %q", expr)
+	}
+	if s.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, errors.Wrapf(err, "invalid hour in cron expression %q", expr)
+	}
+	if s.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, errors.Wrapf(err, "invalid day of month in cron expression %q", expr)
+	}
+	if s.month, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return nil, errors.Wrapf(err, "invalid month in cron expression %q", expr)
+	}
+	if s.dow, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return nil, errors.Wrapf(err, "invalid day of week in cron expression %q", expr)
+	}
+	// 7 is an alias for Sunday.
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domStar = fields[2] == "*"
+	s.dowStar = fields[4] == "*"
+
+	return &s, nil
+}
+
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangeExpr, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangeExpr = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, errors.Newf("invalid step in %q", part)
+			}
+		}
+
+		lo, hi := min, max
+		if rangeExpr != "*" {
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if lo, err = parseCronValue(bounds[0], names); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = parseCronValue(bounds[1], names); err != nil {
+					return 0, err
+				}
+			} else if step != 1 {
+				// "n/step" means every step starting at n.
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, errors.Newf("%q is out of range %d-%d", part, min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(s string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.Newf("invalid value %q", s)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t that matches the schedule, or the zero
+// time if the schedule never matches, e.g. on February 30th.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+
+	// Every valid schedule matches at least once within a leap year cycle.
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	// A Wednesday.
+	now := time.Date(2022, 12, 21, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr    string
+		want    time.Time
+		wantErr bool
+	}{
+		{expr: "0 * * * *", want: time.Date(2022, 12, 21, 11, 0, 0, 0, time.UTC)},
+		{expr: "45 * * * *", want: time.Date(2022, 12, 21, 10, 45, 0, 0, time.UTC)},
+		{expr: "30 10 * * *", want: time.Date(2022, 12, 22, 10, 30, 0, 0, time.UTC)},
+		{expr: "@daily", want: time.Date(2022, 12, 22, 0, 0, 0, 0, time.UTC)},
+		{expr: "@weekly", want: time.Date(2022, 12, 25, 0, 0, 0, 0, time.UTC)},
+		{expr: "@monthly", want: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * mon-fri", want: time.Date(2022, 12, 22, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * 7", want: time.Date(2022, 12, 25, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 */6 * * *", want: time.Date(2022, 12, 21, 12, 0, 0, 0, time.UTC)},
+		{expr: "0 1 1 jan,jul *", want: time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC)},
+		// Both day fields are restricted, so either of them matches.
+		{expr: "0 0 1 * fri", want: time.Date(2022, 12, 23, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Never matches.
+		{expr: "0 0 30 2 *", want: time.Time{}},
+
+		{expr: "*/5 * * * *", wantErr: true},
+		{expr: "0 * * *", wantErr: true},
+		{expr: "0 24 * * *", wantErr: true},
+		{expr: "0 * 0 * *", wantErr: true},
+		{expr: "0 * * foo *", wantErr: true},
+		{expr: "0 5-1 * * *", wantErr: true},
+		{expr: "0 */0 * * *", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			s, err := ParseCronSchedule(tc.expr)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have := s.Next(now); !have.Equal(tc.want) {
+				t.Fatalf("wrong next run: have=%s want=%s", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestBatchChangeSchedule_RecordRun(t *testing.T) {
+	now := time.Now()
+	s := &BatchChangeSchedule{MaxConsecutiveFailures: 2}
+
+	s.RecordRun(true, now)
+	if s.ConsecutiveFailures != 1 || s.Paused() {
+		t.Fatalf("unexpected schedule after first failure: %+v", s)
+	}
+
+	s.RecordRun(false, now)
+	if s.ConsecutiveFailures != 0 || s.Paused() {
+		t.Fatalf("unexpected schedule after success: %+v", s)
+	}
+
+	s.RecordRun(true, now)
+	s.RecordRun(true, now)
+	if s.ConsecutiveFailures != 2 || !s.Paused() {
+		t.Fatalf("expected schedule to be paused: %+v", s)
+	}
+}
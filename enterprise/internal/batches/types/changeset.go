@@ -297,6 +297,10 @@ type Changeset struct {
 
 	// DetachedAt is the time when the changeset became "detached".
 	DetachedAt time.Time
+
+	// Blocked is set by the reconciler when the changeset spec declares
+	// dependencies on other changesets that haven't been merged yet.
+	Blocked bool
 }
 
 // RecordID is needed to implement the workerutil.Record interface.
@@ -1135,6 +1139,8 @@ func ChangesetEventKindFor(e any) (ChangesetEventKind, error) {
 		return ChangesetEventKindBitbucketCloudRepoCommitStatusCreated, nil
 	case *bitbucketcloud.RepoCommitStatusUpdatedEvent:
 		return ChangesetEventKindBitbucketCloudRepoCommitStatusUpdated, nil
+	case *AutoMergedEvent:
+		return ChangesetEventKindAutoMerged, nil
 	}
 
 	return ChangesetEventKindInvalid, errors.Errorf("unknown changeset event kind for %T", e)
@@ -1249,6 +1255,8 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (any, error) {
 		case ChangesetEventKindGitLabReopened:
 			return new(gitlab.MergeRequestReopenedEvent), nil
 		}
+	case k == ChangesetEventKindAutoMerged:
+		return new(AutoMergedEvent), nil
 	}
 	return nil, errors.Errorf("unknown changeset event kind %q", k)
 }
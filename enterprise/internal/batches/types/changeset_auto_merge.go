@@ -0,0 +1,82 @@
+package types
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AutoMergeAction is what the reconciler has to do to merge a changeset
+// according to the autoMerge policy of its changeset spec.
+type AutoMergeAction string
+
+const (
+	// AutoMergeActionNone means the changeset doesn't satisfy the policy (yet).
+	AutoMergeActionNone AutoMergeAction = ""
+	// AutoMergeActionMerge means the changeset should be merged right away.
+	AutoMergeActionMerge AutoMergeAction = "MERGE"
+	// AutoMergeActionNative means the code host should be asked to merge the
+	// changeset once its pending checks pass.
+	AutoMergeActionNative AutoMergeAction = "NATIVE"
+)
+
+// AutoMergeAction returns what needs to happen to the changeset at the given
+// time according to the given policy, based on the last synced state of the
+// changeset.
+//
+// Native auto-merge is only used if the code host supports it and the policy
+// has no merge window, since the code host would not respect the window.
+func (c *Changeset) AutoMergeAction(policy *batcheslib.AutoMergePolicy, now time.Time) (AutoMergeAction, error) {
+	if policy == nil || c.ExternalState != ChangesetExternalStateOpen {
+		return AutoMergeActionNone, nil
+	}
+
+	if policy.RequiredReviewState != batcheslib.AutoMergeReviewStateAny &&
+		c.ExternalReviewState != ChangesetReviewStateApproved {
+		return AutoMergeActionNone, nil
+	}
+
+	if policy.MergeWindow != nil {
+		w, err := window.ParseWindow(policy.MergeWindow.Days, policy.MergeWindow.Start, policy.MergeWindow.End)
+		if err != nil {
+			return AutoMergeActionNone, errors.Wrap(err, "parsing merge window")
+		}
+		if !w.IsOpen(now.UTC()) {
+			return AutoMergeActionNone, nil
+		}
+	}
+
+	if policy.RequiredCheckState == batcheslib.AutoMergeCheckStateAny {
+		return AutoMergeActionMerge, nil
+	}
+	switch c.ExternalCheckState {
+	case ChangesetCheckStatePassed:
+		return AutoMergeActionMerge, nil
+	case ChangesetCheckStatePending:
+		if policy.MergeWindow == nil && ExternalServiceSupports(c.ExternalServiceType, CodehostCapabilityAutoMerge) {
+			return AutoMergeActionNative, nil
+		}
+	}
+	return AutoMergeActionNone, nil
+}
+
+// AutoMergedEvent is the metadata of the changeset event that is recorded
+// whenever a changeset is merged, or handed over to the auto-merge of its code
+// host, because it satisfied the autoMerge policy of its changeset spec.
+type AutoMergedEvent struct {
+	MergeMethod batcheslib.AutoMergeMethod `json:"mergeMethod"`
+	// Native is true if the code host was asked to merge the changeset once
+	// its checks pass, instead of merging it right away.
+	Native bool `json:"native"`
+	// HeadRefOid is the commit that was merged.
+	HeadRefOid string    `json:"headRefOid"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// Key is a unique key identifying this event in the context of its changeset.
+func (e *AutoMergedEvent) Key() string {
+	return e.HeadRefOid + ":" + strconv.FormatBool(e.Native)
+}
@@ -0,0 +1,154 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestChangeset_AutoMergeAction(t *testing.T) {
+	// A Wednesday.
+	now := time.Date(2022, 12, 21, 10, 30, 0, 0, time.UTC)
+
+	policy := &batcheslib.AutoMergePolicy{
+		MergeMethod:         batcheslib.AutoMergeMethodMerge,
+		RequiredReviewState: batcheslib.AutoMergeReviewStateApproved,
+		RequiredCheckState:  batcheslib.AutoMergeCheckStatePassed,
+	}
+	policyWith := func(f func(p *batcheslib.AutoMergePolicy)) *batcheslib.AutoMergePolicy {
+		p := *policy
+		f(&p)
+		return &p
+	}
+
+	openChangeset := func(typ string, review ChangesetReviewState, check ChangesetCheckState) *Changeset {
+		return &Changeset{
+			ExternalServiceType: typ,
+			ExternalState:       ChangesetExternalStateOpen,
+			ExternalReviewState: review,
+			ExternalCheckState:  check,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		changeset *Changeset
+		policy    *batcheslib.AutoMergePolicy
+		want      AutoMergeAction
+		wantErr   bool
+	}{
+		{
+			name:      "no policy",
+			changeset: openChangeset(extsvc.TypeGitHub, ChangesetReviewStateApproved, ChangesetCheckStatePassed),
+			want:      AutoMergeActionNone,
+		},
+		{
+			name:      "approved and passed",
+			changeset: openChangeset(extsvc.TypeBitbucketServer, ChangesetReviewStateApproved, ChangesetCheckStatePassed),
+			policy:    policy,
+			want:      AutoMergeActionMerge,
+		},
+		{
+			name: "closed",
+			changeset: &Changeset{
+				ExternalServiceType: extsvc.TypeGitHub,
+				ExternalState:       ChangesetExternalStateClosed,
+				ExternalReviewState: ChangesetReviewStateApproved,
+				ExternalCheckState:  ChangesetCheckStatePassed,
+			},
+			policy: policy,
+			want:   AutoMergeActionNone,
+		},
+		{
+			name:      "changes requested",
+			changeset: openChangeset(extsvc.TypeGitHub, ChangesetReviewStateChangesRequested, ChangesetCheckStatePassed),
+			policy:    policy,
+			want:      AutoMergeActionNone,
+		},
+		{
+			name:      "changes requested with any review state",
+			changeset: openChangeset(extsvc.TypeGitHub, ChangesetReviewStateChangesRequested, ChangesetCheckStatePassed),
+			policy: policyWith(func(p *batcheslib.AutoMergePolicy) {
+				p.RequiredReviewState = batcheslib.AutoMergeReviewStateAny
+			}),
+			want: AutoMergeActionMerge,
+		},
+		{
+			name:      "failed checks",
+			changeset: openChangeset(extsvc.TypeGitHub, ChangesetReviewStateApproved, ChangesetCheckStateFailed),
+			policy:    policy,
+			want:      AutoMergeActionNone,
+		},
+		{
+			name:      "failed checks with any check state",
+			changeset: openChangeset(extsvc.TypeGitHub, ChangesetReviewStateApproved, ChangesetCheckStateFailed),
+			policy: policyWith(func(p *batcheslib.AutoMergePolicy) {
+				p.RequiredCheckState = batcheslib.AutoMergeCheckStateAny
+			}),
+			want: AutoMergeActionMerge,
+		},
+		{
+			name:      "pending checks with native auto-merge",
+			changeset: openChangeset(extsvc.TypeGitLab, ChangesetReviewStateApproved, ChangesetCheckStatePending),
+			policy:    policy,
+			want:      AutoMergeActionNative,
+		},
+		{
+			name:      "pending checks without native auto-merge",
+			changeset: openChangeset(extsvc.TypeBitbucketCloud, ChangesetReviewStateApproved, ChangesetCheckStatePending),
+			policy:    policy,
+			want:      AutoMergeActionNone,
+		},
+		{
+			name:      "pending checks with merge window",
+			changeset: openChangeset(extsvc.TypeGitHub, ChangesetReviewStateApproved, ChangesetCheckStatePending),
+			policy: policyWith(func(p *batcheslib.AutoMergePolicy) {
+				p.MergeWindow = &batcheslib.MergeWindow{Start: "10:00", End: "11:00"}
+			}),
+			want: AutoMergeActionNone,
+		},
+		{
+			name:      "open merge window",
+			changeset: openChangeset(extsvc.TypeGitHub, ChangesetReviewStateApproved, ChangesetCheckStatePassed),
+			policy: policyWith(func(p *batcheslib.AutoMergePolicy) {
+				p.MergeWindow = &batcheslib.MergeWindow{Days: []string{"wed"}, Start: "10:00", End: "11:00"}
+			}),
+			want: AutoMergeActionMerge,
+		},
+		{
+			name:      "closed merge window",
+			changeset: openChangeset(extsvc.TypeGitHub, ChangesetReviewStateApproved, ChangesetCheckStatePassed),
+			policy: policyWith(func(p *batcheslib.AutoMergePolicy) {
+				p.MergeWindow = &batcheslib.MergeWindow{Days: []string{"sat", "sun"}}
+			}),
+			want: AutoMergeActionNone,
+		},
+		{
+			name:      "invalid merge window",
+			changeset: openChangeset(extsvc.TypeGitHub, ChangesetReviewStateApproved, ChangesetCheckStatePassed),
+			policy: policyWith(func(p *batcheslib.AutoMergePolicy) {
+				p.MergeWindow = &batcheslib.MergeWindow{Start: "25:00", End: "26:00"}
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := tc.changeset.AutoMergeAction(tc.policy, now)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("no error returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Fatalf("wrong action. want=%q, have=%q", tc.want, have)
+			}
+		})
+	}
+}
@@ -102,6 +102,10 @@ const (
 	ChangesetEventKindBitbucketCloudRepoCommitStatusCreated          ChangesetEventKind = "bitbucketcloud:repo:commit_status_created"          // RepoCommitStatusCreatedEvent
 	ChangesetEventKindBitbucketCloudRepoCommitStatusUpdated          ChangesetEventKind = "bitbucketcloud:repo:commit_status_updated"          // RepoCommitStatusUpdatedEvent
 
+	// This changeset event is recorded by the reconciler when it merges a
+	// changeset according to the autoMerge policy of its changeset spec.
+	ChangesetEventKindAutoMerged ChangesetEventKind = "sourcegraph:auto_merged"
+
 	ChangesetEventKindInvalid ChangesetEventKind = "invalid"
 )
 
@@ -316,6 +320,8 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.CommitStatus.CreatedOn
 	case *bitbucketcloud.RepoCommitStatusUpdatedEvent:
 		t = ev.CommitStatus.UpdatedOn
+	case *AutoMergedEvent:
+		t = ev.CreatedAt
 	}
 
 	return t
@@ -854,6 +860,10 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		o := o.Metadata.(*bitbucketcloud.RepoCommitStatusUpdatedEvent)
 		*e = *o
 
+	case *AutoMergedEvent:
+		o := o.Metadata.(*AutoMergedEvent)
+		*e = *o
+
 	default:
 		return errors.Errorf("unknown changeset event metadata %T", e)
 	}
@@ -35,6 +35,16 @@ func NewChangesetSpecFromSpec(spec *batcheslib.ChangesetSpec) (*ChangesetSpec, e
 		Title:      spec.Title,
 		Body:       spec.Body,
 		Published:  spec.Published,
+		AutoMerge:  spec.AutoMerge,
+
+		Reviewers:     spec.Reviewers,
+		TeamReviewers: spec.TeamReviewers,
+		Labels:        spec.Labels,
+		Assignees:     spec.Assignees,
+		Milestone:     spec.Milestone,
+
+		DependsOn: spec.DependsOn,
+		Provides:  spec.Provides,
 	}
 
 	if spec.IsImportingExisting() {
@@ -113,6 +123,26 @@ type ChangesetSpec struct {
 	CommitAuthorName  string
 	CommitAuthorEmail string
 
+	// AutoMerge is the policy the changeset is merged automatically by. It is
+	// nil if the changeset is never merged automatically.
+	AutoMerge *batcheslib.AutoMergePolicy
+
+	// Reviewers, TeamReviewers, Labels, Assignees and Milestone are applied
+	// to the changeset on the code host, if supported.
+	Reviewers     []string
+	TeamReviewers []string
+	Labels        []string
+	Assignees     []string
+	Milestone     string
+
+	// DependsOn selects the changesets in the same batch change that have to
+	// be merged before this one is published, by the name of their repository
+	// or by a name in their Provides.
+	DependsOn []string
+	// Provides are the names that other changesets can depend on this one by,
+	// in addition to the name of its repository.
+	Provides []string
+
 	ForkNamespace *string
 }
 
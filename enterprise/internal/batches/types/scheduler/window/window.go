@@ -159,3 +159,19 @@ func parseWindow(raw *schema.BatchChangeRolloutWindow) (Window, error) {
 
 	return w, errs
 }
+
+// ParseWindow parses a window that only restricts when an action may happen,
+// such as the merge window of an autoMerge policy. Unlike rollout windows, it
+// has no rate.
+func ParseWindow(days []string, start, end string) (*Window, error) {
+	w, err := parseWindow(&schema.BatchChangeRolloutWindow{
+		Days:  days,
+		Start: start,
+		End:   end,
+		Rate:  "unlimited",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
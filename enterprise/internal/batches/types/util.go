@@ -12,6 +12,7 @@ type CodehostCapability string
 const (
 	CodehostCapabilityLabels          CodehostCapability = "Labels"
 	CodehostCapabilityDraftChangesets CodehostCapability = "DraftChangesets"
+	CodehostCapabilityAutoMerge       CodehostCapability = "AutoMerge"
 )
 
 type CodehostCapabilities map[CodehostCapability]bool
@@ -21,9 +22,9 @@ type CodehostCapabilities map[CodehostCapability]bool
 // whose type is not in this list will simply be filtered out from the search
 // results.
 var SupportedExternalServices = map[string]CodehostCapabilities{
-	extsvc.TypeGitHub:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
+	extsvc.TypeGitHub:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true, CodehostCapabilityAutoMerge: true},
 	extsvc.TypeBitbucketServer: {},
-	extsvc.TypeGitLab:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
+	extsvc.TypeGitLab:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true, CodehostCapabilityAutoMerge: true},
 	extsvc.TypeBitbucketCloud:  {},
 }
 
@@ -16,6 +16,7 @@ import (
 	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -60,14 +61,14 @@ func (r *rootResolver) IndexConfiguration(ctx context.Context, id graphql.ID) (_
 	return NewIndexConfigurationResolver(r.autoindexSvc, int(repositoryID), traceErrs), nil
 }
 
-// 🚨 SECURITY: Only site admins may modify code intelligence index data
+// 🚨 SECURITY: Only site admins and users with permission to delete indexes may modify code intelligence index data
 func (r *rootResolver) DeleteLSIFIndex(ctx context.Context, args *struct{ ID graphql.ID }) (_ *resolverstubs.EmptyResponse, err error) {
 	ctx, _, endObservation := r.operations.deleteLsifIndex.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("indexID", string(args.ID)),
 	}})
 	defer endObservation(1, observation.Args{})
 
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.autoindexSvc.GetUnsafeDB()); err != nil {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.autoindexSvc.GetUnsafeDB(), rbac.CodeIntelIndexesDelete); err != nil {
 		return nil, err
 	}
 	if !autoIndexingEnabled() {
@@ -86,12 +87,12 @@ func (r *rootResolver) DeleteLSIFIndex(ctx context.Context, args *struct{ ID gra
 	return &resolverstubs.EmptyResponse{}, nil
 }
 
-// 🚨 SECURITY: Only site admins may modify code intelligence upload data
+// 🚨 SECURITY: Only site admins and users with permission to delete indexes may modify code intelligence index data
 func (r *rootResolver) DeleteLSIFIndexes(ctx context.Context, args *resolverstubs.DeleteLSIFIndexesArgs) (_ *resolverstubs.EmptyResponse, err error) {
 	ctx, _, endObservation := r.operations.deleteLsifIndexes.With(ctx, &err, observation.Args{})
 	endObservation.OnCancel(ctx, 1, observation.Args{})
 
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.autoindexSvc.GetUnsafeDB()); err != nil {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.autoindexSvc.GetUnsafeDB(), rbac.CodeIntelIndexesDelete); err != nil {
 		return nil, err
 	}
 	if !autoIndexingEnabled() {
@@ -54,6 +54,8 @@ type UploadsService interface {
 	GetListTags(ctx context.Context, repo api.RepoName, commitObjs ...string) (_ []*gitdomain.Tag, err error)
 	GetUploadDocumentsForPath(ctx context.Context, bundleID int, pathPattern string) ([]string, int, error)
 	GetUploadsByIDs(ctx context.Context, ids ...int) (_ []types.Upload, err error)
+	GetPreviousUpload(ctx context.Context, upload types.Upload) (_ types.Upload, _ bool, err error)
+	DiffUploads(ctx context.Context, base, head types.Upload) (_ uploadsshared.UploadDiff, err error)
 }
 
 type PolicyService interface {
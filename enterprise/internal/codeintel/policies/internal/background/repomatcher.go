@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func NewRepositoryMatcher(store store.Store, observationCtx *observation.Context, interval time.Duration, configurationPolicyMembershipBatchSize int) goroutine.BackgroundRoutine {
@@ -36,12 +37,18 @@ func (matcher *repoMatcher) handleRepositoryMatcherBatch(ctx context.Context, ba
 		return err
 	}
 
+	var errs error
 	for _, policy := range policies {
 		var patterns []string
 		if policy.RepositoryPatterns != nil {
 			patterns = *policy.RepositoryPatterns
 		}
 
+		var repositoryQuery string
+		if policy.RepositoryQuery != nil {
+			repositoryQuery = *policy.RepositoryQuery
+		}
+
 		var repositoryMatchLimit *int
 		if val := conf.CodeIntelAutoIndexingPolicyRepositoryMatchLimit(); val != -1 {
 			repositoryMatchLimit = &val
@@ -50,12 +57,15 @@ func (matcher *repoMatcher) handleRepositoryMatcherBatch(ctx context.Context, ba
 		// Always call this even if patterns are not supplied. Otherwise we run into the
 		// situation where we have deleted all of the patterns associated with a policy
 		// but it still has entries in the lookup table.
-		if err := matcher.store.UpdateReposMatchingPatterns(ctx, patterns, policy.ID, repositoryMatchLimit); err != nil {
-			return err
+		if err := matcher.store.UpdateReposMatchingPatterns(ctx, patterns, repositoryQuery, policy.ID, repositoryMatchLimit); err != nil {
+			// A repository query may stop resolving (e.g., its search context was deleted)
+			// without it affecting the remaining policies in this batch.
+			errs = errors.Append(errs, err)
+			continue
 		}
 
 		metrics.numPoliciesUpdated.Inc()
 	}
 
-	return nil
+	return errs
 }
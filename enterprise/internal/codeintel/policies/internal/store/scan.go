@@ -21,6 +21,7 @@ func scanConfigurationPolicy(s dbutil.Scanner) (configurationPolicy types.Config
 		&configurationPolicy.ID,
 		&configurationPolicy.RepositoryID,
 		pq.Array(&repositoryPatterns),
+		&configurationPolicy.RepositoryQuery,
 		&configurationPolicy.Name,
 		&configurationPolicy.Type,
 		&configurationPolicy.Pattern,
@@ -22,8 +22,8 @@ type Store interface {
 	DeleteConfigurationPolicyByID(ctx context.Context, id int) (err error)
 
 	// Repositories
-	GetRepoIDsByGlobPatterns(ctx context.Context, patterns []string, limit, offset int) (_ []int, _ int, err error)
-	UpdateReposMatchingPatterns(ctx context.Context, patterns []string, policyID int, repositoryMatchLimit *int) (err error)
+	GetRepoIDsByGlobPatterns(ctx context.Context, patterns []string, repositoryQuery string, limit, offset int) (_ []int, _ int, err error)
+	UpdateReposMatchingPatterns(ctx context.Context, patterns []string, repositoryQuery string, policyID int, repositoryMatchLimit *int) (err error)
 
 	// Utilities
 	GetUnsafeDB() database.DB
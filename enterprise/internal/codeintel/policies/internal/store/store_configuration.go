@@ -33,7 +33,7 @@ func (s *store) GetConfigurationPolicies(ctx context.Context, opts policiesshare
 	conds := make([]*sqlf.Query, 0, 5)
 	if opts.RepositoryID != 0 {
 		conds = append(conds, sqlf.Sprintf(`(
-			(p.repository_id IS NULL AND p.repository_patterns IS NULL) OR
+			(p.repository_id IS NULL AND p.repository_patterns IS NULL AND p.repository_query IS NULL) OR
 			p.repository_id = %s OR
 			EXISTS (
 				SELECT 1
@@ -96,6 +96,7 @@ SELECT
 	p.id,
 	p.repository_id,
 	p.repository_patterns,
+	p.repository_query,
 	p.name,
 	p.type,
 	p.pattern,
@@ -136,6 +137,7 @@ SELECT
 	p.id,
 	p.repository_id,
 	p.repository_patterns,
+	p.repository_query,
 	p.name,
 	p.type,
 	p.pattern,
@@ -180,6 +182,7 @@ func (s *store) CreateConfigurationPolicy(ctx context.Context, configurationPoli
 		createConfigurationPolicyQuery,
 		configurationPolicy.RepositoryID,
 		repositoryPatterns,
+		configurationPolicy.RepositoryQuery,
 		configurationPolicy.Name,
 		configurationPolicy.Type,
 		configurationPolicy.Pattern,
@@ -201,6 +204,7 @@ const createConfigurationPolicyQuery = `
 INSERT INTO lsif_configuration_policies (
 	repository_id,
 	repository_patterns,
+	repository_query,
 	name,
 	type,
 	pattern,
@@ -210,11 +214,12 @@ INSERT INTO lsif_configuration_policies (
 	indexing_enabled,
 	index_commit_max_age_hours,
 	index_intermediate_commits
-) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING
 	id,
 	repository_id,
 	repository_patterns,
+	repository_query,
 	name,
 	type,
 	pattern,
@@ -283,6 +288,7 @@ func (s *store) UpdateConfigurationPolicy(ctx context.Context, policy types.Conf
 	return tx.Exec(ctx, sqlf.Sprintf(updateConfigurationPolicyQuery,
 		policy.Name,
 		repositoryPatterns,
+		policy.RepositoryQuery,
 		policy.Type,
 		policy.Pattern,
 		policy.RetentionEnabled,
@@ -300,6 +306,7 @@ SELECT
 	id,
 	repository_id,
 	repository_patterns,
+	repository_query,
 	name,
 	type,
 	pattern,
@@ -319,6 +326,7 @@ const updateConfigurationPolicyQuery = `
 UPDATE lsif_configuration_policies SET
 	name = %s,
 	repository_patterns = %s,
+	repository_query = %s,
 	type = %s,
 	pattern = %s,
 	retention_enabled = %s,
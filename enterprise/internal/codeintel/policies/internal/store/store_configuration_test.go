@@ -65,7 +65,7 @@ func TestGetConfigurationPolicies(t *testing.T) {
 		108: {"gitlab.com/*2"},
 		109: {"github.com/*"},
 	} {
-		if err := store.UpdateReposMatchingPatterns(ctx, patterns, policyID, nil); err != nil {
+		if err := store.UpdateReposMatchingPatterns(ctx, patterns, "", policyID, nil); err != nil {
 			t.Fatalf("unexpected error while updating repositories matching patterns: %s", err)
 		}
 	}
@@ -61,7 +61,7 @@ func TestRepoIDsByGlobPatterns(t *testing.T) {
 			)
 
 			t.Run(name, func(t *testing.T) {
-				repositoryIDs, _, err := store.GetRepoIDsByGlobPatterns(ctx, testCase.patterns, 3, lo)
+				repositoryIDs, _, err := store.GetRepoIDsByGlobPatterns(ctx, testCase.patterns, "", 3, lo)
 				if err != nil {
 					t.Fatalf("unexpected error fetching repository ids by glob pattern: %s", err)
 				}
@@ -81,7 +81,7 @@ func TestRepoIDsByGlobPatterns(t *testing.T) {
 		globals.SetPermissionsUserMapping(&schema.PermissionsUserMapping{Enabled: true})
 		defer globals.SetPermissionsUserMapping(before)
 
-		repoIDs, _, err := store.GetRepoIDsByGlobPatterns(ctx, []string{"*"}, 10, 0)
+		repoIDs, _, err := store.GetRepoIDsByGlobPatterns(ctx, []string{"*"}, "", 10, 0)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -129,7 +129,7 @@ func TestUpdateReposMatchingPatterns(t *testing.T) {
 		{105, []string{}},
 	}
 	for _, update := range updates {
-		if err := store.UpdateReposMatchingPatterns(ctx, update.pattern, update.policyID, nil); err != nil {
+		if err := store.UpdateReposMatchingPatterns(ctx, update.pattern, "", update.policyID, nil); err != nil {
 			t.Fatalf("unexpected error updating repositories matching patterns: %s", err)
 		}
 	}
@@ -174,7 +174,7 @@ func TestUpdateReposMatchingPatternsOverLimit(t *testing.T) {
 		insertRepo(t, db, id, fmt.Sprintf("r%03d", id))
 	}
 
-	if err := store.UpdateReposMatchingPatterns(ctx, []string{"r*"}, 100, &limit); err != nil {
+	if err := store.UpdateReposMatchingPatterns(ctx, []string{"r*"}, "", 100, &limit); err != nil {
 		t.Fatalf("unexpected error updating repositories matching patterns: %s", err)
 	}
 
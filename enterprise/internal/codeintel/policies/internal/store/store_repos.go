@@ -8,35 +8,42 @@ import (
 	"github.com/opentracing/opentracing-go/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 )
 
 // UpdateReposMatchingPatterns updates the values of the repository pattern lookup table for the
-// given configuration policy identifier. Each repository matching one of the given patterns will
-// be associated with the target configuration policy. If the patterns list is empty, the lookup
-// table will remove any data associated with the target configuration policy. If the number of
-// matches exceeds the given limit (if supplied), then only top ranked repositories by star count
-// will be associated to the policy in the database and the remainder will be dropped.
-func (s *store) UpdateReposMatchingPatterns(ctx context.Context, patterns []string, policyID int, repositoryMatchLimit *int) (err error) {
+// given configuration policy identifier. Each repository matching one of the given patterns or the
+// given repository query will be associated with the target configuration policy. If the patterns
+// list and the repository query are both empty, the lookup table will remove any data associated
+// with the target configuration policy. If the number of matches exceeds the given limit (if
+// supplied), then only top ranked repositories by star count will be associated to the policy in
+// the database and the remainder will be dropped.
+func (s *store) UpdateReposMatchingPatterns(ctx context.Context, patterns []string, repositoryQuery string, policyID int, repositoryMatchLimit *int) (err error) {
 	ctx, _, endObservation := s.operations.updateReposMatchingPatterns.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("pattern", strings.Join(patterns, ",")),
+		log.String("repositoryQuery", repositoryQuery),
 		log.Int("policyID", policyID),
 	}})
 	defer endObservation(1, observation.Args{})
 
-	n := len(patterns)
-	if n == 0 {
-		n = 1
+	tx, err := s.db.Transact(ctx)
+	if err != nil {
+		return err
 	}
+	defer func() { err = tx.Done(err) }()
 
-	conds := make([]*sqlf.Query, 0, n)
-	for _, pattern := range patterns {
-		conds = append(conds, sqlf.Sprintf("lower(name) LIKE %s", makeWildcardPattern(pattern)))
+	// Policies apply to every matching repository regardless of the permissions of the user
+	// that created them, so the repository query is evaluated as the internal actor.
+	conds, err := s.makeRepositoryMatchConditions(actor.WithInternalActor(ctx), tx, patterns, repositoryQuery, repositoryMatchLimit)
+	if err != nil {
+		return err
 	}
-	if len(patterns) == 0 {
+	if len(conds) == 0 {
 		// We'll get a SQL syntax error if we try to join an empty disjunction list, so we
 		// put this sentinel value here. Note that we choose FALSE over TRUE because we want
 		// the absence of patterns to match NO repositories, not ALL repositories.
@@ -48,7 +55,7 @@ func (s *store) UpdateReposMatchingPatterns(ctx context.Context, patterns []stri
 		limitExpression = sqlf.Sprintf("LIMIT %s", *repositoryMatchLimit)
 	}
 
-	return s.db.Exec(ctx, sqlf.Sprintf(updateReposMatchingPatternsQuery, sqlf.Join(conds, "OR"), limitExpression, policyID, policyID, policyID))
+	return tx.Exec(ctx, sqlf.Sprintf(updateReposMatchingPatternsQuery, sqlf.Join(conds, "OR"), limitExpression, policyID, policyID, policyID))
 }
 
 const updateReposMatchingPatternsQuery = `
@@ -101,35 +108,93 @@ SELECT
 	(SELECT COUNT(*) FROM deleted) AS num_deleted
 `
 
+// makeRepositoryMatchConditions returns a condition on the repo table for each of the given name
+// patterns, as well as one for the given repository query (if supplied). The repositories matching
+// the query are written to a temporary table of the given transaction, which the condition joins
+// against. If a limit is supplied, only that many of the top ranked repositories by star count are
+// matched by the query.
+func (s *store) makeRepositoryMatchConditions(ctx context.Context, tx *basestore.Store, patterns []string, repositoryQuery string, limit *int) ([]*sqlf.Query, error) {
+	conds := make([]*sqlf.Query, 0, len(patterns)+1)
+	for _, pattern := range patterns {
+		conds = append(conds, sqlf.Sprintf("lower(name) LIKE %s", makeWildcardPattern(pattern)))
+	}
+
+	if repositoryQuery != "" {
+		ids, err := s.getRepoIDsMatchingQuery(ctx, repositoryQuery, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tx.Exec(ctx, sqlf.Sprintf(repositoryQueryMatchesTemporaryTableQuery)); err != nil {
+			return nil, err
+		}
+		if err := batch.InsertValues(
+			ctx,
+			tx.Handle(),
+			"t_policy_repository_query_matches",
+			batch.MaxNumPostgresParameters,
+			[]string{"repo_id"},
+			loadRepoIDsChannel(ids),
+		); err != nil {
+			return nil, err
+		}
+
+		conds = append(conds, sqlf.Sprintf("id IN (SELECT repo_id FROM t_policy_repository_query_matches)"))
+	}
+
+	return conds, nil
+}
+
+const repositoryQueryMatchesTemporaryTableQuery = `
+CREATE TEMPORARY TABLE t_policy_repository_query_matches (
+	repo_id integer PRIMARY KEY
+) ON COMMIT DROP
+`
+
+func loadRepoIDsChannel(ids []int) <-chan []any {
+	ch := make(chan []any, len(ids))
+
+	go func() {
+		defer close(ch)
+
+		for _, id := range ids {
+			ch <- []any{id}
+		}
+	}()
+
+	return ch
+}
+
 func makeWildcardPattern(pattern string) string {
 	return strings.ToLower(strings.ReplaceAll(pattern, "*", "%"))
 }
 
 // GetRepoIDsByGlobPatterns returns a page of repository identifiers and a total count of repositories matching
-// one of the given patterns.
-func (s *store) GetRepoIDsByGlobPatterns(ctx context.Context, patterns []string, limit, offset int) (_ []int, _ int, err error) {
+// one of the given patterns or the given repository query.
+func (s *store) GetRepoIDsByGlobPatterns(ctx context.Context, patterns []string, repositoryQuery string, limit, offset int) (_ []int, _ int, err error) {
 	ctx, _, endObservation := s.operations.getRepoIDsByGlobPatterns.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("patterns", strings.Join(patterns, ", ")),
+		log.String("repositoryQuery", repositoryQuery),
 		log.Int("limit", limit),
 		log.Int("offset", offset),
 	}})
 	defer endObservation(1, observation.Args{})
 
-	if len(patterns) == 0 {
+	if len(patterns) == 0 && repositoryQuery == "" {
 		return nil, 0, nil
 	}
 
-	conds := make([]*sqlf.Query, 0, len(patterns))
-	for _, pattern := range patterns {
-		conds = append(conds, sqlf.Sprintf("lower(name) LIKE %s", makeWildcardPattern(pattern)))
-	}
-
 	tx, err := s.db.Transact(ctx)
 	if err != nil {
 		return nil, 0, err
 	}
 	defer func() { err = tx.Done(err) }()
 
+	conds, err := s.makeRepositoryMatchConditions(ctx, tx, patterns, repositoryQuery, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	authzConds, err := database.AuthzQueryConds(ctx, database.NewDBWith(s.logger, tx))
 	if err != nil {
 		return nil, 0, err
@@ -171,7 +236,8 @@ LIMIT %s OFFSET %s
 `
 
 // SelectPoliciesForRepositoryMembershipUpdate returns a slice of configuration policies that should be considered
-// for repository membership updates. Configuration policies are returned in the order of least recently updated.
+// for repository membership updates. Query-based policies whose matches may be stale are returned first, then
+// configuration policies are returned in the order of least recently updated.
 func (s *store) SelectPoliciesForRepositoryMembershipUpdate(ctx context.Context, batchSize int) (configurationPolicies []types.ConfigurationPolicy, err error) {
 	ctx, trace, endObservation := s.operations.selectPoliciesForRepositoryMembershipUpdate.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
@@ -185,12 +251,24 @@ func (s *store) SelectPoliciesForRepositoryMembershipUpdate(ctx context.Context,
 	return configurationPolicies, nil
 }
 
+// Policies matching repositories by query are resolved first if repository metadata or search
+// contexts changed since they were last resolved. The repo write itself does not touch the
+// policies, so this check is done here rather than when repositories are synced.
 const selectPoliciesForRepositoryMembershipUpdate = `
 WITH
+last_change AS (
+	SELECT GREATEST(
+		(SELECT MAX(GREATEST(updated_at, deleted_at)) FROM repo),
+		(SELECT MAX(updated_at) FROM search_contexts)
+	) AS changed_at
+),
 candidate_policies AS (
 	SELECT p.id
-	FROM lsif_configuration_policies p
-	ORDER BY p.last_resolved_at NULLS FIRST, p.id
+	FROM lsif_configuration_policies p, last_change
+	ORDER BY
+		(p.repository_query IS NOT NULL AND p.last_resolved_at < last_change.changed_at) DESC,
+		p.last_resolved_at NULLS FIRST,
+		p.id
 	LIMIT %d
 ),
 locked_policies AS (
@@ -205,6 +283,7 @@ RETURNING
 	id,
 	repository_id,
 	repository_patterns,
+	repository_query,
 	name,
 	type,
 	pattern,
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"context"
+	"sort"
+
+	policiesshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// getRepoIDsMatchingQuery returns the identifiers of the repositories matching the repository
+// filters of the given search query. Search contexts defined by a query are substituted with
+// that query, exactly as they are when searching. Repositories and search contexts are visible
+// according to the actor of the given context. If a limit is supplied, at most that many of the
+// top ranked repositories by star count are returned for each disjunct of the query, which
+// includes the top ranked repositories matching the whole query.
+func (s *store) getRepoIDsMatchingQuery(ctx context.Context, repositoryQuery string, limit *int) ([]int, error) {
+	db := database.NewDBWith(s.logger, s.db)
+
+	plan, err := query.Pipeline(
+		query.Init(repositoryQuery, query.SearchTypeStandard),
+		query.SubstituteSearchContexts(func(spec string) (string, error) {
+			searchContext, err := searchcontexts.ResolveSearchContextSpec(ctx, db, spec)
+			if err != nil {
+				return "", err
+			}
+			return searchContext.Query, nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := policiesshared.ValidateRepositoryQuery(plan); err != nil {
+		return nil, err
+	}
+
+	// Each basic query of the plan is a disjunct of the original query
+	idSet := map[int]struct{}{}
+	for _, basic := range plan {
+		opts, err := reposListOptionsForQuery(ctx, db, basic)
+		if err != nil {
+			return nil, err
+		}
+		if limit != nil {
+			opts.OrderBy = database.RepoListOrderBy{
+				{Field: database.RepoListStars, Descending: true, Nulls: "LAST"},
+				{Field: database.RepoListID},
+			}
+			opts.LimitOffset = &database.LimitOffset{Limit: *limit}
+		}
+
+		repos, err := db.Repos().ListMinimalRepos(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repos {
+			idSet[int(repo.ID)] = struct{}{}
+		}
+	}
+
+	ids := make([]int, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	return ids, nil
+}
+
+// reposListOptionsForQuery converts the repository filters of the given basic query into options
+// for the repo store. Forks and archived repositories are excluded unless the query explicitly
+// includes them, matching the defaults of search.
+func reposListOptionsForQuery(ctx context.Context, db database.DB, basic query.Basic) (database.ReposListOptions, error) {
+	includePatterns, excludePatterns := basic.Repositories()
+
+	fork := query.No
+	if value := basic.Fork(); value != nil {
+		fork = *value
+	}
+	archived := query.No
+	if value := basic.Archived(); value != nil {
+		archived = *value
+	}
+	visibility := basic.Visibility()
+
+	kvpFilters := make([]database.RepoKVPFilter, 0, len(basic.RepoHasKVPs()))
+	for _, filter := range basic.RepoHasKVPs() {
+		kvpFilters = append(kvpFilters, database.RepoKVPFilter{
+			Key:     filter.Key,
+			Value:   filter.Value,
+			Negated: filter.Negated,
+			KeyOnly: filter.KeyOnly,
+		})
+	}
+
+	opts := database.ReposListOptions{
+		IncludePatterns:     includePatterns,
+		ExcludePattern:      query.UnionRegExps(excludePatterns),
+		DescriptionPatterns: basic.RepoHasDescription(),
+		KVPFilters:          kvpFilters,
+		NoForks:             fork == query.No,
+		OnlyForks:           fork == query.Only,
+		NoArchived:          archived == query.No,
+		OnlyArchived:        archived == query.Only,
+		NoPrivate:           visibility == query.Public,
+		OnlyPrivate:         visibility == query.Private,
+	}
+
+	// Search contexts defined by a query have already been substituted, so any remaining
+	// search context must be defined by its set of repositories.
+	searchContext, err := searchcontexts.ResolveSearchContextSpec(ctx, db, basic.FindValue(query.FieldContext))
+	if err != nil {
+		return database.ReposListOptions{}, err
+	}
+	if searchContext.Query != "" {
+		return database.ReposListOptions{}, errors.Newf("search context %q is defined by a query nested in another search context query", basic.FindValue(query.FieldContext))
+	}
+	opts.SearchContextID = searchContext.ID
+	opts.UserID = searchContext.NamespaceUserID
+	opts.OrgID = searchContext.NamespaceOrgID
+
+	return opts, nil
+}
@@ -0,0 +1,153 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestUpdateReposMatchingQuery(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	insertRepo(t, db, 50, "github.com/sourcegraph/sourcegraph")
+	insertRepo(t, db, 51, "github.com/sourcegraph/zoekt")
+	insertRepo(t, db, 52, "github.com/sourcegraph/scip")
+	insertRepo(t, db, 53, "github.com/sourcegraph/sourcegraph-fork")
+	insertRepo(t, db, 54, "github.com/golang/go")
+
+	if _, err := db.ExecContext(ctx, `UPDATE repo SET fork = true WHERE id = 53`); err != nil {
+		t.Fatalf("unexpected error updating repository: %s", err)
+	}
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO repo_kvps (repo_id, key, value) VALUES
+			(50, 'tier-1', NULL),
+			(52, 'tier-1', NULL),
+			(53, 'tier-1', NULL),
+			(54, 'owner', 'go-team')
+	`); err != nil {
+		t.Fatalf("unexpected error inserting repository key-value pairs: %s", err)
+	}
+
+	updates := []struct {
+		policyID        int
+		patterns        []string
+		repositoryQuery string
+	}{
+		// tags, excluding forks by default
+		{100, nil, `repo:has.tag(tier-1)`},
+
+		// tags, including forks
+		{101, nil, `repo:has.tag(tier-1) fork:yes`},
+
+		// key-value pairs
+		{102, nil, `repo:has(owner:go-team)`},
+
+		// name patterns combined with a query
+		{103, []string{"github.com/golang/*"}, `repo:^github\.com/sourcegraph/zoekt$`},
+
+		// disjunction
+		{104, nil, `repo:zoekt or repo:scip`},
+
+		// negation
+		{105, nil, `repo:^github\.com/sourcegraph/ -repo:has.tag(tier-1)`},
+
+		// updated query
+		{106, nil, `repo:has.tag(tier-1)`},
+		{106, nil, `repo:has.key(owner)`},
+	}
+	for _, update := range updates {
+		if err := store.UpdateReposMatchingPatterns(ctx, update.patterns, update.repositoryQuery, update.policyID, nil); err != nil {
+			t.Fatalf("unexpected error updating repositories matching query: %s", err)
+		}
+	}
+
+	policies, err := scanPolicyRepositories(db.QueryContext(context.Background(), `
+		SELECT policy_id, repo_id
+		FROM lsif_configuration_policies_repository_pattern_lookup
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error while scanning policies: %s", err)
+	}
+
+	for _, repositoryIDs := range policies {
+		sort.Ints(repositoryIDs)
+	}
+
+	expectedPolicies := map[int][]int{
+		100: {50, 52},     // tags, excluding forks by default
+		101: {50, 52, 53}, // tags, including forks
+		102: {54},         // key-value pairs
+		103: {51, 54},     // name patterns combined with a query
+		104: {51, 52},     // disjunction
+		105: {51},         // negation
+		106: {54},         // updated query
+	}
+	if diff := cmp.Diff(expectedPolicies, policies); diff != "" {
+		t.Errorf("unexpected repository identifiers for policies (-want +got):\n%s", diff)
+	}
+
+	if err := store.UpdateReposMatchingPatterns(ctx, nil, `repo:zoekt lang:go`, 107, nil); err == nil {
+		t.Fatalf("expected error for query with unsupported filters")
+	}
+}
+
+func TestSelectPoliciesForRepositoryMembershipUpdateStaleQueries(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := testStoreWithoutConfigurationPolicies(t, db)
+
+	insertRepo(t, db, 50, "github.com/sourcegraph/sourcegraph")
+
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO lsif_configuration_policies (id, name, type, pattern, repository_patterns, repository_query, retention_enabled, retain_intermediate_commits, indexing_enabled, index_intermediate_commits, last_resolved_at) VALUES
+			(101, 'by query',   'GIT_TREE', '/', NULL,             'repo:has.tag(tier-1)', false, false, true, false, NOW() - '1 hour'::interval),
+			(102, 'by pattern', 'GIT_TREE', '/', '{github.com/*}', NULL,                   false, false, true, false, NOW() - '2 hours'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error while inserting configuration policies: %s", err)
+	}
+
+	selectPolicyIDs := func() []int {
+		t.Helper()
+
+		policies, err := store.SelectPoliciesForRepositoryMembershipUpdate(ctx, 1)
+		if err != nil {
+			t.Fatalf("unexpected error fetching configuration policies for repository membership update: %s", err)
+		}
+
+		ids := make([]int, 0, len(policies))
+		for _, policy := range policies {
+			ids = append(ids, policy.ID)
+		}
+		return ids
+	}
+
+	if _, err := db.ExecContext(ctx, `UPDATE repo SET updated_at = NOW() - '3 hours'::interval`); err != nil {
+		t.Fatalf("unexpected error updating repository: %s", err)
+	}
+	// Nothing changed since the policies were resolved: least recently resolved first
+	if diff := cmp.Diff([]int{102}, selectPolicyIDs()); diff != "" {
+		t.Errorf("unexpected policies (-want +got):\n%s", diff)
+	}
+
+	if _, err := db.ExecContext(ctx, `
+		UPDATE lsif_configuration_policies SET last_resolved_at = NOW() - '2 hours'::interval WHERE id = 102;
+		UPDATE repo SET updated_at = NOW();
+	`); err != nil {
+		t.Fatalf("unexpected error updating repository: %s", err)
+	}
+	// Repository metadata changed since the query-based policy was resolved
+	if diff := cmp.Diff([]int{101}, selectPolicyIDs()); diff != "" {
+		t.Errorf("unexpected policies (-want +got):\n%s", diff)
+	}
+}
@@ -83,7 +83,7 @@ func NewMockStore() *MockStore {
 			},
 		},
 		GetRepoIDsByGlobPatternsFunc: &StoreGetRepoIDsByGlobPatternsFunc{
-			defaultHook: func(context.Context, []string, int, int) (r0 []int, r1 int, r2 error) {
+			defaultHook: func(context.Context, []string, string, int, int) (r0 []int, r1 int, r2 error) {
 				return
 			},
 		},
@@ -103,7 +103,7 @@ func NewMockStore() *MockStore {
 			},
 		},
 		UpdateReposMatchingPatternsFunc: &StoreUpdateReposMatchingPatternsFunc{
-			defaultHook: func(context.Context, []string, int, *int) (r0 error) {
+			defaultHook: func(context.Context, []string, string, int, *int) (r0 error) {
 				return
 			},
 		},
@@ -135,7 +135,7 @@ func NewStrictMockStore() *MockStore {
 			},
 		},
 		GetRepoIDsByGlobPatternsFunc: &StoreGetRepoIDsByGlobPatternsFunc{
-			defaultHook: func(context.Context, []string, int, int) ([]int, int, error) {
+			defaultHook: func(context.Context, []string, string, int, int) ([]int, int, error) {
 				panic("unexpected invocation of MockStore.GetRepoIDsByGlobPatterns")
 			},
 		},
@@ -155,7 +155,7 @@ func NewStrictMockStore() *MockStore {
 			},
 		},
 		UpdateReposMatchingPatternsFunc: &StoreUpdateReposMatchingPatternsFunc{
-			defaultHook: func(context.Context, []string, int, *int) error {
+			defaultHook: func(context.Context, []string, string, int, *int) error {
 				panic("unexpected invocation of MockStore.UpdateReposMatchingPatterns")
 			},
 		},
@@ -645,24 +645,24 @@ func (c StoreGetConfigurationPolicyByIDFuncCall) Results() []interface{} {
 // GetRepoIDsByGlobPatterns method of the parent MockStore instance is
 // invoked.
 type StoreGetRepoIDsByGlobPatternsFunc struct {
-	defaultHook func(context.Context, []string, int, int) ([]int, int, error)
-	hooks       []func(context.Context, []string, int, int) ([]int, int, error)
+	defaultHook func(context.Context, []string, string, int, int) ([]int, int, error)
+	hooks       []func(context.Context, []string, string, int, int) ([]int, int, error)
 	history     []StoreGetRepoIDsByGlobPatternsFuncCall
 	mutex       sync.Mutex
 }
 
 // GetRepoIDsByGlobPatterns delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockStore) GetRepoIDsByGlobPatterns(v0 context.Context, v1 []string, v2 int, v3 int) ([]int, int, error) {
-	r0, r1, r2 := m.GetRepoIDsByGlobPatternsFunc.nextHook()(v0, v1, v2, v3)
-	m.GetRepoIDsByGlobPatternsFunc.appendCall(StoreGetRepoIDsByGlobPatternsFuncCall{v0, v1, v2, v3, r0, r1, r2})
+func (m *MockStore) GetRepoIDsByGlobPatterns(v0 context.Context, v1 []string, v2 string, v3 int, v4 int) ([]int, int, error) {
+	r0, r1, r2 := m.GetRepoIDsByGlobPatternsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetRepoIDsByGlobPatternsFunc.appendCall(StoreGetRepoIDsByGlobPatternsFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
 	return r0, r1, r2
 }
 
 // SetDefaultHook sets function that is called when the
 // GetRepoIDsByGlobPatterns method of the parent MockStore instance is
 // invoked and the hook queue is empty.
-func (f *StoreGetRepoIDsByGlobPatternsFunc) SetDefaultHook(hook func(context.Context, []string, int, int) ([]int, int, error)) {
+func (f *StoreGetRepoIDsByGlobPatternsFunc) SetDefaultHook(hook func(context.Context, []string, string, int, int) ([]int, int, error)) {
 	f.defaultHook = hook
 }
 
@@ -670,7 +670,7 @@ func (f *StoreGetRepoIDsByGlobPatternsFunc) SetDefaultHook(hook func(context.Con
 // GetRepoIDsByGlobPatterns method of the parent MockStore instance invokes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *StoreGetRepoIDsByGlobPatternsFunc) PushHook(hook func(context.Context, []string, int, int) ([]int, int, error)) {
+func (f *StoreGetRepoIDsByGlobPatternsFunc) PushHook(hook func(context.Context, []string, string, int, int) ([]int, int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -679,19 +679,19 @@ func (f *StoreGetRepoIDsByGlobPatternsFunc) PushHook(hook func(context.Context,
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *StoreGetRepoIDsByGlobPatternsFunc) SetDefaultReturn(r0 []int, r1 int, r2 error) {
-	f.SetDefaultHook(func(context.Context, []string, int, int) ([]int, int, error) {
+	f.SetDefaultHook(func(context.Context, []string, string, int, int) ([]int, int, error) {
 		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *StoreGetRepoIDsByGlobPatternsFunc) PushReturn(r0 []int, r1 int, r2 error) {
-	f.PushHook(func(context.Context, []string, int, int) ([]int, int, error) {
+	f.PushHook(func(context.Context, []string, string, int, int) ([]int, int, error) {
 		return r0, r1, r2
 	})
 }
 
-func (f *StoreGetRepoIDsByGlobPatternsFunc) nextHook() func(context.Context, []string, int, int) ([]int, int, error) {
+func (f *StoreGetRepoIDsByGlobPatternsFunc) nextHook() func(context.Context, []string, string, int, int) ([]int, int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -733,10 +733,13 @@ type StoreGetRepoIDsByGlobPatternsFuncCall struct {
 	Arg1 []string
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 int
+	Arg2 string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
 	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 []int
@@ -751,7 +754,7 @@ type StoreGetRepoIDsByGlobPatternsFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c StoreGetRepoIDsByGlobPatternsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
@@ -1082,24 +1085,24 @@ func (c StoreUpdateConfigurationPolicyFuncCall) Results() []interface{} {
 // UpdateReposMatchingPatterns method of the parent MockStore instance is
 // invoked.
 type StoreUpdateReposMatchingPatternsFunc struct {
-	defaultHook func(context.Context, []string, int, *int) error
-	hooks       []func(context.Context, []string, int, *int) error
+	defaultHook func(context.Context, []string, string, int, *int) error
+	hooks       []func(context.Context, []string, string, int, *int) error
 	history     []StoreUpdateReposMatchingPatternsFuncCall
 	mutex       sync.Mutex
 }
 
 // UpdateReposMatchingPatterns delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockStore) UpdateReposMatchingPatterns(v0 context.Context, v1 []string, v2 int, v3 *int) error {
-	r0 := m.UpdateReposMatchingPatternsFunc.nextHook()(v0, v1, v2, v3)
-	m.UpdateReposMatchingPatternsFunc.appendCall(StoreUpdateReposMatchingPatternsFuncCall{v0, v1, v2, v3, r0})
+func (m *MockStore) UpdateReposMatchingPatterns(v0 context.Context, v1 []string, v2 string, v3 int, v4 *int) error {
+	r0 := m.UpdateReposMatchingPatternsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateReposMatchingPatternsFunc.appendCall(StoreUpdateReposMatchingPatternsFuncCall{v0, v1, v2, v3, v4, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the
 // UpdateReposMatchingPatterns method of the parent MockStore instance is
 // invoked and the hook queue is empty.
-func (f *StoreUpdateReposMatchingPatternsFunc) SetDefaultHook(hook func(context.Context, []string, int, *int) error) {
+func (f *StoreUpdateReposMatchingPatternsFunc) SetDefaultHook(hook func(context.Context, []string, string, int, *int) error) {
 	f.defaultHook = hook
 }
 
@@ -1108,7 +1111,7 @@ func (f *StoreUpdateReposMatchingPatternsFunc) SetDefaultHook(hook func(context.
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *StoreUpdateReposMatchingPatternsFunc) PushHook(hook func(context.Context, []string, int, *int) error) {
+func (f *StoreUpdateReposMatchingPatternsFunc) PushHook(hook func(context.Context, []string, string, int, *int) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1117,19 +1120,19 @@ func (f *StoreUpdateReposMatchingPatternsFunc) PushHook(hook func(context.Contex
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *StoreUpdateReposMatchingPatternsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, []string, int, *int) error {
+	f.SetDefaultHook(func(context.Context, []string, string, int, *int) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *StoreUpdateReposMatchingPatternsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, []string, int, *int) error {
+	f.PushHook(func(context.Context, []string, string, int, *int) error {
 		return r0
 	})
 }
 
-func (f *StoreUpdateReposMatchingPatternsFunc) nextHook() func(context.Context, []string, int, *int) error {
+func (f *StoreUpdateReposMatchingPatternsFunc) nextHook() func(context.Context, []string, string, int, *int) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1171,10 +1174,13 @@ type StoreUpdateReposMatchingPatternsFuncCall struct {
 	Arg1 []string
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 int
+	Arg2 string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 *int
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 *int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -1183,7 +1189,7 @@ type StoreUpdateReposMatchingPatternsFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c StoreUpdateReposMatchingPatternsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
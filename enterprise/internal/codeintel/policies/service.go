@@ -77,7 +77,12 @@ func (s *Service) updateReposMatchingPolicyPatterns(ctx context.Context, policy
 		patterns = *policy.RepositoryPatterns
 	}
 
-	if len(patterns) == 0 {
+	var repositoryQuery string
+	if policy.RepositoryQuery != nil {
+		repositoryQuery = *policy.RepositoryQuery
+	}
+
+	if len(patterns) == 0 && repositoryQuery == "" {
 		return nil
 	}
 
@@ -86,7 +91,7 @@ func (s *Service) updateReposMatchingPolicyPatterns(ctx context.Context, policy
 		repositoryMatchLimit = &val
 	}
 
-	if err := s.store.UpdateReposMatchingPatterns(ctx, patterns, policy.ID, repositoryMatchLimit); err != nil {
+	if err := s.store.UpdateReposMatchingPatterns(ctx, patterns, repositoryQuery, policy.ID, repositoryMatchLimit); err != nil {
 		return err
 	}
 
@@ -170,7 +175,7 @@ func (s *Service) GetRetentionPolicyOverview(ctx context.Context, upload types.U
 	return potentialMatches, len(potentialMatches), nil
 }
 
-func (s *Service) GetPreviewRepositoryFilter(ctx context.Context, patterns []string, limit, offset int) (_ []int, totalCount int, repositoryMatchLimit *int, err error) {
+func (s *Service) GetPreviewRepositoryFilter(ctx context.Context, patterns []string, repositoryQuery *string, limit, offset int) (_ []int, totalCount int, repositoryMatchLimit *int, err error) {
 	ctx, _, endObservation := s.operations.getPreviewRepositoryFilter.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
@@ -182,7 +187,12 @@ func (s *Service) GetPreviewRepositoryFilter(ctx context.Context, patterns []str
 		}
 	}
 
-	ids, totalCount, err := s.store.GetRepoIDsByGlobPatterns(ctx, patterns, limit, offset)
+	var query string
+	if repositoryQuery != nil {
+		query = *repositoryQuery
+	}
+
+	ids, totalCount, err := s.store.GetRepoIDsByGlobPatterns(ctx, patterns, query, limit, offset)
 	if err != nil {
 		return nil, 0, nil, err
 	}
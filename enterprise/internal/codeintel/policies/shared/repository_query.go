@@ -0,0 +1,71 @@
+package shared
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// repositoryQueryFields are the search query fields that can be evaluated against repository
+// metadata alone, without reading the contents of a repository.
+var repositoryQueryFields = map[string]struct{}{
+	query.FieldRepo:       {},
+	query.FieldFork:       {},
+	query.FieldArchived:   {},
+	query.FieldVisibility: {},
+	query.FieldContext:    {},
+}
+
+// repositoryQueryPredicates are the repo: predicates that can be evaluated against repository
+// metadata alone, without reading the contents of a repository.
+var repositoryQueryPredicates = map[string]struct{}{
+	"has.description": {},
+	"has.tag":         {},
+	"has":             {},
+	"has.key":         {},
+}
+
+// ParseRepositoryQuery parses the given search query and ensures that it selects repositories
+// only by metadata (e.g., `repo:^github\.com/sourcegraph/ repo:has.tag(tier-1) fork:no`).
+func ParseRepositoryQuery(q string) (query.Plan, error) {
+	plan, err := query.Pipeline(query.Init(q, query.SearchTypeStandard))
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateRepositoryQuery(plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// ValidateRepositoryQuery returns an error if the given query plan contains a search pattern
+// or a filter that cannot be evaluated against repository metadata.
+func ValidateRepositoryQuery(plan query.Plan) error {
+	for _, basic := range plan {
+		if !basic.IsEmptyPattern() {
+			return errors.New("repository queries must not contain a search pattern")
+		}
+
+		for _, parameter := range basic.Parameters {
+			if _, ok := repositoryQueryFields[parameter.Field]; !ok {
+				return errors.Newf("unsupported filter %q in repository query", parameter.Field+":")
+			}
+			if parameter.Field != query.FieldRepo {
+				continue
+			}
+
+			if parameter.Annotation.Labels.IsSet(query.IsPredicate) {
+				name, _ := query.ParseAsPredicate(parameter.Value)
+				if _, ok := repositoryQueryPredicates[name]; !ok {
+					return errors.Newf("unsupported predicate %q in repository query", "repo:"+name+"()")
+				}
+			} else if strings.Contains(parameter.Value, "@") {
+				return errors.New("repository queries must not contain revisions")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package shared
+
+import (
+	"testing"
+)
+
+func TestParseRepositoryQuery(t *testing.T) {
+	for _, tc := range []struct {
+		query string
+		valid bool
+	}{
+		{query: `repo:^github\.com/sourcegraph/`, valid: true},
+		{query: `repo:has.tag(tier-1) fork:yes archived:no`, valid: true},
+		{query: `repo:has(team:code-intel) -repo:has.key(deprecated) visibility:private`, valid: true},
+		{query: `repo:has.description(indexer) context:@sourcegraph/backend`, valid: true},
+		{query: `repo:sourcegraph or repo:zoekt`, valid: true},
+		{query: `repo:sourcegraph func main`, valid: false},
+		{query: `repo:sourcegraph lang:go`, valid: false},
+		{query: `repo:sourcegraph@main`, valid: false},
+		{query: `repo:has.file(go.mod)`, valid: false},
+		{query: `repo:contains.commit.after(1 month ago)`, valid: false},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			_, err := ParseRepositoryQuery(tc.query)
+			if tc.valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !tc.valid && err == nil {
+				t.Errorf("expected query to be rejected")
+			}
+		})
+	}
+}
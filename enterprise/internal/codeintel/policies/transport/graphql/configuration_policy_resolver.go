@@ -67,6 +67,10 @@ func (r *configurationPolicyResolver) RepositoryPatterns() *[]string {
 	return r.configurationPolicy.RepositoryPatterns
 }
 
+func (r *configurationPolicyResolver) RepositoryQuery() *string {
+	return r.configurationPolicy.RepositoryQuery
+}
+
 func (r *configurationPolicyResolver) Type() (_ resolverstubs.GitObjectType, err error) {
 	defer r.errTracer.Collect(&err,
 		log.String("configurationPolicyResolver.field", "type"),
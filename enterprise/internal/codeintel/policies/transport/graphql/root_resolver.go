@@ -130,6 +130,7 @@ func (r *rootResolver) CreateCodeIntelligenceConfigurationPolicy(ctx context.Con
 		RepositoryID:              repositoryID,
 		Name:                      args.Name,
 		RepositoryPatterns:        args.RepositoryPatterns,
+		RepositoryQuery:           toRepositoryQuery(args.RepositoryQuery),
 		Type:                      types.GitObjectType(args.Type),
 		Pattern:                   args.Pattern,
 		RetentionEnabled:          args.RetentionEnabled,
@@ -171,6 +172,7 @@ func (r *rootResolver) UpdateCodeIntelligenceConfigurationPolicy(ctx context.Con
 		ID:                        int(id),
 		Name:                      args.Name,
 		RepositoryPatterns:        args.RepositoryPatterns,
+		RepositoryQuery:           toRepositoryQuery(args.RepositoryQuery),
 		Type:                      types.GitObjectType(args.Type),
 		Pattern:                   args.Pattern,
 		RetentionEnabled:          args.RetentionEnabled,
@@ -226,7 +228,7 @@ func (r *rootResolver) PreviewRepositoryFilter(ctx context.Context, args *resolv
 		pageSize = int(*args.First)
 	}
 
-	ids, totalMatches, repositoryMatchLimit, err := r.policySvc.GetPreviewRepositoryFilter(ctx, args.Patterns, pageSize, offset)
+	ids, totalMatches, repositoryMatchLimit, err := r.policySvc.GetPreviewRepositoryFilter(ctx, args.Patterns, toRepositoryQuery(args.RepositoryQuery), pageSize, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -3,11 +3,13 @@ package graphql
 import (
 	"encoding/base64"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 
+	policiesshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies/shared"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
 	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -88,10 +90,25 @@ func validateConfigurationPolicy(policy resolverstubs.CodeIntelConfigurationPoli
 	if policy.IndexingEnabled && policy.IndexCommitMaxAgeHours != nil && *policy.IndexCommitMaxAgeHours <= 0 {
 		return errors.Errorf("illegal index commit max age '%d'", *policy.IndexCommitMaxAgeHours)
 	}
+	if repositoryQuery := toRepositoryQuery(policy.RepositoryQuery); repositoryQuery != nil {
+		if _, err := policiesshared.ParseRepositoryQuery(*repositoryQuery); err != nil {
+			return errors.Wrap(err, "illegal repository query")
+		}
+	}
 
 	return nil
 }
 
+// toRepositoryQuery returns nil if the given repository query is not supplied or blank.
+func toRepositoryQuery(repositoryQuery *string) *string {
+	if repositoryQuery == nil || strings.TrimSpace(*repositoryQuery) == "" {
+		return nil
+	}
+
+	v := strings.TrimSpace(*repositoryQuery)
+	return &v
+}
+
 func toDuration(hours *int32) *time.Duration {
 	if hours == nil {
 		return nil
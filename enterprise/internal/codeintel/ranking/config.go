@@ -21,8 +21,9 @@ func (c *indexerConfig) Load() {
 type loaderConfig struct {
 	env.BaseConfig
 
-	LoadInterval  time.Duration
-	MergeInterval time.Duration
+	LoadInterval   time.Duration
+	MergeInterval  time.Duration
+	ReduceInterval time.Duration
 }
 
 var LoaderConfigInst = &loaderConfig{}
@@ -30,4 +31,5 @@ var LoaderConfigInst = &loaderConfig{}
 func (c *loaderConfig) Load() {
 	c.LoadInterval = c.GetInterval("CODEINTEL_RANKING_LOADER_INTERVAL", "10s", "The frequency with which to run periodic codeintel rank loading tasks.")
 	c.MergeInterval = c.GetInterval("CODEINTEL_RANKING_MERGER_INTERVAL", "1s", "The frequency with which to run periodic codeintel rank merging tasks.")
+	c.ReduceInterval = c.GetInterval("CODEINTEL_RANKING_REDUCER_INTERVAL", "1m", "The frequency with which to compute codeintel ranks from the symbols of uploads.")
 }
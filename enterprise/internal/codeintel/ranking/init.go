@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/internal/background"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/internal/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/env"
@@ -25,11 +26,6 @@ func NewService(
 	uploadSvc *uploads.Service,
 	gitserverClient GitserverClient,
 ) *Service {
-	if resultsGraphKey == "" {
-		// The codenav default
-		resultsGraphKey = "dev"
-	}
-
 	resultsBucket := func() *storage.BucketHandle {
 		if resultsBucketCredentialsFile == "" && os.Getenv("ENABLE_EXPERIMENTAL_RANKING") == "" {
 			return nil
@@ -70,7 +66,6 @@ type serviceDependencies struct {
 var (
 	// TODO - move these into background config
 	resultsBucketName             = env.Get("CODEINTEL_RANKING_RESULTS_BUCKET", "lsif-pagerank-experiments", "The GCS bucket.")
-	resultsGraphKey               = env.Get("CODEINTEL_RANKING_RESULTS_GRAPH_KEY", "dev", "An identifier of the graph export. Change to start a new import from the configured bucket.")
 	resultsObjectKeyPrefix        = env.Get("CODEINTEL_RANKING_RESULTS_OBJECT_KEY_PREFIX", "ranks/", "The object key prefix that holds results of the last PageRank batch job.")
 	resultsBucketCredentialsFile  = env.Get("CODEINTEL_RANKING_RESULTS_GOOGLE_APPLICATION_CREDENTIALS_FILE", "", "The path to a service account key file with access to GCS.")
 	exportObjectKeyPrefix         = env.Get("CODEINTEL_RANKING_DEVELOPMENT_EXPORT_OBJECT_KEY_PREFIX", "", "The object key prefix that should be used for development exports.")
@@ -78,6 +73,8 @@ var (
 
 	// Backdoor tuning for dotcom
 	mergeBatchSize = env.MustGetInt("CODEINTEL_RANKING_MERGE_BATCH_SIZE", 5000, "")
+
+	reduceBatchSize = env.MustGetInt("CODEINTEL_RANKING_REDUCE_BATCH_SIZE", 100, "How many repositories to compute reference count ranks for at once.")
 )
 
 func scopedContext(component string, observationCtx *observation.Context) *observation.Context {
@@ -103,7 +100,7 @@ func NewPageRankLoader(observationCtx *observation.Context, service *Service) []
 			service.store,
 			service.resultsBucket,
 			background.RankLoaderConfig{
-				ResultsGraphKey:        resultsGraphKey,
+				ResultsGraphKey:        uploadsshared.RankingGraphKey(),
 				ResultsObjectKeyPrefix: resultsObjectKeyPrefix,
 			},
 			LoaderConfigInst.LoadInterval,
@@ -113,12 +110,22 @@ func NewPageRankLoader(observationCtx *observation.Context, service *Service) []
 			service.store,
 			service.resultsBucket,
 			background.RankMergerConfig{
-				ResultsGraphKey:               resultsGraphKey,
+				ResultsGraphKey:               uploadsshared.RankingGraphKey(),
 				MergeBatchSize:                mergeBatchSize,
 				ExportObjectKeyPrefix:         exportObjectKeyPrefix,
 				DevelopmentExportRepositories: developmentExportRepositories,
 			},
 			LoaderConfigInst.MergeInterval,
 		),
+		background.NewRankReducer(
+			observationCtx,
+			service.store,
+			background.RankReducerConfig{
+				Enabled:         service.resultsBucket == nil,
+				ResultsGraphKey: uploadsshared.RankingGraphKey(),
+				BatchSize:       reduceBatchSize,
+			},
+			LoaderConfigInst.ReduceInterval,
+		),
 	}
 }
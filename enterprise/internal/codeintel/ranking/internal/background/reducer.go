@@ -0,0 +1,62 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/internal/store"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type rankReducer struct {
+	store   store.Store
+	metrics *reducerMetrics
+}
+
+type RankReducerConfig struct {
+	// Enabled is false when ranks are loaded from the results bucket of an
+	// external pipeline instead.
+	Enabled         bool
+	ResultsGraphKey string
+	BatchSize       int
+}
+
+// NewRankReducer creates a background routine that computes document ranks in-process from
+// the symbols of the uploads written to Postgres by the uploads service. This allows instances
+// without access to the external ranking pipeline to rank documents by the number of
+// references to them.
+func NewRankReducer(
+	observationCtx *observation.Context,
+	store store.Store,
+	config RankReducerConfig,
+	interval time.Duration,
+) goroutine.BackgroundRoutine {
+	reducer := &rankReducer{
+		store:   store,
+		metrics: newReducerMetrics(observationCtx),
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		context.Background(),
+		"pagerank.reducer", "computes reference count ranks from symbols of uploads",
+		interval,
+		goroutine.HandlerFunc(func(ctx context.Context) error {
+			return reducer.reduceRanks(ctx, config)
+		}),
+	)
+}
+
+func (r *rankReducer) reduceRanks(ctx context.Context, config RankReducerConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+
+	numRepositoriesUpdated, err := r.store.UpdateReferenceCountRanks(ctx, config.ResultsGraphKey, pageRankPrecision, config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	r.metrics.numRepositoriesUpdated.Add(float64(numRepositoriesUpdated))
+	return nil
+}
@@ -0,0 +1,32 @@
+package background
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type reducerMetrics struct {
+	numRepositoriesUpdated prometheus.Counter
+}
+
+func newReducerMetrics(observationCtx *observation.Context) *reducerMetrics {
+	counter := func(name, help string) prometheus.Counter {
+		counter := prometheus.NewCounter(prometheus.CounterOpts{
+			Name: name,
+			Help: help,
+		})
+
+		observationCtx.Registerer.MustRegister(counter)
+		return counter
+	}
+
+	numRepositoriesUpdated := counter(
+		"src_codeintel_ranking_reference_count_repositories_updated_total",
+		"The number of updates to document scores of any repository computed from reference counts.",
+	)
+
+	return &reducerMetrics{
+		numRepositoriesUpdated: numRepositoriesUpdated,
+	}
+}
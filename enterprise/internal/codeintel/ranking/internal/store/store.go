@@ -31,6 +31,7 @@ type Store interface {
 	HasInputFilename(ctx context.Context, graphKey string, filenames []string) ([]string, error)
 	BulkSetDocumentRanks(ctx context.Context, graphKey, filename string, precision float64, ranks map[api.RepoName]map[string]float64) error
 	MergeDocumentRanks(ctx context.Context, graphKey string, inputFileBatchSize int) (numRepositoriesUpdated int, numInputsProcessed int, _ error)
+	UpdateReferenceCountRanks(ctx context.Context, graphKey string, precision float64, batchSize int) (numRepositoriesUpdated int, _ error)
 	LastUpdatedAt(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]time.Time, error)
 	UpdatedAfter(ctx context.Context, t time.Time) ([]api.RepoName, error)
 
@@ -296,6 +297,97 @@ SELECT
 	(SELECT COUNT(*) FROM processed) AS num_processed
 `
 
+// UpdateReferenceCountRanks computes the document ranks of a batch of repositories from the
+// symbols of uploads written to Postgres under the given graph key. The rank of a document is
+// the number of documents, in any repository, referencing one of the symbols it defines. Only
+// the repositories marked dirty since their ranks were last computed are updated; the ranks of
+// repositories that no longer define any symbols are removed.
+func (s *store) UpdateReferenceCountRanks(ctx context.Context, graphKey string, precision float64, batchSize int) (numRepositoriesUpdated int, err error) {
+	numRepositoriesUpdated, _, err = basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(
+		updateReferenceCountRanksQuery,
+		graphKey,
+		batchSize,
+		graphKey,
+		precision,
+		graphKey,
+		precision,
+		graphKey,
+	)))
+	return numRepositoriesUpdated, err
+}
+
+const updateReferenceCountRanksQuery = `
+WITH
+candidates AS (
+	SELECT dr.graph_key, dr.repository_name, dr.dirty_token
+	FROM codeintel_ranking_dirty_repositories dr
+	WHERE
+		dr.graph_key = %s AND
+		dr.dirty_token > dr.update_token
+	ORDER BY dr.repository_name
+	LIMIT %s
+	FOR UPDATE SKIP LOCKED
+),
+repositories AS (
+	SELECT r.id, r.name
+	FROM candidates c
+	JOIN repo r ON r.name = c.repository_name
+	WHERE
+		r.deleted_at IS NULL AND
+		r.blocked IS NULL
+),
+counts AS (
+	SELECT
+		r.id AS repository_id,
+		d.document_path,
+		COALESCE(SUM(rr.document_count), 0) AS count
+	FROM repositories r
+	JOIN codeintel_ranking_definitions d ON
+		d.graph_key = %s AND
+		d.repository_name = r.name
+	LEFT JOIN codeintel_ranking_references rr ON
+		rr.graph_key = d.graph_key AND
+		rr.symbol_name = d.symbol_name
+	GROUP BY r.id, d.document_path
+),
+upserted AS (
+	INSERT INTO codeintel_path_ranks AS pr (repository_id, precision, graph_key, payload)
+	SELECT
+		c.repository_id,
+		%s,
+		%s,
+		jsonb_object_agg(c.document_path, c.count)
+	FROM counts c
+	GROUP BY c.repository_id
+	ON CONFLICT (repository_id, precision) DO UPDATE SET
+		graph_key  = EXCLUDED.graph_key,
+		payload    = EXCLUDED.payload,
+		updated_at = NOW()
+	RETURNING 1
+),
+deleted AS (
+	DELETE FROM codeintel_path_ranks pr
+	WHERE
+		pr.repository_id IN (SELECT r.id FROM repositories r) AND
+		pr.repository_id NOT IN (SELECT c.repository_id FROM counts c) AND
+		pr.precision = %s AND
+		pr.graph_key = %s
+	RETURNING 1
+),
+updated AS (
+	UPDATE codeintel_ranking_dirty_repositories dr
+	SET
+		update_token = c.dirty_token,
+		updated_at = NOW()
+	FROM candidates c
+	WHERE
+		dr.graph_key = c.graph_key AND
+		dr.repository_name = c.repository_name
+	RETURNING 1
+)
+SELECT COUNT(*) FROM upserted
+`
+
 func (s *store) LastUpdatedAt(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]time.Time, error) {
 	pairs, err := scanLastUpdatedAtPairs(s.db.Query(ctx, sqlf.Sprintf(lastUpdatedAtQuery, pq.Array(repoIDs))))
 	if err != nil {
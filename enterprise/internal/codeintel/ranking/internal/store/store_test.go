@@ -254,6 +254,105 @@ func TestBulkSetAndMergeDocumentRanks(t *testing.T) {
 	}
 }
 
+func TestUpdateReferenceCountRanks(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO repo (id, name) VALUES (50, 'lib');
+		INSERT INTO repo (id, name) VALUES (51, 'app');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state) VALUES (100, 50, '0000000000000000000000000000000000000001', 'scip-test', 1, '{}', 'completed');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state) VALUES (101, 51, '0000000000000000000000000000000000000002', 'scip-test', 1, '{}', 'completed');
+		INSERT INTO codeintel_ranking_definitions (upload_id, graph_key, repository_name, document_path, symbol_name) VALUES (100, 'test', 'lib', 'util.go', 'Util');
+		INSERT INTO codeintel_ranking_definitions (upload_id, graph_key, repository_name, document_path, symbol_name) VALUES (100, 'test', 'lib', 'util.go', 'Helper');
+		INSERT INTO codeintel_ranking_definitions (upload_id, graph_key, repository_name, document_path, symbol_name) VALUES (100, 'test', 'lib', 'unused.go', 'Unused');
+		INSERT INTO codeintel_ranking_definitions (upload_id, graph_key, repository_name, document_path, symbol_name) VALUES (101, 'test', 'app', 'main.go', 'Main');
+		INSERT INTO codeintel_ranking_definitions (upload_id, graph_key, repository_name, document_path, symbol_name) VALUES (101, 'old', 'app', 'old.go', 'Old');
+		INSERT INTO codeintel_ranking_references (upload_id, graph_key, symbol_name, document_count) VALUES (100, 'test', 'Util', 1);
+		INSERT INTO codeintel_ranking_references (upload_id, graph_key, symbol_name, document_count) VALUES (101, 'test', 'Util', 3);
+		INSERT INTO codeintel_ranking_references (upload_id, graph_key, symbol_name, document_count) VALUES (101, 'test', 'Helper', 2);
+		INSERT INTO codeintel_ranking_references (upload_id, graph_key, symbol_name, document_count) VALUES (101, 'old', 'Main', 5);
+		INSERT INTO codeintel_ranking_dirty_repositories (graph_key, repository_name, dirty_token) VALUES ('test', 'lib', 1);
+		INSERT INTO codeintel_ranking_dirty_repositories (graph_key, repository_name, dirty_token) VALUES ('test', 'app', 1);
+		INSERT INTO codeintel_ranking_dirty_repositories (graph_key, repository_name, dirty_token) VALUES ('old', 'app', 1);
+	`); err != nil {
+		t.Fatalf("unexpected error setting up test: %s", err)
+	}
+
+	if numRepositoriesUpdated, err := store.UpdateReferenceCountRanks(ctx, "test", 1, 10); err != nil {
+		t.Fatalf("unexpected error updating reference count ranks: %s", err)
+	} else if expected := 2; numRepositoriesUpdated != expected {
+		t.Fatalf("unexpected numRepositoriesUpdated. want=%d have=%d", expected, numRepositoriesUpdated)
+	}
+
+	allRanks := map[string]map[string][2]float64{}
+	for _, repoName := range []string{"lib", "app"} {
+		ranks, _, err := store.GetDocumentRanks(ctx, api.RepoName(repoName))
+		if err != nil {
+			t.Fatalf("unexpected error getting ranks for repo %s: %s", repoName, err)
+		}
+
+		allRanks[repoName] = ranks
+	}
+	expectedRanks := map[string]map[string][2]float64{
+		"lib": {"util.go": {1, 6}, "unused.go": {1, 0}},
+		"app": {"main.go": {1, 0}},
+	}
+	if diff := cmp.Diff(expectedRanks, allRanks); diff != "" {
+		t.Errorf("unexpected ranks (-want +got):\n%s", diff)
+	}
+
+	// Ranks are up to date
+	if numRepositoriesUpdated, err := store.UpdateReferenceCountRanks(ctx, "test", 1, 10); err != nil {
+		t.Fatalf("unexpected error updating reference count ranks: %s", err)
+	} else if expected := 0; numRepositoriesUpdated != expected {
+		t.Fatalf("unexpected numRepositoriesUpdated. want=%d have=%d", expected, numRepositoriesUpdated)
+	}
+
+	// A new reference to lib only marks lib dirty
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO codeintel_ranking_references (upload_id, graph_key, symbol_name, document_count) VALUES (101, 'test', 'Unused', 1);
+		UPDATE codeintel_ranking_dirty_repositories SET dirty_token = dirty_token + 1 WHERE graph_key = 'test' AND repository_name = 'lib';
+	`); err != nil {
+		t.Fatalf("unexpected error setting up test: %s", err)
+	}
+	if numRepositoriesUpdated, err := store.UpdateReferenceCountRanks(ctx, "test", 1, 10); err != nil {
+		t.Fatalf("unexpected error updating reference count ranks: %s", err)
+	} else if expected := 1; numRepositoriesUpdated != expected {
+		t.Fatalf("unexpected numRepositoriesUpdated. want=%d have=%d", expected, numRepositoriesUpdated)
+	}
+	ranks, _, err := store.GetDocumentRanks(ctx, api.RepoName("lib"))
+	if err != nil {
+		t.Fatalf("unexpected error getting ranks for repo lib: %s", err)
+	}
+	if diff := cmp.Diff(map[string][2]float64{"util.go": {1, 6}, "unused.go": {1, 1}}, ranks); diff != "" {
+		t.Errorf("unexpected ranks (-want +got):\n%s", diff)
+	}
+	// The ranks of a dirty repository without definitions are removed
+	if _, err := db.ExecContext(ctx, `
+		DELETE FROM codeintel_ranking_definitions WHERE graph_key = 'test' AND repository_name = 'app';
+		UPDATE codeintel_ranking_dirty_repositories SET dirty_token = dirty_token + 1 WHERE graph_key = 'test' AND repository_name = 'app';
+	`); err != nil {
+		t.Fatalf("unexpected error setting up test: %s", err)
+	}
+	if _, err := store.UpdateReferenceCountRanks(ctx, "test", 1, 10); err != nil {
+		t.Fatalf("unexpected error updating reference count ranks: %s", err)
+	}
+	ranks, _, err = store.GetDocumentRanks(ctx, api.RepoName("app"))
+	if err != nil {
+		t.Fatalf("unexpected error getting ranks for repo app: %s", err)
+	}
+	if len(ranks) != 0 {
+		t.Errorf("unexpected ranks for repo app: %v", ranks)
+	}
+}
+
 func TestLastUpdatedAt(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -61,6 +61,9 @@ type MockStore struct {
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *StoreTransactFunc
+	// UpdateReferenceCountRanksFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateReferenceCountRanks.
+	UpdateReferenceCountRanksFunc *StoreUpdateReferenceCountRanksFunc
 	// UpdatedAfterFunc is an instance of a mock function object controlling
 	// the behavior of the method UpdatedAfter.
 	UpdatedAfterFunc *StoreUpdatedAfterFunc
@@ -125,6 +128,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		UpdateReferenceCountRanksFunc: &StoreUpdateReferenceCountRanksFunc{
+			defaultHook: func(context.Context, string, float64, int) (r0 int, r1 error) {
+				return
+			},
+		},
 		UpdatedAfterFunc: &StoreUpdatedAfterFunc{
 			defaultHook: func(context.Context, time.Time) (r0 []api.RepoName, r1 error) {
 				return
@@ -192,6 +200,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.Transact")
 			},
 		},
+		UpdateReferenceCountRanksFunc: &StoreUpdateReferenceCountRanksFunc{
+			defaultHook: func(context.Context, string, float64, int) (int, error) {
+				panic("unexpected invocation of MockStore.UpdateReferenceCountRanks")
+			},
+		},
 		UpdatedAfterFunc: &StoreUpdatedAfterFunc{
 			defaultHook: func(context.Context, time.Time) ([]api.RepoName, error) {
 				panic("unexpected invocation of MockStore.UpdatedAfter")
@@ -237,6 +250,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		TransactFunc: &StoreTransactFunc{
 			defaultHook: i.Transact,
 		},
+		UpdateReferenceCountRanksFunc: &StoreUpdateReferenceCountRanksFunc{
+			defaultHook: i.UpdateReferenceCountRanks,
+		},
 		UpdatedAfterFunc: &StoreUpdatedAfterFunc{
 			defaultHook: i.UpdatedAfter,
 		},
@@ -1438,6 +1454,122 @@ func (c StoreTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreUpdateReferenceCountRanksFunc describes the behavior when the
+// UpdateReferenceCountRanks method of the parent MockStore instance is
+// invoked.
+type StoreUpdateReferenceCountRanksFunc struct {
+	defaultHook func(context.Context, string, float64, int) (int, error)
+	hooks       []func(context.Context, string, float64, int) (int, error)
+	history     []StoreUpdateReferenceCountRanksFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateReferenceCountRanks delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) UpdateReferenceCountRanks(v0 context.Context, v1 string, v2 float64, v3 int) (int, error) {
+	r0, r1 := m.UpdateReferenceCountRanksFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateReferenceCountRanksFunc.appendCall(StoreUpdateReferenceCountRanksFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateReferenceCountRanks method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreUpdateReferenceCountRanksFunc) SetDefaultHook(hook func(context.Context, string, float64, int) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateReferenceCountRanks method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreUpdateReferenceCountRanksFunc) PushHook(hook func(context.Context, string, float64, int) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreUpdateReferenceCountRanksFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, float64, int) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreUpdateReferenceCountRanksFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, string, float64, int) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreUpdateReferenceCountRanksFunc) nextHook() func(context.Context, string, float64, int) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreUpdateReferenceCountRanksFunc) appendCall(r0 StoreUpdateReferenceCountRanksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreUpdateReferenceCountRanksFuncCall
+// objects describing the invocations of this function.
+func (f *StoreUpdateReferenceCountRanksFunc) History() []StoreUpdateReferenceCountRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreUpdateReferenceCountRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreUpdateReferenceCountRanksFuncCall is an object that describes an
+// invocation of method UpdateReferenceCountRanks on an instance of
+// MockStore.
+type StoreUpdateReferenceCountRanksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 float64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreUpdateReferenceCountRanksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreUpdateReferenceCountRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreUpdatedAfterFunc describes the behavior when the UpdatedAfter method
 // of the parent MockStore instance is invoked.
 type StoreUpdatedAfterFunc struct {
@@ -700,12 +700,18 @@ func (c AutoIndexingServiceGetUnsafeDBFuncCall) Results() []interface{} {
 // github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/resolvers)
 // used for unit testing.
 type MockUploadsService struct {
+	// DiffUploadsFunc is an instance of a mock function object controlling
+	// the behavior of the method DiffUploads.
+	DiffUploadsFunc *UploadsServiceDiffUploadsFunc
 	// GetAuditLogsForUploadFunc is an instance of a mock function object
 	// controlling the behavior of the method GetAuditLogsForUpload.
 	GetAuditLogsForUploadFunc *UploadsServiceGetAuditLogsForUploadFunc
 	// GetListTagsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetListTags.
 	GetListTagsFunc *UploadsServiceGetListTagsFunc
+	// GetPreviousUploadFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPreviousUpload.
+	GetPreviousUploadFunc *UploadsServiceGetPreviousUploadFunc
 	// GetUploadDocumentsForPathFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// GetUploadDocumentsForPath.
@@ -722,6 +728,11 @@ type MockUploadsService struct {
 // All methods return zero values for all results, unless overwritten.
 func NewMockUploadsService() *MockUploadsService {
 	return &MockUploadsService{
+		DiffUploadsFunc: &UploadsServiceDiffUploadsFunc{
+			defaultHook: func(context.Context, types.Upload, types.Upload) (r0 shared1.UploadDiff, r1 error) {
+				return
+			},
+		},
 		GetAuditLogsForUploadFunc: &UploadsServiceGetAuditLogsForUploadFunc{
 			defaultHook: func(context.Context, int) (r0 []types.UploadLog, r1 error) {
 				return
@@ -732,6 +743,11 @@ func NewMockUploadsService() *MockUploadsService {
 				return
 			},
 		},
+		GetPreviousUploadFunc: &UploadsServiceGetPreviousUploadFunc{
+			defaultHook: func(context.Context, types.Upload) (r0 types.Upload, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetUploadDocumentsForPathFunc: &UploadsServiceGetUploadDocumentsForPathFunc{
 			defaultHook: func(context.Context, int, string) (r0 []string, r1 int, r2 error) {
 				return
@@ -754,6 +770,11 @@ func NewMockUploadsService() *MockUploadsService {
 // interface. All methods panic on invocation, unless overwritten.
 func NewStrictMockUploadsService() *MockUploadsService {
 	return &MockUploadsService{
+		DiffUploadsFunc: &UploadsServiceDiffUploadsFunc{
+			defaultHook: func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error) {
+				panic("unexpected invocation of MockUploadsService.DiffUploads")
+			},
+		},
 		GetAuditLogsForUploadFunc: &UploadsServiceGetAuditLogsForUploadFunc{
 			defaultHook: func(context.Context, int) ([]types.UploadLog, error) {
 				panic("unexpected invocation of MockUploadsService.GetAuditLogsForUpload")
@@ -764,6 +785,11 @@ func NewStrictMockUploadsService() *MockUploadsService {
 				panic("unexpected invocation of MockUploadsService.GetListTags")
 			},
 		},
+		GetPreviousUploadFunc: &UploadsServiceGetPreviousUploadFunc{
+			defaultHook: func(context.Context, types.Upload) (types.Upload, bool, error) {
+				panic("unexpected invocation of MockUploadsService.GetPreviousUpload")
+			},
+		},
 		GetUploadDocumentsForPathFunc: &UploadsServiceGetUploadDocumentsForPathFunc{
 			defaultHook: func(context.Context, int, string) ([]string, int, error) {
 				panic("unexpected invocation of MockUploadsService.GetUploadDocumentsForPath")
@@ -787,12 +813,18 @@ func NewStrictMockUploadsService() *MockUploadsService {
 // overwritten.
 func NewMockUploadsServiceFrom(i UploadsService) *MockUploadsService {
 	return &MockUploadsService{
+		DiffUploadsFunc: &UploadsServiceDiffUploadsFunc{
+			defaultHook: i.DiffUploads,
+		},
 		GetAuditLogsForUploadFunc: &UploadsServiceGetAuditLogsForUploadFunc{
 			defaultHook: i.GetAuditLogsForUpload,
 		},
 		GetListTagsFunc: &UploadsServiceGetListTagsFunc{
 			defaultHook: i.GetListTags,
 		},
+		GetPreviousUploadFunc: &UploadsServiceGetPreviousUploadFunc{
+			defaultHook: i.GetPreviousUpload,
+		},
 		GetUploadDocumentsForPathFunc: &UploadsServiceGetUploadDocumentsForPathFunc{
 			defaultHook: i.GetUploadDocumentsForPath,
 		},
@@ -805,6 +837,117 @@ func NewMockUploadsServiceFrom(i UploadsService) *MockUploadsService {
 	}
 }
 
+// UploadsServiceDiffUploadsFunc describes the behavior when the DiffUploads
+// method of the parent MockUploadsService instance is invoked.
+type UploadsServiceDiffUploadsFunc struct {
+	defaultHook func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error)
+	hooks       []func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error)
+	history     []UploadsServiceDiffUploadsFuncCall
+	mutex       sync.Mutex
+}
+
+// DiffUploads delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUploadsService) DiffUploads(v0 context.Context, v1 types.Upload, v2 types.Upload) (shared1.UploadDiff, error) {
+	r0, r1 := m.DiffUploadsFunc.nextHook()(v0, v1, v2)
+	m.DiffUploadsFunc.appendCall(UploadsServiceDiffUploadsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DiffUploads method
+// of the parent MockUploadsService instance is invoked and the hook queue
+// is empty.
+func (f *UploadsServiceDiffUploadsFunc) SetDefaultHook(hook func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DiffUploads method of the parent MockUploadsService instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UploadsServiceDiffUploadsFunc) PushHook(hook func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadsServiceDiffUploadsFunc) SetDefaultReturn(r0 shared1.UploadDiff, r1 error) {
+	f.SetDefaultHook(func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadsServiceDiffUploadsFunc) PushReturn(r0 shared1.UploadDiff, r1 error) {
+	f.PushHook(func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error) {
+		return r0, r1
+	})
+}
+
+func (f *UploadsServiceDiffUploadsFunc) nextHook() func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadsServiceDiffUploadsFunc) appendCall(r0 UploadsServiceDiffUploadsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UploadsServiceDiffUploadsFuncCall objects
+// describing the invocations of this function.
+func (f *UploadsServiceDiffUploadsFunc) History() []UploadsServiceDiffUploadsFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadsServiceDiffUploadsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadsServiceDiffUploadsFuncCall is an object that describes an
+// invocation of method DiffUploads on an instance of MockUploadsService.
+type UploadsServiceDiffUploadsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.Upload
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 types.Upload
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared1.UploadDiff
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadsServiceDiffUploadsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadsServiceDiffUploadsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // UploadsServiceGetAuditLogsForUploadFunc describes the behavior when the
 // GetAuditLogsForUpload method of the parent MockUploadsService instance is
 // invoked.
@@ -1034,6 +1177,120 @@ func (c UploadsServiceGetListTagsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// UploadsServiceGetPreviousUploadFunc describes the behavior when the
+// GetPreviousUpload method of the parent MockUploadsService instance is
+// invoked.
+type UploadsServiceGetPreviousUploadFunc struct {
+	defaultHook func(context.Context, types.Upload) (types.Upload, bool, error)
+	hooks       []func(context.Context, types.Upload) (types.Upload, bool, error)
+	history     []UploadsServiceGetPreviousUploadFuncCall
+	mutex       sync.Mutex
+}
+
+// GetPreviousUpload delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUploadsService) GetPreviousUpload(v0 context.Context, v1 types.Upload) (types.Upload, bool, error) {
+	r0, r1, r2 := m.GetPreviousUploadFunc.nextHook()(v0, v1)
+	m.GetPreviousUploadFunc.appendCall(UploadsServiceGetPreviousUploadFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetPreviousUpload
+// method of the parent MockUploadsService instance is invoked and the hook
+// queue is empty.
+func (f *UploadsServiceGetPreviousUploadFunc) SetDefaultHook(hook func(context.Context, types.Upload) (types.Upload, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetPreviousUpload method of the parent MockUploadsService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UploadsServiceGetPreviousUploadFunc) PushHook(hook func(context.Context, types.Upload) (types.Upload, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadsServiceGetPreviousUploadFunc) SetDefaultReturn(r0 types.Upload, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, types.Upload) (types.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadsServiceGetPreviousUploadFunc) PushReturn(r0 types.Upload, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, types.Upload) (types.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *UploadsServiceGetPreviousUploadFunc) nextHook() func(context.Context, types.Upload) (types.Upload, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadsServiceGetPreviousUploadFunc) appendCall(r0 UploadsServiceGetPreviousUploadFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UploadsServiceGetPreviousUploadFuncCall
+// objects describing the invocations of this function.
+func (f *UploadsServiceGetPreviousUploadFunc) History() []UploadsServiceGetPreviousUploadFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadsServiceGetPreviousUploadFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadsServiceGetPreviousUploadFuncCall is an object that describes an
+// invocation of method GetPreviousUpload on an instance of
+// MockUploadsService.
+type UploadsServiceGetPreviousUploadFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.Upload
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 types.Upload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadsServiceGetPreviousUploadFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadsServiceGetPreviousUploadFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // UploadsServiceGetUploadDocumentsForPathFunc describes the behavior when
 // the GetUploadDocumentsForPath method of the parent MockUploadsService
 // instance is invoked.
@@ -0,0 +1,98 @@
+package sharedresolvers
+
+import (
+	uploadsShared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+)
+
+const DefaultMissingDefinitionsPageSize = 100
+
+type uploadDiffResolver struct {
+	base resolverstubs.LSIFUploadResolver
+	head resolverstubs.LSIFUploadResolver
+	diff uploadsShared.UploadDiff
+}
+
+func NewUploadDiffResolver(base, head resolverstubs.LSIFUploadResolver, diff uploadsShared.UploadDiff) resolverstubs.LSIFUploadDiffResolver {
+	return &uploadDiffResolver{
+		base: base,
+		head: head,
+		diff: diff,
+	}
+}
+
+func (r *uploadDiffResolver) Base() resolverstubs.LSIFUploadResolver { return r.base }
+func (r *uploadDiffResolver) Head() resolverstubs.LSIFUploadResolver { return r.head }
+func (r *uploadDiffResolver) AddedDocuments() []string               { return emptyIfNil(r.diff.AddedDocuments) }
+func (r *uploadDiffResolver) RemovedDocuments() []string             { return emptyIfNil(r.diff.RemovedDocuments) }
+func (r *uploadDiffResolver) BaseSymbolCount() int32                 { return int32(r.diff.BaseSymbolCount) }
+func (r *uploadDiffResolver) HeadSymbolCount() int32                 { return int32(r.diff.HeadSymbolCount) }
+func (r *uploadDiffResolver) BaseOccurrenceCount() int32             { return int32(r.diff.BaseOccurrenceCount) }
+func (r *uploadDiffResolver) HeadOccurrenceCount() int32             { return int32(r.diff.HeadOccurrenceCount) }
+
+func (r *uploadDiffResolver) MissingDefinitionCount() int32 {
+	return int32(len(r.diff.MissingDefinitions))
+}
+
+func (r *uploadDiffResolver) ChangedDocuments() []resolverstubs.LSIFUploadDocumentDiffResolver {
+	resolvers := make([]resolverstubs.LSIFUploadDocumentDiffResolver, 0, len(r.diff.ChangedDocuments))
+	for _, document := range r.diff.ChangedDocuments {
+		resolvers = append(resolvers, &uploadDocumentDiffResolver{document: document})
+	}
+
+	return resolvers
+}
+
+func (r *uploadDiffResolver) MissingDefinitions(args *resolverstubs.LSIFUploadMissingDefinitionsArgs) []resolverstubs.LSIFUploadMissingDefinitionResolver {
+	limit := DefaultMissingDefinitionsPageSize
+	if args.First != nil {
+		limit = int(*args.First)
+	}
+	if limit < 0 || limit > len(r.diff.MissingDefinitions) {
+		limit = len(r.diff.MissingDefinitions)
+	}
+
+	resolvers := make([]resolverstubs.LSIFUploadMissingDefinitionResolver, 0, limit)
+	for _, definition := range r.diff.MissingDefinitions[:limit] {
+		resolvers = append(resolvers, &uploadMissingDefinitionResolver{definition: definition})
+	}
+
+	return resolvers
+}
+
+type uploadDocumentDiffResolver struct {
+	document uploadsShared.DocumentDiff
+}
+
+func (r *uploadDocumentDiffResolver) Path() string { return r.document.Path }
+
+func (r *uploadDocumentDiffResolver) BaseSymbolCount() int32 {
+	return int32(r.document.BaseSymbolCount)
+}
+
+func (r *uploadDocumentDiffResolver) HeadSymbolCount() int32 {
+	return int32(r.document.HeadSymbolCount)
+}
+
+func (r *uploadDocumentDiffResolver) BaseOccurrenceCount() int32 {
+	return int32(r.document.BaseOccurrenceCount)
+}
+
+func (r *uploadDocumentDiffResolver) HeadOccurrenceCount() int32 {
+	return int32(r.document.HeadOccurrenceCount)
+}
+
+type uploadMissingDefinitionResolver struct {
+	definition uploadsShared.MissingDefinition
+}
+
+func (r *uploadMissingDefinitionResolver) Symbol() string { return r.definition.Symbol }
+func (r *uploadMissingDefinitionResolver) Path() string   { return r.definition.Path }
+
+func emptyIfNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type UploadResolver struct {
@@ -173,3 +174,60 @@ func (r *UploadResolver) AuditLogs(ctx context.Context) (*[]resolverstubs.LSIFUp
 
 	return &resolvers, nil
 }
+
+func (r *UploadResolver) Diff(ctx context.Context, args *resolverstubs.LSIFUploadDiffArgs) (_ resolverstubs.LSIFUploadDiffResolver, err error) {
+	defer r.traceErrs.Collect(&err,
+		log.String("uploadResolver.field", "diff"),
+		log.Int("uploadID", r.upload.ID),
+	)
+
+	var base types.Upload
+	if args.Base != nil {
+		baseID, err := unmarshalLSIFUploadGQLID(*args.Base)
+		if err != nil {
+			return nil, err
+		}
+
+		uploads, err := r.uploadsSvc.GetUploadsByIDs(ctx, int(baseID))
+		if err != nil {
+			return nil, err
+		}
+		if len(uploads) == 0 {
+			return nil, &uploadDiffBaseNotFoundError{reason: errors.Newf("upload %d not found", baseID)}
+		}
+		base = uploads[0]
+	} else {
+		previous, exists, err := r.uploadsSvc.GetPreviousUpload(ctx, r.upload)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, &uploadDiffBaseNotFoundError{reason: errors.Newf("no completed upload precedes upload %d", r.upload.ID)}
+		}
+		base = previous
+	}
+
+	diff, err := r.uploadsSvc.DiffUploads(ctx, base, r.upload)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewUploadDiffResolver(
+		NewUploadResolver(r.uploadsSvc, r.autoindexingSvc, r.policySvc, base, r.prefetcher, r.traceErrs),
+		r,
+		diff,
+	), nil
+}
+
+// uploadDiffBaseNotFoundError is returned when there is no upload to compare an upload against.
+type uploadDiffBaseNotFoundError struct {
+	reason error
+}
+
+func (e *uploadDiffBaseNotFoundError) Error() string {
+	return "base upload not found: " + e.reason.Error()
+}
+
+func (e *uploadDiffBaseNotFoundError) NotFound() bool {
+	return true
+}
@@ -41,6 +41,11 @@ func marshalLSIFUploadGQLID(uploadID int64) graphql.ID {
 	return relay.MarshalID("LSIFUpload", uploadID)
 }
 
+func unmarshalLSIFUploadGQLID(id graphql.ID) (uploadID int64, err error) {
+	err = relay.UnmarshalSpec(id, &uploadID)
+	return uploadID, err
+}
+
 func unmarshalConfigurationPolicyGQLID(id graphql.ID) (configurationPolicyID int64, err error) {
 	err = relay.UnmarshalSpec(id, &configurationPolicyID)
 	return configurationPolicyID, err
@@ -6,6 +6,7 @@ type ConfigurationPolicy struct {
 	ID                        int
 	RepositoryID              *int
 	RepositoryPatterns        *[]string
+	RepositoryQuery           *string
 	Name                      string
 	Type                      GitObjectType
 	Pattern                   string
@@ -84,11 +84,12 @@ type serviceDependencies struct {
 }
 
 var (
-	bucketName                   = env.Get("CODEINTEL_UPLOADS_RANKING_BUCKET", "lsif-pagerank-experiments", "The GCS bucket.")
-	rankingGraphKey              = env.Get("CODEINTEL_UPLOADS_RANKING_GRAPH_KEY", "dev", "An identifier of the graph export. Change to start a new export in the configured bucket.")
-	rankingGraphBatchSize        = env.MustGetInt("CODEINTEL_UPLOADS_RANKING_GRAPH_BATCH_SIZE", 16, "How many uploads to process at once.")
-	rankingGraphDeleteBatchSize  = env.MustGetInt("CODEINTEL_UPLOADS_RANKING_GRAPH_DELETE_BATCH_SIZE", 32, "How many stale uploads to delete at once.")
-	rankingBucketCredentialsFile = env.Get("CODEINTEL_UPLOADS_RANKING_GOOGLE_APPLICATION_CREDENTIALS_FILE", "", "The path to a service account key file with access to GCS.")
+	bucketName                    = env.Get("CODEINTEL_UPLOADS_RANKING_BUCKET", "lsif-pagerank-experiments", "The GCS bucket.")
+	rankingGraphBatchSize         = env.MustGetInt("CODEINTEL_UPLOADS_RANKING_GRAPH_BATCH_SIZE", 16, "How many uploads to process at once.")
+	rankingGraphDeleteBatchSize   = env.MustGetInt("CODEINTEL_UPLOADS_RANKING_GRAPH_DELETE_BATCH_SIZE", 32, "How many stale uploads to delete at once.")
+	rankingBucketCredentialsFile  = env.Get("CODEINTEL_UPLOADS_RANKING_GOOGLE_APPLICATION_CREDENTIALS_FILE", "", "The path to a service account key file with access to GCS.")
+	rankingInProcess              = env.MustGetBool("CODEINTEL_UPLOADS_RANKING_IN_PROCESS", false, "Write the symbols of uploads to Postgres so that document ranks are computed in-process when no ranking bucket is configured.")
+	rankingSymbolsDeleteBatchSize = env.MustGetInt("CODEINTEL_UPLOADS_RANKING_SYMBOLS_DELETE_BATCH_SIZE", 10000, "How many stale ranking symbol records to delete at once.")
 )
 
 func scopedContext(component string, parent *observation.Context) *observation.Context {
@@ -104,6 +105,7 @@ func NewUploadProcessorJob(
 	workerBudget int64,
 	workerPollInterval time.Duration,
 	maximumRuntimePerJob time.Duration,
+	symbolCountRegressionThreshold float64,
 ) goroutine.BackgroundRoutine {
 	uploadsProcessorStore := dbworkerstore.New(observationCtx, db.Handle(), store.UploadWorkerStoreOptions)
 
@@ -121,6 +123,7 @@ func NewUploadProcessorJob(
 		workerBudget,
 		workerPollInterval,
 		maximumRuntimePerJob,
+		symbolCountRegressionThreshold,
 	)
 }
 
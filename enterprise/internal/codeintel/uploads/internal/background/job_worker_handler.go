@@ -17,6 +17,7 @@ import (
 	codeinteltypes "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/lsifstore"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -43,6 +44,7 @@ func NewUploadProcessorWorker(
 	workerBudget int64,
 	workerPollInterval time.Duration,
 	maximumRuntimePerJob time.Duration,
+	symbolCountRegressionThreshold float64,
 ) *workerutil.Worker[codeinteltypes.Upload] {
 	rootContext := actor.WithInternalActor(context.Background())
 
@@ -56,6 +58,7 @@ func NewUploadProcessorWorker(
 		uploadStore,
 		workerConcurrency,
 		workerBudget,
+		symbolCountRegressionThreshold,
 	)
 
 	metrics := workerutil.NewMetrics(observationCtx, "codeintel_upload_processor", workerutil.WithSampler(func(job workerutil.Record) bool { return true }))
@@ -81,6 +84,9 @@ type handler struct {
 	budgetRemaining int64
 	enableBudget    bool
 	uploadSizeGuage prometheus.Gauge
+
+	symbolCountRegressionThreshold float64
+	numSymbolCountRegressions      prometheus.Counter
 }
 
 var (
@@ -99,6 +105,7 @@ func NewUploadProcessorHandler(
 	uploadStore uploadstore.Store,
 	numProcessorRoutines int,
 	budgetMax int64,
+	symbolCountRegressionThreshold float64,
 ) workerutil.Handler[codeinteltypes.Upload] {
 	operations := newOperations(observationCtx)
 
@@ -113,6 +120,9 @@ func NewUploadProcessorHandler(
 		budgetRemaining: budgetMax,
 		enableBudget:    budgetMax > 0,
 		uploadSizeGuage: operations.uploadSizeGuage,
+
+		symbolCountRegressionThreshold: symbolCountRegressionThreshold,
+		numSymbolCountRegressions:      operations.numSymbolCountRegressions,
 	}
 }
 
@@ -130,10 +140,64 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, upload codeinte
 	}()
 
 	requeued, err = h.HandleRawUpload(ctx, logger, upload, h.uploadStore, otLogger)
+	if err == nil && !requeued && h.symbolCountRegressionThreshold > 0 {
+		h.checkSymbolCountRegression(ctx, logger, upload)
+	}
 
 	return err
 }
 
+// checkSymbolCountRegression compares the number of symbols defined by a freshly processed upload
+// with the number of symbols defined by the previous upload for the same repository, root, and
+// indexer, as recorded in their document summaries. A drop below the configured fraction of the
+// previous count is logged and counted so that index quality regressions can be alerted on.
+// Failures here never fail the upload.
+func (h *handler) checkSymbolCountRegression(ctx context.Context, logger log.Logger, upload codeinteltypes.Upload) {
+	previous, ok, err := h.store.GetPreviousUpload(ctx, upload)
+	if err != nil {
+		logger.Error("Failed to find previous upload", log.Error(err))
+		return
+	}
+	if !ok {
+		return
+	}
+
+	previousSymbolCount, err := h.countSymbols(ctx, previous.ID)
+	if err != nil {
+		logger.Error("Failed to count symbols of previous upload", log.Int("previousUploadID", previous.ID), log.Error(err))
+		return
+	}
+	symbolCount, err := h.countSymbols(ctx, upload.ID)
+	if err != nil {
+		logger.Error("Failed to count symbols of upload", log.Error(err))
+		return
+	}
+
+	if float64(symbolCount) < h.symbolCountRegressionThreshold*float64(previousSymbolCount) {
+		logger.Warn("Upload defines significantly fewer symbols than the previous upload",
+			log.Int("uploadID", upload.ID),
+			log.Int("previousUploadID", previous.ID),
+			log.Int("symbolCount", symbolCount),
+			log.Int("previousSymbolCount", previousSymbolCount),
+		)
+		h.numSymbolCountRegressions.Inc()
+	}
+}
+
+func (h *handler) countSymbols(ctx context.Context, uploadID int) (int, error) {
+	summaries, err := h.lsifstore.GetDocumentSummaries(ctx, uploadID)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, summary := range summaries {
+		count += summary.Definitions
+	}
+
+	return count, nil
+}
+
 func (h *handler) PreDequeue(ctx context.Context, logger log.Logger) (bool, any, error) {
 	if !h.enableBudget {
 		return true, nil, nil
@@ -443,7 +507,35 @@ func writeData(ctx context.Context, lsifStore lsifstore.LsifStore, upload codein
 	if err := tx.WriteMeta(ctx, upload.ID, groupedBundleData.Meta); err != nil {
 		return errors.Wrap(err, "store.WriteMeta")
 	}
-	count, err := tx.WriteDocuments(ctx, upload.ID, groupedBundleData.Documents)
+
+	// Summarize the documents and definitions as they are written
+	summaries := map[string]shared.DocumentSummary{}
+	documents := make(chan precise.KeyedDocumentData)
+	go func() {
+		defer close(documents)
+
+		for document := range groupedBundleData.Documents {
+			summaries[document.Path] = shared.DocumentSummary{Occurrences: len(document.Document.Ranges)}
+			documents <- document
+		}
+	}()
+	definedMonikers := map[string]map[string]struct{}{}
+	definitions := make(chan precise.MonikerLocations)
+	go func() {
+		defer close(definitions)
+
+		for monikerLocations := range groupedBundleData.Definitions {
+			for _, location := range monikerLocations.Locations {
+				if _, ok := definedMonikers[location.URI]; !ok {
+					definedMonikers[location.URI] = map[string]struct{}{}
+				}
+				definedMonikers[location.URI][monikerLocations.Scheme+":"+monikerLocations.Identifier] = struct{}{}
+			}
+			definitions <- monikerLocations
+		}
+	}()
+
+	count, err := tx.WriteDocuments(ctx, upload.ID, documents)
 	if err != nil {
 		return errors.Wrap(err, "store.WriteDocuments")
 	}
@@ -455,12 +547,22 @@ func writeData(ctx context.Context, lsifStore lsifstore.LsifStore, upload codein
 	}
 	trace.Log(otlog.Uint32("numResultChunks", count))
 
-	count, err = tx.WriteDefinitions(ctx, upload.ID, groupedBundleData.Definitions)
+	count, err = tx.WriteDefinitions(ctx, upload.ID, definitions)
 	if err != nil {
 		return errors.Wrap(err, "store.WriteDefinitions")
 	}
 	trace.Log(otlog.Uint32("numDefinitions", count))
 
+	for path, monikers := range definedMonikers {
+		if summary, ok := summaries[path]; ok {
+			summary.Definitions = len(monikers)
+			summaries[path] = summary
+		}
+	}
+	if err := tx.InsertDocumentSummaries(ctx, upload.ID, summaries); err != nil {
+		return errors.Wrap(err, "store.InsertDocumentSummaries")
+	}
+
 	count, err = tx.WriteReferences(ctx, upload.ID, groupedBundleData.References)
 	if err != nil {
 		return errors.Wrap(err, "store.WriteReferences")
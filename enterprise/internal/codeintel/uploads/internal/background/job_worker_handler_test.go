@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/sourcegraph/log/logtest"
 	scip "github.com/sourcegraph/scip/bindings/go/scip"
 	"google.golang.org/protobuf/proto"
@@ -19,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	codeinteltypes "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/lsifstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -567,6 +570,53 @@ func TestHandleCloneInProgress(t *testing.T) {
 //
 //
 
+func TestCheckSymbolCountRegression(t *testing.T) {
+	upload := codeinteltypes.Upload{ID: 42, Root: "root/", RepositoryID: 50, Indexer: "scip-go"}
+	previous := codeinteltypes.Upload{ID: 41, Root: "root/", RepositoryID: 50, Indexer: "scip-go"}
+
+	summaries := map[int]map[string]shared.DocumentSummary{
+		41: {
+			"a.go": {Occurrences: 10, Definitions: 3},
+			"b.go": {Occurrences: 10, Definitions: 1},
+		},
+		42: {
+			"a.go": {Occurrences: 10, Definitions: 1},
+		},
+	}
+
+	testCases := []struct {
+		threshold       float64
+		hasPrevious     bool
+		expectedReports float64
+	}{
+		{threshold: 0.5, hasPrevious: true, expectedReports: 1},
+		{threshold: 0.25, hasPrevious: true, expectedReports: 0},
+		{threshold: 0.5, hasPrevious: false, expectedReports: 0},
+	}
+
+	for _, testCase := range testCases {
+		mockDBStore := NewMockStore()
+		mockDBStore.GetPreviousUploadFunc.SetDefaultReturn(previous, testCase.hasPrevious, nil)
+		mockLSIFStore := NewMockLsifStore()
+		mockLSIFStore.GetDocumentSummariesFunc.SetDefaultHook(func(ctx context.Context, uploadID int) (map[string]shared.DocumentSummary, error) {
+			return summaries[uploadID], nil
+		})
+
+		counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_symbol_count_regressions_total"})
+		svc := &handler{
+			store:                          mockDBStore,
+			lsifstore:                      mockLSIFStore,
+			symbolCountRegressionThreshold: testCase.threshold,
+			numSymbolCountRegressions:      counter,
+		}
+		svc.checkSymbolCountRegression(context.Background(), logtest.Scoped(t), upload)
+
+		if value := testutil.ToFloat64(counter); value != testCase.expectedReports {
+			t.Errorf("unexpected number of reported regressions with threshold %.2f. want=%.0f have=%.0f", testCase.threshold, testCase.expectedReports, value)
+		}
+	}
+}
+
 func copyTestDump(ctx context.Context, key string) (io.ReadCloser, error) {
 	return os.Open("./testdata/dump1.lsif.gz")
 }
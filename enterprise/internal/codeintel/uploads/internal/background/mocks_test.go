@@ -2431,6 +2431,9 @@ type MockStore struct {
 	// GetOldestCommitDateFunc is an instance of a mock function object
 	// controlling the behavior of the method GetOldestCommitDate.
 	GetOldestCommitDateFunc *StoreGetOldestCommitDateFunc
+	// GetPreviousUploadFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPreviousUpload.
+	GetPreviousUploadFunc *StoreGetPreviousUploadFunc
 	// GetRecentUploadsSummaryFunc is an instance of a mock function object
 	// controlling the behavior of the method GetRecentUploadsSummary.
 	GetRecentUploadsSummaryFunc *StoreGetRecentUploadsSummaryFunc
@@ -2482,6 +2485,9 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// InsertDependencySyncingJob.
 	InsertDependencySyncingJobFunc *StoreInsertDependencySyncingJobFunc
+	// InsertSymbolsForRankingFunc is an instance of a mock function object
+	// controlling the behavior of the method InsertSymbolsForRanking.
+	InsertSymbolsForRankingFunc *StoreInsertSymbolsForRankingFunc
 	// InsertUploadFunc is an instance of a mock function object controlling
 	// the behavior of the method InsertUpload.
 	InsertUploadFunc *StoreInsertUploadFunc
@@ -2551,6 +2557,10 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// UpdateUploadsVisibleToCommits.
 	UpdateUploadsVisibleToCommitsFunc *StoreUpdateUploadsVisibleToCommitsFunc
+	// VacuumStaleRankingSymbolsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// VacuumStaleRankingSymbols.
+	VacuumStaleRankingSymbolsFunc *StoreVacuumStaleRankingSymbolsFunc
 	// WorkerutilStoreFunc is an instance of a mock function object
 	// controlling the behavior of the method WorkerutilStore.
 	WorkerutilStoreFunc *StoreWorkerutilStoreFunc
@@ -2655,6 +2665,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetPreviousUploadFunc: &StoreGetPreviousUploadFunc{
+			defaultHook: func(context.Context, types.Upload) (r0 types.Upload, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetRecentUploadsSummaryFunc: &StoreGetRecentUploadsSummaryFunc{
 			defaultHook: func(context.Context, int) (r0 []shared1.UploadsWithRepositoryNamespace, r1 error) {
 				return
@@ -2730,6 +2745,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		InsertSymbolsForRankingFunc: &StoreInsertSymbolsForRankingFunc{
+			defaultHook: func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) (r0 error) {
+				return
+			},
+		},
 		InsertUploadFunc: &StoreInsertUploadFunc{
 			defaultHook: func(context.Context, types.Upload) (r0 int, r1 error) {
 				return
@@ -2835,6 +2855,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		VacuumStaleRankingSymbolsFunc: &StoreVacuumStaleRankingSymbolsFunc{
+			defaultHook: func(context.Context, string, int) (r0 int, r1 error) {
+				return
+			},
+		},
 		WorkerutilStoreFunc: &StoreWorkerutilStoreFunc{
 			defaultHook: func(*observation.Context) (r0 store1.Store[types.Upload]) {
 				return
@@ -2942,6 +2967,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetOldestCommitDate")
 			},
 		},
+		GetPreviousUploadFunc: &StoreGetPreviousUploadFunc{
+			defaultHook: func(context.Context, types.Upload) (types.Upload, bool, error) {
+				panic("unexpected invocation of MockStore.GetPreviousUpload")
+			},
+		},
 		GetRecentUploadsSummaryFunc: &StoreGetRecentUploadsSummaryFunc{
 			defaultHook: func(context.Context, int) ([]shared1.UploadsWithRepositoryNamespace, error) {
 				panic("unexpected invocation of MockStore.GetRecentUploadsSummary")
@@ -3017,6 +3047,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.InsertDependencySyncingJob")
 			},
 		},
+		InsertSymbolsForRankingFunc: &StoreInsertSymbolsForRankingFunc{
+			defaultHook: func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error {
+				panic("unexpected invocation of MockStore.InsertSymbolsForRanking")
+			},
+		},
 		InsertUploadFunc: &StoreInsertUploadFunc{
 			defaultHook: func(context.Context, types.Upload) (int, error) {
 				panic("unexpected invocation of MockStore.InsertUpload")
@@ -3122,6 +3157,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.UpdateUploadsVisibleToCommits")
 			},
 		},
+		VacuumStaleRankingSymbolsFunc: &StoreVacuumStaleRankingSymbolsFunc{
+			defaultHook: func(context.Context, string, int) (int, error) {
+				panic("unexpected invocation of MockStore.VacuumStaleRankingSymbols")
+			},
+		},
 		WorkerutilStoreFunc: &StoreWorkerutilStoreFunc{
 			defaultHook: func(*observation.Context) store1.Store[types.Upload] {
 				panic("unexpected invocation of MockStore.WorkerutilStore")
@@ -3191,6 +3231,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetOldestCommitDateFunc: &StoreGetOldestCommitDateFunc{
 			defaultHook: i.GetOldestCommitDate,
 		},
+		GetPreviousUploadFunc: &StoreGetPreviousUploadFunc{
+			defaultHook: i.GetPreviousUpload,
+		},
 		GetRecentUploadsSummaryFunc: &StoreGetRecentUploadsSummaryFunc{
 			defaultHook: i.GetRecentUploadsSummary,
 		},
@@ -3236,6 +3279,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		InsertDependencySyncingJobFunc: &StoreInsertDependencySyncingJobFunc{
 			defaultHook: i.InsertDependencySyncingJob,
 		},
+		InsertSymbolsForRankingFunc: &StoreInsertSymbolsForRankingFunc{
+			defaultHook: i.InsertSymbolsForRanking,
+		},
 		InsertUploadFunc: &StoreInsertUploadFunc{
 			defaultHook: i.InsertUpload,
 		},
@@ -3299,6 +3345,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		UpdateUploadsVisibleToCommitsFunc: &StoreUpdateUploadsVisibleToCommitsFunc{
 			defaultHook: i.UpdateUploadsVisibleToCommits,
 		},
+		VacuumStaleRankingSymbolsFunc: &StoreVacuumStaleRankingSymbolsFunc{
+			defaultHook: i.VacuumStaleRankingSymbols,
+		},
 		WorkerutilStoreFunc: &StoreWorkerutilStoreFunc{
 			defaultHook: i.WorkerutilStore,
 		},
@@ -5427,6 +5476,117 @@ func (c StoreGetOldestCommitDateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// StoreGetPreviousUploadFunc describes the behavior when the
+// GetPreviousUpload method of the parent MockStore instance is invoked.
+type StoreGetPreviousUploadFunc struct {
+	defaultHook func(context.Context, types.Upload) (types.Upload, bool, error)
+	hooks       []func(context.Context, types.Upload) (types.Upload, bool, error)
+	history     []StoreGetPreviousUploadFuncCall
+	mutex       sync.Mutex
+}
+
+// GetPreviousUpload delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetPreviousUpload(v0 context.Context, v1 types.Upload) (types.Upload, bool, error) {
+	r0, r1, r2 := m.GetPreviousUploadFunc.nextHook()(v0, v1)
+	m.GetPreviousUploadFunc.appendCall(StoreGetPreviousUploadFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetPreviousUpload
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetPreviousUploadFunc) SetDefaultHook(hook func(context.Context, types.Upload) (types.Upload, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetPreviousUpload method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreGetPreviousUploadFunc) PushHook(hook func(context.Context, types.Upload) (types.Upload, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetPreviousUploadFunc) SetDefaultReturn(r0 types.Upload, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, types.Upload) (types.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetPreviousUploadFunc) PushReturn(r0 types.Upload, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, types.Upload) (types.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetPreviousUploadFunc) nextHook() func(context.Context, types.Upload) (types.Upload, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetPreviousUploadFunc) appendCall(r0 StoreGetPreviousUploadFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetPreviousUploadFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetPreviousUploadFunc) History() []StoreGetPreviousUploadFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetPreviousUploadFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetPreviousUploadFuncCall is an object that describes an invocation
+// of method GetPreviousUpload on an instance of MockStore.
+type StoreGetPreviousUploadFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.Upload
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 types.Upload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetPreviousUploadFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetPreviousUploadFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreGetRecentUploadsSummaryFunc describes the behavior when the
 // GetRecentUploadsSummary method of the parent MockStore instance is
 // invoked.
@@ -7158,6 +7318,121 @@ func (c StoreInsertDependencySyncingJobFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreInsertSymbolsForRankingFunc describes the behavior when the
+// InsertSymbolsForRanking method of the parent MockStore instance is
+// invoked.
+type StoreInsertSymbolsForRankingFunc struct {
+	defaultHook func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error
+	hooks       []func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error
+	history     []StoreInsertSymbolsForRankingFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertSymbolsForRanking delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) InsertSymbolsForRanking(v0 context.Context, v1 store.ExportedUpload, v2 string, v3 []store.RankingDefinition, v4 map[string]int) error {
+	r0 := m.InsertSymbolsForRankingFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.InsertSymbolsForRankingFunc.appendCall(StoreInsertSymbolsForRankingFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertSymbolsForRanking method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreInsertSymbolsForRankingFunc) SetDefaultHook(hook func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertSymbolsForRanking method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreInsertSymbolsForRankingFunc) PushHook(hook func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertSymbolsForRankingFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertSymbolsForRankingFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error {
+		return r0
+	})
+}
+
+func (f *StoreInsertSymbolsForRankingFunc) nextHook() func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertSymbolsForRankingFunc) appendCall(r0 StoreInsertSymbolsForRankingFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertSymbolsForRankingFuncCall
+// objects describing the invocations of this function.
+func (f *StoreInsertSymbolsForRankingFunc) History() []StoreInsertSymbolsForRankingFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertSymbolsForRankingFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertSymbolsForRankingFuncCall is an object that describes an
+// invocation of method InsertSymbolsForRanking on an instance of MockStore.
+type StoreInsertSymbolsForRankingFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.ExportedUpload
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []store.RankingDefinition
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 map[string]int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertSymbolsForRankingFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertSymbolsForRankingFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreInsertUploadFunc describes the behavior when the InsertUpload method
 // of the parent MockStore instance is invoked.
 type StoreInsertUploadFunc struct {
@@ -9487,35 +9762,36 @@ func (c StoreUpdateUploadsVisibleToCommitsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// StoreWorkerutilStoreFunc describes the behavior when the WorkerutilStore
-// method of the parent MockStore instance is invoked.
-type StoreWorkerutilStoreFunc struct {
-	defaultHook func(*observation.Context) store1.Store[types.Upload]
-	hooks       []func(*observation.Context) store1.Store[types.Upload]
-	history     []StoreWorkerutilStoreFuncCall
+// StoreVacuumStaleRankingSymbolsFunc describes the behavior when the
+// VacuumStaleRankingSymbols method of the parent MockStore instance is
+// invoked.
+type StoreVacuumStaleRankingSymbolsFunc struct {
+	defaultHook func(context.Context, string, int) (int, error)
+	hooks       []func(context.Context, string, int) (int, error)
+	history     []StoreVacuumStaleRankingSymbolsFuncCall
 	mutex       sync.Mutex
 }
 
-// WorkerutilStore delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockStore) WorkerutilStore(v0 *observation.Context) store1.Store[types.Upload] {
-	r0 := m.WorkerutilStoreFunc.nextHook()(v0)
-	m.WorkerutilStoreFunc.appendCall(StoreWorkerutilStoreFuncCall{v0, r0})
-	return r0
+// VacuumStaleRankingSymbols delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) VacuumStaleRankingSymbols(v0 context.Context, v1 string, v2 int) (int, error) {
+	r0, r1 := m.VacuumStaleRankingSymbolsFunc.nextHook()(v0, v1, v2)
+	m.VacuumStaleRankingSymbolsFunc.appendCall(StoreVacuumStaleRankingSymbolsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the WorkerutilStore
-// method of the parent MockStore instance is invoked and the hook queue is
-// empty.
-func (f *StoreWorkerutilStoreFunc) SetDefaultHook(hook func(*observation.Context) store1.Store[types.Upload]) {
+// SetDefaultHook sets function that is called when the
+// VacuumStaleRankingSymbols method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreVacuumStaleRankingSymbolsFunc) SetDefaultHook(hook func(context.Context, string, int) (int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// WorkerutilStore method of the parent MockStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *StoreWorkerutilStoreFunc) PushHook(hook func(*observation.Context) store1.Store[types.Upload]) {
+// VacuumStaleRankingSymbols method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreVacuumStaleRankingSymbolsFunc) PushHook(hook func(context.Context, string, int) (int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -9523,20 +9799,20 @@ func (f *StoreWorkerutilStoreFunc) PushHook(hook func(*observation.Context) stor
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreWorkerutilStoreFunc) SetDefaultReturn(r0 store1.Store[types.Upload]) {
-	f.SetDefaultHook(func(*observation.Context) store1.Store[types.Upload] {
-		return r0
+func (f *StoreVacuumStaleRankingSymbolsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreWorkerutilStoreFunc) PushReturn(r0 store1.Store[types.Upload]) {
-	f.PushHook(func(*observation.Context) store1.Store[types.Upload] {
-		return r0
+func (f *StoreVacuumStaleRankingSymbolsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
 	})
 }
 
-func (f *StoreWorkerutilStoreFunc) nextHook() func(*observation.Context) store1.Store[types.Upload] {
+func (f *StoreVacuumStaleRankingSymbolsFunc) nextHook() func(context.Context, string, int) (int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -9549,51 +9825,163 @@ func (f *StoreWorkerutilStoreFunc) nextHook() func(*observation.Context) store1.
 	return hook
 }
 
-func (f *StoreWorkerutilStoreFunc) appendCall(r0 StoreWorkerutilStoreFuncCall) {
+func (f *StoreVacuumStaleRankingSymbolsFunc) appendCall(r0 StoreVacuumStaleRankingSymbolsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreWorkerutilStoreFuncCall objects
-// describing the invocations of this function.
-func (f *StoreWorkerutilStoreFunc) History() []StoreWorkerutilStoreFuncCall {
+// History returns a sequence of StoreVacuumStaleRankingSymbolsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreVacuumStaleRankingSymbolsFunc) History() []StoreVacuumStaleRankingSymbolsFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreWorkerutilStoreFuncCall, len(f.history))
+	history := make([]StoreVacuumStaleRankingSymbolsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreWorkerutilStoreFuncCall is an object that describes an invocation of
-// method WorkerutilStore on an instance of MockStore.
-type StoreWorkerutilStoreFuncCall struct {
+// StoreVacuumStaleRankingSymbolsFuncCall is an object that describes an
+// invocation of method VacuumStaleRankingSymbols on an instance of
+// MockStore.
+type StoreVacuumStaleRankingSymbolsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 *observation.Context
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 store1.Store[types.Upload]
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreWorkerutilStoreFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c StoreVacuumStaleRankingSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreWorkerutilStoreFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c StoreVacuumStaleRankingSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// MockRepoStore is a mock implementation of the RepoStore interface (from
-// the package
-// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/background)
-// used for unit testing.
-type MockRepoStore struct {
+// StoreWorkerutilStoreFunc describes the behavior when the WorkerutilStore
+// method of the parent MockStore instance is invoked.
+type StoreWorkerutilStoreFunc struct {
+	defaultHook func(*observation.Context) store1.Store[types.Upload]
+	hooks       []func(*observation.Context) store1.Store[types.Upload]
+	history     []StoreWorkerutilStoreFuncCall
+	mutex       sync.Mutex
+}
+
+// WorkerutilStore delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) WorkerutilStore(v0 *observation.Context) store1.Store[types.Upload] {
+	r0 := m.WorkerutilStoreFunc.nextHook()(v0)
+	m.WorkerutilStoreFunc.appendCall(StoreWorkerutilStoreFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the WorkerutilStore
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreWorkerutilStoreFunc) SetDefaultHook(hook func(*observation.Context) store1.Store[types.Upload]) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WorkerutilStore method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreWorkerutilStoreFunc) PushHook(hook func(*observation.Context) store1.Store[types.Upload]) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreWorkerutilStoreFunc) SetDefaultReturn(r0 store1.Store[types.Upload]) {
+	f.SetDefaultHook(func(*observation.Context) store1.Store[types.Upload] {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreWorkerutilStoreFunc) PushReturn(r0 store1.Store[types.Upload]) {
+	f.PushHook(func(*observation.Context) store1.Store[types.Upload] {
+		return r0
+	})
+}
+
+func (f *StoreWorkerutilStoreFunc) nextHook() func(*observation.Context) store1.Store[types.Upload] {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreWorkerutilStoreFunc) appendCall(r0 StoreWorkerutilStoreFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreWorkerutilStoreFuncCall objects
+// describing the invocations of this function.
+func (f *StoreWorkerutilStoreFunc) History() []StoreWorkerutilStoreFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreWorkerutilStoreFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreWorkerutilStoreFuncCall is an object that describes an invocation of
+// method WorkerutilStore on an instance of MockStore.
+type StoreWorkerutilStoreFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 *observation.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 store1.Store[types.Upload]
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreWorkerutilStoreFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreWorkerutilStoreFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockRepoStore is a mock implementation of the RepoStore interface (from
+// the package
+// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/background)
+// used for unit testing.
+type MockRepoStore struct {
 	// GetFunc is an instance of a mock function object controlling the
 	// behavior of the method Get.
 	GetFunc *RepoStoreGetFunc
@@ -9882,6 +10270,12 @@ type MockLsifStore struct {
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *LsifStoreDoneFunc
+	// GetDocumentSummariesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDocumentSummaries.
+	GetDocumentSummariesFunc *LsifStoreGetDocumentSummariesFunc
+	// GetMissingDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetMissingDefinitions.
+	GetMissingDefinitionsFunc *LsifStoreGetMissingDefinitionsFunc
 	// GetUploadDocumentsForPathFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// GetUploadDocumentsForPath.
@@ -9889,6 +10283,9 @@ type MockLsifStore struct {
 	// IDsWithMetaFunc is an instance of a mock function object controlling
 	// the behavior of the method IDsWithMeta.
 	IDsWithMetaFunc *LsifStoreIDsWithMetaFunc
+	// InsertDocumentSummariesFunc is an instance of a mock function object
+	// controlling the behavior of the method InsertDocumentSummaries.
+	InsertDocumentSummariesFunc *LsifStoreInsertDocumentSummariesFunc
 	// InsertMetadataFunc is an instance of a mock function object
 	// controlling the behavior of the method InsertMetadata.
 	InsertMetadataFunc *LsifStoreInsertMetadataFunc
@@ -9907,6 +10304,9 @@ type MockLsifStore struct {
 	// ScanResultChunksFunc is an instance of a mock function object
 	// controlling the behavior of the method ScanResultChunks.
 	ScanResultChunksFunc *LsifStoreScanResultChunksFunc
+	// ScanSCIPDocumentsFunc is an instance of a mock function object
+	// controlling the behavior of the method ScanSCIPDocuments.
+	ScanSCIPDocumentsFunc *LsifStoreScanSCIPDocumentsFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *LsifStoreTransactFunc
@@ -9949,6 +10349,16 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetDocumentSummariesFunc: &LsifStoreGetDocumentSummariesFunc{
+			defaultHook: func(context.Context, int) (r0 map[string]shared1.DocumentSummary, r1 error) {
+				return
+			},
+		},
+		GetMissingDefinitionsFunc: &LsifStoreGetMissingDefinitionsFunc{
+			defaultHook: func(context.Context, int, int) (r0 []shared1.MissingDefinition, r1 error) {
+				return
+			},
+		},
 		GetUploadDocumentsForPathFunc: &LsifStoreGetUploadDocumentsForPathFunc{
 			defaultHook: func(context.Context, int, string) (r0 []string, r1 int, r2 error) {
 				return
@@ -9959,6 +10369,11 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		InsertDocumentSummariesFunc: &LsifStoreInsertDocumentSummariesFunc{
+			defaultHook: func(context.Context, int, map[string]shared1.DocumentSummary) (r0 error) {
+				return
+			},
+		},
 		InsertMetadataFunc: &LsifStoreInsertMetadataFunc{
 			defaultHook: func(context.Context, int, lsifstore.ProcessedMetadata) (r0 error) {
 				return
@@ -9989,6 +10404,11 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		ScanSCIPDocumentsFunc: &LsifStoreScanSCIPDocumentsFunc{
+			defaultHook: func(context.Context, int, func(path string, document *scip.Document) error) (r0 error) {
+				return
+			},
+		},
 		TransactFunc: &LsifStoreTransactFunc{
 			defaultHook: func(context.Context) (r0 lsifstore.LsifStore, r1 error) {
 				return
@@ -10046,6 +10466,16 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.Done")
 			},
 		},
+		GetDocumentSummariesFunc: &LsifStoreGetDocumentSummariesFunc{
+			defaultHook: func(context.Context, int) (map[string]shared1.DocumentSummary, error) {
+				panic("unexpected invocation of MockLsifStore.GetDocumentSummaries")
+			},
+		},
+		GetMissingDefinitionsFunc: &LsifStoreGetMissingDefinitionsFunc{
+			defaultHook: func(context.Context, int, int) ([]shared1.MissingDefinition, error) {
+				panic("unexpected invocation of MockLsifStore.GetMissingDefinitions")
+			},
+		},
 		GetUploadDocumentsForPathFunc: &LsifStoreGetUploadDocumentsForPathFunc{
 			defaultHook: func(context.Context, int, string) ([]string, int, error) {
 				panic("unexpected invocation of MockLsifStore.GetUploadDocumentsForPath")
@@ -10056,6 +10486,11 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.IDsWithMeta")
 			},
 		},
+		InsertDocumentSummariesFunc: &LsifStoreInsertDocumentSummariesFunc{
+			defaultHook: func(context.Context, int, map[string]shared1.DocumentSummary) error {
+				panic("unexpected invocation of MockLsifStore.InsertDocumentSummaries")
+			},
+		},
 		InsertMetadataFunc: &LsifStoreInsertMetadataFunc{
 			defaultHook: func(context.Context, int, lsifstore.ProcessedMetadata) error {
 				panic("unexpected invocation of MockLsifStore.InsertMetadata")
@@ -10086,6 +10521,11 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.ScanResultChunks")
 			},
 		},
+		ScanSCIPDocumentsFunc: &LsifStoreScanSCIPDocumentsFunc{
+			defaultHook: func(context.Context, int, func(path string, document *scip.Document) error) error {
+				panic("unexpected invocation of MockLsifStore.ScanSCIPDocuments")
+			},
+		},
 		TransactFunc: &LsifStoreTransactFunc{
 			defaultHook: func(context.Context) (lsifstore.LsifStore, error) {
 				panic("unexpected invocation of MockLsifStore.Transact")
@@ -10137,12 +10577,21 @@ func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
 		DoneFunc: &LsifStoreDoneFunc{
 			defaultHook: i.Done,
 		},
+		GetDocumentSummariesFunc: &LsifStoreGetDocumentSummariesFunc{
+			defaultHook: i.GetDocumentSummaries,
+		},
+		GetMissingDefinitionsFunc: &LsifStoreGetMissingDefinitionsFunc{
+			defaultHook: i.GetMissingDefinitions,
+		},
 		GetUploadDocumentsForPathFunc: &LsifStoreGetUploadDocumentsForPathFunc{
 			defaultHook: i.GetUploadDocumentsForPath,
 		},
 		IDsWithMetaFunc: &LsifStoreIDsWithMetaFunc{
 			defaultHook: i.IDsWithMeta,
 		},
+		InsertDocumentSummariesFunc: &LsifStoreInsertDocumentSummariesFunc{
+			defaultHook: i.InsertDocumentSummaries,
+		},
 		InsertMetadataFunc: &LsifStoreInsertMetadataFunc{
 			defaultHook: i.InsertMetadata,
 		},
@@ -10161,6 +10610,9 @@ func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
 		ScanResultChunksFunc: &LsifStoreScanResultChunksFunc{
 			defaultHook: i.ScanResultChunks,
 		},
+		ScanSCIPDocumentsFunc: &LsifStoreScanSCIPDocumentsFunc{
+			defaultHook: i.ScanSCIPDocuments,
+		},
 		TransactFunc: &LsifStoreTransactFunc{
 			defaultHook: i.Transact,
 		},
@@ -10519,6 +10971,229 @@ func (c LsifStoreDoneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// LsifStoreGetDocumentSummariesFunc describes the behavior when the
+// GetDocumentSummaries method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreGetDocumentSummariesFunc struct {
+	defaultHook func(context.Context, int) (map[string]shared1.DocumentSummary, error)
+	hooks       []func(context.Context, int) (map[string]shared1.DocumentSummary, error)
+	history     []LsifStoreGetDocumentSummariesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDocumentSummaries delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetDocumentSummaries(v0 context.Context, v1 int) (map[string]shared1.DocumentSummary, error) {
+	r0, r1 := m.GetDocumentSummariesFunc.nextHook()(v0, v1)
+	m.GetDocumentSummariesFunc.appendCall(LsifStoreGetDocumentSummariesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDocumentSummaries
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreGetDocumentSummariesFunc) SetDefaultHook(hook func(context.Context, int) (map[string]shared1.DocumentSummary, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDocumentSummaries method of the parent MockLsifStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LsifStoreGetDocumentSummariesFunc) PushHook(hook func(context.Context, int) (map[string]shared1.DocumentSummary, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetDocumentSummariesFunc) SetDefaultReturn(r0 map[string]shared1.DocumentSummary, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (map[string]shared1.DocumentSummary, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetDocumentSummariesFunc) PushReturn(r0 map[string]shared1.DocumentSummary, r1 error) {
+	f.PushHook(func(context.Context, int) (map[string]shared1.DocumentSummary, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetDocumentSummariesFunc) nextHook() func(context.Context, int) (map[string]shared1.DocumentSummary, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetDocumentSummariesFunc) appendCall(r0 LsifStoreGetDocumentSummariesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetDocumentSummariesFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreGetDocumentSummariesFunc) History() []LsifStoreGetDocumentSummariesFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetDocumentSummariesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetDocumentSummariesFuncCall is an object that describes an
+// invocation of method GetDocumentSummaries on an instance of
+// MockLsifStore.
+type LsifStoreGetDocumentSummariesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]shared1.DocumentSummary
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetDocumentSummariesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetDocumentSummariesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LsifStoreGetMissingDefinitionsFunc describes the behavior when the
+// GetMissingDefinitions method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreGetMissingDefinitionsFunc struct {
+	defaultHook func(context.Context, int, int) ([]shared1.MissingDefinition, error)
+	hooks       []func(context.Context, int, int) ([]shared1.MissingDefinition, error)
+	history     []LsifStoreGetMissingDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetMissingDefinitions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetMissingDefinitions(v0 context.Context, v1 int, v2 int) ([]shared1.MissingDefinition, error) {
+	r0, r1 := m.GetMissingDefinitionsFunc.nextHook()(v0, v1, v2)
+	m.GetMissingDefinitionsFunc.appendCall(LsifStoreGetMissingDefinitionsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetMissingDefinitions method of the parent MockLsifStore instance is
+// invoked and the hook queue is empty.
+func (f *LsifStoreGetMissingDefinitionsFunc) SetDefaultHook(hook func(context.Context, int, int) ([]shared1.MissingDefinition, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetMissingDefinitions method of the parent MockLsifStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LsifStoreGetMissingDefinitionsFunc) PushHook(hook func(context.Context, int, int) ([]shared1.MissingDefinition, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetMissingDefinitionsFunc) SetDefaultReturn(r0 []shared1.MissingDefinition, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, int) ([]shared1.MissingDefinition, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetMissingDefinitionsFunc) PushReturn(r0 []shared1.MissingDefinition, r1 error) {
+	f.PushHook(func(context.Context, int, int) ([]shared1.MissingDefinition, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetMissingDefinitionsFunc) nextHook() func(context.Context, int, int) ([]shared1.MissingDefinition, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetMissingDefinitionsFunc) appendCall(r0 LsifStoreGetMissingDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetMissingDefinitionsFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreGetMissingDefinitionsFunc) History() []LsifStoreGetMissingDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetMissingDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetMissingDefinitionsFuncCall is an object that describes an
+// invocation of method GetMissingDefinitions on an instance of
+// MockLsifStore.
+type LsifStoreGetMissingDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared1.MissingDefinition
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetMissingDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetMissingDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LsifStoreGetUploadDocumentsForPathFunc describes the behavior when the
 // GetUploadDocumentsForPath method of the parent MockLsifStore instance is
 // invoked.
@@ -10744,6 +11419,117 @@ func (c LsifStoreIDsWithMetaFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// LsifStoreInsertDocumentSummariesFunc describes the behavior when the
+// InsertDocumentSummaries method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreInsertDocumentSummariesFunc struct {
+	defaultHook func(context.Context, int, map[string]shared1.DocumentSummary) error
+	hooks       []func(context.Context, int, map[string]shared1.DocumentSummary) error
+	history     []LsifStoreInsertDocumentSummariesFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertDocumentSummaries delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockLsifStore) InsertDocumentSummaries(v0 context.Context, v1 int, v2 map[string]shared1.DocumentSummary) error {
+	r0 := m.InsertDocumentSummariesFunc.nextHook()(v0, v1, v2)
+	m.InsertDocumentSummariesFunc.appendCall(LsifStoreInsertDocumentSummariesFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertDocumentSummaries method of the parent MockLsifStore instance is
+// invoked and the hook queue is empty.
+func (f *LsifStoreInsertDocumentSummariesFunc) SetDefaultHook(hook func(context.Context, int, map[string]shared1.DocumentSummary) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertDocumentSummaries method of the parent MockLsifStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *LsifStoreInsertDocumentSummariesFunc) PushHook(hook func(context.Context, int, map[string]shared1.DocumentSummary) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreInsertDocumentSummariesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, map[string]shared1.DocumentSummary) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreInsertDocumentSummariesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, map[string]shared1.DocumentSummary) error {
+		return r0
+	})
+}
+
+func (f *LsifStoreInsertDocumentSummariesFunc) nextHook() func(context.Context, int, map[string]shared1.DocumentSummary) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreInsertDocumentSummariesFunc) appendCall(r0 LsifStoreInsertDocumentSummariesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreInsertDocumentSummariesFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreInsertDocumentSummariesFunc) History() []LsifStoreInsertDocumentSummariesFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreInsertDocumentSummariesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreInsertDocumentSummariesFuncCall is an object that describes an
+// invocation of method InsertDocumentSummaries on an instance of
+// MockLsifStore.
+type LsifStoreInsertDocumentSummariesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 map[string]shared1.DocumentSummary
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreInsertDocumentSummariesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreInsertDocumentSummariesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // LsifStoreInsertMetadataFunc describes the behavior when the
 // InsertMetadata method of the parent MockLsifStore instance is invoked.
 type LsifStoreInsertMetadataFunc struct {
@@ -11393,6 +12179,114 @@ func (c LsifStoreScanResultChunksFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// LsifStoreScanSCIPDocumentsFunc describes the behavior when the
+// ScanSCIPDocuments method of the parent MockLsifStore instance is invoked.
+type LsifStoreScanSCIPDocumentsFunc struct {
+	defaultHook func(context.Context, int, func(path string, document *scip.Document) error) error
+	hooks       []func(context.Context, int, func(path string, document *scip.Document) error) error
+	history     []LsifStoreScanSCIPDocumentsFuncCall
+	mutex       sync.Mutex
+}
+
+// ScanSCIPDocuments delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) ScanSCIPDocuments(v0 context.Context, v1 int, v2 func(path string, document *scip.Document) error) error {
+	r0 := m.ScanSCIPDocumentsFunc.nextHook()(v0, v1, v2)
+	m.ScanSCIPDocumentsFunc.appendCall(LsifStoreScanSCIPDocumentsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ScanSCIPDocuments
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreScanSCIPDocumentsFunc) SetDefaultHook(hook func(context.Context, int, func(path string, document *scip.Document) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ScanSCIPDocuments method of the parent MockLsifStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LsifStoreScanSCIPDocumentsFunc) PushHook(hook func(context.Context, int, func(path string, document *scip.Document) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreScanSCIPDocumentsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, func(path string, document *scip.Document) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreScanSCIPDocumentsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, func(path string, document *scip.Document) error) error {
+		return r0
+	})
+}
+
+func (f *LsifStoreScanSCIPDocumentsFunc) nextHook() func(context.Context, int, func(path string, document *scip.Document) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreScanSCIPDocumentsFunc) appendCall(r0 LsifStoreScanSCIPDocumentsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreScanSCIPDocumentsFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreScanSCIPDocumentsFunc) History() []LsifStoreScanSCIPDocumentsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreScanSCIPDocumentsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreScanSCIPDocumentsFuncCall is an object that describes an
+// invocation of method ScanSCIPDocuments on an instance of MockLsifStore.
+type LsifStoreScanSCIPDocumentsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(path string, document *scip.Document) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreScanSCIPDocumentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreScanSCIPDocumentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // LsifStoreTransactFunc describes the behavior when the Transact method of
 // the parent MockLsifStore instance is invoked.
 type LsifStoreTransactFunc struct {
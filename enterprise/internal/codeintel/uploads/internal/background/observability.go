@@ -17,6 +17,8 @@ type operations struct {
 	uploadProcessor *observation.Operation
 	uploadSizeGuage prometheus.Gauge
 
+	numSymbolCountRegressions prometheus.Counter
+
 	numReconcileScansFromFrontend      prometheus.Counter
 	numReconcileDeletesFromFrontend    prometheus.Counter
 	numReconcileScansFromCodeIntelDB   prometheus.Counter
@@ -70,6 +72,11 @@ func newOperations(observationCtx *observation.Context) *operations {
 		"The number of abandoned uploads deleted from the codeintel-db.",
 	)
 
+	numSymbolCountRegressions := counter(
+		"src_codeintel_upload_symbol_count_regressions_total",
+		"The number of processed uploads defining significantly fewer symbols than the previous upload for the same repository, root, and indexer.",
+	)
+
 	honeyobservationCtx := *observationCtx
 	honeyobservationCtx.HoneyDataset = &honey.Dataset{Name: "codeintel-worker"}
 	uploadProcessor := honeyobservationCtx.Operation(observation.Op{
@@ -92,6 +99,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 		uploadProcessor: uploadProcessor,
 		uploadSizeGuage: uploadSizeGuage,
 
+		numSymbolCountRegressions: numSymbolCountRegressions,
+
 		numReconcileScansFromFrontend:      numReconcileScansFromFrontend,
 		numReconcileDeletesFromFrontend:    numReconcileDeletesFromFrontend,
 		numReconcileScansFromCodeIntelDB:   numReconcileScansFromCodeIntelDB,
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
 	codeinteltypes "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/lsifstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
@@ -326,10 +327,12 @@ func writeSCIPData(
 	}
 
 	var numDocuments uint32
+	summaries := map[string]shared.DocumentSummary{}
 	for document := range correlatedSCIPData.Documents {
 		if err := scipWriter.InsertDocument(ctx, document.Path, document.Document); err != nil {
 			return err
 		}
+		summaries[document.Path] = summarizeSCIPDocument(document.Document)
 
 		numDocuments += 1
 	}
@@ -341,9 +344,33 @@ func writeSCIPData(
 	}
 	trace.Log(otlog.Uint32("numSymbols", count))
 
+	if err := tx.InsertDocumentSummaries(ctx, upload.ID, summaries); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// summarizeSCIPDocument counts the occurrences and the defined non-local symbols of the given
+// document.
+func summarizeSCIPDocument(document *scip.Document) shared.DocumentSummary {
+	definitions := map[string]struct{}{}
+	for _, occurrence := range document.Occurrences {
+		if occurrence.Symbol == "" || scip.IsLocalSymbol(occurrence.Symbol) {
+			continue
+		}
+
+		if occurrence.SymbolRoles&int32(scip.SymbolRole_Definition) != 0 {
+			definitions[occurrence.Symbol] = struct{}{}
+		}
+	}
+
+	return shared.DocumentSummary{
+		Occurrences: len(document.Occurrences),
+		Definitions: len(definitions),
+	}
+}
+
 // comparePackages returns true if pi sorts lower than pj.
 func comparePackages(pi, pj precise.Package) bool {
 	if pi.Scheme == pj.Scheme {
@@ -7,6 +7,7 @@ import (
 	"github.com/sourcegraph/scip/bindings/go/scip"
 
 	codeintelshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
@@ -17,10 +18,13 @@ type LsifStore interface {
 	Done(err error) error
 
 	GetUploadDocumentsForPath(ctx context.Context, bundleID int, pathPattern string) ([]string, int, error)
+	GetDocumentSummaries(ctx context.Context, uploadID int) (map[string]shared.DocumentSummary, error)
+	GetMissingDefinitions(ctx context.Context, baseUploadID, headUploadID int) ([]shared.MissingDefinition, error)
 	DeleteLsifDataByUploadIds(ctx context.Context, bundleIDs ...int) (err error)
 
 	InsertMetadata(ctx context.Context, uploadID int, meta ProcessedMetadata) error
 	NewSCIPWriter(ctx context.Context, uploadID int) (SCIPWriter, error)
+	InsertDocumentSummaries(ctx context.Context, uploadID int, summaries map[string]shared.DocumentSummary) error
 
 	WriteMeta(ctx context.Context, bundleID int, meta precise.MetaData) error
 	WriteDocuments(ctx context.Context, bundleID int, documents chan precise.KeyedDocumentData) (count uint32, err error)
@@ -37,6 +41,7 @@ type LsifStore interface {
 	ScanDocuments(ctx context.Context, id int, f func(path string, ranges map[precise.ID]precise.RangeData) error) (err error)
 	ScanResultChunks(ctx context.Context, id int, f func(idx int, resultChunk precise.ResultChunkData) error) (err error)
 	ScanLocations(ctx context.Context, id int, f func(scheme, identifier, monikerType string, locations []precise.LocationData) error) (err error)
+	ScanSCIPDocuments(ctx context.Context, id int, f func(path string, document *scip.Document) error) (err error)
 }
 
 type SCIPWriter interface {
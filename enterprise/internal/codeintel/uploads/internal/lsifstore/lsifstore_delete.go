@@ -37,6 +37,10 @@ func (s *store) DeleteLsifDataByUploadIds(ctx context.Context, bundleIDs ...int)
 		return err
 	}
 
+	if err := s.db.Exec(ctx, sqlf.Sprintf(deleteDocumentSummariesQuery, pq.Array(bundleIDs))); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -51,6 +55,17 @@ WITH locked_rows AS (
 DELETE FROM codeintel_last_reconcile WHERE dump_id IN (SELECT dump_id FROM locked_rows)
 `
 
+const deleteDocumentSummariesQuery = `
+WITH locked_rows AS (
+	SELECT upload_id, document_path
+	FROM codeintel_document_summaries
+	WHERE upload_id = ANY(%s)
+	ORDER BY upload_id, document_path
+	FOR UPDATE
+)
+DELETE FROM codeintel_document_summaries WHERE (upload_id, document_path) IN (SELECT upload_id, document_path FROM locked_rows)
+`
+
 var lsifDataTables = []string{
 	"lsif_data_metadata",
 	"lsif_data_documents",
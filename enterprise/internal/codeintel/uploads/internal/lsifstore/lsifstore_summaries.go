@@ -0,0 +1,191 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/keegancsmith/sqlf"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// InsertDocumentSummaries records the number of definitions and occurrences of each document of
+// the given upload. This is done when the upload is processed, so that uploads can later be
+// compared without reading their documents.
+func (s *store) InsertDocumentSummaries(ctx context.Context, uploadID int, summaries map[string]shared.DocumentSummary) (err error) {
+	ctx, _, endObservation := s.operations.insertDocumentSummaries.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("uploadID", uploadID),
+		otlog.Int("numDocuments", len(summaries)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	paths := make([]string, 0, len(summaries))
+	for path := range summaries {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return batch.WithInserter(
+		ctx,
+		s.db.Handle(),
+		"codeintel_document_summaries",
+		batch.MaxNumPostgresParameters,
+		[]string{"upload_id", "document_path", "definition_count", "occurrence_count"},
+		func(inserter *batch.Inserter) error {
+			for _, path := range paths {
+				summary := summaries[path]
+				if err := inserter.Insert(ctx, uploadID, path, summary.Definitions, summary.Occurrences); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	)
+}
+
+// GetDocumentSummaries returns the number of definitions and occurrences of each document of the
+// given upload, as recorded when it was processed. Uploads processed before summaries were recorded
+// have none.
+func (s *store) GetDocumentSummaries(ctx context.Context, uploadID int) (_ map[string]shared.DocumentSummary, err error) {
+	ctx, trace, endObservation := s.operations.getDocumentSummaries.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	summaries, err := scanDocumentSummaries(s.db.Query(ctx, sqlf.Sprintf(getDocumentSummariesQuery, uploadID)))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(otlog.Int("numDocuments", len(summaries)))
+
+	return summaries, nil
+}
+
+const getDocumentSummariesQuery = `
+SELECT document_path, definition_count, occurrence_count
+FROM codeintel_document_summaries
+WHERE upload_id = %s
+`
+
+var scanDocumentSummaries = basestore.NewMapScanner(func(s dbutil.Scanner) (path string, summary shared.DocumentSummary, _ error) {
+	err := s.Scan(&path, &summary.Definitions, &summary.Occurrences)
+	return path, summary, err
+})
+
+// GetMissingDefinitions returns the symbols defined by the base upload that are no longer defined
+// anywhere in the head upload. Symbols of SCIP uploads are compared by the symbol tables of both
+// uploads; monikers of LSIF uploads are compared by their keys, and only the locations of missing
+// monikers are decoded.
+func (s *store) GetMissingDefinitions(ctx context.Context, baseUploadID, headUploadID int) (_ []shared.MissingDefinition, err error) {
+	ctx, trace, endObservation := s.operations.getMissingDefinitions.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("baseUploadID", baseUploadID),
+		otlog.Int("headUploadID", headUploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	missingDefinitions, err := scanMissingDefinitions(s.db.Query(ctx, sqlf.Sprintf(
+		getMissingSCIPDefinitionsQuery,
+		baseUploadID, headUploadID,
+		baseUploadID,
+		headUploadID,
+	)))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[shared.MissingDefinition]struct{}{}
+	if err := runQuery(ctx, s.db, sqlf.Sprintf(getMissingLSIFDefinitionsQuery, baseUploadID, headUploadID), func(dbs dbutil.Scanner) error {
+		var scheme, identifier string
+		var rawData []byte
+		if err := dbs.Scan(&scheme, &identifier, &rawData); err != nil {
+			return err
+		}
+
+		var locations []precise.LocationData
+		if err := s.serializer.decode(rawData, &locations); err != nil {
+			return err
+		}
+
+		for _, location := range locations {
+			missingDefinition := shared.MissingDefinition{Symbol: scheme + ":" + identifier, Path: location.URI}
+			if _, ok := seen[missingDefinition]; ok {
+				continue
+			}
+			seen[missingDefinition] = struct{}{}
+			missingDefinitions = append(missingDefinitions, missingDefinition)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	trace.Log(otlog.Int("numMissingDefinitions", len(missingDefinitions)))
+
+	return missingDefinitions, nil
+}
+
+const getMissingSCIPDefinitionsQuery = `
+WITH RECURSIVE
+-- Reconstruct the full symbol names from the symbol name tries of both uploads
+symbol_names(upload_id, id, name) AS (
+	(
+		SELECT ssn.upload_id, ssn.id, ssn.name_segment
+		FROM codeintel_scip_symbol_names ssn
+		WHERE
+			ssn.upload_id IN (%s, %s) AND
+			ssn.prefix_id IS NULL
+	) UNION (
+		SELECT ssn.upload_id, ssn.id, sn.name || ssn.name_segment
+		FROM symbol_names sn
+		JOIN codeintel_scip_symbol_names ssn ON
+			ssn.upload_id = sn.upload_id AND
+			ssn.prefix_id = sn.id
+	)
+),
+base_definitions AS (
+	SELECT sn.name, sdl.document_path
+	FROM codeintel_scip_symbols ss
+	JOIN symbol_names sn ON sn.upload_id = ss.upload_id AND sn.id = ss.symbol_id
+	JOIN codeintel_scip_document_lookup sdl ON sdl.id = ss.document_lookup_id
+	WHERE
+		ss.upload_id = %s AND
+		ss.definition_ranges IS NOT NULL
+),
+head_definitions AS (
+	SELECT DISTINCT sn.name
+	FROM codeintel_scip_symbols ss
+	JOIN symbol_names sn ON sn.upload_id = ss.upload_id AND sn.id = ss.symbol_id
+	WHERE
+		ss.upload_id = %s AND
+		ss.definition_ranges IS NOT NULL
+)
+SELECT DISTINCT bd.name, bd.document_path
+FROM base_definitions bd
+WHERE bd.name NOT IN (SELECT name FROM head_definitions)
+`
+
+const getMissingLSIFDefinitionsQuery = `
+SELECT d.scheme, d.identifier, d.data
+FROM lsif_data_definitions d
+WHERE
+	d.dump_id = %s AND
+	NOT EXISTS (
+		SELECT 1
+		FROM lsif_data_definitions h
+		WHERE
+			h.dump_id = %s AND
+			h.scheme = d.scheme AND
+			h.identifier = d.identifier
+	)
+`
+
+var scanMissingDefinitions = basestore.NewSliceScanner(func(s dbutil.Scanner) (missingDefinition shared.MissingDefinition, _ error) {
+	err := s.Scan(&missingDefinition.Symbol, &missingDefinition.Path)
+	return missingDefinition, err
+})
@@ -0,0 +1,114 @@
+package lsifstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	codeintelshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestDocumentSummaries(t *testing.T) {
+	logger := logtest.Scoped(t)
+	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, codeIntelDB)
+	ctx := context.Background()
+
+	expected := map[string]shared.DocumentSummary{
+		"internal/util.go": {Occurrences: 3, Definitions: 2},
+		"main.go":          {Occurrences: 1, Definitions: 0},
+	}
+	if err := store.InsertDocumentSummaries(ctx, 42, expected); err != nil {
+		t.Fatalf("unexpected error inserting document summaries: %s", err)
+	}
+
+	summaries, err := store.GetDocumentSummaries(ctx, 42)
+	if err != nil {
+		t.Fatalf("unexpected error getting document summaries: %s", err)
+	}
+	if diff := cmp.Diff(expected, summaries); diff != "" {
+		t.Errorf("unexpected document summaries (-want +got):\n%s", diff)
+	}
+
+	summaries, err = store.GetDocumentSummaries(ctx, 43)
+	if err != nil {
+		t.Fatalf("unexpected error getting document summaries: %s", err)
+	}
+	if len(summaries) != 0 {
+		t.Errorf("unexpected document summaries for upload without summaries: %v", summaries)
+	}
+}
+
+func TestGetMissingDefinitions(t *testing.T) {
+	logger := logtest.Scoped(t)
+	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, codeIntelDB)
+	ctx := context.Background()
+
+	const (
+		util   = "scip-go gomod example v1 `example/internal`/Util()."
+		helper = "scip-go gomod example v1 `example/internal`/helper()."
+	)
+
+	uploads := map[int]map[string]*scip.Document{
+		42: {
+			"internal/util.go": {
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{1, 2, 3}, Symbol: util, SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Range: []int32{2, 2, 3}, Symbol: helper, SymbolRoles: int32(scip.SymbolRole_Definition)},
+				},
+			},
+			"main.go": {
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{4, 5, 6}, Symbol: util},
+				},
+			},
+		},
+		43: {
+			"internal/util.go": {
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{1, 2, 3}, Symbol: util, SymbolRoles: int32(scip.SymbolRole_Definition)},
+				},
+			},
+			"main.go": {
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{4, 5, 6}, Symbol: util},
+					{Range: []int32{5, 5, 6}, Symbol: helper},
+				},
+			},
+		},
+	}
+
+	for uploadID, documents := range uploads {
+		scipWriter, err := store.NewSCIPWriter(ctx, uploadID)
+		if err != nil {
+			t.Fatalf("failed to create SCIP writer: %s", err)
+		}
+		for path, document := range documents {
+			if err := scipWriter.InsertDocument(ctx, path, document); err != nil {
+				t.Fatalf("failed to write SCIP document: %s", err)
+			}
+		}
+		if _, err := scipWriter.Flush(ctx); err != nil {
+			t.Fatalf("failed to flush SCIP data: %s", err)
+		}
+	}
+
+	missingDefinitions, err := store.GetMissingDefinitions(ctx, 42, 43)
+	if err != nil {
+		t.Fatalf("unexpected error getting missing definitions: %s", err)
+	}
+
+	expected := []shared.MissingDefinition{
+		{Symbol: helper, Path: "internal/util.go"},
+	}
+	if diff := cmp.Diff(expected, missingDefinitions); diff != "" {
+		t.Errorf("unexpected missing definitions (-want +got):\n%s", diff)
+	}
+}
@@ -12,9 +12,13 @@ type operations struct {
 	idsWithMeta                 *observation.Operation
 	reconcileCandidates         *observation.Operation
 	getUploadDocumentsForPath   *observation.Operation
+	getDocumentSummaries        *observation.Operation
+	insertDocumentSummaries     *observation.Operation
+	getMissingDefinitions       *observation.Operation
 	scanDocuments               *observation.Operation
 	scanResultChunks            *observation.Operation
 	scanLocations               *observation.Operation
+	scanSCIPDocuments           *observation.Operation
 	insertMetadata              *observation.Operation
 	writeMeta                   *observation.Operation
 	writeDocuments              *observation.Operation
@@ -50,9 +54,13 @@ func newOperations(observationCtx *observation.Context) *operations {
 		idsWithMeta:                 op("IDsWithMeta"),
 		reconcileCandidates:         op("ReconcileCandidates"),
 		getUploadDocumentsForPath:   op("GetUploadDocumentsForPath"),
+		getDocumentSummaries:        op("GetDocumentSummaries"),
+		insertDocumentSummaries:     op("InsertDocumentSummaries"),
+		getMissingDefinitions:       op("GetMissingDefinitions"),
 		scanDocuments:               op("ScanDocuments"),
 		scanResultChunks:            op("ScanResultChunks"),
 		scanLocations:               op("ScanLocations"),
+		scanSCIPDocuments:           op("ScanSCIPDocuments"),
 		insertMetadata:              op("InsertMetadata"),
 		writeMeta:                   op("WriteMeta"),
 		writeDocuments:              op("WriteDocuments"),
@@ -0,0 +1,63 @@
+package lsifstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	codeintelshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestScanSCIPDocuments(t *testing.T) {
+	logger := logtest.Scoped(t)
+	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, codeIntelDB)
+	ctx := context.Background()
+
+	documents := map[string]*scip.Document{
+		"internal/util.go": {
+			Occurrences: []*scip.Occurrence{
+				{Range: []int32{1, 2, 3}, Symbol: "scip-go gomod example v1 `example/internal`/Util().", SymbolRoles: int32(scip.SymbolRole_Definition)},
+			},
+		},
+		"main.go": {
+			Occurrences: []*scip.Occurrence{
+				{Range: []int32{4, 5, 6}, Symbol: "scip-go gomod example v1 `example/internal`/Util()."},
+			},
+		},
+	}
+
+	scipWriter, err := store.NewSCIPWriter(ctx, 42)
+	if err != nil {
+		t.Fatalf("failed to create SCIP writer: %s", err)
+	}
+	for path, document := range documents {
+		if err := scipWriter.InsertDocument(ctx, path, document); err != nil {
+			t.Fatalf("failed to write SCIP document: %s", err)
+		}
+	}
+	if _, err := scipWriter.Flush(ctx); err != nil {
+		t.Fatalf("failed to flush SCIP data: %s", err)
+	}
+
+	var paths []string
+	if err := store.ScanSCIPDocuments(ctx, 42, func(path string, document *scip.Document) error {
+		paths = append(paths, path)
+
+		if diff := cmp.Diff(documents[path].Occurrences[0].Symbol, document.Occurrences[0].Symbol); diff != "" {
+			t.Errorf("unexpected symbol for %s (-want +got):\n%s", path, diff)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error scanning SCIP documents: %s", err)
+	}
+
+	if diff := cmp.Diff([]string{"internal/util.go", "main.go"}, paths); diff != "" {
+		t.Errorf("unexpected paths (-want +got):\n%s", diff)
+	}
+}
@@ -35,6 +35,7 @@ type operations struct {
 	// Uploads
 	getUploads                           *observation.Operation
 	getUploadByID                        *observation.Operation
+	getPreviousUpload                    *observation.Operation
 	getUploadsByIDs                      *observation.Operation
 	getVisibleUploadsMatchingMonikers    *observation.Operation
 	updateUploadsVisibleToCommits        *observation.Operation
@@ -126,6 +127,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		// Uploads
 		getUploads:                           op("GetUploads"),
 		getUploadByID:                        op("GetUploadByID"),
+		getPreviousUpload:                    op("GetPreviousUpload"),
 		getUploadsByIDs:                      op("GetUploadsByIDs"),
 		getVisibleUploadsMatchingMonikers:    op("GetVisibleUploadsMatchingMonikers"),
 		updateUploadsVisibleToCommits:        op("UpdateUploadsVisibleToCommits"),
@@ -45,6 +45,7 @@ type Store interface {
 	// Uploads
 	GetUploads(ctx context.Context, opts shared.GetUploadsOptions) (_ []types.Upload, _ int, err error)
 	GetUploadByID(ctx context.Context, id int) (_ types.Upload, _ bool, err error)
+	GetPreviousUpload(ctx context.Context, upload types.Upload) (_ types.Upload, _ bool, err error)
 	GetUploadsByIDs(ctx context.Context, ids ...int) (_ []types.Upload, err error)
 	GetUploadsByIDsAllowDeleted(ctx context.Context, ids ...int) (_ []types.Upload, err error)
 	GetUploadIDsWithReferences(ctx context.Context, orderedMonikers []precise.QualifiedMonikerData, ignoreIDs []int, repositoryID int, commit string, limit int, offset int, trace observation.TraceLogger) (ids []int, recordsScanned int, totalCount int, err error)
@@ -103,6 +104,9 @@ type Store interface {
 		batchSize int,
 		deleter func(ctx context.Context, objectPrefix string) error,
 	) (totalDeleted int, err error)
+
+	InsertSymbolsForRanking(ctx context.Context, upload ExportedUpload, graphKey string, definitions []RankingDefinition, references map[string]int) error
+	VacuumStaleRankingSymbols(ctx context.Context, graphKey string, batchSize int) (numDeleted int, err error)
 }
 
 // store manages the database operations for uploads.
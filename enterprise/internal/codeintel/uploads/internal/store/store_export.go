@@ -7,6 +7,7 @@ import (
 	"github.com/lib/pq"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 )
 
@@ -140,3 +141,190 @@ const deleteStaleExportedUploadsQuery = `
 DELETE FROM codeintel_ranking_exports re
 WHERE re.id = ANY(%s)
 `
+
+// RankingDefinition is a symbol defined by a document of an exported upload.
+type RankingDefinition struct {
+	SymbolName   string
+	DocumentPath string
+}
+
+// InsertSymbolsForRanking replaces the symbols defined and referenced by the given exported upload
+// for the given graph key. The references map symbol names to the number of documents of the upload
+// that reference them. The repository of the upload and the repositories defining a symbol it
+// references (or referenced) are marked so that their document ranks are recomputed.
+func (s *store) InsertSymbolsForRanking(
+	ctx context.Context,
+	upload ExportedUpload,
+	graphKey string,
+	definitions []RankingDefinition,
+	references map[string]int,
+) (err error) {
+	tx, err := s.db.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	symbolNames, err := basestore.ScanStrings(tx.Query(ctx, sqlf.Sprintf(deleteSymbolsForRankingQuery, upload.ID, graphKey, upload.ID, graphKey)))
+	if err != nil {
+		return err
+	}
+	for symbolName := range references {
+		symbolNames = append(symbolNames, symbolName)
+	}
+
+	definitionInserter := batch.NewInserter(
+		ctx,
+		tx.Handle(),
+		"codeintel_ranking_definitions",
+		batch.MaxNumPostgresParameters,
+		"upload_id",
+		"graph_key",
+		"repository_name",
+		"document_path",
+		"symbol_name",
+	)
+	for _, definition := range definitions {
+		if err := definitionInserter.Insert(ctx, upload.ID, graphKey, upload.Repo, definition.DocumentPath, definition.SymbolName); err != nil {
+			return err
+		}
+	}
+	if err := definitionInserter.Flush(ctx); err != nil {
+		return err
+	}
+
+	referenceInserter := batch.NewInserter(
+		ctx,
+		tx.Handle(),
+		"codeintel_ranking_references",
+		batch.MaxNumPostgresParameters,
+		"upload_id",
+		"graph_key",
+		"symbol_name",
+		"document_count",
+	)
+	for symbolName, documentCount := range references {
+		if err := referenceInserter.Insert(ctx, upload.ID, graphKey, symbolName, documentCount); err != nil {
+			return err
+		}
+	}
+
+	if err := referenceInserter.Flush(ctx); err != nil {
+		return err
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(markRankingRepositoriesDirtyQuery, graphKey, upload.Repo, graphKey, pq.Array(symbolNames)))
+}
+
+const deleteSymbolsForRankingQuery = `
+WITH
+deleted_definitions AS (
+	DELETE FROM codeintel_ranking_definitions
+	WHERE upload_id = %s AND graph_key = %s
+	RETURNING 1
+)
+deleted_references AS (
+	DELETE FROM codeintel_ranking_references
+	WHERE upload_id = %s AND graph_key = %s
+	RETURNING symbol_name
+)
+SELECT symbol_name FROM deleted_references
+`
+
+const markRankingRepositoriesDirtyQuery = `
+INSERT INTO codeintel_ranking_dirty_repositories AS dr (graph_key, repository_name, dirty_token)
+SELECT %s, s.repository_name, 1
+FROM (
+	SELECT %s::text AS repository_name
+	UNION
+	SELECT d.repository_name
+	FROM codeintel_ranking_definitions d
+	WHERE
+		d.graph_key = %s AND
+		d.symbol_name = ANY(%s)
+) s
+ON CONFLICT (graph_key, repository_name) DO UPDATE SET dirty_token = dr.dirty_token + 1
+`
+
+// VacuumStaleRankingSymbols deletes the symbols of uploads that are no longer exported for the
+// given graph key, either because the graph key changed or because the upload is no longer
+// visible at the tip of the default branch. The repositories whose document ranks under the
+// given graph key depend on the deleted symbols are marked so that their ranks are recomputed.
+func (s *store) VacuumStaleRankingSymbols(ctx context.Context, graphKey string, batchSize int) (numDeleted int, err error) {
+	numDeleted, _, err = basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(
+		vacuumStaleRankingSymbolsQuery,
+		graphKey,
+		batchSize,
+		graphKey,
+		batchSize,
+		graphKey,
+		graphKey,
+		graphKey,
+	)))
+	return numDeleted, err
+}
+
+const vacuumStaleRankingSymbolsQuery = `
+WITH
+stale_definitions AS (
+	SELECT d.id
+	FROM codeintel_ranking_definitions d
+	WHERE NOT EXISTS (
+		SELECT 1
+		FROM codeintel_ranking_exports re
+		WHERE
+			re.graph_key = %s AND
+			re.graph_key = d.graph_key AND
+			re.upload_id = d.upload_id
+	)
+	ORDER BY d.id
+	LIMIT %s
+	FOR UPDATE SKIP LOCKED
+),
+stale_references AS (
+	SELECT r.id
+	FROM codeintel_ranking_references r
+	WHERE NOT EXISTS (
+		SELECT 1
+		FROM codeintel_ranking_exports re
+		WHERE
+			re.graph_key = %s AND
+			re.graph_key = r.graph_key AND
+			re.upload_id = r.upload_id
+	)
+	ORDER BY r.id
+	LIMIT %s
+	FOR UPDATE SKIP LOCKED
+),
+deleted_definitions AS (
+	DELETE FROM codeintel_ranking_definitions
+	WHERE id IN (SELECT id FROM stale_definitions)
+	RETURNING graph_key, repository_name
+),
+deleted_references AS (
+	DELETE FROM codeintel_ranking_references
+	WHERE id IN (SELECT id FROM stale_references)
+	RETURNING graph_key, symbol_name
+),
+dirty_repositories AS (
+	INSERT INTO codeintel_ranking_dirty_repositories AS dr (graph_key, repository_name, dirty_token)
+	SELECT %s, s.repository_name, 1
+	FROM (
+		SELECT dd.repository_name
+		FROM deleted_definitions dd
+		WHERE dd.graph_key = %s
+		UNION
+		SELECT d.repository_name
+		FROM deleted_references dref
+		JOIN codeintel_ranking_definitions d ON
+			d.graph_key = dref.graph_key AND
+			d.symbol_name = dref.symbol_name
+		WHERE dref.graph_key = %s
+	) s
+	ON CONFLICT (graph_key, repository_name) DO UPDATE SET dirty_token = dr.dirty_token + 1
+	RETURNING 1
+)
+SELECT
+	(SELECT COUNT(*) FROM deleted_definitions) +
+	(SELECT COUNT(*) FROM deleted_references)
+`
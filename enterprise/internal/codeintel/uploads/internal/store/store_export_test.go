@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/log/logtest"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -144,3 +145,109 @@ func TestProcessStaleExportedUploads(t *testing.T) {
 		t.Fatalf("unexpected deleted IDs (-want +got):\n%s", diff)
 	}
 }
+
+func TestInsertSymbolsForRanking(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO repo (id, name, deleted_at) VALUES (50, 'foo', NULL);
+		INSERT INTO repo (id, name, deleted_at) VALUES (51, 'bar', NULL);
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state) VALUES (100, 50, '0000000000000000000000000000000000000001', 'lsif-test', 1, '{}', 'completed');
+		INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state) VALUES (101, 51, '0000000000000000000000000000000000000002', 'lsif-test', 1, '{}', 'completed');
+		INSERT INTO lsif_uploads_visible_at_tip (upload_id, repository_id, is_default_branch) VALUES (100, 50, true);
+		INSERT INTO lsif_uploads_visible_at_tip (upload_id, repository_id, is_default_branch) VALUES (101, 51, true);
+	`); err != nil {
+		t.Fatalf("unexpected error setting up test: %s", err)
+	}
+
+	uploads, err := store.GetUploadsForRanking(ctx, "test", "in-process", 10)
+	if err != nil {
+		t.Fatalf("unexpected error getting uploads for ranking: %s", err)
+	}
+	for _, upload := range uploads {
+		definitions := []RankingDefinition{{SymbolName: "s-" + upload.Repo, DocumentPath: "lib.go"}}
+		references := map[string]int{"s-foo": 2, "s-bar": 1}
+
+		// Inserting twice replaces the first set of symbols
+		for i := 0; i < 2; i++ {
+			if err := store.InsertSymbolsForRanking(ctx, upload, "test", definitions, references); err != nil {
+				t.Fatalf("unexpected error inserting symbols for ranking: %s", err)
+			}
+		}
+	}
+
+	assertCounts := func(expectedDefinitions, expectedReferences int) {
+		t.Helper()
+
+		numDefinitions, _, err := basestore.ScanFirstInt(db.QueryContext(ctx, `SELECT COUNT(*) FROM codeintel_ranking_definitions`))
+		if err != nil {
+			t.Fatalf("unexpected error counting definitions: %s", err)
+		}
+		numReferences, _, err := basestore.ScanFirstInt(db.QueryContext(ctx, `SELECT COUNT(*) FROM codeintel_ranking_references`))
+		if err != nil {
+			t.Fatalf("unexpected error counting references: %s", err)
+		}
+		if numDefinitions != expectedDefinitions || numReferences != expectedReferences {
+			t.Fatalf("unexpected counts. want=(%d, %d) have=(%d, %d)", expectedDefinitions, expectedReferences, numDefinitions, numReferences)
+		}
+	}
+	assertCounts(2, 4)
+
+	assertDirtyRepositories := func(expected []string) {
+		t.Helper()
+
+		repositoryNames, err := basestore.ScanStrings(db.QueryContext(ctx, `
+			UPDATE codeintel_ranking_dirty_repositories
+			SET update_token = dirty_token
+			WHERE graph_key = 'test' AND dirty_token > update_token
+			RETURNING repository_name
+		`))
+		if err != nil {
+			t.Fatalf("unexpected error getting dirty repositories: %s", err)
+		}
+		sort.Strings(repositoryNames)
+		if diff := cmp.Diff(expected, repositoryNames); diff != "" {
+			t.Fatalf("unexpected dirty repositories (-want +got):\n%s", diff)
+		}
+	}
+	assertDirtyRepositories([]string{"bar", "foo"})
+
+	// Nothing is stale yet
+	if numDeleted, err := store.VacuumStaleRankingSymbols(ctx, "test", 100); err != nil {
+		t.Fatalf("unexpected error vacuuming stale ranking symbols: %s", err)
+	} else if numDeleted != 0 {
+		t.Fatalf("unexpected number of deleted records. want=%d have=%d", 0, numDeleted)
+	}
+
+	// Upload 101 is no longer visible at tip
+	if _, err := db.ExecContext(ctx, `DELETE FROM lsif_uploads_visible_at_tip WHERE upload_id = 101`); err != nil {
+		t.Fatalf("unexpected error setting up test: %s", err)
+	}
+	if _, err := store.ProcessStaleExportedUploads(ctx, "test", 100, func(ctx context.Context, objectPrefix string) error { return nil }); err != nil {
+		t.Fatalf("unexpected error processing stale exported uploads: %s", err)
+	}
+	if numDeleted, err := store.VacuumStaleRankingSymbols(ctx, "test", 100); err != nil {
+		t.Fatalf("unexpected error vacuuming stale ranking symbols: %s", err)
+	} else if numDeleted != 3 {
+		t.Fatalf("unexpected number of deleted records. want=%d have=%d", 3, numDeleted)
+	}
+	assertCounts(1, 2)
+	// bar lost its definitions, foo lost the references of bar
+	assertDirtyRepositories([]string{"bar", "foo"})
+
+	// Changing the graph key makes everything stale
+	if numDeleted, err := store.VacuumStaleRankingSymbols(ctx, "test-2", 100); err != nil {
+		t.Fatalf("unexpected error vacuuming stale ranking symbols: %s", err)
+	} else if numDeleted != 3 {
+		t.Fatalf("unexpected number of deleted records. want=%d have=%d", 3, numDeleted)
+	}
+	assertCounts(0, 0)
+	assertDirtyRepositories(nil)
+}
@@ -75,7 +75,8 @@ global_policy_descriptor AS MATERIALIZED (
 	WHERE
 		p.indexing_enabled AND
 		p.repository_id IS NULL AND
-		p.repository_patterns IS NULL
+		p.repository_patterns IS NULL AND
+		p.repository_query IS NULL
 	ORDER BY is_head_policy DESC
 	LIMIT 1
 ),
@@ -247,6 +247,68 @@ JOIN repo ON repo.id = u.repository_id
 WHERE repo.deleted_at IS NULL AND u.state != 'deleted' AND u.id = %s AND %s
 `
 
+// GetPreviousUpload returns the most recently uploaded completed upload for the same repository, root,
+// and indexer as the given upload that was uploaded before it.
+func (s *store) GetPreviousUpload(ctx context.Context, upload types.Upload) (_ types.Upload, _ bool, err error) {
+	ctx, _, endObservation := s.operations.getPreviousUpload.With(ctx, &err, observation.Args{LogFields: []log.Field{log.Int("id", upload.ID)}})
+	defer endObservation(1, observation.Args{})
+
+	authzConds, err := database.AuthzQueryConds(ctx, database.NewDBWith(s.logger, s.db))
+	if err != nil {
+		return types.Upload{}, false, err
+	}
+
+	return scanFirstUpload(s.db.Query(ctx, sqlf.Sprintf(
+		getPreviousUploadQuery,
+		upload.RepositoryID,
+		upload.Root,
+		upload.Indexer,
+		upload.ID,
+		upload.UploadedAt,
+		authzConds,
+	)))
+}
+
+const getPreviousUploadQuery = `
+SELECT
+	u.id,
+	u.commit,
+	u.root,
+	EXISTS (` + visibleAtTipSubselectQuery + `) AS visible_at_tip,
+	u.uploaded_at,
+	u.state,
+	u.failure_message,
+	u.started_at,
+	u.finished_at,
+	u.process_after,
+	u.num_resets,
+	u.num_failures,
+	u.repository_id,
+	repo.name,
+	u.indexer,
+	u.indexer_version,
+	u.num_parts,
+	u.uploaded_parts,
+	u.upload_size,
+	u.associated_index_id,
+	u.content_type,
+	NULL::bigint AS rank,
+	u.uncompressed_size
+FROM lsif_uploads u
+JOIN repo ON repo.id = u.repository_id
+WHERE
+	repo.deleted_at IS NULL AND
+	u.state = 'completed' AND
+	u.repository_id = %s AND
+	u.root = %s AND
+	u.indexer = %s AND
+	u.id != %s AND
+	u.uploaded_at < %s AND
+	%s
+ORDER BY u.uploaded_at DESC, u.id DESC
+LIMIT 1
+`
+
 func (s *store) getUploadsByIDs(ctx context.Context, allowDeleted bool, ids ...int) (_ []types.Upload, err error) {
 	ctx, _, endObservation := s.operations.getUploadsByIDs.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("ids", intsToString(ids)),
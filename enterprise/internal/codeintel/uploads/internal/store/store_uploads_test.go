@@ -342,6 +342,66 @@ func TestGetUploadByIDDeleted(t *testing.T) {
 	}
 }
 
+func TestGetPreviousUpload(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	t1 := time.Unix(1587396557, 0).UTC()
+	t2 := t1.Add(time.Minute)
+	t3 := t1.Add(time.Minute * 2)
+	t4 := t1.Add(time.Minute * 3)
+	t5 := t1.Add(time.Minute * 4)
+
+	insertUploads(t, db,
+		types.Upload{ID: 1, UploadedAt: t1, Root: "sub/"},
+		types.Upload{ID: 2, UploadedAt: t2, Root: "sub/"},
+		types.Upload{ID: 3, UploadedAt: t3, Root: "sub/", State: "errored"},   // not completed
+		types.Upload{ID: 4, UploadedAt: t3, Root: "other/"},                   // different root
+		types.Upload{ID: 5, UploadedAt: t3, Root: "sub/", Indexer: "scip-go"}, // different indexer
+		types.Upload{ID: 6, UploadedAt: t3, Root: "sub/", RepositoryID: 51},   // different repository
+		types.Upload{ID: 7, UploadedAt: t4, Root: "sub/"},
+		types.Upload{ID: 8, UploadedAt: t5, Root: "sub/"},
+	)
+
+	testCases := []struct {
+		uploadID   int
+		expectedID int
+	}{
+		{8, 7},
+		{7, 2},
+		{2, 1},
+		{1, 0},
+		{5, 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(fmt.Sprintf("uploadID=%d", testCase.uploadID), func(t *testing.T) {
+			upload, exists, err := store.GetUploadByID(context.Background(), testCase.uploadID)
+			if err != nil {
+				t.Fatalf("unexpected error getting upload: %s", err)
+			} else if !exists {
+				t.Fatal("expected record to exist")
+			}
+
+			previous, exists, err := store.GetPreviousUpload(context.Background(), upload)
+			if err != nil {
+				t.Fatalf("unexpected error getting previous upload: %s", err)
+			}
+
+			if testCase.expectedID == 0 {
+				if exists {
+					t.Fatalf("unexpected previous upload %d", previous.ID)
+				}
+			} else if !exists {
+				t.Fatal("expected previous upload to exist")
+			} else if previous.ID != testCase.expectedID {
+				t.Errorf("unexpected previous upload. want=%d have=%d", testCase.expectedID, previous.ID)
+			}
+		})
+	}
+}
+
 func TestGetQueuedUploadRank(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
@@ -14,6 +14,7 @@ import (
 
 	regexp "github.com/grafana/regexp"
 	sqlf "github.com/keegancsmith/sqlf"
+	scip "github.com/sourcegraph/scip/bindings/go/scip"
 	enterprise "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies/enterprise"
 	shared1 "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies/shared"
 	types "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
@@ -101,6 +102,9 @@ type MockStore struct {
 	// GetOldestCommitDateFunc is an instance of a mock function object
 	// controlling the behavior of the method GetOldestCommitDate.
 	GetOldestCommitDateFunc *StoreGetOldestCommitDateFunc
+	// GetPreviousUploadFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPreviousUpload.
+	GetPreviousUploadFunc *StoreGetPreviousUploadFunc
 	// GetRecentUploadsSummaryFunc is an instance of a mock function object
 	// controlling the behavior of the method GetRecentUploadsSummary.
 	GetRecentUploadsSummaryFunc *StoreGetRecentUploadsSummaryFunc
@@ -152,6 +156,9 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// InsertDependencySyncingJob.
 	InsertDependencySyncingJobFunc *StoreInsertDependencySyncingJobFunc
+	// InsertSymbolsForRankingFunc is an instance of a mock function object
+	// controlling the behavior of the method InsertSymbolsForRanking.
+	InsertSymbolsForRankingFunc *StoreInsertSymbolsForRankingFunc
 	// InsertUploadFunc is an instance of a mock function object controlling
 	// the behavior of the method InsertUpload.
 	InsertUploadFunc *StoreInsertUploadFunc
@@ -221,6 +228,10 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// UpdateUploadsVisibleToCommits.
 	UpdateUploadsVisibleToCommitsFunc *StoreUpdateUploadsVisibleToCommitsFunc
+	// VacuumStaleRankingSymbolsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// VacuumStaleRankingSymbols.
+	VacuumStaleRankingSymbolsFunc *StoreVacuumStaleRankingSymbolsFunc
 	// WorkerutilStoreFunc is an instance of a mock function object
 	// controlling the behavior of the method WorkerutilStore.
 	WorkerutilStoreFunc *StoreWorkerutilStoreFunc
@@ -325,6 +336,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetPreviousUploadFunc: &StoreGetPreviousUploadFunc{
+			defaultHook: func(context.Context, types.Upload) (r0 types.Upload, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetRecentUploadsSummaryFunc: &StoreGetRecentUploadsSummaryFunc{
 			defaultHook: func(context.Context, int) (r0 []shared.UploadsWithRepositoryNamespace, r1 error) {
 				return
@@ -400,6 +416,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		InsertSymbolsForRankingFunc: &StoreInsertSymbolsForRankingFunc{
+			defaultHook: func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) (r0 error) {
+				return
+			},
+		},
 		InsertUploadFunc: &StoreInsertUploadFunc{
 			defaultHook: func(context.Context, types.Upload) (r0 int, r1 error) {
 				return
@@ -505,6 +526,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		VacuumStaleRankingSymbolsFunc: &StoreVacuumStaleRankingSymbolsFunc{
+			defaultHook: func(context.Context, string, int) (r0 int, r1 error) {
+				return
+			},
+		},
 		WorkerutilStoreFunc: &StoreWorkerutilStoreFunc{
 			defaultHook: func(*observation.Context) (r0 store1.Store[types.Upload]) {
 				return
@@ -612,6 +638,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetOldestCommitDate")
 			},
 		},
+		GetPreviousUploadFunc: &StoreGetPreviousUploadFunc{
+			defaultHook: func(context.Context, types.Upload) (types.Upload, bool, error) {
+				panic("unexpected invocation of MockStore.GetPreviousUpload")
+			},
+		},
 		GetRecentUploadsSummaryFunc: &StoreGetRecentUploadsSummaryFunc{
 			defaultHook: func(context.Context, int) ([]shared.UploadsWithRepositoryNamespace, error) {
 				panic("unexpected invocation of MockStore.GetRecentUploadsSummary")
@@ -687,6 +718,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.InsertDependencySyncingJob")
 			},
 		},
+		InsertSymbolsForRankingFunc: &StoreInsertSymbolsForRankingFunc{
+			defaultHook: func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error {
+				panic("unexpected invocation of MockStore.InsertSymbolsForRanking")
+			},
+		},
 		InsertUploadFunc: &StoreInsertUploadFunc{
 			defaultHook: func(context.Context, types.Upload) (int, error) {
 				panic("unexpected invocation of MockStore.InsertUpload")
@@ -792,6 +828,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.UpdateUploadsVisibleToCommits")
 			},
 		},
+		VacuumStaleRankingSymbolsFunc: &StoreVacuumStaleRankingSymbolsFunc{
+			defaultHook: func(context.Context, string, int) (int, error) {
+				panic("unexpected invocation of MockStore.VacuumStaleRankingSymbols")
+			},
+		},
 		WorkerutilStoreFunc: &StoreWorkerutilStoreFunc{
 			defaultHook: func(*observation.Context) store1.Store[types.Upload] {
 				panic("unexpected invocation of MockStore.WorkerutilStore")
@@ -861,6 +902,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetOldestCommitDateFunc: &StoreGetOldestCommitDateFunc{
 			defaultHook: i.GetOldestCommitDate,
 		},
+		GetPreviousUploadFunc: &StoreGetPreviousUploadFunc{
+			defaultHook: i.GetPreviousUpload,
+		},
 		GetRecentUploadsSummaryFunc: &StoreGetRecentUploadsSummaryFunc{
 			defaultHook: i.GetRecentUploadsSummary,
 		},
@@ -906,6 +950,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		InsertDependencySyncingJobFunc: &StoreInsertDependencySyncingJobFunc{
 			defaultHook: i.InsertDependencySyncingJob,
 		},
+		InsertSymbolsForRankingFunc: &StoreInsertSymbolsForRankingFunc{
+			defaultHook: i.InsertSymbolsForRanking,
+		},
 		InsertUploadFunc: &StoreInsertUploadFunc{
 			defaultHook: i.InsertUpload,
 		},
@@ -969,6 +1016,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		UpdateUploadsVisibleToCommitsFunc: &StoreUpdateUploadsVisibleToCommitsFunc{
 			defaultHook: i.UpdateUploadsVisibleToCommits,
 		},
+		VacuumStaleRankingSymbolsFunc: &StoreVacuumStaleRankingSymbolsFunc{
+			defaultHook: i.VacuumStaleRankingSymbols,
+		},
 		WorkerutilStoreFunc: &StoreWorkerutilStoreFunc{
 			defaultHook: i.WorkerutilStore,
 		},
@@ -3097,6 +3147,117 @@ func (c StoreGetOldestCommitDateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// StoreGetPreviousUploadFunc describes the behavior when the
+// GetPreviousUpload method of the parent MockStore instance is invoked.
+type StoreGetPreviousUploadFunc struct {
+	defaultHook func(context.Context, types.Upload) (types.Upload, bool, error)
+	hooks       []func(context.Context, types.Upload) (types.Upload, bool, error)
+	history     []StoreGetPreviousUploadFuncCall
+	mutex       sync.Mutex
+}
+
+// GetPreviousUpload delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetPreviousUpload(v0 context.Context, v1 types.Upload) (types.Upload, bool, error) {
+	r0, r1, r2 := m.GetPreviousUploadFunc.nextHook()(v0, v1)
+	m.GetPreviousUploadFunc.appendCall(StoreGetPreviousUploadFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetPreviousUpload
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetPreviousUploadFunc) SetDefaultHook(hook func(context.Context, types.Upload) (types.Upload, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetPreviousUpload method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetPreviousUploadFunc) PushHook(hook func(context.Context, types.Upload) (types.Upload, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetPreviousUploadFunc) SetDefaultReturn(r0 types.Upload, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, types.Upload) (types.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetPreviousUploadFunc) PushReturn(r0 types.Upload, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, types.Upload) (types.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetPreviousUploadFunc) nextHook() func(context.Context, types.Upload) (types.Upload, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetPreviousUploadFunc) appendCall(r0 StoreGetPreviousUploadFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetPreviousUploadFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetPreviousUploadFunc) History() []StoreGetPreviousUploadFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetPreviousUploadFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetPreviousUploadFuncCall is an object that describes an invocation of
+// method GetPreviousUpload on an instance of MockStore.
+type StoreGetPreviousUploadFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.Upload
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 types.Upload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetPreviousUploadFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetPreviousUploadFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreGetRecentUploadsSummaryFunc describes the behavior when the
 // GetRecentUploadsSummary method of the parent MockStore instance is
 // invoked.
@@ -4828,6 +4989,121 @@ func (c StoreInsertDependencySyncingJobFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreInsertSymbolsForRankingFunc describes the behavior when the
+// InsertSymbolsForRanking method of the parent MockStore instance is
+// invoked.
+type StoreInsertSymbolsForRankingFunc struct {
+	defaultHook func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error
+	hooks       []func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error
+	history     []StoreInsertSymbolsForRankingFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertSymbolsForRanking delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) InsertSymbolsForRanking(v0 context.Context, v1 store.ExportedUpload, v2 string, v3 []store.RankingDefinition, v4 map[string]int) error {
+	r0 := m.InsertSymbolsForRankingFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.InsertSymbolsForRankingFunc.appendCall(StoreInsertSymbolsForRankingFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertSymbolsForRanking method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreInsertSymbolsForRankingFunc) SetDefaultHook(hook func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertSymbolsForRanking method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreInsertSymbolsForRankingFunc) PushHook(hook func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertSymbolsForRankingFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertSymbolsForRankingFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error {
+		return r0
+	})
+}
+
+func (f *StoreInsertSymbolsForRankingFunc) nextHook() func(context.Context, store.ExportedUpload, string, []store.RankingDefinition, map[string]int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertSymbolsForRankingFunc) appendCall(r0 StoreInsertSymbolsForRankingFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertSymbolsForRankingFuncCall
+// objects describing the invocations of this function.
+func (f *StoreInsertSymbolsForRankingFunc) History() []StoreInsertSymbolsForRankingFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertSymbolsForRankingFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertSymbolsForRankingFuncCall is an object that describes an
+// invocation of method InsertSymbolsForRanking on an instance of MockStore.
+type StoreInsertSymbolsForRankingFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.ExportedUpload
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []store.RankingDefinition
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 map[string]int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertSymbolsForRankingFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertSymbolsForRankingFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreInsertUploadFunc describes the behavior when the InsertUpload method
 // of the parent MockStore instance is invoked.
 type StoreInsertUploadFunc struct {
@@ -7157,6 +7433,119 @@ func (c StoreUpdateUploadsVisibleToCommitsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// StoreVacuumStaleRankingSymbolsFunc describes the behavior when the
+// VacuumStaleRankingSymbols method of the parent MockStore instance is
+// invoked.
+type StoreVacuumStaleRankingSymbolsFunc struct {
+	defaultHook func(context.Context, string, int) (int, error)
+	hooks       []func(context.Context, string, int) (int, error)
+	history     []StoreVacuumStaleRankingSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// VacuumStaleRankingSymbols delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) VacuumStaleRankingSymbols(v0 context.Context, v1 string, v2 int) (int, error) {
+	r0, r1 := m.VacuumStaleRankingSymbolsFunc.nextHook()(v0, v1, v2)
+	m.VacuumStaleRankingSymbolsFunc.appendCall(StoreVacuumStaleRankingSymbolsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// VacuumStaleRankingSymbols method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreVacuumStaleRankingSymbolsFunc) SetDefaultHook(hook func(context.Context, string, int) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VacuumStaleRankingSymbols method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreVacuumStaleRankingSymbolsFunc) PushHook(hook func(context.Context, string, int) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreVacuumStaleRankingSymbolsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreVacuumStaleRankingSymbolsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreVacuumStaleRankingSymbolsFunc) nextHook() func(context.Context, string, int) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreVacuumStaleRankingSymbolsFunc) appendCall(r0 StoreVacuumStaleRankingSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreVacuumStaleRankingSymbolsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreVacuumStaleRankingSymbolsFunc) History() []StoreVacuumStaleRankingSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreVacuumStaleRankingSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreVacuumStaleRankingSymbolsFuncCall is an object that describes an
+// invocation of method VacuumStaleRankingSymbols on an instance of
+// MockStore.
+type StoreVacuumStaleRankingSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreVacuumStaleRankingSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreVacuumStaleRankingSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreWorkerutilStoreFunc describes the behavior when the WorkerutilStore
 // method of the parent MockStore instance is invoked.
 type StoreWorkerutilStoreFunc struct {
@@ -7552,6 +7941,9 @@ type MockLsifStore struct {
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *LsifStoreDoneFunc
+	// GetDocumentSummariesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDocumentSummaries.
+	GetDocumentSummariesFunc *LsifStoreGetDocumentSummariesFunc
 	// GetUploadDocumentsForPathFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// GetUploadDocumentsForPath.
@@ -7577,6 +7969,9 @@ type MockLsifStore struct {
 	// ScanResultChunksFunc is an instance of a mock function object
 	// controlling the behavior of the method ScanResultChunks.
 	ScanResultChunksFunc *LsifStoreScanResultChunksFunc
+	// ScanSCIPDocumentsFunc is an instance of a mock function object
+	// controlling the behavior of the method ScanSCIPDocuments.
+	ScanSCIPDocumentsFunc *LsifStoreScanSCIPDocumentsFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *LsifStoreTransactFunc
@@ -7619,6 +8014,11 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetDocumentSummariesFunc: &LsifStoreGetDocumentSummariesFunc{
+			defaultHook: func(context.Context, int) (r0 map[string]shared.DocumentSummary, r1 error) {
+				return
+			},
+		},
 		GetUploadDocumentsForPathFunc: &LsifStoreGetUploadDocumentsForPathFunc{
 			defaultHook: func(context.Context, int, string) (r0 []string, r1 int, r2 error) {
 				return
@@ -7659,6 +8059,11 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		ScanSCIPDocumentsFunc: &LsifStoreScanSCIPDocumentsFunc{
+			defaultHook: func(context.Context, int, func(path string, document *scip.Document) error) (r0 error) {
+				return
+			},
+		},
 		TransactFunc: &LsifStoreTransactFunc{
 			defaultHook: func(context.Context) (r0 lsifstore.LsifStore, r1 error) {
 				return
@@ -7716,6 +8121,11 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.Done")
 			},
 		},
+		GetDocumentSummariesFunc: &LsifStoreGetDocumentSummariesFunc{
+			defaultHook: func(context.Context, int) (map[string]shared.DocumentSummary, error) {
+				panic("unexpected invocation of MockLsifStore.GetDocumentSummaries")
+			},
+		},
 		GetUploadDocumentsForPathFunc: &LsifStoreGetUploadDocumentsForPathFunc{
 			defaultHook: func(context.Context, int, string) ([]string, int, error) {
 				panic("unexpected invocation of MockLsifStore.GetUploadDocumentsForPath")
@@ -7756,6 +8166,11 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.ScanResultChunks")
 			},
 		},
+		ScanSCIPDocumentsFunc: &LsifStoreScanSCIPDocumentsFunc{
+			defaultHook: func(context.Context, int, func(path string, document *scip.Document) error) error {
+				panic("unexpected invocation of MockLsifStore.ScanSCIPDocuments")
+			},
+		},
 		TransactFunc: &LsifStoreTransactFunc{
 			defaultHook: func(context.Context) (lsifstore.LsifStore, error) {
 				panic("unexpected invocation of MockLsifStore.Transact")
@@ -7807,6 +8222,9 @@ func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
 		DoneFunc: &LsifStoreDoneFunc{
 			defaultHook: i.Done,
 		},
+		GetDocumentSummariesFunc: &LsifStoreGetDocumentSummariesFunc{
+			defaultHook: i.GetDocumentSummaries,
+		},
 		GetUploadDocumentsForPathFunc: &LsifStoreGetUploadDocumentsForPathFunc{
 			defaultHook: i.GetUploadDocumentsForPath,
 		},
@@ -7831,6 +8249,9 @@ func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
 		ScanResultChunksFunc: &LsifStoreScanResultChunksFunc{
 			defaultHook: i.ScanResultChunks,
 		},
+		ScanSCIPDocumentsFunc: &LsifStoreScanSCIPDocumentsFunc{
+			defaultHook: i.ScanSCIPDocuments,
+		},
 		TransactFunc: &LsifStoreTransactFunc{
 			defaultHook: i.Transact,
 		},
@@ -8189,6 +8610,115 @@ func (c LsifStoreDoneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// LsifStoreGetDocumentSummariesFunc describes the behavior when the
+// GetDocumentSummaries method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreGetDocumentSummariesFunc struct {
+	defaultHook func(context.Context, int) (map[string]shared.DocumentSummary, error)
+	hooks       []func(context.Context, int) (map[string]shared.DocumentSummary, error)
+	history     []LsifStoreGetDocumentSummariesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDocumentSummaries delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetDocumentSummaries(v0 context.Context, v1 int) (map[string]shared.DocumentSummary, error) {
+	r0, r1 := m.GetDocumentSummariesFunc.nextHook()(v0, v1)
+	m.GetDocumentSummariesFunc.appendCall(LsifStoreGetDocumentSummariesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDocumentSummaries
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreGetDocumentSummariesFunc) SetDefaultHook(hook func(context.Context, int) (map[string]shared.DocumentSummary, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDocumentSummaries method of the parent MockLsifStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LsifStoreGetDocumentSummariesFunc) PushHook(hook func(context.Context, int) (map[string]shared.DocumentSummary, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetDocumentSummariesFunc) SetDefaultReturn(r0 map[string]shared.DocumentSummary, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (map[string]shared.DocumentSummary, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetDocumentSummariesFunc) PushReturn(r0 map[string]shared.DocumentSummary, r1 error) {
+	f.PushHook(func(context.Context, int) (map[string]shared.DocumentSummary, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetDocumentSummariesFunc) nextHook() func(context.Context, int) (map[string]shared.DocumentSummary, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetDocumentSummariesFunc) appendCall(r0 LsifStoreGetDocumentSummariesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetDocumentSummariesFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreGetDocumentSummariesFunc) History() []LsifStoreGetDocumentSummariesFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetDocumentSummariesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetDocumentSummariesFuncCall is an object that describes an
+// invocation of method GetDocumentSummaries on an instance of MockLsifStore.
+type LsifStoreGetDocumentSummariesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]shared.DocumentSummary
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetDocumentSummariesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetDocumentSummariesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LsifStoreGetUploadDocumentsForPathFunc describes the behavior when the
 // GetUploadDocumentsForPath method of the parent MockLsifStore instance is
 // invoked.
@@ -9063,6 +9593,114 @@ func (c LsifStoreScanResultChunksFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// LsifStoreScanSCIPDocumentsFunc describes the behavior when the
+// ScanSCIPDocuments method of the parent MockLsifStore instance is invoked.
+type LsifStoreScanSCIPDocumentsFunc struct {
+	defaultHook func(context.Context, int, func(path string, document *scip.Document) error) error
+	hooks       []func(context.Context, int, func(path string, document *scip.Document) error) error
+	history     []LsifStoreScanSCIPDocumentsFuncCall
+	mutex       sync.Mutex
+}
+
+// ScanSCIPDocuments delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) ScanSCIPDocuments(v0 context.Context, v1 int, v2 func(path string, document *scip.Document) error) error {
+	r0 := m.ScanSCIPDocumentsFunc.nextHook()(v0, v1, v2)
+	m.ScanSCIPDocumentsFunc.appendCall(LsifStoreScanSCIPDocumentsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ScanSCIPDocuments
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreScanSCIPDocumentsFunc) SetDefaultHook(hook func(context.Context, int, func(path string, document *scip.Document) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ScanSCIPDocuments method of the parent MockLsifStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LsifStoreScanSCIPDocumentsFunc) PushHook(hook func(context.Context, int, func(path string, document *scip.Document) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreScanSCIPDocumentsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, func(path string, document *scip.Document) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreScanSCIPDocumentsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, func(path string, document *scip.Document) error) error {
+		return r0
+	})
+}
+
+func (f *LsifStoreScanSCIPDocumentsFunc) nextHook() func(context.Context, int, func(path string, document *scip.Document) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreScanSCIPDocumentsFunc) appendCall(r0 LsifStoreScanSCIPDocumentsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreScanSCIPDocumentsFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreScanSCIPDocumentsFunc) History() []LsifStoreScanSCIPDocumentsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreScanSCIPDocumentsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreScanSCIPDocumentsFuncCall is an object that describes an
+// invocation of method ScanSCIPDocuments on an instance of MockLsifStore.
+type LsifStoreScanSCIPDocumentsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(path string, document *scip.Document) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreScanSCIPDocumentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreScanSCIPDocumentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // LsifStoreTransactFunc describes the behavior when the Transact method of
 // the parent MockLsifStore instance is invoked.
 type LsifStoreTransactFunc struct {
@@ -46,6 +46,8 @@ type operations struct {
 	softDeleteExpiredUploadsViaTraversal *observation.Operation
 	hardDeleteUploadsByIDs               *observation.Operation
 	deleteLsifDataByUploadIds            *observation.Operation
+	getPreviousUpload                    *observation.Operation
+	diffUploads                          *observation.Operation
 
 	// Dumps
 	getDumpsWithDefinitionsForMonikers *observation.Operation
@@ -159,6 +161,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 		softDeleteExpiredUploadsViaTraversal: op("SoftDeleteExpiredUploadsViaTraversal"),
 		hardDeleteUploadsByIDs:               op("HardDeleteUploadsByIDs"),
 		deleteLsifDataByUploadIds:            op("DeleteLsifDataByUploadIds"),
+		getPreviousUpload:                    op("GetPreviousUpload"),
+		diffUploads:                          op("DiffUploads"),
 
 		// Dumps
 		getDumpsWithDefinitionsForMonikers: op("GetDumpsWithDefinitionsForMonikers"),
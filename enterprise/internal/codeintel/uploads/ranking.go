@@ -14,17 +14,25 @@ import (
 	"google.golang.org/api/iterator"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/lib/group"
 )
 
 func (s *Service) SerializeRankingGraph(ctx context.Context, numRankingRoutines int) error {
+	persist := s.serializeAndPersistRankingGraphForUpload
 	if s.rankingBucket == nil {
-		return nil
+		if !rankingInProcess {
+			return nil
+		}
+
+		// Without a bucket to export to, write the symbols of each upload to Postgres
+		// so that the ranking service can compute document ranks in-process.
+		persist = s.mapAndPersistRankingSymbolsForUpload
 	}
 
-	uploads, err := s.store.GetUploadsForRanking(ctx, rankingGraphKey, "ranking", rankingGraphBatchSize)
+	uploads, err := s.store.GetUploadsForRanking(ctx, shared.RankingGraphKey(), "ranking", rankingGraphBatchSize)
 	if err != nil {
 		return err
 	}
@@ -40,7 +48,7 @@ func (s *Service) SerializeRankingGraph(ctx context.Context, numRankingRoutines
 	for i := 0; i < numRankingRoutines; i++ {
 		g.Go(func(ctx context.Context) error {
 			for upload := range sharedUploads {
-				if err := s.serializeAndPersistRankingGraphForUpload(ctx, upload.ID, upload.Repo, upload.Root, upload.ObjectPrefix); err != nil {
+				if err := persist(ctx, upload); err != nil {
 					s.logger.Error(
 						"Failed to process upload for ranking graph",
 						log.Int("id", upload.ID),
@@ -74,10 +82,14 @@ func (s *Service) SerializeRankingGraph(ctx context.Context, numRankingRoutines
 
 func (s *Service) VacuumRankingGraph(ctx context.Context) error {
 	if s.rankingBucket == nil {
-		return nil
+		if !rankingInProcess {
+			return nil
+		}
+
+		return s.vacuumRankingSymbols(ctx)
 	}
 
-	numDeleted, err := s.store.ProcessStaleExportedUploads(ctx, rankingGraphKey, rankingGraphDeleteBatchSize, func(ctx context.Context, objectPrefix string) error {
+	numDeleted, err := s.store.ProcessStaleExportedUploads(ctx, shared.RankingGraphKey(), rankingGraphDeleteBatchSize, func(ctx context.Context, objectPrefix string) error {
 		if objectPrefix == "" {
 			// Special case: we haven't backfilled some data on dotcom yet
 			return nil
@@ -115,13 +127,7 @@ func (s *Service) VacuumRankingGraph(ctx context.Context) error {
 
 const maxBytesPerObject = 1024 * 1024 * 1024 // 1GB
 
-func (s *Service) serializeAndPersistRankingGraphForUpload(
-	ctx context.Context,
-	id int,
-	repo string,
-	root string,
-	objectPrefix string,
-) (err error) {
+func (s *Service) serializeAndPersistRankingGraphForUpload(ctx context.Context, upload store.ExportedUpload) (err error) {
 	writers := map[string]*gcsObjectWriter{}
 	defer func() {
 		for _, wc := range writers {
@@ -131,8 +137,8 @@ func (s *Service) serializeAndPersistRankingGraphForUpload(
 		}
 	}()
 
-	return s.serializeRankingGraphForUpload(ctx, id, repo, root, func(filename string, format string, args ...any) error {
-		path := fmt.Sprintf("%s/%s", objectPrefix, filename)
+	return s.serializeRankingGraphForUpload(ctx, upload.ID, upload.Repo, upload.Root, func(filename string, format string, args ...any) error {
+		path := fmt.Sprintf("%s/%s", upload.ObjectPrefix, filename)
 
 		ow, ok := writers[path]
 		if !ok {
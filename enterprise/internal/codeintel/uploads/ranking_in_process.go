@@ -0,0 +1,85 @@
+package uploads
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+)
+
+// mapAndPersistRankingSymbolsForUpload writes the symbols defined and referenced by the
+// documents of the given upload to Postgres. The ranking service joins references and
+// definitions across all exported uploads to count the references to each document.
+func (s *Service) mapAndPersistRankingSymbolsForUpload(ctx context.Context, upload store.ExportedUpload) error {
+	var definitions []store.RankingDefinition
+	references := map[string]int{}
+
+	if err := s.lsifstore.ScanSCIPDocuments(ctx, upload.ID, func(path string, document *scip.Document) error {
+		documentDefinitions, documentReferences := rankingSymbolsForDocument(filepath.Join(upload.Root, path), document)
+		definitions = append(definitions, documentDefinitions...)
+		for _, symbolName := range documentReferences {
+			references[symbolName]++
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return s.store.InsertSymbolsForRanking(ctx, upload, shared.RankingGraphKey(), definitions, references)
+}
+
+// rankingSymbolsForDocument returns the non-local symbols defined by the given document and
+// the ones it references without defining them. Both lists are sorted and free of duplicates.
+func rankingSymbolsForDocument(path string, document *scip.Document) (definitions []store.RankingDefinition, references []string) {
+	defined := map[string]struct{}{}
+	referenced := map[string]struct{}{}
+	for _, occurrence := range document.Occurrences {
+		if occurrence.Symbol == "" || scip.IsLocalSymbol(occurrence.Symbol) {
+			continue
+		}
+
+		if occurrence.SymbolRoles&int32(scip.SymbolRole_Definition) != 0 {
+			defined[occurrence.Symbol] = struct{}{}
+		} else {
+			referenced[occurrence.Symbol] = struct{}{}
+		}
+	}
+
+	for symbolName := range defined {
+		definitions = append(definitions, store.RankingDefinition{SymbolName: symbolName, DocumentPath: path})
+	}
+	for symbolName := range referenced {
+		if _, ok := defined[symbolName]; !ok {
+			references = append(references, symbolName)
+		}
+	}
+
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].SymbolName < definitions[j].SymbolName })
+	sort.Strings(references)
+	return definitions, references
+}
+
+// vacuumRankingSymbols deletes the symbols of uploads that are no longer visible at the tip
+// of the default branch, as well as the ones exported under a previous graph key.
+func (s *Service) vacuumRankingSymbols(ctx context.Context) error {
+	numExportsDeleted, err := s.store.ProcessStaleExportedUploads(ctx, shared.RankingGraphKey(), rankingGraphDeleteBatchSize, func(ctx context.Context, objectPrefix string) error {
+		// Nothing was written to a bucket
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	numSymbolsDeleted, err := s.store.VacuumStaleRankingSymbols(ctx, shared.RankingGraphKey(), rankingSymbolsDeleteBatchSize)
+	if err != nil {
+		return err
+	}
+
+	s.operations.numStaleRecordsDeleted.Add(float64(numExportsDeleted + numSymbolsDeleted))
+	return nil
+}
@@ -0,0 +1,46 @@
+package uploads
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/store"
+)
+
+func TestRankingSymbolsForDocument(t *testing.T) {
+	const (
+		util   = "scip-go gomod example v1 `example/internal`/Util()."
+		helper = "scip-go gomod example v1 `example/internal`/helper()."
+		fmt    = "scip-go gomod github.com/golang/go v1 fmt/Println()."
+	)
+
+	document := &scip.Document{
+		Occurrences: []*scip.Occurrence{
+			{Symbol: util, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Symbol: helper, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Symbol: helper},
+			{Symbol: fmt},
+			{Symbol: fmt},
+			{Symbol: "local 0", SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Symbol: "local 0"},
+			{Symbol: ""},
+		},
+	}
+
+	definitions, references := rankingSymbolsForDocument("internal/util.go", document)
+
+	expectedDefinitions := []store.RankingDefinition{
+		{SymbolName: util, DocumentPath: "internal/util.go"},
+		{SymbolName: helper, DocumentPath: "internal/util.go"},
+	}
+	if diff := cmp.Diff(expectedDefinitions, definitions); diff != "" {
+		t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+	}
+
+	// References to symbols defined in the same document are not counted
+	if diff := cmp.Diff([]string{fmt}, references); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+}
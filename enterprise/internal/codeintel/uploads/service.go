@@ -139,6 +139,55 @@ func (s *Service) GetUploadsByIDs(ctx context.Context, ids ...int) (_ []types.Up
 	return s.store.GetUploadsByIDs(ctx, ids...)
 }
 
+func (s *Service) GetPreviousUpload(ctx context.Context, upload types.Upload) (_ types.Upload, _ bool, err error) {
+	ctx, _, endObservation := s.operations.getPreviousUpload.With(ctx, &err, observation.Args{LogFields: []log.Field{log.Int("id", upload.ID)}})
+	defer endObservation(1, observation.Args{})
+
+	return s.store.GetPreviousUpload(ctx, upload)
+}
+
+// DiffUploads compares the documents, symbols, and occurrences of two completed uploads for the
+// same repository, root, and indexer. Both uploads must have been processed since document
+// summaries are recorded.
+func (s *Service) DiffUploads(ctx context.Context, base, head types.Upload) (_ shared.UploadDiff, err error) {
+	ctx, _, endObservation := s.operations.diffUploads.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("baseID", base.ID),
+		log.Int("headID", head.ID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if base.State != "completed" || head.State != "completed" {
+		return shared.UploadDiff{}, errors.New("only completed uploads can be compared")
+	}
+	if base.RepositoryID != head.RepositoryID || base.Root != head.Root || base.Indexer != head.Indexer {
+		return shared.UploadDiff{}, errors.New("only uploads for the same repository, root, and indexer can be compared")
+	}
+
+	baseSummaries, err := s.lsifstore.GetDocumentSummaries(ctx, base.ID)
+	if err != nil {
+		return shared.UploadDiff{}, err
+	}
+	headSummaries, err := s.lsifstore.GetDocumentSummaries(ctx, head.ID)
+	if err != nil {
+		return shared.UploadDiff{}, err
+	}
+	for _, upload := range []struct {
+		id        int
+		summaries map[string]shared.DocumentSummary
+	}{{base.ID, baseSummaries}, {head.ID, headSummaries}} {
+		if len(upload.summaries) == 0 {
+			return shared.UploadDiff{}, errors.Newf("upload %d has no recorded document summaries, re-index the commit to compare it", upload.id)
+		}
+	}
+
+	missingDefinitions, err := s.lsifstore.GetMissingDefinitions(ctx, base.ID, head.ID)
+	if err != nil {
+		return shared.UploadDiff{}, err
+	}
+
+	return shared.DiffUploadSummaries(baseSummaries, headSummaries, missingDefinitions), nil
+}
+
 func (s *Service) GetUploadIDsWithReferences(ctx context.Context, orderedMonikers []precise.QualifiedMonikerData, ignoreIDs []int, repositoryID int, commit string, limit int, offset int) (ids []int, recordsScanned int, totalCount int, err error) {
 	ctx, trace, endObservation := s.operations.getVisibleUploadsMatchingMonikers.With(ctx, &err, observation.Args{
 		LogFields: []log.Field{
@@ -0,0 +1,98 @@
+package shared
+
+import "sort"
+
+// DocumentSummary describes the data an upload contains for a single document. It is
+// recorded when the upload is processed.
+type DocumentSummary struct {
+	// Occurrences is the number of symbol occurrences (or ranges, for LSIF uploads)
+	// in the document.
+	Occurrences int
+
+	// Definitions is the number of non-local symbols (or exported monikers, for LSIF
+	// uploads) defined in the document.
+	Definitions int
+}
+
+// UploadDiff describes the differences between the data of two uploads for the same
+// repository, root, and indexer.
+type UploadDiff struct {
+	AddedDocuments      []string
+	RemovedDocuments    []string
+	ChangedDocuments    []DocumentDiff
+	MissingDefinitions  []MissingDefinition
+	BaseSymbolCount     int
+	HeadSymbolCount     int
+	BaseOccurrenceCount int
+	HeadOccurrenceCount int
+}
+
+// DocumentDiff describes a document present in both uploads for which the number of
+// defined symbols or occurrences differs.
+type DocumentDiff struct {
+	Path                string
+	BaseSymbolCount     int
+	HeadSymbolCount     int
+	BaseOccurrenceCount int
+	HeadOccurrenceCount int
+}
+
+// MissingDefinition is a symbol defined by the base upload that is no longer defined
+// anywhere in the head upload.
+type MissingDefinition struct {
+	Symbol string
+	Path   string
+}
+
+// DiffUploadSummaries compares the per-document summaries of a base and a head upload,
+// given the symbols defined by the base upload that are no longer defined by the head
+// upload. All slices of the resulting diff are ordered by path, then by symbol.
+func DiffUploadSummaries(base, head map[string]DocumentSummary, missingDefinitions []MissingDefinition) UploadDiff {
+	diff := UploadDiff{
+		MissingDefinitions: append([]MissingDefinition(nil), missingDefinitions...),
+	}
+
+	for path, headSummary := range head {
+		diff.HeadSymbolCount += headSummary.Definitions
+		diff.HeadOccurrenceCount += headSummary.Occurrences
+
+		baseSummary, ok := base[path]
+		if !ok {
+			diff.AddedDocuments = append(diff.AddedDocuments, path)
+			continue
+		}
+
+		if baseSummary.Definitions != headSummary.Definitions || baseSummary.Occurrences != headSummary.Occurrences {
+			diff.ChangedDocuments = append(diff.ChangedDocuments, DocumentDiff{
+				Path:                path,
+				BaseSymbolCount:     baseSummary.Definitions,
+				HeadSymbolCount:     headSummary.Definitions,
+				BaseOccurrenceCount: baseSummary.Occurrences,
+				HeadOccurrenceCount: headSummary.Occurrences,
+			})
+		}
+	}
+
+	for path, baseSummary := range base {
+		diff.BaseSymbolCount += baseSummary.Definitions
+		diff.BaseOccurrenceCount += baseSummary.Occurrences
+
+		if _, ok := head[path]; !ok {
+			diff.RemovedDocuments = append(diff.RemovedDocuments, path)
+		}
+	}
+
+	sort.Strings(diff.AddedDocuments)
+	sort.Strings(diff.RemovedDocuments)
+	sort.Slice(diff.ChangedDocuments, func(i, j int) bool {
+		return diff.ChangedDocuments[i].Path < diff.ChangedDocuments[j].Path
+	})
+	sort.Slice(diff.MissingDefinitions, func(i, j int) bool {
+		if diff.MissingDefinitions[i].Path != diff.MissingDefinitions[j].Path {
+			return diff.MissingDefinitions[i].Path < diff.MissingDefinitions[j].Path
+		}
+		return diff.MissingDefinitions[i].Symbol < diff.MissingDefinitions[j].Symbol
+	})
+
+	return diff
+}
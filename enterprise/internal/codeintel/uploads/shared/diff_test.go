@@ -0,0 +1,47 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffUploadSummaries(t *testing.T) {
+	base := map[string]DocumentSummary{
+		"a.go": {Occurrences: 10, Definitions: 2},
+		"b.go": {Occurrences: 5, Definitions: 2},
+		"c.go": {Occurrences: 3, Definitions: 1},
+		"d.go": {Occurrences: 7, Definitions: 1},
+	}
+	head := map[string]DocumentSummary{
+		"a.go": {Occurrences: 10, Definitions: 2},
+		"b.go": {Occurrences: 2, Definitions: 1},
+		"d.go": {Occurrences: 7, Definitions: 1},
+		"e.go": {Occurrences: 4, Definitions: 2},
+	}
+	missingDefinitions := []MissingDefinition{
+		{Symbol: "d", Path: "b.go"},
+		{Symbol: "b", Path: "a.go"},
+		{Symbol: "a", Path: "b.go"},
+	}
+
+	expected := UploadDiff{
+		AddedDocuments:   []string{"e.go"},
+		RemovedDocuments: []string{"c.go"},
+		ChangedDocuments: []DocumentDiff{
+			{Path: "b.go", BaseSymbolCount: 2, HeadSymbolCount: 1, BaseOccurrenceCount: 5, HeadOccurrenceCount: 2},
+		},
+		MissingDefinitions: []MissingDefinition{
+			{Symbol: "b", Path: "a.go"},
+			{Symbol: "a", Path: "b.go"},
+			{Symbol: "d", Path: "b.go"},
+		},
+		BaseSymbolCount:     6,
+		HeadSymbolCount:     6,
+		BaseOccurrenceCount: 25,
+		HeadOccurrenceCount: 23,
+	}
+	if diff := cmp.Diff(expected, DiffUploadSummaries(base, head, missingDefinitions)); diff != "" {
+		t.Errorf("unexpected upload diff (-want +got):\n%s", diff)
+	}
+}
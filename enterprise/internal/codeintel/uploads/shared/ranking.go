@@ -0,0 +1,17 @@
+package shared
+
+import "github.com/sourcegraph/sourcegraph/internal/env"
+
+var rankingGraphKey = env.Get("CODEINTEL_RANKING_GRAPH_KEY", "dev", "An identifier of the ranking graph. Change to start a new export to, and import from, the configured buckets, or to recompute in-process ranks from scratch.")
+
+// RankingGraphKey returns the identifier of the ranking graph. The uploads service exports
+// symbols under this key, and the ranking service imports or computes document ranks from
+// the symbols exported under the same key.
+func RankingGraphKey() string {
+	if rankingGraphKey == "" {
+		// The codenav default
+		return "dev"
+	}
+
+	return rankingGraphKey
+}
@@ -19,6 +19,8 @@ type UploadService interface {
 	GetUploadDocumentsForPath(ctx context.Context, bundleID int, pathPattern string) (_ []string, _ int, err error)
 	GetUploads(ctx context.Context, opts uploadsshared.GetUploadsOptions) (uploads []types.Upload, totalCount int, err error)
 	GetUploadsByIDs(ctx context.Context, ids ...int) (_ []types.Upload, err error)
+	GetPreviousUpload(ctx context.Context, upload types.Upload) (_ types.Upload, _ bool, err error)
+	DiffUploads(ctx context.Context, base, head types.Upload) (_ uploadsshared.UploadDiff, err error)
 	DeleteUploadByID(ctx context.Context, id int) (_ bool, err error)
 	DeleteUploads(ctx context.Context, opts uploadsshared.DeleteUploadsOptions) (err error)
 }
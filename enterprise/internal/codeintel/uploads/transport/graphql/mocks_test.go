@@ -883,6 +883,9 @@ type MockUploadService struct {
 	// DeleteUploadsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteUploads.
 	DeleteUploadsFunc *UploadServiceDeleteUploadsFunc
+	// DiffUploadsFunc is an instance of a mock function object controlling
+	// the behavior of the method DiffUploads.
+	DiffUploadsFunc *UploadServiceDiffUploadsFunc
 	// GetAuditLogsForUploadFunc is an instance of a mock function object
 	// controlling the behavior of the method GetAuditLogsForUpload.
 	GetAuditLogsForUploadFunc *UploadServiceGetAuditLogsForUploadFunc
@@ -892,6 +895,9 @@ type MockUploadService struct {
 	// GetListTagsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetListTags.
 	GetListTagsFunc *UploadServiceGetListTagsFunc
+	// GetPreviousUploadFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPreviousUpload.
+	GetPreviousUploadFunc *UploadServiceGetPreviousUploadFunc
 	// GetUploadDocumentsForPathFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// GetUploadDocumentsForPath.
@@ -918,6 +924,11 @@ func NewMockUploadService() *MockUploadService {
 				return
 			},
 		},
+		DiffUploadsFunc: &UploadServiceDiffUploadsFunc{
+			defaultHook: func(context.Context, types.Upload, types.Upload) (r0 shared1.UploadDiff, r1 error) {
+				return
+			},
+		},
 		GetAuditLogsForUploadFunc: &UploadServiceGetAuditLogsForUploadFunc{
 			defaultHook: func(context.Context, int) (r0 []types.UploadLog, r1 error) {
 				return
@@ -933,6 +944,11 @@ func NewMockUploadService() *MockUploadService {
 				return
 			},
 		},
+		GetPreviousUploadFunc: &UploadServiceGetPreviousUploadFunc{
+			defaultHook: func(context.Context, types.Upload) (r0 types.Upload, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetUploadDocumentsForPathFunc: &UploadServiceGetUploadDocumentsForPathFunc{
 			defaultHook: func(context.Context, int, string) (r0 []string, r1 int, r2 error) {
 				return
@@ -965,6 +981,11 @@ func NewStrictMockUploadService() *MockUploadService {
 				panic("unexpected invocation of MockUploadService.DeleteUploads")
 			},
 		},
+		DiffUploadsFunc: &UploadServiceDiffUploadsFunc{
+			defaultHook: func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error) {
+				panic("unexpected invocation of MockUploadService.DiffUploads")
+			},
+		},
 		GetAuditLogsForUploadFunc: &UploadServiceGetAuditLogsForUploadFunc{
 			defaultHook: func(context.Context, int) ([]types.UploadLog, error) {
 				panic("unexpected invocation of MockUploadService.GetAuditLogsForUpload")
@@ -980,6 +1001,11 @@ func NewStrictMockUploadService() *MockUploadService {
 				panic("unexpected invocation of MockUploadService.GetListTags")
 			},
 		},
+		GetPreviousUploadFunc: &UploadServiceGetPreviousUploadFunc{
+			defaultHook: func(context.Context, types.Upload) (types.Upload, bool, error) {
+				panic("unexpected invocation of MockUploadService.GetPreviousUpload")
+			},
+		},
 		GetUploadDocumentsForPathFunc: &UploadServiceGetUploadDocumentsForPathFunc{
 			defaultHook: func(context.Context, int, string) ([]string, int, error) {
 				panic("unexpected invocation of MockUploadService.GetUploadDocumentsForPath")
@@ -1009,6 +1035,9 @@ func NewMockUploadServiceFrom(i UploadService) *MockUploadService {
 		DeleteUploadsFunc: &UploadServiceDeleteUploadsFunc{
 			defaultHook: i.DeleteUploads,
 		},
+		DiffUploadsFunc: &UploadServiceDiffUploadsFunc{
+			defaultHook: i.DiffUploads,
+		},
 		GetAuditLogsForUploadFunc: &UploadServiceGetAuditLogsForUploadFunc{
 			defaultHook: i.GetAuditLogsForUpload,
 		},
@@ -1018,6 +1047,9 @@ func NewMockUploadServiceFrom(i UploadService) *MockUploadService {
 		GetListTagsFunc: &UploadServiceGetListTagsFunc{
 			defaultHook: i.GetListTags,
 		},
+		GetPreviousUploadFunc: &UploadServiceGetPreviousUploadFunc{
+			defaultHook: i.GetPreviousUpload,
+		},
 		GetUploadDocumentsForPathFunc: &UploadServiceGetUploadDocumentsForPathFunc{
 			defaultHook: i.GetUploadDocumentsForPath,
 		},
@@ -1245,6 +1277,117 @@ func (c UploadServiceDeleteUploadsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// UploadServiceDiffUploadsFunc describes the behavior when the DiffUploads
+// method of the parent MockUploadService instance is invoked.
+type UploadServiceDiffUploadsFunc struct {
+	defaultHook func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error)
+	hooks       []func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error)
+	history     []UploadServiceDiffUploadsFuncCall
+	mutex       sync.Mutex
+}
+
+// DiffUploads delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUploadService) DiffUploads(v0 context.Context, v1 types.Upload, v2 types.Upload) (shared1.UploadDiff, error) {
+	r0, r1 := m.DiffUploadsFunc.nextHook()(v0, v1, v2)
+	m.DiffUploadsFunc.appendCall(UploadServiceDiffUploadsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DiffUploads method
+// of the parent MockUploadService instance is invoked and the hook queue is
+// empty.
+func (f *UploadServiceDiffUploadsFunc) SetDefaultHook(hook func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DiffUploads method of the parent MockUploadService instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UploadServiceDiffUploadsFunc) PushHook(hook func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadServiceDiffUploadsFunc) SetDefaultReturn(r0 shared1.UploadDiff, r1 error) {
+	f.SetDefaultHook(func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadServiceDiffUploadsFunc) PushReturn(r0 shared1.UploadDiff, r1 error) {
+	f.PushHook(func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error) {
+		return r0, r1
+	})
+}
+
+func (f *UploadServiceDiffUploadsFunc) nextHook() func(context.Context, types.Upload, types.Upload) (shared1.UploadDiff, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadServiceDiffUploadsFunc) appendCall(r0 UploadServiceDiffUploadsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UploadServiceDiffUploadsFuncCall objects
+// describing the invocations of this function.
+func (f *UploadServiceDiffUploadsFunc) History() []UploadServiceDiffUploadsFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadServiceDiffUploadsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadServiceDiffUploadsFuncCall is an object that describes an
+// invocation of method DiffUploads on an instance of MockUploadService.
+type UploadServiceDiffUploadsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.Upload
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 types.Upload
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared1.UploadDiff
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadServiceDiffUploadsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadServiceDiffUploadsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // UploadServiceGetAuditLogsForUploadFunc describes the behavior when the
 // GetAuditLogsForUpload method of the parent MockUploadService instance is
 // invoked.
@@ -1588,6 +1731,119 @@ func (c UploadServiceGetListTagsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// UploadServiceGetPreviousUploadFunc describes the behavior when the
+// GetPreviousUpload method of the parent MockUploadService instance is
+// invoked.
+type UploadServiceGetPreviousUploadFunc struct {
+	defaultHook func(context.Context, types.Upload) (types.Upload, bool, error)
+	hooks       []func(context.Context, types.Upload) (types.Upload, bool, error)
+	history     []UploadServiceGetPreviousUploadFuncCall
+	mutex       sync.Mutex
+}
+
+// GetPreviousUpload delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUploadService) GetPreviousUpload(v0 context.Context, v1 types.Upload) (types.Upload, bool, error) {
+	r0, r1, r2 := m.GetPreviousUploadFunc.nextHook()(v0, v1)
+	m.GetPreviousUploadFunc.appendCall(UploadServiceGetPreviousUploadFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetPreviousUpload
+// method of the parent MockUploadService instance is invoked and the hook
+// queue is empty.
+func (f *UploadServiceGetPreviousUploadFunc) SetDefaultHook(hook func(context.Context, types.Upload) (types.Upload, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetPreviousUpload method of the parent MockUploadService instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UploadServiceGetPreviousUploadFunc) PushHook(hook func(context.Context, types.Upload) (types.Upload, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadServiceGetPreviousUploadFunc) SetDefaultReturn(r0 types.Upload, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, types.Upload) (types.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadServiceGetPreviousUploadFunc) PushReturn(r0 types.Upload, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, types.Upload) (types.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *UploadServiceGetPreviousUploadFunc) nextHook() func(context.Context, types.Upload) (types.Upload, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadServiceGetPreviousUploadFunc) appendCall(r0 UploadServiceGetPreviousUploadFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UploadServiceGetPreviousUploadFuncCall
+// objects describing the invocations of this function.
+func (f *UploadServiceGetPreviousUploadFunc) History() []UploadServiceGetPreviousUploadFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadServiceGetPreviousUploadFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadServiceGetPreviousUploadFuncCall is an object that describes an
+// invocation of method GetPreviousUpload on an instance of
+// MockUploadService.
+type UploadServiceGetPreviousUploadFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.Upload
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 types.Upload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadServiceGetPreviousUploadFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadServiceGetPreviousUploadFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // UploadServiceGetUploadDocumentsForPathFunc describes the behavior when
 // the GetUploadDocumentsForPath method of the parent MockUploadService
 // instance is invoked.
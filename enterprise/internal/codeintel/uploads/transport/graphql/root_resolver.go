@@ -7,9 +7,9 @@ import (
 	"github.com/opentracing/opentracing-go/log"
 
 	sharedresolvers "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/resolvers"
-	"github.com/sourcegraph/sourcegraph/internal/auth"
 	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 )
 
 type rootResolver struct {
@@ -99,14 +99,14 @@ func (r *rootResolver) LSIFUploadsByRepo(ctx context.Context, args *resolverstub
 	return sharedresolvers.NewUploadConnectionResolver(r.uploadSvc, r.autoindexSvc, r.policySvc, uploadsResolver, prefetcher, traceErrs), nil
 }
 
-// 🚨 SECURITY: Only site admins may modify code intelligence upload data
+// 🚨 SECURITY: Only site admins and users with permission to delete uploads may modify code intelligence upload data
 func (r *rootResolver) DeleteLSIFUpload(ctx context.Context, args *struct{ ID graphql.ID }) (_ *resolverstubs.EmptyResponse, err error) {
 	ctx, _, endObservation := r.operations.deleteLsifUpload.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("uploadID", string(args.ID)),
 	}})
 	endObservation.OnCancel(ctx, 1, observation.Args{})
 
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.autoindexSvc.GetUnsafeDB()); err != nil {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.autoindexSvc.GetUnsafeDB(), rbac.CodeIntelUploadsDelete); err != nil {
 		return nil, err
 	}
 
@@ -122,12 +122,12 @@ func (r *rootResolver) DeleteLSIFUpload(ctx context.Context, args *struct{ ID gr
 	return &resolverstubs.EmptyResponse{}, nil
 }
 
-// 🚨 SECURITY: Only site admins may modify code intelligence upload data
+// 🚨 SECURITY: Only site admins and users with permission to delete uploads may modify code intelligence upload data
 func (r *rootResolver) DeleteLSIFUploads(ctx context.Context, args *resolverstubs.DeleteLSIFUploadsArgs) (_ *resolverstubs.EmptyResponse, err error) {
 	ctx, _, endObservation := r.operations.deleteLsifUploads.With(ctx, &err, observation.Args{})
 	endObservation.OnCancel(ctx, 1, observation.Args{})
 
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.autoindexSvc.GetUnsafeDB()); err != nil {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.autoindexSvc.GetUnsafeDB(), rbac.CodeIntelUploadsDelete); err != nil {
 		return nil, err
 	}
 
@@ -6901,6 +6901,9 @@ type MockEnterpriseDB struct {
 	// RepoStatisticsFunc is an instance of a mock function object
 	// controlling the behavior of the method RepoStatistics.
 	RepoStatisticsFunc *EnterpriseDBRepoStatisticsFunc
+	// RepoUpdateScheduleFunc is an instance of a mock function object
+	// controlling the behavior of the method RepoUpdateSchedule.
+	RepoUpdateScheduleFunc *EnterpriseDBRepoUpdateScheduleFunc
 	// ReposFunc is an instance of a mock function object controlling the
 	// behavior of the method Repos.
 	ReposFunc *EnterpriseDBReposFunc
@@ -6919,6 +6922,9 @@ type MockEnterpriseDB struct {
 	// SettingsFunc is an instance of a mock function object controlling the
 	// behavior of the method Settings.
 	SettingsFunc *EnterpriseDBSettingsFunc
+	// SubRepoPermissionRulesFunc is an instance of a mock function object
+	// controlling the behavior of the method SubRepoPermissionRules.
+	SubRepoPermissionRulesFunc *EnterpriseDBSubRepoPermissionRulesFunc
 	// SubRepoPermsFunc is an instance of a mock function object controlling
 	// the behavior of the method SubRepoPerms.
 	SubRepoPermsFunc *EnterpriseDBSubRepoPermsFunc
@@ -7103,6 +7109,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		RepoUpdateScheduleFunc: &EnterpriseDBRepoUpdateScheduleFunc{
+			defaultHook: func() (r0 database.RepoUpdateScheduleStore) {
+				return
+			},
+		},
 		ReposFunc: &EnterpriseDBReposFunc{
 			defaultHook: func() (r0 database.RepoStore) {
 				return
@@ -7133,6 +7144,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		SubRepoPermissionRulesFunc: &EnterpriseDBSubRepoPermissionRulesFunc{
+			defaultHook: func() (r0 database.SubRepoPermissionRuleStore) {
+				return
+			},
+		},
 		SubRepoPermsFunc: &EnterpriseDBSubRepoPermsFunc{
 			defaultHook: func() (r0 database.SubRepoPermsStore) {
 				return
@@ -7340,6 +7356,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.RepoStatistics")
 			},
 		},
+		RepoUpdateScheduleFunc: &EnterpriseDBRepoUpdateScheduleFunc{
+			defaultHook: func() database.RepoUpdateScheduleStore {
+				panic("unexpected invocation of MockEnterpriseDB.RepoUpdateSchedule")
+			},
+		},
 		ReposFunc: &EnterpriseDBReposFunc{
 			defaultHook: func() database.RepoStore {
 				panic("unexpected invocation of MockEnterpriseDB.Repos")
@@ -7370,6 +7391,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.Settings")
 			},
 		},
+		SubRepoPermissionRulesFunc: &EnterpriseDBSubRepoPermissionRulesFunc{
+			defaultHook: func() database.SubRepoPermissionRuleStore {
+				panic("unexpected invocation of MockEnterpriseDB.SubRepoPermissionRules")
+			},
+		},
 		SubRepoPermsFunc: &EnterpriseDBSubRepoPermsFunc{
 			defaultHook: func() database.SubRepoPermsStore {
 				panic("unexpected invocation of MockEnterpriseDB.SubRepoPerms")
@@ -7520,6 +7546,9 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		RepoStatisticsFunc: &EnterpriseDBRepoStatisticsFunc{
 			defaultHook: i.RepoStatistics,
 		},
+		RepoUpdateScheduleFunc: &EnterpriseDBRepoUpdateScheduleFunc{
+			defaultHook: i.RepoUpdateSchedule,
+		},
 		ReposFunc: &EnterpriseDBReposFunc{
 			defaultHook: i.Repos,
 		},
@@ -7538,6 +7567,9 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		SettingsFunc: &EnterpriseDBSettingsFunc{
 			defaultHook: i.Settings,
 		},
+		SubRepoPermissionRulesFunc: &EnterpriseDBSubRepoPermissionRulesFunc{
+			defaultHook: i.SubRepoPermissionRules,
+		},
 		SubRepoPermsFunc: &EnterpriseDBSubRepoPermsFunc{
 			defaultHook: i.SubRepoPerms,
 		},
@@ -10513,6 +10545,107 @@ func (c EnterpriseDBRepoStatisticsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBRepoUpdateScheduleFunc describes the behavior when the
+// RepoUpdateSchedule method of the parent MockEnterpriseDB instance is
+// invoked.
+type EnterpriseDBRepoUpdateScheduleFunc struct {
+	defaultHook func() database.RepoUpdateScheduleStore
+	hooks       []func() database.RepoUpdateScheduleStore
+	history     []EnterpriseDBRepoUpdateScheduleFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoUpdateSchedule delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) RepoUpdateSchedule() database.RepoUpdateScheduleStore {
+	r0 := m.RepoUpdateScheduleFunc.nextHook()()
+	m.RepoUpdateScheduleFunc.appendCall(EnterpriseDBRepoUpdateScheduleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RepoUpdateSchedule
+// method of the parent MockEnterpriseDB instance is invoked and the hook
+// queue is empty.
+func (f *EnterpriseDBRepoUpdateScheduleFunc) SetDefaultHook(hook func() database.RepoUpdateScheduleStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoUpdateSchedule method of the parent MockEnterpriseDB instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *EnterpriseDBRepoUpdateScheduleFunc) PushHook(hook func() database.RepoUpdateScheduleStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBRepoUpdateScheduleFunc) SetDefaultReturn(r0 database.RepoUpdateScheduleStore) {
+	f.SetDefaultHook(func() database.RepoUpdateScheduleStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBRepoUpdateScheduleFunc) PushReturn(r0 database.RepoUpdateScheduleStore) {
+	f.PushHook(func() database.RepoUpdateScheduleStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBRepoUpdateScheduleFunc) nextHook() func() database.RepoUpdateScheduleStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBRepoUpdateScheduleFunc) appendCall(r0 EnterpriseDBRepoUpdateScheduleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBRepoUpdateScheduleFuncCall
+// objects describing the invocations of this function.
+func (f *EnterpriseDBRepoUpdateScheduleFunc) History() []EnterpriseDBRepoUpdateScheduleFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBRepoUpdateScheduleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBRepoUpdateScheduleFuncCall is an object that describes an
+// invocation of method RepoUpdateSchedule on an instance of
+// MockEnterpriseDB.
+type EnterpriseDBRepoUpdateScheduleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.RepoUpdateScheduleStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBRepoUpdateScheduleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBRepoUpdateScheduleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBReposFunc describes the behavior when the Repos method of the
 // parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBReposFunc struct {
@@ -11107,6 +11240,108 @@ func (c EnterpriseDBSettingsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBSubRepoPermissionRulesFunc describes the behavior when the
+// SubRepoPermissionRules method of the parent MockEnterpriseDB instance is
+// invoked.
+type EnterpriseDBSubRepoPermissionRulesFunc struct {
+	defaultHook func() database.SubRepoPermissionRuleStore
+	hooks       []func() database.SubRepoPermissionRuleStore
+	history     []EnterpriseDBSubRepoPermissionRulesFuncCall
+	mutex       sync.Mutex
+}
+
+// SubRepoPermissionRules delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) SubRepoPermissionRules() database.SubRepoPermissionRuleStore {
+	r0 := m.SubRepoPermissionRulesFunc.nextHook()()
+	m.SubRepoPermissionRulesFunc.appendCall(EnterpriseDBSubRepoPermissionRulesFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// SubRepoPermissionRules method of the parent MockEnterpriseDB instance is
+// invoked and the hook queue is empty.
+func (f *EnterpriseDBSubRepoPermissionRulesFunc) SetDefaultHook(hook func() database.SubRepoPermissionRuleStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SubRepoPermissionRules method of the parent MockEnterpriseDB instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EnterpriseDBSubRepoPermissionRulesFunc) PushHook(hook func() database.SubRepoPermissionRuleStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBSubRepoPermissionRulesFunc) SetDefaultReturn(r0 database.SubRepoPermissionRuleStore) {
+	f.SetDefaultHook(func() database.SubRepoPermissionRuleStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBSubRepoPermissionRulesFunc) PushReturn(r0 database.SubRepoPermissionRuleStore) {
+	f.PushHook(func() database.SubRepoPermissionRuleStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBSubRepoPermissionRulesFunc) nextHook() func() database.SubRepoPermissionRuleStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBSubRepoPermissionRulesFunc) appendCall(r0 EnterpriseDBSubRepoPermissionRulesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBSubRepoPermissionRulesFuncCall
+// objects describing the invocations of this function.
+func (f *EnterpriseDBSubRepoPermissionRulesFunc) History() []EnterpriseDBSubRepoPermissionRulesFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBSubRepoPermissionRulesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBSubRepoPermissionRulesFuncCall is an object that describes an
+// invocation of method SubRepoPermissionRules on an instance of
+// MockEnterpriseDB.
+type EnterpriseDBSubRepoPermissionRulesFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.SubRepoPermissionRuleStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBSubRepoPermissionRulesFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBSubRepoPermissionRulesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBSubRepoPermsFunc describes the behavior when the SubRepoPerms
 // method of the parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBSubRepoPermsFunc struct {
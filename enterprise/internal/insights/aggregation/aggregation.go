@@ -52,7 +52,7 @@ func countRepo(r result.Match) (map[MatchKey]int, error) {
 	return nil, nil
 }
 
-func countLang(r result.Match) map[MatchKey]int {
+func countLang(r result.Match) (map[MatchKey]int, error) {
 	var lang string
 	switch match := r.(type) {
 	case *result.FileMatch:
@@ -64,9 +64,9 @@ func countLang(r result.Match) map[MatchKey]int {
 			RepoID: int32(r.RepoName().ID),
 			Repo:   string(r.RepoName().Name),
 			Group:  lang,
-		}: r.ResultCount()}
+		}: r.ResultCount()}, nil
 	}
-	return nil
+	return nil, nil
 }
 
 func countPath(r result.Match) (map[MatchKey]int, error) {
@@ -103,6 +103,67 @@ func countAuthor(r result.Match) (map[MatchKey]int, error) {
 	return nil, nil
 }
 
+// countOwnerFunc counts file matches by the owners of the file according to the
+// repository's CODEOWNERS file. Files with multiple owners are counted once for each
+// owner, and files without owners are not counted.
+func countOwnerFunc(ctx context.Context, metadata FileMetadataSource) AggregationCountFunc {
+	return func(r result.Match) (map[MatchKey]int, error) {
+		match, ok := r.(*result.FileMatch)
+		if !ok {
+			return nil, nil
+		}
+		owners, err := metadata.Owners(ctx, match.Repo.Name, match.CommitID, match.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, "Owners")
+		}
+		if len(owners) == 0 {
+			return nil, nil
+		}
+		matches := make(map[MatchKey]int, len(owners))
+		for _, owner := range owners {
+			matches[MatchKey{
+				RepoID: int32(r.RepoName().ID),
+				Repo:   string(r.RepoName().Name),
+				Group:  owner,
+			}] = r.ResultCount()
+		}
+		return matches, nil
+	}
+}
+
+// commitDateBucketFormat groups commit dates by month.
+const commitDateBucketFormat = "2006-01"
+
+// countCommitDateFunc counts commit and diff matches by the month they were committed in,
+// and file matches by the month of the last commit modifying the file.
+func countCommitDateFunc(ctx context.Context, metadata FileMetadataSource) AggregationCountFunc {
+	return func(r result.Match) (map[MatchKey]int, error) {
+		var date time.Time
+		switch match := r.(type) {
+		case *result.CommitMatch:
+			date = match.Commit.Author.Date
+			if match.Commit.Committer != nil {
+				date = match.Commit.Committer.Date
+			}
+		case *result.FileMatch:
+			var err error
+			date, err = metadata.LastCommitDate(ctx, match.Repo.Name, match.CommitID, match.Path)
+			if err != nil {
+				return nil, errors.Wrap(err, "LastCommitDate")
+			}
+		default:
+		}
+		if !date.IsZero() {
+			return map[MatchKey]int{{
+				RepoID: int32(r.RepoName().ID),
+				Repo:   string(r.RepoName().Name),
+				Group:  date.UTC().Format(commitDateBucketFormat),
+			}: r.ResultCount()}, nil
+		}
+		return nil, nil
+	}
+}
+
 func countCaptureGroupsFunc(querystring string) (AggregationCountFunc, error) {
 	pattern, err := getCasedPattern(querystring)
 	if err != nil {
@@ -166,19 +227,30 @@ func matchContent(event result.Match) []string {
 	}
 }
 
-func GetCountFuncForMode(query, patternType string, mode types.SearchAggregationMode) (AggregationCountFunc, error) {
+// GetCountFuncForMode returns the function counting search results for the given mode.
+// Modes that group file matches by information that isn't part of the results read it
+// from the given metadata source.
+func GetCountFuncForMode(ctx context.Context, query, patternType string, mode types.SearchAggregationMode, metadata FileMetadataSource) (AggregationCountFunc, error) {
 	modeCountTypes := map[types.SearchAggregationMode]AggregationCountFunc{
-		types.REPO_AGGREGATION_MODE:   countRepo,
-		types.PATH_AGGREGATION_MODE:   countPath,
-		types.AUTHOR_AGGREGATION_MODE: countAuthor,
+		types.REPO_AGGREGATION_MODE:     countRepo,
+		types.PATH_AGGREGATION_MODE:     countPath,
+		types.AUTHOR_AGGREGATION_MODE:   countAuthor,
+		types.LANGUAGE_AGGREGATION_MODE: countLang,
 	}
 
-	if mode == types.CAPTURE_GROUP_AGGREGATION_MODE {
+	switch mode {
+	case types.CAPTURE_GROUP_AGGREGATION_MODE:
 		captureGroupsCount, err := countCaptureGroupsFunc(query)
 		if err != nil {
 			return nil, err
 		}
 		modeCountTypes[types.CAPTURE_GROUP_AGGREGATION_MODE] = captureGroupsCount
+	case types.OWNER_AGGREGATION_MODE, types.COMMIT_DATE_AGGREGATION_MODE:
+		if metadata == nil {
+			return nil, errors.Newf("aggregation mode %s requires a file metadata source", mode)
+		}
+		modeCountTypes[types.OWNER_AGGREGATION_MODE] = countOwnerFunc(ctx, metadata)
+		modeCountTypes[types.COMMIT_DATE_AGGREGATION_MODE] = countCommitDateFunc(ctx, metadata)
 	}
 
 	modeCountFunc, ok := modeCountTypes[mode]
@@ -242,12 +314,12 @@ func (r *searchAggregationResults) Send(event streaming.SearchEvent) {
 			return
 		default:
 			groups, err := r.countFunc(match)
-			for groupKey, count := range groups {
+			if err != nil {
 				// delegate error handling to the passed in tabulator
-				if err != nil {
-					r.tabulator(nil, err)
-					continue
-				}
+				r.tabulator(nil, err)
+				continue
+			}
+			for groupKey, count := range groups {
 				current := combined[groupKey]
 				combined[groupKey] = current + count
 			}
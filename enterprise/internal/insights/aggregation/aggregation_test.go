@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	internaltypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func newTestSearchResultsAggregator(ctx context.Context, tabulator AggregationTabulator, countFunc AggregationCountFunc) SearchResultsAggregator {
@@ -95,7 +96,7 @@ func commitMatch(repo, author string, date time.Time, repoID, numRanges int32, c
 	return &result.CommitMatch{
 		Commit: gitdomain.Commit{
 			Author:    gitdomain.Signature{Name: author},
-			Committer: &gitdomain.Signature{},
+			Committer: &gitdomain.Signature{Date: date},
 			Message:   gitdomain.Message(content),
 		},
 		Repo: internaltypes.MinimalRepo{Name: api.RepoName(repo), ID: api.RepoID(repoID)},
@@ -240,7 +241,7 @@ func TestRepoAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode(context.Background(), "", "", tc.mode, nil)
 			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -302,7 +303,7 @@ func TestAuthorAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode(context.Background(), "", "", tc.mode, nil)
 			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -399,7 +400,7 @@ func TestPathAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode(context.Background(), "", "", tc.mode, nil)
 			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -588,7 +589,7 @@ func TestCaptureGroupAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, err := GetCountFuncForMode(tc.query, "regexp", tc.mode)
+			countFunc, err := GetCountFuncForMode(context.Background(), tc.query, "regexp", tc.mode, nil)
 			if err != nil {
 				t.Errorf("expected test not to error, got %v", err)
 				t.FailNow()
@@ -600,6 +601,169 @@ func TestCaptureGroupAggregation(t *testing.T) {
 	}
 }
 
+type fakeFileMetadataSource struct {
+	owners      map[string][]string
+	commitDates map[string]time.Time
+	err         error
+}
+
+func (s *fakeFileMetadataSource) LastCommitDate(_ context.Context, repo api.RepoName, _ api.CommitID, path string) (time.Time, error) {
+	return s.commitDates[string(repo)+"/"+path], s.err
+}
+
+func (s *fakeFileMetadataSource) Owners(_ context.Context, repo api.RepoName, _ api.CommitID, path string) ([]string, error) {
+	return s.owners[string(repo)+"/"+path], s.err
+}
+
+func TestLanguageAggregation(t *testing.T) {
+	testCases := []struct {
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{types.LANGUAGE_AGGREGATION_MODE, streaming.SearchEvent{}, autogold.Want("No language results", map[string]int{})},
+		{
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "file.go", 1, "a", "b"),
+					pathMatch("myRepo", "file.py", 1),
+					symbolMatch("myRepo2", "dir/file.go", 2, "c"),
+				},
+			},
+			autogold.Want("counts by language", map[string]int{"Go": 3, "Python": 1}),
+		},
+		{
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					repoMatch("myRepo", 1),
+					commitMatch("myRepo", "Author A", sampleDate, 1, 2, "a"),
+					pathMatch("myRepo", "no-extension", 1),
+				},
+			},
+			autogold.Want("No language for repo, commit and unknown files", map[string]int{}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode(context.Background(), "", "", tc.mode, nil)
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestOwnerAggregation(t *testing.T) {
+	metadata := &fakeFileMetadataSource{owners: map[string][]string{
+		"myRepo/file.go":     {"@alice", "@bob"},
+		"myRepo/doc/file.md": {"@docs"},
+		"myRepo2/file.go":    {"@alice"},
+	}}
+
+	testCases := []struct {
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{types.OWNER_AGGREGATION_MODE, streaming.SearchEvent{}, autogold.Want("No owner results", map[string]int{})},
+		{
+			types.OWNER_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "file.go", 1, "a", "b"),
+					pathMatch("myRepo", "doc/file.md", 1),
+					pathMatch("myRepo2", "file.go", 2),
+				},
+			},
+			autogold.Want("counts each owner of a file", map[string]int{"@alice": 3, "@bob": 2, "@docs": 1}),
+		},
+		{
+			types.OWNER_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					pathMatch("myRepo", "unowned.go", 1),
+					repoMatch("myRepo", 1),
+					commitMatch("myRepo", "Author A", sampleDate, 1, 2, "a"),
+				},
+			},
+			autogold.Want("No owner for unowned files, repos and commits", map[string]int{}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode(context.Background(), "", "", tc.mode, metadata)
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+
+	t.Run("requires metadata", func(t *testing.T) {
+		if _, err := GetCountFuncForMode(context.Background(), "", "", types.OWNER_AGGREGATION_MODE, nil); err == nil {
+			t.Error("expected error without a metadata source")
+		}
+	})
+
+	t.Run("metadata errors are tabulated", func(t *testing.T) {
+		aggregator := testAggregator{results: make(map[string]int)}
+		countFunc, _ := GetCountFuncForMode(context.Background(), "", "", types.OWNER_AGGREGATION_MODE, &fakeFileMetadataSource{err: errors.New("gitserver unavailable")})
+		sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+		sra.Send(streaming.SearchEvent{Results: []result.Match{pathMatch("myRepo", "file.go", 1)}})
+		if len(aggregator.errors) != 1 {
+			t.Errorf("expected one error, got %v", aggregator.errors)
+		}
+	})
+}
+
+func TestCommitDateAggregation(t *testing.T) {
+	metadata := &fakeFileMetadataSource{commitDates: map[string]time.Time{
+		"myRepo/file.go":  time.Date(2022, time.March, 31, 23, 0, 0, 0, time.UTC),
+		"myRepo/file2.go": time.Date(2021, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}}
+
+	testCases := []struct {
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{types.COMMIT_DATE_AGGREGATION_MODE, streaming.SearchEvent{}, autogold.Want("No commit date results", map[string]int{})},
+		{
+			types.COMMIT_DATE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+					commitMatch("repoB", "Author B", sampleDate.AddDate(0, 2, 0), 2, 2, "a"),
+				},
+			},
+			autogold.Want("counts commits by month", map[string]int{"2022-04": 2, "2022-06": 2}),
+		},
+		{
+			types.COMMIT_DATE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "file.go", 1, "a", "b"),
+					pathMatch("myRepo", "file2.go", 1),
+					pathMatch("myRepo", "uncommitted.go", 1),
+				},
+			},
+			autogold.Want("counts files by month of last commit", map[string]int{"2021-12": 1, "2022-03": 2}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode(context.Background(), "", "", tc.mode, metadata)
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
 func TestAggregationCancelation(t *testing.T) {
 
 	testCases := []struct {
@@ -624,7 +788,7 @@ func TestAggregationCancelation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, err := GetCountFuncForMode(tc.query, "regexp", tc.mode)
+			countFunc, err := GetCountFuncForMode(context.Background(), tc.query, "regexp", tc.mode, nil)
 			if err != nil {
 				t.Errorf("expected test not to error, got %v", err)
 				t.FailNow()
@@ -0,0 +1,109 @@
+package aggregation
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	"github.com/grafana/regexp"
+)
+
+// codeownersPaths are the locations in which code hosts look for a CODEOWNERS file, in
+// the order of precedence used by GitHub. GitLab's own directory is checked last.
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+type codeownersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// codeownersFile is a parsed CODEOWNERS file.
+type codeownersFile struct {
+	rules []codeownersRule
+}
+
+// parseCodeowners parses the content of a CODEOWNERS file. Lines with invalid patterns
+// are skipped, as code hosts ignore them as well.
+func parseCodeowners(content []byte) *codeownersFile {
+	file := &codeownersFile{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		// Skip comments, and GitLab section headers such as "[Docs]" or "^[Optional]".
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		pattern, err := compileCodeownersPattern(fields[0])
+		if err != nil {
+			continue
+		}
+		file.rules = append(file.rules, codeownersRule{pattern: pattern, owners: fields[1:]})
+	}
+	return file
+}
+
+// Owners returns the owners of the given path. As on GitHub and GitLab, the last rule
+// matching the path takes precedence.
+func (f *codeownersFile) Owners(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(f.rules) - 1; i >= 0; i-- {
+		if f.rules[i].pattern.MatchString(path) {
+			return f.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// compileCodeownersPattern converts a gitignore-style CODEOWNERS pattern into a regular
+// expression matching the paths (relative to the repository root) it applies to.
+func compileCodeownersPattern(pattern string) (*regexp.Regexp, error) {
+	pattern = strings.ReplaceAll(pattern, `\#`, "#")
+
+	// Patterns that contain a slash other than a trailing one are relative to the root,
+	// all other patterns match at any depth.
+	directory := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var sb strings.Builder
+	if anchored {
+		sb.WriteString("^")
+	} else {
+		sb.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					// "**/" matches zero or more directories.
+					i++
+					sb.WriteString("(?:.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	// A pattern matching a directory applies to everything beneath it.
+	if directory {
+		sb.WriteString("/.*$")
+	} else {
+		sb.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(sb.String())
+}
@@ -0,0 +1,46 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCodeownersOwners(t *testing.T) {
+	file := parseCodeowners([]byte(`
+# Default owners
+*                   @everyone
+
+[Docs]
+docs/               @docs-team
+*.md                @writers # markdown anywhere
+/build/logs/        @ops
+src/**/internal     @core @security
+cmd/?/main.go       @cli
+/README.md
+`))
+
+	for path, want := range map[string][]string{
+		"main.go":                      {"@everyone"},
+		"docs/index.html":              {"@docs-team"},
+		"docs/index.md":                {"@writers"},
+		"nested/docs/index.html":       {"@docs-team"},
+		"build/logs/out.txt":           {"@ops"},
+		"src/build/logs/out.txt":       {"@everyone"},
+		"src/internal/a.go":            {"@core", "@security"},
+		"src/pkg/deep/internal/a.go":   {"@core", "@security"},
+		"cmd/a/main.go":                {"@cli"},
+		"cmd/ab/main.go":               {"@everyone"},
+		"README.md":                    {},
+		"/docs/README.md":              {"@writers"},
+		"src/pkg/internal_test/a.go":   {"@everyone"},
+		"src/pkg/deep/internal":        {"@core", "@security"},
+		"src/pkg/deep/internal.go":     {"@everyone"},
+		"build/logs":                   {"@everyone"},
+		"build/logs/nested/output.log": {"@ops"},
+	} {
+		if diff := cmp.Diff(want, file.Owners(path)); diff != "" {
+			t.Errorf("unexpected owners for %q (-want +got):\n%s", path, diff)
+		}
+	}
+}
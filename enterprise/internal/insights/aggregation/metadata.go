@@ -0,0 +1,163 @@
+package aggregation
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// FileMetadataSource provides information about the files in search results that is not
+// part of the results themselves.
+type FileMetadataSource interface {
+	// LastCommitDate returns the date of the last commit modifying the path at the given
+	// commit, or the zero time if there is none.
+	LastCommitDate(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) (time.Time, error)
+	// Owners returns the owners of the path at the given commit according to the
+	// repository's CODEOWNERS file.
+	Owners(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) ([]string, error)
+}
+
+// NewGitserverFileMetadataSource returns a FileMetadataSource reading from gitserver. Results
+// are cached for the lifetime of the source, which should be a single aggregation.
+func NewGitserverFileMetadataSource(client gitserver.Client) FileMetadataSource {
+	return &gitserverFileMetadataSource{
+		client:      client,
+		codeowners:  map[repoCommit]*codeownersFile{},
+		commitDates: map[repoCommitPath]time.Time{},
+	}
+}
+
+type repoCommit struct {
+	repo   api.RepoName
+	commit api.CommitID
+}
+
+type repoCommitPath struct {
+	repoCommit
+	path string
+}
+
+type gitserverFileMetadataSource struct {
+	client gitserver.Client
+
+	// group deduplicates concurrent gitserver requests for the same key, so that mu is
+	// only held while accessing the caches.
+	group singleflight.Group
+
+	mu          sync.Mutex
+	codeowners  map[repoCommit]*codeownersFile
+	commitDates map[repoCommitPath]time.Time
+}
+
+func (s *gitserverFileMetadataSource) LastCommitDate(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) (time.Time, error) {
+	key := repoCommitPath{repoCommit{repo, commit}, path}
+	s.mu.Lock()
+	date, ok := s.commitDates[key]
+	s.mu.Unlock()
+	if ok {
+		return date, nil
+	}
+
+	v, err, _ := s.group.Do("date:"+string(repo)+"@"+string(commit)+":"+path, func() (any, error) {
+		// Another call may have filled the cache since we checked it.
+		s.mu.Lock()
+		date, ok := s.commitDates[key]
+		s.mu.Unlock()
+		if ok {
+			return date, nil
+		}
+
+		date, err := s.lastCommitDate(ctx, repo, commit, path)
+		if err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		s.commitDates[key] = date
+		s.mu.Unlock()
+		return date, nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return v.(time.Time), nil
+}
+
+func (s *gitserverFileMetadataSource) lastCommitDate(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) (time.Time, error) {
+	commits, err := s.client.Commits(ctx, repo, gitserver.CommitsOptions{Range: string(orHead(commit)), N: 1, Path: path}, authz.DefaultSubRepoPermsChecker)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "Commits")
+	}
+	var date time.Time
+	if len(commits) > 0 {
+		date = commits[0].Author.Date
+		if commits[0].Committer != nil {
+			date = commits[0].Committer.Date
+		}
+	}
+	return date, nil
+}
+
+func (s *gitserverFileMetadataSource) Owners(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) ([]string, error) {
+	key := repoCommit{repo, commit}
+	s.mu.Lock()
+	file, ok := s.codeowners[key]
+	s.mu.Unlock()
+	if ok {
+		return file.Owners(path), nil
+	}
+
+	v, err, _ := s.group.Do("codeowners:"+string(repo)+"@"+string(commit), func() (any, error) {
+		// Another call may have filled the cache since we checked it.
+		s.mu.Lock()
+		file, ok := s.codeowners[key]
+		s.mu.Unlock()
+		if ok {
+			return file, nil
+		}
+
+		file, err := s.readCodeowners(ctx, repo, orHead(commit))
+		if err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		s.codeowners[key] = file
+		s.mu.Unlock()
+		return file, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*codeownersFile).Owners(path), nil
+}
+
+// readCodeowners returns the first CODEOWNERS file found in the repository, or an empty
+// file if there is none.
+func (s *gitserverFileMetadataSource) readCodeowners(ctx context.Context, repo api.RepoName, commit api.CommitID) (*codeownersFile, error) {
+	for _, path := range codeownersPaths {
+		content, err := s.client.ReadFile(ctx, repo, commit, path, authz.DefaultSubRepoPermsChecker)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "ReadFile %s", path)
+		}
+		return parseCodeowners(content), nil
+	}
+	return &codeownersFile{}, nil
+}
+
+// orHead returns HEAD for results that don't specify the commit they were found at.
+func orHead(commit api.CommitID) api.CommitID {
+	if commit == "" {
+		return "HEAD"
+	}
+	return commit
+}
@@ -0,0 +1,51 @@
+package aggregation
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+func TestGitserverFileMetadataSourceOwners(t *testing.T) {
+	client := gitserver.NewMockClient()
+	client.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, _ api.CommitID, name string, _ authz.SubRepoPermissionChecker) ([]byte, error) {
+		switch name {
+		case ".github/CODEOWNERS":
+			return []byte("* @github"), nil
+		case "CODEOWNERS":
+			return []byte("* @root"), nil
+		}
+		return nil, os.ErrNotExist
+	})
+	source := NewGitserverFileMetadataSource(client)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			owners, err := source.Owners(context.Background(), "github.com/sourcegraph/sourcegraph", "abc", "main.go")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if diff := cmp.Diff([]string{"@github"}, owners); diff != "" {
+				t.Errorf("unexpected owners (-want +got):\n%s", diff)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The CODEOWNERS file in .github/ takes precedence, so the one in the root is never
+	// read, and it is read only once.
+	if have := len(client.ReadFileFunc.History()); have != 1 {
+		t.Errorf("expected CODEOWNERS to be read once, got %d reads", have)
+	}
+}
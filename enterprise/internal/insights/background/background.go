@@ -61,11 +61,12 @@ func GetBackgroundJobs(ctx context.Context, logger log.Logger, mainAppDB databas
 
 		searchRateLimiter := limiter.SearchQueryRate()
 		historicRateLimiter := limiter.HistoricalWorkRate()
+		commitClient := gitserver.NewGitCommitClient(mainAppDB)
 		backfillConfig := pipeline.BackfillerConfig{
 			CompressionPlan:         compression.NewGitserverFilter(mainAppDB, logger),
-			SearchHandlers:          queryrunner.GetSearchHandlers(),
+			SearchHandlers:          queryrunner.GetSearchHandlers(commitClient.Gitclient),
 			InsightStore:            insightsStore,
-			CommitClient:            gitserver.NewGitCommitClient(mainAppDB),
+			CommitClient:            commitClient,
 			SearchPlanWorkerLimit:   1,
 			SearchRunnerWorkerLimit: 5, // TODO: move these to settings
 			SearchRateLimiter:       searchRateLimiter,
@@ -105,6 +106,7 @@ func GetBackgroundJobs(ctx context.Context, logger log.Logger, mainAppDB databas
 		NewInsightsDataPrunerJob(ctx, mainAppDB, insightsDB),
 		NewLicenseCheckJob(ctx, mainAppDB, insightsDB),
 		NewBackfillCompletedCheckJob(ctx, mainAppDB, insightsDB),
+		NewDashboardReportJob(ctx, mainAppDB, insightsDB),
 	)
 
 	return routines
@@ -131,7 +133,7 @@ func GetBackgroundQueryRunnerJob(ctx context.Context, logger log.Logger, mainApp
 	return []goroutine.BackgroundRoutine{
 		// Register the query-runner worker and resetter, which executes search queries and records
 		// results to the insights DB.
-		queryrunner.NewWorker(ctx, logger.Scoped("queryrunner.Worker", ""), workerStore, insightsStore, repoStore, queryRunnerWorkerMetrics, seachQueryLimiter),
+		queryrunner.NewWorker(ctx, logger.Scoped("queryrunner.Worker", ""), workerStore, insightsStore, repoStore, gitserver.NewGitCommitClient(mainAppDB).Gitclient, queryRunnerWorkerMetrics, seachQueryLimiter),
 		queryrunner.NewResetter(ctx, logger.Scoped("queryrunner.Resetter", ""), workerStore, queryRunnerResetterMetrics),
 		queryrunner.NewCleaner(ctx, observationCtx, workerBaseStore),
 	}
@@ -0,0 +1,153 @@
+package background
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/slack-go/slack"
+
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const utmSourceDashboardReport = "code-insights-report"
+
+var (
+	//go:embed dashboard_report.html.tmpl
+	dashboardReportHTMLTemplate string
+
+	//go:embed dashboard_report.txt.tmpl
+	dashboardReportTextTemplate string
+)
+
+var dashboardReportEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `{{.Cadence}} digest of the Sourcegraph code insights dashboard {{.DashboardTitle}}`,
+	Text:    dashboardReportTextTemplate,
+	HTML:    dashboardReportHTMLTemplate,
+})
+
+func sendDashboardReportEmail(ctx context.Context, db database.DB, userID int32, digest *dashboardDigest) error {
+	email, _, err := db.UserEmails().GetPrimaryEmail(ctx, userID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Errorf("unable to send email to user ID %d with unknown email address", userID)
+		}
+		return errors.Errorf("UserEmails.GetPrimaryEmail for userID=%d: %w", userID, err)
+	}
+	if err := internalapi.Client.SendEmail(ctx, "code-insights-report", txtypes.Message{
+		To:       []string{email},
+		Template: dashboardReportEmailTemplates,
+		Data:     digest,
+	}); err != nil {
+		return errors.Errorf("internalapi.Client.SendEmail to email=%q userID=%d: %w", email, userID, err)
+	}
+	return nil
+}
+
+func sendDashboardReportSlack(ctx context.Context, webhookURL string, digest *dashboardDigest) error {
+	return postSlackWebhook(ctx, httpcli.ExternalDoer, webhookURL, dashboardReportSlackPayload(digest))
+}
+
+func dashboardReportSlackPayload(digest *dashboardDigest) *slack.WebhookMessage {
+	newMarkdownSection := func(s string) slack.Block {
+		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
+	}
+
+	blocks := []slack.Block{
+		newMarkdownSection(fmt.Sprintf(
+			"%s digest of the Sourcegraph code insights dashboard <%s|%s>",
+			digest.Cadence,
+			digest.DashboardURL,
+			escapeSlack(digest.DashboardTitle),
+		)),
+	}
+	for _, insight := range digest.Insights {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "*%s*", escapeSlack(insight.Title))
+		for _, series := range insight.Series {
+			fmt.Fprintf(&sb, "\n• %s: *%s* (%s", escapeSlack(series.Label), series.Value, series.Change)
+			if series.Since != "" {
+				fmt.Fprintf(&sb, " since %s", series.Since)
+			}
+			sb.WriteString(")")
+		}
+		blocks = append(blocks, newMarkdownSection(sb.String()))
+	}
+	if len(digest.TopMovers) > 0 {
+		var sb strings.Builder
+		sb.WriteString("*Top movers by repository*")
+		for _, mover := range digest.TopMovers {
+			fmt.Fprintf(&sb, "\n• %s: %s in %s", escapeSlack(mover.RepoName), mover.Change, escapeSlack(mover.Insight))
+			if mover.Series != "" {
+				fmt.Fprintf(&sb, " / %s", escapeSlack(mover.Series))
+			}
+		}
+		blocks = append(blocks, newMarkdownSection(sb.String()))
+	}
+
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: blocks}}
+}
+
+// escapeSlack escapes the characters that have a special meaning in Slack message text.
+func escapeSlack(s string) string {
+	return slackEscaper.Replace(s)
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// adapted from slack.PostWebhookCustomHTTPContext
+func postSlackWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *slack.WebhookMessage) error {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal failed")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
+	if err != nil {
+		return errors.Wrap(err, "failed new request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to post webhook")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return errors.Errorf("non-200 response %d %s with body %q", resp.StatusCode, resp.Status, string(body))
+	}
+
+	return nil
+}
+
+// To avoid a circular dependency with the insights/resolvers package we have to redeclare the
+// GraphQL ID of dashboards.
+const dashboardKind = "dashboard"
+
+type dashboardID struct {
+	IdType string
+	Arg    int64
+}
+
+func getDashboardURL(externalURL *url.URL, id int) string {
+	u := externalURL.ResolveReference(&url.URL{Path: fmt.Sprintf("insights/dashboards/%s", relay.MarshalID(dashboardKind, dashboardID{IdType: "custom", Arg: int64(id)}))})
+	q := u.Query()
+	q.Set("utm_source", utmSourceDashboardReport)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
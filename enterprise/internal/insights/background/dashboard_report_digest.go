@@ -0,0 +1,300 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// digestLookback is how far back points are loaded to find the latest value of a series and
+	// the value it is compared against.
+	digestLookback = 90 * 24 * time.Hour
+	// digestComparisonPeriod is the period the change of each series is computed over. Points are
+	// not recorded at exact intervals, so points up to digestComparisonSlack more recent count as well.
+	digestComparisonPeriod = 7 * 24 * time.Hour
+	digestComparisonSlack  = 12 * time.Hour
+	// digestTopMovers is the maximum number of repositories listed as top movers.
+	digestTopMovers = 5
+)
+
+// reportPointsSource is the subset of the time series store used to render dashboard digests.
+type reportPointsSource interface {
+	SeriesPoints(ctx context.Context, opts store.SeriesPointsOpts) ([]store.SeriesPoint, error)
+	SeriesPointsByRepo(ctx context.Context, opts store.SeriesPointsOpts) ([]store.RepoSeriesPoint, error)
+}
+
+// dashboardDigest is the rendered content of a dashboard report. It is used as template data for
+// emails and to build Slack messages.
+type dashboardDigest struct {
+	Cadence        string
+	DashboardTitle string
+	DashboardURL   string
+	Insights       []digestInsight
+	TopMovers      []digestRepoChange
+}
+
+type digestInsight struct {
+	Title  string
+	Series []digestSeries
+}
+
+type digestSeries struct {
+	Label  string
+	Value  string
+	Change string
+	Since  string
+}
+
+type digestRepoChange struct {
+	RepoName string
+	Insight  string
+	Series   string
+	Change   string
+
+	delta float64
+}
+
+// buildDashboardDigest renders the digest of the given dashboard from the stored points of its series.
+// The points are read with the permissions of the actor in ctx.
+func buildDashboardDigest(ctx context.Context, points reportPointsSource, cadence types.ReportCadence, dashboard *types.Dashboard, dashboardURL string, viewSeries []types.InsightViewSeries, now time.Time) (*dashboardDigest, error) {
+	digest := &dashboardDigest{
+		Cadence:        cadenceTitle(cadence),
+		DashboardTitle: dashboard.Title,
+		DashboardURL:   dashboardURL,
+	}
+
+	insightIndexes := map[string]int{}
+	from := now.Add(-digestLookback)
+	for _, series := range viewSeries {
+		opts := store.SeriesPointsOpts{
+			SeriesID: &series.SeriesID,
+			From:     &from,
+			To:       &now,
+		}
+		if series.DefaultFilterIncludeRepoRegex != nil {
+			opts.IncludeRepoRegex = []string{*series.DefaultFilterIncludeRepoRegex}
+		}
+		if series.DefaultFilterExcludeRepoRegex != nil {
+			opts.ExcludeRepoRegex = []string{*series.DefaultFilterExcludeRepoRegex}
+		}
+
+		seriesPoints, err := points.SeriesPoints(ctx, opts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "SeriesPoints for series %s", series.SeriesID)
+		}
+		changes := seriesChanges(seriesPoints)
+		if len(changes) == 0 {
+			continue
+		}
+
+		i, ok := insightIndexes[series.UniqueID]
+		if !ok {
+			i = len(digest.Insights)
+			insightIndexes[series.UniqueID] = i
+			digest.Insights = append(digest.Insights, digestInsight{Title: series.Title})
+		}
+
+		// All captured values of a series are recorded at the same times, so the range of the first
+		// change covers the repository points of all of them.
+		var latest, previous time.Time
+		for _, change := range changes {
+			label := series.Label
+			if change.capture != nil {
+				label = *change.capture
+			}
+			digest.Insights[i].Series = append(digest.Insights[i].Series, change.render(label))
+			if change.previous != nil {
+				latest, previous = change.latest.Time, change.previous.Time
+			}
+		}
+		if previous.IsZero() {
+			continue
+		}
+
+		opts.From, opts.To = &previous, &latest
+		repoPoints, err := points.SeriesPointsByRepo(ctx, opts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "SeriesPointsByRepo for series %s", series.SeriesID)
+		}
+		for _, change := range repoChanges(repoPoints, previous, latest) {
+			change.Insight = series.Title
+			change.Series = series.Label
+			if change.capture != nil {
+				change.Series = *change.capture
+			}
+			digest.TopMovers = append(digest.TopMovers, change.digestRepoChange)
+		}
+	}
+
+	sort.SliceStable(digest.TopMovers, func(i, j int) bool {
+		return math.Abs(digest.TopMovers[i].delta) > math.Abs(digest.TopMovers[j].delta)
+	})
+	if len(digest.TopMovers) > digestTopMovers {
+		digest.TopMovers = digest.TopMovers[:digestTopMovers]
+	}
+
+	return digest, nil
+}
+
+type seriesChange struct {
+	capture  *string
+	latest   store.SeriesPoint
+	previous *store.SeriesPoint
+}
+
+func (c seriesChange) render(label string) digestSeries {
+	rendered := digestSeries{
+		Label:  label,
+		Value:  formatValue(c.latest.Value),
+		Change: "new",
+	}
+	if c.previous != nil {
+		rendered.Change = formatChange(c.latest.Value-c.previous.Value, c.previous.Value)
+		rendered.Since = c.previous.Time.Format("Jan 2")
+	}
+	return rendered
+}
+
+// seriesChanges returns, for each captured value (or the series itself if it has no captures), the
+// latest point and the latest point about a comparison period older than it, if any.
+func seriesChanges(points []store.SeriesPoint) []seriesChange {
+	byCapture := map[string][]store.SeriesPoint{}
+	var captures []string
+	for _, point := range points {
+		key := ""
+		if point.Capture != nil {
+			key = *point.Capture
+		}
+		if _, ok := byCapture[key]; !ok {
+			captures = append(captures, key)
+		}
+		byCapture[key] = append(byCapture[key], point)
+	}
+	sort.Strings(captures)
+
+	changes := make([]seriesChange, 0, len(captures))
+	for _, key := range captures {
+		capturePoints := byCapture[key]
+		sort.Slice(capturePoints, func(i, j int) bool { return capturePoints[i].Time.Before(capturePoints[j].Time) })
+
+		latest := capturePoints[len(capturePoints)-1]
+		change := seriesChange{capture: latest.Capture, latest: latest}
+		cutoff := latest.Time.Add(-digestComparisonPeriod + digestComparisonSlack)
+		for i := len(capturePoints) - 2; i >= 0; i-- {
+			if !capturePoints[i].Time.After(cutoff) {
+				change.previous = &capturePoints[i]
+				break
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+type repoChange struct {
+	digestRepoChange
+	capture *string
+}
+
+// repoChanges returns the change of each repository (and captured value) between the points recorded
+// at previous and latest. Repositories without a point at either time are treated as having a value
+// of zero, and repositories that did not change are omitted.
+func repoChanges(points []store.RepoSeriesPoint, previous, latest time.Time) []repoChange {
+	type key struct {
+		repoName string
+		capture  string
+	}
+	values := map[key]*[2]float64{}
+	captures := map[key]*string{}
+	for _, point := range points {
+		var slot int
+		switch {
+		case point.Time.Equal(previous):
+			slot = 0
+		case point.Time.Equal(latest):
+			slot = 1
+		default:
+			continue
+		}
+
+		k := key{repoName: point.RepoName}
+		if point.Capture != nil {
+			k.capture = *point.Capture
+		}
+		if _, ok := values[k]; !ok {
+			values[k] = &[2]float64{}
+			captures[k] = point.Capture
+		}
+		values[k][slot] += point.Value
+	}
+
+	changes := make([]repoChange, 0, len(values))
+	for k, v := range values {
+		delta := v[1] - v[0]
+		if delta == 0 {
+			continue
+		}
+		changes = append(changes, repoChange{
+			digestRepoChange: digestRepoChange{
+				RepoName: k.repoName,
+				Change:   formatChange(delta, v[0]),
+				delta:    delta,
+			},
+			capture: captures[k],
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].RepoName != changes[j].RepoName {
+			return changes[i].RepoName < changes[j].RepoName
+		}
+		return strings.Compare(derefString(changes[i].capture), derefString(changes[j].capture)) < 0
+	})
+	return changes
+}
+
+func cadenceTitle(cadence types.ReportCadence) string {
+	switch cadence {
+	case types.ReportCadenceDaily:
+		return "Daily"
+	case types.ReportCadenceMonthly:
+		return "Monthly"
+	default:
+		return "Weekly"
+	}
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// formatChange formats a change by delta from the value previous, e.g. "+3 (+50%)".
+func formatChange(delta, previous float64) string {
+	if delta == 0 {
+		return "no change"
+	}
+	sign := "+"
+	if delta < 0 {
+		sign = "-"
+	}
+	formatted := sign + formatValue(math.Abs(delta))
+	if previous != 0 {
+		formatted += fmt.Sprintf(" (%s%.1f%%)", sign, math.Abs(delta/previous*100))
+	}
+	return formatted
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
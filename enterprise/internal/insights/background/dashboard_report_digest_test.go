@@ -0,0 +1,212 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+type fakeReportPointsSource struct {
+	points     map[string][]store.SeriesPoint
+	repoPoints map[string][]store.RepoSeriesPoint
+}
+
+func (f *fakeReportPointsSource) SeriesPoints(_ context.Context, opts store.SeriesPointsOpts) ([]store.SeriesPoint, error) {
+	return f.points[*opts.SeriesID], nil
+}
+
+func (f *fakeReportPointsSource) SeriesPointsByRepo(_ context.Context, opts store.SeriesPointsOpts) ([]store.RepoSeriesPoint, error) {
+	var points []store.RepoSeriesPoint
+	for _, point := range f.repoPoints[*opts.SeriesID] {
+		if !point.Time.Before(*opts.From) && !point.Time.After(*opts.To) {
+			points = append(points, point)
+		}
+	}
+	return points, nil
+}
+
+func TestBuildDashboardDigest(t *testing.T) {
+	now := time.Date(2022, 12, 19, 9, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+	t0, t1, t2 := now.Add(-2*week), now.Add(-week), now.Add(-time.Hour)
+	capture := func(s string) *string { return &s }
+
+	points := &fakeReportPointsSource{
+		points: map[string][]store.SeriesPoint{
+			"todos": {
+				{SeriesID: "todos", Time: t0, Value: 10},
+				{SeriesID: "todos", Time: t1, Value: 20},
+				{SeriesID: "todos", Time: t2, Value: 15},
+			},
+			"versions": {
+				{SeriesID: "versions", Time: t1, Value: 4, Capture: capture("1.18")},
+				{SeriesID: "versions", Time: t2, Value: 1, Capture: capture("1.18")},
+				{SeriesID: "versions", Time: t1, Value: 2, Capture: capture("1.19")},
+				{SeriesID: "versions", Time: t2, Value: 5, Capture: capture("1.19")},
+			},
+			"new": {
+				{SeriesID: "new", Time: t2, Value: 3},
+			},
+		},
+		repoPoints: map[string][]store.RepoSeriesPoint{
+			"todos": {
+				{RepoID: 1, RepoName: "github.com/sourcegraph/a", Time: t1, Value: 12},
+				{RepoID: 2, RepoName: "github.com/sourcegraph/b", Time: t1, Value: 8},
+				{RepoID: 1, RepoName: "github.com/sourcegraph/a", Time: t2, Value: 5},
+				{RepoID: 2, RepoName: "github.com/sourcegraph/b", Time: t2, Value: 8},
+				{RepoID: 3, RepoName: "github.com/sourcegraph/c", Time: t2, Value: 2},
+			},
+			"versions": {
+				{RepoID: 1, RepoName: "github.com/sourcegraph/a", Time: t1, Value: 4, Capture: capture("1.18")},
+				{RepoID: 1, RepoName: "github.com/sourcegraph/a", Time: t2, Value: 1, Capture: capture("1.18")},
+				{RepoID: 2, RepoName: "github.com/sourcegraph/b", Time: t1, Value: 2, Capture: capture("1.19")},
+				{RepoID: 2, RepoName: "github.com/sourcegraph/b", Time: t2, Value: 5, Capture: capture("1.19")},
+			},
+		},
+	}
+	viewSeries := []types.InsightViewSeries{
+		{UniqueID: "health", Title: "Code health", SeriesID: "todos", Label: "TODOs"},
+		{UniqueID: "health", Title: "Code health", SeriesID: "empty", Label: "FIXMEs"},
+		{UniqueID: "go", Title: "Go versions", SeriesID: "versions", Label: "go.mod", GeneratedFromCaptureGroups: true},
+		{UniqueID: "recent", Title: "Recent insight", SeriesID: "new", Label: "Matches"},
+	}
+
+	digest, err := buildDashboardDigest(context.Background(), points, types.ReportCadenceWeekly, &types.Dashboard{ID: 1, Title: "Leadership"}, "https://sourcegraph.test/insights/dashboards/1", viewSeries, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("digest", &dashboardDigest{
+		Cadence: "Weekly", DashboardTitle: "Leadership",
+		DashboardURL: "https://sourcegraph.test/insights/dashboards/1",
+		Insights: []digestInsight{
+			{
+				Title: "Code health",
+				Series: []digestSeries{{
+					Label:  "TODOs",
+					Value:  "15",
+					Change: "-5 (-25.0%)",
+					Since:  "Dec 12",
+				}},
+			},
+			{
+				Title: "Go versions",
+				Series: []digestSeries{
+					{
+						Label:  "1.18",
+						Value:  "1",
+						Change: "-3 (-75.0%)",
+						Since:  "Dec 12",
+					},
+					{
+						Label:  "1.19",
+						Value:  "5",
+						Change: "+3 (+150.0%)",
+						Since:  "Dec 12",
+					},
+				},
+			},
+			{
+				Title: "Recent insight",
+				Series: []digestSeries{{
+					Label:  "Matches",
+					Value:  "3",
+					Change: "new",
+				}},
+			},
+		},
+		TopMovers: []digestRepoChange{
+			{
+				RepoName: "github.com/sourcegraph/a",
+				Insight:  "Code health",
+				Series:   "TODOs",
+				Change:   "-7 (-58.3%)",
+				delta:    -7,
+			},
+			{
+				RepoName: "github.com/sourcegraph/a",
+				Insight:  "Go versions",
+				Series:   "1.18",
+				Change:   "-3 (-75.0%)",
+				delta:    -3,
+			},
+			{
+				RepoName: "github.com/sourcegraph/b",
+				Insight:  "Go versions",
+				Series:   "1.19",
+				Change:   "+3 (+150.0%)",
+				delta:    3,
+			},
+			{
+				RepoName: "github.com/sourcegraph/c",
+				Insight:  "Code health",
+				Series:   "TODOs",
+				Change:   "+2",
+				delta:    2,
+			},
+		},
+	}).Equal(t, digest, autogold.ExportedOnly())
+}
+
+func TestFormatChange(t *testing.T) {
+	for _, tc := range []struct {
+		delta, previous float64
+		want            string
+	}{
+		{0, 10, "no change"},
+		{5, 10, "+5 (+50.0%)"},
+		{-2.5, 10, "-2.5 (-25.0%)"},
+		{3, 0, "+3"},
+	} {
+		if got := formatChange(tc.delta, tc.previous); got != tc.want {
+			t.Errorf("formatChange(%v, %v) = %q, want %q", tc.delta, tc.previous, got, tc.want)
+		}
+	}
+}
+
+func TestDashboardReportSlackPayload(t *testing.T) {
+	payload := dashboardReportSlackPayload(&dashboardDigest{
+		Cadence:        "Weekly",
+		DashboardTitle: "R&D <leadership>",
+		DashboardURL:   "https://sourcegraph.test/insights/dashboards/1",
+		Insights: []digestInsight{{
+			Title:  "Code health",
+			Series: []digestSeries{{Label: "TODOs", Value: "15", Change: "-5 (-25.0%)", Since: "Dec 12"}},
+		}},
+		TopMovers: []digestRepoChange{{RepoName: "github.com/sourcegraph/a", Insight: "Code health", Series: "TODOs", Change: "-7 (-58.3%)"}},
+	})
+	raw, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("slack payload", `{
+  "blocks": [
+    {
+      "type": "section",
+      "text": {
+        "type": "mrkdwn",
+        "text": "Weekly digest of the Sourcegraph code insights dashboard \u003chttps://sourcegraph.test/insights/dashboards/1|R\u0026amp;D \u0026lt;leadership\u0026gt;\u003e"
+      }
+    },
+    {
+      "type": "section",
+      "text": {
+        "type": "mrkdwn",
+        "text": "*Code health*\n• TODOs: *15* (-5 (-25.0%) since Dec 12)"
+      }
+    },
+    {
+      "type": "section",
+      "text": {
+        "type": "mrkdwn",
+        "text": "*Top movers by repository*\n• github.com/sourcegraph/a: -7 (-58.3%) in Code health / TODOs"
+      }
+    }
+  ]
+}`).Equal(t, string(raw))
+}
@@ -0,0 +1,161 @@
+package background
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewDashboardReportJob periodically sends the digests of dashboards with a report subscription that is due.
+func NewDashboardReportJob(ctx context.Context, postgres database.DB, insightsdb edb.InsightsDB) goroutine.BackgroundRoutine {
+	interval := time.Minute * 5
+	reporter := &dashboardReporter{
+		logger:         log.Scoped("DashboardReports", ""),
+		postgres:       postgres,
+		dashboardStore: store.NewDashboardStore(insightsdb),
+		insightStore:   store.NewInsightStore(insightsdb),
+		points:         store.New(insightsdb, store.NewInsightPermissionStore(postgres)),
+		sendEmail: func(ctx context.Context, userID int32, digest *dashboardDigest) error {
+			return sendDashboardReportEmail(ctx, postgres, userID, digest)
+		},
+		sendSlack: sendDashboardReportSlack,
+		now:       time.Now,
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		"insights.dashboard_reports", "sends digests of code insights dashboards to subscribers",
+		interval,
+		goroutine.HandlerFunc(func(ctx context.Context) error {
+			return reporter.sendDueReports(ctx)
+		}),
+	)
+}
+
+type dashboardReporter struct {
+	logger         log.Logger
+	postgres       database.DB
+	dashboardStore *store.DBDashboardStore
+	insightStore   *store.InsightStore
+	points         reportPointsSource
+
+	sendEmail func(ctx context.Context, userID int32, digest *dashboardDigest) error
+	sendSlack func(ctx context.Context, webhookURL string, digest *dashboardDigest) error
+	now       func() time.Time
+}
+
+// sendDueReports delivers every report that is due. A subscription is marked as sent even if some of its
+// deliveries failed, so that a recipient that cannot be reached does not cause the others to receive the
+// report repeatedly.
+func (r *dashboardReporter) sendDueReports(ctx context.Context) error {
+	now := r.now()
+	subscriptions, err := r.dashboardStore.GetReportSubscriptions(ctx, store.ReportSubscriptionQueryArgs{DueBefore: &now})
+	if err != nil {
+		return errors.Wrap(err, "GetReportSubscriptions")
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	externalURL, err := url.Parse(conf.ExternalURL())
+	if err != nil {
+		return errors.Wrap(err, "parsing external URL")
+	}
+
+	var errs error
+	for _, subscription := range subscriptions {
+		if err := r.sendReport(ctx, externalURL, subscription, now); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "report subscription %d", subscription.ID))
+		}
+
+		next := subscription.Cadence.Next(subscription.NextSendAt)
+		for !next.After(now) {
+			// Skip reports that were missed, e.g. because the worker was not running.
+			next = subscription.Cadence.Next(next)
+		}
+		if err := r.dashboardStore.MarkReportSubscriptionSent(ctx, subscription.ID, now, next); err != nil {
+			return errors.Wrap(err, "MarkReportSubscriptionSent")
+		}
+	}
+	return errs
+}
+
+func (r *dashboardReporter) sendReport(ctx context.Context, externalURL *url.URL, subscription *types.DashboardReportSubscription, now time.Time) error {
+	dashboards, err := r.dashboardStore.GetDashboards(ctx, store.DashboardQueryArgs{ID: []int{subscription.DashboardID}, WithoutAuthorization: true})
+	if err != nil {
+		return errors.Wrap(err, "GetDashboards")
+	}
+	if len(dashboards) == 0 {
+		return nil
+	}
+	dashboard := dashboards[0]
+
+	viewSeries, err := r.insightStore.GetAllOnDashboard(ctx, store.InsightsOnDashboardQueryArgs{DashboardID: dashboard.ID})
+	if err != nil {
+		return errors.Wrap(err, "GetAllOnDashboard")
+	}
+	dashboardURL := getDashboardURL(externalURL, dashboard.ID)
+
+	// 🚨 SECURITY: Every digest is rendered with the permissions of the user it is delivered to, and only
+	// delivered if that user can view the dashboard. Slack messages are rendered for the creator of the
+	// subscription.
+	digestFor := func(userID int32) (*dashboardDigest, error) {
+		userCtx := actor.WithActor(ctx, actor.FromUser(userID))
+		visible, err := r.canViewDashboard(userCtx, userID, dashboard.ID)
+		if err != nil || !visible {
+			return nil, err
+		}
+		return buildDashboardDigest(userCtx, r.points, subscription.Cadence, dashboard, dashboardURL, viewSeries, now)
+	}
+
+	var errs error
+	for _, userID := range subscription.RecipientUserIDs {
+		digest, err := digestFor(userID)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		if digest == nil {
+			r.logger.Debug("skipping dashboard report for recipient without access", log.Int("subscription", subscription.ID), log.Int32("user", userID))
+			continue
+		}
+		if err := r.sendEmail(ctx, userID, digest); err != nil {
+			errs = errors.Append(errs, err)
+		}
+	}
+
+	if subscription.SlackWebhookURL != nil && *subscription.SlackWebhookURL != "" {
+		digest, err := digestFor(subscription.CreatedByUserID)
+		if err != nil {
+			errs = errors.Append(errs, err)
+		} else if digest != nil {
+			if err := r.sendSlack(ctx, *subscription.SlackWebhookURL, digest); err != nil {
+				errs = errors.Append(errs, errors.Wrap(err, "posting Slack webhook"))
+			}
+		}
+	}
+	return errs
+}
+
+func (r *dashboardReporter) canViewDashboard(ctx context.Context, userID int32, dashboardID int) (bool, error) {
+	orgs, err := r.postgres.Orgs().GetByUserID(ctx, userID)
+	if err != nil {
+		return false, errors.Wrap(err, "GetByUserID")
+	}
+	orgIDs := make([]int, 0, len(orgs))
+	for _, org := range orgs {
+		orgIDs = append(orgIDs, int(org.ID))
+	}
+	return r.dashboardStore.HasDashboardPermission(ctx, []int{dashboardID}, []int{int(userID)}, orgIDs)
+}
@@ -681,7 +681,7 @@ func (a *backfillAnalyzer) analyzeSeries(ctx context.Context, bctx *buildSeriesC
 		return
 	}
 	newQueryStr = modifiedQuery.String()
-	if bctx.series.GroupBy != nil {
+	if bctx.series.GroupBy != nil && !querybuilder.IsSearchAggregationMapType(querybuilder.MapType(*bctx.series.GroupBy)) {
 		computeQuery, computeErr := querybuilder.ComputeInsightCommandQuery(modifiedQuery, querybuilder.MapType(*bctx.series.GroupBy))
 		if computeErr != nil {
 			err = errors.Append(err, errors.Wrap(err, "ComputeInsightCommandQuery"))
@@ -148,7 +148,7 @@ func (ie *InsightEnqueuer) EnqueueSingle(
 		return errors.Wrapf(err, "GlobalQuery series_id:%s", seriesID)
 	}
 	finalQuery = modifiedQuery.String()
-	if series.GroupBy != nil {
+	if series.GroupBy != nil && !querybuilder.IsSearchAggregationMapType(querybuilder.MapType(*series.GroupBy)) {
 		computeQuery, err := querybuilder.ComputeInsightCommandQuery(modifiedQuery, querybuilder.MapType(*series.GroupBy))
 		if err != nil {
 			return errors.Wrapf(err, "ComputeInsightCommandQuery series_id:%s", seriesID)
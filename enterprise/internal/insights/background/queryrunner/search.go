@@ -3,11 +3,13 @@ package queryrunner
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/sourcegraph/log"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/aggregation"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/discovery"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/streaming"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
@@ -15,12 +17,13 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
-func GetSearchHandlers() map[types.GenerationMethod]InsightsHandler {
+func GetSearchHandlers(gitserverClient gitserver.Client) map[types.GenerationMethod]InsightsHandler {
 	searchStream := func(ctx context.Context, query string) (*streaming.TabulationResult, error) {
 		tr, ctx := trace.New(ctx, "CodeInsightsSearch", "searchStream")
 		defer tr.Finish()
@@ -56,10 +59,26 @@ func GetSearchHandlers() map[types.GenerationMethod]InsightsHandler {
 		return streamResults, nil
 	}
 
+	aggregationSearch := func(ctx context.Context, query string, mode types.SearchAggregationMode) (*streaming.ComputeTabulationResult, error) {
+		// Metadata is cached for a single search only, as files change over time.
+		metadata := aggregation.NewGitserverFileMetadataSource(gitserverClient)
+		countFunc, err := aggregation.GetCountFuncForMode(ctx, query, "", mode, metadata)
+		if err != nil {
+			return nil, errors.Wrap(err, "GetCountFuncForMode")
+		}
+		decoder, streamResults := streaming.AggregationDecoder(countFunc)
+		err = streaming.Search(ctx, query, nil, decoder)
+		if err != nil {
+			return nil, errors.Wrap(err, "streaming.Search")
+		}
+		return streamResults, nil
+	}
+
 	return map[types.GenerationMethod]InsightsHandler{
-		types.MappingCompute: makeMappingComputeHandler(computeTextExtraSearch),
-		types.SearchCompute:  makeComputeHandler(computeSearchStream),
-		types.Search:         makeSearchHandler(searchStream),
+		types.MappingCompute:    makeMappingComputeHandler(computeTextExtraSearch),
+		types.SearchCompute:     makeComputeHandler(computeSearchStream),
+		types.Search:            makeSearchHandler(searchStream),
+		types.SearchAggregation: makeSearchAggregationHandler(aggregationSearch),
 	}
 
 }
@@ -89,6 +108,7 @@ func toRecording(record *SearchJob, value float64, recordTime time.Time, repoNam
 
 type streamComputeProvider func(context.Context, string) (*streaming.ComputeTabulationResult, error)
 type streamSearchProvider func(context.Context, string) (*streaming.TabulationResult, error)
+type streamAggregationProvider func(context.Context, string, types.SearchAggregationMode) (*streaming.ComputeTabulationResult, error)
 
 func generateComputeRecordingsStream(ctx context.Context, job *SearchJob, recordTime time.Time, provider streamComputeProvider, logger log.Logger) (_ []store.RecordSeriesPointArgs, err error) {
 	streamResults, err := provider(ctx, job.SearchQuery)
@@ -205,6 +225,25 @@ func makeMappingComputeHandler(provider streamComputeProvider) InsightsHandler {
 	}
 }
 
+// makeSearchAggregationHandler returns a handler for series grouped by a search aggregation
+// mode, which records the same points as a compute series grouped by the same field would.
+func makeSearchAggregationHandler(provider streamAggregationProvider) InsightsHandler {
+	return func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
+		if series.GroupBy == nil {
+			return nil, errors.New("searchAggregationHandler: series is not grouped")
+		}
+		mode := types.SearchAggregationMode(strings.ToUpper(*series.GroupBy))
+		computeProvider := func(ctx context.Context, query string) (*streaming.ComputeTabulationResult, error) {
+			return provider(ctx, query, mode)
+		}
+		recordings, err := generateComputeRecordingsStream(ctx, job, recordTime, computeProvider, log.Scoped("SearchAggregationRecordingsGenerator", ""))
+		if err != nil {
+			return nil, errors.Wrapf(err, "searchAggregationHandler")
+		}
+		return recordings, nil
+	}
+}
+
 func (r *workHandler) persistRecordings(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordings []store.RecordSeriesPointArgs, recordTime time.Time) (err error) {
 	tx, err := r.insightsStore.Transact(ctx)
 	if err != nil {
@@ -7,17 +7,24 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/aggregation"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/compression"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/streaming"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type ComputeExecutor struct {
 	justInTimeExecutor
 	computeSearch func(ctx context.Context, query string) ([]GroupedResults, error)
+
+	// aggregationSearch groups the results of a search query for fields that compute
+	// doesn't support.
+	aggregationSearch func(ctx context.Context, query string, mode types.SearchAggregationMode) ([]GroupedResults, error)
 }
 
 func NewComputeExecutor(postgres database.DB, clock func() time.Time) *ComputeExecutor {
@@ -29,6 +36,9 @@ func NewComputeExecutor(postgres database.DB, clock func() time.Time) *ComputeEx
 			clock:     clock,
 		},
 		computeSearch: streamTextExtraCompute,
+		aggregationSearch: func(ctx context.Context, query string, mode types.SearchAggregationMode) ([]GroupedResults, error) {
+			return streamSearchAggregation(ctx, query, mode, aggregation.NewGitserverFileMetadataSource(gitserver.NewClient(postgres)))
+		},
 	}
 
 	return &executor
@@ -49,6 +59,25 @@ func streamTextExtraCompute(ctx context.Context, query string) ([]GroupedResults
 	return computeTabulationResultToGroupedResults(streamResults), nil
 }
 
+func streamSearchAggregation(ctx context.Context, query string, mode types.SearchAggregationMode, metadata aggregation.FileMetadataSource) ([]GroupedResults, error) {
+	countFunc, err := aggregation.GetCountFuncForMode(ctx, query, "", mode, metadata)
+	if err != nil {
+		return nil, err
+	}
+	decoder, streamResults := streaming.AggregationDecoder(countFunc)
+	err = streaming.Search(ctx, query, nil, decoder)
+	if err != nil {
+		return nil, err
+	}
+	if len(streamResults.Errors) > 0 {
+		return nil, errors.Errorf("streaming search: errors: %v", streamResults.Errors)
+	}
+	if len(streamResults.Alerts) > 0 {
+		return nil, errors.Errorf("streaming search: alerts: %v", streamResults.Alerts)
+	}
+	return computeTabulationResultToGroupedResults(streamResults), nil
+}
+
 func (c *ComputeExecutor) Execute(ctx context.Context, query, groupBy string, repositories []string) ([]GeneratedTimeSeries, error) {
 	repoIds := make(map[string]api.RepoID)
 	for _, repository := range repositories {
@@ -62,12 +91,18 @@ func (c *ComputeExecutor) Execute(ctx context.Context, query, groupBy string, re
 	groupedValues := make(map[string]int)
 	for _, repository := range repositories {
 		modifiedQuery := querybuilder.SingleRepoQueryIndexed(querybuilder.BasicQuery(query), repository)
-		finalQuery, err := querybuilder.ComputeInsightCommandQuery(modifiedQuery, querybuilder.MapType(strings.ToLower(groupBy)))
-		if err != nil {
-			return nil, errors.Wrap(err, "query validation")
-		}
 
-		grouped, err := c.computeSearch(ctx, finalQuery.String())
+		var grouped []GroupedResults
+		var err error
+		if mapType := querybuilder.MapType(strings.ToLower(groupBy)); querybuilder.IsSearchAggregationMapType(mapType) {
+			grouped, err = c.aggregationSearch(ctx, modifiedQuery.String(), types.SearchAggregationMode(strings.ToUpper(groupBy)))
+		} else {
+			finalQuery, queryErr := querybuilder.ComputeInsightCommandQuery(modifiedQuery, mapType)
+			if queryErr != nil {
+				return nil, errors.Wrap(queryErr, "query validation")
+			}
+			grouped, err = c.computeSearch(ctx, finalQuery.String())
+		}
 		if err != nil {
 			errorMsg := "failed to execute capture group search for repository:" + repository
 			return nil, errors.Wrap(err, errorMsg)
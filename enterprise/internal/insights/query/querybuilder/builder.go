@@ -2,7 +2,9 @@ package querybuilder
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/grafana/regexp"
 
@@ -179,8 +181,19 @@ const (
 	Path   MapType = "path"
 	Author MapType = "author"
 	Date   MapType = "date"
+
+	// Owner and CommitDate are not supported by compute. Series grouped by them are
+	// aggregated from the results of the search query instead.
+	Owner      MapType = "owner"
+	CommitDate MapType = "commit_date"
 )
 
+// IsSearchAggregationMapType returns true if series grouped by the given type are aggregated
+// from search results rather than computed with a compute query.
+func IsSearchAggregationMapType(mapType MapType) bool {
+	return mapType == Owner || mapType == CommitDate
+}
+
 // This is the compute command that corresponds to the execution for Code Insights.
 const insightsComputeCommand = "output.extra"
 
@@ -272,6 +285,51 @@ func AddFileFilter(query BasicQuery, file string) (BasicQuery, error) {
 	return addFilterSimple(query, searchquery.FieldFile, file)
 }
 
+// AddLanguageFilter restricts the query to files in the given language, as detected by
+// the search backend.
+func AddLanguageFilter(query BasicQuery, language string) (BasicQuery, error) {
+	if strings.Contains(language, " ") {
+		language = strconv.Quote(language)
+	}
+	return addFilter(query, searchquery.FieldLang, language)
+}
+
+// AddCommitDateFilter restricts commit and diff searches in the query to commits made in
+// the given month, formatted as YYYY-MM. Other searches are returned unmodified.
+func AddCommitDateFilter(query BasicQuery, month string) (BasicQuery, error) {
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid month")
+	}
+	end := start.AddDate(0, 1, 0)
+
+	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
+	if err != nil {
+		return "", err
+	}
+
+	mutatedQuery := searchquery.MapPlan(plan, func(basic searchquery.Basic) searchquery.Basic {
+		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+2)
+		isCommitDiffType := false
+		for _, parameter := range basic.Parameters {
+			modified = append(modified, parameter)
+			if parameter.Field == searchquery.FieldType && (parameter.Value == "commit" || parameter.Value == "diff") {
+				isCommitDiffType = true
+			}
+		}
+		if !isCommitDiffType {
+			// only commits have a date, so return the original input
+			return basic
+		}
+		modified = append(modified,
+			searchquery.Parameter{Field: searchquery.FieldAfter, Value: start.Format("2006-01-02")},
+			searchquery.Parameter{Field: searchquery.FieldBefore, Value: end.Format("2006-01-02")},
+		)
+		return basic.MapParameters(modified)
+	})
+	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
+}
+
 func buildFilterText(raw string) string {
 	quoted := regexp.QuoteMeta(raw)
 	if strings.Contains(raw, " ") {
@@ -281,6 +339,11 @@ func buildFilterText(raw string) string {
 }
 
 func addFilterSimple(query BasicQuery, field, value string) (BasicQuery, error) {
+	return addFilter(query, field, buildFilterText(value))
+}
+
+// addFilter adds a filter with the given value as is to every step of the query.
+func addFilter(query BasicQuery, field, value string) (BasicQuery, error) {
 	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
 	if err != nil {
 		return "", err
@@ -291,7 +354,7 @@ func addFilterSimple(query BasicQuery, field, value string) (BasicQuery, error)
 		modified = append(modified, basic.Parameters...)
 		modified = append(modified, searchquery.Parameter{
 			Field:      field,
-			Value:      buildFilterText(value),
+			Value:      value,
 			Negated:    false,
 			Annotation: searchquery.Annotation{},
 		})
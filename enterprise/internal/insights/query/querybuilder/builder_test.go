@@ -602,6 +602,74 @@ func Test_addFileFilter(t *testing.T) {
 	}
 }
 
+func Test_addLanguageFilter(t *testing.T) {
+	tests := []struct {
+		input    string
+		language string
+		want     autogold.Value
+	}{
+		{
+			input:    "myquery repo:supergreat",
+			language: "Go",
+			want:     autogold.Want("adds language filter", BasicQuery("repo:supergreat lang:Go myquery")),
+		},
+		{
+			input:    "myquery",
+			language: "Protocol Buffer",
+			want:     autogold.Want("quotes language with whitespace", BasicQuery(`lang:"Protocol Buffer" myquery`)),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := AddLanguageFilter(BasicQuery(test.input), test.language)
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
+func Test_addCommitDateFilter(t *testing.T) {
+	tests := []struct {
+		input string
+		month string
+		want  autogold.Value
+	}{
+		{
+			input: "myquery repo:myrepo type:commit",
+			month: "2022-12",
+			want:  autogold.Want("commit search", BasicQuery("repo:myrepo type:commit after:2022-12-01 before:2023-01-01 myquery")),
+		},
+		{
+			input: "myquery repo:myrepo type:diff",
+			month: "2022-04",
+			want:  autogold.Want("diff search", BasicQuery("repo:myrepo type:diff after:2022-04-01 before:2022-05-01 myquery")),
+		},
+		{
+			input: "myquery repo:myrepo",
+			month: "2022-04",
+			want:  autogold.Want("file search - should return input", BasicQuery("repo:myrepo myquery")),
+		},
+		{
+			input: "myquery type:commit",
+			month: "April",
+			want:  autogold.Want("invalid month - should error", `invalid month: parsing time "April" as "2006-01": cannot parse "April" as "2006"`),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := AddCommitDateFilter(BasicQuery(test.input), test.month)
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
 func TestRepositoryScopeQuery(t *testing.T) {
 	tests := []struct {
 		input string
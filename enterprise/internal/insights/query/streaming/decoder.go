@@ -6,8 +6,11 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/compute"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/compute/client"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/aggregation"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
 	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
 	itypes "github.com/sourcegraph/sourcegraph/internal/types"
@@ -272,3 +275,110 @@ func SelectRepoDecoder() (streamhttp.FrontendStreamDecoder, *SelectRepoResult) {
 		},
 	}, repoResult
 }
+
+// AggregationDecoder groups search results using the given search aggregation count function.
+func AggregationDecoder(countFunc aggregation.AggregationCountFunc) (streamhttp.FrontendStreamDecoder, *ComputeTabulationResult) {
+	ctr := &ComputeTabulationResult{
+		RepoCounts: make(map[string]*ComputeMatch),
+	}
+	getRepoCounts := func(key aggregation.MatchKey) *ComputeMatch {
+		if got, ok := ctr.RepoCounts[key.Repo]; ok {
+			return got
+		}
+		v := newComputeMatch(key.Repo, key.RepoID)
+		ctr.RepoCounts[key.Repo] = v
+		return v
+	}
+
+	return streamhttp.FrontendStreamDecoder{
+		OnProgress: ctr.onProgress,
+		OnMatches: func(matches []streamhttp.EventMatch) {
+			for _, match := range matches {
+				resultMatch := toResultMatch(match)
+				if resultMatch == nil {
+					continue
+				}
+				groups, err := countFunc(resultMatch)
+				if err != nil {
+					ctr.Errors = append(ctr.Errors, err.Error())
+					continue
+				}
+				for key, count := range groups {
+					value := key.Group
+					if len(value) > capturedValueMaxLength {
+						value = value[:capturedValueMaxLength]
+					}
+					ctr.TotalCount += count
+					getRepoCounts(key).ValueCounts[value] += count
+				}
+			}
+		},
+		OnAlert: func(ea *streamhttp.EventAlert) {
+			if ea.Title == "No repositories found" {
+				// If we hit a case where we don't find a repository we don't want to error, just
+				// complete our search.
+			} else {
+				ctr.Alerts = append(ctr.Alerts, fmt.Sprintf("%s: %s", ea.Title, ea.Description))
+			}
+		},
+		OnError: func(eventError *streamhttp.EventError) {
+			ctr.Errors = append(ctr.Errors, eventError.Message)
+		},
+	}, ctr
+}
+
+// toResultMatch converts a streamed match into the search result it was created from, as far
+// as it is needed to aggregate it.
+func toResultMatch(match streamhttp.EventMatch) result.Match {
+	switch match := match.(type) {
+	case *streamhttp.EventContentMatch:
+		chunkMatches := make(result.ChunkMatches, 0, len(match.ChunkMatches))
+		for _, chunkMatch := range match.ChunkMatches {
+			chunkMatches = append(chunkMatches, result.ChunkMatch{
+				Content: chunkMatch.Content,
+				Ranges:  make(result.Ranges, len(chunkMatch.Ranges)),
+			})
+		}
+		return &result.FileMatch{
+			File:         toResultFile(match.Repository, match.RepositoryID, match.Commit, match.Path),
+			ChunkMatches: chunkMatches,
+		}
+	case *streamhttp.EventPathMatch:
+		return &result.FileMatch{File: toResultFile(match.Repository, match.RepositoryID, match.Commit, match.Path)}
+	case *streamhttp.EventSymbolMatch:
+		symbols := make([]*result.SymbolMatch, 0, len(match.Symbols))
+		for _, symbol := range match.Symbols {
+			symbols = append(symbols, &result.SymbolMatch{Symbol: result.Symbol{Name: symbol.Name}})
+		}
+		return &result.FileMatch{
+			File:    toResultFile(match.Repository, match.RepositoryID, match.Commit, match.Path),
+			Symbols: symbols,
+		}
+	case *streamhttp.EventCommitMatch:
+		return &result.CommitMatch{
+			Repo: itypes.MinimalRepo{ID: api.RepoID(match.RepositoryID), Name: api.RepoName(match.Repository)},
+			Commit: gitdomain.Commit{
+				ID:        api.CommitID(match.OID),
+				Author:    gitdomain.Signature{Name: match.AuthorName, Date: match.AuthorDate},
+				Committer: &gitdomain.Signature{Name: match.CommitterName, Date: match.CommitterDate},
+				Message:   gitdomain.Message(match.Message),
+			},
+			MessagePreview: &result.MatchedString{
+				Content:       match.Content,
+				MatchedRanges: make(result.Ranges, len(match.Ranges)),
+			},
+		}
+	case *streamhttp.EventRepoMatch:
+		return &result.RepoMatch{ID: api.RepoID(match.RepositoryID), Name: api.RepoName(match.Repository)}
+	default:
+		return nil
+	}
+}
+
+func toResultFile(repo string, repoID int32, commit, path string) result.File {
+	return result.File{
+		Repo:     itypes.MinimalRepo{ID: api.RepoID(repoID), Name: api.RepoName(repo)},
+		CommitID: api.CommitID(commit),
+		Path:     path,
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
 	"github.com/sourcegraph/sourcegraph/internal/search/limits"
@@ -34,6 +35,9 @@ const (
 // Possible reasons that grouping is disabled
 const invalidQueryMsg = "Grouping is disabled because the search query is not valid."
 const fileUnsupportedFieldValueFmt = `Grouping by file is not available for searches with "%s:%s".`
+const languageUnsupportedFieldValueFmt = `Grouping by language is not available for searches with "%s:%s".`
+const ownerUnsupportedFieldValueFmt = `Grouping by owner is not available for searches with "%s:%s".`
+const commitDateUnsupportedFieldValueFmt = `Grouping by commit date is not available for searches with "%s:%s".`
 const authNotCommitDiffMsg = "Grouping by author is only available for diff and commit searches."
 const cgInvalidQueryMsg = "Grouping by capture group is only available for regexp searches that contain a capturing group."
 const cgMultipleQueryPatternMsg = "Grouping by capture group does not support search patterns with the following: and, or, negation."
@@ -138,7 +142,10 @@ func (r *searchAggregateResolver) Aggregations(ctx context.Context, args graphql
 		cappedAggregator.Add(amr.Key.Group, int32(amr.Count))
 	}
 
-	countingFunc, err := aggregation.GetCountFuncForMode(r.searchQuery, r.patternType, aggregationMode)
+	// Only used by modes that group files by information that isn't part of the search results,
+	// such as their owners.
+	metadata := aggregation.NewGitserverFileMetadataSource(gitserver.NewClient(r.postgresDB))
+	countingFunc, err := aggregation.GetCountFuncForMode(ctx, r.searchQuery, r.patternType, aggregationMode, metadata)
 	if err != nil {
 		r.getLogger().Debug("no aggregation counting function for mode", log.String("mode", string(aggregationMode)), log.Error(err))
 		return &searchAggregationResultResolver{
@@ -368,6 +375,9 @@ func getAggregateBy(mode types.SearchAggregationMode) canAggregateBy {
 		types.PATH_AGGREGATION_MODE:          canAggregateByPath,
 		types.AUTHOR_AGGREGATION_MODE:        canAggregateByAuthor,
 		types.CAPTURE_GROUP_AGGREGATION_MODE: canAggregateByCaptureGroup,
+		types.LANGUAGE_AGGREGATION_MODE:      canAggregateByLanguage,
+		types.OWNER_AGGREGATION_MODE:         canAggregateByOwner,
+		types.COMMIT_DATE_AGGREGATION_MODE:   canAggregateByCommitDate,
 	}
 	canAggregateByFunc, ok := checkByMode[mode]
 	if !ok {
@@ -393,6 +403,20 @@ func canAggregateByRepo(searchQuery, patternType string) (bool, *notAvailableRea
 }
 
 func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByFile(searchQuery, patternType, fileUnsupportedFieldValueFmt)
+}
+
+func canAggregateByLanguage(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByFile(searchQuery, patternType, languageUnsupportedFieldValueFmt)
+}
+
+func canAggregateByOwner(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByFile(searchQuery, patternType, ownerUnsupportedFieldValueFmt)
+}
+
+// canAggregateByFile checks that a query returns file matches, using the given format for
+// the reason if it doesn't.
+func canAggregateByFile(searchQuery, patternType, unsupportedFieldValueFmt string) (bool, *notAvailableReason, error) {
 	plan, err := querybuilder.ParseQuery(searchQuery, patternType)
 	if err != nil {
 		return false, &notAvailableReason{reason: invalidQueryMsg, reasonType: types.INVALID_QUERY}, errors.Wrapf(err, "ParseQuery")
@@ -403,7 +427,27 @@ func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableRea
 	for _, parameter := range parameters {
 		if parameter.Field == query.FieldSelect || parameter.Field == query.FieldType {
 			if strings.EqualFold(parameter.Value, "commit") || strings.EqualFold(parameter.Value, "diff") || strings.EqualFold(parameter.Value, "repo") {
-				reason := fmt.Sprintf(fileUnsupportedFieldValueFmt,
+				reason := fmt.Sprintf(unsupportedFieldValueFmt,
+					parameter.Field, parameter.Value)
+				return false, &notAvailableReason{reason: reason, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
+			}
+		}
+	}
+	return true, nil, nil
+}
+
+func canAggregateByCommitDate(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	plan, err := querybuilder.ParseQuery(searchQuery, patternType)
+	if err != nil {
+		return false, &notAvailableReason{reason: invalidQueryMsg, reasonType: types.INVALID_QUERY}, errors.Wrapf(err, "ParseQuery")
+	}
+	parameters := querybuilder.ParametersFromQueryPlan(plan)
+	// commits are grouped by their date and files by the date of their last commit, but
+	// repositories have no commit date.
+	for _, parameter := range parameters {
+		if parameter.Field == query.FieldSelect || parameter.Field == query.FieldType {
+			if strings.EqualFold(parameter.Value, "repo") {
+				reason := fmt.Sprintf(commitDateUnsupportedFieldValueFmt,
 					parameter.Field, parameter.Value)
 				return false, &notAvailableReason{reason: reason, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
 			}
@@ -585,6 +629,13 @@ func buildDrilldownQuery(mode types.SearchAggregationMode, originalQuery string,
 		modifierFunc = querybuilder.AddFileFilter
 	case types.AUTHOR_AGGREGATION_MODE:
 		modifierFunc = querybuilder.AddAuthorFilter
+	case types.LANGUAGE_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddLanguageFilter
+	case types.COMMIT_DATE_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddCommitDateFilter
+	case types.OWNER_AGGREGATION_MODE:
+		// There is no search filter for owners, so we can't narrow down the original query.
+		return originalQuery, nil
 	case types.CAPTURE_GROUP_AGGREGATION_MODE:
 		searchType, err := client.SearchTypeFromString(patternType)
 		if err != nil {
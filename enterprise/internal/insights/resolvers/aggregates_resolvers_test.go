@@ -128,6 +128,40 @@ func Test_canAggregateByPath(t *testing.T) {
 	suite.Test_canAggregateBy()
 }
 
+func Test_canAggregateByCommitDate(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "can aggregate for file search",
+			query:        "func(t *testing.T)",
+			canAggregate: true,
+		},
+		{
+			name:         "can aggregate for query with type:commit parameter",
+			query:        "insights type:commit",
+			canAggregate: true,
+		},
+		{
+			name:         "cannot aggregate for query with select:repo parameter",
+			query:        "repo:contains.path(README) select:repo",
+			reason:       fmt.Sprintf(commitDateUnsupportedFieldValueFmt, "select", "repo"),
+			canAggregate: false,
+		},
+		{
+			name:         "cannot aggregate for invalid query",
+			query:        "insights type:commit fork:test",
+			canAggregate: false,
+			reason:       invalidQueryMsg,
+			err:          errors.Newf("ParseQuery"),
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByCommitDate,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
 func Test_canAggregateByAuthor(t *testing.T) {
 	testCases := []canAggregateTestCase{
 		{
@@ -470,6 +504,27 @@ func Test_buildDrilldownQuery(t *testing.T) {
 			patternType: "standard",
 			mode:        types.CAPTURE_GROUP_AGGREGATION_MODE,
 		},
+		{
+			want:        autogold.Want("language", "lang:Go findme"),
+			query:       "findme",
+			drilldown:   "Go",
+			patternType: "standard",
+			mode:        types.LANGUAGE_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("commit_date", "type:commit after:2022-04-01 before:2022-05-01 findme"),
+			query:       "findme type:commit",
+			drilldown:   "2022-04",
+			patternType: "standard",
+			mode:        types.COMMIT_DATE_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("owner_returns_original_query", "findme"),
+			query:       "findme",
+			drilldown:   "@owner",
+			patternType: "standard",
+			mode:        types.OWNER_AGGREGATION_MODE,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.want.Name(), func(t *testing.T) {
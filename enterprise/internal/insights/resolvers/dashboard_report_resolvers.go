@@ -0,0 +1,185 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ graphqlbackend.InsightsDashboardReportSubscriptionResolver = &dashboardReportSubscriptionResolver{}
+
+const dashboardReportSubscriptionKind = "InsightsDashboardReportSubscription"
+
+func (i *insightsDashboardResolver) ReportSubscriptions(ctx context.Context) ([]graphqlbackend.InsightsDashboardReportSubscriptionResolver, error) {
+	if !i.id.isReal() {
+		return []graphqlbackend.InsightsDashboardReportSubscriptionResolver{}, nil
+	}
+	subscriptions, err := i.dashboardStore.GetReportSubscriptions(ctx, store.ReportSubscriptionQueryArgs{DashboardID: []int{i.dashboard.ID}})
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.InsightsDashboardReportSubscriptionResolver, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		resolvers = append(resolvers, &dashboardReportSubscriptionResolver{subscription: subscription})
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CreateInsightsDashboardReportSubscription(ctx context.Context, args *graphqlbackend.CreateInsightsDashboardReportSubscriptionArgs) (graphqlbackend.InsightsDashboardReportSubscriptionResolver, error) {
+	currentUser := actor.FromContext(ctx)
+	if !currentUser.IsAuthenticated() {
+		return nil, auth.ErrNotAuthenticated
+	}
+	dashboardID, err := unmarshalDashboardID(args.Input.DashboardID)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal dashboard id")
+	}
+	if !dashboardID.isReal() {
+		return nil, errors.New("reports can only be subscribed to for custom dashboards")
+	}
+	cadence := types.ReportCadence(args.Input.Cadence)
+	if !cadence.Valid() {
+		return nil, errors.Newf("invalid report cadence %q", args.Input.Cadence)
+	}
+	if args.Input.SlackWebhookURL != nil {
+		if err := validateSlackWebhookURL(*args.Input.SlackWebhookURL); err != nil {
+			return nil, err
+		}
+	}
+	if len(args.Input.Recipients) == 0 && args.Input.SlackWebhookURL == nil {
+		return nil, errors.New("a report subscription requires at least one recipient or a Slack webhook")
+	}
+
+	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
+	if err := permissionsValidator.validateUserAccessForDashboard(ctx, int(dashboardID.Arg)); err != nil {
+		return nil, err
+	}
+
+	recipients := make([]int32, 0, len(args.Input.Recipients))
+	for _, id := range args.Input.Recipients {
+		userID, err := graphqlbackend.UnmarshalUserID(id)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("unable to unmarshal user id: %s", id))
+		}
+		// 🚨 SECURITY: Digests contain the data of the dashboard, so they may only be sent to users that
+		// can view it.
+		visible, err := r.userCanViewDashboard(ctx, userID, int(dashboardID.Arg))
+		if err != nil {
+			return nil, err
+		}
+		if !visible {
+			return nil, errors.Newf("user %s cannot view the dashboard", id)
+		}
+		recipients = append(recipients, userID)
+	}
+
+	subscription, err := r.dashboardStore.CreateReportSubscription(ctx, types.DashboardReportSubscription{
+		DashboardID:      int(dashboardID.Arg),
+		Cadence:          cadence,
+		RecipientUserIDs: recipients,
+		SlackWebhookURL:  args.Input.SlackWebhookURL,
+		CreatedByUserID:  currentUser.UID,
+		NextSendAt:       cadence.Next(r.dashboardStore.Now()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dashboardReportSubscriptionResolver{subscription: subscription}, nil
+}
+
+func (r *Resolver) DeleteInsightsDashboardReportSubscription(ctx context.Context, args *graphqlbackend.DeleteInsightsDashboardReportSubscriptionArgs) (*graphqlbackend.EmptyResponse, error) {
+	var id int
+	if err := relay.UnmarshalSpec(args.Id, &id); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal report subscription id")
+	}
+
+	subscriptions, err := r.dashboardStore.GetReportSubscriptions(ctx, store.ReportSubscriptionQueryArgs{ID: []int{id}})
+	if err != nil {
+		return nil, err
+	}
+	if len(subscriptions) == 0 {
+		return nil, errors.New("report subscription not found")
+	}
+
+	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
+	if err := permissionsValidator.validateUserAccessForDashboard(ctx, subscriptions[0].DashboardID); err != nil {
+		return nil, err
+	}
+
+	if err := r.dashboardStore.DeleteReportSubscription(ctx, id); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) userCanViewDashboard(ctx context.Context, userID int32, dashboardID int) (bool, error) {
+	orgs, err := r.postgresDB.Orgs().GetByUserID(ctx, userID)
+	if err != nil {
+		return false, errors.Wrap(err, "GetByUserID")
+	}
+	orgIDs := make([]int, 0, len(orgs))
+	for _, org := range orgs {
+		orgIDs = append(orgIDs, int(org.ID))
+	}
+	return r.dashboardStore.HasDashboardPermission(ctx, []int{dashboardID}, []int{int(userID)}, orgIDs)
+}
+
+func validateSlackWebhookURL(webhookURL string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return errors.Newf("invalid Slack webhook URL %q", webhookURL)
+	}
+	return nil
+}
+
+type dashboardReportSubscriptionResolver struct {
+	subscription *types.DashboardReportSubscription
+}
+
+func (r *dashboardReportSubscriptionResolver) ID() graphql.ID {
+	return relay.MarshalID(dashboardReportSubscriptionKind, r.subscription.ID)
+}
+
+func (r *dashboardReportSubscriptionResolver) Cadence() string {
+	return string(r.subscription.Cadence)
+}
+
+func (r *dashboardReportSubscriptionResolver) Recipients() []graphql.ID {
+	ids := make([]graphql.ID, 0, len(r.subscription.RecipientUserIDs))
+	for _, userID := range r.subscription.RecipientUserIDs {
+		ids = append(ids, graphqlbackend.MarshalUserID(userID))
+	}
+	return ids
+}
+
+func (r *dashboardReportSubscriptionResolver) SlackWebhookURL(ctx context.Context) *string {
+	// 🚨 SECURITY: Anyone with the webhook URL can post to the channel, so it is only shown to the
+	// creator of the subscription.
+	if actor.FromContext(ctx).UID != r.subscription.CreatedByUserID {
+		return nil
+	}
+	return r.subscription.SlackWebhookURL
+}
+
+func (r *dashboardReportSubscriptionResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.subscription.CreatedAt}
+}
+
+func (r *dashboardReportSubscriptionResolver) LastSentAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.subscription.LastSentAt)
+}
+
+func (r *dashboardReportSubscriptionResolver) NextSendAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.subscription.NextSendAt}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -248,6 +249,9 @@ func (r *Resolver) CreateInsightsDashboard(ctx context.Context, args *graphqlbac
 	if !hasPermissionToCreate {
 		return nil, errors.New("user does not have permission to create this dashboard")
 	}
+	if err := r.checkGlobalDashboardPermission(ctx, hasGlobalGrant(dashboardGrants)); err != nil {
+		return nil, err
+	}
 
 	dashboard, err := r.dashboardStore.CreateDashboard(ctx, store.CreateDashboardArgs{
 		Dashboard: types.Dashboard{Title: args.Input.Title, Save: true},
@@ -286,6 +290,13 @@ func (r *Resolver) UpdateInsightsDashboard(ctx context.Context, args *graphqlbac
 	if err != nil {
 		return nil, err
 	}
+	isGlobal, err := r.isGlobalDashboard(ctx, int(dashboardID.Arg))
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkGlobalDashboardPermission(ctx, isGlobal || hasGlobalGrant(dashboardGrants)); err != nil {
+		return nil, err
+	}
 
 	dashboard, err := r.dashboardStore.UpdateDashboard(ctx, store.UpdateDashboardArgs{
 		ID:     int(dashboardID.Arg),
@@ -355,6 +366,37 @@ func hasPermissionForGrants(dashboardGrants []store.DashboardGrant, userIds []in
 	return true
 }
 
+func hasGlobalGrant(dashboardGrants []store.DashboardGrant) bool {
+	for _, grant := range dashboardGrants {
+		if grant.Global != nil && *grant.Global {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Resolver) isGlobalDashboard(ctx context.Context, dashboardID int) (bool, error) {
+	grants, err := r.dashboardStore.GetDashboardGrants(ctx, dashboardID)
+	if err != nil {
+		return false, errors.Wrap(err, "GetDashboardGrants")
+	}
+	for _, grant := range grants {
+		if grant.Global != nil && *grant.Global {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// 🚨 SECURITY: Dashboards that are visible to all users may only be managed by
+// site admins and users with permission to manage global dashboards.
+func (r *Resolver) checkGlobalDashboardPermission(ctx context.Context, global bool) error {
+	if !global {
+		return nil
+	}
+	return rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsGlobalDashboardsManage)
+}
+
 func (r *Resolver) DeleteInsightsDashboard(ctx context.Context, args *graphqlbackend.DeleteInsightsDashboardArgs) (*graphqlbackend.EmptyResponse, error) {
 	emptyResponse := &graphqlbackend.EmptyResponse{}
 
@@ -382,6 +424,13 @@ func (r *Resolver) DeleteInsightsDashboard(ctx context.Context, args *graphqlbac
 	if err != nil {
 		return nil, err
 	}
+	isGlobal, err := r.isGlobalDashboard(ctx, int(dashboardID.Arg))
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkGlobalDashboardPermission(ctx, isGlobal); err != nil {
+		return nil, err
+	}
 
 	err = r.dashboardStore.DeleteDashboard(ctx, int(dashboardID.Arg))
 	if err != nil {
@@ -39,6 +39,14 @@ func (r *disabledResolver) RemoveInsightViewFromDashboard(ctx context.Context, a
 	return nil, errors.New(r.reason)
 }
 
+func (r *disabledResolver) CreateInsightsDashboardReportSubscription(ctx context.Context, args *graphqlbackend.CreateInsightsDashboardReportSubscriptionArgs) (graphqlbackend.InsightsDashboardReportSubscriptionResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) DeleteInsightsDashboardReportSubscription(ctx context.Context, args *graphqlbackend.DeleteInsightsDashboardReportSubscriptionArgs) (*graphqlbackend.EmptyResponse, error) {
+	return nil, errors.New(r.reason)
+}
+
 func (r *disabledResolver) UpdateInsightSeries(ctx context.Context, args *graphqlbackend.UpdateInsightSeriesArgs) (graphqlbackend.InsightSeriesMetadataPayloadResolver, error) {
 	return nil, errors.New(r.reason)
 }
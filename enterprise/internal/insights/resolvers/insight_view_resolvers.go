@@ -1461,6 +1461,9 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 func searchGenerationMethod(series graphqlbackend.LineChartSearchInsightDataSeriesInput) types.GenerationMethod {
 	if series.GeneratedFromCaptureGroups != nil && *series.GeneratedFromCaptureGroups {
 		if series.GroupBy != nil {
+			if querybuilder.IsSearchAggregationMapType(querybuilder.MapType(strings.ToLower(*series.GroupBy))) {
+				return types.SearchAggregation
+			}
 			return types.MappingCompute
 		}
 		return types.SearchCompute
@@ -0,0 +1,154 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type ReportSubscriptionQueryArgs struct {
+	ID          []int
+	DashboardID []int
+	// DueBefore limits results to subscriptions of dashboards that are not deleted with a report due
+	// at or before the given time.
+	DueBefore *time.Time
+	Limit     int
+}
+
+// GetReportSubscriptions returns the report subscriptions matching the given arguments, ordered by ID.
+func (s *DBDashboardStore) GetReportSubscriptions(ctx context.Context, args ReportSubscriptionQueryArgs) ([]*types.DashboardReportSubscription, error) {
+	preds := make([]*sqlf.Query, 0, 3)
+	if len(args.ID) > 0 {
+		preds = append(preds, sqlf.Sprintf("drs.id = ANY(%s)", pq.Array(args.ID)))
+	}
+	if len(args.DashboardID) > 0 {
+		preds = append(preds, sqlf.Sprintf("drs.dashboard_id = ANY(%s)", pq.Array(args.DashboardID)))
+	}
+	if args.DueBefore != nil {
+		preds = append(preds, sqlf.Sprintf("drs.next_send_at <= %s", *args.DueBefore))
+		preds = append(preds, sqlf.Sprintf("db.deleted_at IS NULL"))
+	}
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+	limitClause := sqlf.Sprintf("")
+	if args.Limit > 0 {
+		limitClause = sqlf.Sprintf("LIMIT %s", args.Limit)
+	}
+
+	q := sqlf.Sprintf(getReportSubscriptionsSql, sqlf.Join(preds, "\n AND "), limitClause)
+	return scanReportSubscriptions(s.Query(ctx, q))
+}
+
+// CreateReportSubscription stores a new report subscription. The first report is due at
+// subscription.NextSendAt.
+func (s *DBDashboardStore) CreateReportSubscription(ctx context.Context, subscription types.DashboardReportSubscription) (*types.DashboardReportSubscription, error) {
+	if !subscription.Cadence.Valid() {
+		return nil, errors.Newf("invalid report cadence %q", subscription.Cadence)
+	}
+	recipients := subscription.RecipientUserIDs
+	if recipients == nil {
+		recipients = []int32{}
+	}
+
+	q := sqlf.Sprintf(
+		insertReportSubscriptionSql,
+		subscription.DashboardID,
+		subscription.Cadence,
+		pq.Array(recipients),
+		subscription.SlackWebhookURL,
+		subscription.CreatedByUserID,
+		s.Now(),
+		subscription.NextSendAt,
+	)
+	subscriptions, err := scanReportSubscriptions(s.Query(ctx, q))
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateReportSubscription")
+	}
+	if len(subscriptions) == 0 {
+		return nil, errors.New("report subscription was not created")
+	}
+	return subscriptions[0], nil
+}
+
+// DeleteReportSubscription hard deletes the report subscription with the given ID.
+func (s *DBDashboardStore) DeleteReportSubscription(ctx context.Context, id int) error {
+	err := s.Exec(ctx, sqlf.Sprintf(deleteReportSubscriptionSql, id))
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete report subscription with id: %d", id)
+	}
+	return nil
+}
+
+// MarkReportSubscriptionSent records that a report was sent at sentAt and that the next one is due at
+// nextSendAt.
+func (s *DBDashboardStore) MarkReportSubscriptionSent(ctx context.Context, id int, sentAt, nextSendAt time.Time) error {
+	err := s.Exec(ctx, sqlf.Sprintf(markReportSubscriptionSentSql, sentAt, nextSendAt, id))
+	if err != nil {
+		return errors.Wrapf(err, "failed to mark report subscription with id %d as sent", id)
+	}
+	return nil
+}
+
+func scanReportSubscriptions(rows *sql.Rows, queryErr error) (_ []*types.DashboardReportSubscription, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var results []*types.DashboardReportSubscription
+	for rows.Next() {
+		var temp types.DashboardReportSubscription
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.DashboardID,
+			&temp.Cadence,
+			pq.Array(&temp.RecipientUserIDs),
+			&temp.SlackWebhookURL,
+			&temp.CreatedByUserID,
+			&temp.CreatedAt,
+			&temp.LastSentAt,
+			&temp.NextSendAt,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, &temp)
+	}
+	return results, nil
+}
+
+const reportSubscriptionColumns = `
+drs.id, drs.dashboard_id, drs.cadence, drs.recipient_user_ids, drs.slack_webhook_url,
+drs.created_by_user_id, drs.created_at, drs.last_sent_at, drs.next_send_at
+`
+
+const getReportSubscriptionsSql = `
+SELECT` + reportSubscriptionColumns + `
+FROM dashboard_report_subscriptions drs
+JOIN dashboard db ON db.id = drs.dashboard_id
+WHERE %s
+ORDER BY drs.id
+%s;
+`
+
+const insertReportSubscriptionSql = `
+INSERT INTO dashboard_report_subscriptions AS drs
+	(dashboard_id, cadence, recipient_user_ids, slack_webhook_url, created_by_user_id, created_at, next_send_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+RETURNING` + reportSubscriptionColumns + `;
+`
+
+const deleteReportSubscriptionSql = `
+DELETE FROM dashboard_report_subscriptions WHERE id = %s;
+`
+
+const markReportSubscriptionSentSql = `
+UPDATE dashboard_report_subscriptions SET last_sent_at = %s, next_send_at = %s WHERE id = %s;
+`
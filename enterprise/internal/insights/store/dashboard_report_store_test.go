@@ -0,0 +1,148 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestReportSubscriptions(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	now := time.Now().UTC().Truncate(time.Microsecond).Round(0)
+	ctx := context.Background()
+
+	_, err := insightsDB.ExecContext(ctx, `
+		INSERT INTO dashboard (id, title, deleted_at)
+		VALUES (1, 'test dashboard', NULL), (2, 'deleted dashboard', NOW());`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewDashboardStore(insightsDB)
+	store.Now = func() time.Time { return now }
+
+	webhook := "https://hooks.slack.com/services/test"
+	due, err := store.CreateReportSubscription(ctx, types.DashboardReportSubscription{
+		DashboardID:      1,
+		Cadence:          types.ReportCadenceWeekly,
+		RecipientUserIDs: []int32{1, 2},
+		CreatedByUserID:  1,
+		NextSendAt:       now.Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	notDue, err := store.CreateReportSubscription(ctx, types.DashboardReportSubscription{
+		DashboardID:     1,
+		Cadence:         types.ReportCadenceDaily,
+		SlackWebhookURL: &webhook,
+		CreatedByUserID: 2,
+		NextSendAt:      now.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deletedDashboard, err := store.CreateReportSubscription(ctx, types.DashboardReportSubscription{
+		DashboardID:     2,
+		Cadence:         types.ReportCadenceMonthly,
+		CreatedByUserID: 1,
+		NextSendAt:      now.Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.CreateReportSubscription(ctx, types.DashboardReportSubscription{DashboardID: 1, Cadence: "HOURLY"}); err == nil {
+		t.Fatal("expected error for invalid cadence")
+	}
+
+	want := &types.DashboardReportSubscription{
+		ID:               due.ID,
+		DashboardID:      1,
+		Cadence:          types.ReportCadenceWeekly,
+		RecipientUserIDs: []int32{1, 2},
+		CreatedByUserID:  1,
+		CreatedAt:        now,
+		NextSendAt:       now.Add(-time.Minute),
+	}
+	if diff := cmp.Diff(want, due); diff != "" {
+		t.Errorf("unexpected created subscription (-want +got):\n%s", diff)
+	}
+
+	ids := func(subscriptions []*types.DashboardReportSubscription) []int {
+		result := []int{}
+		for _, subscription := range subscriptions {
+			result = append(result, subscription.ID)
+		}
+		return result
+	}
+
+	t.Run("by dashboard", func(t *testing.T) {
+		got, err := store.GetReportSubscriptions(ctx, ReportSubscriptionQueryArgs{DashboardID: []int{1}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int{due.ID, notDue.ID}, ids(got)); diff != "" {
+			t.Errorf("unexpected subscriptions (-want +got):\n%s", diff)
+		}
+		if got[1].SlackWebhookURL == nil || *got[1].SlackWebhookURL != webhook {
+			t.Errorf("unexpected webhook URL %v", got[1].SlackWebhookURL)
+		}
+		if len(got[1].RecipientUserIDs) != 0 {
+			t.Errorf("unexpected recipients %v", got[1].RecipientUserIDs)
+		}
+	})
+
+	t.Run("due subscriptions of dashboards that are not deleted", func(t *testing.T) {
+		got, err := store.GetReportSubscriptions(ctx, ReportSubscriptionQueryArgs{DueBefore: &now})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int{due.ID}, ids(got)); diff != "" {
+			t.Errorf("unexpected subscriptions (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("mark sent", func(t *testing.T) {
+		next := now.Add(7 * 24 * time.Hour)
+		if err := store.MarkReportSubscriptionSent(ctx, due.ID, now, next); err != nil {
+			t.Fatal(err)
+		}
+		got, err := store.GetReportSubscriptions(ctx, ReportSubscriptionQueryArgs{ID: []int{due.ID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || got[0].LastSentAt == nil || !got[0].LastSentAt.Equal(now) || !got[0].NextSendAt.Equal(next) {
+			t.Fatalf("unexpected subscription after marking it sent: %+v", got)
+		}
+
+		gotDue, err := store.GetReportSubscriptions(ctx, ReportSubscriptionQueryArgs{DueBefore: &now})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(gotDue) != 0 {
+			t.Errorf("expected no due subscriptions, got %v", ids(gotDue))
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := store.DeleteReportSubscription(ctx, deletedDashboard.ID); err != nil {
+			t.Fatal(err)
+		}
+		got, err := store.GetReportSubscriptions(ctx, ReportSubscriptionQueryArgs{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int{due.ID, notDue.ID}, ids(got)); diff != "" {
+			t.Errorf("unexpected subscriptions (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -299,6 +299,60 @@ func (s *Store) LoadSeriesInMem(ctx context.Context, opts SeriesPointsOpts) (poi
 	return points, err
 }
 
+// RepoSeriesPoint describes the value of an insights' series for a single repository at a point in time.
+type RepoSeriesPoint struct {
+	RepoID   api.RepoID
+	RepoName string
+	Time     time.Time
+	Value    float64
+	Capture  *string
+}
+
+// SeriesPointsByRepo queries data points over time for a specific insights' series, broken down by
+// repository. Points that are not associated with a repository are omitted.
+func (s *Store) SeriesPointsByRepo(ctx context.Context, opts SeriesPointsOpts) ([]RepoSeriesPoint, error) {
+	// 🚨 SECURITY: Exclude repositories the current user cannot see, see SeriesPoints.
+	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts.Excluded = append(opts.Excluded, denylist...)
+
+	points := make([]RepoSeriesPoint, 0, opts.Limit)
+	err = s.query(ctx, seriesPointsQuery(repoSeriesPointsAggregation, opts), func(sc scanner) error {
+		var point RepoSeriesPoint
+		if err := sc.Scan(
+			&point.RepoID,
+			&point.RepoName,
+			&point.Time,
+			&point.Value,
+			&point.Capture,
+		); err != nil {
+			return err
+		}
+		points = append(points, point)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+// As in fullVectorSeriesAggregation, the maximum per repository and interval eliminates duplicate points.
+const repoSeriesPointsAggregation = `
+SELECT sp.repo_id, rname.name, date_trunc('seconds', sp.time) AS interval_time, MAX(value) as value, capture
+FROM (  select * from series_points
+		union all
+		select * from series_points_snapshots
+) AS sp
+JOIN repo_names rname ON sp.repo_name_id = rname.id
+%s
+WHERE sp.repo_id IS NOT NULL AND %s
+GROUP BY sp.repo_id, rname.name, interval_time, capture
+ORDER BY interval_time ASC, sp.repo_id
+`
+
 // Delete will delete the time series data for a particular series_id. This will hard (permanently) delete the data.
 func (s *Store) Delete(ctx context.Context, seriesId string) (err error) {
 	tx, err := s.Transact(ctx)
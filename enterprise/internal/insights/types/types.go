@@ -129,6 +129,10 @@ const (
 	SearchCompute  GenerationMethod = "search-compute"
 	LanguageStats  GenerationMethod = "language-stats"
 	MappingCompute GenerationMethod = "mapping-compute"
+
+	// SearchAggregation series are grouped by a search aggregation mode that
+	// can't be computed by the compute API, such as file owners.
+	SearchAggregation GenerationMethod = "search-aggregation"
 )
 
 type Dashboard struct {
@@ -141,6 +145,48 @@ type Dashboard struct {
 	Save         bool // temporarily save dashboards from being cleared during setting migration
 }
 
+// DashboardReportSubscription is a subscription to a periodic digest of a dashboard.
+type DashboardReportSubscription struct {
+	ID               int
+	DashboardID      int
+	Cadence          ReportCadence
+	RecipientUserIDs []int32 // references user(id) in the main application database
+	SlackWebhookURL  *string
+	CreatedByUserID  int32
+	CreatedAt        time.Time
+	LastSentAt       *time.Time
+	NextSendAt       time.Time
+}
+
+type ReportCadence string
+
+const (
+	ReportCadenceDaily   ReportCadence = "DAILY"
+	ReportCadenceWeekly  ReportCadence = "WEEKLY"
+	ReportCadenceMonthly ReportCadence = "MONTHLY"
+)
+
+// Valid returns true if c is a known cadence.
+func (c ReportCadence) Valid() bool {
+	switch c {
+	case ReportCadenceDaily, ReportCadenceWeekly, ReportCadenceMonthly:
+		return true
+	}
+	return false
+}
+
+// Next returns the time at which the report following the one due at t is due.
+func (c ReportCadence) Next(t time.Time) time.Time {
+	switch c {
+	case ReportCadenceDaily:
+		return t.AddDate(0, 0, 1)
+	case ReportCadenceMonthly:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 7)
+	}
+}
+
 type InsightSeriesStatus struct {
 	SeriesId   string
 	Query      string
@@ -210,9 +256,12 @@ const (
 	PATH_AGGREGATION_MODE          SearchAggregationMode = "PATH"
 	AUTHOR_AGGREGATION_MODE        SearchAggregationMode = "AUTHOR"
 	CAPTURE_GROUP_AGGREGATION_MODE SearchAggregationMode = "CAPTURE_GROUP"
+	LANGUAGE_AGGREGATION_MODE      SearchAggregationMode = "LANGUAGE"
+	OWNER_AGGREGATION_MODE         SearchAggregationMode = "OWNER"
+	COMMIT_DATE_AGGREGATION_MODE   SearchAggregationMode = "COMMIT_DATE"
 )
 
-var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE}
+var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE, LANGUAGE_AGGREGATION_MODE, OWNER_AGGREGATION_MODE, COMMIT_DATE_AGGREGATION_MODE}
 
 type AggregationNotAvailableReasonType string
 
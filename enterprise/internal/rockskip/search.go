@@ -254,6 +254,13 @@ func (s *Service) querySymbols(ctx context.Context, args search.SymbolsParameter
 		return nil, err
 	}
 
+	// Kinds and parents are not indexed, so the symbol filter is applied to
+	// the parsed symbols before they count towards the limit.
+	matchesFilter, err := args.SymbolFilter.Matcher()
+	if err != nil {
+		return nil, err
+	}
+
 	paths := goset.NewSet[string]()
 	for rows.Next() {
 		var path string
@@ -299,14 +306,18 @@ func (s *Service) querySymbols(ctx context.Context, args search.SymbolsParameter
 					character = 0
 				}
 
-				symbols = append(symbols, result.Symbol{
+				sym := result.Symbol{
 					Name:      symbol.Name,
 					Path:      path,
 					Line:      symbol.Line - 1,
 					Character: character,
 					Kind:      symbol.Kind,
 					Parent:    symbol.Parent,
-				})
+				}
+				if !matchesFilter(sym) {
+					continue
+				}
+				symbols = append(symbols, sym)
 
 				if len(symbols) >= limit {
 					return stopErr
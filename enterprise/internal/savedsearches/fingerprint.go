@@ -0,0 +1,113 @@
+package savedsearches
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Fingerprint returns the sorted, deduplicated keys identifying the matches
+// of a search. Keys are paths relative to the Sourcegraph URL, so they can be
+// both compared across runs and linked to:
+//
+//   - repository matches: github.com/foo/bar
+//   - file and path matches: github.com/foo/bar/-/blob/main.go
+//   - content matches: github.com/foo/bar/-/blob/main.go#1f2e3d4c5b6a7980, one
+//     key per matched line, identified by a hash of its trimmed content so
+//     that a line moving within its file is not reported as a change
+//   - symbol matches: github.com/foo/bar/-/blob/main.go#symbol:function:main
+//   - commit and diff matches: github.com/foo/bar/-/commit/abc123
+//
+// Revisions are only part of a key if they were explicitly searched, so that
+// new commits to a repository do not change the keys of unchanged matches.
+func Fingerprint(matches result.Matches) []string {
+	seen := map[string]struct{}{}
+	var keys []string
+	for _, match := range matches {
+		for _, key := range matchKeys(match) {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func matchKeys(match result.Match) []string {
+	switch m := match.(type) {
+	case *result.RepoMatch:
+		return []string{trimSlash(m.URL().Path)}
+
+	case *result.FileMatch:
+		file := trimSlash(m.File.URL().Path)
+		var keys []string
+		for _, symbol := range m.Symbols {
+			keys = append(keys, fmt.Sprintf("%s#symbol:%s:%s", file, strings.ToLower(symbol.Symbol.Kind), symbol.Symbol.Name))
+		}
+		for _, chunk := range m.ChunkMatches {
+			for _, line := range chunk.AsLineMatches() {
+				if len(line.OffsetAndLengths) == 0 {
+					continue
+				}
+				keys = append(keys, fmt.Sprintf("%s#%s", file, lineHash(line.Preview)))
+			}
+		}
+		if len(keys) == 0 {
+			// Path matches and files selected with select:file.
+			keys = append(keys, file)
+		}
+		return keys
+
+	case *result.CommitMatch:
+		return []string{trimSlash(m.URL().Path)}
+
+	default:
+		key := m.Key()
+		s := string(key.Repo)
+		if key.Commit != "" {
+			s += "/-/commit/" + string(key.Commit)
+		}
+		if key.Path != "" {
+			s += "#" + key.Path
+		}
+		return []string{s}
+	}
+}
+
+func lineHash(line string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strings.TrimSpace(line)))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+func trimSlash(s string) string {
+	return strings.TrimPrefix(s, "/")
+}
+
+// Diff returns the keys in current that are not in previous, and the keys in
+// previous that are not in current. Both inputs must be sorted.
+func Diff(previous, current []string) (added, removed []string) {
+	i, j := 0, 0
+	for i < len(previous) && j < len(current) {
+		switch {
+		case previous[i] == current[j]:
+			i++
+			j++
+		case previous[i] < current[j]:
+			removed = append(removed, previous[i])
+			i++
+		default:
+			added = append(added, current[j])
+			j++
+		}
+	}
+	removed = append(removed, previous[i:]...)
+	added = append(added, current[j:]...)
+	return added, removed
+}
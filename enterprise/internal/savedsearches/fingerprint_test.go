@@ -0,0 +1,102 @@
+package savedsearches
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestFingerprint(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	branch := "release"
+
+	chunk := func(line int, content string) result.ChunkMatch {
+		return result.ChunkMatch{
+			Content:      content,
+			ContentStart: result.Location{Line: line},
+			Ranges: result.Ranges{{
+				Start: result.Location{Line: line, Column: 0},
+				End:   result.Location{Line: line, Column: 4},
+			}},
+		}
+	}
+
+	matches := result.Matches{
+		&result.RepoMatch{Name: repo.Name, ID: repo.ID},
+		&result.RepoMatch{Name: "github.com/sourcegraph/zoekt", Rev: "main"},
+		&result.FileMatch{File: result.File{Repo: repo, CommitID: "abc", Path: "README.md"}},
+		&result.FileMatch{
+			File:         result.File{Repo: repo, CommitID: "abc", Path: "main.go"},
+			ChunkMatches: result.ChunkMatches{chunk(3, "func main() {"), chunk(10, "\tfunc main() {  ")},
+		},
+		&result.FileMatch{
+			File:    result.File{Repo: repo, CommitID: "abc", InputRev: &branch, Path: "main.go"},
+			Symbols: []*result.SymbolMatch{{Symbol: result.Symbol{Name: "main", Kind: "Function"}}},
+		},
+		&result.CommitMatch{Repo: repo, Commit: gitdomain.Commit{ID: "def"}},
+		// Duplicates are removed.
+		&result.RepoMatch{Name: repo.Name, ID: repo.ID},
+	}
+
+	want := []string{
+		"github.com/sourcegraph/sourcegraph",
+		"github.com/sourcegraph/sourcegraph/-/blob/README.md",
+		"github.com/sourcegraph/sourcegraph/-/blob/main.go#" + lineHash("func main() {"),
+		"github.com/sourcegraph/sourcegraph/-/commit/def",
+		"github.com/sourcegraph/sourcegraph@release/-/blob/main.go#symbol:function:main",
+		"github.com/sourcegraph/zoekt@main",
+	}
+	if diff := cmp.Diff(want, Fingerprint(matches)); diff != "" {
+		t.Errorf("unexpected fingerprint (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		previous    []string
+		current     []string
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{
+			name:    "no previous results",
+			current: []string{"a", "b"},
+
+			wantAdded: []string{"a", "b"},
+		},
+		{
+			name:     "unchanged",
+			previous: []string{"a", "b"},
+			current:  []string{"a", "b"},
+		},
+		{
+			name:     "added and removed",
+			previous: []string{"a", "c", "d"},
+			current:  []string{"b", "c", "e", "f"},
+
+			wantAdded:   []string{"b", "e", "f"},
+			wantRemoved: []string{"a", "d"},
+		},
+		{
+			name:     "no current results",
+			previous: []string{"a", "b"},
+
+			wantRemoved: []string{"a", "b"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			added, removed := Diff(tc.previous, tc.current)
+			if diff := cmp.Diff(tc.wantAdded, added); diff != "" {
+				t.Errorf("unexpected added (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantRemoved, removed); diff != "" {
+				t.Errorf("unexpected removed (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
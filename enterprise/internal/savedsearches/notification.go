@@ -0,0 +1,240 @@
+package savedsearches
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const utmSourceNotification = "saved-search-notification"
+
+// maxNotificationMatches is the maximum number of added and removed matches
+// listed in email and Slack notifications. Webhook payloads list all of them.
+const maxNotificationMatches = 10
+
+var (
+	//go:embed notification.html.tmpl
+	notificationHTMLTemplate string
+
+	//go:embed notification.txt.tmpl
+	notificationTextTemplate string
+)
+
+var notificationEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Sourcegraph saved search {{.Description}}: {{.AddedCount}} added, {{.RemovedCount}} removed`,
+	Text:    notificationTextTemplate,
+	HTML:    notificationHTMLTemplate,
+})
+
+// notification describes the changes in the results of a saved search between
+// two runs.
+type notification struct {
+	Description  string
+	Query        string
+	PermalinkURL string
+	RanAt        time.Time
+	ResultCount  int
+
+	AddedCount   int
+	RemovedCount int
+	Added        []notificationMatch
+	Removed      []notificationMatch
+	MoreAdded    int
+	MoreRemoved  int
+
+	allAdded   []notificationMatch
+	allRemoved []notificationMatch
+}
+
+type notificationMatch struct {
+	Key string `json:"key"`
+	URL string `json:"url"`
+}
+
+func newNotification(externalURL *url.URL, description string, run *types.SavedSearchRun) *notification {
+	toMatches := func(keys []string) []notificationMatch {
+		matches := make([]notificationMatch, 0, len(keys))
+		for _, key := range keys {
+			matches = append(matches, notificationMatch{Key: key, URL: matchURL(externalURL, key)})
+		}
+		return matches
+	}
+	truncate := func(matches []notificationMatch) ([]notificationMatch, int) {
+		if len(matches) > maxNotificationMatches {
+			return matches[:maxNotificationMatches], len(matches) - maxNotificationMatches
+		}
+		return matches, 0
+	}
+
+	n := &notification{
+		Description:  description,
+		Query:        run.Query,
+		PermalinkURL: searchURL(externalURL, run.PermalinkQuery),
+		RanAt:        run.RanAt,
+		ResultCount:  run.ResultCount,
+		AddedCount:   run.AddedCount,
+		RemovedCount: run.RemovedCount,
+		allAdded:     toMatches(run.Added),
+		allRemoved:   toMatches(run.Removed),
+	}
+	n.Added, n.MoreAdded = truncate(n.allAdded)
+	n.Removed, n.MoreRemoved = truncate(n.allRemoved)
+	return n
+}
+
+// matchURL returns the URL of the match identified by key (see Fingerprint).
+func matchURL(externalURL *url.URL, key string) string {
+	path, _, _ := strings.Cut(key, "#")
+	u := externalURL.ResolveReference(&url.URL{Path: path})
+	q := u.Query()
+	q.Set("utm_source", utmSourceNotification)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func searchURL(externalURL *url.URL, query string) string {
+	u := externalURL.ResolveReference(&url.URL{Path: "search"})
+	q := u.Query()
+	q.Set("q", query)
+	q.Set("utm_source", utmSourceNotification)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func sendNotificationEmail(ctx context.Context, db database.DB, userID int32, n *notification) error {
+	email, _, err := db.UserEmails().GetPrimaryEmail(ctx, userID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Errorf("unable to send email to user ID %d with unknown email address", userID)
+		}
+		return errors.Errorf("UserEmails.GetPrimaryEmail for userID=%d: %w", userID, err)
+	}
+	if err := internalapi.Client.SendEmail(ctx, "saved-search", txtypes.Message{
+		To:       []string{email},
+		Template: notificationEmailTemplates,
+		Data:     n,
+	}); err != nil {
+		return errors.Errorf("internalapi.Client.SendEmail to email=%q userID=%d: %w", email, userID, err)
+	}
+	return nil
+}
+
+func sendNotificationSlack(ctx context.Context, webhookURL string, n *notification) error {
+	return postJSON(ctx, httpcli.ExternalDoer, webhookURL, notificationSlackPayload(n))
+}
+
+func notificationSlackPayload(n *notification) *slack.WebhookMessage {
+	newMarkdownSection := func(s string) slack.Block {
+		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
+	}
+	matchList := func(title string, matches []notificationMatch, more int, link bool) slack.Block {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "*%s*", title)
+		for _, match := range matches {
+			if link {
+				fmt.Fprintf(&sb, "\n• <%s|%s>", match.URL, escapeSlack(match.Key))
+			} else {
+				fmt.Fprintf(&sb, "\n• %s", escapeSlack(match.Key))
+			}
+		}
+		if more > 0 {
+			fmt.Fprintf(&sb, "\n...and %d more", more)
+		}
+		return newMarkdownSection(sb.String())
+	}
+
+	blocks := []slack.Block{
+		newMarkdownSection(fmt.Sprintf(
+			"The results of the Sourcegraph saved search *%s* changed: *%d* added, *%d* removed.",
+			escapeSlack(n.Description),
+			n.AddedCount,
+			n.RemovedCount,
+		)),
+	}
+	if len(n.Added) > 0 {
+		blocks = append(blocks, matchList("Added", n.Added, n.MoreAdded, true))
+	}
+	if len(n.Removed) > 0 {
+		blocks = append(blocks, matchList("Removed", n.Removed, n.MoreRemoved, false))
+	}
+	blocks = append(blocks, newMarkdownSection(fmt.Sprintf("<%s|View results as of this run>", n.PermalinkURL)))
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: blocks}}
+}
+
+// escapeSlack escapes the characters that have a special meaning in Slack message text.
+func escapeSlack(s string) string {
+	return slackEscaper.Replace(s)
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func sendNotificationWebhook(ctx context.Context, webhookURL string, n *notification) error {
+	return postJSON(ctx, httpcli.ExternalDoer, webhookURL, notificationWebhookPayload(n))
+}
+
+type webhookPayload struct {
+	Description  string              `json:"description"`
+	Query        string              `json:"query"`
+	PermalinkURL string              `json:"permalinkURL"`
+	RanAt        time.Time           `json:"ranAt"`
+	ResultCount  int                 `json:"resultCount"`
+	Added        []notificationMatch `json:"added"`
+	Removed      []notificationMatch `json:"removed"`
+}
+
+func notificationWebhookPayload(n *notification) webhookPayload {
+	return webhookPayload{
+		Description:  n.Description,
+		Query:        n.Query,
+		PermalinkURL: n.PermalinkURL,
+		RanAt:        n.RanAt,
+		ResultCount:  n.ResultCount,
+		Added:        n.allAdded,
+		Removed:      n.allRemoved,
+	}
+}
+
+// adapted from slack.PostWebhookCustomHTTPContext
+func postJSON(ctx context.Context, doer httpcli.Doer, url string, payload any) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal failed")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
+	if err != nil {
+		return errors.Wrap(err, "failed new request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to post webhook")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return errors.Errorf("non-2xx response %d %s with body %q", resp.StatusCode, resp.Status, string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package savedsearches
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func testNotification(t *testing.T) *notification {
+	t.Helper()
+	externalURL, err := url.Parse("https://sourcegraph.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var added []string
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"} {
+		added = append(added, "github.com/sourcegraph/"+name)
+	}
+	return newNotification(externalURL, "TODOs <new>", &types.SavedSearchRun{
+		Query:          "TODO patternType:standard",
+		PermalinkQuery: "(repo:^github\\.com/sourcegraph/a$@abc) TODO patternType:standard",
+		RanAt:          time.Date(2022, 12, 19, 9, 0, 0, 0, time.UTC),
+		ResultCount:    12,
+		Added:          added,
+		AddedCount:     len(added),
+		Removed:        []string{"github.com/sourcegraph/z/-/blob/main.go#0123456789abcdef"},
+		RemovedCount:   1,
+	})
+}
+
+func TestNotificationSlackPayload(t *testing.T) {
+	raw, err := json.MarshalIndent(notificationSlackPayload(testNotification(t)), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("slack payload", `{
+  "blocks": [
+    {
+      "type": "section",
+      "text": {
+        "type": "mrkdwn",
+        "text": "The results of the Sourcegraph saved search *TODOs \u0026lt;new\u0026gt;* changed: *12* added, *1* removed."
+      }
+    },
+    {
+      "type": "section",
+      "text": {
+        "type": "mrkdwn",
+        "text": "*Added*\n• \u003chttps://sourcegraph.test/github.com/sourcegraph/a?utm_source=saved-search-notification|github.com/sourcegraph/a\u003e\n• \u003chttps://sourcegraph.test/github.com/sourcegraph/b?utm_source=saved-search-notification|github.com/sourcegraph/b\u003e\n• \u003chttps://sourcegraph.test/github.com/sourcegraph/c?utm_source=saved-search-notification|github.com/sourcegraph/c\u003e\n• \u003chttps://sourcegraph.test/github.com/sourcegraph/d?utm_source=saved-search-notification|github.com/sourcegraph/d\u003e\n• \u003chttps://sourcegraph.test/github.com/sourcegraph/e?utm_source=saved-search-notification|github.com/sourcegraph/e\u003e\n• \u003chttps://sourcegraph.test/github.com/sourcegraph/f?utm_source=saved-search-notification|github.com/sourcegraph/f\u003e\n• \u003chttps://sourcegraph.test/github.com/sourcegraph/g?utm_source=saved-search-notification|github.com/sourcegraph/g\u003e\n• \u003chttps://sourcegraph.test/github.com/sourcegraph/h?utm_source=saved-search-notification|github.com/sourcegraph/h\u003e\n• \u003chttps://sourcegraph.test/github.com/sourcegraph/i?utm_source=saved-search-notification|github.com/sourcegraph/i\u003e\n• \u003chttps://sourcegraph.test/github.com/sourcegraph/j?utm_source=saved-search-notification|github.com/sourcegraph/j\u003e\n...and 2 more"
+      }
+    },
+    {
+      "type": "section",
+      "text": {
+        "type": "mrkdwn",
+        "text": "*Removed*\n• github.com/sourcegraph/z/-/blob/main.go#0123456789abcdef"
+      }
+    },
+    {
+      "type": "section",
+      "text": {
+        "type": "mrkdwn",
+        "text": "\u003chttps://sourcegraph.test/search?q=%28repo%3A%5Egithub%5C.com%2Fsourcegraph%2Fa%24%40abc%29+TODO+patternType%3Astandard\u0026utm_source=saved-search-notification|View results as of this run\u003e"
+      }
+    }
+  ]
+}`).Equal(t, string(raw))
+}
+
+func TestNotificationWebhookPayload(t *testing.T) {
+	n := testNotification(t)
+	payload := notificationWebhookPayload(n)
+	if len(payload.Added) != n.AddedCount {
+		t.Errorf("expected webhook payload to list all %d added matches, got %d", n.AddedCount, len(payload.Added))
+	}
+	payload.Added = payload.Added[:1]
+	raw, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("webhook payload", `{
+  "description": "TODOs \u003cnew\u003e",
+  "query": "TODO patternType:standard",
+  "permalinkURL": "https://sourcegraph.test/search?q=%28repo%3A%5Egithub%5C.com%2Fsourcegraph%2Fa%24%40abc%29+TODO+patternType%3Astandard\u0026utm_source=saved-search-notification",
+  "ranAt": "2022-12-19T09:00:00Z",
+  "resultCount": 12,
+  "added": [
+    {
+      "key": "github.com/sourcegraph/a",
+      "url": "https://sourcegraph.test/github.com/sourcegraph/a?utm_source=saved-search-notification"
+    }
+  ],
+  "removed": [
+    {
+      "key": "github.com/sourcegraph/z/-/blob/main.go#0123456789abcdef",
+      "url": "https://sourcegraph.test/github.com/sourcegraph/z/-/blob/main.go?utm_source=saved-search-notification"
+    }
+  ]
+}`).Equal(t, string(raw))
+}
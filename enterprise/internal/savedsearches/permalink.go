@@ -0,0 +1,75 @@
+package savedsearches
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// maxPinnedRepos is the maximum number of repositories PermalinkQuery pins to
+// a commit. Queries matching more repositories are not pinned to keep the
+// permalink a reasonable length.
+const maxPinnedRepos = 50
+
+// PermalinkQuery returns a query that reruns q as of ranAt, given the plan and
+// matches of running q at that time:
+//
+//   - commit and diff searches are restricted to commits before ranAt.
+//   - other searches are restricted to the commits that were searched in each
+//     repository with a match.
+//
+// q is returned unchanged if it cannot be restricted faithfully, e.g. because
+// it already specifies revisions or only matched repositories.
+func PermalinkQuery(q string, plan query.Plan, ranAt time.Time, matches result.Matches) string {
+	if len(plan) != 1 {
+		return q
+	}
+	b := plan[0]
+
+	switch b.FindValue(query.FieldType) {
+	case "commit", "diff":
+		if b.Exists(query.FieldBefore) {
+			return q
+		}
+		return q + " before:" + strconv.Quote(ranAt.UTC().Format(time.RFC3339))
+	}
+
+	if b.Exists(query.FieldRev) {
+		return q
+	}
+	repos, _ := b.Repositories()
+	for _, repo := range repos {
+		if strings.Contains(repo, "@") {
+			return q
+		}
+	}
+	if op, ok := b.Pattern.(query.Operator); ok && op.Kind == query.Or {
+		// Prepending repository filters would bind tighter than the top-level
+		// or of the pattern.
+		return q
+	}
+
+	commits := map[api.RepoName]api.CommitID{}
+	for _, match := range matches {
+		if fm, ok := match.(*result.FileMatch); ok && fm.CommitID != "" {
+			commits[fm.Repo.Name] = fm.CommitID
+		}
+	}
+	if len(commits) == 0 || len(commits) > maxPinnedRepos {
+		return q
+	}
+
+	pins := make([]string, 0, len(commits))
+	for repo, commit := range commits {
+		pins = append(pins, "repo:^"+regexp.QuoteMeta(string(repo))+"$@"+string(commit))
+	}
+	sort.Strings(pins)
+	return "(" + strings.Join(pins, " OR ") + ") " + q
+}
@@ -0,0 +1,49 @@
+package savedsearches
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestPermalinkQuery(t *testing.T) {
+	ranAt := time.Date(2022, 12, 19, 9, 0, 0, 0, time.FixedZone("CET", 3600))
+	matches := result.Matches{
+		&result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: "github.com/sourcegraph/zoekt"}, CommitID: "def", Path: "main.go"}},
+		&result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: "github.com/sourcegraph/sourcegraph"}, CommitID: "abc", Path: "main.go"}},
+		&result.RepoMatch{Name: "github.com/sourcegraph/conc"},
+	}
+
+	permalink := func(q string, matches result.Matches) string {
+		t.Helper()
+		plan, err := query.Pipeline(query.Init(q, query.SearchTypeStandard))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return PermalinkQuery(q, plan, ranAt, matches)
+	}
+
+	pinned := permalink("lang:go func main patternType:standard", matches)
+	autogold.Want("content search is pinned to the searched commits", `(repo:^github\.com/sourcegraph/sourcegraph$@abc OR repo:^github\.com/sourcegraph/zoekt$@def) lang:go func main patternType:standard`).
+		Equal(t, pinned)
+	if plan, err := query.Pipeline(query.Init(pinned, query.SearchTypeStandard)); err != nil {
+		t.Fatalf("pinned query is invalid: %s", err)
+	} else if len(plan) != 2 {
+		t.Fatalf("expected pinned query to search each repository separately, got %d queries", len(plan))
+	}
+	autogold.Want("commit search is restricted to commits before the run", `type:diff fix patternType:standard before:"2022-12-19T08:00:00Z"`).
+		Equal(t, permalink("type:diff fix patternType:standard", nil))
+	autogold.Want("commit search with a date is unchanged", "type:commit fix until:yesterday patternType:standard").
+		Equal(t, permalink("type:commit fix until:yesterday patternType:standard", nil))
+	autogold.Want("explicit revisions are unchanged", "repo:sourcegraph@main func patternType:standard").
+		Equal(t, permalink("repo:sourcegraph@main func patternType:standard", matches))
+	autogold.Want("top-level or is unchanged", "func or main patternType:standard").
+		Equal(t, permalink("func or main patternType:standard", matches))
+	autogold.Want("repository matches are unchanged", "type:repo sourcegraph patternType:standard").
+		Equal(t, permalink("type:repo sourcegraph patternType:standard", matches[2:]))
+}
@@ -0,0 +1,180 @@
+package savedsearches
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// batchSize is the maximum number of saved searches run per iteration.
+const batchSize = 50
+
+// NewRunJob periodically runs the saved searches whose schedule is due, records a fingerprint of their
+// results and notifies about the matches that were added or removed since the previous run.
+func NewRunJob(ctx context.Context, db database.DB) goroutine.BackgroundRoutine {
+	logger := log.Scoped("SavedSearchRunner", "")
+	r := &runner{
+		logger: logger,
+		db:     db,
+		search: func(ctx context.Context, q string) (*Results, error) {
+			settings, err := codemonitors.Settings(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "query settings")
+			}
+			return Search(ctx, logger, db, q, settings)
+		},
+		sendEmail: func(ctx context.Context, userID int32, n *notification) error {
+			return sendNotificationEmail(ctx, db, userID, n)
+		},
+		sendSlack:   sendNotificationSlack,
+		sendWebhook: sendNotificationWebhook,
+		now:         time.Now,
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		"savedsearches.runner", "runs scheduled saved searches and notifies about changes in their results",
+		time.Minute,
+		goroutine.HandlerFunc(r.runDue),
+	)
+}
+
+type runner struct {
+	logger log.Logger
+	db     database.DB
+
+	search      func(ctx context.Context, q string) (*Results, error)
+	sendEmail   func(ctx context.Context, userID int32, n *notification) error
+	sendSlack   func(ctx context.Context, webhookURL string, n *notification) error
+	sendWebhook func(ctx context.Context, webhookURL string, n *notification) error
+	now         func() time.Time
+}
+
+// runDue runs every saved search whose schedule is due. Selecting a schedule moves its next run one interval
+// into the future, so a saved search that fails to run is retried on its next run rather than immediately.
+func (r *runner) runDue(ctx context.Context) error {
+	schedules, err := r.db.SavedSearches().SelectSchedulesForRun(ctx, batchSize)
+	if err != nil {
+		return errors.Wrap(err, "SelectSchedulesForRun")
+	}
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	externalURL, err := url.Parse(conf.ExternalURL())
+	if err != nil {
+		return errors.Wrap(err, "parsing external URL")
+	}
+
+	var errs error
+	for _, schedule := range schedules {
+		if err := r.run(ctx, externalURL, schedule); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "saved search %d", schedule.SavedSearchID))
+		}
+	}
+	return errs
+}
+
+func (r *runner) run(ctx context.Context, externalURL *url.URL, schedule *types.SavedSearchSchedule) error {
+	ss, err := r.db.SavedSearches().GetByID(ctx, schedule.SavedSearchID)
+	if err != nil {
+		return errors.Wrap(err, "GetByID")
+	}
+
+	// 🚨 SECURITY: The saved search is run with the permissions of the user of its schedule, who must still
+	// have access to the saved search. Slack and webhook notifications receive the same results.
+	if ok, err := r.canAccess(ctx, schedule.UserID, ss.Config); err != nil {
+		return err
+	} else if !ok {
+		r.logger.Debug("skipping saved search for user without access", log.Int32("savedSearch", schedule.SavedSearchID), log.Int32("user", schedule.UserID))
+		return nil
+	}
+	userCtx := actor.WithActor(ctx, actor.FromUser(schedule.UserID))
+
+	ranAt := r.now()
+	results, err := r.search(userCtx, ss.Config.Query)
+	if err != nil {
+		return errors.Wrap(err, "search")
+	}
+
+	previous, err := r.db.SavedSearches().ListRuns(ctx, schedule.SavedSearchID, 1)
+	if err != nil {
+		return errors.Wrap(err, "ListRuns")
+	}
+
+	run := &types.SavedSearchRun{
+		SavedSearchID:  schedule.SavedSearchID,
+		Query:          ss.Config.Query,
+		PermalinkQuery: PermalinkQuery(ss.Config.Query, results.Plan, ranAt, results.Matches),
+		RanAt:          ranAt,
+		ResultCount:    results.Matches.ResultCount(),
+		LimitHit:       results.LimitHit,
+		Fingerprint:    Fingerprint(results.Matches),
+	}
+	// The first run of a query only records its results, so that scheduling a saved search or changing its
+	// query does not report every match as added. Runs that hit the result limit are not compared, as they
+	// are missing an arbitrary subset of the matches.
+	if len(previous) > 0 && previous[0].Query == run.Query && !previous[0].LimitHit && !run.LimitHit {
+		run.Added, run.Removed = Diff(previous[0].Fingerprint, run.Fingerprint)
+		run.AddedCount, run.RemovedCount = len(run.Added), len(run.Removed)
+	}
+
+	run, err = r.db.SavedSearches().CreateRun(ctx, run)
+	if err != nil {
+		return errors.Wrap(err, "CreateRun")
+	}
+	if len(run.Added) == 0 && len(run.Removed) == 0 {
+		return nil
+	}
+
+	n := newNotification(externalURL, ss.Config.Description, run)
+
+	var errs error
+	if schedule.NotifyEmail {
+		if err := r.sendEmail(ctx, schedule.UserID, n); err != nil {
+			errs = errors.Append(errs, err)
+		}
+	}
+	if schedule.SlackWebhookURL != nil && *schedule.SlackWebhookURL != "" {
+		if err := r.sendSlack(ctx, *schedule.SlackWebhookURL, n); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "posting Slack webhook"))
+		}
+	}
+	if schedule.WebhookURL != nil && *schedule.WebhookURL != "" {
+		if err := r.sendWebhook(ctx, *schedule.WebhookURL, n); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "posting webhook"))
+		}
+	}
+	return errs
+}
+
+// canAccess returns whether the user may view the saved search: it must be owned by the user, or by an
+// organization the user is a member of.
+func (r *runner) canAccess(ctx context.Context, userID int32, ss api.ConfigSavedQuery) (bool, error) {
+	switch {
+	case ss.UserID != nil:
+		return *ss.UserID == userID, nil
+	case ss.OrgID != nil:
+		if _, err := r.db.OrgMembers().GetByOrgIDAndUserID(ctx, *ss.OrgID, userID); err != nil {
+			if errcode.IsNotFound(err) {
+				return false, nil
+			}
+			return false, errors.Wrap(err, "GetByOrgIDAndUserID")
+		}
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,197 @@
+package savedsearches
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestRunnerRunDue(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{ExternalURL: "https://sourcegraph.test"}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	userID, orgID := int32(1), int32(2)
+	now := time.Date(2022, 12, 19, 9, 0, 0, 0, time.UTC)
+	slackURL, webhookURL := "https://slack.test", "https://webhook.test"
+	const q = "type:repo patternType:standard"
+
+	newRunner := func(ss api.ConfigSavedQuery, previous []*types.SavedSearchRun, isOrgMember, limitHit bool) (*runner, *database.MockSavedSearchStore, *[]*notification) {
+		savedSearches := database.NewMockSavedSearchStore()
+		savedSearches.SelectSchedulesForRunFunc.SetDefaultReturn([]*types.SavedSearchSchedule{{
+			SavedSearchID:   1,
+			UserID:          userID,
+			Interval:        time.Hour,
+			NotifyEmail:     true,
+			SlackWebhookURL: &slackURL,
+			WebhookURL:      &webhookURL,
+		}}, nil)
+		savedSearches.GetByIDFunc.SetDefaultReturn(&api.SavedQuerySpecAndConfig{Config: ss}, nil)
+		savedSearches.ListRunsFunc.SetDefaultReturn(previous, nil)
+		savedSearches.CreateRunFunc.SetDefaultHook(func(_ context.Context, run *types.SavedSearchRun) (*types.SavedSearchRun, error) {
+			return run, nil
+		})
+
+		orgMembers := database.NewMockOrgMemberStore()
+		if isOrgMember {
+			orgMembers.GetByOrgIDAndUserIDFunc.SetDefaultReturn(&types.OrgMembership{OrgID: orgID, UserID: userID}, nil)
+		} else {
+			orgMembers.GetByOrgIDAndUserIDFunc.SetDefaultReturn(nil, &database.ErrOrgMemberNotFound{})
+		}
+
+		db := database.NewMockDB()
+		db.SavedSearchesFunc.SetDefaultReturn(savedSearches)
+		db.OrgMembersFunc.SetDefaultReturn(orgMembers)
+
+		var sent []*notification
+		r := &runner{
+			logger: logtest.Scoped(t),
+			db:     db,
+			search: func(ctx context.Context, q string) (*Results, error) {
+				if a := actor.FromContext(ctx); a.UID != userID {
+					t.Fatalf("expected search to run as user %d, got %d", userID, a.UID)
+				}
+				return &Results{
+					Matches: result.Matches{
+						&result.RepoMatch{Name: "github.com/sourcegraph/b"},
+						&result.RepoMatch{Name: "github.com/sourcegraph/c"},
+					},
+					LimitHit: limitHit,
+				}, nil
+			},
+			sendEmail: func(_ context.Context, to int32, n *notification) error {
+				if to != userID {
+					t.Fatalf("expected email to user %d, got %d", userID, to)
+				}
+				sent = append(sent, n)
+				return nil
+			},
+			sendSlack: func(_ context.Context, url string, n *notification) error {
+				if url != slackURL {
+					t.Fatalf("unexpected Slack webhook URL %q", url)
+				}
+				sent = append(sent, n)
+				return nil
+			},
+			sendWebhook: func(_ context.Context, url string, n *notification) error {
+				if url != webhookURL {
+					t.Fatalf("unexpected webhook URL %q", url)
+				}
+				sent = append(sent, n)
+				return nil
+			},
+			now: func() time.Time { return now },
+		}
+		return r, savedSearches, &sent
+	}
+
+	t.Run("changes are recorded and notified", func(t *testing.T) {
+		previous := []*types.SavedSearchRun{{Query: q, Fingerprint: []string{"github.com/sourcegraph/a", "github.com/sourcegraph/b"}}}
+		r, savedSearches, sent := newRunner(api.ConfigSavedQuery{Description: "repos", Query: q, UserID: &userID}, previous, false, false)
+
+		if err := r.runDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(savedSearches.CreateRunFunc.History()) != 1 {
+			t.Fatalf("expected one run to be recorded, got %d", len(savedSearches.CreateRunFunc.History()))
+		}
+		run := savedSearches.CreateRunFunc.History()[0].Arg1
+		if diff := cmp.Diff(&types.SavedSearchRun{
+			SavedSearchID:  1,
+			Query:          q,
+			PermalinkQuery: q,
+			RanAt:          now,
+			ResultCount:    2,
+			Fingerprint:    []string{"github.com/sourcegraph/b", "github.com/sourcegraph/c"},
+			Added:          []string{"github.com/sourcegraph/c"},
+			AddedCount:     1,
+			Removed:        []string{"github.com/sourcegraph/a"},
+			RemovedCount:   1,
+		}, run); diff != "" {
+			t.Errorf("unexpected run (-want +got):\n%s", diff)
+		}
+
+		if len(*sent) != 3 {
+			t.Fatalf("expected email, Slack and webhook notifications, got %d", len(*sent))
+		}
+		n := (*sent)[0]
+		if diff := cmp.Diff([]notificationMatch{{
+			Key: "github.com/sourcegraph/c",
+			URL: "https://sourcegraph.test/github.com/sourcegraph/c?utm_source=saved-search-notification",
+		}}, n.Added); diff != "" {
+			t.Errorf("unexpected added matches (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("first run of a query is not notified", func(t *testing.T) {
+		previous := []*types.SavedSearchRun{{Query: "type:repo old patternType:standard", Fingerprint: []string{"github.com/sourcegraph/a"}}}
+		r, savedSearches, sent := newRunner(api.ConfigSavedQuery{Description: "repos", Query: q, UserID: &userID}, previous, false, false)
+
+		if err := r.runDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(savedSearches.CreateRunFunc.History()) != 1 {
+			t.Fatalf("expected one run to be recorded, got %d", len(savedSearches.CreateRunFunc.History()))
+		}
+		if run := savedSearches.CreateRunFunc.History()[0].Arg1; len(run.Added) != 0 || len(run.Removed) != 0 {
+			t.Errorf("expected no changes, got %+v", run)
+		}
+		if len(*sent) != 0 {
+			t.Errorf("expected no notifications, got %d", len(*sent))
+		}
+	})
+
+	t.Run("runs that hit the result limit are not compared", func(t *testing.T) {
+		for _, tc := range []struct {
+			name             string
+			previousLimitHit bool
+			limitHit         bool
+		}{
+			{name: "previous run", previousLimitHit: true},
+			{name: "current run", limitHit: true},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				previous := []*types.SavedSearchRun{{Query: q, LimitHit: tc.previousLimitHit, Fingerprint: []string{"github.com/sourcegraph/a"}}}
+				r, savedSearches, sent := newRunner(api.ConfigSavedQuery{Description: "repos", Query: q, UserID: &userID}, previous, false, tc.limitHit)
+
+				if err := r.runDue(context.Background()); err != nil {
+					t.Fatal(err)
+				}
+
+				if len(savedSearches.CreateRunFunc.History()) != 1 {
+					t.Fatalf("expected one run to be recorded, got %d", len(savedSearches.CreateRunFunc.History()))
+				}
+				if run := savedSearches.CreateRunFunc.History()[0].Arg1; run.LimitHit != tc.limitHit || len(run.Added) != 0 || len(run.Removed) != 0 {
+					t.Errorf("expected no changes, got %+v", run)
+				}
+				if len(*sent) != 0 {
+					t.Errorf("expected no notifications, got %d", len(*sent))
+				}
+			})
+		}
+	})
+
+	t.Run("user without access to the saved search", func(t *testing.T) {
+		r, savedSearches, sent := newRunner(api.ConfigSavedQuery{Description: "repos", Query: q, OrgID: &orgID}, nil, false, false)
+
+		if err := r.runDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(savedSearches.CreateRunFunc.History()) != 0 || len(*sent) != 0 {
+			t.Errorf("expected saved search to be skipped")
+		}
+	})
+}
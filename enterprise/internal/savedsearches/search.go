@@ -0,0 +1,81 @@
+package savedsearches
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// resultLimit is the number of results fetched for a saved search query that
+// does not set count:. It is much higher than the default limit of streaming
+// searches, so that most saved searches return all of their matches.
+const resultLimit = 10000
+
+// Results are the results of running a saved search query.
+type Results struct {
+	Plan    query.Plan
+	Matches result.Matches
+	// LimitHit is true if the search stopped before finding all matches, in
+	// which case the matches that were found vary from run to run.
+	LimitHit bool
+}
+
+// Search runs a saved search query as the actor in ctx and returns the query
+// plan along with all matches. Unlike code monitors, any query type is
+// supported, including type:repo and select: queries.
+func Search(ctx context.Context, logger log.Logger, db database.DB, q string, settings *schema.Settings) (*Results, error) {
+	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
+	inputs, err := searchClient.Plan(
+		ctx,
+		"V3",
+		nil,
+		q,
+		search.Precise,
+		search.Streaming,
+		settings,
+		envvar.SourcegraphDotComMode(),
+	)
+	if err != nil {
+		return nil, errcode.MakeNonRetryable(err)
+	}
+	inputs.Plan = withResultLimit(inputs.Plan)
+	inputs.Query = inputs.Plan.ToQ()
+
+	agg := streaming.NewAggregatingStream()
+	if _, err := searchClient.Execute(ctx, agg, inputs); err != nil {
+		return nil, err
+	}
+	return &Results{
+		Plan:     inputs.Plan,
+		Matches:  agg.Results,
+		LimitHit: agg.Stats.IsLimitHit,
+	}, nil
+}
+
+// withResultLimit sets count:resultLimit on each query of the plan that does
+// not set a count.
+func withResultLimit(plan query.Plan) query.Plan {
+	limited := make(query.Plan, 0, len(plan))
+	for _, b := range plan {
+		if b.Parameters.Exists(query.FieldCount) {
+			limited = append(limited, b)
+			continue
+		}
+		parameters := make(query.Parameters, 0, len(b.Parameters)+1)
+		parameters = append(parameters, b.Parameters...)
+		parameters = append(parameters, query.Parameter{Field: query.FieldCount, Value: strconv.Itoa(resultLimit)})
+		limited = append(limited, b.MapParameters(parameters))
+	}
+	return limited
+}
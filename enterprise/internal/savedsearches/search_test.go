@@ -0,0 +1,24 @@
+package savedsearches
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+func TestWithResultLimit(t *testing.T) {
+	limited := func(q string) string {
+		t.Helper()
+		plan, err := query.Pipeline(query.Init(q, query.SearchTypeStandard))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return query.StringHuman(withResultLimit(plan).ToQ())
+	}
+
+	autogold.Want("query without count", "type:repo count:10000 sourcegraph").Equal(t, limited("type:repo sourcegraph"))
+	autogold.Want("query with count", "count:50 foo").Equal(t, limited("foo count:50"))
+	autogold.Want("query with count:all", "count:99999999 foo").Equal(t, limited("foo count:all"))
+}
@@ -44,6 +44,11 @@ type Actor struct {
 	// cookie, logout would be ineffective.)
 	FromSessionCookie bool `json:"-"`
 
+	// AccessTokenKey identifies the access token that was used to authenticate the actor, without
+	// revealing the token itself. It is empty if the actor wasn't authenticated with an access token.
+	// It is used to apply API rate limits per access token.
+	AccessTokenKey string `json:"-"`
+
 	// user is populated lazily by (*Actor).User()
 	user     *types.User
 	userErr  error
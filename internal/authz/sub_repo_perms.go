@@ -154,6 +154,25 @@ func (rules compiledRules) GetPermissionsForPath(path string) Perms {
 	return None
 }
 
+func (rules compiledRules) filePermissionsFunc() FilePermissionFunc {
+	return func(path string) (Perms, error) {
+		// An empty path is equivalent to repo permissions so we can assume it has
+		// already been checked at that level.
+		if path == "" {
+			return Read, nil
+		}
+
+		// Prefix path with "/", otherwise suffix rules like "**/file.txt" won't match
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+
+		// Iterate through all rules for the current path, and the final match takes
+		// preference.
+		return rules.GetPermissionsForPath(path), nil
+	}
+}
+
 // NewSubRepoPermsClient instantiates an instance of authz.SubRepoPermsClient
 // which implements SubRepoPermissionChecker.
 //
@@ -291,22 +310,7 @@ func (s *SubRepoPermsClient) FilePermissionsFunc(ctx context.Context, userID int
 		return filePermissionsFuncAllRead, nil
 	}
 
-	return func(path string) (Perms, error) {
-		// An empty path is equivalent to repo permissions so we can assume it has
-		// already been checked at that level.
-		if path == "" {
-			return Read, nil
-		}
-
-		// Prefix path with "/", otherwise suffix rules like "**/file.txt" won't match
-		if !strings.HasPrefix(path, "/") {
-			path = "/" + path
-		}
-
-		// Iterate through all rules for the current path, and the final match takes
-		// preference.
-		return rules.GetPermissionsForPath(path), nil
-	}, nil
+	return rules.filePermissionsFunc(), nil
 }
 
 // getCompiledRules fetches rules for the given repo with caching.
@@ -338,74 +342,93 @@ func (s *SubRepoPermsClient) getCompiledRules(ctx context.Context, userID int32)
 			rules: make(map[api.RepoName]compiledRules, len(repoPerms)),
 		}
 		for repo, perms := range repoPerms {
-			paths := make([]path, 0, len(perms.Paths))
-			for _, rule := range perms.Paths {
-				exclusion := strings.HasPrefix(rule, "-")
-				rule = strings.TrimPrefix(rule, "-")
+			rules, err := compileRules(perms.Paths)
+			if err != nil {
+				return nil, err
+			}
+			toCache.rules[repo] = rules
+		}
+		toCache.timestamp = s.clock()
+		s.cache.Add(userID, toCache)
+		return toCache.rules, nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-				if !strings.HasPrefix(rule, "/") {
-					rule = "/" + rule
-				}
+	compiled := result.(map[api.RepoName]compiledRules)
+	return compiled, nil
+}
 
-				g, err := glob.Compile(rule, '/')
-				if err != nil {
-					return nil, errors.Wrap(err, "building include matcher")
-				}
+// compileRules compiles the given sub-repo permission rules, in the format of
+// SubRepoPermissions.Paths, into glob matchers.
+func compileRules(rules []string) (compiledRules, error) {
+	paths := make([]path, 0, len(rules))
+	for _, rule := range rules {
+		exclusion := strings.HasPrefix(rule, "-")
+		rule = strings.TrimPrefix(rule, "-")
 
-				paths = append(paths, path{globPath: g, exclusion: exclusion, original: rule})
-
-				// Special case. Our glob package does not handle rules starting with a double
-				// wildcard correctly. For example, we would expect `/**/*.java` to match all
-				// java files, but it does not match files at the root, eg `/foo.java`. To get
-				// around this we add an extra rule to cover this case.
-				if strings.HasPrefix(rule, "/**/") {
-					trimmed := rule
-					for {
-						trimmed = strings.TrimPrefix(trimmed, "/**")
-						if strings.HasPrefix(trimmed, "/**/") {
-							// Keep trimming
-							continue
-						}
-						g, err := glob.Compile(trimmed, '/')
-						if err != nil {
-							return nil, errors.Wrap(err, "building include matcher")
-						}
-						paths = append(paths, path{globPath: g, exclusion: exclusion, original: trimmed})
-						break
-					}
-				}
+		if !strings.HasPrefix(rule, "/") {
+			rule = "/" + rule
+		}
 
-				// We should include all directories above an include rule so that we can browse
-				// to the included items.
-				if exclusion {
-					// Not required for an exclude rule
+		g, err := glob.Compile(rule, '/')
+		if err != nil {
+			return compiledRules{}, errors.Wrap(err, "building include matcher")
+		}
+
+		paths = append(paths, path{globPath: g, exclusion: exclusion, original: rule})
+
+		// Special case. Our glob package does not handle rules starting with a double
+		// wildcard correctly. For example, we would expect `/**/*.java` to match all
+		// java files, but it does not match files at the root, eg `/foo.java`. To get
+		// around this we add an extra rule to cover this case.
+		if strings.HasPrefix(rule, "/**/") {
+			trimmed := rule
+			for {
+				trimmed = strings.TrimPrefix(trimmed, "/**")
+				if strings.HasPrefix(trimmed, "/**/") {
+					// Keep trimming
 					continue
 				}
-
-				dirs := expandDirs(rule)
-				for _, dir := range dirs {
-					g, err := glob.Compile(dir, '/')
-					if err != nil {
-						return nil, errors.Wrap(err, "building include matcher for dir")
-					}
-					paths = append(paths, path{globPath: g, exclusion: false, original: dir})
+				g, err := glob.Compile(trimmed, '/')
+				if err != nil {
+					return compiledRules{}, errors.Wrap(err, "building include matcher")
 				}
+				paths = append(paths, path{globPath: g, exclusion: exclusion, original: trimmed})
+				break
 			}
+		}
 
-			toCache.rules[repo] = compiledRules{
-				paths: paths,
+		// We should include all directories above an include rule so that we can browse
+		// to the included items.
+		if exclusion {
+			// Not required for an exclude rule
+			continue
+		}
+
+		dirs := expandDirs(rule)
+		for _, dir := range dirs {
+			g, err := glob.Compile(dir, '/')
+			if err != nil {
+				return compiledRules{}, errors.Wrap(err, "building include matcher for dir")
 			}
+			paths = append(paths, path{globPath: g, exclusion: false, original: dir})
 		}
-		toCache.timestamp = s.clock()
-		s.cache.Add(userID, toCache)
-		return toCache.rules, nil
-	})
+	}
+
+	return compiledRules{paths: paths}, nil
+}
+
+// NewFilePermissionsFunc returns a FilePermissionFunc that evaluates the given
+// sub-repo permission rules, in the format of SubRepoPermissions.Paths, the
+// same way they are evaluated for a user by SubRepoPermsClient.
+func NewFilePermissionsFunc(rules []string) (FilePermissionFunc, error) {
+	compiled, err := compileRules(rules)
 	if err != nil {
 		return nil, err
 	}
-
-	compiled := result.(map[api.RepoName]compiledRules)
-	return compiled, nil
+	return compiled.filePermissionsFunc(), nil
 }
 
 func (s *SubRepoPermsClient) Enabled() bool {
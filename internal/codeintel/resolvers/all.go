@@ -358,6 +358,7 @@ type CodeIntelligenceConfigurationPolicyResolver interface {
 	ID() graphql.ID
 	Repository(ctx context.Context) (RepositoryResolver, error)
 	RepositoryPatterns() *[]string
+	RepositoryQuery() *string
 	Name() string
 	Type() (GitObjectType, error)
 	Pattern() string
@@ -445,6 +446,7 @@ type LSIFUploadResolver interface {
 	RetentionPolicyOverview(ctx context.Context, args *LSIFUploadRetentionPolicyMatchesArgs) (CodeIntelligenceRetentionPolicyMatchesConnectionResolver, error)
 	DocumentPaths(ctx context.Context, args *LSIFUploadDocumentPathsQueryArgs) (LSIFUploadDocumentPathsConnectionResolver, error)
 	AuditLogs(ctx context.Context) (*[]LSIFUploadsAuditLogsResolver, error)
+	Diff(ctx context.Context, args *LSIFUploadDiffArgs) (LSIFUploadDiffResolver, error)
 }
 
 type LSIFUploadRetentionPolicyMatchesArgs struct {
@@ -458,6 +460,41 @@ type LSIFUploadDocumentPathsQueryArgs struct {
 	Pattern string
 }
 
+type LSIFUploadDiffArgs struct {
+	Base *graphql.ID
+}
+
+type LSIFUploadDiffResolver interface {
+	Base() LSIFUploadResolver
+	Head() LSIFUploadResolver
+	AddedDocuments() []string
+	RemovedDocuments() []string
+	ChangedDocuments() []LSIFUploadDocumentDiffResolver
+	BaseSymbolCount() int32
+	HeadSymbolCount() int32
+	BaseOccurrenceCount() int32
+	HeadOccurrenceCount() int32
+	MissingDefinitions(args *LSIFUploadMissingDefinitionsArgs) []LSIFUploadMissingDefinitionResolver
+	MissingDefinitionCount() int32
+}
+
+type LSIFUploadMissingDefinitionsArgs struct {
+	First *int32
+}
+
+type LSIFUploadDocumentDiffResolver interface {
+	Path() string
+	BaseSymbolCount() int32
+	HeadSymbolCount() int32
+	BaseOccurrenceCount() int32
+	HeadOccurrenceCount() int32
+}
+
+type LSIFUploadMissingDefinitionResolver interface {
+	Symbol() string
+	Path() string
+}
+
 type LSIFUploadsAuditLogsResolver interface {
 	LogTimestamp() gqlutil.DateTime
 	UploadDeletedAt() *gqlutil.DateTime
@@ -622,6 +659,7 @@ type CodeIntelConfigurationPolicy struct {
 	Name                      string
 	RepositoryID              *int32
 	RepositoryPatterns        *[]string
+	RepositoryQuery           *string
 	Type                      GitObjectType
 	Pattern                   string
 	RetentionEnabled          bool
@@ -654,8 +692,9 @@ type PreviewGitObjectFilterArgs struct {
 
 type PreviewRepositoryFilterArgs struct {
 	graphqlutil.ConnectionArgs
-	Patterns []string
-	After    *string
+	Patterns        []string
+	RepositoryQuery *string
+	After           *string
 }
 
 type InferredAvailableIndexersResolver interface {
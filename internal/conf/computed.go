@@ -489,6 +489,17 @@ func GitMaxCodehostRequestsPerSecond() int {
 	return *val
 }
 
+// RepoDeletionConfirmationThreshold returns the number of repositories a code
+// host connection configuration change may delete without explicit
+// confirmation. A negative value means no confirmation is ever required.
+func RepoDeletionConfirmationThreshold() int {
+	v := Get().RepoDeletionConfirmationThreshold
+	if v == nil {
+		return 100
+	}
+	return *v
+}
+
 func GitMaxConcurrentClones() int {
 	v := Get().GitMaxConcurrentClones
 	if v <= 0 {
@@ -107,6 +107,38 @@ func TestGitMaxCodehostRequestsPerSecond(t *testing.T) {
 	}
 }
 
+func TestRepoDeletionConfirmationThreshold(t *testing.T) {
+	tests := []struct {
+		name string
+		sc   *Unified
+		want int
+	}{
+		{
+			name: "not set should return default",
+			sc:   &Unified{SiteConfiguration: schema.SiteConfiguration{}},
+			want: 100,
+		},
+		{
+			name: "set 0 should return 0",
+			sc:   &Unified{SiteConfiguration: schema.SiteConfiguration{RepoDeletionConfirmationThreshold: intPtr(0)}},
+			want: 0,
+		},
+		{
+			name: "set -1 should return -1",
+			sc:   &Unified{SiteConfiguration: schema.SiteConfiguration{RepoDeletionConfirmationThreshold: intPtr(-1)}},
+			want: -1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			Mock(test.sc)
+			if got, want := RepoDeletionConfirmationThreshold(), test.want; got != want {
+				t.Fatalf("RepoDeletionConfirmationThreshold() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func TestGitMaxConcurrentClones(t *testing.T) {
 	tests := []struct {
 		name string
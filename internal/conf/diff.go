@@ -1,10 +1,14 @@
 package conf
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -18,6 +22,58 @@ func diff(before, after *Unified) (fields map[string]struct{}) {
 	return diff
 }
 
+// FieldDiff describes a site configuration field that differs between two
+// versions of the site configuration.
+type FieldDiff struct {
+	// Field is the name of the field, see diff for the naming of nested fields.
+	Field string
+	// Before and After are the JSON values of the field, "null" if unset.
+	Before json.RawMessage
+	After  json.RawMessage
+}
+
+// DiffSiteConfigs returns the fields that differ between the two raw site
+// configurations, sorted by field name.
+//
+// Secrets are redacted from the returned values. A changed secret is still
+// returned as a differing field, with redacted values on both sides.
+func DiffSiteConfigs(before, after string) ([]FieldDiff, error) {
+	var parsed, redacted [2]*Unified
+	for i, site := range []string{before, after} {
+		var err error
+		parsed[i], err = ParseConfig(conftypes.RawUnified{Site: site})
+		if err != nil {
+			return nil, errors.Wrap(err, "parse config")
+		}
+		r, err := RedactSecrets(conftypes.RawUnified{Site: site})
+		if err != nil {
+			return nil, errors.Wrap(err, "redact config")
+		}
+		redacted[i], err = ParseConfig(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse redacted config")
+		}
+	}
+
+	beforeFields := getJSONFields(redacted[0].SiteConfiguration, "")
+	afterFields := getJSONFields(redacted[1].SiteConfiguration, "")
+
+	var diffs []FieldDiff
+	for field := range diffStruct(parsed[0].SiteConfiguration, parsed[1].SiteConfiguration, "") {
+		b, err := json.Marshal(beforeFields[field])
+		if err != nil {
+			return nil, err
+		}
+		a, err := json.Marshal(afterFields[field])
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, FieldDiff{Field: field, Before: b, After: a})
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs, nil
+}
+
 func diffStruct(before, after any, prefix string) (fields map[string]struct{}) {
 	fields = make(map[string]struct{})
 	beforeFields := getJSONFields(before, prefix)
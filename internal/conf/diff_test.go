@@ -1,10 +1,13 @@
 package conf
 
 import (
+	"encoding/json"
 	"reflect"
 	"sort"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -83,6 +86,38 @@ func TestDiff(t *testing.T) {
 	}
 }
 
+func TestDiffSiteConfigs(t *testing.T) {
+	before := `{
+		"externalURL": "https://a.example.com",
+		"email.smtp": {"host": "smtp.example.com", "port": 25, "authentication": "PLAIN", "username": "alice", "password": "secret"},
+		"experimentalFeatures": {"apidocs.search.indexing": "enabled"},
+	}`
+	after := `{
+		"externalURL": "https://b.example.com",
+		"email.smtp": {"host": "smtp.example.com", "port": 25, "authentication": "PLAIN", "username": "alice", "password": "changed"},
+		"experimentalFeatures": {"apidocs.search.indexing": "enabled"},
+		"disableAutoGitUpdates": true,
+	}`
+
+	got, err := DiffSiteConfigs(before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []FieldDiff{
+		{Field: "disableAutoGitUpdates", Before: json.RawMessage(`false`), After: json.RawMessage(`true`)},
+		{
+			Field:  "email.smtp",
+			Before: json.RawMessage(`{"authentication":"PLAIN","host":"smtp.example.com","password":"REDACTED","port":25,"username":"REDACTED"}`),
+			After:  json.RawMessage(`{"authentication":"PLAIN","host":"smtp.example.com","password":"REDACTED","port":25,"username":"REDACTED"}`),
+		},
+		{Field: "externalURL", Before: json.RawMessage(`"https://a.example.com"`), After: json.RawMessage(`"https://b.example.com"`)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
 func toSlice(m map[string]struct{}) []string {
 	var s []string
 	for v := range m {
@@ -3,11 +3,13 @@ package database
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/keegancsmith/sqlf"
 
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/conf/confdefaults"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
@@ -38,11 +40,40 @@ type ConfStore interface {
 	// responsible for ensuring this or that the response never makes it to a user.
 	SiteGetLatest(ctx context.Context) (*SiteConfig, error)
 
+	// SiteGetByID returns the site config with the given ID.
+	//
+	// 🚨 SECURITY: This method does NOT verify the user is an admin. The caller is
+	// responsible for ensuring this or that the response never makes it to a user.
+	SiteGetByID(ctx context.Context, id int32) (*SiteConfig, error)
+
+	// ListSiteConfigs returns the history of site configs, most recent first.
+	//
+	// 🚨 SECURITY: This method does NOT verify the user is an admin. The caller is
+	// responsible for ensuring this or that the response never makes it to a user.
+	ListSiteConfigs(ctx context.Context, paginationArgs *PaginationArgs) ([]*SiteConfig, error)
+
+	// GetSiteConfigCount returns the number of site configs in the history.
+	GetSiteConfigCount(ctx context.Context) (int, error)
+
 	Transact(ctx context.Context) (ConfStore, error)
 	Done(error) error
 	basestore.ShareableStore
 }
 
+// SiteConfigNotFoundErr is returned by SiteGetByID when no site config with the
+// given ID exists.
+type SiteConfigNotFoundErr struct {
+	ID int32
+}
+
+func (e *SiteConfigNotFoundErr) Error() string {
+	return fmt.Sprintf("site config not found: %d", e.ID)
+}
+
+func (e *SiteConfigNotFoundErr) NotFound() bool {
+	return true
+}
+
 // ErrNewerEdit is returned by SiteCreateIfUpToDate when a newer edit has already been applied and
 // the edit has been rejected.
 var ErrNewerEdit = errors.New("someone else has already applied a newer edit")
@@ -53,14 +84,16 @@ type confStore struct {
 
 // SiteConfig contains the contents of a site config along with associated metadata.
 type SiteConfig struct {
-	ID        int32     // the unique ID of this config
-	Contents  string    // the raw JSON content (with comments and trailing commas allowed)
-	CreatedAt time.Time // the date when this config was created
-	UpdatedAt time.Time // the date when this config was updated
+	ID           int32     // the unique ID of this config
+	AuthorUserID int32     // the user who saved this config, 0 if unknown
+	Contents     string    // the raw JSON content (with comments and trailing commas allowed)
+	CreatedAt    time.Time // the date when this config was created
+	UpdatedAt    time.Time // the date when this config was updated
 }
 
 var siteConfigColumns = []*sqlf.Query{
 	sqlf.Sprintf("critical_and_site_config.id"),
+	sqlf.Sprintf("critical_and_site_config.author_user_id"),
 	sqlf.Sprintf("critical_and_site_config.contents"),
 	sqlf.Sprintf("critical_and_site_config.created_at"),
 	sqlf.Sprintf("critical_and_site_config.updated_at"),
@@ -128,8 +161,8 @@ func (s *confStore) addDefault(ctx context.Context, contents string) (newLastID
 }
 
 const createSiteConfigFmtStr = `
-INSERT INTO critical_and_site_config (type, contents)
-VALUES ('site', %s)
+INSERT INTO critical_and_site_config (type, author_user_id, contents)
+VALUES ('site', %s, %s)
 RETURNING %s -- siteConfigColumns
 `
 
@@ -158,8 +191,16 @@ func (s *confStore) createIfUpToDate(ctx context.Context, lastID *int32, content
 		return nil, ErrNewerEdit
 	}
 
+	// The author is recorded for the site config history. Configs written
+	// without a user, e.g. the defaults, have no author.
+	var authorUserID *int32
+	if a := actor.FromContext(ctx); a.IsAuthenticated() {
+		authorUserID = &a.UID
+	}
+
 	q := sqlf.Sprintf(
 		createSiteConfigFmtStr,
+		authorUserID,
 		contents,
 		sqlf.Join(siteConfigColumns, ","),
 	)
@@ -189,12 +230,82 @@ func (s *confStore) getLatest(ctx context.Context) (*SiteConfig, error) {
 	return config, err
 }
 
+const getSiteConfigByIDFmtStr = `
+SELECT %s -- siteConfigColumns
+FROM critical_and_site_config
+WHERE type='site' AND id = %s
+`
+
+func (s *confStore) SiteGetByID(ctx context.Context, id int32) (*SiteConfig, error) {
+	q := sqlf.Sprintf(
+		getSiteConfigByIDFmtStr,
+		sqlf.Join(siteConfigColumns, ","),
+		id,
+	)
+	config, err := scanSiteConfigRow(s.QueryRow(ctx, q))
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		return nil, &SiteConfigNotFoundErr{ID: id}
+	}
+	return config, err
+}
+
+const listSiteConfigsFmtStr = `
+SELECT %s -- siteConfigColumns
+FROM critical_and_site_config
+WHERE %s
+`
+
+func (s *confStore) ListSiteConfigs(ctx context.Context, paginationArgs *PaginationArgs) (_ []*SiteConfig, err error) {
+	where := []*sqlf.Query{sqlf.Sprintf("type = 'site'")}
+	queryArgs := &QueryArgs{Order: sqlf.Sprintf("id DESC")}
+	if paginationArgs != nil {
+		queryArgs, err = paginationArgs.SQL()
+		if err != nil {
+			return nil, err
+		}
+		if queryArgs.Where != nil {
+			where = append(where, queryArgs.Where)
+		}
+	}
+
+	q := sqlf.Sprintf(
+		listSiteConfigsFmtStr,
+		sqlf.Join(siteConfigColumns, ","),
+		sqlf.Join(where, "AND"),
+	)
+	q = queryArgs.AppendOrderToQuery(q)
+	q = queryArgs.AppendLimitToQuery(q)
+
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var configs []*SiteConfig
+	for rows.Next() {
+		config, err := scanSiteConfigRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+func (s *confStore) GetSiteConfigCount(ctx context.Context) (int, error) {
+	q := sqlf.Sprintf("SELECT COUNT(*) FROM critical_and_site_config WHERE type = 'site'")
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, q))
+	return count, err
+}
+
 // scanSiteConfigRow scans a single row from a *sql.Row or *sql.Rows.
 // It must be kept in sync with siteConfigColumns
 func scanSiteConfigRow(scanner dbutil.Scanner) (*SiteConfig, error) {
 	var s SiteConfig
 	err := scanner.Scan(
 		&s.ID,
+		&dbutil.NullInt32{N: &s.AuthorUserID},
 		&s.Contents,
 		&s.CreatedAt,
 		&s.UpdatedAt,
@@ -9,7 +9,9 @@ import (
 
 	"github.com/sourcegraph/log/logtest"
 
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 )
 
 func TestSiteGetLatestDefault(t *testing.T) {
@@ -215,3 +217,74 @@ func TestSiteCreateIfUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestSiteConfigHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	user, err := db.Users().Create(ctx, NewUser{Username: "admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The default config is created without an author.
+	latest, err := db.Conf().SiteGetLatest(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userCtx := actor.WithActor(ctx, actor.FromUser(user.ID))
+	for _, contents := range []string{`{"disableAutoGitUpdates": true}`, `{"disableAutoGitUpdates": false}`} {
+		latest, err = db.Conf().SiteCreateIfUpToDate(userCtx, &latest.ID, contents, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	count, err := db.Conf().GetSiteConfigCount(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Fatalf("unexpected count, want 3, got %d", count)
+	}
+
+	first := 2
+	configs, err := db.Conf().ListSiteConfigs(ctx, &PaginationArgs{First: &first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 2 {
+		t.Fatalf("unexpected number of configs, want 2, got %d", len(configs))
+	}
+	if configs[0].ID != latest.ID || configs[0].AuthorUserID != user.ID {
+		t.Fatalf("unexpected latest config: %+v", configs[0])
+	}
+
+	after := int(configs[1].ID)
+	configs, err = db.Conf().ListSiteConfigs(ctx, &PaginationArgs{First: &first, After: &after})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 1 || configs[0].AuthorUserID != 0 {
+		t.Fatalf("unexpected oldest configs: %+v", configs)
+	}
+
+	got, err := db.Conf().SiteGetByID(ctx, configs[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Contents != configs[0].Contents {
+		t.Fatalf("unexpected contents: %q", got.Contents)
+	}
+
+	_, err = db.Conf().SiteGetByID(ctx, latest.ID+1)
+	if !errcode.IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -40,11 +40,13 @@ type DB interface {
 	Phabricator() PhabricatorStore
 	Repos() RepoStore
 	RepoKVPs() RepoKVPStore
+	RepoUpdateSchedule() RepoUpdateScheduleStore
 	Roles() RoleStore
 	SavedSearches() SavedSearchStore
 	SearchContexts() SearchContextsStore
 	Settings() SettingsStore
 	SubRepoPerms() SubRepoPermsStore
+	SubRepoPermissionRules() SubRepoPermissionRuleStore
 	TemporarySettings() TemporarySettingsStore
 	UserCredentials(encryption.Key) UserCredentialsStore
 	UserEmails() UserEmailsStore
@@ -184,6 +186,10 @@ func (d *db) RepoKVPs() RepoKVPStore {
 	return &repoKVPStore{d.Store}
 }
 
+func (d *db) RepoUpdateSchedule() RepoUpdateScheduleStore {
+	return RepoUpdateScheduleWith(d.Store)
+}
+
 func (d *db) Roles() RoleStore {
 	return RolesWith(d.Store)
 }
@@ -204,6 +210,10 @@ func (d *db) SubRepoPerms() SubRepoPermsStore {
 	return SubRepoPermsWith(d.Store)
 }
 
+func (d *db) SubRepoPermissionRules() SubRepoPermissionRuleStore {
+	return SubRepoPermissionRulesWith(d.Store)
+}
+
 func (d *db) TemporarySettings() TemporarySettingsStore {
 	return TemporarySettingsWith(d.Store)
 }
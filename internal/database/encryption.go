@@ -89,6 +89,72 @@ func (s *RecordEncrypter) EncryptBatch(ctx context.Context, config EncryptionCon
 	return len(encryptedValues), nil
 }
 
+// ReencryptBatch re-encrypts a batch of records that were encrypted with an
+// earlier version of the configured key with its current version. Records
+// encrypted with a different key are left alone. This is a no-op for keys that
+// can't decrypt values encrypted with their earlier versions.
+func (s *RecordEncrypter) ReencryptBatch(ctx context.Context, config EncryptionConfig) (count int, err error) {
+	key, ok := config.Key().(encryption.RotatableKey)
+	if !ok || !key.DecryptsEarlierVersions() {
+		return 0, nil
+	}
+	version, err := key.Version(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	keyID := quote(config.KeyIDFieldName)
+	values, err := config.Scan(tx.Query(ctx, sqlf.Sprintf(
+		// Key identifiers are the JSON encoded key version, but we only parse
+		// them once we know they are JSON objects.
+		`SELECT %s FROM %s WHERE %s NOT IN ('', %s, %s) AND (CASE WHEN %s LIKE '{%%' THEN %s::jsonb->>'Type' = %s AND %s::jsonb->>'Name' = %s ELSE FALSE END) ORDER BY %s ASC LIMIT %s FOR UPDATE SKIP LOCKED`,
+		fields(config),
+		quote(config.TableName),
+		keyID,
+		encryption.UnmigratedEncryptionKeyID,
+		version.JSON(),
+		keyID,
+		keyID,
+		version.Type,
+		keyID,
+		version.Name,
+		quote(config.IDFieldName),
+		config.Limit,
+	)))
+	if err != nil {
+		return 0, err
+	}
+
+	decryptedValues, err := decryptValues(ctx, key, values)
+	if err != nil {
+		return 0, err
+	}
+	encryptedValues, err := encryptValues(ctx, key, decryptedValues)
+	if err != nil {
+		return 0, err
+	}
+
+	for id, ev := range encryptedValues {
+		if err := tx.Exec(ctx, sqlf.Sprintf(
+			"UPDATE %s SET %s WHERE %s = %s",
+			quote(config.TableName),
+			updatePairs(config, ev),
+			quote(config.IDFieldName),
+			id,
+		)); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(encryptedValues), nil
+}
+
 func (s *RecordEncrypter) DecryptBatch(ctx context.Context, config EncryptionConfig) (count int, err error) {
 	tx, err := s.Transact(ctx)
 	if err != nil {
@@ -137,6 +137,104 @@ func TestRecordEncrypter(t *testing.T) {
 	}
 }
 
+func TestRecordEncrypter_ReencryptBatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	key := &rotatableBase64Key{version: "1"}
+	encrypter := NewRecordEncrypter(db)
+
+	if err := encrypter.Exec(ctx, sqlf.Sprintf("CREATE TABLE test_encryptable (id int, encryption_key_id text, data text)")); err != nil {
+		t.Fatalf("failed to create test table: %s", err)
+	}
+
+	otherKeyID := testEncryptionKeyID(&base64Key{})
+	for i := 0; i < 10; i++ {
+		data := fmt.Sprintf("data-%d", i)
+		keyID := `{"Type":"base64","Name":"rotatable","Version":"1"}`
+		if i%5 == 0 {
+			// Values encrypted with a different key must be left alone.
+			keyID = otherKeyID
+		}
+		if err := encrypter.Exec(ctx, sqlf.Sprintf("INSERT INTO test_encryptable VALUES (%s, %s, %s)", i+1, keyID, base64.StdEncoding.EncodeToString([]byte(data)))); err != nil {
+			t.Fatalf("failed to insert test data: %s", err)
+		}
+	}
+	if err := encrypter.Exec(ctx, sqlf.Sprintf("INSERT INTO test_encryptable VALUES (11, '', 'plaintext')")); err != nil {
+		t.Fatalf("failed to insert test data: %s", err)
+	}
+
+	config := EncryptionConfig{
+		TableName:           "test_encryptable",
+		IDFieldName:         "id",
+		KeyIDFieldName:      "encryption_key_id",
+		EncryptedFieldNames: []string{"data"},
+		Scan:                basestore.NewMapScanner(scanEncryptedString),
+		Key:                 func() encryption.Key { return key },
+		Limit:               5,
+	}
+
+	// Nothing is stale before the key is rotated.
+	count, err := encrypter.ReencryptBatch(ctx, config)
+	if err != nil {
+		t.Fatalf("unexpected error re-encrypting batch: %s", err)
+	}
+	if count != 0 {
+		t.Errorf("unexpected count. want=%d have=%d", 0, count)
+	}
+
+	key.version = "2"
+	for _, want := range []int{5, 3, 0} {
+		count, err := encrypter.ReencryptBatch(ctx, config)
+		if err != nil {
+			t.Fatalf("unexpected error re-encrypting batch: %s", err)
+		}
+		if count != want {
+			t.Errorf("unexpected count. want=%d have=%d", want, count)
+		}
+	}
+
+	encryptionKeyIDs, err := basestore.ScanStrings(encrypter.Query(ctx, sqlf.Sprintf("SELECT encryption_key_id FROM test_encryptable ORDER BY id")))
+	if err != nil {
+		t.Fatalf("failed to query encryption keys: %s", err)
+	}
+	for i, keyID := range encryptionKeyIDs {
+		want := testEncryptionKeyID(key)
+		switch {
+		case i == 10:
+			want = ""
+		case i%5 == 0:
+			want = otherKeyID
+		}
+		if keyID != want {
+			t.Errorf("unexpected key identifier for record %d. want=%q have=%q", i+1, want, keyID)
+		}
+	}
+}
+
+// rotatableBase64Key is a base64Key whose version can be changed, and which
+// decrypts values encrypted with all of its versions.
+type rotatableBase64Key struct {
+	base64Key
+	version string
+}
+
+func (k *rotatableBase64Key) Version(ctx context.Context) (encryption.KeyVersion, error) {
+	return encryption.KeyVersion{
+		Type:    "base64",
+		Name:    "rotatable",
+		Version: k.version,
+	}, nil
+}
+
+func (k *rotatableBase64Key) DecryptsEarlierVersions() bool {
+	return true
+}
+
 type base64Key struct{}
 
 func (k *base64Key) Version(ctx context.Context) (encryption.KeyVersion, error) {
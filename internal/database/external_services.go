@@ -299,9 +299,9 @@ type ValidateExternalServiceConfigOptions struct {
 type ValidateExternalServiceConfigFunc = func(ctx context.Context, e ExternalServiceStore, opt ValidateExternalServiceConfigOptions) (normalized []byte, err error)
 
 // ValidateExternalServiceConfig is the default non-enterprise version of our validation function
-var ValidateExternalServiceConfig = MakeValidateExternalServiceConfigFunc(nil, nil, nil, nil)
+var ValidateExternalServiceConfig = MakeValidateExternalServiceConfigFunc(nil, nil, nil, nil, nil)
 
-func MakeValidateExternalServiceConfigFunc(gitHubValidators []func(*types.GitHubConnection) error, gitLabValidators []func(*schema.GitLabConnection, []schema.AuthProviders) error, bitbucketServerValidators []func(*schema.BitbucketServerConnection) error, perforceValidators []func(*schema.PerforceConnection) error) ValidateExternalServiceConfigFunc {
+func MakeValidateExternalServiceConfigFunc(gitHubValidators []func(*types.GitHubConnection) error, gitLabValidators []func(*schema.GitLabConnection, []schema.AuthProviders) error, bitbucketServerValidators []func(*schema.BitbucketServerConnection) error, bitbucketCloudValidators []func(*schema.BitbucketCloudConnection) error, perforceValidators []func(*schema.PerforceConnection) error) ValidateExternalServiceConfigFunc {
 	return func(ctx context.Context, e ExternalServiceStore, opt ValidateExternalServiceConfigOptions) (normalized []byte, err error) {
 		ext, ok := ExternalServiceKinds[opt.Kind]
 		if !ok {
@@ -379,6 +379,7 @@ func MakeValidateExternalServiceConfigFunc(gitHubValidators []func(*types.GitHub
 			if err = jsoniter.Unmarshal(normalized, &c); err != nil {
 				return nil, err
 			}
+			err = validateBitbucketCloudConnection(bitbucketCloudValidators, opt.ExternalServiceID, &c)
 
 		case extsvc.KindPerforce:
 			var c schema.PerforceConnection
@@ -468,6 +469,14 @@ func validateBitbucketServerConnection(bitbucketServerValidators []func(connecti
 	return err
 }
 
+func validateBitbucketCloudConnection(bitbucketCloudValidators []func(connection *schema.BitbucketCloudConnection) error, _ int64, c *schema.BitbucketCloudConnection) error {
+	var err error
+	for _, validate := range bitbucketCloudValidators {
+		err = errors.Append(err, validate(c))
+	}
+	return err
+}
+
 func validatePerforceConnection(perforceValidators []func(*schema.PerforceConnection) error, _ int64, c *schema.PerforceConnection) error {
 	var err error
 	for _, validate := range perforceValidators {
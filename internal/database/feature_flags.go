@@ -3,8 +3,10 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -36,6 +38,9 @@ type FeatureFlagStore interface {
 	GetUserFlags(context.Context, int32) (map[string]bool, error)
 	GetAnonymousUserFlags(ctx context.Context, anonymousUID string) (map[string]bool, error)
 	GetGlobalFeatureFlags(context.Context) (map[string]bool, error)
+	GetUserFlagVariants(context.Context, int32) (map[string]json.RawMessage, error)
+	GetAnonymousUserFlagVariants(ctx context.Context, anonymousUID string) (map[string]json.RawMessage, error)
+	GetGlobalFlagVariants(context.Context) (map[string]json.RawMessage, error)
 	GetOrgFeatureFlag(ctx context.Context, orgID int32, flagName string) (bool, error)
 }
 
@@ -62,8 +67,12 @@ func (f *featureFlagStore) CreateFeatureFlag(ctx context.Context, flag *ff.Featu
 			flag_name,
 			flag_type,
 			bool_value,
-			rollout
+			rollout,
+			variant_value,
+			rules
 		) VALUES (
+			%s,
+			%s,
 			%s,
 			%s,
 			%s,
@@ -73,33 +82,26 @@ func (f *featureFlagStore) CreateFeatureFlag(ctx context.Context, flag *ff.Featu
 			flag_type,
 			bool_value,
 			rollout,
+			variant_value,
+			rules,
 			created_at,
 			updated_at,
 			deleted_at
 		;
 	`
-	var (
-		flagType string
-		boolVal  *bool
-		rollout  *int32
-	)
-	switch {
-	case flag.Bool != nil:
-		flagType = "bool"
-		boolVal = &flag.Bool.Value
-	case flag.Rollout != nil:
-		flagType = "rollout"
-		rollout = &flag.Rollout.Rollout
-	default:
-		return nil, errors.New("feature flag must have exactly one type")
+	cols, err := featureFlagColumnValues(flag)
+	if err != nil {
+		return nil, err
 	}
 
 	row := f.QueryRow(ctx, sqlf.Sprintf(
 		newFeatureFlagFmtStr,
 		flag.Name,
-		flagType,
-		boolVal,
-		rollout))
+		cols.flagType,
+		cols.boolVal,
+		cols.rollout,
+		cols.variant,
+		cols.rules))
 	return scanFeatureFlag(row)
 }
 
@@ -109,39 +111,34 @@ func (f *featureFlagStore) UpdateFeatureFlag(ctx context.Context, flag *ff.Featu
 		SET
 			flag_type = %s,
 			bool_value = %s,
-			rollout = %s
+			rollout = %s,
+			variant_value = %s,
+			rules = %s
 		WHERE flag_name = %s
 		RETURNING
 			flag_name,
 			flag_type,
 			bool_value,
 			rollout,
+			variant_value,
+			rules,
 			created_at,
 			updated_at,
 			deleted_at
 		;
 	`
-	var (
-		flagType string
-		boolVal  *bool
-		rollout  *int32
-	)
-	switch {
-	case flag.Bool != nil:
-		flagType = "bool"
-		boolVal = &flag.Bool.Value
-	case flag.Rollout != nil:
-		flagType = "rollout"
-		rollout = &flag.Rollout.Rollout
-	default:
-		return nil, errors.New("feature flag must have exactly one type")
+	cols, err := featureFlagColumnValues(flag)
+	if err != nil {
+		return nil, err
 	}
 
 	row := f.QueryRow(ctx, sqlf.Sprintf(
 		updateFeatureFlagFmtStr,
-		flagType,
-		boolVal,
-		rollout,
+		cols.flagType,
+		cols.boolVal,
+		cols.rollout,
+		cols.variant,
+		cols.rules,
 		flag.Name,
 	))
 	return scanFeatureFlag(row)
@@ -180,66 +177,79 @@ func (f *featureFlagStore) CreateBool(ctx context.Context, name string, value bo
 
 var ErrInvalidColumnState = errors.New("encountered column that is unexpectedly null based on column type")
 
-func scanFeatureFlagAndOverride(scanner dbutil.Scanner) (*ff.FeatureFlag, *bool, error) {
-	var (
-		res      ff.FeatureFlag
-		flagType string
-		boolVal  *bool
-		rollout  *int32
-		override *bool
-	)
-	err := scanner.Scan(
-		&res.Name,
-		&flagType,
-		&boolVal,
-		&rollout,
-		&res.CreatedAt,
-		&res.UpdatedAt,
-		&res.DeletedAt,
-		&override,
-	)
-	if err != nil {
-		return nil, nil, err
-	}
+type featureFlagColumns struct {
+	flagType string
+	boolVal  *bool
+	rollout  *int32
+	variant  *string
+	rules    string
+}
 
-	switch flagType {
-	case "bool":
-		if boolVal == nil {
-			return nil, nil, ErrInvalidColumnState
-		}
-		res.Bool = &ff.FeatureFlagBool{
-			Value: *boolVal,
-		}
-	case "rollout":
-		if rollout == nil {
-			return nil, nil, ErrInvalidColumnState
-		}
-		res.Rollout = &ff.FeatureFlagRollout{
-			Rollout: *rollout,
+func featureFlagColumnValues(flag *ff.FeatureFlag) (cols featureFlagColumns, err error) {
+	switch {
+	case flag.Bool != nil:
+		cols.flagType = "bool"
+		cols.boolVal = &flag.Bool.Value
+	case flag.Rollout != nil:
+		cols.flagType = "rollout"
+		cols.rollout = &flag.Rollout.Rollout
+	case flag.Variant != nil:
+		if !json.Valid(flag.Variant.Value) {
+			return cols, errors.New("variant value must be valid JSON")
 		}
+		cols.flagType = "variant"
+		variant := string(flag.Variant.Value)
+		cols.variant = &variant
 	default:
-		return nil, nil, ErrInvalidColumnState
+		return cols, errors.New("feature flag must have exactly one type")
+	}
+
+	if err := flag.ValidateRules(); err != nil {
+		return cols, err
+	}
+	rules := flag.Rules
+	if rules == nil {
+		rules = []*ff.TargetingRule{}
+	}
+	serialized, err := json.Marshal(rules)
+	if err != nil {
+		return cols, err
 	}
+	cols.rules = string(serialized)
 
-	return &res, override, nil
+	return cols, nil
+}
+
+func scanFeatureFlagAndOverride(scanner dbutil.Scanner) (*ff.FeatureFlag, *bool, error) {
+	var override *bool
+	res, err := scanFeatureFlagColumns(scanner, &override)
+	return res, override, err
 }
 
 func scanFeatureFlag(scanner dbutil.Scanner) (*ff.FeatureFlag, error) {
+	return scanFeatureFlagColumns(scanner)
+}
+
+func scanFeatureFlagColumns(scanner dbutil.Scanner, extra ...any) (*ff.FeatureFlag, error) {
 	var (
 		res      ff.FeatureFlag
 		flagType string
 		boolVal  *bool
 		rollout  *int32
+		variant  *[]byte
+		rules    []byte
 	)
-	err := scanner.Scan(
+	err := scanner.Scan(append([]any{
 		&res.Name,
 		&flagType,
 		&boolVal,
 		&rollout,
+		&variant,
+		&rules,
 		&res.CreatedAt,
 		&res.UpdatedAt,
 		&res.DeletedAt,
-	)
+	}, extra...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -259,10 +269,21 @@ func scanFeatureFlag(scanner dbutil.Scanner) (*ff.FeatureFlag, error) {
 		res.Rollout = &ff.FeatureFlagRollout{
 			Rollout: *rollout,
 		}
+	case "variant":
+		if variant == nil {
+			return nil, ErrInvalidColumnState
+		}
+		res.Variant = &ff.FeatureFlagVariant{
+			Value: *variant,
+		}
 	default:
 		return nil, ErrInvalidColumnState
 	}
 
+	if err := json.Unmarshal(rules, &res.Rules); err != nil {
+		return nil, err
+	}
+
 	return &res, nil
 }
 
@@ -273,6 +294,8 @@ func (f *featureFlagStore) GetFeatureFlag(ctx context.Context, flagName string)
 			flag_type,
 			bool_value,
 			rollout,
+			variant_value,
+			rules,
 			created_at,
 			updated_at,
 			deleted_at
@@ -292,6 +315,8 @@ func (f *featureFlagStore) GetFeatureFlags(ctx context.Context) ([]*ff.FeatureFl
 			flag_type,
 			bool_value,
 			rollout,
+			variant_value,
+			rules,
 			created_at,
 			updated_at,
 			deleted_at
@@ -545,6 +570,8 @@ func (f *featureFlagStore) GetUserFlags(ctx context.Context, userID int32) (map[
 			flag_type,
 			bool_value,
 			rollout,
+			variant_value,
+			rules,
 			created_at,
 			updated_at,
 			deleted_at,
@@ -562,19 +589,106 @@ func (f *featureFlagStore) GetUserFlags(ctx context.Context, userID int32) (map[
 	}
 	defer rows.Close()
 
-	res := make(map[string]bool)
+	var flags []*ff.FeatureFlag
+	var overrides []*bool
 	for rows.Next() {
-		ff, override, err := scanFeatureFlagAndOverride(rows)
+		flag, override, err := scanFeatureFlagAndOverride(rows)
 		if err != nil {
 			return nil, err
 		}
-		if override != nil {
-			res[ff.Name] = *override
+		flags = append(flags, flag)
+		overrides = append(overrides, override)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	user, err := f.getFlagUser(ctx, userID, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]bool, len(flags))
+	for i, flag := range flags {
+		if flag.Variant != nil {
+			continue
+		}
+		if overrides[i] != nil {
+			res[flag.Name] = *overrides[i]
 		} else {
-			res[ff.Name] = ff.EvaluateForUser(userID)
+			res[flag.Name] = flag.EvaluateForUser(user)
 		}
 	}
-	return res, rows.Err()
+	return res, nil
+}
+
+// getFlagUser returns the attributes of the given user that targeting rules
+// are evaluated against. The attributes are only loaded from the database if
+// any of flags has targeting rules.
+func (f *featureFlagStore) getFlagUser(ctx context.Context, userID int32, flags []*ff.FeatureFlag) (*ff.User, error) {
+	user := &ff.User{ID: userID}
+
+	hasRules := false
+	for _, flag := range flags {
+		hasRules = hasRules || len(flag.Rules) > 0
+	}
+	if !hasRules {
+		return user, nil
+	}
+
+	const getFlagUserFmtStr = `
+		SELECT
+			u.site_admin,
+			ARRAY(
+				SELECT om.org_id
+				FROM org_members om
+				JOIN orgs o ON o.id = om.org_id
+				WHERE om.user_id = u.id
+					AND o.deleted_at IS NULL
+			),
+			ARRAY(
+				SELECT ue.email
+				FROM user_emails ue
+				WHERE ue.user_id = u.id
+					AND ue.verified_at IS NOT NULL
+			)
+		FROM users u
+		WHERE u.id = %s
+	`
+	var orgIDs []int64
+	err := f.QueryRow(ctx, sqlf.Sprintf(getFlagUserFmtStr, userID)).Scan(
+		&user.SiteAdmin,
+		pq.Array(&orgIDs),
+		pq.Array(&user.VerifiedEmails),
+	)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	for _, id := range orgIDs {
+		user.OrgIDs = append(user.OrgIDs, int32(id))
+	}
+
+	return user, nil
+}
+
+// GetUserFlagVariants returns the calculated values for variant feature flags for the given userID.
+// Overrides only apply to boolean flags.
+func (f *featureFlagStore) GetUserFlagVariants(ctx context.Context, userID int32) (map[string]json.RawMessage, error) {
+	flags, err := f.getVariantFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := f.getFlagUser(ctx, userID, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]json.RawMessage, len(flags))
+	for _, flag := range flags {
+		res[flag.Name] = flag.EvaluateVariantForUser(user)
+	}
+	return res, nil
 }
 
 // GetAnonymousUserFlags returns the calculated values for feature flags for the given anonymousUID
@@ -586,12 +700,29 @@ func (f *featureFlagStore) GetAnonymousUserFlags(ctx context.Context, anonymousU
 
 	res := make(map[string]bool, len(flags))
 	for _, ff := range flags {
+		if ff.Variant != nil {
+			continue
+		}
 		res[ff.Name] = ff.EvaluateForAnonymousUser(anonymousUID)
 	}
 
 	return res, nil
 }
 
+// GetAnonymousUserFlagVariants returns the calculated values for variant feature flags for anonymous users.
+func (f *featureFlagStore) GetAnonymousUserFlagVariants(ctx context.Context, _ string) (map[string]json.RawMessage, error) {
+	flags, err := f.getVariantFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]json.RawMessage, len(flags))
+	for _, ff := range flags {
+		res[ff.Name] = ff.EvaluateVariantForAnonymousUser()
+	}
+	return res, nil
+}
+
 func (f *featureFlagStore) GetGlobalFeatureFlags(ctx context.Context) (map[string]bool, error) {
 	flags, err := f.GetFeatureFlags(ctx)
 	if err != nil {
@@ -608,6 +739,36 @@ func (f *featureFlagStore) GetGlobalFeatureFlags(ctx context.Context) (map[strin
 	return res, nil
 }
 
+func (f *featureFlagStore) GetGlobalFlagVariants(ctx context.Context) (map[string]json.RawMessage, error) {
+	flags, err := f.getVariantFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]json.RawMessage, len(flags))
+	for _, ff := range flags {
+		if val, ok := ff.EvaluateVariantGlobal(); ok {
+			res[ff.Name] = val
+		}
+	}
+	return res, nil
+}
+
+func (f *featureFlagStore) getVariantFlags(ctx context.Context) ([]*ff.FeatureFlag, error) {
+	flags, err := f.GetFeatureFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	variants := flags[:0]
+	for _, flag := range flags {
+		if flag.Variant != nil {
+			variants = append(variants, flag)
+		}
+	}
+	return variants, nil
+}
+
 // GetOrgFeatureFlag returns the calculated flag value for the given organization, taking potential override into account
 func (f *featureFlagStore) GetOrgFeatureFlag(ctx context.Context, orgID int32, flagName string) (bool, error) {
 	g, ctx := errgroup.WithContext(ctx)
@@ -634,7 +795,7 @@ func (f *featureFlagStore) GetOrgFeatureFlag(ctx context.Context, orgID int32, f
 
 	if override != nil {
 		return override.Value, nil
-	} else if globalFlag != nil {
+	} else if globalFlag != nil && globalFlag.Bool != nil {
 		return globalFlag.Bool.Value, nil
 	}
 
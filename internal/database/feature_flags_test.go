@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -86,6 +87,18 @@ func testNewFeatureFlagRoundtrip(t *testing.T) {
 			flag:      &ff.FeatureFlag{Name: "err_too_low_rollout", Rollout: &ff.FeatureFlagRollout{Rollout: -1}},
 			assertErr: errorContains(`violates check constraint "feature_flags_rollout_check"`),
 		},
+		{
+			flag: &ff.FeatureFlag{Name: "variant", Variant: &ff.FeatureFlagVariant{Value: json.RawMessage(`{"limit":10}`)}},
+		},
+		{
+			flag: &ff.FeatureFlag{Name: "bool_with_rules", Bool: &ff.FeatureFlagBool{Value: false}, Rules: []*ff.TargetingRule{
+				{EmailDomains: []string{"contractor.com"}, Value: json.RawMessage(`true`)},
+			}},
+		},
+		{
+			flag:      &ff.FeatureFlag{Name: "err_invalid_rule_value", Bool: &ff.FeatureFlagBool{Value: false}, Rules: []*ff.TargetingRule{{Value: json.RawMessage(`"yes"`)}}},
+			assertErr: errorContains(`value must be a boolean`),
+		},
 		{
 			flag:      &ff.FeatureFlag{Name: "err_no_types"},
 			assertErr: errorContains(`feature flag must have exactly one type`),
@@ -106,6 +119,10 @@ func testNewFeatureFlagRoundtrip(t *testing.T) {
 			require.Equal(t, tc.flag.Name, res.Name)
 			require.Equal(t, tc.flag.Bool, res.Bool)
 			require.Equal(t, tc.flag.Rollout, res.Rollout)
+			if tc.flag.Variant != nil {
+				require.JSONEq(t, string(tc.flag.Variant.Value), string(res.Variant.Value))
+			}
+			require.Equal(t, len(tc.flag.Rules), len(res.Rules))
 		})
 	}
 }
@@ -521,6 +538,40 @@ func testUserFlags(t *testing.T) {
 		require.Equal(t, expected, got)
 	})
 
+	t.Run("targeting rules", func(t *testing.T) {
+		t.Cleanup(cleanup(t, db))
+		o1 := mkOrg("o1")
+		u1 := mkUser("u1", o1.ID)
+		u2 := mkUser("u2")
+		yes := true
+		_, err := flagStore.CreateFeatureFlag(ctx, &ff.FeatureFlag{
+			Name:  "f1",
+			Bool:  &ff.FeatureFlagBool{Value: false},
+			Rules: []*ff.TargetingRule{{OrgIDs: []int32{o1.ID}, Value: json.RawMessage(`true`)}},
+		})
+		require.NoError(t, err)
+		_, err = flagStore.CreateFeatureFlag(ctx, &ff.FeatureFlag{
+			Name:    "v1",
+			Variant: &ff.FeatureFlagVariant{Value: json.RawMessage(`"default"`)},
+			Rules:   []*ff.TargetingRule{{Authenticated: &yes, OrgIDs: []int32{o1.ID}, Value: json.RawMessage(`"org"`)}},
+		})
+		require.NoError(t, err)
+
+		got, err := flagStore.GetUserFlags(ctx, u1.ID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{"f1": true}, got)
+		got, err = flagStore.GetUserFlags(ctx, u2.ID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{"f1": false}, got)
+
+		variants, err := flagStore.GetUserFlagVariants(ctx, u1.ID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]json.RawMessage{"v1": json.RawMessage(`"org"`)}, variants)
+		variants, err = flagStore.GetUserFlagVariants(ctx, u2.ID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]json.RawMessage{"v1": json.RawMessage(`"default"`)}, variants)
+	})
+
 	t.Run("delete flag with override", func(t *testing.T) {
 		t.Cleanup(cleanup(t, db))
 		o1 := mkOrg("o1")
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"os"
 	"sort"
 
 	"cuelang.org/go/pkg/strings"
@@ -30,11 +31,35 @@ func Drift(commandName string, factory RunnerFactory, outFactory OutputFactory,
 		Usage:    "The target schema description file.",
 		Required: false,
 	}
+	fixFlag := &cli.BoolFlag{
+		Name:     "fix",
+		Usage:    "Print a SQL script that repairs the detected drift.",
+		Required: false,
+	}
+	fixFileFlag := &cli.StringFlag{
+		Name:     "fix-file",
+		Usage:    "Write the repair script to the given `path` instead of printing it. Implies -fix.",
+		Required: false,
+	}
+	applyFlag := &cli.BoolFlag{
+		Name:     "apply",
+		Usage:    "Apply the repair script to the database and check for drift again. Implies -fix.",
+		Required: false,
+	}
+	allowDestructiveFlag := &cli.BoolFlag{
+		Name:     "allow-destructive",
+		Usage:    "Include statements in the repair script that drop objects not in the expected schema or change column types. These may discard data.",
+		Required: false,
+	}
 
 	action := makeAction(outFactory, func(ctx context.Context, cmd *cli.Context, out *output.Output) error {
 		schemaName := schemaNameFlag.Get(cmd)
 		version := versionFlag.Get(cmd)
 		file := fileFlag.Get(cmd)
+		fixFile := fixFileFlag.Get(cmd)
+		apply := applyFlag.Get(cmd)
+		fix := fixFlag.Get(cmd) || fixFile != "" || apply
+		allowDestructive := allowDestructiveFlag.Get(cmd)
 
 		if (version == "" && file == "") || (version != "" && file != "") {
 			return errors.New("must supply exactly one of -version or -file")
@@ -49,18 +74,57 @@ func Drift(commandName string, factory RunnerFactory, outFactory OutputFactory,
 		if err != nil {
 			return err
 		}
+		expectedSchema = canonicalize(expectedSchema)
 
-		store, err := setupStore(ctx, factory, schemaName)
+		r, err := setupRunner(factory, schemaName)
 		if err != nil {
 			return err
 		}
-		schemas, err := store.Describe(ctx)
+		store, err := r.Store(ctx, schemaName)
 		if err != nil {
 			return err
 		}
-		schema := schemas["public"]
+		schema, err := describePublicSchema(ctx, store)
+		if err != nil {
+			return err
+		}
+
+		plan, err := compareSchemaDescriptions(out, schemaName, version, canonicalize(schema), expectedSchema)
+		if err == nil || !fix {
+			return err
+		}
 
-		return compareSchemaDescriptions(out, schemaName, version, canonicalize(schema), canonicalize(expectedSchema))
+		script := plan.Script(schemaName, version, allowDestructive)
+		if fixFile != "" {
+			if err := os.WriteFile(fixFile, []byte(script), 0644); err != nil {
+				return err
+			}
+			out.WriteLine(output.Linef(output.EmojiInfo, output.StyleReset, "Repair script written to %s", fixFile))
+		} else {
+			out.Write("")
+			out.WriteLine(output.Line(output.EmojiLightbulb, output.StyleBold, "Repair script:"))
+			_ = out.WriteCode("sql", script)
+		}
+		if !apply {
+			return err
+		}
+
+		out.WriteLine(output.Line(output.EmojiInfo, output.StyleReset, "Applying repair script"))
+		db, err := extractDB(ctx, r, schemaName)
+		if err != nil {
+			return err
+		}
+		if err := applyRepairPlan(ctx, db, plan, allowDestructive); err != nil {
+			return errors.Wrap(err, "failed to apply repair script")
+		}
+		out.WriteLine(output.Line(output.EmojiSuccess, output.StyleSuccess, "Repair script applied. Checking for drift again"))
+
+		schema, err = describePublicSchema(ctx, store)
+		if err != nil {
+			return err
+		}
+		_, err = compareSchemaDescriptions(out, schemaName, version, canonicalize(schema), expectedSchema)
+		return err
 	})
 
 	return &cli.Command{
@@ -72,6 +136,10 @@ func Drift(commandName string, factory RunnerFactory, outFactory OutputFactory,
 			schemaNameFlag,
 			versionFlag,
 			fileFlag,
+			fixFlag,
+			fixFileFlag,
+			applyFlag,
+			allowDestructiveFlag,
 		},
 	}
 }
@@ -174,6 +242,16 @@ func fetchExpectedSchema(
 	return descriptions.SchemaDescription{}, errors.Newf("failed to locate target schema description")
 }
 
+// describePublicSchema returns a description of the public schema of the given store's database.
+func describePublicSchema(ctx context.Context, store Store) (descriptions.SchemaDescription, error) {
+	schemas, err := store.Describe(ctx)
+	if err != nil {
+		return descriptions.SchemaDescription{}, err
+	}
+
+	return schemas["public"], nil
+}
+
 func canonicalize(schemaDescription descriptions.SchemaDescription) descriptions.SchemaDescription {
 	descriptions.Canonicalize(schemaDescription)
 
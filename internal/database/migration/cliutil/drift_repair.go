@@ -0,0 +1,386 @@
+package cliutil
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/migration/schemas"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// repairPhase determines the position of a statement within a repair script. Phases
+// are ordered so that every object is created after the objects it depends on (e.g.,
+// enum types before the columns using them, tables before the foreign keys that
+// reference them, and column types before the indexes and views built on them).
+type repairPhase int
+
+const (
+	// repairPhaseEnumLabels statements cannot be used in the same transaction that
+	// adds them, so they are applied on their own before anything else.
+	repairPhaseEnumLabels repairPhase = iota
+	repairPhaseExtensions
+	repairPhaseTypes
+	repairPhaseSequences
+	repairPhaseTables
+	repairPhaseColumns
+
+	// repairPhaseDropViews statements drop the views that are redefined, or that depend
+	// on columns whose type is changed, so that they can be recreated afterwards.
+	repairPhaseDropViews
+
+	// repairPhaseColumnTypes statements change the type of existing columns, which may
+	// fail or lose information for existing rows. Like destructive statements, they are
+	// only included on request.
+	repairPhaseColumnTypes
+
+	repairPhaseFunctions
+	repairPhaseConstraints
+	repairPhaseIndexes
+	repairPhaseForeignKeys
+	repairPhaseTriggers
+	repairPhaseViews
+	repairPhaseComments
+
+	// repairPhaseDestructive statements drop objects (and possibly data) that are not
+	// part of the expected schema. They are only included on request.
+	repairPhaseDestructive
+
+	numRepairPhases
+)
+
+// destructive returns true if statements of the phase may discard data.
+func (phase repairPhase) destructive() bool {
+	return phase == repairPhaseColumnTypes || phase == repairPhaseDestructive
+}
+
+// repairPlan collects the statements that bring a schema to its expected state.
+type repairPlan struct {
+	phases       [numRepairPhases][]string
+	manual       []string
+	rebuiltViews map[string]struct{}
+}
+
+// add records the given statements to be run in the given phase.
+func (p *repairPlan) add(phase repairPhase, statements ...string) {
+	p.phases[phase] = append(p.phases[phase], statements...)
+}
+
+// rebuildView records the statements that drop the given view and create it with the
+// given statement. It returns false if the view is already rebuilt by the plan.
+func (p *repairPlan) rebuildView(name, createViewStmt string) bool {
+	if _, ok := p.rebuiltViews[name]; ok {
+		return false
+	}
+	if p.rebuiltViews == nil {
+		p.rebuiltViews = map[string]struct{}{}
+	}
+	p.rebuiltViews[name] = struct{}{}
+
+	p.add(repairPhaseDropViews, makeDropViewStatement(name))
+	p.add(repairPhaseViews, createViewStmt)
+	return true
+}
+
+// addManual records drift for which no repair statement can be generated.
+func (p *repairPlan) addManual(description string) {
+	p.manual = append(p.manual, description)
+}
+
+// empty returns true if the plan contains no statements and no manual steps.
+func (p *repairPlan) empty() bool {
+	for _, statements := range p.phases {
+		if len(statements) > 0 {
+			return false
+		}
+	}
+
+	return len(p.manual) == 0
+}
+
+// nonTransactionalStatements returns the statements that must be run outside of a
+// transaction.
+func (p *repairPlan) nonTransactionalStatements() []string {
+	return p.phases[repairPhaseEnumLabels]
+}
+
+// transactionalStatements returns the remaining statements in the order in which they
+// must be applied. Destructive statements are included only if allowDestructive is true.
+func (p *repairPlan) transactionalStatements(allowDestructive bool) []string {
+	var statements []string
+	for phase := repairPhaseEnumLabels + 1; phase < numRepairPhases; phase++ {
+		if !phase.destructive() || allowDestructive {
+			statements = append(statements, p.phases[phase]...)
+		}
+	}
+
+	return statements
+}
+
+// numDestructiveStatements returns the number of statements that may discard data.
+func (p *repairPlan) numDestructiveStatements() (n int) {
+	for phase := repairPhaseEnumLabels + 1; phase < numRepairPhases; phase++ {
+		if phase.destructive() {
+			n += len(p.phases[phase])
+		}
+	}
+
+	return n
+}
+
+// Script renders the plan as a SQL script that can be applied with psql.
+func (p *repairPlan) Script(schemaName, version string, allowDestructive bool) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "-- Repair script for schema %q", schemaName)
+	if version != "" {
+		fmt.Fprintf(&sb, " at version %s", version)
+	}
+	sb.WriteString(".\n")
+
+	if statements := p.nonTransactionalStatements(); len(statements) > 0 {
+		sb.WriteString("\n-- The following statements cannot be run inside of a transaction.\n")
+		writeStatements(&sb, statements)
+	}
+
+	if statements := p.transactionalStatements(allowDestructive); len(statements) > 0 {
+		sb.WriteString("\nBEGIN;\n")
+		for phase := repairPhaseEnumLabels + 1; phase < numRepairPhases; phase++ {
+			statements := p.phases[phase]
+			if len(statements) == 0 || (phase.destructive() && !allowDestructive) {
+				continue
+			}
+
+			switch phase {
+			case repairPhaseColumnTypes:
+				sb.WriteString("\n-- DESTRUCTIVE: The following statements change the type of existing columns,\n")
+				sb.WriteString("-- which may fail or discard data. Review them carefully.\n")
+			case repairPhaseDestructive:
+				sb.WriteString("\n-- DESTRUCTIVE: The following statements drop objects that are not part of the\n")
+				sb.WriteString("-- expected schema, which may discard data. Review them carefully.\n")
+			}
+			writeStatements(&sb, statements)
+			if phase == repairPhaseColumnTypes {
+				sb.WriteString("\n")
+			}
+		}
+
+		sb.WriteString("\nCOMMIT;\n")
+	}
+
+	if n := p.numDestructiveStatements(); n > 0 && !allowDestructive {
+		fmt.Fprintf(&sb, "\n-- Omitted %d destructive statement(s). Supply -allow-destructive to include them.\n", n)
+	}
+
+	if len(p.manual) > 0 {
+		sb.WriteString("\n-- The following drift cannot be repaired automatically and must be resolved by hand:\n")
+		for _, description := range p.manual {
+			fmt.Fprintf(&sb, "--   - %s\n", description)
+		}
+	}
+
+	return sb.String()
+}
+
+func writeStatements(sb *strings.Builder, statements []string) {
+	for _, statement := range statements {
+		sb.WriteString(statement)
+		sb.WriteString("\n")
+	}
+}
+
+// applyRepairPlan runs the statements of the given plan against the given database.
+// Statements that cannot be run inside of a transaction are applied first, and all
+// remaining statements are applied within a single transaction.
+func applyRepairPlan(ctx context.Context, db *sql.DB, plan *repairPlan, allowDestructive bool) (err error) {
+	for _, statement := range plan.nonTransactionalStatements() {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return errors.Wrapf(err, "failed to apply %q", statement)
+		}
+	}
+
+	statements := plan.transactionalStatements(allowDestructive)
+	if len(statements) == 0 {
+		return nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Append(err, tx.Rollback())
+		}
+	}()
+
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return errors.Wrapf(err, "failed to apply %q", statement)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// makeCreateTableStatements returns the statements that define the given table along with
+// the phase in which each statement must run. Columns whose definition cannot be constructed
+// from the description are returned separately.
+func makeCreateTableStatements(table schemas.TableDescription) (statements map[repairPhase][]string, unsupportedColumns []string) {
+	columnDefinitions := make([]string, 0, len(table.Columns))
+	for _, column := range table.Columns {
+		definition, ok := makeColumnDefinition(column)
+		if !ok {
+			unsupportedColumns = append(unsupportedColumns, column.Name)
+			continue
+		}
+
+		columnDefinitions = append(columnDefinitions, "    "+definition)
+	}
+
+	statements = map[repairPhase][]string{}
+	statements[repairPhaseTables] = append(statements[repairPhaseTables], fmt.Sprintf("CREATE TABLE %s (\n%s\n);", table.Name, strings.Join(columnDefinitions, ",\n")))
+
+	for _, index := range table.Indexes {
+		phase, stmt := makeCreateIndexStatement(table.Name, index)
+		statements[phase] = append(statements[phase], stmt)
+	}
+	for _, constraint := range table.Constraints {
+		phase, stmt := makeCreateConstraintStatement(table.Name, constraint)
+		statements[phase] = append(statements[phase], stmt)
+	}
+	for _, trigger := range table.Triggers {
+		statements[repairPhaseTriggers] = append(statements[repairPhaseTriggers], fmt.Sprintf("%s;", trigger.Definition))
+	}
+	if table.Comment != "" {
+		statements[repairPhaseComments] = append(statements[repairPhaseComments], makeTableCommentStatement(table.Name, table.Comment))
+	}
+	for _, column := range table.Columns {
+		if column.Comment != "" {
+			statements[repairPhaseComments] = append(statements[repairPhaseComments], makeColumnCommentStatement(table.Name, column.Name, column.Comment))
+		}
+	}
+
+	return statements, unsupportedColumns
+}
+
+// makeColumnDefinition returns the definition of the given column as it would appear in a
+// CREATE TABLE or ALTER TABLE ADD COLUMN statement. If the column type cannot be determined
+// from the description (e.g., arrays of user-defined types), a false-valued flag is returned.
+func makeColumnDefinition(column schemas.ColumnDescription) (string, bool) {
+	typeName, ok := makeColumnType(column)
+	if !ok {
+		return "", false
+	}
+
+	parts := []string{column.Name, typeName}
+	switch {
+	case column.IsGenerated == "ALWAYS":
+		parts = append(parts, fmt.Sprintf("GENERATED ALWAYS AS (%s) STORED", column.GenerationExpression))
+	case column.IsIdentity:
+		parts = append(parts, fmt.Sprintf("GENERATED %s AS IDENTITY", column.IdentityGeneration))
+	case column.Default != "":
+		parts = append(parts, "DEFAULT "+column.Default)
+	}
+	if !column.IsNullable {
+		parts = append(parts, "NOT NULL")
+	}
+
+	return strings.Join(parts, " "), true
+}
+
+// makeColumnType returns the SQL type of the given column.
+func makeColumnType(column schemas.ColumnDescription) (string, bool) {
+	if strings.HasPrefix(column.TypeName, "USER-DEFINED") {
+		return "", false
+	}
+	if column.CharacterMaximumLength > 0 {
+		return fmt.Sprintf("%s(%d)", column.TypeName, column.CharacterMaximumLength), true
+	}
+
+	return column.TypeName, true
+}
+
+// makeCreateIndexStatement returns the statement that defines the given index. Indexes that
+// back a primary key or unique constraint are defined via their constraint.
+func makeCreateIndexStatement(tableName string, index schemas.IndexDescription) (repairPhase, string) {
+	switch index.ConstraintType {
+	case "u", "p":
+		return repairPhaseConstraints, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s;", tableName, index.Name, index.ConstraintDefinition)
+	default:
+		return repairPhaseIndexes, fmt.Sprintf("%s;", index.IndexDefinition)
+	}
+}
+
+// makeDropIndexStatement returns the statement that drops the given index.
+func makeDropIndexStatement(tableName string, index schemas.IndexDescription) string {
+	switch index.ConstraintType {
+	case "u", "p":
+		return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", tableName, index.Name)
+	default:
+		return fmt.Sprintf("DROP INDEX %s;", index.Name)
+	}
+}
+
+// makeCreateConstraintStatement returns the statement that defines the given constraint.
+// Foreign keys are defined once all tables and their unique constraints exist.
+func makeCreateConstraintStatement(tableName string, constraint schemas.ConstraintDescription) (repairPhase, string) {
+	phase := repairPhaseConstraints
+	if constraint.ConstraintType == "f" {
+		phase = repairPhaseForeignKeys
+	}
+
+	return phase, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s;", tableName, constraint.Name, constraint.ConstraintDefinition)
+}
+
+// makeCreateViewStatement returns the statement that defines the given view.
+func makeCreateViewStatement(view schemas.ViewDescription) string {
+	// pgsql has weird indents here
+	viewDefinition := strings.TrimSpace(stripIndent(" " + view.Definition))
+	return fmt.Sprintf("CREATE VIEW %s AS %s", view.Name, viewDefinition)
+}
+
+// makeDropViewStatement returns the statement that drops the given view. The view may
+// already have been dropped along with another view it depends on.
+func makeDropViewStatement(viewName string) string {
+	return fmt.Sprintf("DROP VIEW IF EXISTS %s;", viewName)
+}
+
+// makeSequenceStatement returns a CREATE SEQUENCE or ALTER SEQUENCE statement (depending on
+// the given verb) that sets all properties of the given sequence.
+func makeSequenceStatement(verb string, sequence schemas.SequenceDescription) string {
+	cycle := "NO CYCLE"
+	if sequence.CycleOption == "YES" {
+		cycle = "CYCLE"
+	}
+
+	return fmt.Sprintf(
+		"%s SEQUENCE %s AS %s INCREMENT BY %d MINVALUE %d MAXVALUE %d START WITH %d %s;",
+		verb,
+		sequence.Name,
+		sequence.TypeName,
+		sequence.Increment,
+		sequence.MinimumValue,
+		sequence.MaximumValue,
+		sequence.StartValue,
+		cycle,
+	)
+}
+
+func makeTableCommentStatement(tableName, comment string) string {
+	return fmt.Sprintf("COMMENT ON TABLE %s IS %s;", tableName, quoteCommentLiteral(comment))
+}
+
+func makeColumnCommentStatement(tableName, columnName, comment string) string {
+	return fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", tableName, columnName, quoteCommentLiteral(comment))
+}
+
+// quoteCommentLiteral returns the given comment as a SQL string literal. Empty comments
+// are removed by setting them to NULL.
+func quoteCommentLiteral(comment string) string {
+	if comment == "" {
+		return "NULL"
+	}
+
+	return "'" + strings.ReplaceAll(comment, "'", "''") + "'"
+}
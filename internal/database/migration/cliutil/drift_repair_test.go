@@ -0,0 +1,166 @@
+package cliutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/migration/schemas"
+	"github.com/sourcegraph/sourcegraph/lib/output"
+)
+
+func TestRepairPlan(t *testing.T) {
+	actual := schemas.SchemaDescription{
+		Enums: []schemas.EnumDescription{{Name: "state", Labels: []string{"queued", "completed"}}},
+		Tables: []schemas.TableDescription{
+			{
+				Name: "jobs",
+				Columns: []schemas.ColumnDescription{
+					{Name: "id", TypeName: "integer", Default: "nextval('jobs_id_seq'::regclass)"},
+					{Name: "legacy", TypeName: "text", IsNullable: true},
+					{Name: "state", TypeName: "state", IsNullable: true},
+				},
+				Indexes: []schemas.IndexDescription{
+					{Name: "jobs_legacy", IndexDefinition: "CREATE INDEX jobs_legacy ON jobs USING btree (legacy)"},
+				},
+			},
+		},
+	}
+	expected := schemas.SchemaDescription{
+		Enums: []schemas.EnumDescription{{Name: "state", Labels: []string{"queued", "processing", "completed"}}},
+		Sequences: []schemas.SequenceDescription{
+			{Name: "workers_id_seq", TypeName: "integer", StartValue: 1, MinimumValue: 1, MaximumValue: 2147483647, Increment: 1, CycleOption: "NO"},
+		},
+		Tables: []schemas.TableDescription{
+			{
+				Name: "jobs",
+				Columns: []schemas.ColumnDescription{
+					{Name: "id", TypeName: "integer", Default: "nextval('jobs_id_seq'::regclass)"},
+					{Name: "state", TypeName: "state", Default: "'queued'::state", Comment: "The job's state."},
+					{Name: "worker_id", TypeName: "integer", IsNullable: true},
+				},
+				Indexes: []schemas.IndexDescription{
+					{Name: "jobs_pkey", IsPrimaryKey: true, IsUnique: true, ConstraintType: "p", ConstraintDefinition: "PRIMARY KEY (id)"},
+				},
+				Constraints: []schemas.ConstraintDescription{
+					{Name: "jobs_worker_id_fkey", ConstraintType: "f", RefTableName: "workers", ConstraintDefinition: "FOREIGN KEY (worker_id) REFERENCES workers(id)"},
+				},
+			},
+			{
+				Name: "workers",
+				Columns: []schemas.ColumnDescription{
+					{Name: "id", TypeName: "integer", Default: "nextval('workers_id_seq'::regclass)"},
+					{Name: "hostname", TypeName: "character varying", CharacterMaximumLength: 255},
+				},
+				Indexes: []schemas.IndexDescription{
+					{Name: "workers_pkey", IsPrimaryKey: true, IsUnique: true, ConstraintType: "p", ConstraintDefinition: "PRIMARY KEY (id)"},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	plan, err := compareSchemaDescriptions(output.NewOutput(&buf, output.OutputOpts{}), "frontend", "v4.3.0", canonicalize(actual), canonicalize(expected))
+	if err != errOutOfSync {
+		t.Fatalf("unexpected error. want=%v have=%v", errOutOfSync, err)
+	}
+
+	expectedNonTransactional := []string{
+		"ALTER TYPE state ADD VALUE 'processing' AFTER 'queued';",
+	}
+	if diff := cmp.Diff(expectedNonTransactional, plan.nonTransactionalStatements()); diff != "" {
+		t.Errorf("unexpected non-transactional statements (-want +got):\n%s", diff)
+	}
+
+	expectedTransactional := []string{
+		"CREATE SEQUENCE workers_id_seq AS integer INCREMENT BY 1 MINVALUE 1 MAXVALUE 2147483647 START WITH 1 NO CYCLE;",
+		"CREATE TABLE workers (\n    hostname character varying(255) NOT NULL,\n    id integer DEFAULT nextval('workers_id_seq'::regclass) NOT NULL\n);",
+		"ALTER TABLE jobs ALTER COLUMN state SET NOT NULL;",
+		"ALTER TABLE jobs ALTER COLUMN state SET DEFAULT 'queued'::state;",
+		"ALTER TABLE jobs ADD COLUMN worker_id integer;",
+		"ALTER TABLE jobs ADD CONSTRAINT jobs_pkey PRIMARY KEY (id);",
+		"ALTER TABLE workers ADD CONSTRAINT workers_pkey PRIMARY KEY (id);",
+		"ALTER TABLE jobs ADD CONSTRAINT jobs_worker_id_fkey FOREIGN KEY (worker_id) REFERENCES workers(id);",
+		"COMMENT ON COLUMN jobs.state IS 'The job''s state.';",
+	}
+	if diff := cmp.Diff(expectedTransactional, plan.transactionalStatements(false)); diff != "" {
+		t.Errorf("unexpected transactional statements (-want +got):\n%s", diff)
+	}
+
+	expectedDestructive := []string{
+		"ALTER TABLE jobs DROP COLUMN legacy;",
+		"DROP INDEX jobs_legacy;",
+	}
+	if diff := cmp.Diff(expectedDestructive, plan.phases[repairPhaseDestructive]); diff != "" {
+		t.Errorf("unexpected destructive statements (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(append(expectedTransactional, expectedDestructive...), plan.transactionalStatements(true)); diff != "" {
+		t.Errorf("unexpected transactional statements with destructive statements (-want +got):\n%s", diff)
+	}
+}
+
+func TestRepairPlanColumnTypes(t *testing.T) {
+	actual := schemas.SchemaDescription{
+		Tables: []schemas.TableDescription{
+			{
+				Name: "jobs",
+				Columns: []schemas.ColumnDescription{
+					{Name: "id", TypeName: "integer"},
+					{Name: "priority", TypeName: "text"},
+				},
+				Indexes: []schemas.IndexDescription{
+					{Name: "jobs_priority", IndexDefinition: "CREATE INDEX jobs_priority ON jobs USING btree (priority)"},
+				},
+			},
+		},
+		Views: []schemas.ViewDescription{
+			{Name: "urgent_jobs", Definition: " SELECT jobs.id\n   FROM jobs\n  WHERE jobs.priority > 10;"},
+		},
+	}
+	expected := schemas.SchemaDescription{
+		Tables: []schemas.TableDescription{
+			{
+				Name: "jobs",
+				Columns: []schemas.ColumnDescription{
+					{Name: "id", TypeName: "integer"},
+					{Name: "priority", TypeName: "integer"},
+				},
+				Indexes: []schemas.IndexDescription{
+					{Name: "jobs_priority", IndexDefinition: "CREATE INDEX jobs_priority ON jobs USING btree (priority DESC)"},
+				},
+			},
+		},
+		Views: []schemas.ViewDescription{
+			{Name: "urgent_jobs", Definition: " SELECT jobs.id\n   FROM jobs\n  WHERE jobs.priority > 10;"},
+		},
+	}
+
+	var buf bytes.Buffer
+	plan, err := compareSchemaDescriptions(output.NewOutput(&buf, output.OutputOpts{}), "frontend", "v4.3.0", canonicalize(actual), canonicalize(expected))
+	if err != errOutOfSync {
+		t.Fatalf("unexpected error. want=%v have=%v", errOutOfSync, err)
+	}
+
+	expectedTransactional := []string{
+		"DROP VIEW IF EXISTS urgent_jobs;",
+		"DROP INDEX jobs_priority;",
+		"CREATE INDEX jobs_priority ON jobs USING btree (priority DESC);",
+		"CREATE VIEW urgent_jobs AS SELECT jobs.id\n FROM jobs\nWHERE jobs.priority > 10;",
+	}
+	if diff := cmp.Diff(expectedTransactional, plan.transactionalStatements(false)); diff != "" {
+		t.Errorf("unexpected transactional statements (-want +got):\n%s", diff)
+	}
+
+	// The column type is changed before the index and the view using it are rebuilt
+	expectedWithColumnTypes := []string{
+		"DROP VIEW IF EXISTS urgent_jobs;",
+		"ALTER TABLE jobs ALTER COLUMN priority TYPE integer;",
+		"DROP INDEX jobs_priority;",
+		"CREATE INDEX jobs_priority ON jobs USING btree (priority DESC);",
+		"CREATE VIEW urgent_jobs AS SELECT jobs.id\n FROM jobs\nWHERE jobs.priority > 10;",
+	}
+	if diff := cmp.Diff(expectedWithColumnTypes, plan.transactionalStatements(true)); diff != "" {
+		t.Errorf("unexpected transactional statements with destructive statements (-want +got):\n%s", diff)
+	}
+}
@@ -30,8 +30,11 @@ func getSchemaJSONFilename(schemaName string) (string, error) {
 
 var errOutOfSync = errors.Newf("database schema is out of sync")
 
-func compareSchemaDescriptions(rawOut *output.Output, schemaName, version string, actual, expected schemas.SchemaDescription) (err error) {
-	out := &preambledOutput{rawOut, false}
+// compareSchemaDescriptions writes the differences between the given schema descriptions
+// to the given output and returns errOutOfSync if there are any. The returned plan contains
+// the statements that repair the detected drift.
+func compareSchemaDescriptions(rawOut *output.Output, schemaName, version string, actual, expected schemas.SchemaDescription) (_ *repairPlan, err error) {
+	out := &preambledOutput{out: rawOut, plan: &repairPlan{}}
 
 	for _, f := range []func(out *preambledOutput, schemaName, version string, actual, expected schemas.SchemaDescription) bool{
 		compareExtensions,
@@ -49,14 +52,14 @@ func compareSchemaDescriptions(rawOut *output.Output, schemaName, version string
 	if err == nil {
 		rawOut.WriteLine(output.Line(output.EmojiSuccess, output.StyleSuccess, "No drift detected"))
 	}
-	return err
+	return out.plan, err
 }
 
 func compareExtensions(out *preambledOutput, schemaName, version string, actual, expected schemas.SchemaDescription) bool {
 	return compareNamedLists(wrapStrings(actual.Extensions), wrapStrings(expected.Extensions), func(extension *stringNamer, expectedExtension stringNamer) bool {
 		if extension == nil {
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Missing extension %q", expectedExtension)))
-			writeSQLSolution(out, "install the extension", fmt.Sprintf("CREATE EXTENSION %s;", expectedExtension))
+			writeRepairSolution(out, repairPhaseExtensions, "install the extension", fmt.Sprintf("CREATE EXTENSION %s;", expectedExtension))
 			return true
 		}
 
@@ -75,19 +78,19 @@ func compareEnums(out *preambledOutput, schemaName, version string, actual, expe
 
 		if enum == nil {
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Missing enum %q", expectedEnum.Name)))
-			writeSQLSolution(out, "create the type", createEnumStmt)
+			writeRepairSolution(out, repairPhaseTypes, "create the type", createEnumStmt)
 			return true
 		}
 
 		if ordered, ok := constructEnumRepairStatements(*enum, expectedEnum); ok {
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Missing %d labels for enum %q", len(ordered), expectedEnum.Name)))
-			writeSQLSolution(out, "add the missing enum labels", ordered...)
+			writeRepairSolution(out, repairPhaseEnumLabels, "add the missing enum labels", ordered...)
 			return true
 		}
 
 		out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Unexpected labels for enum %q", expectedEnum.Name)))
 		writeDiff(out, enum.Labels, expectedEnum.Labels)
-		writeSQLSolution(out, "drop and re-create the type", dropEnumStmt, createEnumStmt)
+		writeRepairSolution(out, repairPhaseDestructive, "drop and re-create the type", dropEnumStmt, createEnumStmt)
 		return true
 	}, noopAdditionalCallback[schemas.EnumDescription])
 }
@@ -98,33 +101,28 @@ func compareFunctions(out *preambledOutput, schemaName, version string, actual,
 
 		if function == nil {
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Missing function %q", expectedFunction.Name)))
-			writeSQLSolution(out, "define the function", definitionStmt)
+			writeRepairSolution(out, repairPhaseFunctions, "define the function", definitionStmt)
 			return true
 		}
 
 		out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Unexpected definition of function %q", expectedFunction.Name)))
 		writeDiff(out, expectedFunction.Definition, function.Definition)
-		writeSQLSolution(out, "replace the function definition", definitionStmt)
+		writeRepairSolution(out, repairPhaseFunctions, "replace the function definition", definitionStmt)
 		return true
 	}, noopAdditionalCallback[schemas.FunctionDescription])
 }
 
 func compareSequences(out *preambledOutput, schemaName, version string, actual, expected schemas.SchemaDescription) bool {
 	return compareNamedLists(actual.Sequences, expected.Sequences, func(sequence *schemas.SequenceDescription, expectedSequence schemas.SequenceDescription) bool {
-		definitionStmt := makeSearchURL(schemaName, version,
-			fmt.Sprintf("CREATE SEQUENCE %s", expectedSequence.Name),
-			fmt.Sprintf("nextval('%s'::regclass);", expectedSequence.Name),
-		)
-
 		if sequence == nil {
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Missing sequence %q", expectedSequence.Name)))
-			writeSearchHint(out, "define the sequence", definitionStmt)
+			writeRepairSolution(out, repairPhaseSequences, "define the sequence", makeSequenceStatement("CREATE", expectedSequence))
 			return true
 		}
 
 		out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Unexpected properties of sequence %q", expectedSequence.Name)))
 		writeDiff(out, expectedSequence, *sequence)
-		writeSearchHint(out, "redefine the sequence", definitionStmt)
+		writeRepairSolution(out, repairPhaseSequences, "redefine the sequence", makeSequenceStatement("ALTER", expectedSequence))
 		return true
 	}, noopAdditionalCallback[schemas.SequenceDescription])
 }
@@ -133,16 +131,27 @@ func compareTables(out *preambledOutput, schemaName, version string, actual, exp
 	return compareNamedLists(actual.Tables, expected.Tables, func(table *schemas.TableDescription, expectedTable schemas.TableDescription) bool {
 		if table == nil {
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Missing table %q", expectedTable.Name)))
-			writeSearchHint(out, "define the table", makeSearchURL(schemaName, version,
-				fmt.Sprintf("CREATE TABLE %s", expectedTable.Name),
-				fmt.Sprintf("ALTER TABLE ONLY %s", expectedTable.Name),
-				fmt.Sprintf("CREATE .*(INDEX|TRIGGER).* ON %s", expectedTable.Name),
-			))
+
+			statementsByPhase, unsupportedColumns := makeCreateTableStatements(expectedTable)
+			var statements []string
+			for phase := repairPhase(0); phase < numRepairPhases; phase++ {
+				statements = append(statements, statementsByPhase[phase]...)
+				out.plan.add(phase, statementsByPhase[phase]...)
+			}
+			writeSQLSolution(out, "define the table", statements...)
+
+			if len(unsupportedColumns) > 0 {
+				out.plan.addManual(fmt.Sprintf("define columns %s of table %q", strings.Join(unsupportedColumns, ", "), expectedTable.Name))
+				writeSearchHint(out, "define the remaining columns", makeSearchURL(schemaName, version,
+					fmt.Sprintf("CREATE TABLE %s", expectedTable.Name),
+					fmt.Sprintf("ALTER TABLE ONLY %s", expectedTable.Name),
+				))
+			}
 			return true
 		}
 
 		outOfSync := false
-		outOfSync = compareColumns(out, schemaName, version, *table, expectedTable) || outOfSync
+		outOfSync = compareColumns(out, schemaName, version, *table, expectedTable, expected.Views) || outOfSync
 		outOfSync = compareConstraints(out, *table, expectedTable) || outOfSync
 		outOfSync = compareIndexes(out, *table, expectedTable) || outOfSync
 		outOfSync = compareTriggers(out, *table, expectedTable) || outOfSync
@@ -151,10 +160,20 @@ func compareTables(out *preambledOutput, schemaName, version string, actual, exp
 	}, noopAdditionalCallback[schemas.TableDescription])
 }
 
-func compareColumns(out *preambledOutput, schemaName, version string, actualTable, expectedTable schemas.TableDescription) bool {
+func compareColumns(out *preambledOutput, schemaName, version string, actualTable, expectedTable schemas.TableDescription, expectedViews []schemas.ViewDescription) bool {
 	return compareNamedLists(actualTable.Columns, expectedTable.Columns, func(column *schemas.ColumnDescription, expectedColumn schemas.ColumnDescription) bool {
 		if column == nil {
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Missing column %q.%q", expectedTable.Name, expectedColumn.Name)))
+
+			if definition, ok := makeColumnDefinition(expectedColumn); ok {
+				writeRepairSolution(out, repairPhaseColumns, "define the column", fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", expectedTable.Name, definition))
+				if expectedColumn.Comment != "" {
+					out.plan.add(repairPhaseComments, makeColumnCommentStatement(expectedTable.Name, expectedColumn.Name, expectedColumn.Comment))
+				}
+				return true
+			}
+
+			out.plan.addManual(fmt.Sprintf("define column %q.%q", expectedTable.Name, expectedColumn.Name))
 			writeSearchHint(out, "define the column", makeSearchURL(schemaName, version,
 				fmt.Sprintf("CREATE TABLE %s", expectedTable.Name),
 				fmt.Sprintf("ALTER TABLE ONLY %s", expectedTable.Name),
@@ -165,15 +184,28 @@ func compareColumns(out *preambledOutput, schemaName, version string, actualTabl
 		out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Unexpected properties of column %q.%q", expectedTable.Name, expectedColumn.Name)))
 		writeDiff(out, expectedColumn, *column)
 
-		equivIf := func(f func(*schemas.ColumnDescription)) bool {
-			c := *column
-			f(&c)
-			return cmp.Diff(c, expectedColumn) == ""
+		// Repair each property we know how to alter in-place, and track the state of the
+		// column after these repairs. Anything left over must be redefined by hand.
+		repaired := *column
+
+		if column.TypeName != expectedColumn.TypeName || column.CharacterMaximumLength != expectedColumn.CharacterMaximumLength {
+			if typeName, ok := makeColumnType(expectedColumn); ok {
+				// Changing the type of a column may fail or lose information for existing rows
+				alterTypeStmt := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", expectedTable.Name, expectedColumn.Name, typeName)
+				writeRepairSolution(out, repairPhaseColumnTypes, "change the column type", alterTypeStmt)
+
+				// Views using the column prevent changing its type
+				for _, view := range viewsReferencingTable(expectedViews, expectedTable.Name) {
+					createViewStmt := makeCreateViewStatement(view)
+					if out.plan.rebuildView(view.Name, createViewStmt) {
+						writeSQLSolution(out, "rebuild the view using the column", makeDropViewStatement(view.Name), createViewStmt)
+					}
+				}
+				repaired.TypeName = expectedColumn.TypeName
+				repaired.CharacterMaximumLength = expectedColumn.CharacterMaximumLength
+			}
 		}
-
-		// TODO
-		// if equivIf(func(s *schemas.ColumnDescription) { s.TypeName = expectedColumn.TypeName }) {}
-		if equivIf(func(s *schemas.ColumnDescription) { s.IsNullable = expectedColumn.IsNullable }) {
+		if column.IsNullable != expectedColumn.IsNullable {
 			var verb string
 			if expectedColumn.IsNullable {
 				verb = "DROP"
@@ -182,30 +214,36 @@ func compareColumns(out *preambledOutput, schemaName, version string, actualTabl
 			}
 
 			nullabilityStmt := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s NOT NULL;", expectedTable.Name, expectedColumn.Name, verb)
-			writeSQLSolution(out, "change the column nullability constraint", nullabilityStmt)
-			return true
+			writeRepairSolution(out, repairPhaseColumns, "change the column nullability constraint", nullabilityStmt)
+			repaired.IsNullable = expectedColumn.IsNullable
 		}
-		if equivIf(func(s *schemas.ColumnDescription) { s.Default = expectedColumn.Default }) {
+		if column.Default != expectedColumn.Default && !column.IsIdentity && !expectedColumn.IsIdentity {
 			setDefaultStmt := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s;", expectedTable.Name, expectedColumn.Name, expectedColumn.Default)
-			writeSQLSolution(out, "change the column default", setDefaultStmt)
-			return true
+			if expectedColumn.Default == "" {
+				setDefaultStmt = fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;", expectedTable.Name, expectedColumn.Name)
+			}
+			writeRepairSolution(out, repairPhaseColumns, "change the column default", setDefaultStmt)
+			repaired.Default = expectedColumn.Default
 		}
-		if equivIf(func(s *schemas.ColumnDescription) { s.Comment = expectedColumn.Comment }) {
-			setDefaultStmt := fmt.Sprintf("COMMENT ON COLUMN %s.%s IS '%s';", expectedTable.Name, expectedColumn.Name, expectedColumn.Comment)
-			writeSQLSolution(out, "change the column comment", setDefaultStmt)
-			return true
+		if column.Comment != expectedColumn.Comment {
+			setCommentStmt := makeColumnCommentStatement(expectedTable.Name, expectedColumn.Name, expectedColumn.Comment)
+			writeRepairSolution(out, repairPhaseComments, "change the column comment", setCommentStmt)
+			repaired.Comment = expectedColumn.Comment
 		}
 
-		writeSearchHint(out, "redefine the column", makeSearchURL(schemaName, version,
-			fmt.Sprintf("CREATE TABLE %s", expectedTable.Name),
-			fmt.Sprintf("ALTER TABLE ONLY %s", expectedTable.Name),
-		))
+		if cmp.Diff(repaired, expectedColumn) != "" {
+			out.plan.addManual(fmt.Sprintf("redefine column %q.%q", expectedTable.Name, expectedColumn.Name))
+			writeSearchHint(out, "redefine the column", makeSearchURL(schemaName, version,
+				fmt.Sprintf("CREATE TABLE %s", expectedTable.Name),
+				fmt.Sprintf("ALTER TABLE ONLY %s", expectedTable.Name),
+			))
+		}
 		return true
 	}, func(additional []schemas.ColumnDescription) bool {
 		for _, column := range additional {
 			dropColumnStmt := fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", expectedTable.Name, column.Name)
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Unexpected column %q.%q", expectedTable.Name, column.Name)))
-			writeSQLSolution(out, "drop the column", dropColumnStmt)
+			writeRepairSolution(out, repairPhaseDestructive, "drop the column", dropColumnStmt)
 		}
 
 		return true
@@ -214,24 +252,24 @@ func compareColumns(out *preambledOutput, schemaName, version string, actualTabl
 
 func compareConstraints(out *preambledOutput, actualTable, expectedTable schemas.TableDescription) bool {
 	return compareNamedLists(actualTable.Constraints, expectedTable.Constraints, func(constraint *schemas.ConstraintDescription, expectedConstraint schemas.ConstraintDescription) bool {
-		createConstraintStmt := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s;", expectedTable.Name, expectedConstraint.Name, expectedConstraint.ConstraintDefinition)
+		phase, createConstraintStmt := makeCreateConstraintStatement(expectedTable.Name, expectedConstraint)
 		dropConstraintStmt := fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", expectedTable.Name, expectedConstraint.Name)
 
 		if constraint == nil {
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Missing constraint %q.%q", expectedTable.Name, expectedConstraint.Name)))
-			writeSQLSolution(out, "define the constraint", createConstraintStmt)
+			writeRepairSolution(out, phase, "define the constraint", createConstraintStmt)
 			return true
 		}
 
 		out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Unexpected properties of constraint %q.%q", expectedTable.Name, expectedConstraint.Name)))
 		writeDiff(out, expectedConstraint, *constraint)
-		writeSQLSolution(out, "redefine the constraint", dropConstraintStmt, createConstraintStmt)
+		writeRepairSolution(out, phase, "redefine the constraint", dropConstraintStmt, createConstraintStmt)
 		return true
 	}, func(additional []schemas.ConstraintDescription) bool {
 		for _, constraint := range additional {
 			dropConstraintStmt := fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", expectedTable.Name, constraint.Name)
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Unexpected constraint %q.%q", expectedTable.Name, constraint.Name)))
-			writeSQLSolution(out, "drop the constraint", dropConstraintStmt)
+			writeRepairSolution(out, repairPhaseDestructive, "drop the constraint", dropConstraintStmt)
 		}
 
 		return true
@@ -240,32 +278,24 @@ func compareConstraints(out *preambledOutput, actualTable, expectedTable schemas
 
 func compareIndexes(out *preambledOutput, actualTable, expectedTable schemas.TableDescription) bool {
 	return compareNamedLists(actualTable.Indexes, expectedTable.Indexes, func(index *schemas.IndexDescription, expectedIndex schemas.IndexDescription) bool {
-		var createIndexStmt string
-		switch expectedIndex.ConstraintType {
-		case "u":
-			fallthrough
-		case "p":
-			createIndexStmt = fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s;", actualTable.Name, expectedIndex.Name, expectedIndex.ConstraintDefinition)
-		default:
-			createIndexStmt = fmt.Sprintf("%s;", expectedIndex.IndexDefinition)
-		}
+		phase, createIndexStmt := makeCreateIndexStatement(expectedTable.Name, expectedIndex)
 
 		if index == nil {
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Missing index %q.%q", expectedTable.Name, expectedIndex.Name)))
-			writeSQLSolution(out, "define the index", createIndexStmt)
+			writeRepairSolution(out, phase, "define the index", createIndexStmt)
 			return true
 		}
 
-		dropIndexStmt := fmt.Sprintf("DROP INDEX %s;", expectedIndex.Name)
+		dropIndexStmt := makeDropIndexStatement(expectedTable.Name, *index)
 		out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Unexpected properties of index %q.%q", expectedTable.Name, expectedIndex.Name)))
 		writeDiff(out, expectedIndex, *index)
-		writeSQLSolution(out, "redefine the index", dropIndexStmt, createIndexStmt)
+		writeRepairSolution(out, phase, "redefine the index", dropIndexStmt, createIndexStmt)
 		return true
 	}, func(additional []schemas.IndexDescription) bool {
 		for _, index := range additional {
-			dropIndexStmt := fmt.Sprintf("DROP INDEX %s;", index.Name)
+			dropIndexStmt := makeDropIndexStatement(expectedTable.Name, index)
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Unexpected index %q.%q", expectedTable.Name, index.Name)))
-			writeSQLSolution(out, "drop the index", dropIndexStmt)
+			writeRepairSolution(out, repairPhaseDestructive, "drop the index", dropIndexStmt)
 		}
 
 		return true
@@ -279,19 +309,19 @@ func compareTriggers(out *preambledOutput, actualTable, expectedTable schemas.Ta
 
 		if trigger == nil {
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Missing trigger %q.%q", expectedTable.Name, expectedTrigger.Name)))
-			writeSQLSolution(out, "define the trigger", createTriggerStmt)
+			writeRepairSolution(out, repairPhaseTriggers, "define the trigger", createTriggerStmt)
 			return true
 		}
 
 		out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Unexpected properties of trigger %q.%q", expectedTable.Name, expectedTrigger.Name)))
 		writeDiff(out, expectedTrigger, *trigger)
-		writeSQLSolution(out, "redefine the trigger", dropTriggerStmt, createTriggerStmt)
+		writeRepairSolution(out, repairPhaseTriggers, "redefine the trigger", dropTriggerStmt, createTriggerStmt)
 		return true
 	}, func(additional []schemas.TriggerDescription) bool {
 		for _, trigger := range additional {
 			dropTriggerStmt := fmt.Sprintf("DROP TRIGGER %s ON %s;", trigger.Name, expectedTable.Name)
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Unexpected trigger %q.%q", expectedTable.Name, trigger.Name)))
-			writeSQLSolution(out, "drop the trigger", dropTriggerStmt)
+			writeRepairSolution(out, repairPhaseDestructive, "drop the trigger", dropTriggerStmt)
 		}
 
 		return true
@@ -302,8 +332,8 @@ func compareTriggers(out *preambledOutput, actualTable, expectedTable schemas.Ta
 func compareTableComments(out *preambledOutput, actualTable, expectedTable schemas.TableDescription) bool {
 	if actualTable.Comment != expectedTable.Comment {
 		out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Unexpected comment of table %q", expectedTable.Name)))
-		setDefaultStmt := fmt.Sprintf("COMMENT ON TABLE %s IS '%s';", expectedTable.Name, expectedTable.Comment)
-		writeSQLSolution(out, "change the table comment", setDefaultStmt)
+		setCommentStmt := makeTableCommentStatement(expectedTable.Name, expectedTable.Comment)
+		writeRepairSolution(out, repairPhaseComments, "change the table comment", setCommentStmt)
 		return true
 	}
 
@@ -312,24 +342,37 @@ func compareTableComments(out *preambledOutput, actualTable, expectedTable schem
 
 func compareViews(out *preambledOutput, schemaName, version string, actual, expected schemas.SchemaDescription) bool {
 	return compareNamedLists(actual.Views, expected.Views, func(view *schemas.ViewDescription, expectedView schemas.ViewDescription) bool {
-		// pgsql has weird indents here
-		viewDefinition := strings.TrimSpace(stripIndent(" " + expectedView.Definition))
-		createViewStmt := fmt.Sprintf("CREATE VIEW %s AS %s", expectedView.Name, viewDefinition)
-		dropViewStmt := fmt.Sprintf("DROP VIEW %s;", expectedView.Name)
+		createViewStmt := makeCreateViewStatement(expectedView)
 
 		if view == nil {
 			out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Missing view %q", expectedView.Name)))
 			writeSQLSolution(out, "define the view", createViewStmt)
+			out.plan.rebuildView(expectedView.Name, createViewStmt)
 			return true
 		}
 
 		out.WriteLine(output.Line(output.EmojiFailure, output.StyleBold, fmt.Sprintf("Unexpected definition of view %q", expectedView.Name)))
 		writeDiff(out, expectedView.Definition, view.Definition)
-		writeSQLSolution(out, "redefine the view", dropViewStmt, createViewStmt)
+		writeSQLSolution(out, "redefine the view", makeDropViewStatement(expectedView.Name), createViewStmt)
+		out.plan.rebuildView(expectedView.Name, createViewStmt)
 		return true
 	}, noopAdditionalCallback[schemas.ViewDescription])
 }
 
+// viewsReferencingTable returns the views whose definition mentions the given table.
+func viewsReferencingTable(views []schemas.ViewDescription, tableName string) []schemas.ViewDescription {
+	pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(tableName) + `\b`)
+
+	var referencing []schemas.ViewDescription
+	for _, view := range views {
+		if pattern.MatchString(view.Definition) {
+			referencing = append(referencing, view)
+		}
+	}
+
+	return referencing
+}
+
 func noopAdditionalCallback[T schemas.Namer](_ []T) bool {
 	return false
 }
@@ -346,6 +389,13 @@ func writeSQLSolution(out *preambledOutput, description string, statements ...st
 	out.WriteCode("sql", strings.Join(statements, "\n"))
 }
 
+// writeRepairSolution writes the given solution as by writeSQLSolution and records the
+// given statements in the repair plan.
+func writeRepairSolution(out *preambledOutput, phase repairPhase, description string, statements ...string) {
+	writeSQLSolution(out, description, statements...)
+	out.plan.add(phase, statements...)
+}
+
 // writeSearchHint writes a block of text containing the given hint description and
 // a link to a set of Sourcegraph search results relevant to the missing or unexpected
 // object definition.
@@ -575,6 +625,7 @@ func stripIndent(s string) string {
 
 type preambledOutput struct {
 	out     *output.Output
+	plan    *repairPlan
 	emitted bool
 }
 
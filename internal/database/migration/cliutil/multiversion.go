@@ -325,7 +325,7 @@ func checkDrift(ctx context.Context, r Runner, version string, out *output.Outpu
 		if err != nil {
 			return err
 		}
-		if err := compareSchemaDescriptions(noopOutput, schemaName, version, canonicalize(schema), canonicalize(expectedSchema)); err != nil {
+		if _, err := compareSchemaDescriptions(noopOutput, schemaName, version, canonicalize(schema), canonicalize(expectedSchema)); err != nil {
 			schemasWithDrift = append(schemasWithDrift, schemaName)
 		}
 	}
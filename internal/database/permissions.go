@@ -38,6 +38,10 @@ type PermissionStore interface {
 	GetByID(ctx context.Context, opts GetPermissionOpts) (*types.Permission, error)
 	// List returns all the permissions in the database.
 	List(ctx context.Context) ([]*types.Permission, error)
+	// ListForRole returns the permissions granted to the role with the provided ID.
+	ListForRole(ctx context.Context, roleID int32) ([]*types.Permission, error)
+	// UserHasPermission returns whether any of the roles assigned to a user grants the given permission.
+	UserHasPermission(ctx context.Context, opts UserHasPermissionOpts) (bool, error)
 }
 
 type CreatePermissionOpts struct {
@@ -45,6 +49,12 @@ type CreatePermissionOpts struct {
 	Action    string
 }
 
+type UserHasPermissionOpts struct {
+	UserID    int32
+	Namespace string
+	Action    string
+}
+
 type PermissionOpts struct {
 	ID int32
 }
@@ -216,3 +226,61 @@ func (p *permissionStore) List(ctx context.Context) ([]*types.Permission, error)
 
 	return permissions, rows.Err()
 }
+
+const permissionListForRoleQueryFmtStr = `
+SELECT %s FROM permissions
+JOIN role_permissions ON role_permissions.permission_id = permissions.id
+WHERE role_permissions.role_id = %s
+ORDER BY permissions.namespace ASC, permissions.action ASC
+`
+
+func (p *permissionStore) ListForRole(ctx context.Context, roleID int32) ([]*types.Permission, error) {
+	if roleID == 0 {
+		return nil, errors.New("missing role id")
+	}
+
+	rows, err := p.Query(ctx, sqlf.Sprintf(permissionListForRoleQueryFmtStr, sqlf.Join(permissionColumns, ", "), roleID))
+	if err != nil {
+		return nil, errors.Wrap(err, "error running query")
+	}
+
+	var permissions []*types.Permission
+	defer rows.Close()
+	for rows.Next() {
+		perm, err := scanPermission(rows)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, perm)
+	}
+
+	return permissions, rows.Err()
+}
+
+const userHasPermissionQueryFmtStr = `
+SELECT EXISTS (
+	SELECT 1
+	FROM user_roles
+	JOIN roles ON roles.id = user_roles.role_id AND roles.deleted_at IS NULL
+	JOIN role_permissions ON role_permissions.role_id = roles.id
+	JOIN permissions ON permissions.id = role_permissions.permission_id
+	WHERE
+		user_roles.user_id = %s AND
+		permissions.namespace = %s AND
+		permissions.action = %s
+)
+`
+
+func (p *permissionStore) UserHasPermission(ctx context.Context, opts UserHasPermissionOpts) (bool, error) {
+	if opts.UserID == 0 {
+		return false, errors.New("missing user id")
+	}
+
+	has, _, err := basestore.ScanFirstBool(p.Query(ctx, sqlf.Sprintf(
+		userHasPermissionQueryFmtStr,
+		opts.UserID,
+		opts.Namespace,
+		opts.Action,
+	)))
+	return has, err
+}
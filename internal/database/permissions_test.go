@@ -71,6 +71,10 @@ func TestPermissionList(t *testing.T) {
 	db := NewDB(logger, dbtest.NewDB(logger, t))
 	store := db.Permissions()
 
+	// Some permissions are created by migrations.
+	systemPerms, err := store.List(ctx)
+	assert.NoError(t, err)
+
 	totalPerms := 10
 	for i := 1; i <= totalPerms; i++ {
 		_, err := store.Create(ctx, CreatePermissionOpts{
@@ -82,7 +86,7 @@ func TestPermissionList(t *testing.T) {
 
 	ps, err := store.List(ctx)
 	assert.NoError(t, err)
-	assert.Len(t, ps, totalPerms)
+	assert.Len(t, ps, len(systemPerms)+totalPerms)
 }
 
 func TestPermissionDelete(t *testing.T) {
@@ -148,3 +152,66 @@ func TestPermissionBulkCreate(t *testing.T) {
 	assert.NotNil(t, ps)
 	assert.Len(t, ps, 5)
 }
+
+func TestPermissionListForRole(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	store := db.Permissions()
+
+	role, err := db.Roles().Create(ctx, "TEST-ROLE", false)
+	assert.NoError(t, err)
+
+	ps, err := store.ListForRole(ctx, role.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, ps)
+
+	p, err := store.Create(ctx, CreatePermissionOpts{Namespace: "BATCHCHANGES", Action: "READ"})
+	assert.NoError(t, err)
+	_, err = db.Handle().ExecContext(ctx, "INSERT INTO role_permissions (role_id, permission_id) VALUES ($1, $2)", role.ID, p.ID)
+	assert.NoError(t, err)
+
+	ps, err = store.ListForRole(ctx, role.ID)
+	assert.NoError(t, err)
+	assert.Len(t, ps, 1)
+	assert.Equal(t, p.ID, ps[0].ID)
+}
+
+func TestPermissionUserHasPermission(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	store := db.Permissions()
+
+	user, role := createUserAndRole(ctx, t, db)
+	p, err := store.Create(ctx, CreatePermissionOpts{Namespace: "BATCHCHANGES", Action: "READ"})
+	assert.NoError(t, err)
+	_, err = db.Handle().ExecContext(ctx, "INSERT INTO role_permissions (role_id, permission_id) VALUES ($1, $2)", role.ID, p.ID)
+	assert.NoError(t, err)
+
+	opts := UserHasPermissionOpts{UserID: user.ID, Namespace: "BATCHCHANGES", Action: "READ"}
+
+	// The role isn't assigned to the user yet.
+	has, err := store.UserHasPermission(ctx, opts)
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	_, err = db.UserRoles().Create(ctx, CreateUserRoleOpts{UserID: user.ID, RoleID: role.ID})
+	assert.NoError(t, err)
+
+	has, err = store.UserHasPermission(ctx, opts)
+	assert.NoError(t, err)
+	assert.True(t, has)
+
+	has, err = store.UserHasPermission(ctx, UserHasPermissionOpts{UserID: user.ID, Namespace: "BATCHCHANGES", Action: "WRITE"})
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	// Deleted roles don't grant permissions.
+	assert.NoError(t, db.Roles().Delete(ctx, DeleteRoleOpts{ID: role.ID}))
+	has, err = store.UserHasPermission(ctx, opts)
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
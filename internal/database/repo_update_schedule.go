@@ -0,0 +1,404 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RepoUpdateScheduleStore persists the state of the repo-updater git update
+// scheduler, so that it survives restarts and can be shared between replicas.
+type RepoUpdateScheduleStore interface {
+	basestore.ShareableStore
+
+	With(other basestore.ShareableStore) RepoUpdateScheduleStore
+
+	// List returns the persisted schedule of all repositories that are not
+	// deleted, ordered by their due time. Repositories that are only queued
+	// for an update come last.
+	List(ctx context.Context) ([]*RepoUpdateScheduleEntry, error)
+
+	// GetPosition returns the persisted schedule of the given repository along
+	// with its position in the schedule and the update queue. It returns nil if
+	// the repository is neither scheduled nor queued for an update.
+	GetPosition(ctx context.Context, id api.RepoID) (*RepoUpdateSchedulePosition, error)
+
+	// Upsert inserts or replaces the persisted schedule of the given
+	// repositories.
+	Upsert(ctx context.Context, entries ...*RepoUpdateScheduleEntry) error
+
+	// Delete removes the given repositories from the persisted schedule.
+	Delete(ctx context.Context, ids ...api.RepoID) error
+
+	// CreateRequests records schedule changes for the scheduler leader to
+	// apply.
+	CreateRequests(ctx context.Context, requests ...*RepoUpdateScheduleRequest) error
+
+	// ClaimRequests deletes and returns up to limit of the oldest pending
+	// schedule requests, in the order they were created.
+	ClaimRequests(ctx context.Context, limit int) ([]*RepoUpdateScheduleRequest, error)
+
+	// AcquireLease takes or renews the scheduler leader lease for holder until
+	// ttl from now. It returns false if the lease is held by another holder and
+	// has not expired.
+	AcquireLease(ctx context.Context, holder string, ttl time.Duration) (bool, error)
+
+	// ReleaseLease gives up the scheduler leader lease if it is held by holder.
+	ReleaseLease(ctx context.Context, holder string) error
+}
+
+// RepoUpdateScheduleEntry is the persisted update schedule of a single
+// repository.
+type RepoUpdateScheduleEntry struct {
+	RepoID   api.RepoID
+	RepoName api.RepoName // read-only, populated by List and GetPosition
+
+	// Interval and Due are zero if the repository is queued for an update
+	// but not scheduled.
+	Interval time.Duration
+	Due      time.Time
+
+	// QueuePriority is the priority of the repository in the update queue, or
+	// nil if it is not queued.
+	QueuePriority *int
+	// QueueSeq orders queued repositories with the same priority.
+	QueueSeq int64
+}
+
+// RepoUpdateSchedulePosition is the position of a repository in the persisted
+// schedule and update queue.
+type RepoUpdateSchedulePosition struct {
+	RepoUpdateScheduleEntry
+
+	ScheduleIndex int
+	ScheduleTotal int
+	QueueIndex    int
+	QueueTotal    int
+}
+
+// RepoUpdateScheduleAction is the kind of change a RepoUpdateScheduleRequest
+// makes to the schedule.
+type RepoUpdateScheduleAction string
+
+const (
+	// RepoUpdateScheduleActionUpsert adds the repository to the schedule, or
+	// updates it if it is already scheduled.
+	RepoUpdateScheduleActionUpsert RepoUpdateScheduleAction = "upsert"
+	// RepoUpdateScheduleActionRemove removes the repository from the schedule
+	// and the update queue.
+	RepoUpdateScheduleActionRemove RepoUpdateScheduleAction = "remove"
+	// RepoUpdateScheduleActionUpdate queues a single update of the repository
+	// without changing its schedule.
+	RepoUpdateScheduleActionUpdate RepoUpdateScheduleAction = "update"
+)
+
+// RepoUpdateScheduleRequest is a change to the update schedule that was
+// received by a repo-updater replica that is not the scheduler leader.
+type RepoUpdateScheduleRequest struct {
+	RepoID   api.RepoID
+	RepoName api.RepoName // read-only, populated by ClaimRequests
+	Action   RepoUpdateScheduleAction
+
+	// Priority is the priority the repository should be queued for an update
+	// with, or nil if it should not be queued.
+	Priority *int
+}
+
+var _ RepoUpdateScheduleStore = (*repoUpdateScheduleStore)(nil)
+
+type repoUpdateScheduleStore struct {
+	*basestore.Store
+}
+
+// RepoUpdateScheduleWith instantiates and returns a new RepoUpdateScheduleStore
+// using the other store handle.
+func RepoUpdateScheduleWith(other basestore.ShareableStore) RepoUpdateScheduleStore {
+	return &repoUpdateScheduleStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *repoUpdateScheduleStore) With(other basestore.ShareableStore) RepoUpdateScheduleStore {
+	return &repoUpdateScheduleStore{Store: s.Store.With(other)}
+}
+
+func (s *repoUpdateScheduleStore) Transact(ctx context.Context) (*repoUpdateScheduleStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &repoUpdateScheduleStore{Store: txBase}, err
+}
+
+func (s *repoUpdateScheduleStore) List(ctx context.Context) ([]*RepoUpdateScheduleEntry, error) {
+	return scanRepoUpdateScheduleEntries(s.Query(ctx, sqlf.Sprintf(listRepoUpdateScheduleQuery)))
+}
+
+var scanRepoUpdateScheduleEntries = basestore.NewSliceScanner(func(sc dbutil.Scanner) (*RepoUpdateScheduleEntry, error) {
+	var e RepoUpdateScheduleEntry
+	err := scanRepoUpdateScheduleEntry(sc, &e)
+	return &e, err
+})
+
+func scanRepoUpdateScheduleEntry(sc dbutil.Scanner, e *RepoUpdateScheduleEntry, extra ...any) error {
+	var (
+		intervalSeconds sql.NullInt32
+		due             sql.NullTime
+		queuePriority   sql.NullInt32
+		queueSeq        sql.NullInt64
+	)
+	err := sc.Scan(append([]any{
+		&e.RepoID,
+		&e.RepoName,
+		&intervalSeconds,
+		&due,
+		&queuePriority,
+		&queueSeq,
+	}, extra...)...)
+	if err != nil {
+		return err
+	}
+
+	if due.Valid {
+		e.Interval = time.Duration(intervalSeconds.Int32) * time.Second
+		e.Due = due.Time
+	}
+	if queuePriority.Valid {
+		p := int(queuePriority.Int32)
+		e.QueuePriority = &p
+		e.QueueSeq = queueSeq.Int64
+	}
+	return nil
+}
+
+const listRepoUpdateScheduleQuery = `
+SELECT
+	rus.repo_id,
+	repo.name,
+	rus.interval_seconds,
+	rus.due_at,
+	rus.queue_priority,
+	rus.queue_seq
+FROM repo_update_schedule rus
+JOIN repo ON repo.id = rus.repo_id
+WHERE repo.deleted_at IS NULL
+ORDER BY rus.due_at, rus.repo_id
+`
+
+func (s *repoUpdateScheduleStore) GetPosition(ctx context.Context, id api.RepoID) (*RepoUpdateSchedulePosition, error) {
+	var p RepoUpdateSchedulePosition
+	err := scanRepoUpdateScheduleEntry(
+		s.QueryRow(ctx, sqlf.Sprintf(getRepoUpdateSchedulePositionQuery, id)),
+		&p.RepoUpdateScheduleEntry,
+		&p.ScheduleIndex,
+		&p.ScheduleTotal,
+		&p.QueueIndex,
+		&p.QueueTotal,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+const getRepoUpdateSchedulePositionQuery = `
+WITH schedule AS (
+	SELECT rus.*
+	FROM repo_update_schedule rus
+	JOIN repo ON repo.id = rus.repo_id
+	WHERE repo.deleted_at IS NULL
+)
+SELECT
+	t.repo_id,
+	repo.name,
+	t.interval_seconds,
+	t.due_at,
+	t.queue_priority,
+	t.queue_seq,
+	(SELECT COUNT(*) FROM schedule s WHERE (s.due_at, s.repo_id) < (t.due_at, t.repo_id)) AS schedule_index,
+	(SELECT COUNT(*) FROM schedule WHERE due_at IS NOT NULL) AS schedule_total,
+	(
+		SELECT COUNT(*) FROM schedule s
+		WHERE
+			t.queue_priority IS NOT NULL AND
+			s.queue_priority IS NOT NULL AND
+			(s.queue_priority > t.queue_priority OR (s.queue_priority = t.queue_priority AND s.queue_seq < t.queue_seq))
+	) AS queue_index,
+	(SELECT COUNT(*) FROM schedule WHERE queue_priority IS NOT NULL) AS queue_total
+FROM schedule t
+JOIN repo ON repo.id = t.repo_id
+WHERE t.repo_id = %s
+`
+
+func (s *repoUpdateScheduleStore) Upsert(ctx context.Context, entries ...*RepoUpdateScheduleEntry) (err error) {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(upsertRepoUpdateScheduleCreateTempTableQuery)); err != nil {
+		return err
+	}
+
+	inserter := batch.NewInserter(ctx, tx.Handle(), "temp_repo_update_schedule", batch.MaxNumPostgresParameters, repoUpdateScheduleColumns...)
+	for _, e := range entries {
+		var (
+			intervalSeconds *int
+			due             *time.Time
+			queueSeq        *int64
+		)
+		if !e.Due.IsZero() {
+			seconds := int(e.Interval / time.Second)
+			intervalSeconds, due = &seconds, &e.Due
+		}
+		if e.QueuePriority != nil {
+			queueSeq = &e.QueueSeq
+		}
+		if err := inserter.Insert(ctx, e.RepoID, intervalSeconds, due, e.QueuePriority, queueSeq); err != nil {
+			return err
+		}
+	}
+	if err := inserter.Flush(ctx); err != nil {
+		return err
+	}
+
+	return errors.Wrap(tx.Exec(ctx, sqlf.Sprintf(upsertRepoUpdateScheduleQuery)), "upserting repo update schedule")
+}
+
+var repoUpdateScheduleColumns = []string{
+	"repo_id",
+	"interval_seconds",
+	"due_at",
+	"queue_priority",
+	"queue_seq",
+}
+
+const upsertRepoUpdateScheduleCreateTempTableQuery = `
+CREATE TEMPORARY TABLE temp_repo_update_schedule (
+	repo_id          integer NOT NULL,
+	interval_seconds integer,
+	due_at           timestamp with time zone,
+	queue_priority   integer,
+	queue_seq        bigint
+) ON COMMIT DROP
+`
+
+// The join with repo skips repositories that were hard deleted since they
+// were scheduled, which would otherwise violate the foreign key.
+const upsertRepoUpdateScheduleQuery = `
+INSERT INTO repo_update_schedule (repo_id, interval_seconds, due_at, queue_priority, queue_seq, updated_at)
+SELECT source.repo_id, source.interval_seconds, source.due_at, source.queue_priority, source.queue_seq, now()
+FROM temp_repo_update_schedule source
+JOIN repo ON repo.id = source.repo_id
+ON CONFLICT (repo_id) DO UPDATE SET
+	interval_seconds = EXCLUDED.interval_seconds,
+	due_at           = EXCLUDED.due_at,
+	queue_priority   = EXCLUDED.queue_priority,
+	queue_seq        = EXCLUDED.queue_seq,
+	updated_at       = EXCLUDED.updated_at
+`
+
+func (s *repoUpdateScheduleStore) Delete(ctx context.Context, ids ...api.RepoID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.Exec(ctx, sqlf.Sprintf(deleteRepoUpdateScheduleQuery, pq.Array(ids)))
+}
+
+const deleteRepoUpdateScheduleQuery = `
+DELETE FROM repo_update_schedule WHERE repo_id = ANY(%s)
+`
+
+func (s *repoUpdateScheduleStore) CreateRequests(ctx context.Context, requests ...*RepoUpdateScheduleRequest) error {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	values := make(chan []any, len(requests))
+	for _, r := range requests {
+		values <- []any{r.RepoID, string(r.Action), r.Priority}
+	}
+	close(values)
+
+	return batch.InsertValues(
+		ctx,
+		s.Handle(),
+		"repo_update_schedule_requests",
+		batch.MaxNumPostgresParameters,
+		[]string{"repo_id", "action", "priority"},
+		values,
+	)
+}
+
+func (s *repoUpdateScheduleStore) ClaimRequests(ctx context.Context, limit int) ([]*RepoUpdateScheduleRequest, error) {
+	return scanRepoUpdateScheduleRequests(s.Query(ctx, sqlf.Sprintf(claimRepoUpdateScheduleRequestsQuery, limit)))
+}
+
+var scanRepoUpdateScheduleRequests = basestore.NewSliceScanner(func(sc dbutil.Scanner) (*RepoUpdateScheduleRequest, error) {
+	var (
+		r        RepoUpdateScheduleRequest
+		priority sql.NullInt32
+	)
+	if err := sc.Scan(&r.RepoID, &r.RepoName, &r.Action, &priority); err != nil {
+		return nil, err
+	}
+	if priority.Valid {
+		p := int(priority.Int32)
+		r.Priority = &p
+	}
+	return &r, nil
+})
+
+const claimRepoUpdateScheduleRequestsQuery = `
+WITH claimed AS (
+	DELETE FROM repo_update_schedule_requests
+	WHERE id IN (
+		SELECT id FROM repo_update_schedule_requests
+		ORDER BY id
+		LIMIT %s
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING id, repo_id, action, priority
+)
+SELECT c.repo_id, repo.name, c.action, c.priority
+FROM claimed c
+JOIN repo ON repo.id = c.repo_id
+ORDER BY c.id
+`
+
+func (s *repoUpdateScheduleStore) AcquireLease(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	ok, _, err := basestore.ScanFirstBool(s.Query(ctx, sqlf.Sprintf(acquireRepoUpdateSchedulerLeaseQuery, holder, ttl.Seconds())))
+	return ok, err
+}
+
+const acquireRepoUpdateSchedulerLeaseQuery = `
+INSERT INTO repo_update_scheduler_lease (holder, expires_at)
+VALUES (%s, now() + %s * interval '1 second')
+ON CONFLICT (id) DO UPDATE SET
+	holder     = EXCLUDED.holder,
+	expires_at = EXCLUDED.expires_at
+WHERE
+	repo_update_scheduler_lease.holder = EXCLUDED.holder OR
+	repo_update_scheduler_lease.expires_at < now()
+RETURNING true
+`
+
+func (s *repoUpdateScheduleStore) ReleaseLease(ctx context.Context, holder string) error {
+	return s.Exec(ctx, sqlf.Sprintf(releaseRepoUpdateSchedulerLeaseQuery, holder))
+}
+
+const releaseRepoUpdateSchedulerLeaseQuery = `
+DELETE FROM repo_update_scheduler_lease WHERE holder = %s
+`
@@ -0,0 +1,164 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestRepoUpdateSchedule(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	s := db.RepoUpdateSchedule()
+
+	repo1, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo1"})
+	repo2, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo2"})
+	repo3, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo3"})
+	repo4, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo4"})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	high := 1
+	entries := []*RepoUpdateScheduleEntry{
+		{RepoID: repo1.ID, RepoName: repo1.Name, Interval: time.Hour, Due: now.Add(time.Hour)},
+		{RepoID: repo2.ID, RepoName: repo2.Name, Interval: time.Minute, Due: now.Add(time.Minute), QueuePriority: &high, QueueSeq: 3},
+		{RepoID: repo3.ID, RepoName: repo3.Name, Interval: time.Minute, Due: now.Add(2 * time.Minute)},
+		// repo4 is queued for an update without being scheduled.
+		{RepoID: repo4.ID, RepoName: repo4.Name, QueuePriority: &high, QueueSeq: 5},
+	}
+	if err := s.Upsert(ctx, entries...); err != nil {
+		t.Fatal(err)
+	}
+
+	// Upserting again replaces the existing schedule.
+	entries[2].QueuePriority = &high
+	entries[2].QueueSeq = 4
+	if err := s.Upsert(ctx, entries[2]); err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := s.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*RepoUpdateScheduleEntry{entries[1], entries[2], entries[0], entries[3]}
+	if diff := cmp.Diff(want, have, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Fatalf("unexpected schedule (-want +got):\n%s", diff)
+	}
+
+	pos, err := s.GetPosition(ctx, repo3.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pos.ScheduleIndex != 1 || pos.ScheduleTotal != 3 || pos.QueueIndex != 1 || pos.QueueTotal != 3 {
+		t.Fatalf("unexpected position: %+v", pos)
+	}
+
+	pos, err = s.GetPosition(ctx, repo4.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pos.Due.IsZero() || pos.QueueIndex != 2 || pos.QueueTotal != 3 {
+		t.Fatalf("unexpected position: %+v", pos)
+	}
+
+	if err := s.Delete(ctx, repo1.ID); err != nil {
+		t.Fatal(err)
+	}
+	if pos, err := s.GetPosition(ctx, repo1.ID); err != nil || pos != nil {
+		t.Fatalf("expected no position for deleted entry, got %+v, %v", pos, err)
+	}
+}
+
+func TestRepoUpdateScheduleRequests(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	s := db.RepoUpdateSchedule()
+
+	repo1, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo1"})
+	repo2, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo2"})
+
+	high := 1
+	requests := []*RepoUpdateScheduleRequest{
+		{RepoID: repo1.ID, Action: RepoUpdateScheduleActionUpsert},
+		{RepoID: repo2.ID, Action: RepoUpdateScheduleActionUpdate, Priority: &high},
+		{RepoID: repo1.ID, Action: RepoUpdateScheduleActionRemove},
+	}
+	if err := s.CreateRequests(ctx, requests...); err != nil {
+		t.Fatal(err)
+	}
+
+	claimed, err := s.ClaimRequests(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*RepoUpdateScheduleRequest{
+		{RepoID: repo1.ID, RepoName: repo1.Name, Action: RepoUpdateScheduleActionUpsert},
+		{RepoID: repo2.ID, RepoName: repo2.Name, Action: RepoUpdateScheduleActionUpdate, Priority: &high},
+	}
+	if diff := cmp.Diff(want, claimed); diff != "" {
+		t.Fatalf("unexpected claimed requests (-want +got):\n%s", diff)
+	}
+
+	claimed, err = s.ClaimRequests(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 1 || claimed[0].RepoID != repo1.ID || claimed[0].Action != RepoUpdateScheduleActionRemove {
+		t.Fatalf("unexpected claimed requests: %+v", claimed)
+	}
+
+}
+
+func TestRepoUpdateSchedulerLease(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	s := db.RepoUpdateSchedule()
+
+	acquire := func(holder string, ttl time.Duration, want bool) {
+		t.Helper()
+		ok, err := s.AcquireLease(ctx, holder, ttl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != want {
+			t.Fatalf("AcquireLease(%q) = %v, want %v", holder, ok, want)
+		}
+	}
+
+	acquire("a", time.Minute, true)
+	acquire("b", time.Minute, false)
+	// The holder renews its own lease.
+	acquire("a", -time.Minute, true)
+	// An expired lease can be taken over.
+	acquire("b", time.Minute, true)
+	acquire("a", time.Minute, false)
+
+	if err := s.ReleaseLease(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	acquire("a", time.Minute, false)
+	if err := s.ReleaseLease(ctx, "b"); err != nil {
+		t.Fatal(err)
+	}
+	acquire("a", time.Minute, true)
+}
@@ -71,8 +71,11 @@ func TestRoleList(t *testing.T) {
 		})
 		assert.NoError(t, err)
 		assert.Len(t, roles, 2)
-		assert.Equal(t, roles[0].ID, int32(2))
-		assert.Equal(t, roles[1].ID, int32(3))
+
+		allRoles, err := store.List(ctx, RolesListOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, roles[0].ID, allRoles[1].ID)
+		assert.Equal(t, roles[1].ID, allRoles[2].ID)
 	})
 }
 
@@ -164,15 +167,20 @@ func TestRoleDelete(t *testing.T) {
 	})
 }
 
+// createTestRoles creates test roles and returns the total number of roles,
+// including the system roles created by migrations.
 func createTestRoles(ctx context.Context, t *testing.T, store RoleStore) int {
 	t.Helper()
+	systemRoles, err := store.Count(ctx, RolesListOptions{})
+	assert.NoError(t, err)
+
 	totalRoles := 10
 	name := "TESTROLE"
 	for i := 1; i <= totalRoles; i++ {
 		_, err := createTestRole(ctx, fmt.Sprintf("%s-%d", name, i), false, t, store)
 		assert.NoError(t, err)
 	}
-	return totalRoles
+	return systemRoles + totalRoles
 }
 
 func createTestRole(ctx context.Context, name string, readonly bool, t *testing.T, store RoleStore) (*types.Role, error) {
@@ -3,12 +3,16 @@ package database
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	otlog "github.com/opentracing/opentracing-go/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -16,18 +20,38 @@ import (
 
 type SavedSearchStore interface {
 	Create(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
+	CreateRun(context.Context, *types.SavedSearchRun) (*types.SavedSearchRun, error)
 	Delete(context.Context, int32) error
+	DeleteSchedule(ctx context.Context, savedSearchID int32) error
 	GetByID(context.Context, int32) (*api.SavedQuerySpecAndConfig, error)
+	GetSchedule(ctx context.Context, savedSearchID int32) (*types.SavedSearchSchedule, error)
 	IsEmpty(context.Context) (bool, error)
 	ListAll(context.Context) ([]api.SavedQuerySpecAndConfig, error)
+	ListRuns(ctx context.Context, savedSearchID int32, limit int) ([]*types.SavedSearchRun, error)
 	ListSavedSearchesByOrgID(ctx context.Context, orgID int32) ([]*types.SavedSearch, error)
 	ListSavedSearchesByUserID(ctx context.Context, userID int32) ([]*types.SavedSearch, error)
+	SelectSchedulesForRun(ctx context.Context, limit int) ([]*types.SavedSearchSchedule, error)
 	Transact(context.Context) (SavedSearchStore, error)
 	Update(context.Context, *types.SavedSearch) (*types.SavedSearch, error)
+	UpsertSchedule(context.Context, *types.SavedSearchSchedule) (*types.SavedSearchSchedule, error)
 	With(basestore.ShareableStore) SavedSearchStore
 	basestore.ShareableStore
 }
 
+// SavedSearchScheduleNotFoundErr is returned when a saved search has no
+// schedule.
+type SavedSearchScheduleNotFoundErr struct {
+	savedSearchID int32
+}
+
+func (err SavedSearchScheduleNotFoundErr) Error() string {
+	return fmt.Sprintf("saved search schedule not found: savedSearchID=%d", err.savedSearchID)
+}
+
+func (SavedSearchScheduleNotFoundErr) NotFound() bool {
+	return true
+}
+
 type savedSearchStore struct {
 	*basestore.Store
 }
@@ -340,3 +364,317 @@ func (s *savedSearchStore) Delete(ctx context.Context, id int32) (err error) {
 	_, err = s.Handle().ExecContext(ctx, `DELETE FROM saved_searches WHERE ID=$1`, id)
 	return err
 }
+
+const savedSearchScheduleColumns = `
+	saved_search_id,
+	user_id,
+	interval_minutes,
+	notify_email,
+	slack_webhook_url,
+	webhook_url,
+	next_run_at,
+	created_at,
+	updated_at
+`
+
+func scanSavedSearchSchedule(sc dbutil.Scanner) (*types.SavedSearchSchedule, error) {
+	var (
+		s               types.SavedSearchSchedule
+		intervalMinutes int
+	)
+	if err := sc.Scan(
+		&s.SavedSearchID,
+		&s.UserID,
+		&intervalMinutes,
+		&s.NotifyEmail,
+		&s.SlackWebhookURL,
+		&s.WebhookURL,
+		&s.NextRunAt,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	s.Interval = time.Duration(intervalMinutes) * time.Minute
+	return &s, nil
+}
+
+// GetSchedule returns the schedule of the saved search with the given ID. A
+// SavedSearchScheduleNotFoundErr is returned if the saved search is not
+// scheduled.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure this response
+// only makes it to users with proper permissions to access the saved search.
+func (s *savedSearchStore) GetSchedule(ctx context.Context, savedSearchID int32) (*types.SavedSearchSchedule, error) {
+	q := sqlf.Sprintf(`SELECT `+savedSearchScheduleColumns+` FROM saved_search_schedules WHERE saved_search_id = %s`, savedSearchID)
+	schedule, err := scanSavedSearchSchedule(s.QueryRow(ctx, q))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, SavedSearchScheduleNotFoundErr{savedSearchID: savedSearchID}
+		}
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// UpsertSchedule creates or updates the schedule of a saved search. A new
+// schedule runs as soon as possible to record the initial results; an updated
+// schedule runs no later than one new interval from now.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the user has
+// proper permissions to schedule the saved search, and that schedule.UserID
+// may see its results.
+func (s *savedSearchStore) UpsertSchedule(ctx context.Context, schedule *types.SavedSearchSchedule) (_ *types.SavedSearchSchedule, err error) {
+	tr, ctx := trace.New(ctx, "database.SavedSearches.UpsertSchedule", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	intervalMinutes := int(schedule.Interval / time.Minute)
+	if intervalMinutes < 1 {
+		return nil, errors.New("schedule interval must be at least one minute")
+	}
+
+	q := sqlf.Sprintf(`
+		INSERT INTO saved_search_schedules (
+			saved_search_id,
+			user_id,
+			interval_minutes,
+			notify_email,
+			slack_webhook_url,
+			webhook_url
+		) VALUES (%s, %s, %s, %s, %s, %s)
+		ON CONFLICT (saved_search_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			interval_minutes = EXCLUDED.interval_minutes,
+			notify_email = EXCLUDED.notify_email,
+			slack_webhook_url = EXCLUDED.slack_webhook_url,
+			webhook_url = EXCLUDED.webhook_url,
+			next_run_at = LEAST(saved_search_schedules.next_run_at, NOW() + (EXCLUDED.interval_minutes * interval '1 minute')),
+			updated_at = NOW()
+		RETURNING `+savedSearchScheduleColumns,
+		schedule.SavedSearchID,
+		schedule.UserID,
+		intervalMinutes,
+		schedule.NotifyEmail,
+		schedule.SlackWebhookURL,
+		schedule.WebhookURL,
+	)
+	return scanSavedSearchSchedule(s.QueryRow(ctx, q))
+}
+
+// DeleteSchedule stops running the saved search with the given ID
+// periodically. The results of previous runs are kept.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the user has
+// proper permissions to unschedule the saved search.
+func (s *savedSearchStore) DeleteSchedule(ctx context.Context, savedSearchID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(`DELETE FROM saved_search_schedules WHERE saved_search_id = %s`, savedSearchID))
+}
+
+const selectSchedulesForRunFmtStr = `
+WITH candidates AS (
+	SELECT saved_search_id
+	FROM saved_search_schedules
+	WHERE next_run_at <= NOW()
+	ORDER BY next_run_at
+	LIMIT %s
+	FOR UPDATE SKIP LOCKED
+)
+UPDATE saved_search_schedules s
+SET next_run_at = NOW() + (s.interval_minutes * interval '1 minute')
+FROM candidates c
+WHERE s.saved_search_id = c.saved_search_id
+RETURNING
+	s.saved_search_id,
+	s.user_id,
+	s.interval_minutes,
+	s.notify_email,
+	s.slack_webhook_url,
+	s.webhook_url,
+	s.next_run_at,
+	s.created_at,
+	s.updated_at
+`
+
+// SelectSchedulesForRun returns up to limit schedules that are due to run and
+// moves their next run one interval into the future, so that concurrent
+// callers do not run the same saved search twice.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to run each saved search
+// as the user of its schedule.
+func (s *savedSearchStore) SelectSchedulesForRun(ctx context.Context, limit int) (_ []*types.SavedSearchSchedule, err error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(selectSchedulesForRunFmtStr, limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var schedules []*types.SavedSearchSchedule
+	for rows.Next() {
+		schedule, err := scanSavedSearchSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, nil
+}
+
+// savedSearchRunsRetained is the number of runs kept per saved search.
+const savedSearchRunsRetained = 30
+
+// savedSearchRunChangesRetained is the number of added and removed keys kept
+// per run.
+const savedSearchRunChangesRetained = 100
+
+const createSavedSearchRunFmtStr = `
+WITH inserted AS (
+	INSERT INTO saved_search_runs (
+		saved_search_id,
+		query,
+		permalink_query,
+		ran_at,
+		result_count,
+		limit_hit,
+		fingerprint,
+		added,
+		added_count,
+		removed,
+		removed_count
+	) VALUES (%s, %s, %s, COALESCE(%s, NOW()), %s, %s, %s, %s, %s, %s, %s)
+	RETURNING id, ran_at
+),
+retained AS (
+	SELECT id FROM saved_search_runs
+	WHERE saved_search_id = %s
+	ORDER BY id DESC
+	-- The inserted run is not visible here yet.
+	LIMIT %s
+),
+pruned AS (
+	DELETE FROM saved_search_runs
+	WHERE saved_search_id = %s AND id NOT IN (SELECT id FROM retained)
+),
+cleared AS (
+	-- Only the fingerprint of the latest run is compared to the next run.
+	UPDATE saved_search_runs
+	SET fingerprint = '{}'
+	WHERE id IN (SELECT id FROM retained) AND cardinality(fingerprint) > 0
+)
+SELECT id, ran_at FROM inserted
+`
+
+// CreateRun records the results of a run of a saved search. RanAt defaults to
+// the current time if zero. Only the most recent runs of each saved search are
+// kept, only the latest of them with its fingerprint, and only the first added
+// and removed keys of each run.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the user has
+// proper permissions to run the saved search.
+func (s *savedSearchStore) CreateRun(ctx context.Context, run *types.SavedSearchRun) (_ *types.SavedSearchRun, err error) {
+	tr, ctx := trace.New(ctx, "database.SavedSearches.CreateRun", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	created := *run
+	q := sqlf.Sprintf(createSavedSearchRunFmtStr,
+		run.SavedSearchID,
+		run.Query,
+		run.PermalinkQuery,
+		dbutil.NullTimeColumn(run.RanAt),
+		run.ResultCount,
+		run.LimitHit,
+		pq.Array(nonNilStrings(run.Fingerprint)),
+		pq.Array(truncateStrings(nonNilStrings(run.Added), savedSearchRunChangesRetained)),
+		run.AddedCount,
+		pq.Array(truncateStrings(nonNilStrings(run.Removed), savedSearchRunChangesRetained)),
+		run.RemovedCount,
+		run.SavedSearchID,
+		savedSearchRunsRetained-1,
+		run.SavedSearchID,
+	)
+	if err := s.QueryRow(ctx, q).Scan(&created.ID, &created.RanAt); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// ListRuns returns the most recent runs of the saved search with the given
+// ID, newest first.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure this response
+// only makes it to users with proper permissions to access the saved search.
+func (s *savedSearchStore) ListRuns(ctx context.Context, savedSearchID int32, limit int) (_ []*types.SavedSearchRun, err error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(`
+		SELECT
+			id,
+			saved_search_id,
+			query,
+			permalink_query,
+			ran_at,
+			result_count,
+			limit_hit,
+			fingerprint,
+			added,
+			added_count,
+			removed,
+			removed_count
+		FROM saved_search_runs
+		WHERE saved_search_id = %s
+		ORDER BY id DESC
+		LIMIT %s
+	`, savedSearchID, limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var runs []*types.SavedSearchRun
+	for rows.Next() {
+		var run types.SavedSearchRun
+		if err := rows.Scan(
+			&run.ID,
+			&run.SavedSearchID,
+			&run.Query,
+			&run.PermalinkQuery,
+			&run.RanAt,
+			&run.ResultCount,
+			&run.LimitHit,
+			pq.Array(&run.Fingerprint),
+			pq.Array(&run.Added),
+			&run.AddedCount,
+			pq.Array(&run.Removed),
+			&run.RemovedCount,
+		); err != nil {
+			return nil, err
+		}
+		runs = append(runs, &run)
+	}
+	return runs, nil
+}
+
+// nonNilStrings returns an empty slice for nil, as the run columns are NOT NULL.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// truncateStrings returns the first n elements of s.
+func truncateStrings(s []string, n int) []string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
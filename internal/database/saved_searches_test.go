@@ -2,8 +2,10 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -11,6 +13,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
@@ -374,3 +377,122 @@ func TestListSavedSearchesByUserID(t *testing.T) {
 		t.Errorf("got %v, want %v", savedSearches, want)
 	}
 }
+
+func TestSavedSearchesSchedulesAndRuns(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	user, err := db.Users().Create(ctx, NewUser{DisplayName: "test", Email: "test@test.com", Username: "test", Password: "test", EmailVerificationCode: "c2"})
+	if err != nil {
+		t.Fatal("can't create user", err)
+	}
+	ss, err := db.SavedSearches().Create(ctx, &types.SavedSearch{
+		Query:       "type:repo patternType:literal",
+		Description: "test",
+		UserID:      &user.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.SavedSearches().GetSchedule(ctx, ss.ID); !errcode.IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	webhookURL := "https://example.com/hook"
+	schedule, err := db.SavedSearches().UpsertSchedule(ctx, &types.SavedSearchSchedule{
+		SavedSearchID: ss.ID,
+		UserID:        user.ID,
+		Interval:      time.Hour,
+		NotifyEmail:   true,
+		WebhookURL:    &webhookURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Interval != time.Hour || !schedule.NotifyEmail || schedule.WebhookURL == nil || *schedule.WebhookURL != webhookURL {
+		t.Fatalf("unexpected schedule %+v", schedule)
+	}
+
+	// A new schedule is due immediately, and is claimed only once.
+	due, err := db.SavedSearches().SelectSchedulesForRun(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 1 || due[0].SavedSearchID != ss.ID {
+		t.Fatalf("unexpected due schedules %+v", due)
+	}
+	if !due[0].NextRunAt.After(time.Now().Add(59 * time.Minute)) {
+		t.Fatalf("expected next run to be an interval away, got %s", due[0].NextRunAt)
+	}
+	if due, err := db.SavedSearches().SelectSchedulesForRun(ctx, 10); err != nil {
+		t.Fatal(err)
+	} else if len(due) != 0 {
+		t.Fatalf("expected no due schedules, got %+v", due)
+	}
+
+	// Keep fewer runs than are created.
+	for i := 0; i < savedSearchRunsRetained+2; i++ {
+		if _, err := db.SavedSearches().CreateRun(ctx, &types.SavedSearchRun{
+			SavedSearchID:  ss.ID,
+			Query:          ss.Query,
+			PermalinkQuery: ss.Query,
+			ResultCount:    1,
+			Fingerprint:    []string{"github.com/sourcegraph/sourcegraph"},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runs, err := db.SavedSearches().ListRuns(ctx, ss.ID, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != savedSearchRunsRetained {
+		t.Fatalf("expected %d runs, got %d", savedSearchRunsRetained, len(runs))
+	}
+	if diff := cmp.Diff([]string{"github.com/sourcegraph/sourcegraph"}, runs[0].Fingerprint); diff != "" {
+		t.Fatalf("unexpected fingerprint (-want +got):\n%s", diff)
+	}
+	if len(runs[0].Added) != 0 || len(runs[0].Removed) != 0 {
+		t.Fatalf("unexpected changes in run %+v", runs[0])
+	}
+	if len(runs[1].Fingerprint) != 0 {
+		t.Fatalf("expected fingerprint of older run to be cleared, got %v", runs[1].Fingerprint)
+	}
+
+	// Keep only the first changes of a run, along with their number.
+	added := make([]string, savedSearchRunChangesRetained+1)
+	for i := range added {
+		added[i] = fmt.Sprintf("github.com/sourcegraph/repo%d", i)
+	}
+	if _, err := db.SavedSearches().CreateRun(ctx, &types.SavedSearchRun{
+		SavedSearchID:  ss.ID,
+		Query:          ss.Query,
+		PermalinkQuery: ss.Query,
+		ResultCount:    len(added),
+		Fingerprint:    added,
+		Added:          added,
+		AddedCount:     len(added),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	runs, err = db.SavedSearches().ListRuns(ctx, ss.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs[0].Added) != savedSearchRunChangesRetained || runs[0].AddedCount != len(added) || len(runs[0].Fingerprint) != len(added) {
+		t.Fatalf("unexpected run: %d added keys, added count %d, %d fingerprint keys", len(runs[0].Added), runs[0].AddedCount, len(runs[0].Fingerprint))
+	}
+
+	if err := db.SavedSearches().DeleteSchedule(ctx, ss.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.SavedSearches().GetSchedule(ctx, ss.ID); !errcode.IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
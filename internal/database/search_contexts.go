@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
@@ -44,6 +45,9 @@ type SearchContextsStore interface {
 	GetDefaultSearchContextForCurrentUser(ctx context.Context) (*types.SearchContext, error)
 	CreateSearchContextStarForUser(ctx context.Context, userID int32, searchContextID int64) error
 	DeleteSearchContextStarForUser(ctx context.Context, userID int32, searchContextID int64) error
+	SelectQuerySearchContextsForRepoSync(ctx context.Context, limit int) ([]*types.SearchContext, error)
+	SetSearchContextQueryRepos(ctx context.Context, searchContextID int64, repoIDs []api.RepoID) (added, removed int, err error)
+	ListSearchContextQueryRepoChanges(ctx context.Context, searchContextID int64, limit int) ([]*types.SearchContextRepoChange, error)
 }
 
 type searchContextsStore struct {
@@ -712,3 +716,164 @@ func (s *searchContextsStore) DeleteSearchContextStarForUser(ctx context.Context
 		userID, searchContextID)
 	return s.Exec(ctx, q)
 }
+
+const (
+	// searchContextQueryReposMinInterval is the minimum time between two
+	// evaluations of a query-based search context caused by repository
+	// metadata changes.
+	searchContextQueryReposMinInterval = 5 * time.Minute
+
+	// searchContextQueryReposMaxInterval is the maximum time between two
+	// evaluations of a query-based search context. It bounds how long changes
+	// that don't update the repo row (such as key-value pairs set through the
+	// API) take to be picked up.
+	searchContextQueryReposMaxInterval = time.Hour
+)
+
+const selectQuerySearchContextsForRepoSyncFmtStr = `
+WITH
+last_change AS (
+	SELECT MAX(GREATEST(updated_at, deleted_at)) AS changed_at FROM repo
+),
+candidates AS (
+	SELECT sc.id
+	FROM search_contexts sc, last_change
+	WHERE
+		sc.query IS NOT NULL AND
+		sc.deleted_at IS NULL AND
+		(
+			sc.query_repos_synced_at IS NULL OR
+			(sc.query_repos_synced_at < last_change.changed_at AND sc.query_repos_synced_at < now() - %s * interval '1 second') OR
+			sc.query_repos_synced_at < now() - %s * interval '1 second'
+		)
+	ORDER BY sc.query_repos_synced_at NULLS FIRST, sc.id
+	LIMIT %s
+	FOR UPDATE OF sc SKIP LOCKED
+)
+UPDATE search_contexts
+SET query_repos_synced_at = now()
+WHERE id IN (SELECT id FROM candidates)
+RETURNING id, name, query, namespace_user_id, namespace_org_id
+`
+
+// SelectQuerySearchContextsForRepoSync returns up to limit query-based search
+// contexts whose matched repositories must be re-evaluated, and marks them as
+// evaluated. A search context must be re-evaluated if it was never evaluated,
+// its query changed, or repository metadata changed since it was last
+// evaluated. The check is done here rather than when repositories are
+// written, so that syncing repositories doesn't touch search contexts.
+func (s *searchContextsStore) SelectQuerySearchContextsForRepoSync(ctx context.Context, limit int) (_ []*types.SearchContext, err error) {
+	if a := actor.FromContext(ctx); !a.IsInternal() {
+		return nil, errors.New("SelectQuerySearchContextsForRepoSync can only be accessed by an internal actor")
+	}
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(
+		selectQuerySearchContextsForRepoSyncFmtStr,
+		searchContextQueryReposMinInterval.Seconds(),
+		searchContextQueryReposMaxInterval.Seconds(),
+		limit,
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var searchContexts []*types.SearchContext
+	for rows.Next() {
+		var sc types.SearchContext
+		if err := rows.Scan(
+			&sc.ID,
+			&sc.Name,
+			&sc.Query,
+			&dbutil.NullInt32{N: &sc.NamespaceUserID},
+			&dbutil.NullInt32{N: &sc.NamespaceOrgID},
+		); err != nil {
+			return nil, err
+		}
+		searchContexts = append(searchContexts, &sc)
+	}
+
+	return searchContexts, nil
+}
+
+const setSearchContextQueryReposFmtStr = `
+WITH
+matched AS (
+	SELECT id AS repo_id FROM repo WHERE id = ANY (%s)
+),
+removed AS (
+	DELETE FROM search_context_query_repos
+	WHERE search_context_id = %s AND repo_id NOT IN (SELECT repo_id FROM matched)
+	RETURNING repo_id
+),
+added AS (
+	INSERT INTO search_context_query_repos (search_context_id, repo_id)
+	SELECT %s, repo_id FROM matched
+	ON CONFLICT DO NOTHING
+	RETURNING repo_id
+),
+changes AS (
+	INSERT INTO search_context_query_repo_changes (search_context_id, repo_id, added)
+	SELECT %s, repo_id, true FROM added
+	UNION ALL
+	SELECT %s, repo_id, false FROM removed
+	RETURNING added
+)
+SELECT
+	COUNT(*) FILTER (WHERE added),
+	COUNT(*) FILTER (WHERE NOT added)
+FROM changes
+`
+
+// SetSearchContextQueryRepos replaces the repositories matched by the query of
+// the given search context with the given ones, and records the repositories
+// that were added or removed in the search context's change history.
+func (s *searchContextsStore) SetSearchContextQueryRepos(ctx context.Context, searchContextID int64, repoIDs []api.RepoID) (added, removed int, err error) {
+	if a := actor.FromContext(ctx); !a.IsInternal() {
+		return 0, 0, errors.New("SetSearchContextQueryRepos can only be accessed by an internal actor")
+	}
+
+	q := sqlf.Sprintf(
+		setSearchContextQueryReposFmtStr,
+		pq.Array(repoIDs),
+		searchContextID,
+		searchContextID,
+		searchContextID,
+		searchContextID,
+	)
+
+	err = s.QueryRow(ctx, q).Scan(&added, &removed)
+	return added, removed, err
+}
+
+const listSearchContextQueryRepoChangesFmtStr = `
+SELECT id, search_context_id, repo_id, added, created_at
+FROM search_context_query_repo_changes
+WHERE search_context_id = %s
+ORDER BY id DESC
+LIMIT %s
+`
+
+// ListSearchContextQueryRepoChanges returns the most recent changes to the
+// repositories matched by the query of the given search context, newest first.
+//
+// 🚨 SECURITY: The caller must filter out the repositories the actor does not
+// have access to.
+func (s *searchContextsStore) ListSearchContextQueryRepoChanges(ctx context.Context, searchContextID int64, limit int) (_ []*types.SearchContextRepoChange, err error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listSearchContextQueryRepoChangesFmtStr, searchContextID, limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var changes []*types.SearchContextRepoChange
+	for rows.Next() {
+		var c types.SearchContextRepoChange
+		if err := rows.Scan(&c.ID, &c.SearchContextID, &c.RepoID, &c.Added, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		changes = append(changes, &c)
+	}
+
+	return changes, nil
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/sourcegraph/log/logtest"
 
@@ -1008,6 +1009,126 @@ func TestSearchContexts_GetAllRevisionsForRepos(t *testing.T) {
 	}
 }
 
+func TestSearchContexts_QueryRepoSync(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	t.Parallel()
+	// Required for this DB query.
+	internalCtx := actor.WithInternalActor(context.Background())
+	sc := db.SearchContexts()
+
+	repos := []*types.Repo{
+		{Name: "testA", URI: "https://example.com/a"},
+		{Name: "testB", URI: "https://example.com/b"},
+		{Name: "testC", URI: "https://example.com/c"},
+	}
+	if err := db.Repos().Create(internalCtx, repos...); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	searchContexts, err := createSearchContexts(internalCtx, sc, []*types.SearchContext{
+		{Name: "query", Public: true, Query: "repo:has.tag(github.topic:payments)"},
+		{Name: "static", Public: true},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	queryContext := searchContexts[0]
+
+	assertSelected := func(want []int64) {
+		t.Helper()
+
+		selected, err := sc.SelectQuerySearchContextsForRepoSync(internalCtx, 10)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+		var ids []int64
+		for _, searchContext := range selected {
+			ids = append(ids, searchContext.ID)
+		}
+		if diff := cmp.Diff(want, ids); diff != "" {
+			t.Fatalf("unexpected search contexts (-want +got):\n%s", diff)
+		}
+	}
+
+	// New query-based contexts are evaluated once, static contexts never.
+	assertSelected([]int64{queryContext.ID})
+	assertSelected(nil)
+
+	setRepos := func(repoIDs []api.RepoID, wantAdded, wantRemoved int) {
+		t.Helper()
+
+		added, removed, err := sc.SetSearchContextQueryRepos(internalCtx, queryContext.ID, repoIDs)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+		if added != wantAdded || removed != wantRemoved {
+			t.Fatalf("unexpected changes. want=(%d, %d) have=(%d, %d)", wantAdded, wantRemoved, added, removed)
+		}
+	}
+
+	setRepos([]api.RepoID{repos[0].ID, repos[1].ID}, 2, 0)
+	setRepos([]api.RepoID{repos[1].ID, repos[2].ID}, 1, 1)
+	setRepos([]api.RepoID{repos[1].ID, repos[2].ID}, 0, 0)
+
+	changes, err := sc.ListSearchContextQueryRepoChanges(internalCtx, queryContext.ID, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	type change struct {
+		RepoID api.RepoID
+		Added  bool
+	}
+	var have []change
+	for _, c := range changes {
+		have = append(have, change{RepoID: c.RepoID, Added: c.Added})
+	}
+	// Changes of a single evaluation are not ordered, so only look at the last one.
+	if diff := cmp.Diff([]change{{RepoID: repos[2].ID, Added: true}, {RepoID: repos[0].ID, Added: false}}, have[:2], cmpopts.SortSlices(func(a, b change) bool { return a.RepoID < b.RepoID })); diff != "" {
+		t.Fatalf("unexpected changes (-want +got):\n%s", diff)
+	}
+	if len(have) != 4 {
+		t.Fatalf("unexpected number of changes. want=%d have=%d", 4, len(have))
+	}
+
+	// Repository metadata changes queue the query-based context again, but
+	// not more often than searchContextQueryReposMinInterval.
+	if _, err := db.ExecContext(internalCtx, "UPDATE repo SET updated_at = now() WHERE id = $1", repos[0].ID); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	assertSelected(nil)
+	if _, err := db.ExecContext(internalCtx, "UPDATE search_contexts SET query_repos_synced_at = now() - interval '10 minutes' WHERE id = $1", queryContext.ID); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	assertSelected([]int64{queryContext.ID})
+
+	// Contexts are re-evaluated at least every searchContextQueryReposMaxInterval.
+	if _, err := db.ExecContext(internalCtx, "UPDATE repo SET updated_at = now() - interval '1 day'"); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if _, err := db.ExecContext(internalCtx, "UPDATE search_contexts SET query_repos_synced_at = now() - interval '10 minutes' WHERE id = $1", queryContext.ID); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	assertSelected(nil)
+	if _, err := db.ExecContext(internalCtx, "UPDATE search_contexts SET query_repos_synced_at = now() - interval '2 hours' WHERE id = $1", queryContext.ID); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	assertSelected([]int64{queryContext.ID})
+
+	// So do changes to its query.
+	queryContext.Query = "repo:has.tag(github.topic:billing)"
+	if _, err := sc.UpdateSearchContextWithRepositoryRevisions(internalCtx, queryContext, nil); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	assertSelected([]int64{queryContext.ID})
+
+	// Deleted contexts are never evaluated.
+	if _, err := db.ExecContext(internalCtx, "UPDATE search_contexts SET deleted_at = now(), query_repos_synced_at = NULL WHERE id = $1", queryContext.ID); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	assertSelected(nil)
+}
+
 func TestSearchContexts_DefaultContexts(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := NewDB(logger, dbtest.NewDB(logger, t))
@@ -0,0 +1,364 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var subRepoPermissionRuleColumns = []*sqlf.Query{
+	sqlf.Sprintf("sub_repo_permission_rules.id"),
+	sqlf.Sprintf("sub_repo_permission_rules.repo_pattern"),
+	sqlf.Sprintf("sub_repo_permission_rules.user_id"),
+	sqlf.Sprintf("sub_repo_permission_rules.org_id"),
+	sqlf.Sprintf("sub_repo_permission_rules.paths"),
+	sqlf.Sprintf("sub_repo_permission_rules.created_at"),
+	sqlf.Sprintf("sub_repo_permission_rules.updated_at"),
+}
+
+// SubRepoPermissionRuleStore manages the site-admin defined rules that define
+// sub-repo permissions for repositories whose code host has no native notion
+// of sub-repo permissions, e.g. plain git repositories. The rules are resolved
+// by SubRepoPermsStore when permissions are read, from the repositories matched
+// by each rule that are recorded in sub_repo_permission_rule_repos.
+//
+// Rules are evaluated in the order they were created: for a given user and
+// repository, the paths of every matching rule are concatenated, so that a
+// later rule overrides an earlier one for the paths they both match.
+type SubRepoPermissionRuleStore interface {
+	basestore.ShareableStore
+	With(other basestore.ShareableStore) SubRepoPermissionRuleStore
+	Transact(ctx context.Context) (SubRepoPermissionRuleStore, error)
+	Done(err error) error
+
+	// Create inserts the given rule into the database.
+	Create(ctx context.Context, rule *types.SubRepoPermissionRule) (*types.SubRepoPermissionRule, error)
+	// Update updates the repo pattern, subject and paths of an existing rule.
+	Update(ctx context.Context, rule *types.SubRepoPermissionRule) (*types.SubRepoPermissionRule, error)
+	// Delete removes the rule with the given ID.
+	Delete(ctx context.Context, id int32) error
+	// GetByID returns the rule with the given ID, or
+	// SubRepoPermissionRuleNotFoundErr if no such rule exists.
+	GetByID(ctx context.Context, id int32) (*types.SubRepoPermissionRule, error)
+	// List returns all rules matching the given options, in evaluation order.
+	List(ctx context.Context, opts SubRepoPermissionRulesListOptions) ([]*types.SubRepoPermissionRule, error)
+	// ListSubjectUserIDs returns the IDs of the users the given rule applies to.
+	ListSubjectUserIDs(ctx context.Context, rule *types.SubRepoPermissionRule) ([]int32, error)
+	// MatchRepoPattern returns true if the given repo pattern matches the
+	// given repository name. Repo patterns are evaluated by Postgres, so this
+	// returns an error if Postgres cannot compile the pattern.
+	MatchRepoPattern(ctx context.Context, pattern string, repoName api.RepoName) (bool, error)
+}
+
+type SubRepoPermissionRulesListOptions struct {
+	// RepoName, if set, only returns the rules whose repo pattern matches the
+	// given repository name.
+	RepoName api.RepoName
+}
+
+type SubRepoPermissionRuleNotFoundErr struct {
+	ID int32
+}
+
+func (e *SubRepoPermissionRuleNotFoundErr) Error() string {
+	return fmt.Sprintf("sub-repo permission rule with ID %d not found", e.ID)
+}
+
+func (e *SubRepoPermissionRuleNotFoundErr) NotFound() bool {
+	return true
+}
+
+type subRepoPermissionRuleStore struct {
+	*basestore.Store
+}
+
+var _ SubRepoPermissionRuleStore = (*subRepoPermissionRuleStore)(nil)
+
+// SubRepoPermissionRulesWith instantiates and returns a new SubRepoPermissionRuleStore using the other store handle.
+func SubRepoPermissionRulesWith(other basestore.ShareableStore) SubRepoPermissionRuleStore {
+	return &subRepoPermissionRuleStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *subRepoPermissionRuleStore) With(other basestore.ShareableStore) SubRepoPermissionRuleStore {
+	return &subRepoPermissionRuleStore{Store: s.Store.With(other)}
+}
+
+func (s *subRepoPermissionRuleStore) Transact(ctx context.Context) (SubRepoPermissionRuleStore, error) {
+	return s.transact(ctx)
+}
+
+func (s *subRepoPermissionRuleStore) transact(ctx context.Context) (*subRepoPermissionRuleStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &subRepoPermissionRuleStore{Store: txBase}, err
+}
+
+func (s *subRepoPermissionRuleStore) Done(err error) error {
+	return s.Store.Done(err)
+}
+
+const subRepoPermissionRuleCreateQueryFmtstr = `
+INSERT INTO sub_repo_permission_rules (repo_pattern, user_id, org_id, paths)
+VALUES (%s, %s, %s, %s)
+RETURNING %s
+`
+
+func (s *subRepoPermissionRuleStore) Create(ctx context.Context, rule *types.SubRepoPermissionRule) (_ *types.SubRepoPermissionRule, err error) {
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	q := sqlf.Sprintf(
+		subRepoPermissionRuleCreateQueryFmtstr,
+		rule.RepoPattern,
+		dbutil.NullInt32Column(rule.UserID),
+		dbutil.NullInt32Column(rule.OrgID),
+		pq.Array(rule.Paths),
+		sqlf.Join(subRepoPermissionRuleColumns, ", "),
+	)
+
+	created, err := scanSubRepoPermissionRule(tx.QueryRow(ctx, q))
+	if err != nil {
+		return nil, errors.Wrap(invalidRepoPatternErr(err, rule.RepoPattern), "creating sub-repo permission rule")
+	}
+	if err := tx.matchRepos(ctx, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+const subRepoPermissionRuleUpdateQueryFmtstr = `
+UPDATE sub_repo_permission_rules
+SET
+	repo_pattern = %s,
+	user_id = %s,
+	org_id = %s,
+	paths = %s,
+	updated_at = NOW()
+WHERE id = %s
+RETURNING %s
+`
+
+func (s *subRepoPermissionRuleStore) Update(ctx context.Context, rule *types.SubRepoPermissionRule) (_ *types.SubRepoPermissionRule, err error) {
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	q := sqlf.Sprintf(
+		subRepoPermissionRuleUpdateQueryFmtstr,
+		rule.RepoPattern,
+		dbutil.NullInt32Column(rule.UserID),
+		dbutil.NullInt32Column(rule.OrgID),
+		pq.Array(rule.Paths),
+		rule.ID,
+		sqlf.Join(subRepoPermissionRuleColumns, ", "),
+	)
+
+	updated, err := scanSubRepoPermissionRule(tx.QueryRow(ctx, q))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &SubRepoPermissionRuleNotFoundErr{ID: rule.ID}
+		}
+		return nil, errors.Wrap(invalidRepoPatternErr(err, rule.RepoPattern), "updating sub-repo permission rule")
+	}
+	if err := tx.matchRepos(ctx, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+const subRepoPermissionRuleMatchReposQueryFmtstr = `
+INSERT INTO sub_repo_permission_rule_repos (rule_id, repo_id)
+SELECT %s, r.id
+FROM repo r
+WHERE r.name ~ %s AND r.deleted_at IS NULL
+`
+
+// matchRepos records the repositories matched by the repo pattern of the given
+// rule. Repositories created or renamed later are matched by a trigger on the
+// repo table.
+func (s *subRepoPermissionRuleStore) matchRepos(ctx context.Context, rule *types.SubRepoPermissionRule) error {
+	if err := s.Exec(ctx, sqlf.Sprintf("DELETE FROM sub_repo_permission_rule_repos WHERE rule_id = %s", rule.ID)); err != nil {
+		return errors.Wrap(err, "deleting repositories of sub-repo permission rule")
+	}
+	if err := s.Exec(ctx, sqlf.Sprintf(subRepoPermissionRuleMatchReposQueryFmtstr, rule.ID, rule.RepoPattern)); err != nil {
+		return errors.Wrap(err, "matching repositories of sub-repo permission rule")
+	}
+	return nil
+}
+
+// invalidRepoPatternErr returns a user-facing error if err was caused by a repo
+// pattern Postgres cannot compile. Patterns are evaluated by Postgres, so its
+// regular expression syntax is the one that applies.
+func invalidRepoPatternErr(err error, pattern string) error {
+	var e *pgconn.PgError
+	if errors.As(err, &e) && e.Code == "2201B" {
+		return errors.Newf("invalid repository pattern %q: %s", pattern, e.Message)
+	}
+	return err
+}
+
+func (s *subRepoPermissionRuleStore) Delete(ctx context.Context, id int32) error {
+	q := sqlf.Sprintf("DELETE FROM sub_repo_permission_rules WHERE id = %s", id)
+	result, err := s.ExecResult(ctx, q)
+	if err != nil {
+		return errors.Wrap(err, "deleting sub-repo permission rule")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "checking deleted rows")
+	}
+	if rowsAffected == 0 {
+		return &SubRepoPermissionRuleNotFoundErr{ID: id}
+	}
+	return nil
+}
+
+func (s *subRepoPermissionRuleStore) GetByID(ctx context.Context, id int32) (*types.SubRepoPermissionRule, error) {
+	q := sqlf.Sprintf(
+		"SELECT %s FROM sub_repo_permission_rules WHERE id = %s",
+		sqlf.Join(subRepoPermissionRuleColumns, ", "),
+		id,
+	)
+
+	rule, err := scanSubRepoPermissionRule(s.QueryRow(ctx, q))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &SubRepoPermissionRuleNotFoundErr{ID: id}
+		}
+		return nil, errors.Wrap(err, "getting sub-repo permission rule")
+	}
+	return rule, nil
+}
+
+const subRepoPermissionRuleListQueryFmtstr = `
+SELECT %s
+FROM sub_repo_permission_rules
+WHERE %s
+ORDER BY id ASC
+`
+
+func (s *subRepoPermissionRuleStore) List(ctx context.Context, opts SubRepoPermissionRulesListOptions) ([]*types.SubRepoPermissionRule, error) {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.RepoName != "" {
+		conds = append(conds, sqlf.Sprintf("%s ~ repo_pattern", opts.RepoName))
+	}
+
+	q := sqlf.Sprintf(
+		subRepoPermissionRuleListQueryFmtstr,
+		sqlf.Join(subRepoPermissionRuleColumns, ", "),
+		sqlf.Join(conds, "AND"),
+	)
+
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing sub-repo permission rules")
+	}
+	defer rows.Close()
+
+	var rules []*types.SubRepoPermissionRule
+	for rows.Next() {
+		rule, err := scanSubRepoPermissionRule(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "scanning row")
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+const subRepoPermissionRuleSubjectUsersQueryFmtstr = `
+SELECT u.id
+FROM users u
+WHERE u.deleted_at IS NULL
+AND %s
+ORDER BY u.id ASC
+`
+
+func (s *subRepoPermissionRuleStore) ListSubjectUserIDs(ctx context.Context, rule *types.SubRepoPermissionRule) ([]int32, error) {
+	var cond *sqlf.Query
+	switch {
+	case rule.UserID != 0:
+		cond = sqlf.Sprintf("u.id = %s", rule.UserID)
+	case rule.OrgID != 0:
+		cond = sqlf.Sprintf("EXISTS (SELECT 1 FROM org_members om WHERE om.org_id = %s AND om.user_id = u.id)", rule.OrgID)
+	default:
+		cond = sqlf.Sprintf("TRUE")
+	}
+
+	ids, err := basestore.ScanInt32s(s.Query(ctx, sqlf.Sprintf(subRepoPermissionRuleSubjectUsersQueryFmtstr, cond)))
+	if err != nil {
+		return nil, errors.Wrap(err, "listing users of sub-repo permission rule")
+	}
+	return ids, nil
+}
+
+func (s *subRepoPermissionRuleStore) MatchRepoPattern(ctx context.Context, pattern string, repoName api.RepoName) (bool, error) {
+	matches, _, err := basestore.ScanFirstBool(s.Query(ctx, sqlf.Sprintf("SELECT %s ~ %s", repoName, pattern)))
+	if err != nil {
+		return false, invalidRepoPatternErr(err, pattern)
+	}
+	return matches, nil
+}
+
+// subRepoPermissionRulesForUserQuery returns a query producing the repo_id
+// and paths of the sub-repo permissions the rules define for the given user.
+// Rules are resolved when permissions are read, so that they apply to new
+// users, organization members and repositories right away.
+func subRepoPermissionRulesForUserQuery(userID int32) *sqlf.Query {
+	return sqlf.Sprintf(
+		subRepoPermissionRulesForUserQueryFmtstr,
+		userID,
+		userID,
+		sqlf.Join(supportedTypesQuery, ","),
+	)
+}
+
+const subRepoPermissionRulesForUserQueryFmtstr = `
+SELECT r.id AS repo_id, array_agg(p.path ORDER BY rl.id, p.ord) AS paths
+FROM sub_repo_permission_rules rl
+JOIN sub_repo_permission_rule_repos rr ON rr.rule_id = rl.id
+JOIN repo r ON r.id = rr.repo_id
+CROSS JOIN LATERAL unnest(rl.paths) WITH ORDINALITY AS p(path, ord)
+WHERE
+	(
+		(rl.user_id IS NULL AND rl.org_id IS NULL)
+		OR rl.user_id = %s
+		OR EXISTS (SELECT 1 FROM org_members om WHERE om.org_id = rl.org_id AND om.user_id = %s)
+	)
+	AND r.deleted_at IS NULL
+	-- Repositories of code hosts with native sub-repo permissions are managed
+	-- by their authz provider.
+	AND r.external_service_type NOT IN (%s)
+GROUP BY r.id
+`
+
+func scanSubRepoPermissionRule(sc dbutil.Scanner) (*types.SubRepoPermissionRule, error) {
+	var rule types.SubRepoPermissionRule
+	if err := sc.Scan(
+		&rule.ID,
+		&rule.RepoPattern,
+		&dbutil.NullInt32{N: &rule.UserID},
+		&dbutil.NullInt32{N: &rule.OrgID},
+		pq.Array(&rule.Paths),
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
@@ -0,0 +1,196 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSubRepoPermissionRules(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+
+	ctx := context.Background()
+	prepareSubRepoTestData(ctx, t, db)
+	s := db.SubRepoPermissionRules()
+
+	ignoreTimestamps := cmpopts.IgnoreFields(types.SubRepoPermissionRule{}, "CreatedAt", "UpdatedAt")
+
+	everyone, err := s.Create(ctx, &types.SubRepoPermissionRule{
+		RepoPattern: "^github\\.com/foo/",
+		Paths:       []string{"/**", "-/secrets/**"},
+	})
+	require.NoError(t, err)
+
+	alice, err := s.Create(ctx, &types.SubRepoPermissionRule{
+		RepoPattern: "^github\\.com/foo/bar$",
+		UserID:      1,
+		Paths:       []string{"/secrets/**"},
+	})
+	require.NoError(t, err)
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		// Go accepts named groups, Postgres does not.
+		_, err := s.Create(ctx, &types.SubRepoPermissionRule{
+			RepoPattern: "^github\\.com/(?P<org>foo)/",
+			Paths:       []string{"/**"},
+		})
+		assert.ErrorContains(t, err, "invalid repository pattern")
+	})
+
+	t.Run("MatchRepoPattern", func(t *testing.T) {
+		matches, err := s.MatchRepoPattern(ctx, everyone.RepoPattern, "github.com/foo/baz")
+		require.NoError(t, err)
+		assert.True(t, matches)
+
+		matches, err = s.MatchRepoPattern(ctx, everyone.RepoPattern, "github.com/bar/baz")
+		require.NoError(t, err)
+		assert.False(t, matches)
+
+		_, err = s.MatchRepoPattern(ctx, "(?P<org>foo)", "github.com/foo/baz")
+		assert.ErrorContains(t, err, "invalid repository pattern")
+	})
+
+	t.Run("GetByID", func(t *testing.T) {
+		have, err := s.GetByID(ctx, alice.ID)
+		require.NoError(t, err)
+		if diff := cmp.Diff(alice, have, ignoreTimestamps); diff != "" {
+			t.Fatal(diff)
+		}
+
+		_, err = s.GetByID(ctx, 1000)
+		assert.True(t, errcode.IsNotFound(err))
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.List(ctx, SubRepoPermissionRulesListOptions{})
+		require.NoError(t, err)
+		if diff := cmp.Diff([]*types.SubRepoPermissionRule{everyone, alice}, have, ignoreTimestamps); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, err = s.List(ctx, SubRepoPermissionRulesListOptions{RepoName: "github.com/foo/baz"})
+		require.NoError(t, err)
+		if diff := cmp.Diff([]*types.SubRepoPermissionRule{everyone}, have, ignoreTimestamps); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("ListSubjectUserIDs", func(t *testing.T) {
+		ids, err := s.ListSubjectUserIDs(ctx, everyone)
+		require.NoError(t, err)
+		assert.Equal(t, []int32{1}, ids)
+
+		ids, err = s.ListSubjectUserIDs(ctx, &types.SubRepoPermissionRule{OrgID: 1})
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		alice.Paths = []string{"/secrets/alice/**"}
+		updated, err := s.Update(ctx, alice)
+		require.NoError(t, err)
+		assert.Equal(t, alice.Paths, updated.Paths)
+
+		_, err = s.Update(ctx, &types.SubRepoPermissionRule{ID: 1000, RepoPattern: "foo", Paths: []string{"/**"}})
+		assert.True(t, errcode.IsNotFound(err))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, s.Delete(ctx, alice.ID))
+		_, err := s.GetByID(ctx, alice.ID)
+		assert.True(t, errcode.IsNotFound(err))
+
+		assert.True(t, errcode.IsNotFound(s.Delete(ctx, alice.ID)))
+	})
+}
+
+func TestSubRepoPermissionRulesGetByUser(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+
+	ctx := context.Background()
+	prepareSubRepoTestData(ctx, t, db)
+	s := db.SubRepoPermissionRules()
+
+	bob, err := db.Users().Create(ctx, NewUser{Username: "bob"})
+	require.NoError(t, err)
+	org, err := db.Orgs().Create(ctx, "legal", nil)
+	require.NoError(t, err)
+	_, err = db.OrgMembers().Create(ctx, org.ID, bob.ID)
+	require.NoError(t, err)
+
+	// Explicitly set permissions are returned unless overridden by a rule.
+	explicit := authz.SubRepoPermissions{Paths: []string{"/docs/**"}}
+	require.NoError(t, db.SubRepoPerms().Upsert(ctx, bob.ID, 2, explicit))
+
+	for _, rule := range []*types.SubRepoPermissionRule{
+		{RepoPattern: "^(github\\.com/foo/bar|perforce2)$", Paths: []string{"/**", "-/legal/**"}},
+		{RepoPattern: "^github\\.com/foo/bar$", OrgID: org.ID, Paths: []string{"/legal/**"}},
+		{RepoPattern: "^gitolite\\.example\\.com/", Paths: []string{"/docs/**"}},
+	} {
+		_, err := s.Create(ctx, rule)
+		require.NoError(t, err)
+	}
+
+	have, err := db.SubRepoPerms().GetByUser(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[api.RepoName]authz.SubRepoPermissions{
+		"github.com/foo/bar": {Paths: []string{"/**", "-/legal/**"}},
+	}, have)
+
+	have, err = db.SubRepoPerms().GetByUser(ctx, bob.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[api.RepoName]authz.SubRepoPermissions{
+		"github.com/foo/bar": {Paths: []string{"/**", "-/legal/**", "/legal/**"}},
+		"github.com/foo/baz": explicit,
+	}, have)
+
+	testSubRepoSupportedForRepo(ctx, t, db.SubRepoPerms(), 1, "github.com/foo/bar", "Rules apply to the repo, therefore sub-repo perms are supported")
+	testSubRepoNotSupportedForRepo(ctx, t, db.SubRepoPerms(), 2, "github.com/foo/baz", "No rule applies to the repo, therefore sub-repo perms are not supported")
+
+	// Repositories created after a rule are matched by it.
+	require.NoError(t, db.Repos().Create(ctx, &types.Repo{Name: "gitolite.example.com/foo"}))
+	have, err = db.SubRepoPerms().GetByUser(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[api.RepoName]authz.SubRepoPermissions{
+		"github.com/foo/bar":       {Paths: []string{"/**", "-/legal/**"}},
+		"gitolite.example.com/foo": {Paths: []string{"/docs/**"}},
+	}, have)
+
+	// Deleting the rules removes the permissions defined by them.
+	rules, err := s.List(ctx, SubRepoPermissionRulesListOptions{})
+	require.NoError(t, err)
+	for _, rule := range rules {
+		require.NoError(t, s.Delete(ctx, rule.ID))
+	}
+
+	have, err = db.SubRepoPerms().GetByUser(ctx, bob.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[api.RepoName]authz.SubRepoPermissions{
+		"github.com/foo/baz": explicit,
+	}, have)
+	testSubRepoNotSupportedForRepo(ctx, t, db.SubRepoPerms(), 1, "github.com/foo/bar", "No rule applies to the repo anymore")
+}
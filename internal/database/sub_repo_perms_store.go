@@ -147,18 +147,27 @@ WHERE repo_id = %s
 func (s *subRepoPermsStore) GetByUser(ctx context.Context, userID int32) (map[api.RepoName]authz.SubRepoPermissions, error) {
 	enforceForSiteAdmins := conf.Get().AuthzEnforceForSiteAdmins
 
+	// Permissions defined by sub-repo permission rules take precedence over
+	// those stored for the same repository.
 	q := sqlf.Sprintf(`
+	WITH rule_perms AS (%s)
 	SELECT r.name, paths
-	FROM sub_repo_permissions
-	JOIN repo r on r.id = repo_id
-	JOIN users u on u.id = user_id
-	WHERE user_id = %s
-	AND version = %s
+	FROM (
+		SELECT repo_id, paths
+		FROM sub_repo_permissions
+		WHERE user_id = %s
+		AND version = %s
+		AND NOT EXISTS (SELECT 1 FROM rule_perms rp WHERE rp.repo_id = sub_repo_permissions.repo_id)
+		UNION ALL
+		SELECT repo_id, paths FROM rule_perms
+	) perms
+	JOIN repo r on r.id = perms.repo_id
+	JOIN users u on u.id = %s
 	-- When user is a site admin and AuthzEnforceForSiteAdmins is FALSE
 	-- we want to return zero results. This causes us to fall back to
 	-- repo level checks and allows access to all paths in all repos.
-	AND NOT (u.site_admin AND NOT %t)
-	`, userID, SubRepoPermsVersion, enforceForSiteAdmins)
+	WHERE NOT (u.site_admin AND NOT %t)
+	`, subRepoPermissionRulesForUserQuery(userID), userID, SubRepoPermsVersion, userID, enforceForSiteAdmins)
 
 	rows, err := s.Query(ctx, q)
 	if err != nil {
@@ -219,17 +228,23 @@ WHERE user_id = %s
 }
 
 // RepoIDSupported returns true if repo with the given ID has sub-repo permissions
-// (i.e. it is private and its type is one of the SubRepoSupportedCodeHostTypes)
+// (i.e. it is private and its type is one of the SubRepoSupportedCodeHostTypes,
+// or sub-repo permission rules apply to it)
 func (s *subRepoPermsStore) RepoIDSupported(ctx context.Context, repoID api.RepoID) (bool, error) {
 	q := sqlf.Sprintf(`
 SELECT EXISTS(
 SELECT
 FROM repo
 WHERE id = %s
-AND private = TRUE
-AND external_service_type IN (%s)
+AND (
+	(private = TRUE AND external_service_type IN (%s))
+	OR (
+		external_service_type NOT IN (%s)
+		AND EXISTS (SELECT 1 FROM sub_repo_permission_rule_repos rr WHERE rr.repo_id = repo.id)
+	)
 )
-`, repoID, sqlf.Join(supportedTypesQuery, ","))
+)
+`, repoID, sqlf.Join(supportedTypesQuery, ","), sqlf.Join(supportedTypesQuery, ","))
 
 	exists, _, err := basestore.ScanFirstBool(s.Query(ctx, q))
 	if err != nil {
@@ -239,17 +254,23 @@ AND external_service_type IN (%s)
 }
 
 // RepoSupported returns true if repo has sub-repo permissions
-// (i.e. it is private and its type is one of the SubRepoSupportedCodeHostTypes)
+// (i.e. it is private and its type is one of the SubRepoSupportedCodeHostTypes,
+// or sub-repo permission rules apply to it)
 func (s *subRepoPermsStore) RepoSupported(ctx context.Context, repo api.RepoName) (bool, error) {
 	q := sqlf.Sprintf(`
 SELECT EXISTS(
 SELECT
 FROM repo
 WHERE name = %s
-AND private = TRUE
-AND external_service_type IN (%s)
+AND (
+	(private = TRUE AND external_service_type IN (%s))
+	OR (
+		external_service_type NOT IN (%s)
+		AND EXISTS (SELECT 1 FROM sub_repo_permission_rule_repos rr WHERE rr.repo_id = repo.id)
+	)
+)
 )
-`, repo, sqlf.Join(supportedTypesQuery, ","))
+`, repo, sqlf.Join(supportedTypesQuery, ","), sqlf.Join(supportedTypesQuery, ","))
 
 	exists, _, err := basestore.ScanFirstBool(s.Query(ctx, q))
 	if err != nil {
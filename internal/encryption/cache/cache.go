@@ -51,6 +51,13 @@ func (k *Key) Decrypt(ctx context.Context, ciphertext []byte) (*encryption.Secre
 	return &s, nil
 }
 
+// DecryptsEarlierVersions reports whether the wrapped key can decrypt values
+// encrypted with an earlier version of itself.
+func (k *Key) DecryptsEarlierVersions() bool {
+	rk, ok := k.Key.(encryption.RotatableKey)
+	return ok && rk.DecryptsEarlierVersions()
+}
+
 func hash(v []byte) uint64 {
 	h := fnv.New64()
 	h.Write(v)
@@ -15,6 +15,17 @@ type Key interface {
 	Version(ctx context.Context) (KeyVersion, error)
 }
 
+// RotatableKey is implemented by keys that can decrypt values encrypted with
+// an earlier version of themselves. Values encrypted with an earlier version are
+// re-encrypted with the current version in the background.
+type RotatableKey interface {
+	Key
+
+	// DecryptsEarlierVersions reports whether Decrypt accepts values encrypted
+	// with an earlier version of the key.
+	DecryptsEarlierVersions() bool
+}
+
 type KeyVersion struct {
 	// TODO: generate this as an enum from JSONSchema
 	Type    string
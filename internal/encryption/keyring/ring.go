@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption/cache"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/cloudkms"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/mounted"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/vault"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -154,6 +155,8 @@ func NewKey(ctx context.Context, k *schema.EncryptionKey, config *schema.Encrypt
 		key, err = awskms.NewKey(ctx, *k.Awskms)
 	case k.Mounted != nil:
 		key, err = mounted.NewKey(ctx, *k.Mounted)
+	case k.Vault != nil:
+		key, err = vault.NewKey(ctx, *k.Vault)
 	case k.Noop != nil:
 		key = &encryption.NoopKey{}
 	default:
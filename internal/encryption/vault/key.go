@@ -0,0 +1,432 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	defaultTransitMount            = "transit"
+	defaultServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	requestTimeout = 30 * time.Second
+
+	// versionTTL is how long the latest version of the key is cached for. It
+	// bounds how long it takes until a rotation of the key is noticed.
+	versionTTL = time.Minute
+)
+
+func NewKey(ctx context.Context, k schema.VaultEncryptionKey) (*Key, error) {
+	return newKey(ctx, k, &http.Client{Timeout: requestTimeout})
+}
+
+func newKey(ctx context.Context, k schema.VaultEncryptionKey, cli httpcli.Doer) (*Key, error) {
+	address, err := url.Parse(k.Address)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing Vault address")
+	}
+	if k.KeyName == "" {
+		return nil, errors.New("keyName must be set")
+	}
+
+	mount := k.TransitMount
+	if mount == "" {
+		mount = defaultTransitMount
+	}
+
+	login, err := newLogin(k.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &Key{
+		address:   address,
+		namespace: k.Namespace,
+		mount:     strings.Trim(mount, "/"),
+		keyName:   k.KeyName,
+		cli:       cli,
+		login:     login,
+		relogin:   k.Auth.Method != "token",
+	}
+	// Test the connection and our permissions on the key.
+	_, err = key.Version(ctx)
+	return key, err
+}
+
+// Key is an encryption.Key implementation that uses the Transit secrets engine
+// of HashiCorp Vault. The plaintext is never stored by Vault, and ciphertexts
+// are the ones returned by Vault, which include the version of the key they
+// were encrypted with.
+type Key struct {
+	address   *url.URL
+	namespace string
+	mount     string
+	keyName   string
+	cli       httpcli.Doer
+	login     loginFunc
+	// relogin is true if login can obtain a new token once the current one
+	// has expired, rather than just returning the configured one.
+	relogin bool
+
+	mu sync.Mutex
+	// token is the current Vault token, and renewAt the time after which it
+	// should be renewed. A zero renewAt means the token doesn't expire.
+	token     string
+	renewable bool
+	renewAt   time.Time
+	// version is the latest version of the key that we know of, and
+	// versionAt the time it was fetched at.
+	version   int
+	versionAt time.Time
+}
+
+var _ encryption.RotatableKey = &Key{}
+
+func (k *Key) Version(ctx context.Context) (encryption.KeyVersion, error) {
+	k.mu.Lock()
+	version, fresh := k.version, time.Since(k.versionAt) < versionTTL
+	k.mu.Unlock()
+
+	if !fresh {
+		var resp struct {
+			Data struct {
+				LatestVersion int `json:"latest_version"`
+			} `json:"data"`
+		}
+		if err := k.do(ctx, http.MethodGet, k.mount+"/keys/"+url.PathEscape(k.keyName), nil, &resp); err != nil {
+			return encryption.KeyVersion{}, errors.Wrap(err, "getting key version")
+		}
+		version = k.observeVersion(resp.Data.LatestVersion, true)
+	}
+
+	return encryption.KeyVersion{
+		Type:    "vault",
+		Name:    k.keyName,
+		Version: strconv.Itoa(version),
+	}, nil
+}
+
+// DecryptsEarlierVersions returns true: Vault decrypts values encrypted with
+// any version of the key that is not below its minimum decryption version.
+func (k *Key) DecryptsEarlierVersions() bool {
+	return true
+}
+
+func (k *Key) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	var resp struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	req := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(plaintext)}
+	if err := k.do(ctx, http.MethodPost, k.mount+"/encrypt/"+url.PathEscape(k.keyName), req, &resp); err != nil {
+		return nil, errors.Wrap(err, "encrypting value")
+	}
+
+	// The ciphertext tells us which version was used, so that Version reflects
+	// a rotation as soon as we encrypt with the new version.
+	if version, ok := ciphertextVersion(resp.Data.Ciphertext); ok {
+		k.observeVersion(version, false)
+	}
+	return []byte(resp.Data.Ciphertext), nil
+}
+
+func (k *Key) Decrypt(ctx context.Context, ciphertext []byte) (*encryption.Secret, error) {
+	if _, ok := ciphertextVersion(string(ciphertext)); !ok {
+		return nil, errors.New("malformed ciphertext, are you trying to decrypt something with the wrong key?")
+	}
+
+	var resp struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	req := map[string]string{"ciphertext": string(ciphertext)}
+	if err := k.do(ctx, http.MethodPost, k.mount+"/decrypt/"+url.PathEscape(k.keyName), req, &resp); err != nil {
+		return nil, errors.Wrap(err, "decrypting value")
+	}
+
+	plaintext, err := base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding plaintext")
+	}
+	s := encryption.NewSecret(string(plaintext))
+	return &s, nil
+}
+
+// observeVersion records that version is a version of the key and returns the
+// latest known version. If latest is true, version is the latest version as
+// reported by Vault.
+func (k *Key) observeVersion(version int, latest bool) int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if latest {
+		k.versionAt = time.Now()
+	}
+	if version > k.version || latest {
+		k.version = version
+	}
+	return k.version
+}
+
+// ciphertextVersion returns the key version from a ciphertext of the form
+// "vault:v<version>:<data>".
+func ciphertextVersion(ciphertext string) (int, bool) {
+	parts := strings.SplitN(ciphertext, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" || !strings.HasPrefix(parts[1], "v") {
+		return 0, false
+	}
+	version, err := strconv.Atoi(parts[1][1:])
+	return version, err == nil
+}
+
+// do sends a request to the Vault API, authenticating with the current token,
+// and decodes the response into out. If the token is rejected, a new one is
+// obtained and the request is retried once.
+func (k *Key) do(ctx context.Context, method, path string, in, out any) error {
+	token, err := k.currentToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = k.send(ctx, method, path, token, in, out)
+	if isPermissionDenied(err) && k.relogin {
+		k.mu.Lock()
+		if k.token == token {
+			k.token = ""
+		}
+		k.mu.Unlock()
+
+		if token, err = k.currentToken(ctx); err != nil {
+			return err
+		}
+		err = k.send(ctx, method, path, token, in, out)
+	}
+	return err
+}
+
+// currentToken returns a valid Vault token, logging in or renewing the current
+// token if required.
+func (k *Key) currentToken(ctx context.Context) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.token != "" && (k.renewAt.IsZero() || time.Now().Before(k.renewAt)) {
+		return k.token, nil
+	}
+
+	if k.token != "" && k.renewable {
+		var resp authResponse
+		err := k.send(ctx, http.MethodPost, "auth/token/renew-self", k.token, struct{}{}, &resp)
+		if err == nil {
+			k.setToken(resp.Auth)
+			return k.token, nil
+		}
+		if !k.relogin {
+			return "", errors.Wrap(err, "renewing Vault token")
+		}
+	} else if k.token != "" && !k.relogin {
+		// The token can't be renewed and we have no way to get a new one, so
+		// we keep using it until Vault rejects it.
+		return k.token, nil
+	}
+
+	auth, err := k.login(ctx, k)
+	if err != nil {
+		return "", errors.Wrap(err, "logging in to Vault")
+	}
+	k.setToken(auth)
+	return k.token, nil
+}
+
+// setToken stores the token returned by a login or renewal, and schedules its
+// renewal after two thirds of its lease have passed. k.mu must be held.
+func (k *Key) setToken(auth tokenAuth) {
+	k.token = auth.ClientToken
+	k.renewable = auth.Renewable
+	k.renewAt = time.Time{}
+	if auth.LeaseDuration > 0 {
+		k.renewAt = time.Now().Add(time.Duration(auth.LeaseDuration) * time.Second * 2 / 3)
+	}
+}
+
+func (k *Key) send(ctx context.Context, method, path, token string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		buf, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, k.address.JoinPath("v1", path).String(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if k.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", k.namespace)
+	}
+
+	resp, err := k.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		return &apiError{StatusCode: resp.StatusCode, Errors: apiErr.Errors}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type apiError struct {
+	StatusCode int
+	Errors     []string
+}
+
+func (e *apiError) Error() string {
+	if len(e.Errors) == 0 {
+		return "Vault responded with status " + strconv.Itoa(e.StatusCode)
+	}
+	return "Vault responded with status " + strconv.Itoa(e.StatusCode) + ": " + strings.Join(e.Errors, "; ")
+}
+
+func isPermissionDenied(err error) bool {
+	var e *apiError
+	return errors.As(err, &e) && e.StatusCode == http.StatusForbidden
+}
+
+type authResponse struct {
+	Auth tokenAuth `json:"auth"`
+}
+
+type tokenAuth struct {
+	ClientToken   string `json:"client_token"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+}
+
+// loginFunc obtains a new Vault token. k.mu is held while it is called.
+type loginFunc func(ctx context.Context, k *Key) (tokenAuth, error)
+
+func newLogin(auth schema.VaultAuth) (loginFunc, error) {
+	switch auth.Method {
+	case "token":
+		token, err := valueOrFile(auth.Token, auth.TokenFile, "token")
+		if err != nil {
+			return nil, err
+		}
+		return tokenLogin(token), nil
+
+	case "approle":
+		if auth.RoleID == "" {
+			return nil, errors.New("roleID must be set for approle authentication")
+		}
+		mount := auth.Mount
+		if mount == "" {
+			mount = "approle"
+		}
+		return func(ctx context.Context, k *Key) (tokenAuth, error) {
+			// Read the secret ID on each login, so that it can be rotated
+			// without restarting.
+			secretID, err := valueOrFile(auth.SecretID, auth.SecretIDFile, "secretID")
+			if err != nil {
+				return tokenAuth{}, err
+			}
+			var resp authResponse
+			err = k.send(ctx, http.MethodPost, "auth/"+strings.Trim(mount, "/")+"/login", "", map[string]string{
+				"role_id":   auth.RoleID,
+				"secret_id": secretID,
+			}, &resp)
+			return resp.Auth, err
+		}, nil
+
+	case "kubernetes":
+		if auth.Role == "" {
+			return nil, errors.New("role must be set for kubernetes authentication")
+		}
+		mount := auth.Mount
+		if mount == "" {
+			mount = "kubernetes"
+		}
+		jwtFile := auth.ServiceAccountTokenFile
+		if jwtFile == "" {
+			jwtFile = defaultServiceAccountTokenFile
+		}
+		return func(ctx context.Context, k *Key) (tokenAuth, error) {
+			// Service account tokens are rotated by Kubernetes, so we read it
+			// on each login.
+			jwt, err := os.ReadFile(jwtFile)
+			if err != nil {
+				return tokenAuth{}, errors.Wrap(err, "reading service account token")
+			}
+			var resp authResponse
+			err = k.send(ctx, http.MethodPost, "auth/"+strings.Trim(mount, "/")+"/login", "", map[string]string{
+				"role": auth.Role,
+				"jwt":  strings.TrimSpace(string(jwt)),
+			}, &resp)
+			return resp.Auth, err
+		}, nil
+	}
+
+	return nil, errors.Errorf("unsupported Vault auth method %q", auth.Method)
+}
+
+// tokenLogin returns a loginFunc for a static token. It looks up the token to
+// find out whether and when it needs to be renewed.
+func tokenLogin(token string) loginFunc {
+	return func(ctx context.Context, k *Key) (tokenAuth, error) {
+		var resp struct {
+			Data struct {
+				TTL       int  `json:"ttl"`
+				Renewable bool `json:"renewable"`
+			} `json:"data"`
+		}
+		if err := k.send(ctx, http.MethodGet, "auth/token/lookup-self", token, nil, &resp); err != nil {
+			return tokenAuth{}, err
+		}
+		return tokenAuth{ClientToken: token, LeaseDuration: resp.Data.TTL, Renewable: resp.Data.Renewable}, nil
+	}
+}
+
+func valueOrFile(value, file, name string) (string, error) {
+	switch {
+	case value != "" && file == "":
+		return value, nil
+	case file != "" && value == "":
+		buf, err := os.ReadFile(file)
+		if err != nil {
+			return "", errors.Wrapf(err, "reading %s file", name)
+		}
+		return strings.TrimSpace(string(buf)), nil
+	}
+	return "", errors.Errorf("exactly one of %s and %sFile must be set", name, name)
+}
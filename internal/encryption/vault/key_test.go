@@ -0,0 +1,351 @@
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestKey(t *testing.T) {
+	ctx := context.Background()
+	vault := newFakeVault(t)
+	vault.tokens["root"] = fakeToken{}
+
+	k, err := newKey(ctx, schema.VaultEncryptionKey{
+		Address: vault.URL,
+		KeyName: "sourcegraph",
+		Auth:    schema.VaultAuth{Method: "token", Token: "root"},
+		Type:    "vault",
+	}, vault.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertVersion := func(t *testing.T, want string) {
+		t.Helper()
+		v, err := k.Version(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.Type != "vault" || v.Name != "sourcegraph" || v.Version != want {
+			t.Fatalf("unexpected version: %+v", v)
+		}
+	}
+	assertDecrypts := func(t *testing.T, ciphertext []byte, want string) {
+		t.Helper()
+		s, err := k.Decrypt(ctx, ciphertext)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Secret() != want {
+			t.Fatalf("unexpected plaintext. want=%q have=%q", want, s.Secret())
+		}
+	}
+
+	assertVersion(t, "1")
+	v1, err := k.Encrypt(ctx, []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(v1), "vault:v1:") {
+		t.Fatalf("unexpected ciphertext %q", v1)
+	}
+	assertDecrypts(t, v1, "hello")
+
+	// After a rotation, new values are encrypted with the new version, which
+	// is reflected in the key version, and old values can still be decrypted.
+	vault.rotate()
+	v2, err := k.Encrypt(ctx, []byte("world"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(v2), "vault:v2:") {
+		t.Fatalf("unexpected ciphertext %q", v2)
+	}
+	assertVersion(t, "2")
+	assertDecrypts(t, v1, "hello")
+	assertDecrypts(t, v2, "world")
+
+	// Rotations are also noticed without encrypting anything once the cached
+	// version expired.
+	vault.rotate()
+	k.versionAt = time.Time{}
+	assertVersion(t, "3")
+
+	if _, err := k.Decrypt(ctx, []byte("bm90IHZhdWx0")); err == nil {
+		t.Fatal("expected error decrypting a value that wasn't encrypted by Vault")
+	}
+}
+
+func TestKey_Auth(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content+"\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("token", func(t *testing.T) {
+		vault := newFakeVault(t)
+		vault.tokens["static"] = fakeToken{ttl: time.Hour, renewable: true}
+
+		k, err := newKey(ctx, schema.VaultEncryptionKey{
+			Address: vault.URL,
+			KeyName: "sourcegraph",
+			Auth:    schema.VaultAuth{Method: "token", TokenFile: writeFile("token", "static")},
+		}, vault.Client())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Renew the token once it's due.
+		k.renewAt = time.Now().Add(-time.Second)
+		if _, err := k.Encrypt(ctx, []byte("secret")); err != nil {
+			t.Fatal(err)
+		}
+		if have := vault.count("auth/token/renew-self"); have != 1 {
+			t.Fatalf("expected token to be renewed once, renewed %d times", have)
+		}
+		if k.renewAt.Before(time.Now()) {
+			t.Fatal("expected renewal to be rescheduled")
+		}
+
+		// A static token that can't be renewed anymore is an error.
+		delete(vault.tokens, "static")
+		k.renewAt = time.Now().Add(-time.Second)
+		if _, err := k.Encrypt(ctx, []byte("secret")); err == nil {
+			t.Fatal("expected error with an expired token")
+		}
+	})
+
+	t.Run("approle", func(t *testing.T) {
+		vault := newFakeVault(t)
+		vault.logins["auth/custom-approle/login"] = func(req map[string]string) bool {
+			return req["role_id"] == "role" && req["secret_id"] == "s3cr3t"
+		}
+
+		k, err := newKey(ctx, schema.VaultEncryptionKey{
+			Address: vault.URL,
+			KeyName: "sourcegraph",
+			Auth: schema.VaultAuth{
+				Method:       "approle",
+				Mount:        "custom-approle",
+				RoleID:       "role",
+				SecretIDFile: writeFile("secret-id", "s3cr3t"),
+			},
+		}, vault.Client())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have := vault.count("auth/custom-approle/login"); have != 1 {
+			t.Fatalf("expected one login, have %d", have)
+		}
+
+		// If renewal fails, we log in again.
+		vault.revokeAll()
+		k.renewAt = time.Now().Add(-time.Second)
+		if _, err := k.Encrypt(ctx, []byte("secret")); err != nil {
+			t.Fatal(err)
+		}
+		if have := vault.count("auth/custom-approle/login"); have != 2 {
+			t.Fatalf("expected two logins, have %d", have)
+		}
+
+		// If the token is revoked before it is due to be renewed, we log in
+		// again and retry the request.
+		vault.revokeAll()
+		if _, err := k.Encrypt(ctx, []byte("secret")); err != nil {
+			t.Fatal(err)
+		}
+		if have := vault.count("auth/custom-approle/login"); have != 3 {
+			t.Fatalf("expected three logins, have %d", have)
+		}
+	})
+
+	t.Run("kubernetes", func(t *testing.T) {
+		vault := newFakeVault(t)
+		vault.logins["auth/kubernetes/login"] = func(req map[string]string) bool {
+			return req["role"] == "sourcegraph" && req["jwt"] == "service-account-jwt"
+		}
+
+		_, err := newKey(ctx, schema.VaultEncryptionKey{
+			Address:   vault.URL,
+			Namespace: "team",
+			KeyName:   "sourcegraph",
+			Auth: schema.VaultAuth{
+				Method:                  "kubernetes",
+				Role:                    "sourcegraph",
+				ServiceAccountTokenFile: writeFile("jwt", "service-account-jwt"),
+			},
+		}, vault.Client())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have := vault.count("auth/kubernetes/login"); have != 1 {
+			t.Fatalf("expected one login, have %d", have)
+		}
+		if vault.namespace != "team" {
+			t.Fatalf("expected namespace header to be sent, have %q", vault.namespace)
+		}
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		for _, auth := range []schema.VaultAuth{
+			{Method: "token"},
+			{Method: "token", Token: "a", TokenFile: "b"},
+			{Method: "approle"},
+			{Method: "kubernetes"},
+			{Method: "userpass"},
+		} {
+			if _, err := newKey(ctx, schema.VaultEncryptionKey{Address: "http://vault", KeyName: "k", Auth: auth}, http.DefaultClient); err == nil {
+				t.Errorf("expected error for auth config %+v", auth)
+			}
+		}
+	})
+}
+
+type fakeToken struct {
+	ttl       time.Duration
+	renewable bool
+}
+
+// fakeVault is a fake implementation of the parts of the Vault API used by
+// Key: the Transit engine mounted at transit/ with a single key, and token
+// authentication.
+type fakeVault struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	version   int
+	tokens    map[string]fakeToken
+	logins    map[string]func(map[string]string) bool
+	requests  map[string]int
+	namespace string
+}
+
+func newFakeVault(t *testing.T) *fakeVault {
+	v := &fakeVault{
+		version:  1,
+		tokens:   map[string]fakeToken{},
+		logins:   map[string]func(map[string]string) bool{},
+		requests: map[string]int{},
+	}
+	v.Server = httptest.NewServer(http.HandlerFunc(v.serveHTTP))
+	t.Cleanup(v.Close)
+	return v
+}
+
+func (v *fakeVault) rotate() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
+}
+
+func (v *fakeVault) revokeAll() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.tokens = map[string]fakeToken{}
+}
+
+func (v *fakeVault) count(path string) int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.requests[path]
+}
+
+func (v *fakeVault) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	v.requests[path]++
+	if ns := r.Header.Get("X-Vault-Namespace"); ns != "" {
+		v.namespace = ns
+	}
+
+	var req map[string]string
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if login, ok := v.logins[path]; ok {
+		if !login(req) {
+			writeError(w, http.StatusBadRequest, "invalid credentials")
+			return
+		}
+		token := fmt.Sprintf("token-%d", len(v.tokens)+v.requests[path])
+		v.tokens[token] = fakeToken{ttl: time.Hour, renewable: true}
+		writeAuth(w, token, v.tokens[token])
+		return
+	}
+
+	token, ok := v.tokens[r.Header.Get("X-Vault-Token")]
+	if !ok {
+		writeError(w, http.StatusForbidden, "permission denied")
+		return
+	}
+
+	switch path {
+	case "auth/token/lookup-self":
+		writeData(w, map[string]any{"ttl": int(token.ttl.Seconds()), "renewable": token.renewable})
+
+	case "auth/token/renew-self":
+		writeAuth(w, r.Header.Get("X-Vault-Token"), token)
+
+	case "transit/keys/sourcegraph":
+		writeData(w, map[string]any{"latest_version": v.version})
+
+	case "transit/encrypt/sourcegraph":
+		// The "ciphertext" is the version and the plaintext, which is good
+		// enough for a fake.
+		ciphertext := fmt.Sprintf("vault:v%d:%s", v.version, base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(v.version)+":"+req["plaintext"])))
+		writeData(w, map[string]any{"ciphertext": ciphertext})
+
+	case "transit/decrypt/sourcegraph":
+		parts := strings.SplitN(req["ciphertext"], ":", 3)
+		payload, err := base64.StdEncoding.DecodeString(parts[2])
+		if err != nil || !strings.HasPrefix(string(payload), strings.TrimPrefix(parts[1], "v")+":") {
+			writeError(w, http.StatusBadRequest, "invalid ciphertext")
+			return
+		}
+		writeData(w, map[string]any{"plaintext": strings.SplitN(string(payload), ":", 2)[1]})
+
+	default:
+		writeError(w, http.StatusNotFound, "unknown path "+path)
+	}
+}
+
+func writeData(w http.ResponseWriter, data any) {
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+func writeAuth(w http.ResponseWriter, clientToken string, token fakeToken) {
+	_ = json.NewEncoder(w).Encode(map[string]any{"auth": map[string]any{
+		"client_token":   clientToken,
+		"lease_duration": int(token.ttl.Seconds()),
+		"renewable":      token.renewable,
+	}})
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{message}})
+}
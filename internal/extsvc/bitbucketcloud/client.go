@@ -45,6 +45,11 @@ type Client interface {
 	ForkRepository(ctx context.Context, upstream *Repo, input ForkInput) (*Repo, error)
 
 	CurrentUser(ctx context.Context) (*User, error)
+
+	WorkspaceMembers(workspace string) (*PaginatedResultSet, error)
+	WorkspaceMember(ctx context.Context, workspace, nickname string) (*Account, error)
+	RepoPermissions(workspace, slug string) (*PaginatedResultSet, error)
+	UserRepoPermissions(workspace, userUUID string) (*PaginatedResultSet, error)
 }
 
 // client access a Bitbucket Cloud via the REST API 2.0.
@@ -274,6 +279,10 @@ func (e *httpError) Unauthorized() bool {
 	return e.StatusCode == http.StatusUnauthorized
 }
 
+func (e *httpError) Forbidden() bool {
+	return e.StatusCode == http.StatusForbidden
+}
+
 func (e *httpError) NotFound() bool {
 	return e.StatusCode == http.StatusNotFound
 }
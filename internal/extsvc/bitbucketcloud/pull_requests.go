@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -21,6 +22,9 @@ type PullRequestInput struct {
 	// If SourceRepo is provided, only FullName is actually used.
 	SourceRepo        *Repo
 	DestinationBranch *string
+	// Reviewers are the UUIDs or account IDs of the reviewers of the pull
+	// request. If provided, they replace the current reviewers.
+	Reviewers []string
 }
 
 // CreatePullRequest opens a new pull request.
@@ -197,11 +201,17 @@ func (input *PullRequestInput) MarshalJSON() ([]byte, error) {
 		Repository *repository `json:"repository,omitempty"`
 	}
 
+	type reviewer struct {
+		UUID      string `json:"uuid,omitempty"`
+		AccountID string `json:"account_id,omitempty"`
+	}
+
 	type request struct {
-		Title       string  `json:"title"`
-		Description string  `json:"description,omitempty"`
-		Source      source  `json:"source"`
-		Destination *source `json:"destination,omitempty"`
+		Title       string     `json:"title"`
+		Description string     `json:"description,omitempty"`
+		Source      source     `json:"source"`
+		Destination *source    `json:"destination,omitempty"`
+		Reviewers   []reviewer `json:"reviewers,omitempty"`
 	}
 
 	req := request{
@@ -222,6 +232,15 @@ func (input *PullRequestInput) MarshalJSON() ([]byte, error) {
 		}
 	}
 
+	for _, r := range input.Reviewers {
+		// UUIDs are wrapped in braces, account IDs are not.
+		if strings.HasPrefix(r, "{") {
+			req.Reviewers = append(req.Reviewers, reviewer{UUID: r})
+		} else {
+			req.Reviewers = append(req.Reviewers, reviewer{AccountID: r})
+		}
+	}
+
 	return json.Marshal(&req)
 }
 
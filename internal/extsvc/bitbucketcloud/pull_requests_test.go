@@ -2,6 +2,7 @@ package bitbucketcloud
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -389,3 +390,25 @@ func TestClient_UpdatePullRequest(t *testing.T) {
 		assertGolden(t, updated)
 	})
 }
+
+func TestPullRequestInput_MarshalJSON(t *testing.T) {
+	branch := "main"
+	input := PullRequestInput{
+		Title:             "title",
+		SourceBranch:      "branch",
+		DestinationBranch: &branch,
+		Reviewers:         []string{"{00000000-0000-0000-0000-000000000000}", "557058:00000000"},
+	}
+
+	data, err := json.Marshal(&input)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"title": "title",
+		"source": {"branch": {"name": "branch"}},
+		"destination": {"branch": {"name": "main"}},
+		"reviewers": [
+			{"uuid": "{00000000-0000-0000-0000-000000000000}"},
+			{"account_id": "557058:00000000"}
+		]
+	}`, string(data))
+}
@@ -0,0 +1,144 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// WorkspaceMembership is a single member of a workspace, as returned by the
+// workspace members API.
+type WorkspaceMembership struct {
+	User      Account   `json:"user"`
+	Workspace Workspace `json:"workspace"`
+}
+
+// RepoPermission is a single user's permission on a single repository, as
+// returned by the workspace permissions API.
+type RepoPermission struct {
+	Permission RepoPermissionLevel `json:"permission"`
+	User       Account             `json:"user"`
+	Repo       Repo                `json:"repository"`
+}
+
+type RepoPermissionLevel string
+
+const (
+	RepoPermissionLevelRead  RepoPermissionLevel = "read"
+	RepoPermissionLevelWrite RepoPermissionLevel = "write"
+	RepoPermissionLevelAdmin RepoPermissionLevel = "admin"
+)
+
+// WorkspaceMembers retrieves the members of the given workspace.
+//
+// Each item in the result set is a *WorkspaceMembership.
+func (c *client) WorkspaceMembers(workspace string) (*PaginatedResultSet, error) {
+	u, err := url.Parse(fmt.Sprintf("/2.0/workspaces/%s/members", workspace))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing URL")
+	}
+
+	return NewPaginatedResultSet(u, func(ctx context.Context, req *http.Request) (*PageToken, []any, error) {
+		var page struct {
+			*PageToken
+			Values []*WorkspaceMembership `json:"values"`
+		}
+
+		if err := c.do(ctx, req, &page); err != nil {
+			return nil, nil, err
+		}
+
+		values := []any{}
+		for _, value := range page.Values {
+			values = append(values, value)
+		}
+
+		return page.PageToken, values, nil
+	}), nil
+}
+
+// WorkspaceMember returns the member of the given workspace whose nickname is
+// the given nickname, or nil if there is none.
+func (c *client) WorkspaceMember(ctx context.Context, workspace, nickname string) (*Account, error) {
+	u, err := url.Parse(fmt.Sprintf("/2.0/workspaces/%s/members", workspace))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing URL")
+	}
+	u.RawQuery = url.Values{
+		"q":       []string{fmt.Sprintf("user.nickname=%q", nickname)},
+		"pagelen": []string{"1"},
+	}.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+
+	var page struct {
+		Values []*WorkspaceMembership `json:"values"`
+	}
+	if err := c.do(ctx, req, &page); err != nil {
+		return nil, err
+	}
+
+	for _, m := range page.Values {
+		// The filter is applied by Bitbucket Cloud, but we don't want to rely on
+		// it to map a Sourcegraph user to a Bitbucket Cloud account.
+		if m.User.Nickname == nickname {
+			return &m.User, nil
+		}
+	}
+	return nil, nil
+}
+
+// RepoPermissions retrieves the explicit and inherited permissions of every
+// user in the workspace on the given repository. The authenticated user must be
+// an administrator of the workspace.
+//
+// Each item in the result set is a *RepoPermission.
+func (c *client) RepoPermissions(workspace, slug string) (*PaginatedResultSet, error) {
+	u, err := url.Parse(fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories/%s", workspace, slug))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing URL")
+	}
+
+	return c.repoPermissions(u), nil
+}
+
+// UserRepoPermissions retrieves the permissions of the user with the given
+// UUID on every repository in the workspace. The authenticated user must be an
+// administrator of the workspace.
+//
+// Each item in the result set is a *RepoPermission.
+func (c *client) UserRepoPermissions(workspace, userUUID string) (*PaginatedResultSet, error) {
+	u, err := url.Parse(fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories", workspace))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing URL")
+	}
+	u.RawQuery = url.Values{"q": []string{fmt.Sprintf("user.uuid=%q", userUUID)}}.Encode()
+
+	return c.repoPermissions(u), nil
+}
+
+func (c *client) repoPermissions(u *url.URL) *PaginatedResultSet {
+	return NewPaginatedResultSet(u, func(ctx context.Context, req *http.Request) (*PageToken, []any, error) {
+		var page struct {
+			*PageToken
+			Values []*RepoPermission `json:"values"`
+		}
+
+		if err := c.do(ctx, req, &page); err != nil {
+			return nil, nil, err
+		}
+
+		values := []any{}
+		for _, value := range page.Values {
+			values = append(values, value)
+		}
+
+		return page.PageToken, values, nil
+	})
+}
@@ -0,0 +1,91 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WorkspaceMembers(t *testing.T) {
+	// WHEN UPDATING: ensure the token in use is an administrator of
+	// https://bitbucket.org/sourcegraph-testing/.
+
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	rs, err := c.WorkspaceMembers("sourcegraph-testing")
+	require.NoError(t, err)
+
+	members, err := rs.All(ctx)
+	require.NoError(t, err)
+
+	var nicknames []string
+	for _, m := range members {
+		membership := m.(*WorkspaceMembership)
+		assert.Equal(t, "sourcegraph-testing", membership.Workspace.Slug)
+		nicknames = append(nicknames, membership.User.Nickname)
+	}
+	assert.Equal(t, []string{"Sourcegraph Testing", "alice", "bob"}, nicknames)
+}
+
+func TestClient_WorkspaceMember(t *testing.T) {
+	// WHEN UPDATING: ensure the token in use is an administrator of
+	// https://bitbucket.org/sourcegraph-testing/.
+
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	member, err := c.WorkspaceMember(ctx, "sourcegraph-testing", "alice")
+	require.NoError(t, err)
+	require.NotNil(t, member)
+	assert.Equal(t, "{2d0bb4e5-0c2a-4a63-9b0b-5b8b0c9e7d1a}", member.UUID)
+
+	member, err = c.WorkspaceMember(ctx, "sourcegraph-testing", "mallory")
+	require.NoError(t, err)
+	assert.Nil(t, member)
+}
+
+func TestClient_RepoPermissions(t *testing.T) {
+	// WHEN UPDATING: ensure the token in use is an administrator of
+	// https://bitbucket.org/sourcegraph-testing/.
+
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	rs, err := c.RepoPermissions("sourcegraph-testing", "src-cli")
+	require.NoError(t, err)
+
+	perms, err := rs.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, perms, 2)
+
+	perm := perms[1].(*RepoPermission)
+	assert.Equal(t, RepoPermissionLevelRead, perm.Permission)
+	assert.Equal(t, "{2d0bb4e5-0c2a-4a63-9b0b-5b8b0c9e7d1a}", perm.User.UUID)
+	assert.Equal(t, "sourcegraph-testing/src-cli", perm.Repo.FullName)
+}
+
+func TestClient_UserRepoPermissions(t *testing.T) {
+	// WHEN UPDATING: ensure the token in use is an administrator of
+	// https://bitbucket.org/sourcegraph-testing/.
+
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	rs, err := c.UserRepoPermissions("sourcegraph-testing", "{2d0bb4e5-0c2a-4a63-9b0b-5b8b0c9e7d1a}")
+	require.NoError(t, err)
+
+	perms, err := rs.All(ctx)
+	require.NoError(t, err)
+
+	var repos []string
+	for _, p := range perms {
+		repos = append(repos, p.(*RepoPermission).Repo.UUID)
+	}
+	assert.Equal(t, []string{
+		"{b090a669-ac7b-44cd-9610-02d027cb39f3}",
+		"{c9514714-1168-4b80-8de1-d3d8359cc293}",
+	}, repos)
+}
@@ -477,6 +477,25 @@ type UpdatePullRequestInput struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	ToRef       Ref    `json:"toRef"`
+
+	// Reviewers replaces the reviewers of the pull request, if set.
+	Reviewers []NamedReviewer `json:"reviewers,omitempty"`
+}
+
+// NamedReviewer is a minimal version of Reviewer identified by the name of its
+// user, to reduce the payload size sent when creating or updating a pull
+// request.
+type NamedReviewer struct {
+	User struct {
+		Name string `json:"name"`
+	} `json:"user"`
+}
+
+// NewNamedReviewer returns the NamedReviewer for the user with the given name.
+func NewNamedReviewer(name string) NamedReviewer {
+	var r NamedReviewer
+	r.User.Name = name
+	return r
 }
 
 func (c *Client) UpdatePullRequest(ctx context.Context, in *UpdatePullRequestInput) (*PullRequest, error) {
@@ -522,23 +541,16 @@ func (c *Client) CreatePullRequest(ctx context.Context, pr *PullRequest) error {
 		}
 	}
 
-	// Minimal version of Reviewer, to reduce payload size sent.
-	type reviewer struct {
-		User struct {
-			Name string `json:"name"`
-		} `json:"user"`
-	}
-
 	type requestBody struct {
-		Title       string     `json:"title"`
-		Description string     `json:"description"`
-		State       string     `json:"state"`
-		Open        bool       `json:"open"`
-		Closed      bool       `json:"closed"`
-		FromRef     Ref        `json:"fromRef"`
-		ToRef       Ref        `json:"toRef"`
-		Locked      bool       `json:"locked"`
-		Reviewers   []reviewer `json:"reviewers"`
+		Title       string          `json:"title"`
+		Description string          `json:"description"`
+		State       string          `json:"state"`
+		Open        bool            `json:"open"`
+		Closed      bool            `json:"closed"`
+		FromRef     Ref             `json:"fromRef"`
+		ToRef       Ref             `json:"toRef"`
+		Locked      bool            `json:"locked"`
+		Reviewers   []NamedReviewer `json:"reviewers"`
 	}
 
 	defaultReviewers, err := c.FetchDefaultReviewers(ctx, pr)
@@ -549,11 +561,23 @@ func (c *Client) CreatePullRequest(ctx context.Context, pr *PullRequest) error {
 		// return errors.Wrap(err, "fetching default reviewers")
 	}
 
-	reviewers := make([]reviewer, 0, len(defaultReviewers))
+	// The reviewers already set on the given pull request are requested in
+	// addition to the default reviewers.
+	seen := make(map[string]struct{}, len(defaultReviewers)+len(pr.Reviewers))
+	reviewers := make([]NamedReviewer, 0, len(defaultReviewers)+len(pr.Reviewers))
 	for _, r := range defaultReviewers {
-		reviewers = append(reviewers, reviewer{User: struct {
-			Name string `json:"name"`
-		}{Name: r}})
+		seen[r] = struct{}{}
+		reviewers = append(reviewers, NewNamedReviewer(r))
+	}
+	for _, r := range pr.Reviewers {
+		if r.User == nil {
+			continue
+		}
+		if _, ok := seen[r.User.Name]; ok {
+			continue
+		}
+		seen[r.User.Name] = struct{}{}
+		reviewers = append(reviewers, NewNamedReviewer(r.User.Name))
 	}
 
 	// Bitbucket Server doesn't support GFM taskitems. But since we might add
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -965,43 +966,93 @@ mutation MergePullRequest($input: MergePullRequestInput!) {
 }
 `
 
+// PullRequestMergeMethod is the method used to merge a pull request.
+type PullRequestMergeMethod string
+
+const (
+	PullRequestMergeMethodMerge  PullRequestMergeMethod = "MERGE"
+	PullRequestMergeMethodSquash PullRequestMergeMethod = "SQUASH"
+	PullRequestMergeMethodRebase PullRequestMergeMethod = "REBASE"
+)
+
 // MergePullRequest tries to merge the PullRequest on Github.
 func (c *V4Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash bool) error {
-	version := c.determineGitHubVersion(ctx)
-	prFragment, err := pullRequestFragments(version)
-	if err != nil {
+	mergeMethod := PullRequestMergeMethodMerge
+	if squash {
+		mergeMethod = PullRequestMergeMethodSquash
+	}
+	return c.MergePullRequestWithMethod(ctx, pr, mergeMethod)
+}
+
+// MergePullRequestWithMethod tries to merge the PullRequest on Github with the
+// given merge method.
+func (c *V4Client) MergePullRequestWithMethod(ctx context.Context, pr *PullRequest, mergeMethod PullRequestMergeMethod) error {
+	var result struct {
+		MergePullRequest pullRequestMutationPayload `json:"mergePullRequest"`
+	}
+	if err := c.mutatePullRequest(ctx, pr, mergePullRequestMutation, mergeMethod, &result); err != nil {
 		return err
 	}
+	return c.updatePullRequestFromMutation(ctx, pr, result.MergePullRequest)
+}
 
+const enablePullRequestAutoMergeMutation = `
+mutation EnablePullRequestAutoMerge($input: EnablePullRequestAutoMergeInput!) {
+  enablePullRequestAutoMerge(input: $input) {
+	  pullRequest {
+		  ...pr
+	  }
+  }
+}
+`
+
+// EnablePullRequestAutoMerge enables auto-merge on the PullRequest, so that
+// Github merges it with the given merge method once all its requirements are
+// met.
+func (c *V4Client) EnablePullRequestAutoMerge(ctx context.Context, pr *PullRequest, mergeMethod PullRequestMergeMethod) error {
 	var result struct {
-		MergePullRequest struct {
-			PullRequest struct {
-				PullRequest
-				Participants  struct{ Nodes []Actor }
-				TimelineItems TimelineItemConnection
-			} `json:"pullRequest"`
-		} `json:"mergePullRequest"`
+		EnablePullRequestAutoMerge pullRequestMutationPayload `json:"enablePullRequestAutoMerge"`
+	}
+	if err := c.mutatePullRequest(ctx, pr, enablePullRequestAutoMergeMutation, mergeMethod, &result); err != nil {
+		return err
 	}
+	return c.updatePullRequestFromMutation(ctx, pr, result.EnablePullRequestAutoMerge)
+}
 
-	mergeMethod := "MERGE"
-	if squash {
-		mergeMethod = "SQUASH"
+// pullRequestMutationPayload is the payload of the mutations that return the
+// mutated pull request.
+type pullRequestMutationPayload struct {
+	PullRequest struct {
+		PullRequest
+		Participants  struct{ Nodes []Actor }
+		TimelineItems TimelineItemConnection
+	} `json:"pullRequest"`
+}
+
+// mutatePullRequest runs a mutation that takes the ID of the pull request and
+// a merge method as its input.
+func (c *V4Client) mutatePullRequest(ctx context.Context, pr *PullRequest, mutation string, mergeMethod PullRequestMergeMethod, result any) error {
+	version := c.determineGitHubVersion(ctx)
+	prFragment, err := pullRequestFragments(version)
+	if err != nil {
+		return err
 	}
+
 	input := map[string]any{"input": struct {
-		PullRequestID string `json:"pullRequestId"`
-		MergeMethod   string `json:"mergeMethod,omitempty"`
+		PullRequestID string                 `json:"pullRequestId"`
+		MergeMethod   PullRequestMergeMethod `json:"mergeMethod,omitempty"`
 	}{
 		PullRequestID: pr.ID,
 		MergeMethod:   mergeMethod,
 	}}
-	if err := c.requestGraphQL(ctx, prFragment+"\n"+mergePullRequestMutation, input, &result); err != nil {
-		return err
-	}
+	return c.requestGraphQL(ctx, prFragment+"\n"+mutation, input, result)
+}
 
-	ti := result.MergePullRequest.PullRequest.TimelineItems
-	*pr = result.MergePullRequest.PullRequest.PullRequest
+func (c *V4Client) updatePullRequestFromMutation(ctx context.Context, pr *PullRequest, payload pullRequestMutationPayload) error {
+	ti := payload.PullRequest.TimelineItems
+	*pr = payload.PullRequest.PullRequest
 	pr.TimelineItems = ti.Nodes
-	pr.Participants = result.MergePullRequest.PullRequest.Participants.Nodes
+	pr.Participants = payload.PullRequest.Participants.Nodes
 
 	items, err := c.loadRemainingTimelineItems(ctx, pr.ID, ti.PageInfo)
 	if err != nil {
@@ -1011,6 +1062,238 @@ func (c *V4Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash
 	return nil
 }
 
+// PullRequestMetadataInput is the set of reviewers, labels, assignees and the
+// milestone to add to a pull request.
+type PullRequestMetadataInput struct {
+	// Reviewers and Assignees are user logins.
+	Reviewers []string
+	// TeamReviewers are team slugs, optionally prefixed with the login of the
+	// organization and a slash. Teams without an organization are looked up in
+	// the organization owning the repository.
+	TeamReviewers []string
+	// Labels are label names. They must exist in the repository.
+	Labels    []string
+	Assignees []string
+	// Milestone is the title of an open milestone of the repository.
+	Milestone string
+}
+
+// AddPullRequestMetadata requests reviews from the reviewers, adds the labels
+// and assignees to and sets the milestone of the given PullRequest in the
+// repository owner/name. Existing review requests, labels and assignees are
+// kept.
+func (c *V4Client) AddPullRequestMetadata(ctx context.Context, owner, name string, pr *PullRequest, in *PullRequestMetadataInput) error {
+	ids, err := c.resolvePullRequestMetadataIDs(ctx, owner, name, pr, in)
+	if err != nil {
+		return err
+	}
+
+	var (
+		params []string
+		fields []string
+		vars   = map[string]any{"pr": pr.ID}
+	)
+	if len(ids.reviewers) != 0 || len(ids.teamReviewers) != 0 {
+		params = append(params, "$reviewers: [ID!]", "$teamReviewers: [ID!]")
+		fields = append(fields, "requestReviews(input: {pullRequestId: $pr, userIds: $reviewers, teamIds: $teamReviewers, union: true}) { clientMutationId }")
+		vars["reviewers"] = ids.reviewers
+		vars["teamReviewers"] = ids.teamReviewers
+	}
+	if len(ids.labels) != 0 {
+		params = append(params, "$labels: [ID!]!")
+		fields = append(fields, "addLabelsToLabelable(input: {labelableId: $pr, labelIds: $labels}) { clientMutationId }")
+		vars["labels"] = ids.labels
+	}
+	if len(ids.assignees) != 0 {
+		params = append(params, "$assignees: [ID!]!")
+		fields = append(fields, "addAssigneesToAssignable(input: {assignableId: $pr, assigneeIds: $assignees}) { clientMutationId }")
+		vars["assignees"] = ids.assignees
+	}
+	if ids.milestone != "" {
+		params = append(params, "$milestone: ID")
+		fields = append(fields, "updatePullRequest(input: {pullRequestId: $pr, milestoneId: $milestone}) { clientMutationId }")
+		vars["milestone"] = ids.milestone
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	q := fmt.Sprintf("mutation AddPullRequestMetadata($pr: ID!, %s) {\n%s\n}", strings.Join(params, ", "), strings.Join(fields, "\n"))
+	return handlePullRequestError(c.requestGraphQL(ctx, q, vars, &struct{}{}))
+}
+
+// pullRequestMetadataIDs are the node IDs of the reviewers, labels, assignees
+// and milestone of a PullRequestMetadataInput.
+type pullRequestMetadataIDs struct {
+	reviewers     []string
+	teamReviewers []string
+	labels        []string
+	assignees     []string
+	milestone     string
+}
+
+// resolvePullRequestMetadataIDs looks up the node IDs of everything in the
+// given input in a single query. The author of the pull request is dropped
+// from the reviewers, since Github doesn't allow authors to review their own
+// pull requests.
+func (c *V4Client) resolvePullRequestMetadataIDs(ctx context.Context, owner, name string, pr *PullRequest, in *PullRequestMetadataInput) (*pullRequestMetadataIDs, error) {
+	var (
+		params = []string{"$owner: String!", "$name: String!"}
+		repo   []string
+		fields []string
+		vars   = map[string]any{"owner": owner, "name": name}
+	)
+
+	// Users are deduplicated across reviewers and assignees and looked up
+	// with the aliases u0, u1, ...
+	userAliases := map[string]string{}
+	for _, login := range append(append([]string{}, in.Reviewers...), in.Assignees...) {
+		if _, ok := userAliases[login]; ok {
+			continue
+		}
+		alias := fmt.Sprintf("u%d", len(userAliases))
+		userAliases[login] = alias
+		params = append(params, fmt.Sprintf("$%s: String!", alias))
+		fields = append(fields, fmt.Sprintf("%s: user(login: $%s) { id }", alias, alias))
+		vars[alias] = login
+	}
+	for i, team := range in.TeamReviewers {
+		org, slug := owner, team
+		if o, s, ok := strings.Cut(team, "/"); ok {
+			org, slug = o, s
+		}
+		params = append(params, fmt.Sprintf("$t%dOrg: String!, $t%d: String!", i, i))
+		fields = append(fields, fmt.Sprintf("t%d: organization(login: $t%dOrg) { team(slug: $t%d) { id } }", i, i, i))
+		vars[fmt.Sprintf("t%dOrg", i)] = org
+		vars[fmt.Sprintf("t%d", i)] = slug
+	}
+	for i, label := range in.Labels {
+		params = append(params, fmt.Sprintf("$l%d: String!", i))
+		repo = append(repo, fmt.Sprintf("l%d: label(name: $l%d) { id }", i, i))
+		vars[fmt.Sprintf("l%d", i)] = label
+	}
+	if in.Milestone != "" {
+		repo = append(repo, "milestones(first: 100, states: [OPEN]) { nodes { id title } }")
+	}
+	fields = append(fields, fmt.Sprintf("repository(owner: $owner, name: $name) {\nid\n%s\n}", strings.Join(repo, "\n")))
+
+	q := fmt.Sprintf("query ResolvePullRequestMetadata(%s) {\n%s\n}", strings.Join(params, ", "), strings.Join(fields, "\n"))
+
+	type node struct {
+		ID string `json:"id"`
+	}
+	var result map[string]json.RawMessage
+	if err := c.requestGraphQL(ctx, q, vars, &result); err != nil {
+		// Users and organizations that don't exist are reported as errors,
+		// but still resolve to null in the data, so we report them below
+		// together with all other missing fields.
+		var errs graphqlErrors
+		if !errors.As(err, &errs) {
+			return nil, err
+		}
+		for _, e := range errs {
+			if e.Type != graphqlErrTypeNotFound || len(e.Path) == 0 || e.Path[0] == "repository" {
+				return nil, err
+			}
+		}
+	}
+
+	decodeID := func(field string, raw json.RawMessage) (string, error) {
+		var n *node
+		if err := json.Unmarshal(raw, &n); err != nil {
+			return "", errors.Wrapf(err, "decoding %s", field)
+		}
+		if n == nil || n.ID == "" {
+			return "", nil
+		}
+		return n.ID, nil
+	}
+
+	var missing []string
+	ids := &pullRequestMetadataIDs{}
+
+	userIDs := map[string]string{}
+	for login, alias := range userAliases {
+		id, err := decodeID(alias, result[alias])
+		if err != nil {
+			return nil, err
+		}
+		if id == "" {
+			missing = append(missing, "user "+login)
+			continue
+		}
+		userIDs[login] = id
+	}
+	for _, login := range in.Reviewers {
+		if id, ok := userIDs[login]; ok && !strings.EqualFold(login, pr.Author.Login) {
+			ids.reviewers = append(ids.reviewers, id)
+		}
+	}
+	for _, login := range in.Assignees {
+		if id, ok := userIDs[login]; ok {
+			ids.assignees = append(ids.assignees, id)
+		}
+	}
+
+	for i, team := range in.TeamReviewers {
+		var org *struct {
+			Team *node `json:"team"`
+		}
+		if err := json.Unmarshal(result[fmt.Sprintf("t%d", i)], &org); err != nil {
+			return nil, errors.Wrap(err, "decoding team")
+		}
+		if org == nil || org.Team == nil {
+			missing = append(missing, "team "+team)
+			continue
+		}
+		ids.teamReviewers = append(ids.teamReviewers, org.Team.ID)
+	}
+
+	var repoResult map[string]json.RawMessage
+	if err := json.Unmarshal(result["repository"], &repoResult); err != nil {
+		return nil, errors.Wrap(err, "decoding repository")
+	}
+	for i, label := range in.Labels {
+		id, err := decodeID("label", repoResult[fmt.Sprintf("l%d", i)])
+		if err != nil {
+			return nil, err
+		}
+		if id == "" {
+			missing = append(missing, "label "+label)
+			continue
+		}
+		ids.labels = append(ids.labels, id)
+	}
+
+	if in.Milestone != "" {
+		var milestones struct {
+			Nodes []struct {
+				ID    string `json:"id"`
+				Title string `json:"title"`
+			} `json:"nodes"`
+		}
+		if err := json.Unmarshal(repoResult["milestones"], &milestones); err != nil {
+			return nil, errors.Wrap(err, "decoding milestones")
+		}
+		for _, m := range milestones.Nodes {
+			if m.Title == in.Milestone {
+				ids.milestone = m.ID
+				break
+			}
+		}
+		if ids.milestone == "" {
+			missing = append(missing, "milestone "+in.Milestone)
+		}
+	}
+
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		return nil, errors.Newf("not found in %s/%s: %s", owner, name, strings.Join(missing, ", "))
+	}
+
+	return ids, nil
+}
+
 func (c *V4Client) loadRemainingTimelineItems(ctx context.Context, prID string, pageInfo PageInfo) (items []TimelineItem, err error) {
 	version := c.determineGitHubVersion(ctx)
 	timelineItemTypes, err := timelineItemTypes(version)
@@ -1764,6 +2047,36 @@ type Repository struct {
 	// to identify if a repository is public or private or internal.
 	// https://developer.github.com/changes/2019-12-03-internal-visibility-changes/#repository-visibility-fields
 	Visibility Visibility `json:",omitempty"`
+
+	// Topics are the topics the repository has been tagged with.
+	Topics []string `json:",omitempty"`
+}
+
+// UnmarshalJSON unmarshals a Repository from either its stored representation
+// or a GraphQL response, which nests topics in repositoryTopics.
+func (r *Repository) UnmarshalJSON(data []byte) error {
+	type repository Repository
+	var v struct {
+		repository
+		RepositoryTopics *struct {
+			Nodes []struct {
+				Topic struct {
+					Name string
+				}
+			}
+		} `json:"repositoryTopics"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*r = Repository(v.repository)
+	if v.RepositoryTopics != nil {
+		for _, node := range v.RepositoryTopics.Nodes {
+			r.Topics = append(r.Topics, node.Topic.Name)
+		}
+	}
+	return nil
 }
 
 type restRepositoryPermissions struct {
@@ -1787,6 +2100,7 @@ type restRepository struct {
 	Stars       int                       `json:"stargazers_count"`
 	Forks       int                       `json:"forks_count"`
 	Visibility  string                    `json:"visibility"`
+	Topics      []string                  `json:"topics"`
 }
 
 // getRepositoryFromAPI attempts to fetch a repository from the GitHub API without use of the redis cache.
@@ -1824,6 +2138,10 @@ func convertRestRepo(restRepo restRepository) *Repository {
 		ForkCount:        restRepo.Forks,
 	}
 
+	if len(restRepo.Topics) > 0 {
+		repo.Topics = restRepo.Topics
+	}
+
 	if conf.ExperimentalFeatures().EnableGithubInternalRepoVisibility {
 		repo.Visibility = Visibility(restRepo.Visibility)
 	}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -53,7 +54,7 @@ func repoListsAreEqual(a []*Repository, b []*Repository) bool {
 		return false
 	}
 	for i := 0; i < len(a); i++ {
-		if *a[i] != *b[i] {
+		if !reflect.DeepEqual(*a[i], *b[i]) {
 			return false
 		}
 	}
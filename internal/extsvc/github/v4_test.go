@@ -873,6 +873,7 @@ func TestClient_GetReposByNameWithOwner(t *testing.T) {
 		IsDisabled:       true,
 		ViewerPermission: "ADMIN",
 		Visibility:       "private",
+		Topics:           []string{"clojure", "grapher"},
 	}
 
 	testCases := []struct {
@@ -911,7 +912,13 @@ func TestClient_GetReposByNameWithOwner(t *testing.T) {
       "isArchived": true,
       "isDisabled": true,
       "viewerPermission": "ADMIN",
-      "visibility": "private"
+      "visibility": "private",
+      "repositoryTopics": {
+        "nodes": [
+          {"topic": {"name": "clojure"}},
+          {"topic": {"name": "grapher"}}
+        ]
+      }
     }
   }
 }
@@ -1017,6 +1024,110 @@ func TestClient_GetReposByNameWithOwner(t *testing.T) {
 	}
 }
 
+func TestResolvePullRequestMetadataIDs(t *testing.T) {
+	in := &PullRequestMetadataInput{
+		Reviewers:     []string{"alice", "author"},
+		TeamReviewers: []string{"batch-changes", "other-org/reviewers"},
+		Labels:        []string{"batch-change"},
+		Assignees:     []string{"alice"},
+		Milestone:     "4.3",
+	}
+	pr := &PullRequest{ID: "pr", Author: Actor{Login: "author"}}
+
+	testCases := []struct {
+		name             string
+		mockResponseBody string
+		want             *pullRequestMetadataIDs
+		err              string
+	}{
+		{
+			name: "found",
+			mockResponseBody: `
+{
+  "data": {
+    "u0": {"id": "alice-id"},
+    "u1": {"id": "author-id"},
+    "t0": {"team": {"id": "batch-changes-id"}},
+    "t1": {"team": {"id": "reviewers-id"}},
+    "repository": {
+      "id": "repo-id",
+      "l0": {"id": "label-id"},
+      "milestones": {"nodes": [{"id": "other-milestone-id", "title": "4.2"}, {"id": "milestone-id", "title": "4.3"}]}
+    }
+  }
+}
+`,
+			want: &pullRequestMetadataIDs{
+				reviewers:     []string{"alice-id"},
+				teamReviewers: []string{"batch-changes-id", "reviewers-id"},
+				labels:        []string{"label-id"},
+				assignees:     []string{"alice-id"},
+				milestone:     "milestone-id",
+			},
+		},
+		{
+			name: "not found",
+			mockResponseBody: `
+{
+  "data": {
+    "u0": null,
+    "u1": {"id": "author-id"},
+    "t0": {"team": null},
+    "t1": {"team": {"id": "reviewers-id"}},
+    "repository": {
+      "id": "repo-id",
+      "l0": null,
+      "milestones": {"nodes": []}
+    }
+  },
+  "errors": [
+    {
+      "type": "NOT_FOUND",
+      "path": ["u0"],
+      "message": "Could not resolve to a User with the login of 'alice'."
+    }
+  ]
+}
+`,
+			err: "not found in sourcegraph/sourcegraph: label batch-change, milestone 4.3, team batch-changes, user alice",
+		},
+		{
+			name: "repository not found",
+			mockResponseBody: `
+{
+  "data": {"repository": null},
+  "errors": [
+    {
+      "type": "NOT_FOUND",
+      "path": ["repository"],
+      "message": "Could not resolve to a Repository with the name 'sourcegraph/sourcegraph'."
+    }
+  ]
+}
+`,
+			err: "error in GraphQL response: Could not resolve to a Repository with the name 'sourcegraph/sourcegraph'.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mockHTTPResponseBody{responseBody: tc.mockResponseBody}
+			apiURL := &url.URL{Scheme: "https", Host: "example.com", Path: "/"}
+			c := NewV4Client("Test", apiURL, nil, &mock)
+
+			ids, err := c.resolvePullRequestMetadataIDs(context.Background(), "sourcegraph", "sourcegraph", pr, in)
+			if have, want := fmt.Sprint(err), fmt.Sprint(tc.err); tc.err != "" && have != want {
+				t.Fatalf("error:\nhave: %v\nwant: %v", have, want)
+			}
+			if tc.err == "" && err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.want, ids)
+		})
+	}
+}
+
 func TestClient_buildGetRepositoriesBatchQuery(t *testing.T) {
 	repos := []string{
 		"sourcegraph/grapher-tutorial",
@@ -47,6 +47,8 @@ type MergeRequest struct {
 	WorkInProgress         bool              `json:"work_in_progress"`
 	Draft                  bool              `json:"draft"`
 	Author                 User              `json:"author"`
+	Assignees              []User            `json:"assignees"`
+	Reviewers              []User            `json:"reviewers"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
@@ -221,6 +223,14 @@ type UpdateMergeRequestOpts struct {
 	Title        string                       `json:"title,omitempty"`
 	Description  string                       `json:"description,omitempty"`
 	StateEvent   UpdateMergeRequestStateEvent `json:"state_event,omitempty"`
+
+	// AddLabels is a comma-separated list of labels to add to the merge
+	// request. AssigneeIDs and ReviewerIDs replace the current assignees and
+	// reviewers.
+	AddLabels   string  `json:"add_labels,omitempty"`
+	AssigneeIDs []int32 `json:"assignee_ids,omitempty"`
+	ReviewerIDs []int32 `json:"reviewer_ids,omitempty"`
+	MilestoneID ID      `json:"milestone_id,omitempty"`
 }
 
 type UpdateMergeRequestStateEvent string
@@ -275,11 +285,24 @@ func (c *Client) MergeMergeRequest(ctx context.Context, project *Project, mr *Me
 		return MockMergeMergeRequest(c, ctx, project, mr, squash)
 	}
 
+	return c.mergeMergeRequest(ctx, project, mr, squash, false)
+}
+
+// MergeMergeRequestWhenPipelineSucceeds makes GitLab merge the merge request
+// once its pipeline succeeds. If the pipeline already succeeded, the merge
+// request is merged right away.
+func (c *Client) MergeMergeRequestWhenPipelineSucceeds(ctx context.Context, project *Project, mr *MergeRequest, squash bool) (*MergeRequest, error) {
+	return c.mergeMergeRequest(ctx, project, mr, squash, true)
+}
+
+func (c *Client) mergeMergeRequest(ctx context.Context, project *Project, mr *MergeRequest, squash, whenPipelineSucceeds bool) (*MergeRequest, error) {
 	payload := struct {
-		Squash              bool   `json:"squash,omitempty"`
-		SquashCommitMessage string `json:"squash_commit_message,omitempty"`
+		Squash                    bool   `json:"squash,omitempty"`
+		SquashCommitMessage       string `json:"squash_commit_message,omitempty"`
+		MergeWhenPipelineSucceeds bool   `json:"merge_when_pipeline_succeeds,omitempty"`
 	}{
-		Squash: squash,
+		Squash:                    squash,
+		MergeWhenPipelineSucceeds: whenPipelineSucceeds,
 	}
 	if squash {
 		payload.SquashCommitMessage = mr.Title + "\n\n" + mr.Description
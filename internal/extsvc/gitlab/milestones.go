@@ -0,0 +1,49 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type Milestone struct {
+	ID    ID     `json:"id"`
+	IID   ID     `json:"iid"`
+	Title string `json:"title"`
+	State string `json:"state"`
+}
+
+// ErrMilestoneNotFound is returned by GetProjectMilestoneByTitle if the
+// project has no active milestone with the given title.
+var ErrMilestoneNotFound = errors.New("milestone not found")
+
+// GetProjectMilestoneByTitle returns the active milestone with the given title
+// of the project or of one of its parent groups.
+func (c *Client) GetProjectMilestoneByTitle(ctx context.Context, project *Project, title string) (*Milestone, error) {
+	if MockGetProjectMilestoneByTitle != nil {
+		return MockGetProjectMilestoneByTitle(c, ctx, project, title)
+	}
+
+	values := url.Values{
+		"title":                     {title},
+		"state":                     {"active"},
+		"include_parent_milestones": {"true"},
+	}
+	u := &url.URL{Path: fmt.Sprintf("projects/%d/milestones", project.ID), RawQuery: values.Encode()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to get milestone")
+	}
+
+	var milestones []*Milestone
+	if _, _, err := c.do(ctx, req, &milestones); err != nil {
+		return nil, errors.Wrap(err, "sending request to get milestone")
+	}
+	if len(milestones) == 0 {
+		return nil, errors.Wrap(ErrMilestoneNotFound, title)
+	}
+	return milestones[0], nil
+}
@@ -11,6 +11,9 @@ var MockListUsers func(c *Client, ctx context.Context, urlStr string) (users []*
 // MockGetUser, if non-nil, will be called instead of Client.GetUser
 var MockGetUser func(c *Client, ctx context.Context, id string) (*User, error)
 
+// MockGetUserByUsername, if non-nil, will be called instead of Client.GetUserByUsername
+var MockGetUserByUsername func(c *Client, ctx context.Context, username string) (*User, error)
+
 // MockGetProject, if non-nil, will be called instead of Client.GetProject
 var MockGetProject func(c *Client, ctx context.Context, op GetProjectOp) (*Project, error)
 
@@ -53,5 +56,9 @@ var MockMergeMergeRequest func(c *Client, ctx context.Context, project *Project,
 // Client.CreateMergeRequestNote
 var MockCreateMergeRequestNote func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, body string) error
 
+// MockGetProjectMilestoneByTitle, if non-nil, will be called instead of
+// Client.GetProjectMilestoneByTitle
+var MockGetProjectMilestoneByTitle func(c *Client, ctx context.Context, project *Project, title string) (*Milestone, error)
+
 // MockGetVersion, if non-nil, will be called instead of Client.GetVersion
 var MockGetVersion func(ctx context.Context) (string, error)
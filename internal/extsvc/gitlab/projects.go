@@ -34,6 +34,7 @@ type Project struct {
 	Archived          bool           `json:"archived"`
 	StarCount         int            `json:"star_count"`
 	ForksCount        int            `json:"forks_count"`
+	Topics            []string       `json:"topics,omitempty"` // Topics the project has been tagged with
 }
 
 type ProjectCommon struct {
@@ -69,6 +70,21 @@ func (pc *ProjectCommon) Namespace() (string, error) {
 	return strings.Join(parts[0:len(parts)-1], "/"), nil
 }
 
+// Namespaces returns the full path of every namespace the project belongs to,
+// from the top-level group down to its direct parent ("a", "a/b", "a/b/c").
+func (pc *ProjectCommon) Namespaces() ([]string, error) {
+	parts := strings.Split(pc.PathWithNamespace, "/")
+	if len(parts) < 2 {
+		return nil, errors.New("path with namespace does not include any namespaces")
+	}
+
+	namespaces := make([]string, 0, len(parts)-1)
+	for i := 1; i < len(parts); i++ {
+		namespaces = append(namespaces, strings.Join(parts[:i], "/"))
+	}
+	return namespaces, nil
+}
+
 // RequiresAuthentication reports whether this project requires authentication to view (i.e., its visibility is
 // "private" or "internal").
 func (p Project) RequiresAuthentication() bool {
@@ -232,3 +232,40 @@ func TestProjectCommon_Namespace(t *testing.T) {
 		}
 	})
 }
+
+func TestProjectCommon_Namespaces(t *testing.T) {
+	t.Run("errors", func(t *testing.T) {
+		for name, pc := range map[string]ProjectCommon{
+			"empty":      {PathWithNamespace: ""},
+			"no slashes": {PathWithNamespace: "foo"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				namespaces, err := pc.Namespaces()
+				assert.Nil(t, namespaces)
+				assert.NotNil(t, err)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			pc   ProjectCommon
+			want []string
+		}{
+			"single namespace": {
+				pc:   ProjectCommon{PathWithNamespace: "foo/bar"},
+				want: []string{"foo"},
+			},
+			"nested namespaces": {
+				pc:   ProjectCommon{PathWithNamespace: "foo/bar/quux/baz"},
+				want: []string{"foo", "foo/bar", "foo/bar/quux"},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				namespaces, err := tc.pc.Namespaces()
+				assert.Nil(t, err)
+				assert.Equal(t, tc.want, namespaces)
+			})
+		}
+	})
+}
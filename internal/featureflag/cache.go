@@ -1,8 +1,8 @@
 package featureflag
 
 import (
+	"encoding/json"
 	"fmt"
-	"strconv"
 
 	"github.com/gomodule/redigo/redis"
 
@@ -32,11 +32,19 @@ func getEvaluatedFlagSetFromCache(flagsSet *FlagSet) EvaluatedFlagSet {
 			evaluatedFlagSet[k] = value
 		}
 	}
+	for k := range flagsSet.variants {
+		if value, err := redis.Bytes(c.Do("HGET", getFlagCacheKey(k), visitorID)); err == nil {
+			evaluatedFlagSet[k] = json.RawMessage(value)
+		}
+	}
 
 	return evaluatedFlagSet
 }
 
-func setEvaluatedFlagToCache(a *actor.Actor, flagName string, value bool) {
+// setEvaluatedFlagToCache stores the evaluated value of a flag for the actor.
+// The value is "true" or "false" for boolean flags and the JSON value for
+// variant flags.
+func setEvaluatedFlagToCache(a *actor.Actor, flagName string, value string) {
 	c := pool.Get()
 	defer c.Close()
 
@@ -48,7 +56,7 @@ func setEvaluatedFlagToCache(a *actor.Actor, flagName string, value bool) {
 		return
 	}
 
-	c.Do("HSET", getFlagCacheKey(flagName), visitorID, strconv.FormatBool(value))
+	c.Do("HSET", getFlagCacheKey(flagName), visitorID, value)
 }
 
 func getVisitorIDForActor(a *actor.Actor) (string, error) {
@@ -2,8 +2,12 @@ package featureflag
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"hash/fnv"
+	"strings"
 	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type FeatureFlag struct {
@@ -13,23 +17,43 @@ type FeatureFlag struct {
 	// Exactly one of the following will be set.
 	Bool    *FeatureFlagBool
 	Rollout *FeatureFlagRollout
+	Variant *FeatureFlagVariant
+
+	// Rules are evaluated in order before the value of the flag type above.
+	// The value of the first rule that matches the user is used.
+	Rules []*TargetingRule
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt *time.Time
 }
 
-// EvaluateForUser evaluates the feature flag for a userID.
-func (f *FeatureFlag) EvaluateForUser(userID int32) bool {
+// EvaluateForUser evaluates the feature flag for a user. It must not be
+// called for variant flags, see EvaluateVariantForUser.
+func (f *FeatureFlag) EvaluateForUser(u *User) bool {
+	if rule := f.matchRule(u); rule != nil {
+		return rule.boolValue()
+	}
 	switch {
 	case f.Bool != nil:
 		return f.Bool.Value
 	case f.Rollout != nil:
-		return hashUserAndFlag(userID, f.Name)%10000 < uint32(f.Rollout.Rollout)
+		return hashUserAndFlag(u.ID, f.Name)%10000 < uint32(f.Rollout.Rollout)
 	}
 	panic("one of Bool or Rollout must be set")
 }
 
+// EvaluateVariantForUser evaluates the variant flag for a user.
+func (f *FeatureFlag) EvaluateVariantForUser(u *User) json.RawMessage {
+	if rule := f.matchRule(u); rule != nil {
+		return rule.Value
+	}
+	if f.Variant == nil {
+		panic("Variant must be set")
+	}
+	return f.Variant.Value
+}
+
 func hashUserAndFlag(userID int32, flagName string) uint32 {
 	h := fnv.New32()
 	binary.Write(h, binary.LittleEndian, userID)
@@ -38,7 +62,11 @@ func hashUserAndFlag(userID int32, flagName string) uint32 {
 }
 
 // EvaluateForAnonymousUser evaluates the feature flag for an anonymous user ID.
+// It must not be called for variant flags, see EvaluateVariantForAnonymousUser.
 func (f *FeatureFlag) EvaluateForAnonymousUser(anonymousUID string) bool {
+	if rule := f.matchRule(nil); rule != nil {
+		return rule.boolValue()
+	}
 	switch {
 	case f.Bool != nil:
 		return f.Bool.Value
@@ -48,6 +76,12 @@ func (f *FeatureFlag) EvaluateForAnonymousUser(anonymousUID string) bool {
 	panic("one of Bool or Rollout must be set")
 }
 
+// EvaluateVariantForAnonymousUser evaluates the variant flag for an anonymous
+// user.
+func (f *FeatureFlag) EvaluateVariantForAnonymousUser() json.RawMessage {
+	return f.EvaluateVariantForUser(nil)
+}
+
 func hashAnonymousUserAndFlag(anonymousUID, flagName string) uint32 {
 	h := fnv.New32()
 	h.Write([]byte(anonymousUID))
@@ -58,7 +92,13 @@ func hashAnonymousUserAndFlag(anonymousUID, flagName string) uint32 {
 // EvaluateGlobal returns the evaluated feature flag for a global context (no user
 // is associated with the request). If the flag is not evaluatable in the global context
 // (i.e. the flag type is a rollout), then the second parameter will return false.
+//
+// Targeting rules are evaluated as for an anonymous user, so only rules that
+// do not require an authenticated user apply.
 func (f *FeatureFlag) EvaluateGlobal() (res bool, ok bool) {
+	if rule := f.matchRule(nil); rule != nil {
+		return rule.boolValue(), true
+	}
 	switch {
 	case f.Bool != nil:
 		return f.Bool.Value, true
@@ -67,6 +107,42 @@ func (f *FeatureFlag) EvaluateGlobal() (res bool, ok bool) {
 	return false, false
 }
 
+// EvaluateVariantGlobal returns the value of the variant flag for a global
+// context. Targeting rules are evaluated as for an anonymous user.
+func (f *FeatureFlag) EvaluateVariantGlobal() (json.RawMessage, bool) {
+	if f.Variant == nil {
+		return nil, false
+	}
+	return f.EvaluateVariantForUser(nil), true
+}
+
+// ValidateRules returns an error if the value of a targeting rule does not fit
+// the type of the flag.
+func (f *FeatureFlag) ValidateRules() error {
+	for i, rule := range f.Rules {
+		if f.Variant != nil {
+			if !json.Valid(rule.Value) {
+				return errors.Errorf("rule %d: value must be valid JSON", i)
+			}
+			continue
+		}
+		var v bool
+		if err := json.Unmarshal(rule.Value, &v); err != nil {
+			return errors.Errorf("rule %d: value must be a boolean", i)
+		}
+	}
+	return nil
+}
+
+func (f *FeatureFlag) matchRule(u *User) *TargetingRule {
+	for _, rule := range f.Rules {
+		if rule.Matches(u) {
+			return rule
+		}
+	}
+	return nil
+}
+
 type FeatureFlagBool struct {
 	Value bool
 }
@@ -78,6 +154,105 @@ type FeatureFlagRollout struct {
 	Rollout int32
 }
 
+type FeatureFlagVariant struct {
+	// Value is any JSON value, for example a string or an object holding a
+	// tuning value.
+	Value json.RawMessage
+}
+
+// User holds the attributes of a user which targeting rules are evaluated
+// against.
+type User struct {
+	ID        int32
+	SiteAdmin bool
+	OrgIDs    []int32
+	// VerifiedEmails are the verified email addresses of the user.
+	VerifiedEmails []string
+}
+
+// TargetingRule sets the value of a feature flag for the users matching all
+// of its predicates. Unset predicates match every user, so a rule without
+// predicates matches everyone.
+type TargetingRule struct {
+	// Authenticated matches authenticated users if true and anonymous users
+	// if false.
+	Authenticated *bool `json:"authenticated,omitempty"`
+	// SiteAdmin matches users by their site admin status. Anonymous users are
+	// not site admins.
+	SiteAdmin *bool `json:"siteAdmin,omitempty"`
+	// UserIDs matches any of the given users.
+	UserIDs []int32 `json:"userIDs,omitempty"`
+	// OrgIDs matches members of any of the given organizations.
+	OrgIDs []int32 `json:"orgIDs,omitempty"`
+	// EmailDomains matches users with a verified email address at any of the
+	// given domains, e.g. "contractor.com".
+	EmailDomains []string `json:"emailDomains,omitempty"`
+
+	// Value is the value of the flag for matching users. It is a JSON boolean
+	// for bool and rollout flags.
+	Value json.RawMessage `json:"value"`
+}
+
+// Matches returns true if u matches all predicates of the rule. A nil user is
+// an anonymous user.
+func (r *TargetingRule) Matches(u *User) bool {
+	if r.Authenticated != nil && *r.Authenticated != (u != nil) {
+		return false
+	}
+	if r.SiteAdmin != nil && *r.SiteAdmin != (u != nil && u.SiteAdmin) {
+		return false
+	}
+	if len(r.UserIDs) > 0 && (u == nil || !containsInt32(r.UserIDs, u.ID)) {
+		return false
+	}
+	if len(r.OrgIDs) > 0 && (u == nil || !containsAnyInt32(r.OrgIDs, u.OrgIDs)) {
+		return false
+	}
+	if len(r.EmailDomains) > 0 && (u == nil || !hasEmailAtDomain(u.VerifiedEmails, r.EmailDomains)) {
+		return false
+	}
+	return true
+}
+
+func (r *TargetingRule) boolValue() bool {
+	var v bool
+	_ = json.Unmarshal(r.Value, &v)
+	return v
+}
+
+func containsInt32(ids []int32, id int32) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAnyInt32(ids, others []int32) bool {
+	for _, id := range others {
+		if containsInt32(ids, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEmailAtDomain(emails, domains []string) bool {
+	for _, email := range emails {
+		i := strings.LastIndexByte(email, '@')
+		if i < 0 {
+			continue
+		}
+		for _, domain := range domains {
+			if strings.EqualFold(email[i+1:], strings.TrimPrefix(domain, "@")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 type Override struct {
 	UserID   *int32
 	OrgID    *int32
@@ -0,0 +1,107 @@
+package featureflag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateWithRules(t *testing.T) {
+	yes, no := true, false
+	flag := &FeatureFlag{
+		Name: "flag",
+		Bool: &FeatureFlagBool{Value: false},
+		Rules: []*TargetingRule{
+			{SiteAdmin: &yes, Value: json.RawMessage(`true`)},
+			{OrgIDs: []int32{2}, Value: json.RawMessage(`true`)},
+			{EmailDomains: []string{"@contractor.com"}, Value: json.RawMessage(`true`)},
+			{UserIDs: []int32{5}, Value: json.RawMessage(`true`)},
+			{Authenticated: &no, Value: json.RawMessage(`true`)},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		user *User
+		want bool
+	}{
+		"site admin":              {user: &User{ID: 1, SiteAdmin: true}, want: true},
+		"org member":              {user: &User{ID: 1, OrgIDs: []int32{1, 2}}, want: true},
+		"not an org member":       {user: &User{ID: 1, OrgIDs: []int32{1}}, want: false},
+		"verified email domain":   {user: &User{ID: 1, VerifiedEmails: []string{"a@Contractor.COM"}}, want: true},
+		"email subdomain":         {user: &User{ID: 1, VerifiedEmails: []string{"a@eu.contractor.com"}}, want: false},
+		"user ID":                 {user: &User{ID: 5}, want: true},
+		"no matching rule":        {user: &User{ID: 1}, want: false},
+		"anonymous is not a user": {user: nil, want: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var got bool
+			if tc.user == nil {
+				got = flag.EvaluateForAnonymousUser("anon")
+			} else {
+				got = flag.EvaluateForUser(tc.user)
+			}
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("rules are ordered", func(t *testing.T) {
+		flag := &FeatureFlag{
+			Name:    "flag",
+			Rollout: &FeatureFlagRollout{Rollout: 10000},
+			Rules: []*TargetingRule{
+				{SiteAdmin: &yes, Value: json.RawMessage(`false`)},
+				{SiteAdmin: &yes, OrgIDs: []int32{1}, Value: json.RawMessage(`true`)},
+			},
+		}
+		require.False(t, flag.EvaluateForUser(&User{ID: 1, SiteAdmin: true, OrgIDs: []int32{1}}))
+		require.True(t, flag.EvaluateForUser(&User{ID: 1, OrgIDs: []int32{1}}))
+	})
+
+	t.Run("global", func(t *testing.T) {
+		v, ok := flag.EvaluateGlobal()
+		require.True(t, ok)
+		require.True(t, v)
+
+		rollout := &FeatureFlag{
+			Name:    "flag",
+			Rollout: &FeatureFlagRollout{Rollout: 5000},
+			Rules:   []*TargetingRule{{Authenticated: &yes, Value: json.RawMessage(`true`)}},
+		}
+		_, ok = rollout.EvaluateGlobal()
+		require.False(t, ok)
+	})
+}
+
+func TestEvaluateVariant(t *testing.T) {
+	yes := true
+	flag := &FeatureFlag{
+		Name:    "tuning",
+		Variant: &FeatureFlagVariant{Value: json.RawMessage(`{"limit":10}`)},
+		Rules: []*TargetingRule{
+			{Authenticated: &yes, SiteAdmin: &yes, Value: json.RawMessage(`{"limit":100}`)},
+		},
+	}
+
+	require.JSONEq(t, `{"limit":100}`, string(flag.EvaluateVariantForUser(&User{ID: 1, SiteAdmin: true})))
+	require.JSONEq(t, `{"limit":10}`, string(flag.EvaluateVariantForUser(&User{ID: 1})))
+	require.JSONEq(t, `{"limit":10}`, string(flag.EvaluateVariantForAnonymousUser()))
+
+	v, ok := flag.EvaluateVariantGlobal()
+	require.True(t, ok)
+	require.JSONEq(t, `{"limit":10}`, string(v))
+}
+
+func TestValidateRules(t *testing.T) {
+	boolFlag := &FeatureFlag{
+		Bool:  &FeatureFlagBool{},
+		Rules: []*TargetingRule{{Value: json.RawMessage(`"yes"`)}},
+	}
+	require.Error(t, boolFlag.ValidateRules())
+
+	variantFlag := &FeatureFlag{
+		Variant: &FeatureFlagVariant{Value: json.RawMessage(`"a"`)},
+		Rules:   []*TargetingRule{{Value: json.RawMessage(`"b"`)}},
+	}
+	require.NoError(t, variantFlag.ValidateRules())
+}
@@ -3,6 +3,7 @@ package featureflag
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -10,14 +11,20 @@ import (
 
 // Current feature flags requested by backend/frontend for the current actor
 //
-// For telemetry/tracking purposes
-type EvaluatedFlagSet map[string]bool
+// For telemetry/tracking purposes. Values are a bool for boolean flags and
+// the JSON value for variant flags.
+type EvaluatedFlagSet map[string]any
 
 func (f EvaluatedFlagSet) String() string {
 	var sb strings.Builder
 	for k, v := range f {
-		if v {
-			fmt.Fprintf(&sb, "%q: %v\n", k, v)
+		switch v := v.(type) {
+		case bool:
+			if v {
+				fmt.Fprintf(&sb, "%q: %v\n", k, v)
+			}
+		case json.RawMessage:
+			fmt.Fprintf(&sb, "%q: %s\n", k, v)
 		}
 	}
 	return sb.String()
@@ -33,8 +40,9 @@ func (f EvaluatedFlagSet) Json() json.RawMessage {
 
 // Feature flags for the current actor
 type FlagSet struct {
-	flags map[string]bool
-	actor *actor.Actor
+	flags    map[string]bool
+	variants map[string]json.RawMessage
+	actor    *actor.Actor
 }
 
 // Returns (flagValue, true) if flag exist, otherwise (false, false)
@@ -44,7 +52,7 @@ func (f *FlagSet) GetBool(flag string) (bool, bool) {
 	}
 	v, ok := f.flags[flag]
 	if ok {
-		setEvaluatedFlagToCache(f.actor, flag, v)
+		setEvaluatedFlagToCache(f.actor, flag, strconv.FormatBool(v))
 	}
 	return v, ok
 }
@@ -57,6 +65,28 @@ func (f *FlagSet) GetBoolOr(flag string, defaultVal bool) bool {
 	return defaultVal
 }
 
+// Returns (variantValue, true) if the variant flag exists, otherwise (nil, false)
+func (f *FlagSet) GetVariant(flag string) (json.RawMessage, bool) {
+	if f == nil {
+		return nil, false
+	}
+	v, ok := f.variants[flag]
+	if ok {
+		setEvaluatedFlagToCache(f.actor, flag, string(v))
+	}
+	return v, ok
+}
+
+// Unmarshals the value of the variant flag into v. Returns false and leaves v
+// untouched if the flag doesn't exist or its value does not fit v.
+func (f *FlagSet) GetVariantInto(flag string, v any) bool {
+	raw, ok := f.GetVariant(flag)
+	if !ok {
+		return false
+	}
+	return json.Unmarshal(raw, v) == nil
+}
+
 func (f *FlagSet) String() string {
 	var sb strings.Builder
 	if f == nil {
@@ -67,5 +97,8 @@ func (f *FlagSet) String() string {
 			fmt.Fprintf(&sb, "%q: %v\n", k, v)
 		}
 	}
+	for k, v := range f.variants {
+		fmt.Fprintf(&sb, "%q: %s\n", k, v)
+	}
 	return sb.String()
 }
@@ -1,6 +1,9 @@
 package featureflag
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 // NewMemoryStore returns a Store that can be used in tests. It is initialized
 // with user, anonymous user, and global feature flags.
@@ -39,3 +42,15 @@ func (m *memoryStore) GetAnonymousUserFlags(context.Context, string) (map[string
 func (m *memoryStore) GetGlobalFeatureFlags(context.Context) (map[string]bool, error) {
 	return m.globalFlags, nil
 }
+
+func (m *memoryStore) GetUserFlagVariants(context.Context, int32) (map[string]json.RawMessage, error) {
+	return nil, nil
+}
+
+func (m *memoryStore) GetAnonymousUserFlagVariants(context.Context, string) (map[string]json.RawMessage, error) {
+	return nil, nil
+}
+
+func (m *memoryStore) GetGlobalFlagVariants(context.Context) (map[string]json.RawMessage, error) {
+	return nil, nil
+}
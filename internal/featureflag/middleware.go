@@ -2,9 +2,12 @@ package featureflag
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"sync"
 
+	"github.com/sourcegraph/log"
+
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 )
 
@@ -14,6 +17,9 @@ type Store interface {
 	GetUserFlags(context.Context, int32) (map[string]bool, error)
 	GetAnonymousUserFlags(context.Context, string) (map[string]bool, error)
 	GetGlobalFeatureFlags(context.Context) (map[string]bool, error)
+	GetUserFlagVariants(context.Context, int32) (map[string]json.RawMessage, error)
+	GetAnonymousUserFlagVariants(context.Context, string) (map[string]json.RawMessage, error)
+	GetGlobalFlagVariants(context.Context) (map[string]json.RawMessage, error)
 }
 
 // Middleware evaluates the feature flags for the current user and adds the
@@ -68,7 +74,11 @@ func (f *flagSetFetcher) fetchForActor(ctx context.Context, a *actor.Actor) *Fla
 	if a.IsAuthenticated() {
 		flags, err := f.ffs.GetUserFlags(ctx, a.UID)
 		if err == nil {
-			return &FlagSet{flags: flags, actor: f.actor}
+			// Variant flags are best effort, we still want the boolean flags
+			// if they fail to evaluate.
+			variants, err := f.ffs.GetUserFlagVariants(ctx, a.UID)
+			logVariantsError(err)
+			return &FlagSet{flags: flags, variants: variants, actor: f.actor}
 		}
 		// Continue if err != nil
 	}
@@ -76,19 +86,29 @@ func (f *flagSetFetcher) fetchForActor(ctx context.Context, a *actor.Actor) *Fla
 	if a.AnonymousUID != "" {
 		flags, err := f.ffs.GetAnonymousUserFlags(ctx, a.AnonymousUID)
 		if err == nil {
-			return &FlagSet{flags: flags, actor: f.actor}
+			variants, err := f.ffs.GetAnonymousUserFlagVariants(ctx, a.AnonymousUID)
+			logVariantsError(err)
+			return &FlagSet{flags: flags, variants: variants, actor: f.actor}
 		}
 		// Continue if err != nil
 	}
 
 	flags, err := f.ffs.GetGlobalFeatureFlags(ctx)
 	if err == nil {
-		return &FlagSet{flags: flags, actor: f.actor}
+		variants, err := f.ffs.GetGlobalFlagVariants(ctx)
+		logVariantsError(err)
+		return &FlagSet{flags: flags, variants: variants, actor: f.actor}
 	}
 
 	return &FlagSet{actor: f.actor}
 }
 
+func logVariantsError(err error) {
+	if err != nil {
+		log.Scoped("featureflag", "feature flag evaluation").Warn("failed to evaluate variant flags", log.Error(err))
+	}
+}
+
 // FromContext retrieves the current set of flags from the current
 // request's context.
 func FromContext(ctx context.Context) *FlagSet {
@@ -8,6 +8,7 @@ package featureflag
 
 import (
 	"context"
+	"encoding/json"
 	"sync"
 )
 
@@ -15,12 +16,22 @@ import (
 // package github.com/sourcegraph/sourcegraph/internal/featureflag) used for
 // unit testing.
 type MockStore struct {
+	// GetAnonymousUserFlagVariantsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetAnonymousUserFlagVariants.
+	GetAnonymousUserFlagVariantsFunc *StoreGetAnonymousUserFlagVariantsFunc
 	// GetAnonymousUserFlagsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetAnonymousUserFlags.
 	GetAnonymousUserFlagsFunc *StoreGetAnonymousUserFlagsFunc
 	// GetGlobalFeatureFlagsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetGlobalFeatureFlags.
 	GetGlobalFeatureFlagsFunc *StoreGetGlobalFeatureFlagsFunc
+	// GetGlobalFlagVariantsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetGlobalFlagVariants.
+	GetGlobalFlagVariantsFunc *StoreGetGlobalFlagVariantsFunc
+	// GetUserFlagVariantsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUserFlagVariants.
+	GetUserFlagVariantsFunc *StoreGetUserFlagVariantsFunc
 	// GetUserFlagsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetUserFlags.
 	GetUserFlagsFunc *StoreGetUserFlagsFunc
@@ -30,6 +41,11 @@ type MockStore struct {
 // return zero values for all results, unless overwritten.
 func NewMockStore() *MockStore {
 	return &MockStore{
+		GetAnonymousUserFlagVariantsFunc: &StoreGetAnonymousUserFlagVariantsFunc{
+			defaultHook: func(context.Context, string) (r0 map[string]json.RawMessage, r1 error) {
+				return
+			},
+		},
 		GetAnonymousUserFlagsFunc: &StoreGetAnonymousUserFlagsFunc{
 			defaultHook: func(context.Context, string) (r0 map[string]bool, r1 error) {
 				return
@@ -40,6 +56,16 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetGlobalFlagVariantsFunc: &StoreGetGlobalFlagVariantsFunc{
+			defaultHook: func(context.Context) (r0 map[string]json.RawMessage, r1 error) {
+				return
+			},
+		},
+		GetUserFlagVariantsFunc: &StoreGetUserFlagVariantsFunc{
+			defaultHook: func(context.Context, int32) (r0 map[string]json.RawMessage, r1 error) {
+				return
+			},
+		},
 		GetUserFlagsFunc: &StoreGetUserFlagsFunc{
 			defaultHook: func(context.Context, int32) (r0 map[string]bool, r1 error) {
 				return
@@ -52,6 +78,11 @@ func NewMockStore() *MockStore {
 // panic on invocation, unless overwritten.
 func NewStrictMockStore() *MockStore {
 	return &MockStore{
+		GetAnonymousUserFlagVariantsFunc: &StoreGetAnonymousUserFlagVariantsFunc{
+			defaultHook: func(context.Context, string) (map[string]json.RawMessage, error) {
+				panic("unexpected invocation of MockStore.GetAnonymousUserFlagVariants")
+			},
+		},
 		GetAnonymousUserFlagsFunc: &StoreGetAnonymousUserFlagsFunc{
 			defaultHook: func(context.Context, string) (map[string]bool, error) {
 				panic("unexpected invocation of MockStore.GetAnonymousUserFlags")
@@ -62,6 +93,16 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetGlobalFeatureFlags")
 			},
 		},
+		GetGlobalFlagVariantsFunc: &StoreGetGlobalFlagVariantsFunc{
+			defaultHook: func(context.Context) (map[string]json.RawMessage, error) {
+				panic("unexpected invocation of MockStore.GetGlobalFlagVariants")
+			},
+		},
+		GetUserFlagVariantsFunc: &StoreGetUserFlagVariantsFunc{
+			defaultHook: func(context.Context, int32) (map[string]json.RawMessage, error) {
+				panic("unexpected invocation of MockStore.GetUserFlagVariants")
+			},
+		},
 		GetUserFlagsFunc: &StoreGetUserFlagsFunc{
 			defaultHook: func(context.Context, int32) (map[string]bool, error) {
 				panic("unexpected invocation of MockStore.GetUserFlags")
@@ -74,18 +115,138 @@ func NewStrictMockStore() *MockStore {
 // methods delegate to the given implementation, unless overwritten.
 func NewMockStoreFrom(i Store) *MockStore {
 	return &MockStore{
+		GetAnonymousUserFlagVariantsFunc: &StoreGetAnonymousUserFlagVariantsFunc{
+			defaultHook: i.GetAnonymousUserFlagVariants,
+		},
 		GetAnonymousUserFlagsFunc: &StoreGetAnonymousUserFlagsFunc{
 			defaultHook: i.GetAnonymousUserFlags,
 		},
 		GetGlobalFeatureFlagsFunc: &StoreGetGlobalFeatureFlagsFunc{
 			defaultHook: i.GetGlobalFeatureFlags,
 		},
+		GetGlobalFlagVariantsFunc: &StoreGetGlobalFlagVariantsFunc{
+			defaultHook: i.GetGlobalFlagVariants,
+		},
+		GetUserFlagVariantsFunc: &StoreGetUserFlagVariantsFunc{
+			defaultHook: i.GetUserFlagVariants,
+		},
 		GetUserFlagsFunc: &StoreGetUserFlagsFunc{
 			defaultHook: i.GetUserFlags,
 		},
 	}
 }
 
+// StoreGetAnonymousUserFlagVariantsFunc describes the behavior when the
+// GetAnonymousUserFlagVariants method of the parent MockStore instance is
+// invoked.
+type StoreGetAnonymousUserFlagVariantsFunc struct {
+	defaultHook func(context.Context, string) (map[string]json.RawMessage, error)
+	hooks       []func(context.Context, string) (map[string]json.RawMessage, error)
+	history     []StoreGetAnonymousUserFlagVariantsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetAnonymousUserFlagVariants delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetAnonymousUserFlagVariants(v0 context.Context, v1 string) (map[string]json.RawMessage, error) {
+	r0, r1 := m.GetAnonymousUserFlagVariantsFunc.nextHook()(v0, v1)
+	m.GetAnonymousUserFlagVariantsFunc.appendCall(StoreGetAnonymousUserFlagVariantsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetAnonymousUserFlagVariants method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetAnonymousUserFlagVariantsFunc) SetDefaultHook(hook func(context.Context, string) (map[string]json.RawMessage, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetAnonymousUserFlagVariants method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetAnonymousUserFlagVariantsFunc) PushHook(hook func(context.Context, string) (map[string]json.RawMessage, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetAnonymousUserFlagVariantsFunc) SetDefaultReturn(r0 map[string]json.RawMessage, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (map[string]json.RawMessage, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetAnonymousUserFlagVariantsFunc) PushReturn(r0 map[string]json.RawMessage, r1 error) {
+	f.PushHook(func(context.Context, string) (map[string]json.RawMessage, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetAnonymousUserFlagVariantsFunc) nextHook() func(context.Context, string) (map[string]json.RawMessage, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetAnonymousUserFlagVariantsFunc) appendCall(r0 StoreGetAnonymousUserFlagVariantsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetAnonymousUserFlagVariantsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetAnonymousUserFlagVariantsFunc) History() []StoreGetAnonymousUserFlagVariantsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetAnonymousUserFlagVariantsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetAnonymousUserFlagVariantsFuncCall is an object that describes an
+// invocation of method GetAnonymousUserFlagVariants on an instance of
+// MockStore.
+type StoreGetAnonymousUserFlagVariantsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]json.RawMessage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetAnonymousUserFlagVariantsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetAnonymousUserFlagVariantsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreGetAnonymousUserFlagsFunc describes the behavior when the
 // GetAnonymousUserFlags method of the parent MockStore instance is invoked.
 type StoreGetAnonymousUserFlagsFunc struct {
@@ -299,6 +460,219 @@ func (c StoreGetGlobalFeatureFlagsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetGlobalFlagVariantsFunc describes the behavior when the
+// GetGlobalFlagVariants method of the parent MockStore instance is invoked.
+type StoreGetGlobalFlagVariantsFunc struct {
+	defaultHook func(context.Context) (map[string]json.RawMessage, error)
+	hooks       []func(context.Context) (map[string]json.RawMessage, error)
+	history     []StoreGetGlobalFlagVariantsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetGlobalFlagVariants delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) GetGlobalFlagVariants(v0 context.Context) (map[string]json.RawMessage, error) {
+	r0, r1 := m.GetGlobalFlagVariantsFunc.nextHook()(v0)
+	m.GetGlobalFlagVariantsFunc.appendCall(StoreGetGlobalFlagVariantsFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetGlobalFlagVariants method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreGetGlobalFlagVariantsFunc) SetDefaultHook(hook func(context.Context) (map[string]json.RawMessage, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetGlobalFlagVariants method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreGetGlobalFlagVariantsFunc) PushHook(hook func(context.Context) (map[string]json.RawMessage, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetGlobalFlagVariantsFunc) SetDefaultReturn(r0 map[string]json.RawMessage, r1 error) {
+	f.SetDefaultHook(func(context.Context) (map[string]json.RawMessage, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetGlobalFlagVariantsFunc) PushReturn(r0 map[string]json.RawMessage, r1 error) {
+	f.PushHook(func(context.Context) (map[string]json.RawMessage, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetGlobalFlagVariantsFunc) nextHook() func(context.Context) (map[string]json.RawMessage, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetGlobalFlagVariantsFunc) appendCall(r0 StoreGetGlobalFlagVariantsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetGlobalFlagVariantsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetGlobalFlagVariantsFunc) History() []StoreGetGlobalFlagVariantsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetGlobalFlagVariantsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetGlobalFlagVariantsFuncCall is an object that describes an
+// invocation of method GetGlobalFlagVariants on an instance of MockStore.
+type StoreGetGlobalFlagVariantsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]json.RawMessage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetGlobalFlagVariantsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetGlobalFlagVariantsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetUserFlagVariantsFunc describes the behavior when the
+// GetUserFlagVariants method of the parent MockStore instance is invoked.
+type StoreGetUserFlagVariantsFunc struct {
+	defaultHook func(context.Context, int32) (map[string]json.RawMessage, error)
+	hooks       []func(context.Context, int32) (map[string]json.RawMessage, error)
+	history     []StoreGetUserFlagVariantsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUserFlagVariants delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetUserFlagVariants(v0 context.Context, v1 int32) (map[string]json.RawMessage, error) {
+	r0, r1 := m.GetUserFlagVariantsFunc.nextHook()(v0, v1)
+	m.GetUserFlagVariantsFunc.appendCall(StoreGetUserFlagVariantsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUserFlagVariants
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetUserFlagVariantsFunc) SetDefaultHook(hook func(context.Context, int32) (map[string]json.RawMessage, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUserFlagVariants method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreGetUserFlagVariantsFunc) PushHook(hook func(context.Context, int32) (map[string]json.RawMessage, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUserFlagVariantsFunc) SetDefaultReturn(r0 map[string]json.RawMessage, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (map[string]json.RawMessage, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUserFlagVariantsFunc) PushReturn(r0 map[string]json.RawMessage, r1 error) {
+	f.PushHook(func(context.Context, int32) (map[string]json.RawMessage, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetUserFlagVariantsFunc) nextHook() func(context.Context, int32) (map[string]json.RawMessage, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUserFlagVariantsFunc) appendCall(r0 StoreGetUserFlagVariantsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUserFlagVariantsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetUserFlagVariantsFunc) History() []StoreGetUserFlagVariantsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUserFlagVariantsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUserFlagVariantsFuncCall is an object that describes an
+// invocation of method GetUserFlagVariants on an instance of MockStore.
+type StoreGetUserFlagVariantsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]json.RawMessage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUserFlagVariantsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUserFlagVariantsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreGetUserFlagsFunc describes the behavior when the GetUserFlags method
 // of the parent MockStore instance is invoked.
 type StoreGetUserFlagsFunc struct {
@@ -2,6 +2,7 @@ package featureflag
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"strings"
 )
@@ -63,6 +64,20 @@ func (s *overrideStore) GetGlobalFeatureFlags(ctx context.Context) (map[string]b
 	return s.override(s.store.GetGlobalFeatureFlags(ctx))
 }
 
+// GetUserFlagVariants does not apply any overrides since only boolean flags can
+// be overridden for a request. The same goes for the other variant getters.
+func (s *overrideStore) GetUserFlagVariants(ctx context.Context, userID int32) (map[string]json.RawMessage, error) {
+	return s.store.GetUserFlagVariants(ctx, userID)
+}
+
+func (s *overrideStore) GetAnonymousUserFlagVariants(ctx context.Context, anonUID string) (map[string]json.RawMessage, error) {
+	return s.store.GetAnonymousUserFlagVariants(ctx, anonUID)
+}
+
+func (s *overrideStore) GetGlobalFlagVariants(ctx context.Context) (map[string]json.RawMessage, error) {
+	return s.store.GetGlobalFlagVariants(ctx)
+}
+
 func (s *overrideStore) override(flags map[string]bool, err error) (map[string]bool, error) {
 	if err != nil {
 		return nil, err
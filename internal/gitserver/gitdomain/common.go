@@ -209,6 +209,62 @@ func AbbreviateRef(ref string) string {
 	return strings.TrimPrefix(ref, "refs/heads/")
 }
 
+// RevisionRange is a range of commits as described in gitrevisions(7). The
+// range "from..to" contains the commits reachable from To but not from From,
+// and the symmetric range "from...to" contains the commits reachable from
+// either From or To but not from both. An empty end refers to HEAD.
+type RevisionRange struct {
+	From      string
+	To        string
+	Symmetric bool
+}
+
+// ParseRevisionRange parses spec as a revision range. ok is false if spec is
+// not a range, and err is set if spec is a malformed range.
+func ParseRevisionRange(spec string) (r RevisionRange, ok bool, err error) {
+	i := strings.Index(spec, "..")
+	if i == -1 {
+		return RevisionRange{}, false, nil
+	}
+
+	r.From, r.To = spec[:i], spec[i+2:]
+	if strings.HasPrefix(r.To, ".") {
+		r.To = r.To[1:]
+		r.Symmetric = true
+	}
+
+	switch {
+	case r.From == "" && r.To == "":
+		return RevisionRange{}, true, errors.Errorf("revision range %q has no ends", spec)
+	case strings.HasPrefix(r.To, ".") || strings.Contains(r.To, ".."):
+		return RevisionRange{}, true, errors.Errorf("revision range %q contains more than one range", spec)
+	case strings.HasPrefix(r.From, "-") || strings.HasPrefix(r.To, "-"):
+		return RevisionRange{}, true, errors.Errorf("revision range %q has an end starting with '-'", spec)
+	case strings.HasPrefix(r.From, "^") || strings.HasPrefix(r.To, "^"):
+		return RevisionRange{}, true, errors.Errorf("revision range %q has a negated end", spec)
+	}
+	return r, true, nil
+}
+
+// Ends returns the ends of the range, substituting HEAD for empty ends.
+func (r RevisionRange) Ends() (from, to string) {
+	from, to = r.From, r.To
+	if from == "" {
+		from = "HEAD"
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+	return from, to
+}
+
+func (r RevisionRange) String() string {
+	if r.Symmetric {
+		return r.From + "..." + r.To
+	}
+	return r.From + ".." + r.To
+}
+
 // Branches is a sortable slice of type Branch
 type Branches []*Branch
 
@@ -42,6 +42,41 @@ func TestValidateBranchName(t *testing.T) {
 	}
 }
 
+func TestParseRevisionRange(t *testing.T) {
+	for _, tc := range []struct {
+		spec    string
+		want    RevisionRange
+		isRange bool
+		wantErr bool
+	}{
+		{spec: "v1.2", isRange: false},
+		{spec: "v1.2..v1.3", want: RevisionRange{From: "v1.2", To: "v1.3"}, isRange: true},
+		{spec: "v1.2...v1.3", want: RevisionRange{From: "v1.2", To: "v1.3", Symmetric: true}, isRange: true},
+		{spec: "v1.2..", want: RevisionRange{From: "v1.2"}, isRange: true},
+		{spec: "...main", want: RevisionRange{To: "main", Symmetric: true}, isRange: true},
+		{spec: "..", isRange: true, wantErr: true},
+		{spec: "...", isRange: true, wantErr: true},
+		{spec: "a....b", isRange: true, wantErr: true},
+		{spec: "a..b..c", isRange: true, wantErr: true},
+		{spec: "a..--output=x", isRange: true, wantErr: true},
+		{spec: "^a..b", isRange: true, wantErr: true},
+	} {
+		t.Run(tc.spec, func(t *testing.T) {
+			r, isRange, err := ParseRevisionRange(tc.spec)
+			assert.Equal(t, tc.isRange, isRange)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, r)
+			if isRange {
+				assert.Equal(t, tc.spec, r.String())
+			}
+		})
+	}
+}
+
 func TestRefGlobs(t *testing.T) {
 	tests := map[string]struct {
 		globs   []RefGlob
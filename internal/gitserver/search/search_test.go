@@ -104,6 +104,24 @@ func TestSearch(t *testing.T) {
 		require.Equal(t, matches[1].Author.Name, "camden1")
 	})
 
+	t.Run("revision range", func(t *testing.T) {
+		query := &protocol.MessageMatches{Expr: "c"}
+		tree, err := ToMatchTree(query)
+		require.NoError(t, err)
+		searcher := &CommitSearcher{
+			RepoDir:   dir,
+			Query:     tree,
+			Revisions: []protocol.RevisionSpecifier{{RevSpec: "HEAD~1..HEAD"}},
+		}
+		var matches []*protocol.CommitMatch
+		err = searcher.Search(context.Background(), func(match *protocol.CommitMatch) {
+			matches = append(matches, match)
+		})
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		require.Equal(t, matches[0].Author.Name, "camden2")
+	})
+
 	t.Run("and with no operands matches all", func(t *testing.T) {
 		query := protocol.NewAnd()
 		tree, err := ToMatchTree(query)
@@ -0,0 +1,86 @@
+// Package rbac implements role-based access control on top of the roles,
+// permissions, role_permissions and user_roles tables.
+package rbac
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// Permission is an action in a namespace that roles can be granted. The
+// permissions below are created by migrations, along with the system roles
+// they are granted to.
+type Permission struct {
+	Namespace string
+	Action    string
+}
+
+func (p Permission) String() string {
+	return p.Namespace + "#" + p.Action
+}
+
+var (
+	// BatchChangesSiteCredentialsManage allows viewing, creating and deleting
+	// batch changes site credentials. Granted to BATCH_CHANGES_ADMIN.
+	BatchChangesSiteCredentialsManage = Permission{Namespace: "BATCH_CHANGES", Action: "SITE_CREDENTIALS_MANAGE"}
+
+	// CodeInsightsGlobalDashboardsManage allows creating, updating and deleting
+	// dashboards that are visible to all users. Granted to CODE_INSIGHTS_ADMIN.
+	CodeInsightsGlobalDashboardsManage = Permission{Namespace: "CODE_INSIGHTS", Action: "GLOBAL_DASHBOARDS_MANAGE"}
+
+	// CodeIntelUploadsDelete allows deleting precise code intelligence uploads.
+	// Granted to CODE_INTEL_OPERATOR.
+	CodeIntelUploadsDelete = Permission{Namespace: "CODE_INTEL", Action: "UPLOADS_DELETE"}
+
+	// CodeIntelIndexesDelete allows deleting auto-indexing jobs. Granted to
+	// CODE_INTEL_OPERATOR.
+	CodeIntelIndexesDelete = Permission{Namespace: "CODE_INTEL", Action: "INDEXES_DELETE"}
+)
+
+// PermissionDeniedErr is returned when the current user is not granted a
+// permission.
+type PermissionDeniedErr struct {
+	Permission Permission
+}
+
+func (e *PermissionDeniedErr) Error() string {
+	return fmt.Sprintf("must be site admin or have the %s permission", e.Permission)
+}
+
+func (e *PermissionDeniedErr) Unauthorized() bool { return true }
+
+// CheckCurrentUserHasPermission returns an error if the current user is not
+// granted the permission by any of their roles. Site admins are granted all
+// permissions.
+func CheckCurrentUserHasPermission(ctx context.Context, db database.DB, p Permission) error {
+	if actor.FromContext(ctx).IsInternal() {
+		return nil
+	}
+	user, err := auth.CurrentUser(ctx, db)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return auth.ErrNotAuthenticated
+	}
+	if user.SiteAdmin {
+		return nil
+	}
+
+	has, err := db.Permissions().UserHasPermission(ctx, database.UserHasPermissionOpts{
+		UserID:    user.ID,
+		Namespace: p.Namespace,
+		Action:    p.Action,
+	})
+	if err != nil {
+		return err
+	}
+	if !has {
+		return &PermissionDeniedErr{Permission: p}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCheckCurrentUserHasPermission(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultHook(func(ctx context.Context) (*types.User, error) {
+		a := actor.FromContext(ctx)
+		if !a.IsAuthenticated() {
+			return nil, database.ErrNoCurrentUser
+		}
+		return &types.User{ID: a.UID, SiteAdmin: a.UID == 1}, nil
+	})
+
+	permissions := database.NewMockPermissionStore()
+	permissions.UserHasPermissionFunc.SetDefaultHook(func(_ context.Context, opts database.UserHasPermissionOpts) (bool, error) {
+		return opts.UserID == 2 && opts.Namespace == "CODE_INTEL" && opts.Action == "UPLOADS_DELETE", nil
+	})
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.PermissionsFunc.SetDefaultReturn(permissions)
+
+	for _, tc := range []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{
+			name: "internal actor",
+			ctx:  actor.WithInternalActor(context.Background()),
+		},
+		{
+			name:    "anonymous",
+			ctx:     context.Background(),
+			wantErr: auth.ErrNotAuthenticated,
+		},
+		{
+			name: "site admin",
+			ctx:  actor.WithActor(context.Background(), actor.FromUser(1)),
+		},
+		{
+			name: "granted by role",
+			ctx:  actor.WithActor(context.Background(), actor.FromUser(2)),
+		},
+		{
+			name:    "not granted",
+			ctx:     actor.WithActor(context.Background(), actor.FromUser(3)),
+			wantErr: &PermissionDeniedErr{Permission: CodeIntelUploadsDelete},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckCurrentUserHasPermission(tc.ctx, db, CodeIntelUploadsDelete)
+			if tc.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tc.wantErr != nil && (err == nil || err.Error() != tc.wantErr.Error()) {
+				t.Fatalf("unexpected error. want=%v have=%v", tc.wantErr, err)
+			}
+		})
+	}
+}
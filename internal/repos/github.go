@@ -271,8 +271,18 @@ func (s *GitHubSource) CheckConnection(ctx context.Context) error {
 // ListRepos returns all Github repositories accessible to all connections configured
 // in Sourcegraph via the external services configuration.
 func (s *GitHubSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	// Team membership has to be known before any repository is yielded, since
+	// a repository can belong to several teams and is only yielded once.
+	teamRepos, teams, err := s.listTeams(ctx)
+	if err != nil {
+		results <- SourceResult{Source: s, Err: err}
+	}
+
 	unfiltered := make(chan *githubResult)
 	go func() {
+		for _, r := range teamRepos {
+			unfiltered <- &githubResult{repo: r}
+		}
 		s.listAllRepositories(ctx, unfiltered)
 		close(unfiltered)
 	}()
@@ -286,7 +296,13 @@ func (s *GitHubSource) ListRepos(ctx context.Context, results chan SourceResult)
 
 		s.logger.Debug("unfiltered", log.String("repo", res.repo.NameWithOwner))
 		if !seen[res.repo.DatabaseID] && !s.excludes(res.repo) {
-			results <- SourceResult{Source: s, Repo: s.makeRepo(res.repo)}
+			repo := s.makeRepo(res.repo, teams[res.repo.DatabaseID])
+			if err != nil {
+				// We don't know all the teams of this repository, so we leave
+				// the stored code host tags untouched.
+				repo.KeyValuePairs = nil
+			}
+			results <- SourceResult{Source: s, Repo: repo}
 			s.logger.Debug("sent to result", log.String("repo", res.repo.NameWithOwner))
 			seen[res.repo.DatabaseID] = true
 		}
@@ -305,15 +321,30 @@ func (s *GitHubSource) GetRepo(ctx context.Context, nameWithOwner string) (*type
 	if err != nil {
 		return nil, err
 	}
-	return s.makeRepo(r), nil
+	repo := s.makeRepo(r, nil)
+	if len(s.config.Teams) > 0 {
+		// Team membership is only known during a full sync, so we leave the
+		// stored code host tags untouched.
+		repo.KeyValuePairs = nil
+	}
+	return repo, nil
 }
 
-func (s *GitHubSource) makeRepo(r *github.Repository) *types.Repo {
+// makeRepo converts the given GitHub repository into a types.Repo. The topics
+// of the repository and the given teams it belongs to are recorded as code
+// host tags in its key-value pairs.
+func (s *GitHubSource) makeRepo(r *github.Repository, teams []string) *types.Repo {
 	urn := s.svc.URN()
 	metadata := *r
 	// This field flip flops depending on which token was used to retrieve the repo
 	// so we don't want to store it.
 	metadata.ViewerPermission = ""
+
+	kvps := types.CodeHostKeyValuePairs(types.GitHubTopicKeyPrefix, r.Topics...)
+	for k, v := range types.CodeHostKeyValuePairs(types.GitHubTeamKeyPrefix, teams...) {
+		kvps[k] = v
+	}
+
 	return &types.Repo{
 		Name: reposource.GitHubRepoName(
 			s.config.RepositoryPathPattern,
@@ -337,7 +368,8 @@ func (s *GitHubSource) makeRepo(r *github.Repository) *types.Repo {
 				CloneURL: s.remoteURL(r),
 			},
 		},
-		Metadata: &metadata,
+		Metadata:      &metadata,
+		KeyValuePairs: kvps,
 	}
 }
 
@@ -892,6 +924,52 @@ func (s *GitHubSource) listRepositoryQuery(ctx context.Context, query string, re
 	s.listSearch(ctx, query, results)
 }
 
+// listTeams handles the `teams` config option. It returns all the
+// repositories belonging to the given teams, along with the teams each of them
+// belongs to keyed by repository database ID.
+//
+// A non-nil error means the membership of some teams could not be listed.
+func (s *GitHubSource) listTeams(ctx context.Context) (repos []*github.Repository, teams map[int64][]string, err error) {
+	teams = make(map[int64][]string)
+	for _, team := range s.config.Teams {
+		org, slug, splitErr := github.SplitRepositoryNameWithOwner(team)
+		if splitErr != nil {
+			err = errors.Append(err, errors.Wrapf(splitErr, "invalid GitHub team %q", team))
+			continue
+		}
+
+		for page, hasNext := 1, true; hasNext; page++ {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return repos, teams, errors.Append(err, ctxErr)
+			}
+
+			var (
+				pageRepos []*github.Repository
+				cost      int
+				listErr   error
+			)
+			pageRepos, hasNext, cost, listErr = s.v3Client.ListTeamRepositories(ctx, org, slug, page)
+			if listErr != nil {
+				err = errors.Append(err, errors.Wrapf(listErr, "listing repositories of GitHub team %q", team))
+				break
+			}
+
+			for _, r := range pageRepos {
+				if _, ok := teams[r.DatabaseID]; !ok {
+					repos = append(repos, r)
+				}
+				teams[r.DatabaseID] = append(teams[r.DatabaseID], team)
+			}
+
+			if hasNext && cost > 0 {
+				timeutil.SleepWithContext(ctx, s.v3Client.RateLimitMonitor().RecommendedWaitForBackgroundOp(cost))
+			}
+		}
+	}
+
+	return repos, teams, err
+}
+
 // listAllRepositories returns the repositories from the given `orgs`, `repos`, and
 // `repositoryQuery` config options excluding the ones specified by `exclude`.
 func (s *GitHubSource) listAllRepositories(ctx context.Context, results chan *githubResult) {
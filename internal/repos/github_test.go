@@ -299,7 +299,7 @@ func TestGithubSource_makeRepo(t *testing.T) {
 
 			var got []*types.Repo
 			for _, r := range repos {
-				got = append(got, s.makeRepo(r))
+				got = append(got, s.makeRepo(r, nil))
 			}
 
 			testutil.AssertGolden(t, "testdata/golden/"+test.name, update(test.name), got)
@@ -307,6 +307,36 @@ func TestGithubSource_makeRepo(t *testing.T) {
 	}
 }
 
+func TestGithubSource_makeRepo_CodeHostTags(t *testing.T) {
+	svc := types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindGitHub,
+		Config: extsvc.NewEmptyConfig(),
+	}
+
+	s, err := newGithubSource(logtest.Scoped(t), database.NewMockExternalServiceStore(), &svc, &schema.GitHubConnection{
+		Url: "https://github.com",
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := s.makeRepo(&github.Repository{
+		NameWithOwner: "sourcegraph/sourcegraph",
+		Topics:        []string{"code-search", "go"},
+	}, []string{"sourcegraph/search", "sourcegraph/code-intel"})
+
+	want := map[string]*string{
+		"github.topic:code-search":           nil,
+		"github.topic:go":                    nil,
+		"github.team:sourcegraph/search":     nil,
+		"github.team:sourcegraph/code-intel": nil,
+	}
+	if diff := cmp.Diff(want, repo.KeyValuePairs); diff != "" {
+		t.Errorf("unexpected key-value pairs (-want +got):\n%s", diff)
+	}
+}
+
 func TestMatchOrg(t *testing.T) {
 	testCases := map[string]string{
 		"":                     "",
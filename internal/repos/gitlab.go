@@ -193,8 +193,19 @@ func (s GitLabSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
 }
 
+// makeRepo converts the given GitLab project into a types.Repo. The topics of
+// the project and every namespace it belongs to are recorded as code host tags
+// in its key-value pairs.
 func (s GitLabSource) makeRepo(proj *gitlab.Project) *types.Repo {
 	urn := s.svc.URN()
+
+	kvps := types.CodeHostKeyValuePairs(types.GitLabTopicKeyPrefix, proj.Topics...)
+	if namespaces, err := proj.Namespaces(); err == nil {
+		for k, v := range types.CodeHostKeyValuePairs(types.GitLabGroupKeyPrefix, namespaces...) {
+			kvps[k] = v
+		}
+	}
+
 	return &types.Repo{
 		Name: reposource.GitLabRepoName(
 			s.config.RepositoryPathPattern,
@@ -220,7 +231,8 @@ func (s GitLabSource) makeRepo(proj *gitlab.Project) *types.Repo {
 				CloneURL: s.remoteURL(proj),
 			},
 		},
-		Metadata: proj,
+		Metadata:      proj,
+		KeyValuePairs: kvps,
 	}
 }
 
@@ -4,10 +4,13 @@ import (
 	"container/heap"
 	"context"
 	"math/rand"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/grafana/regexp"
 
 	"github.com/sourcegraph/log"
@@ -21,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // schedulerConfig tracks the active scheduler configuration.
@@ -30,6 +34,9 @@ type schedulerConfig struct {
 }
 
 // RunScheduler runs the worker that schedules git fetches of synced repositories in git-server.
+//
+// Only one repo-updater replica at a time runs the scheduler, see
+// UpdateScheduler for how the replicas coordinate.
 func RunScheduler(ctx context.Context, logger log.Logger, scheduler *UpdateScheduler) {
 	var (
 		have schedulerConfig
@@ -38,6 +45,9 @@ func RunScheduler(ctx context.Context, logger log.Logger, scheduler *UpdateSched
 
 	logger = logger.Scoped("RunScheduler", "git fetch scheduler")
 
+	// Until we know we are the leader, forward all schedule changes to it.
+	scheduler.standby.Store(true)
+
 	conf.Watch(func() {
 		c := conf.Get()
 
@@ -63,10 +73,7 @@ func RunScheduler(ctx context.Context, logger log.Logger, scheduler *UpdateSched
 		var ctx2 context.Context
 		ctx2, stop = context.WithCancel(ctx)
 
-		go scheduler.runUpdateLoop(ctx2)
-		if want.autoGitUpdatesEnabled {
-			go scheduler.runScheduleLoop(ctx2)
-		}
+		go scheduler.runLeaderElection(ctx2, want.autoGitUpdatesEnabled)
 
 		logger.Debug(
 			"started configured scheduler",
@@ -108,11 +115,33 @@ const (
 //
 // A worker continuously dequeues repos and sends updates to gitserver, but its concurrency
 // is limited by the gitMaxConcurrentClones site configuration.
+//
+// The schedule and the update queue are persisted in the repo_update_schedule
+// table, so that learned update intervals survive restarts. Multiple
+// repo-updater replicas elect a leader with a lease that expires unless it is
+// renewed. The leader keeps the schedule in memory, sends the updates and
+// periodically renews its lease and persists its state. The other replicas
+// are on standby: they record the schedule changes they receive (from syncs or
+// UpdateOnce) for the leader to apply, and serve DebugDump and ScheduleInfo
+// from the persisted state. When the leader goes away, a standby replica takes
+// over and restores the persisted schedule.
 type UpdateScheduler struct {
 	db          database.DB
 	updateQueue *updateQueue
 	schedule    *schedule
 	logger      log.Logger
+
+	// changes tracks the repos whose schedule changed since it was last
+	// persisted.
+	changes *scheduleChanges
+
+	// standby is true while another replica is the scheduler leader.
+	standby atomic.Bool
+
+	// requests buffers the schedule changes received while on standby until
+	// they are persisted for the leader.
+	requestsMu sync.Mutex
+	requests   []*database.RepoUpdateScheduleRequest
 }
 
 // A configuredRepo represents the configuration data for a given repo from
@@ -131,20 +160,300 @@ const notifyChanBuffer = 1
 // NewUpdateScheduler returns a new scheduler.
 func NewUpdateScheduler(logger log.Logger, db database.DB) *UpdateScheduler {
 	updateSchedLogger := logger.Scoped("UpdateScheduler", "repo update scheduler")
+	changes := &scheduleChanges{ids: make(map[api.RepoID]struct{})}
 
 	return &UpdateScheduler{
 		db: db,
 		updateQueue: &updateQueue{
 			index:         make(map[api.RepoID]*repoUpdate),
 			notifyEnqueue: make(chan struct{}, notifyChanBuffer),
+			changes:       changes,
 		},
 		schedule: &schedule{
 			index:         make(map[api.RepoID]*scheduledRepoUpdate),
 			wakeup:        make(chan struct{}, notifyChanBuffer),
 			randGenerator: rand.New(rand.NewSource(time.Now().UnixNano())),
 			logger:        updateSchedLogger.Scoped("Schedule", ""),
+			changes:       changes,
 		},
-		logger: updateSchedLogger,
+		logger:  updateSchedLogger,
+		changes: changes,
+	}
+}
+
+const (
+	// schedulerLeaseTTL is how long the scheduler leader lease is valid for
+	// unless it is renewed. A standby replica takes over at most this long
+	// after the leader went away.
+	schedulerLeaseTTL = 30 * time.Second
+
+	// schedulerPersistInterval is how often the leader renews its lease and
+	// persists the schedule, and standby replicas persist their requests and
+	// try to become the leader.
+	schedulerPersistInterval = 5 * time.Second
+
+	// schedulerRequestsBatchSize is the maximum number of requests the leader
+	// applies per persist interval.
+	schedulerRequestsBatchSize = 10000
+)
+
+// runLeaderElection repeatedly tries to become the scheduler leader until ctx
+// is canceled. While this replica is on standby, it persists the requests it
+// received for the leader.
+func (s *UpdateScheduler) runLeaderElection(ctx context.Context, autoGitUpdatesEnabled bool) {
+	holder := uuid.NewString()
+
+	for {
+		err := s.tryLead(ctx, holder, autoGitUpdatesEnabled)
+		s.standby.Store(true)
+		if err != nil && ctx.Err() == nil {
+			s.logger.Warn("lost repo update scheduler leadership", log.Error(err))
+		}
+
+		if err := s.persistRequests(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("error persisting repo update schedule requests", log.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(schedulerPersistInterval):
+		}
+	}
+}
+
+// tryLead takes the leader lease if it is available and then runs the
+// scheduler until ctx is canceled or the lease can't be renewed. It returns
+// immediately if another replica holds the lease.
+//
+// The lease is a row with an expiry time rather than an advisory lock, so
+// that leading doesn't pin a database connection.
+func (s *UpdateScheduler) tryLead(ctx context.Context, holder string, autoGitUpdatesEnabled bool) error {
+	store := s.db.RepoUpdateSchedule()
+	if ok, err := store.AcquireLease(ctx, holder, schedulerLeaseTTL); err != nil || !ok {
+		return err
+	}
+
+	var (
+		loops     sync.WaitGroup
+		takenOver bool
+	)
+	loopCtx, stopLoops := context.WithCancel(ctx)
+	defer func() {
+		stopLoops()
+		loops.Wait()
+
+		// ctx may be canceled already, so the final persist and the release
+		// use a fresh context.
+		finalCtx, cancel := context.WithTimeout(context.Background(), schedulerPersistInterval)
+		defer cancel()
+
+		// Persist the changes since the last persist, unless another replica
+		// restored the schedule already.
+		if !takenOver {
+			if err := s.persist(finalCtx); err != nil {
+				s.logger.Error("error persisting repo update schedule", log.Error(err))
+			}
+		}
+		s.schedule.reset()
+		s.updateQueue.reset()
+
+		// Let a standby replica take over right away.
+		if err := store.ReleaseLease(finalCtx, holder); err != nil {
+			s.logger.Warn("error releasing repo update scheduler lease", log.Error(err))
+		}
+	}()
+
+	// Anything changed before we became the leader is superseded by the
+	// persisted state.
+	s.changes.take()
+	if err := s.restore(ctx); err != nil {
+		return errors.Wrap(err, "restoring repo update schedule")
+	}
+	s.standby.Store(false)
+	s.logger.Info("became repo update scheduler leader")
+
+	loops.Add(1)
+	go func() {
+		defer loops.Done()
+		s.runUpdateLoop(loopCtx)
+	}()
+	if autoGitUpdatesEnabled {
+		loops.Add(1)
+		go func() {
+			defer loops.Done()
+			s.runScheduleLoop(loopCtx)
+		}()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(schedulerPersistInterval):
+		}
+
+		ok, err := store.AcquireLease(ctx, holder, schedulerLeaseTTL)
+		if err != nil {
+			return errors.Wrap(err, "renewing leader lease")
+		}
+		if !ok {
+			takenOver = true
+			return errors.New("leader lease expired and was taken over by another replica")
+		}
+		if err := s.applyRequests(ctx); err != nil {
+			s.logger.Error("error applying repo update schedule requests", log.Error(err))
+		}
+		if err := s.persist(ctx); err != nil {
+			s.logger.Error("error persisting repo update schedule", log.Error(err))
+		}
+	}
+}
+
+// restore loads the persisted schedule and update queue into memory.
+func (s *UpdateScheduler) restore(ctx context.Context) error {
+	entries, err := s.db.RepoUpdateSchedule().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.schedule.restore(entries)
+
+	queued := make([]*database.RepoUpdateScheduleEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.QueuePriority != nil {
+			queued = append(queued, e)
+		}
+	}
+	sort.Slice(queued, func(i, j int) bool {
+		if *queued[i].QueuePriority != *queued[j].QueuePriority {
+			return *queued[i].QueuePriority > *queued[j].QueuePriority
+		}
+		return queued[i].QueueSeq < queued[j].QueueSeq
+	})
+	for _, e := range queued {
+		s.updateQueue.enqueue(configuredRepo{ID: e.RepoID, Name: e.RepoName}, priority(*e.QueuePriority))
+	}
+
+	// Requests received while we were on standby and not yet persisted are
+	// applied directly.
+	s.requestsMu.Lock()
+	requests := s.requests
+	s.requests = nil
+	s.requestsMu.Unlock()
+	s.apply(requests)
+
+	return nil
+}
+
+// persist writes the schedule of all repos that changed since the last call
+// to the database.
+func (s *UpdateScheduler) persist(ctx context.Context) error {
+	changes := s.changes.take()
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var (
+		upserts []*database.RepoUpdateScheduleEntry
+		removed []api.RepoID
+	)
+	for id := range changes {
+		if e, ok := s.scheduleEntry(id); ok {
+			upserts = append(upserts, e)
+		} else {
+			removed = append(removed, id)
+		}
+	}
+
+	store := s.db.RepoUpdateSchedule()
+	err := store.Upsert(ctx, upserts...)
+	if err == nil {
+		err = store.Delete(ctx, removed...)
+	}
+	if err != nil {
+		// Try again on the next call.
+		s.changes.merge(changes)
+	}
+	return err
+}
+
+// scheduleEntry returns the current schedule and queue state of the repo. It
+// returns false if the repo is neither in the schedule nor in the update
+// queue.
+func (s *UpdateScheduler) scheduleEntry(id api.RepoID) (*database.RepoUpdateScheduleEntry, bool) {
+	e := &database.RepoUpdateScheduleEntry{RepoID: id}
+
+	s.schedule.mu.Lock()
+	scheduled := s.schedule.index[id]
+	if scheduled != nil {
+		e.RepoName = scheduled.Repo.Name
+		e.Interval = scheduled.Interval
+		e.Due = scheduled.Due
+	}
+	s.schedule.mu.Unlock()
+
+	s.updateQueue.mu.Lock()
+	queued := s.updateQueue.index[id]
+	if queued != nil {
+		e.RepoName = queued.Repo.Name
+		p := int(queued.Priority)
+		e.QueuePriority = &p
+		e.QueueSeq = int64(queued.Seq)
+	}
+	s.updateQueue.mu.Unlock()
+
+	return e, scheduled != nil || queued != nil
+}
+
+// request records a schedule change received while on standby.
+func (s *UpdateScheduler) request(r *database.RepoUpdateScheduleRequest) {
+	s.requestsMu.Lock()
+	s.requests = append(s.requests, r)
+	s.requestsMu.Unlock()
+}
+
+// persistRequests writes the requests received while on standby to the
+// database for the leader to apply.
+func (s *UpdateScheduler) persistRequests(ctx context.Context) error {
+	s.requestsMu.Lock()
+	requests := s.requests
+	s.requests = nil
+	s.requestsMu.Unlock()
+
+	if err := s.db.RepoUpdateSchedule().CreateRequests(ctx, requests...); err != nil {
+		s.requestsMu.Lock()
+		s.requests = append(requests, s.requests...)
+		s.requestsMu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// applyRequests applies the requests persisted by standby replicas.
+func (s *UpdateScheduler) applyRequests(ctx context.Context) error {
+	requests, err := s.db.RepoUpdateSchedule().ClaimRequests(ctx, schedulerRequestsBatchSize)
+	if err != nil {
+		return err
+	}
+	s.apply(requests)
+	return nil
+}
+
+func (s *UpdateScheduler) apply(requests []*database.RepoUpdateScheduleRequest) {
+	for _, r := range requests {
+		repo := configuredRepo{ID: r.RepoID, Name: r.RepoName}
+		switch r.Action {
+		case database.RepoUpdateScheduleActionUpsert:
+			s.schedule.upsert(repo)
+		case database.RepoUpdateScheduleActionRemove:
+			s.schedule.remove(repo)
+			s.updateQueue.remove(repo, false)
+			continue
+		}
+		if r.Priority != nil {
+			s.updateQueue.enqueue(repo, priority(*r.Priority))
+		}
 	}
 }
 
@@ -154,7 +463,6 @@ func (s *UpdateScheduler) runScheduleLoop(ctx context.Context) {
 		select {
 		case <-s.schedule.wakeup:
 		case <-ctx.Done():
-			s.schedule.reset()
 			return
 		}
 
@@ -177,6 +485,7 @@ func (s *UpdateScheduler) runSchedule() {
 		schedAutoFetch.Inc()
 		s.updateQueue.enqueue(repoUpdate.Repo, priorityLow)
 		repoUpdate.Due = timeNow().Add(repoUpdate.Interval)
+		s.schedule.changes.add(repoUpdate.Repo.ID)
 		heap.Fix(s.schedule, 0)
 	}
 }
@@ -189,7 +498,6 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 		select {
 		case <-s.updateQueue.notifyEnqueue:
 		case <-ctx.Done():
-			s.updateQueue.reset()
 			return
 		}
 
@@ -331,11 +639,19 @@ func (s *UpdateScheduler) UpdateFromDiff(diff Diff) {
 // This method should be called periodically with the list of all repositories
 // managed by the scheduler that are not cloned on gitserver.
 func (s *UpdateScheduler) PrioritiseUncloned(repos []types.MinimalRepo) {
+	// The leader does this itself.
+	if s.standby.Load() {
+		return
+	}
 	s.schedule.prioritiseUncloned(repos)
 }
 
 // EnsureScheduled ensures that all repos in repos exist in the scheduler.
 func (s *UpdateScheduler) EnsureScheduled(repos []types.MinimalRepo) {
+	// The leader does this itself.
+	if s.standby.Load() {
+		return
+	}
 	s.schedule.insertNew(repos)
 }
 
@@ -360,6 +676,16 @@ func (s *UpdateScheduler) upsert(r *types.Repo, enqueue bool) {
 	repo := configuredRepoFromRepo(r)
 	logger := s.logger.With(log.String("repo", string(r.Name)))
 
+	if s.standby.Load() {
+		req := &database.RepoUpdateScheduleRequest{RepoID: repo.ID, Action: database.RepoUpdateScheduleActionUpsert}
+		if enqueue {
+			p := int(priorityLow)
+			req.Priority = &p
+		}
+		s.request(req)
+		return
+	}
+
 	updated := s.schedule.upsert(repo)
 	logger.Debug("scheduler.schedule.upserted", log.Bool("updated", updated))
 
@@ -374,6 +700,11 @@ func (s *UpdateScheduler) remove(r *types.Repo) {
 	repo := configuredRepoFromRepo(r)
 	logger := s.logger.With(log.String("repo", string(r.Name)))
 
+	if s.standby.Load() {
+		s.request(&database.RepoUpdateScheduleRequest{RepoID: repo.ID, Action: database.RepoUpdateScheduleActionRemove})
+		return
+	}
+
 	if s.schedule.remove(repo) {
 		logger.Debug("scheduler.schedule.removed")
 	}
@@ -400,6 +731,12 @@ func (s *UpdateScheduler) UpdateOnce(id api.RepoID, name api.RepoName) {
 		Name: name,
 	}
 	schedManualFetch.Inc()
+
+	if s.standby.Load() {
+		p := int(priorityHigh)
+		s.request(&database.RepoUpdateScheduleRequest{RepoID: id, Action: database.RepoUpdateScheduleActionUpdate, Priority: &p})
+		return
+	}
 	s.updateQueue.enqueue(repo, priorityHigh)
 }
 
@@ -414,6 +751,28 @@ func (s *UpdateScheduler) DebugDump(ctx context.Context) any {
 		Name: "repos",
 	}
 
+	if s.standby.Load() {
+		// The leader's state is only available through the database.
+		var err error
+		data.Schedule, data.UpdateQueue, err = s.persistedState(ctx)
+		if err != nil {
+			s.logger.Warn("getting persisted repo update schedule for debug page", log.Error(err))
+		}
+	} else {
+		s.dumpState(&data.Schedule, &data.UpdateQueue)
+	}
+
+	var err error
+	data.SyncJobs, err = s.db.ExternalServices().GetSyncJobs(ctx, database.ExternalServicesGetSyncJobsOptions{})
+	if err != nil {
+		s.logger.Warn("getting external service sync jobs for debug page", log.Error(err))
+	}
+
+	return &data
+}
+
+// dumpState appends the in-memory schedule and update queue in order.
+func (s *UpdateScheduler) dumpState(scheduled *[]*scheduledRepoUpdate, queued *[]*repoUpdate) {
 	s.schedule.mu.Lock()
 	schedule := schedule{
 		heap: make([]*scheduledRepoUpdate, len(s.schedule.heap)),
@@ -429,7 +788,7 @@ func (s *UpdateScheduler) DebugDump(ctx context.Context) any {
 
 	for len(schedule.heap) > 0 {
 		update := heap.Pop(&schedule).(*scheduledRepoUpdate)
-		data.Schedule = append(data.Schedule, update)
+		*scheduled = append(*scheduled, update)
 	}
 
 	s.updateQueue.mu.Lock()
@@ -449,20 +808,58 @@ func (s *UpdateScheduler) DebugDump(ctx context.Context) any {
 		// Copy the scheduledRepoUpdate as a value so that the repo pointer
 		// won't change concurrently after we release the lock.
 		update := heap.Pop(&updateQueue).(*repoUpdate)
-		data.UpdateQueue = append(data.UpdateQueue, update)
+		*queued = append(*queued, update)
 	}
+}
 
-	var err error
-	data.SyncJobs, err = s.db.ExternalServices().GetSyncJobs(ctx, database.ExternalServicesGetSyncJobsOptions{})
+// persistedState returns the schedule and update queue last persisted by the
+// leader, in order.
+func (s *UpdateScheduler) persistedState(ctx context.Context) ([]*scheduledRepoUpdate, []*repoUpdate, error) {
+	entries, err := s.db.RepoUpdateSchedule().List(ctx)
 	if err != nil {
-		s.logger.Warn("getting external service sync jobs for debug page", log.Error(err))
+		return nil, nil, err
 	}
 
-	return &data
+	scheduled := make([]*scheduledRepoUpdate, 0, len(entries))
+	var queued []*repoUpdate
+	for _, e := range entries {
+		repo := configuredRepo{ID: e.RepoID, Name: e.RepoName}
+		if !e.Due.IsZero() {
+			scheduled = append(scheduled, &scheduledRepoUpdate{
+				Repo:     repo,
+				Interval: e.Interval,
+				Due:      e.Due,
+				Index:    len(scheduled),
+			})
+		}
+		if e.QueuePriority != nil {
+			queued = append(queued, &repoUpdate{
+				Repo:     repo,
+				Priority: priority(*e.QueuePriority),
+				Seq:      uint64(e.QueueSeq),
+			})
+		}
+	}
+
+	sort.Slice(queued, func(i, j int) bool {
+		if queued[i].Priority != queued[j].Priority {
+			return queued[i].Priority > queued[j].Priority
+		}
+		return queued[i].Seq < queued[j].Seq
+	})
+	for i := range queued {
+		queued[i].Index = i
+	}
+
+	return scheduled, queued, nil
 }
 
 // ScheduleInfo returns the current schedule info for a repo.
-func (s *UpdateScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult {
+func (s *UpdateScheduler) ScheduleInfo(ctx context.Context, id api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error) {
+	if s.standby.Load() {
+		return s.persistedScheduleInfo(ctx, id)
+	}
+
 	var result protocol.RepoUpdateSchedulerInfoResult
 
 	s.schedule.mu.Lock()
@@ -487,7 +884,35 @@ func (s *UpdateScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedu
 	}
 	s.updateQueue.mu.Unlock()
 
-	return &result
+	return &result, nil
+}
+
+// persistedScheduleInfo returns the schedule info for a repo last persisted
+// by the leader.
+func (s *UpdateScheduler) persistedScheduleInfo(ctx context.Context, id api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error) {
+	var result protocol.RepoUpdateSchedulerInfoResult
+
+	pos, err := s.db.RepoUpdateSchedule().GetPosition(ctx, id)
+	if err != nil || pos == nil {
+		return &result, err
+	}
+
+	if !pos.Due.IsZero() {
+		result.Schedule = &protocol.RepoScheduleState{
+			Index:           pos.ScheduleIndex,
+			Total:           pos.ScheduleTotal,
+			IntervalSeconds: int(pos.Interval / time.Second),
+			Due:             pos.Due,
+		}
+	}
+	if pos.QueuePriority != nil {
+		result.Queue = &protocol.RepoQueueState{
+			Index:    pos.QueueIndex,
+			Total:    pos.QueueTotal,
+			Priority: *pos.QueuePriority,
+		}
+	}
+	return &result, nil
 }
 
 // updateQueue is a priority queue of repos to update.
@@ -505,6 +930,9 @@ type updateQueue struct {
 	// when a new value is enqueued so that the update loop
 	// can wake up if it is idle.
 	notifyEnqueue chan struct{}
+
+	// changes records the repos whose queue state changed.
+	changes *scheduleChanges
 }
 
 type priority int
@@ -556,6 +984,7 @@ func (q *updateQueue) enqueue(repo configuredRepo, p priority) (updated bool) {
 			Repo:     repo,
 			Priority: p,
 		})
+		q.changes.add(repo.ID)
 		notify(q.notifyEnqueue)
 		return false
 	}
@@ -574,6 +1003,7 @@ func (q *updateQueue) enqueue(repo configuredRepo, p priority) (updated bool) {
 	update.Priority = p      // bump the priority
 	update.Seq = q.nextSeq() // put it after all existing updates with this priority
 	heap.Fix(q, update.Index)
+	q.changes.add(repo.ID)
 	notify(q.notifyEnqueue)
 
 	return true
@@ -598,6 +1028,7 @@ func (q *updateQueue) remove(repo configuredRepo, updating bool) (removed bool)
 	update := q.index[repo.ID]
 	if update != nil && update.Updating == updating {
 		heap.Remove(q, update.Index)
+		q.changes.add(repo.ID)
 		return true
 	}
 
@@ -690,6 +1121,9 @@ type schedule struct {
 	randGenerator interface {
 		Int63n(n int64) int64
 	}
+
+	// changes records the repos whose schedule changed.
+	changes *scheduleChanges
 }
 
 // scheduledRepoUpdate is the update schedule for a single repo.
@@ -719,6 +1153,7 @@ func (s *schedule) upsert(repo configuredRepo) (updated bool) {
 		Interval: minDelay,
 		Due:      timeNow().Add(minDelay),
 	})
+	s.changes.add(repo.ID)
 
 	s.rescheduleTimer()
 
@@ -744,10 +1179,12 @@ func (s *schedule) prioritiseUncloned(uncloned []types.MinimalRepo) {
 				Interval: minDelay,
 				Due:      notClonedDue,
 			})
+			s.changes.add(repo.ID)
 			rescheduleTimer = true
 		} else if repoUpdate.Due.After(notClonedDue) {
 			repoUpdate.Due = notClonedDue
 			heap.Fix(s, repoUpdate.Index)
+			s.changes.add(repo.ID)
 			rescheduleTimer = true
 		}
 	}
@@ -788,6 +1225,7 @@ func (s *schedule) insertNew(repos []types.MinimalRepo) {
 			Interval: minDelay,
 			Due:      due,
 		})
+		s.changes.add(repo.ID)
 		rescheduleTimer = true
 	}
 
@@ -824,11 +1262,39 @@ func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration) {
 			log.Object("repo", log.String("name", string(repo.Name)), log.Duration("due", update.Due.Sub(timeNow()))),
 		)
 		heap.Fix(s, update.Index)
+		s.changes.add(repo.ID)
 		s.rescheduleTimer()
 	}
 	s.mu.Unlock()
 }
 
+// restore inserts or updates the repos in the schedule with the given
+// persisted schedule.
+func (s *schedule) restore(entries []*database.RepoUpdateScheduleEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range entries {
+		if e.Due.IsZero() {
+			// The repo is only queued for an update.
+			continue
+		}
+		if update := s.index[e.RepoID]; update != nil {
+			update.Interval = e.Interval
+			update.Due = e.Due
+			heap.Fix(s, update.Index)
+		} else {
+			heap.Push(s, &scheduledRepoUpdate{
+				Repo:     configuredRepo{ID: e.RepoID, Name: e.RepoName},
+				Interval: e.Interval,
+				Due:      e.Due,
+			})
+		}
+	}
+
+	s.rescheduleTimer()
+}
+
 // getCurrentInterval gets the current interval for the supplied repo and a bool
 // indicating whether it was found.
 func (s *schedule) getCurrentInterval(repo configuredRepo) (time.Duration, bool) {
@@ -860,6 +1326,7 @@ func (s *schedule) remove(repo configuredRepo) (removed bool) {
 	if heap.Remove(s, update.Index); reschedule {
 		s.rescheduleTimer()
 	}
+	s.changes.add(repo.ID)
 
 	return true
 }
@@ -933,6 +1400,41 @@ func (s *schedule) Pop() any {
 	return item
 }
 
+// scheduleChanges tracks the repos whose schedule or update queue state
+// changed since it was last persisted. A nil *scheduleChanges ignores all
+// changes.
+type scheduleChanges struct {
+	mu  sync.Mutex
+	ids map[api.RepoID]struct{}
+}
+
+func (c *scheduleChanges) add(id api.RepoID) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.ids[id] = struct{}{}
+	c.mu.Unlock()
+}
+
+// take returns and clears the recorded changes.
+func (c *scheduleChanges) take() map[api.RepoID]struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := c.ids
+	c.ids = make(map[api.RepoID]struct{})
+	return ids
+}
+
+// merge adds back changes returned by take that could not be persisted.
+func (c *scheduleChanges) merge(ids map[api.RepoID]struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id := range ids {
+		c.ids[id] = struct{}{}
+	}
+}
+
 // notify performs a non-blocking send on the channel.
 // The channel should be buffered.
 var notify = func(ch chan struct{}) {
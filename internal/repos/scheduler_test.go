@@ -3,7 +3,9 @@ package repos
 import (
 	"container/heap"
 	"context"
+	"fmt"
 	"reflect"
+	"sort"
 	"testing"
 	"time"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	gitserverprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/mutablelimiter"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -1615,3 +1618,160 @@ func TestGetCustomInterval(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateScheduler_persistAndRestore(t *testing.T) {
+	_, stop := startRecording()
+	defer stop()
+
+	store := database.NewMockRepoUpdateScheduleStore()
+	db := database.NewMockDB()
+	db.RepoUpdateScheduleFunc.SetDefaultReturn(store)
+
+	a := configuredRepo{ID: 1, Name: "a"}
+	b := configuredRepo{ID: 2, Name: "b"}
+	c := configuredRepo{ID: 3, Name: "c"}
+
+	s := NewUpdateScheduler(logtest.Scoped(t), db)
+	s.schedule.randGenerator = &mockRandomGenerator{}
+	s.schedule.upsert(a)
+	s.schedule.upsert(b)
+	s.schedule.updateInterval(a, time.Hour)
+	s.updateQueue.enqueue(b, priorityHigh)
+	// c is queued for an update without being scheduled.
+	s.updateQueue.enqueue(c, priorityHigh)
+
+	if err := s.persist(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	high := int(priorityHigh)
+	want := []*database.RepoUpdateScheduleEntry{
+		{RepoID: 1, RepoName: "a", Interval: time.Hour, Due: defaultTime.Add(time.Hour)},
+		{RepoID: 2, RepoName: "b", Interval: minDelay, Due: defaultTime.Add(minDelay), QueuePriority: &high, QueueSeq: 1},
+		{RepoID: 3, RepoName: "c", QueuePriority: &high, QueueSeq: 2},
+	}
+	upserted := store.UpsertFunc.History()[0].Arg1
+	sort.Slice(upserted, func(i, j int) bool { return upserted[i].RepoID < upserted[j].RepoID })
+	if diff := cmp.Diff(want, upserted); diff != "" {
+		t.Fatalf("unexpected upserted entries (-want +got):\n%s", diff)
+	}
+
+	// Only changes since the last call are persisted. Repos that are
+	// neither scheduled nor queued are deleted.
+	s.schedule.remove(a)
+	s.updateQueue.remove(c, false)
+	if err := s.persist(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if have := store.UpsertFunc.History()[1].Arg1; len(have) != 0 {
+		t.Fatalf("unexpected upserted entries: %v", have)
+	}
+	deleted := store.DeleteFunc.History()[1].Arg1
+	sort.Slice(deleted, func(i, j int) bool { return deleted[i] < deleted[j] })
+	if !reflect.DeepEqual(deleted, []api.RepoID{1, 3}) {
+		t.Fatalf("unexpected deleted repos: %v", deleted)
+	}
+
+	// A new leader restores the persisted state.
+	store.ListFunc.SetDefaultReturn(want, nil)
+	restored := NewUpdateScheduler(logtest.Scoped(t), db)
+	if err := restored.restore(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := restored.ScheduleInfo(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Schedule.IntervalSeconds != 3600 || info.Queue != nil {
+		t.Fatalf("unexpected schedule info for a: %s", spew.Sdump(info))
+	}
+	info, err = restored.ScheduleInfo(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Schedule.Index != 0 || info.Queue == nil || info.Queue.Priority != int(priorityHigh) {
+		t.Fatalf("unexpected schedule info for b: %s", spew.Sdump(info))
+	}
+	info, err = restored.ScheduleInfo(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Schedule != nil || info.Queue == nil || info.Queue.Index != 1 {
+		t.Fatalf("unexpected schedule info for c: %s", spew.Sdump(info))
+	}
+}
+
+func TestUpdateScheduler_standby(t *testing.T) {
+	_, stop := startRecording()
+	defer stop()
+
+	store := database.NewMockRepoUpdateScheduleStore()
+	db := database.NewMockDB()
+	db.RepoUpdateScheduleFunc.SetDefaultReturn(store)
+
+	s := NewUpdateScheduler(logtest.Scoped(t), db)
+	s.standby.Store(true)
+
+	s.UpdateFromDiff(Diff{
+		Added:      types.Repos{{ID: 1, Name: "added"}},
+		Deleted:    types.Repos{{ID: 2, Name: "deleted"}},
+		Unmodified: types.Repos{{ID: 3, Name: "unmodified"}},
+	})
+	s.UpdateOnce(4, "manual")
+
+	if len(s.schedule.heap) != 0 || len(s.updateQueue.heap) != 0 {
+		t.Fatal("expected standby scheduler not to schedule repos itself")
+	}
+
+	if err := s.persistRequests(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	low, high := int(priorityLow), int(priorityHigh)
+	want := []*database.RepoUpdateScheduleRequest{
+		{RepoID: 2, Action: database.RepoUpdateScheduleActionRemove},
+		{RepoID: 1, Action: database.RepoUpdateScheduleActionUpsert, Priority: &low},
+		{RepoID: 3, Action: database.RepoUpdateScheduleActionUpsert},
+		{RepoID: 4, Action: database.RepoUpdateScheduleActionUpdate, Priority: &high},
+	}
+	if diff := cmp.Diff(want, store.CreateRequestsFunc.History()[0].Arg1); diff != "" {
+		t.Fatalf("unexpected requests (-want +got):\n%s", diff)
+	}
+
+	// The leader applies the requests.
+	for _, r := range want {
+		r.RepoName = api.RepoName(fmt.Sprintf("repo-%d", r.RepoID))
+	}
+	store.ClaimRequestsFunc.SetDefaultReturn(want, nil)
+	leader := NewUpdateScheduler(logtest.Scoped(t), db)
+	if err := leader.applyRequests(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if have := leader.ListRepoIDs(); len(have) != 2 {
+		t.Fatalf("unexpected scheduled repos: %v", have)
+	}
+	if have, want := len(leader.updateQueue.heap), 2; have != want {
+		t.Fatalf("unexpected queue length: have %d, want %d", have, want)
+	}
+	if front := leader.updateQueue.heap[0]; front.Repo.ID != 4 || front.Priority != priorityHigh {
+		t.Fatalf("unexpected front of queue: %+v", front)
+	}
+
+	// ScheduleInfo is served from the persisted state while on standby.
+	store.GetPositionFunc.SetDefaultReturn(&database.RepoUpdateSchedulePosition{
+		RepoUpdateScheduleEntry: database.RepoUpdateScheduleEntry{RepoID: 1, Interval: time.Minute, Due: defaultTime},
+		ScheduleIndex:           3,
+		ScheduleTotal:           10,
+	}, nil)
+	info, err := s.ScheduleInfo(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantInfo := &protocol.RepoUpdateSchedulerInfoResult{
+		Schedule: &protocol.RepoScheduleState{Index: 3, Total: 10, IntervalSeconds: 60, Due: defaultTime},
+	}
+	if diff := cmp.Diff(wantInfo, info); diff != "" {
+		t.Fatalf("unexpected schedule info (-want +got):\n%s", diff)
+	}
+}
@@ -58,11 +58,14 @@ type Store interface {
 	DeleteExternalServiceRepo(ctx context.Context, svc *types.ExternalService, id api.RepoID) (err error)
 	// CreateExternalServiceRepo inserts a single repo and its association to an
 	// external service, respectively in the repo and "external_service_repos" table.
-	// The associated external service must already exist.
+	// The associated external service must already exist. Code host key-value
+	// pairs of the repo (see types.CodeHostKeyValuePairPrefixes) are stored too.
 	CreateExternalServiceRepo(ctx context.Context, svc *types.ExternalService, r *types.Repo) (err error)
 	// UpdateExternalServiceRepo updates a single repo and its association to an
 	// external service, respectively in the repo and external_service_repos table.
-	// The associated external service must already exist.
+	// The associated external service must already exist. Stored code host
+	// key-value pairs are replaced with the ones of the repo, unless its
+	// KeyValuePairs are nil.
 	UpdateExternalServiceRepo(ctx context.Context, svc *types.ExternalService, r *types.Repo) (err error)
 	// UpdateRepo updates a single repo without updating its association to an
 	// external service. This must only be used when updating metadata on a repo
@@ -388,11 +391,15 @@ func (s *store) CreateExternalServiceRepo(ctx context.Context, svc *types.Extern
 		return err
 	}
 
-	return s.Exec(ctx, sqlf.Sprintf(upsertExternalServiceRepoQuery,
+	if err = s.Exec(ctx, sqlf.Sprintf(upsertExternalServiceRepoQuery,
 		svc.ID,
 		r.ID,
 		src.CloneURL,
-	))
+	)); err != nil {
+		return err
+	}
+
+	return s.syncCodeHostKeyValuePairs(ctx, r)
 }
 
 const createRepoQuery = `
@@ -551,13 +558,60 @@ func (s *store) UpdateExternalServiceRepo(ctx context.Context, svc *types.Extern
 		return err
 	}
 
-	return s.Exec(ctx, sqlf.Sprintf(upsertExternalServiceRepoQuery,
+	if err = s.Exec(ctx, sqlf.Sprintf(upsertExternalServiceRepoQuery,
 		svc.ID,
 		r.ID,
 		src.CloneURL,
+	)); err != nil {
+		return err
+	}
+
+	return s.syncCodeHostKeyValuePairs(ctx, r)
+}
+
+// syncCodeHostKeyValuePairs replaces the key-value pairs of the given repo
+// that are managed by the syncer (see types.CodeHostKeyValuePairPrefixes) with
+// the ones set on r. Other key-value pairs are left untouched. Nothing is done
+// if r.KeyValuePairs is nil.
+func (s *store) syncCodeHostKeyValuePairs(ctx context.Context, r *types.Repo) error {
+	if r.KeyValuePairs == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(r.KeyValuePairs))
+	for k := range r.KeyValuePairs {
+		if types.IsCodeHostKeyValuePair(k) {
+			keys = append(keys, k)
+		}
+	}
+
+	patterns := make([]string, 0, len(types.CodeHostKeyValuePairPrefixes))
+	for _, prefix := range types.CodeHostKeyValuePairPrefixes {
+		patterns = append(patterns, prefix+"%")
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(syncCodeHostKeyValuePairsQuery,
+		r.ID,
+		pq.Array(patterns),
+		pq.Array(keys),
+		r.ID,
+		pq.Array(keys),
 	))
 }
 
+const syncCodeHostKeyValuePairsQuery = `
+WITH deleted AS (
+	DELETE FROM repo_kvps
+	WHERE
+		repo_id = %s AND
+		key LIKE ANY(%s) AND
+		NOT key = ANY(%s)
+)
+INSERT INTO repo_kvps (repo_id, key, value)
+SELECT %s, key, NULL FROM unnest(%s::text[]) AS key
+ON CONFLICT (repo_id, key) DO NOTHING
+`
+
 const updateRepoQuery = `
 UPDATE repo
 SET
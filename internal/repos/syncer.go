@@ -713,6 +713,162 @@ func (s *Syncer) SyncExternalService(
 	return errs
 }
 
+// DryRunExternalServiceSync computes the Diff that syncing the given external
+// service would result in, without persisting anything. svc doesn't have to be
+// stored: it can hold a proposed configuration for an existing external
+// service (with the ID of that service), or a service that is yet to be
+// created (with a zero ID).
+//
+// Deleted contains the repos that are currently owned by only this external
+// service but aren't sourced with the proposed configuration anymore, which
+// are the repos the sync would delete.
+func (s *Syncer) DryRunExternalServiceSync(ctx context.Context, svc *types.ExternalService) (diff Diff, err error) {
+	if svc.CloudDefault {
+		return Diff{}, ErrCloudDefaultSync
+	}
+
+	src, err := s.Sourcer(ctx, svc)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	if err := src.CheckConnection(ctx); err != nil {
+		return Diff{}, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	results := make(chan SourceResult)
+	go func() {
+		src.ListRepos(ctx, results)
+		close(results)
+	}()
+	defer func() {
+		// Unblock the source if we return early.
+		cancel()
+		for range results {
+		}
+	}()
+
+	seen := make(map[api.RepoID]struct{})
+	batch := make([]*types.Repo, 0, dryRunBatchSize)
+	for res := range results {
+		if err := res.Err; err != nil {
+			// Warnings don't prevent a real sync from deleting repos, but any
+			// other error does, so a preview would be meaningless.
+			if errors.IsWarning(err) {
+				continue
+			}
+			return Diff{}, errors.Wrapf(err, "fetching from code host %s", svc.DisplayName)
+		}
+
+		batch = append(batch, res.Repo)
+		if len(batch) == dryRunBatchSize {
+			if err := s.dryRunSyncBatch(ctx, batch, seen, &diff); err != nil {
+				return Diff{}, err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := s.dryRunSyncBatch(ctx, batch, seen, &diff); err != nil {
+		return Diff{}, err
+	}
+
+	if svc.ID == 0 {
+		return diff, nil
+	}
+
+	owned, err := s.Store.RepoStore().List(ctx, database.ReposListOptions{ExternalServiceIDs: []int64{svc.ID}})
+	if err != nil {
+		return Diff{}, errors.Wrap(err, "syncer: listing repos of external service")
+	}
+	for _, r := range owned {
+		if _, ok := seen[r.ID]; ok {
+			continue
+		}
+		// Repos that are also owned by other external services only lose their
+		// association with this one.
+		if len(r.Sources) > 1 {
+			continue
+		}
+		diff.Deleted = append(diff.Deleted, r)
+	}
+
+	return diff, nil
+}
+
+// dryRunBatchSize is the number of sourced repos DryRunExternalServiceSync
+// looks up in the database at once.
+const dryRunBatchSize = 500
+
+// dryRunSyncBatch adds the sourced repos to diff as if they were synced,
+// recording the IDs of the stored repos they correspond to in seen.
+func (s *Syncer) dryRunSyncBatch(ctx context.Context, sourced []*types.Repo, seen map[api.RepoID]struct{}, diff *Diff) error {
+	if len(sourced) == 0 {
+		return nil
+	}
+
+	opts := database.ReposListOptions{
+		Names:          make([]string, 0, len(sourced)),
+		ExternalRepos:  make([]api.ExternalRepoSpec, 0, len(sourced)),
+		IncludeBlocked: true,
+		IncludeDeleted: true,
+		UseOr:          true,
+	}
+	for _, r := range sourced {
+		opts.Names = append(opts.Names, string(r.Name))
+		opts.ExternalRepos = append(opts.ExternalRepos, r.ExternalRepo)
+	}
+	stored, err := s.Store.RepoStore().List(ctx, opts)
+	if err != nil {
+		return errors.Wrap(err, "syncer: getting repos from the database")
+	}
+
+	byName := make(map[string][]*types.Repo, len(stored))
+	byExternalRepo := make(map[api.ExternalRepoSpec][]*types.Repo, len(stored))
+	for _, r := range stored {
+		name := strings.ToLower(string(r.Name))
+		byName[name] = append(byName[name], r)
+		byExternalRepo[r.ExternalRepo] = append(byExternalRepo[r.ExternalRepo], r)
+	}
+
+	for _, sourced := range sourced {
+		// These are the stored repos sync would look up for sourced.
+		matches := append([]*types.Repo(nil), byName[strings.ToLower(string(sourced.Name))]...)
+		for _, r := range byExternalRepo[sourced.ExternalRepo] {
+			if !strings.EqualFold(string(r.Name), string(sourced.Name)) {
+				matches = append(matches, r)
+			}
+		}
+
+		// This mirrors how sync treats the stored repos.
+		var existing *types.Repo
+		switch len(matches) {
+		case 0:
+		case 1:
+			existing = matches[0]
+		default:
+			for _, r := range matches {
+				if r.ExternalRepo.Equal(&sourced.ExternalRepo) {
+					existing = r
+				}
+			}
+		}
+
+		if existing == nil {
+			diff.Added = append(diff.Added, sourced)
+			continue
+		}
+
+		seen[existing.ID] = struct{}{}
+		if modified := existing.Update(sourced); modified == types.RepoUnmodified {
+			diff.Unmodified = append(diff.Unmodified, existing)
+		} else {
+			diff.Modified = append(diff.Modified, RepoModified{Repo: existing, Modified: modified})
+		}
+	}
+	return nil
+}
+
 // syncs a sourced repo of a given external service, returning a diff with a single repo.
 func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *types.Repo) (d Diff, err error) {
 	tx, err := s.Store.Transact(ctx)
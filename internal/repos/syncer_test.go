@@ -1030,6 +1030,80 @@ func TestSyncRun(t *testing.T) {
 	}
 }
 
+func TestDryRunExternalServiceSync(t *testing.T) {
+	t.Parallel()
+	store := getTestRepoStore(t)
+
+	ctx := context.Background()
+
+	svc := &types.ExternalService{
+		Config: extsvc.NewUnencryptedConfig(`{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`),
+		Kind:   extsvc.KindGitHub,
+	}
+	if err := store.ExternalServiceStore().Upsert(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+
+	mk := func(name string) *types.Repo {
+		return &types.Repo{
+			Name:     api.RepoName(name),
+			Metadata: &github.Repository{},
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          name,
+				ServiceID:   "https://github.com",
+				ServiceType: svc.Kind,
+			},
+		}
+	}
+
+	stored := types.Repos{mk("unmodified"), mk("modified"), mk("deleted")}.With(typestest.Opt.RepoSources(svc.URN()))
+	if err := store.RepoStore().Create(ctx, stored...); err != nil {
+		t.Fatal(err)
+	}
+
+	sourced := types.Repos{
+		mk("unmodified"),
+		mk("modified").With(func(r *types.Repo) { r.Description = "updated" }),
+		mk("added"),
+	}
+
+	syncer := &repos.Syncer{
+		ObsvCtx: observation.TestContextTB(t),
+		Sourcer: repos.NewFakeSourcer(nil, repos.NewFakeSource(svc, nil, sourced...)),
+		Store:   store,
+		Now:     time.Now,
+	}
+
+	diff, err := syncer.DryRunExternalServiceSync(ctx, svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		state string
+		have  types.Repos
+		want  []string
+	}{
+		{"added", diff.Added, []string{"added"}},
+		{"modified", diff.Modified.Repos(), []string{"modified"}},
+		{"deleted", diff.Deleted, []string{"deleted"}},
+		{"unmodified", diff.Unmodified, []string{"unmodified"}},
+	} {
+		if d := cmp.Diff(tc.want, tc.have.Names()); d != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", tc.state, d)
+		}
+	}
+
+	// Nothing was persisted.
+	after, err := store.RepoStore().List(ctx, database.ReposListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(stored, after, cmpopts.IgnoreFields(types.Repo{}, "CreatedAt", "UpdatedAt", "Sources", "Metadata"), cmpopts.SortSlices(func(a, b *types.Repo) bool { return a.ID < b.ID })); d != "" {
+		t.Fatalf("stored repos changed (-want +got):\n%s", d)
+	}
+}
+
 func TestSyncerMultipleServices(t *testing.T) {
 	t.Parallel()
 	store := getTestRepoStore(t)
@@ -2122,3 +2196,98 @@ var jobComparer = cmp.Comparer(func(x, y *webhookworker.Job) bool {
 var noopProgressRecorder = func(ctx context.Context, progress repos.SyncProgress, final bool) error {
 	return nil
 }
+
+func TestSyncerSyncCodeHostKeyValuePairs(t *testing.T) {
+	t.Parallel()
+	store := getTestRepoStore(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	now := time.Now()
+
+	svc := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "Github - Test",
+		Config:      extsvc.NewUnencryptedConfig(basicGitHubConfig),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := store.ExternalServiceStore().Upsert(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+
+	githubRepo := &types.Repo{
+		Name:     "github.com/org/foo",
+		Metadata: &github.Repository{},
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "foo-external-12345",
+			ServiceID:   "https://github.com/",
+			ServiceType: extsvc.TypeGitHub,
+		},
+		KeyValuePairs: map[string]*string{
+			"github.topic:go":     nil,
+			"github.team:org/foo": nil,
+		},
+	}
+
+	sync := func() {
+		t.Helper()
+
+		syncer := &repos.Syncer{
+			ObsvCtx: observation.TestContextTB(t),
+			Sourcer: func(ctx context.Context, service *types.ExternalService) (repos.Source, error) {
+				return repos.NewFakeSource(svc, nil, githubRepo), nil
+			},
+			Store: store,
+			Now:   time.Now,
+		}
+		if err := syncer.SyncExternalService(ctx, svc.ID, 10*time.Second, noopProgressRecorder); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assertKeyValuePairs := func(want map[string]*string) {
+		t.Helper()
+
+		repo, err := store.RepoStore().GetByName(ctx, githubRepo.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, repo.KeyValuePairs); diff != "" {
+			t.Errorf("unexpected key-value pairs (-want +got):\n%s", diff)
+		}
+	}
+
+	sync()
+	assertKeyValuePairs(map[string]*string{
+		"github.topic:go":     nil,
+		"github.team:org/foo": nil,
+	})
+
+	// Key-value pairs that weren't set by the syncer must be left untouched.
+	repo, err := store.RepoStore().GetByName(ctx, githubRepo.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := "alice"
+	if err := database.NewDBWith(log.NoOp(), store).RepoKVPs().Create(ctx, repo.ID, database.KeyValuePair{Key: "owner", Value: &owner}); err != nil {
+		t.Fatal(err)
+	}
+
+	githubRepo.KeyValuePairs = map[string]*string{"github.topic:rust": nil}
+	sync()
+	assertKeyValuePairs(map[string]*string{
+		"github.topic:rust": nil,
+		"owner":             &owner,
+	})
+
+	// Nil key-value pairs mean the source didn't report them.
+	githubRepo.KeyValuePairs = nil
+	githubRepo.Description = "updated"
+	sync()
+	assertKeyValuePairs(map[string]*string{
+		"github.topic:rust": nil,
+		"owner":             &owner,
+	})
+}
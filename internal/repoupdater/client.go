@@ -270,6 +270,38 @@ func (c *Client) SyncExternalService(ctx context.Context, externalServiceID int6
 	return &result, nil
 }
 
+// MockSyncExternalServiceDryRun mocks (*Client).SyncExternalServiceDryRun for tests.
+var MockSyncExternalServiceDryRun func(ctx context.Context, req protocol.ExternalServiceSyncDryRunRequest) (*protocol.ExternalServiceSyncDryRunResult, error)
+
+// SyncExternalServiceDryRun computes the changes syncing an external service with the given
+// configuration would make, without making them.
+func (c *Client) SyncExternalServiceDryRun(ctx context.Context, req protocol.ExternalServiceSyncDryRunRequest) (*protocol.ExternalServiceSyncDryRunResult, error) {
+	if MockSyncExternalServiceDryRun != nil {
+		return MockSyncExternalServiceDryRun(ctx, req)
+	}
+
+	resp, err := c.httpPost(ctx, "sync-external-service-dry-run", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.New(string(bs))
+	}
+
+	var result protocol.ExternalServiceSyncDryRunResult
+	if err = json.Unmarshal(bs, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 func (c *Client) httpPost(ctx context.Context, method string, payload any) (resp *http.Response, err error) {
 	reqBody, err := json.Marshal(payload)
 	if err != nil {
@@ -268,3 +268,27 @@ type ExternalServiceSyncRequest struct {
 type ExternalServiceSyncResult struct {
 	Error string
 }
+
+// ExternalServiceSyncDryRunRequest is a request to compute the changes syncing
+// an external service with a proposed configuration would make, without making
+// them.
+type ExternalServiceSyncDryRunRequest struct {
+	// ExternalServiceID is the ID of the external service whose configuration
+	// is being changed, or 0 if the external service doesn't exist yet.
+	ExternalServiceID int64
+	// Kind is the kind of the external service. It is ignored if
+	// ExternalServiceID is set.
+	Kind string
+	// Config is the proposed configuration, with any redacted secrets already
+	// replaced.
+	Config string
+}
+
+// ExternalServiceSyncDryRunResult is the result of an external service sync dry
+// run.
+type ExternalServiceSyncDryRunResult struct {
+	Added      []string
+	Modified   []string
+	Deleted    []string
+	Unmodified int
+}
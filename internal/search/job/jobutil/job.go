@@ -61,6 +61,16 @@ func NewPlanJob(inputs *search.Inputs, plan query.Plan) (job.Job, error) {
 
 // NewBasicJob converts a query.Basic into its job tree representation.
 func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
+	// Commit and diff search pass a revision range to git log as is. Other
+	// searches search both ends of the range and compare their matches.
+	if r, ok := b.RevisionRange(); ok && !computeResultTypes(b, inputs.PatternType).Has(result.TypeCommit|result.TypeDiff) {
+		child, err := NewBasicJob(inputs, b.ExpandRevisionRange())
+		if err != nil {
+			return nil, err
+		}
+		return NewRevisionCompareJob(r, child), nil
+	}
+
 	var children []job.Job
 	addJob := func(j job.Job) {
 		children = append(children, j)
@@ -122,7 +132,10 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 			}
 		}
 
-		if resultTypes.Has(result.TypeSymbol) {
+		// Zoekt cannot filter symbols by kind or parent, so symbol searches
+		// with a symbol filter only run on the symbols service (see
+		// NewFlatJob), which applies the filter in its queries.
+		if resultTypes.Has(result.TypeSymbol) && toSymbolFilter(b).IsEmpty() {
 			// Create Global Symbol Search jobs.
 			if repoUniverseSearch {
 				job, err := builder.newZoektGlobalSearch(search.SymbolRequest)
@@ -297,7 +310,7 @@ func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 
 	repoOptions := toRepoOptions(f.ToBasic(), searchInputs.UserSettings)
 
-	_, skipRepoSubsetSearch, _ := jobMode(f.ToBasic(), repoOptions, resultTypes, searchInputs.PatternType, searchInputs.OnSourcegraphDotCom)
+	repoUniverseSearch, skipRepoSubsetSearch, _ := jobMode(f.ToBasic(), repoOptions, resultTypes, searchInputs.PatternType, searchInputs.OnSourcegraphDotCom)
 
 	var allJobs []job.Job
 	addJob := func(job job.Job) {
@@ -333,8 +346,16 @@ func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 
 		// Create Symbol Search Jobs
 		if resultTypes.Has(result.TypeSymbol) {
+			symbolRepoOptions := repoOptions
+			skipSymbolSearch := skipRepoSubsetSearch
+			if !patternInfo.SymbolFilter.IsEmpty() {
+				// The symbols service searches indexed repositories too.
+				symbolRepoOptions.UseIndex = query.No
+				skipSymbolSearch = skipRepoSubsetSearch && !repoUniverseSearch
+			}
+
 			// Create Symbol Search jobs over repo set.
-			if !skipRepoSubsetSearch {
+			if !skipSymbolSearch {
 				symbolSearchJob := &searcher.SymbolSearchJob{
 					PatternInfo: patternInfo,
 					Limit:       maxResults,
@@ -342,7 +363,7 @@ func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 
 				addJob(&repoPagerJob{
 					child:            &reposPartialJob{symbolSearchJob},
-					repoOpts:         repoOptions,
+					repoOpts:         symbolRepoOptions,
 					containsRefGlobs: query.ContainsRefGlobs(f.ToBasic().ToParseTree()),
 				})
 			}
@@ -608,6 +629,29 @@ func toTextPatternInfo(b query.Basic, resultTypes result.Types, p search.Protoco
 		CombyRule:                    b.FindValue(query.FieldCombyRule),
 		Index:                        b.Index(),
 		Select:                       selector,
+		SymbolFilter:                 toSymbolFilter(b),
+	}
+}
+
+// toSymbolFilter returns the filter for the symbol.kind: and symbol.parent:
+// filters of b. Several symbol.kind: filters match symbols of any of the kinds.
+func toSymbolFilter(b query.Basic) search.SymbolFilter {
+	kindsInclude, kindsExclude := b.IncludeExcludeValues(query.FieldSymbolKind)
+	parentsInclude, parentsExclude := b.IncludeExcludeValues(query.FieldSymbolParent)
+
+	symbolKinds := func(selectKinds []string) (kinds []string) {
+		for _, selectKind := range selectKinds {
+			kinds = append(kinds, result.SymbolKindsForSelectKind(selectKind)...)
+		}
+		return kinds
+	}
+
+	return search.SymbolFilter{
+		IncludeKinds:          symbolKinds(kindsInclude),
+		ExcludeKinds:          symbolKinds(kindsExclude),
+		IncludeParentPatterns: parentsInclude,
+		ExcludeParentPattern:  query.UnionRegExps(parentsExclude),
+		IsCaseSensitive:       b.IsCaseSensitive(),
 	}
 }
 
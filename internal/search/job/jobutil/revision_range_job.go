@@ -0,0 +1,183 @@
+package jobutil
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// NewRevisionCompareJob creates a job that compares the content matches of the
+// two ends of a revision range. The child is expected to search both ends of
+// the range, i.e. repo@from:to.
+//
+// For a range from..to only the matches present at to but not at from are
+// sent. For a symmetric range from...to the matches present at exactly one of
+// the ends are sent.
+func NewRevisionCompareJob(r gitdomain.RevisionRange, child job.Job) job.Job {
+	return &revisionCompareJob{revRange: r, child: child}
+}
+
+type revisionCompareJob struct {
+	revRange gitdomain.RevisionRange
+	child    job.Job
+}
+
+func (j *revisionCompareJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	// Both ends must be searched completely before matches can be compared.
+	agg := streaming.NewAggregatingStream()
+	alert, err = j.child.Run(ctx, clients, agg)
+
+	stream.Send(streaming.SearchEvent{
+		Results: compareRevisionMatches(j.revRange, agg.Results),
+		Stats:   agg.Stats,
+	})
+	return alert, err
+}
+
+func (j *revisionCompareJob) Name() string {
+	return "RevisionCompareJob"
+}
+
+func (j *revisionCompareJob) Fields(v job.Verbosity) (res []log.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Stringer("revisionRange", j.revRange),
+		)
+	}
+	return res
+}
+
+func (j *revisionCompareJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *revisionCompareJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+type revisionCompareKey struct {
+	repo api.RepoID
+	path string
+}
+
+// compareRevisionMatches returns the matches of the ends of r which are not
+// present at the other end. Matches which don't belong to either end of the
+// range are returned as is.
+func compareRevisionMatches(r gitdomain.RevisionRange, matches result.Matches) result.Matches {
+	from, to := r.Ends()
+	side := func(fm *result.FileMatch) int {
+		rev := "HEAD"
+		if fm.InputRev != nil && *fm.InputRev != "" {
+			rev = *fm.InputRev
+		}
+		switch rev {
+		case from:
+			return 0
+		case to:
+			return 1
+		default:
+			return -1
+		}
+	}
+
+	// A file matching at both ends may be reported more than once per end, for
+	// example by both indexed and unindexed search, so we merge those.
+	files := make(map[revisionCompareKey]*[2]*result.FileMatch)
+	var order []revisionCompareKey
+	var compared result.Matches
+	for _, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok || side(fm) == -1 {
+			compared = append(compared, m)
+			continue
+		}
+		k := revisionCompareKey{repo: fm.Repo.ID, path: fm.Path}
+		ends, ok := files[k]
+		if !ok {
+			ends = &[2]*result.FileMatch{}
+			files[k] = ends
+			order = append(order, k)
+		}
+		if prev := ends[side(fm)]; prev != nil {
+			prev.AppendMatches(fm)
+		} else {
+			ends[side(fm)] = fm
+		}
+	}
+
+	for _, k := range order {
+		ends := files[k]
+		if fm := subtractFileMatch(ends[1], ends[0]); fm != nil {
+			compared = append(compared, fm)
+		}
+		if r.Symmetric {
+			if fm := subtractFileMatch(ends[0], ends[1]); fm != nil {
+				compared = append(compared, fm)
+			}
+		}
+	}
+	return compared
+}
+
+// subtractFileMatch returns the chunk and symbol matches of fm which are not
+// matched by other, or nil if there are none. Chunks are compared by content
+// since line numbers usually differ between revisions.
+func subtractFileMatch(fm, other *result.FileMatch) *result.FileMatch {
+	if fm == nil || other == nil {
+		return fm
+	}
+	if fm.IsPathMatch() {
+		// The path matches at both ends.
+		return nil
+	}
+
+	otherChunks := make(map[string]struct{}, len(other.ChunkMatches))
+	for _, cm := range other.ChunkMatches {
+		otherChunks[cm.Content] = struct{}{}
+	}
+	otherSymbols := make(map[result.Symbol]struct{}, len(other.Symbols))
+	for _, sm := range other.Symbols {
+		otherSymbols[symbolIdentity(sm.Symbol)] = struct{}{}
+	}
+
+	cp := *fm
+	cp.ChunkMatches = nil
+	for _, cm := range fm.ChunkMatches {
+		if _, ok := otherChunks[cm.Content]; !ok {
+			cp.ChunkMatches = append(cp.ChunkMatches, cm)
+		}
+	}
+	cp.Symbols = nil
+	for _, sm := range fm.Symbols {
+		if _, ok := otherSymbols[symbolIdentity(sm.Symbol)]; !ok {
+			cp.Symbols = append(cp.Symbols, sm)
+		}
+	}
+	if cp.IsPathMatch() {
+		return nil
+	}
+	return &cp
+}
+
+// symbolIdentity returns s without its location, which usually differs
+// between revisions.
+func symbolIdentity(s result.Symbol) result.Symbol {
+	s.Line, s.Character = 0, 0
+	return s
+}
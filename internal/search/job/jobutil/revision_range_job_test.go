@@ -0,0 +1,91 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRevisionCompareJob(t *testing.T) {
+	fileMatch := func(rev, path string, chunks ...string) *result.FileMatch {
+		fm := &result.FileMatch{File: result.File{
+			InputRev: &rev,
+			Repo:     types.MinimalRepo{ID: 1, Name: "foo"},
+			Path:     path,
+		}}
+		for i, chunk := range chunks {
+			fm.ChunkMatches = append(fm.ChunkMatches, result.ChunkMatch{
+				Content:      chunk,
+				ContentStart: result.Location{Line: i},
+				Ranges:       result.Ranges{{Start: result.Location{Line: i}, End: result.Location{Line: i, Column: 1}}},
+			})
+		}
+		return fm
+	}
+
+	matches := result.Matches{
+		fileMatch("v1.2", "a.go", "old()", "kept()"),
+		fileMatch("v1.3", "a.go", "kept()", "added()"),
+		fileMatch("v1.2", "removed.go", "old()"),
+		fileMatch("v1.3", "added.go", "added()"),
+		fileMatch("v1.2", "same.go", "same()"),
+		fileMatch("v1.3", "same.go", "same()"),
+		&result.RepoMatch{Name: "foo", ID: 1},
+	}
+
+	run := func(t *testing.T, r gitdomain.RevisionRange) []string {
+		t.Helper()
+
+		child := mockjob.NewMockJob()
+		child.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: matches})
+			return nil, nil
+		})
+
+		agg := streaming.NewAggregatingStream()
+		_, err := NewRevisionCompareJob(r, child).Run(context.Background(), job.RuntimeClients{}, agg)
+		require.NoError(t, err)
+
+		var got []string
+		for _, m := range agg.Results {
+			switch v := m.(type) {
+			case *result.FileMatch:
+				for _, cm := range v.ChunkMatches {
+					got = append(got, *v.InputRev+":"+v.Path+":"+cm.Content)
+				}
+			case *result.RepoMatch:
+				got = append(got, "repo:"+string(v.Name))
+			}
+		}
+		return got
+	}
+
+	t.Run("two-dot range", func(t *testing.T) {
+		got := run(t, gitdomain.RevisionRange{From: "v1.2", To: "v1.3"})
+		require.Equal(t, []string{
+			"repo:foo",
+			"v1.3:a.go:added()",
+			"v1.3:added.go:added()",
+		}, got)
+	})
+
+	t.Run("three-dot range", func(t *testing.T) {
+		got := run(t, gitdomain.RevisionRange{From: "v1.2", To: "v1.3", Symmetric: true})
+		require.Equal(t, []string{
+			"repo:foo",
+			"v1.3:a.go:added()",
+			"v1.2:a.go:old()",
+			"v1.2:removed.go:old()",
+			"v1.3:added.go:added()",
+		}, got)
+	})
+}
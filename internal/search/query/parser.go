@@ -601,7 +601,8 @@ func ScanField(buf []byte) (string, bool, int) {
 	success := false
 	for len(buf) > 0 {
 		r = next()
-		if strings.ContainsRune(allowed, r) {
+		// Fields like symbol.kind are namespaced with a dot.
+		if strings.ContainsRune(allowed, r) || (r == '.' && len(result) > 0 && result[len(result)-1] != '-') {
 			result = append(result, r)
 			continue
 		}
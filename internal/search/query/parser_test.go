@@ -289,6 +289,10 @@ func TestScanField(t *testing.T) {
 	autogold.Want("-repo", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("-repo"))
 	autogold.Want("--repo:", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("--repo:"))
 	autogold.Want(":foo", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test(":foo"))
+	autogold.Want("symbol.kind:method", `{"Field":"symbol.kind","Negated":false,"Advance":12}`).Equal(t, test("symbol.kind:method"))
+	autogold.Want("-symbol.parent:", `{"Field":"symbol.parent","Negated":true,"Advance":15}`).Equal(t, test("-symbol.parent:"))
+	autogold.Want("-.kind:", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("-.kind:"))
+	autogold.Want("symbol.foo:", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("symbol.foo:"))
 }
 
 func parseAndOrGrammar(in string) ([]Node, error) {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/grafana/regexp"
 
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/limits"
 )
 
@@ -570,6 +571,39 @@ func (p Parameters) Repositories() (repos []string, negatedRepos []string) {
 	return repos, negatedRepos
 }
 
+// RevisionRange returns the revision range that repo: filters of the form
+// repo@from..to specify, if any. Validation guarantees that a query contains at
+// most one distinct revision range.
+func (p Parameters) RevisionRange() (gitdomain.RevisionRange, bool) {
+	repos, _ := p.Repositories()
+	for _, repo := range repos {
+		i := strings.Index(repo, "@")
+		if i == -1 {
+			continue
+		}
+		if r, isRange, err := gitdomain.ParseRevisionRange(repo[i+1:]); isRange && err == nil {
+			return r, true
+		}
+	}
+	return gitdomain.RevisionRange{}, false
+}
+
+// ExpandRevisionRange replaces the revision range of repo: filters of the form
+// repo@from..to with the two ends of the range, i.e. repo@from:to, so that both
+// ends are searched.
+func (b Basic) ExpandRevisionRange() Basic {
+	nodes := MapField(toNodes(b.Parameters), FieldRepo, func(value string, negated bool, annotation Annotation) Node {
+		if i := strings.Index(value, "@"); i != -1 && !negated {
+			if r, isRange, err := gitdomain.ParseRevisionRange(value[i+1:]); isRange && err == nil {
+				from, to := r.Ends()
+				value = value[:i+1] + from + ":" + to
+			}
+		}
+		return Parameter{Field: FieldRepo, Value: value, Negated: negated, Annotation: annotation}
+	})
+	return Basic{Parameters: toParameters(nodes), Pattern: b.Pattern}
+}
+
 func (p Parameters) Visibility() RepoVisibility {
 	visibilityStr := p.FindValue(FieldVisibility)
 	return ParseVisibility(visibilityStr)
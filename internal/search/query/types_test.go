@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 )
 
 func TestRepoHasDescription(t *testing.T) {
@@ -56,3 +58,25 @@ func TestRepoHasKVPs(t *testing.T) {
 
 	require.Equal(t, want, ps.RepoHasKVPs())
 }
+
+func TestExpandRevisionRange(t *testing.T) {
+	b := Basic{Parameters: Parameters{
+		Parameter{Field: FieldRepo, Value: "foo@v1.2...v1.3"},
+		Parameter{Field: FieldRepo, Value: "bar@main..", Negated: true},
+		Parameter{Field: FieldFile, Value: "README"},
+	}}
+
+	r, ok := b.RevisionRange()
+	require.True(t, ok)
+	require.Equal(t, gitdomain.RevisionRange{From: "v1.2", To: "v1.3", Symmetric: true}, r)
+
+	expanded := b.ExpandRevisionRange()
+	require.Equal(t, Parameters{
+		Parameter{Field: FieldRepo, Value: "foo@v1.2:v1.3"},
+		Parameter{Field: FieldRepo, Value: "bar@main..", Negated: true},
+		Parameter{Field: FieldFile, Value: "README"},
+	}, expanded.Parameters)
+
+	_, ok = expanded.RevisionRange()
+	require.False(t, ok)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/go-enry/go-enry/v2"
 	"github.com/grafana/regexp"
 
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -216,6 +217,13 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		return err
 	}
 
+	isValidSymbolKind := func() error {
+		if _, err := filter.SelectPathFromString(filter.Symbol + "." + value); err != nil {
+			return errors.Errorf("invalid value %q for field %q. Valid values are the kinds of select:symbol, e.g. function or method", value, field)
+		}
+		return nil
+	}
+
 	isValidGitDate := func() error {
 		_, err := ParseGitDate(value, time.Now)
 		return err
@@ -292,6 +300,12 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldSelect:
 		return satisfies(isSingular, isNotNegated, isValidSelect)
+	case
+		FieldSymbolKind:
+		return satisfies(isValidSymbolKind)
+	case
+		FieldSymbolParent:
+		return satisfies(isValidRegexp)
 	default:
 		return isUnrecognizedField()
 	}
@@ -352,6 +366,24 @@ func validateCommitParameters(nodes []Node) error {
 	return nil
 }
 
+// Queries containing symbol parameters without type:symbol are not valid.
+func validateSymbolParameters(nodes []Node) error {
+	var seenSymbolParam string
+	var typeSymbolExists bool
+	VisitParameter(nodes, func(field, value string, _ bool, _ Annotation) {
+		if field == FieldSymbolKind || field == FieldSymbolParent {
+			seenSymbolParam = field
+		}
+		if field == FieldType && value == "symbol" {
+			typeSymbolExists = true
+		}
+	})
+	if seenSymbolParam != "" && !typeSymbolExists {
+		return errors.Errorf(`your query contains the field '%s', which requires type:symbol in the query`, seenSymbolParam)
+	}
+	return nil
+}
+
 func validateTypeStructural(nodes []Node) error {
 	seenStructural := false
 	seenType := false
@@ -390,6 +422,55 @@ func validateRefGlobs(nodes []Node) error {
 	return nil
 }
 
+// validateRevisionRanges validates revision ranges like rev:v1.2..v1.3. A range
+// must be the only revision of a repository, and all the ranges of a query must
+// be the same so that content search can compare the ends of the range.
+func validateRevisionRanges(nodes []Node) error {
+	var ranges []string
+	validateRevs := func(revs string) error {
+		parts := strings.Split(revs, ":")
+		for _, part := range parts {
+			_, isRange, err := gitdomain.ParseRevisionRange(part)
+			if err != nil {
+				return err
+			}
+			if !isRange {
+				continue
+			}
+			if len(parts) > 1 {
+				return errors.Errorf("invalid revision %q. A revision range cannot be combined with other revisions", revs)
+			}
+			ranges = append(ranges, part)
+		}
+		return nil
+	}
+
+	var err error
+	VisitParameter(nodes, func(field, value string, negated bool, annotation Annotation) {
+		if err != nil || negated || annotation.Labels.IsSet(IsPredicate) {
+			return
+		}
+		switch field {
+		case FieldRev:
+			err = validateRevs(value)
+		case FieldRepo:
+			if i := strings.Index(value, "@"); i != -1 {
+				err = validateRevs(value[i+1:])
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, r := range ranges {
+		if r != ranges[0] {
+			return errors.Errorf("invalid revision ranges %q and %q. A query can only contain one revision range", ranges[0], r)
+		}
+	}
+	return nil
+}
+
 // validatePredicates validates predicate parameters with respect to their validation logic.
 func validatePredicate(field, value string, negated bool) error {
 	name, params := ParseAsPredicate(value)                // guaranteed to succeed
@@ -492,8 +573,10 @@ func validate(nodes []Node) error {
 		validateRepoRevPair,
 		validateRepoHasFile,
 		validateCommitParameters,
+		validateSymbolParameters,
 		validateTypeStructural,
 		validateRefGlobs,
+		validateRevisionRanges,
 	)
 }
 
@@ -79,10 +79,30 @@ func TestValidation(t *testing.T) {
 			input: `repo:'' rev:bedge`,
 			want:  "invalid syntax. The query contains `rev:` without `repo:`. Add a `repo:` filter and try again",
 		},
+		{
+			input: "repo:foo rev:v1.2..v1.3:main",
+			want:  `invalid revision "v1.2..v1.3:main". A revision range cannot be combined with other revisions`,
+		},
+		{
+			input: "repo:foo@v1.2..v1.3 repo:bar@v1.3..v1.4",
+			want:  `invalid revision ranges "v1.2..v1.3" and "v1.3..v1.4". A query can only contain one revision range`,
+		},
 		{
 			input: "repo:foo author:rob@saucegraph.com",
 			want:  `your query contains the field 'author', which requires type:commit or type:diff in the query`,
 		},
+		{
+			input: "symbol.kind:method Server",
+			want:  `your query contains the field 'symbol.kind', which requires type:symbol in the query`,
+		},
+		{
+			input: "type:symbol symbol.kind:methods Server",
+			want:  `invalid value "methods" for field "symbol.kind". Valid values are the kinds of select:symbol, e.g. function or method`,
+		},
+		{
+			input: "type:symbol symbol.parent:[ Server",
+			want:  "error parsing regexp: missing closing ]: `[`",
+		},
 		{
 			input: "repohasfile:README type:symbol yolo",
 			want:  "repohasfile is not compatible for type:symbol. Subscribe to https://github.com/sourcegraph/sourcegraph/issues/4610 for updates",
@@ -422,12 +422,32 @@ func (r *Resolver) normalizeRepoRefs(
 			// so we could avoid resolving later.
 			revs = append(revs, rev.RevSpec)
 		case rev.RevSpec != "":
-			trimmedRev := strings.TrimPrefix(rev.RevSpec, "^")
-			_, err := r.gitserver.ResolveRevision(ctx, repo.Name, trimmedRev, gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
-			if err != nil {
-				if errors.Is(err, context.DeadlineExceeded) || errors.HasType(err, &gitdomain.BadCommitError{}) {
-					return nil, err
+			// A revision range like v1.2..v1.3 is passed on as is, but
+			// both of its ends must exist.
+			toResolve := []string{strings.TrimPrefix(rev.RevSpec, "^")}
+			if rr, isRange, err := gitdomain.ParseRevisionRange(rev.RevSpec); err != nil {
+				return nil, err
+			} else if isRange {
+				toResolve = toResolve[:0]
+				for _, end := range []string{rr.From, rr.To} {
+					if end != "" {
+						toResolve = append(toResolve, end)
+					}
+				}
+			}
+
+			missingRev := false
+			for _, trimmedRev := range toResolve {
+				_, err := r.gitserver.ResolveRevision(ctx, repo.Name, trimmedRev, gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+				if err != nil {
+					if errors.Is(err, context.DeadlineExceeded) || errors.HasType(err, &gitdomain.BadCommitError{}) {
+						return nil, err
+					}
+					missingRev = true
+					break
 				}
+			}
+			if missingRev {
 				reportMissing(RepoRevSpecs{Repo: repo, Revs: []search.RevisionSpecifier{rev}})
 				continue
 			}
@@ -3,6 +3,7 @@ package result
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -235,6 +236,20 @@ func pick(symbols []*SymbolMatch, satisfy func(*SymbolMatch) bool) []*SymbolMatc
 	return result
 }
 
+// SymbolKindsForSelectKind returns the internal symbol kinds (cf. ctagsKind)
+// which map to the symbol selector kind value, i.e. the inverse of
+// toSelectKind. The selector kind itself is always included.
+func SymbolKindsForSelectKind(selectKind string) []string {
+	kinds := []string{selectKind}
+	for kind, sk := range toSelectKind {
+		if sk == selectKind && kind != selectKind {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
 func SelectSymbolKind(symbols []*SymbolMatch, field string) []*SymbolMatch {
 	return pick(symbols, func(s *SymbolMatch) bool {
 		return field == toSelectKind[strings.ToLower(s.Symbol.Kind)]
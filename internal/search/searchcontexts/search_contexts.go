@@ -3,6 +3,7 @@ package searchcontexts
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -511,6 +512,15 @@ func ParseRepoOpts(contextQuery string) ([]RepoOpts, error) {
 			},
 		}
 
+		for _, filter := range p.RepoHasKVPs() {
+			rq.KVPFilters = append(rq.KVPFilters, database.RepoKVPFilter{
+				Key:     filter.Key,
+				Value:   filter.Value,
+				Negated: filter.Negated,
+				KeyOnly: filter.KeyOnly,
+			})
+		}
+
 		for _, r := range repoFilters {
 			repoFilter, revs := search.ParseRepositoryRevisions(r)
 			for _, rev := range revs {
@@ -527,6 +537,34 @@ func ParseRepoOpts(contextQuery string) ([]RepoOpts, error) {
 	return qs, nil
 }
 
+// QueryRepoIDs returns the IDs of all the repositories matched by the given
+// search context query, in ascending order.
+func QueryRepoIDs(ctx context.Context, db database.DB, contextQuery string) ([]api.RepoID, error) {
+	opts, err := ParseRepoOpts(contextQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[api.RepoID]struct{}{}
+	for _, o := range opts {
+		repos, err := db.Repos().ListMinimalRepos(ctx, o.ReposListOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range repos {
+			seen[r.ID] = struct{}{}
+		}
+	}
+
+	ids := make([]api.RepoID, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids, nil
+}
+
 func GetRepositoryRevisions(ctx context.Context, db database.DB, searchContextID int64) ([]search.RepositoryRevisions, error) {
 	searchContextRepositoryRevisions, err := db.SearchContexts().GetSearchContextRepositoryRevisions(ctx, searchContextID)
 	if err != nil {
@@ -627,6 +627,8 @@ func TestDeletingAutoDefinedSearchContext(t *testing.T) {
 }
 
 func TestParseRepoOpts(t *testing.T) {
+	alice := "alice"
+
 	for _, tc := range []struct {
 		in  string
 		out []RepoOpts
@@ -731,6 +733,22 @@ func TestParseRepoOpts(t *testing.T) {
 				},
 			},
 		},
+		{
+			in: "repo:has.tag(github.topic:payments) -repo:has.tag(github.team:org/legacy) repo:has(owner:alice)",
+			out: []RepoOpts{
+				{
+					ReposListOptions: database.ReposListOptions{
+						NoForks:    true,
+						NoArchived: true,
+						KVPFilters: []database.RepoKVPFilter{
+							{Key: "owner", Value: &alice},
+							{Key: "github.topic:payments"},
+							{Key: "github.team:org/legacy", Negated: true},
+						},
+					},
+				},
+			},
+		},
 	} {
 		t.Run(tc.in, func(t *testing.T) {
 			have, err := ParseRepoOpts(tc.in)
@@ -126,6 +126,7 @@ func searchInRepo(ctx context.Context, db database.DB, repoRevs *search.Reposito
 		IsRegExp:        patternInfo.IsRegExp,
 		IncludePatterns: patternInfo.IncludePatterns,
 		ExcludePattern:  patternInfo.ExcludePattern,
+		SymbolFilter:    patternInfo.SymbolFilter,
 		// Ask for limit + 1 so we can detect whether there are more results than the limit.
 		First: limit + 1,
 	})
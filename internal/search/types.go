@@ -102,6 +102,9 @@ type SymbolsParameters struct {
 	// need to match to get included in the result
 	ExcludePattern string
 
+	// SymbolFilter restricts the kinds and parents of the returned symbols.
+	SymbolFilter SymbolFilter
+
 	// First indicates that only the first n symbols should be returned.
 	First int
 
@@ -109,6 +112,86 @@ type SymbolsParameters struct {
 	Timeout int
 }
 
+// SymbolFilter restricts symbol search results by the symbol.kind: and
+// symbol.parent: filters of a query.
+type SymbolFilter struct {
+	// IncludeKinds is a list of internal symbol kinds (cf. ctagsKind) in lower
+	// case. If non-empty, a symbol must be of one of these kinds.
+	IncludeKinds []string
+
+	// ExcludeKinds is a list of internal symbol kinds in lower case that a
+	// symbol must not be of.
+	ExcludeKinds []string
+
+	// IncludeParentPatterns is a list of regexes that a symbol's parent needs
+	// to match. The patterns are ANDed together.
+	IncludeParentPatterns []string
+
+	// ExcludeParentPattern is an optional regex that a symbol's parent must
+	// not match.
+	ExcludeParentPattern string
+
+	// IsCaseSensitive if false will ignore the case of parent patterns.
+	IsCaseSensitive bool
+}
+
+// IsEmpty returns true if the filter matches all symbols.
+func (f SymbolFilter) IsEmpty() bool {
+	return len(f.IncludeKinds) == 0 && len(f.ExcludeKinds) == 0 && len(f.IncludeParentPatterns) == 0 && f.ExcludeParentPattern == ""
+}
+
+// Matcher returns a function that reports whether a symbol matches the filter.
+func (f SymbolFilter) Matcher() (func(result.Symbol) bool, error) {
+	compile := func(pattern string) (*regexp.Regexp, error) {
+		if !f.IsCaseSensitive {
+			pattern = "(?i:" + pattern + ")"
+		}
+		return regexp.Compile(pattern)
+	}
+
+	includeParents := make([]*regexp.Regexp, 0, len(f.IncludeParentPatterns))
+	for _, pattern := range f.IncludeParentPatterns {
+		re, err := compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		includeParents = append(includeParents, re)
+	}
+	var excludeParent *regexp.Regexp
+	if f.ExcludeParentPattern != "" {
+		re, err := compile(f.ExcludeParentPattern)
+		if err != nil {
+			return nil, err
+		}
+		excludeParent = re
+	}
+
+	contains := func(kinds []string, kind string) bool {
+		for _, k := range kinds {
+			if k == kind {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(s result.Symbol) bool {
+		kind := strings.ToLower(s.Kind)
+		if len(f.IncludeKinds) > 0 && !contains(f.IncludeKinds, kind) {
+			return false
+		}
+		if contains(f.ExcludeKinds, kind) {
+			return false
+		}
+		for _, re := range includeParents {
+			if !re.MatchString(s.Parent) {
+				return false
+			}
+		}
+		return excludeParent == nil || !excludeParent.MatchString(s.Parent)
+	}, nil
+}
+
 type SymbolsResponse struct {
 	Symbols result.Symbols `json:"symbols,omitempty"`
 	Err     string         `json:"error,omitempty"`
@@ -216,6 +299,8 @@ type TextPatternInfo struct {
 	PatternMatchesPath    bool
 
 	Languages []string
+
+	SymbolFilter SymbolFilter
 }
 
 func (p *TextPatternInfo) Fields() []otlog.Field {
@@ -266,6 +351,18 @@ func (p *TextPatternInfo) Fields() []otlog.Field {
 	if len(p.Languages) > 0 {
 		add(trace.Strings("languages", p.Languages))
 	}
+	if len(p.SymbolFilter.IncludeKinds) > 0 {
+		add(trace.Strings("symbolIncludeKinds", p.SymbolFilter.IncludeKinds))
+	}
+	if len(p.SymbolFilter.ExcludeKinds) > 0 {
+		add(trace.Strings("symbolExcludeKinds", p.SymbolFilter.ExcludeKinds))
+	}
+	if len(p.SymbolFilter.IncludeParentPatterns) > 0 {
+		add(trace.Strings("symbolIncludeParentPatterns", p.SymbolFilter.IncludeParentPatterns))
+	}
+	if p.SymbolFilter.ExcludeParentPattern != "" {
+		add(otlog.String("symbolExcludeParentPattern", p.SymbolFilter.ExcludeParentPattern))
+	}
 	return res
 }
 
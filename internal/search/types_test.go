@@ -0,0 +1,68 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestSymbolFilterMatcher(t *testing.T) {
+	server := result.Symbol{Name: "Server", Kind: "struct"}
+	serve := result.Symbol{Name: "Serve", Kind: "method", Parent: "Server"}
+	close := result.Symbol{Name: "Close", Kind: "Method", Parent: "Listener"}
+	listen := result.Symbol{Name: "Listen", Kind: "func"}
+	symbols := []result.Symbol{server, serve, close, listen}
+
+	cases := []struct {
+		name   string
+		filter SymbolFilter
+		want   []result.Symbol
+	}{
+		{
+			name:   "empty",
+			filter: SymbolFilter{},
+			want:   symbols,
+		},
+		{
+			name:   "kinds",
+			filter: SymbolFilter{IncludeKinds: []string{"method", "func"}},
+			want:   []result.Symbol{serve, close, listen},
+		},
+		{
+			name:   "excluded kinds",
+			filter: SymbolFilter{ExcludeKinds: []string{"method"}},
+			want:   []result.Symbol{server, listen},
+		},
+		{
+			name:   "parent",
+			filter: SymbolFilter{IncludeKinds: []string{"method"}, IncludeParentPatterns: []string{"^server$"}},
+			want:   []result.Symbol{serve},
+		},
+		{
+			name:   "case sensitive parent",
+			filter: SymbolFilter{IncludeParentPatterns: []string{"^server$"}, IsCaseSensitive: true},
+			want:   nil,
+		},
+		{
+			name:   "excluded parent",
+			filter: SymbolFilter{IncludeKinds: []string{"method"}, ExcludeParentPattern: "Listener"},
+			want:   []result.Symbol{serve},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			match, err := tc.filter.Matcher()
+			require.NoError(t, err)
+
+			var got []result.Symbol
+			for _, s := range symbols {
+				if match(s) {
+					got = append(got, s)
+				}
+			}
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // SavedSearch represents a saved search
 type SavedSearch struct {
 	ID              int32 // the globally unique DB ID
@@ -11,3 +13,33 @@ type SavedSearch struct {
 	OrgID           *int32  // if non-nil, the owner is this organization. UserID/OrgID are mutually exclusive.
 	SlackWebhookURL *string // if non-nil && NotifySlack == true, indicates that this Slack webhook URL should be used instead of the owners default Slack webhook.
 }
+
+// SavedSearchSchedule configures a saved search to run periodically and
+// notify about changes in its results.
+type SavedSearchSchedule struct {
+	SavedSearchID   int32
+	UserID          int32         // the user the saved search is run as and who receives email notifications
+	Interval        time.Duration // the time between two runs, with minute precision
+	NotifyEmail     bool          // whether or not to notify UserID via email
+	SlackWebhookURL *string       // if non-nil, the Slack webhook URL to notify
+	WebhookURL      *string       // if non-nil, the URL to post a JSON payload to
+	NextRunAt       time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// SavedSearchRun is the result of a scheduled run of a saved search.
+type SavedSearchRun struct {
+	ID             int64
+	SavedSearchID  int32
+	Query          string    // the query as it was run
+	PermalinkQuery string    // the query with revisions pinned to the commits searched by this run
+	RanAt          time.Time // when the query was run
+	ResultCount    int
+	LimitHit       bool     // whether the run stopped before finding all matches
+	Fingerprint    []string // the sorted keys identifying each match, only stored for the latest run
+	Added          []string // the keys of the matches that were not part of the previous run
+	AddedCount     int
+	Removed        []string // the keys of the matches of the previous run that are no longer part of this run
+	RemovedCount   int
+}
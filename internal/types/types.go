@@ -78,10 +78,55 @@ type Repo struct {
 	Metadata any
 	// Blocked contains the reason this repository was blocked and the timestamp of when it happened.
 	Blocked *RepoBlock `json:",omitempty"`
-	// KeyValuePairs is the set of key-value pairs associated with the repo
+	// KeyValuePairs is the set of key-value pairs associated with the repo.
+	// Keys that start with one of CodeHostKeyValuePairPrefixes are managed
+	// by the repo syncer. A nil map on a sourced repo means the source did not
+	// report them, so the stored ones are left untouched.
 	KeyValuePairs map[string]*string `json:",omitempty"`
 }
 
+// Key prefixes of the repository key-value pairs that record code host
+// membership. They are stored with a NULL value, so they can be matched
+// with the repo:has.tag() search predicate.
+const (
+	GitHubTopicKeyPrefix = "github.topic:"
+	GitHubTeamKeyPrefix  = "github.team:"
+	GitLabTopicKeyPrefix = "gitlab.topic:"
+	GitLabGroupKeyPrefix = "gitlab.group:"
+)
+
+// CodeHostKeyValuePairPrefixes are the prefixes of the repository key-value
+// pairs that are owned by the repo syncer.
+var CodeHostKeyValuePairPrefixes = []string{
+	GitHubTopicKeyPrefix,
+	GitHubTeamKeyPrefix,
+	GitLabTopicKeyPrefix,
+	GitLabGroupKeyPrefix,
+}
+
+// IsCodeHostKeyValuePair returns true if the given key is managed by the
+// repo syncer.
+func IsCodeHostKeyValuePair(key string) bool {
+	for _, prefix := range CodeHostKeyValuePairPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// CodeHostKeyValuePairs returns a map of tags with the given key prefix, one
+// per value, suitable for Repo.KeyValuePairs.
+func CodeHostKeyValuePairs(prefix string, values ...string) map[string]*string {
+	kvps := make(map[string]*string, len(values))
+	for _, value := range values {
+		if value != "" {
+			kvps[prefix+value] = nil
+		}
+	}
+	return kvps
+}
+
 // SearchedRepo is a collection of metadata about repos that is used to decorate search results
 type SearchedRepo struct {
 	// ID is the unique numeric ID for this repository.
@@ -173,6 +218,7 @@ const (
 	RepoModifiedStars
 	RepoModifiedMetadata
 	RepoModifiedSources
+	RepoModifiedKeyValuePairs
 )
 
 func (m RepoModified) String() string {
@@ -211,6 +257,9 @@ func (m RepoModified) String() string {
 	if m&RepoModifiedSources == RepoModifiedSources {
 		modifications = append(modifications, "sources")
 	}
+	if m&RepoModifiedKeyValuePairs == RepoModifiedKeyValuePairs {
+		modifications = append(modifications, "key-value pairs")
+	}
 	if m&RepoUnmodified == RepoUnmodified {
 		modifications = append(modifications, "unmodified")
 	}
@@ -275,9 +324,46 @@ func (r *Repo) Update(n *Repo) (modified RepoModified) {
 		}
 	}
 
+	if n.KeyValuePairs != nil && !codeHostKeyValuePairsEqual(r.KeyValuePairs, n.KeyValuePairs) {
+		kvps := make(map[string]*string, len(r.KeyValuePairs)+len(n.KeyValuePairs))
+		for k, v := range r.KeyValuePairs {
+			if !IsCodeHostKeyValuePair(k) {
+				kvps[k] = v
+			}
+		}
+		for k, v := range n.KeyValuePairs {
+			if IsCodeHostKeyValuePair(k) {
+				kvps[k] = v
+			}
+		}
+		r.KeyValuePairs = kvps
+		modified |= RepoModifiedKeyValuePairs
+	}
+
 	return modified
 }
 
+// codeHostKeyValuePairsEqual returns true if a and b hold the same set of keys
+// managed by the repo syncer.
+func codeHostKeyValuePairsEqual(a, b map[string]*string) bool {
+	count := 0
+	for k := range a {
+		if !IsCodeHostKeyValuePair(k) {
+			continue
+		}
+		if _, ok := b[k]; !ok {
+			return false
+		}
+		count++
+	}
+	for k := range b {
+		if IsCodeHostKeyValuePair(k) {
+			count--
+		}
+	}
+	return count == 0
+}
+
 // Clone returns a clone of the given repo.
 func (r *Repo) Clone() *Repo {
 	if r == nil {
@@ -794,6 +880,22 @@ type Role struct {
 	DeletedAt time.Time
 }
 
+// SubRepoPermissionRule is a site-admin managed rule that restricts the paths
+// a set of users can access in the repositories matching RepoPattern.
+type SubRepoPermissionRule struct {
+	ID          int32
+	RepoPattern string
+	// UserID and OrgID define the subject of the rule. At most one of them is
+	// set, and when neither is, the rule applies to all users.
+	UserID int32
+	OrgID  int32
+	// Paths are the rules in the format of authz.SubRepoPermissions.Paths:
+	// paths that begin with a minus sign (-) are exclusion paths.
+	Paths     []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
 type Permission struct {
 	ID        int32
 	Namespace string
@@ -1683,6 +1785,18 @@ type SearchContext struct {
 	Starred bool
 }
 
+// SearchContextRepoChange records a repository starting or stopping to match
+// the query of a search context.
+type SearchContextRepoChange struct {
+	ID              int64
+	SearchContextID int64
+	RepoID          api.RepoID
+	// Added is true if the repository started matching the query, and false
+	// if it stopped matching it.
+	Added     bool
+	CreatedAt time.Time
+}
+
 // SearchContextRepositoryRevisions is a simple wrapper for a repository and its revisions
 // contained in a search context. It is made compatible with search.RepositoryRevisions, so it can be easily
 // converted when needed. We could use search.RepositoryRevisions directly instead, but it
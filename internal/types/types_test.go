@@ -0,0 +1,67 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRepo_UpdateKeyValuePairs(t *testing.T) {
+	value := "value"
+
+	for _, tc := range []struct {
+		name     string
+		stored   map[string]*string
+		sourced  map[string]*string
+		want     map[string]*string
+		modified RepoModified
+	}{
+		{
+			name:     "nil sourced pairs leave stored pairs untouched",
+			stored:   map[string]*string{"github.topic:go": nil},
+			sourced:  nil,
+			want:     map[string]*string{"github.topic:go": nil},
+			modified: RepoUnmodified,
+		},
+		{
+			name:     "same code host pairs",
+			stored:   map[string]*string{"github.topic:go": nil, "owner": &value},
+			sourced:  map[string]*string{"github.topic:go": nil},
+			want:     map[string]*string{"github.topic:go": nil, "owner": &value},
+			modified: RepoUnmodified,
+		},
+		{
+			name:     "code host pairs are replaced",
+			stored:   map[string]*string{"github.topic:go": nil, "github.team:org/a": nil, "owner": &value},
+			sourced:  map[string]*string{"github.topic:rust": nil, "github.team:org/a": nil},
+			want:     map[string]*string{"github.topic:rust": nil, "github.team:org/a": nil, "owner": &value},
+			modified: RepoModifiedKeyValuePairs,
+		},
+		{
+			name:     "code host pairs are removed",
+			stored:   map[string]*string{"gitlab.group:a": nil, "gitlab.group:a/b": nil, "owner": &value},
+			sourced:  map[string]*string{},
+			want:     map[string]*string{"owner": &value},
+			modified: RepoModifiedKeyValuePairs,
+		},
+		{
+			name:     "other sourced pairs are ignored",
+			stored:   nil,
+			sourced:  map[string]*string{"owner": &value},
+			want:     nil,
+			modified: RepoUnmodified,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			stored := &Repo{KeyValuePairs: tc.stored}
+			sourced := &Repo{KeyValuePairs: tc.sourced}
+
+			if modified := stored.Update(sourced); modified != tc.modified {
+				t.Errorf("unexpected modified. want=%s have=%s", tc.modified, modified)
+			}
+			if diff := cmp.Diff(tc.want, stored.KeyValuePairs); diff != "" {
+				t.Errorf("unexpected key-value pairs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package batches
+
+import "github.com/sourcegraph/sourcegraph/lib/batches/overridable"
+
+// AutoMergeMethod is the method used to merge a changeset automatically.
+type AutoMergeMethod string
+
+const (
+	AutoMergeMethodMerge  AutoMergeMethod = "merge"
+	AutoMergeMethodSquash AutoMergeMethod = "squash"
+	AutoMergeMethodRebase AutoMergeMethod = "rebase"
+)
+
+// AutoMergeReviewState is the review state a changeset needs to have reached
+// before it is merged automatically.
+type AutoMergeReviewState string
+
+const (
+	AutoMergeReviewStateApproved AutoMergeReviewState = "approved"
+	AutoMergeReviewStateAny      AutoMergeReviewState = "any"
+)
+
+// AutoMergeCheckState is the check state a changeset needs to have reached
+// before it is merged automatically.
+type AutoMergeCheckState string
+
+const (
+	AutoMergeCheckStatePassed AutoMergeCheckState = "passed"
+	AutoMergeCheckStateAny    AutoMergeCheckState = "any"
+)
+
+// AutoMerge is the autoMerge policy of a changeset template. Enabled can be
+// overridden on a per-repo basis, all other fields apply to every changeset
+// the policy is enabled for.
+type AutoMerge struct {
+	Enabled             *overridable.Bool    `json:"enabled,omitempty" yaml:"enabled"`
+	MergeMethod         AutoMergeMethod      `json:"mergeMethod,omitempty" yaml:"mergeMethod"`
+	RequiredReviewState AutoMergeReviewState `json:"requiredReviewState,omitempty" yaml:"requiredReviewState"`
+	RequiredCheckState  AutoMergeCheckState  `json:"requiredCheckState,omitempty" yaml:"requiredCheckState"`
+	MergeWindow         *MergeWindow         `json:"mergeWindow,omitempty" yaml:"mergeWindow"`
+}
+
+// MergeWindow restricts automatic merges to the given days and times. All
+// times are in UTC, like the rollout windows of the site configuration.
+type MergeWindow struct {
+	Days  []string `json:"days,omitempty" yaml:"days"`
+	Start string   `json:"start,omitempty" yaml:"start"`
+	End   string   `json:"end,omitempty" yaml:"end"`
+}
+
+// AutoMergePolicy is the autoMerge policy resolved for a single changeset.
+type AutoMergePolicy struct {
+	MergeMethod         AutoMergeMethod      `json:"mergeMethod"`
+	RequiredReviewState AutoMergeReviewState `json:"requiredReviewState"`
+	RequiredCheckState  AutoMergeCheckState  `json:"requiredCheckState"`
+	MergeWindow         *MergeWindow         `json:"mergeWindow,omitempty"`
+}
+
+// PolicyFor returns the policy that applies to changesets in the given
+// repository, or nil if automatic merges are disabled for it. Omitted fields
+// default to merge commits, approved reviews and passed checks.
+func (a *AutoMerge) PolicyFor(repoName string) *AutoMergePolicy {
+	if a == nil {
+		return nil
+	}
+	if a.Enabled != nil && !a.Enabled.Value(repoName) {
+		return nil
+	}
+
+	p := &AutoMergePolicy{
+		MergeMethod:         a.MergeMethod,
+		RequiredReviewState: a.RequiredReviewState,
+		RequiredCheckState:  a.RequiredCheckState,
+		MergeWindow:         a.MergeWindow,
+	}
+	if p.MergeMethod == "" {
+		p.MergeMethod = AutoMergeMethodMerge
+	}
+	if p.RequiredReviewState == "" {
+		p.RequiredReviewState = AutoMergeReviewStateApproved
+	}
+	if p.RequiredCheckState == "" {
+		p.RequiredCheckState = AutoMergeCheckStatePassed
+	}
+	return p
+}
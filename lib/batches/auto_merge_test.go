@@ -0,0 +1,97 @@
+package batches
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v2"
+)
+
+func TestAutoMergePolicyFor(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		repo string
+		want *AutoMergePolicy
+	}{
+		{
+			name: "defaults",
+			raw:  `{}`,
+			repo: "github.com/sourcegraph/sourcegraph",
+			want: &AutoMergePolicy{
+				MergeMethod:         AutoMergeMethodMerge,
+				RequiredReviewState: AutoMergeReviewStateApproved,
+				RequiredCheckState:  AutoMergeCheckStatePassed,
+			},
+		},
+		{
+			name: "explicit",
+			raw: `
+enabled: true
+mergeMethod: squash
+requiredReviewState: any
+requiredCheckState: any
+mergeWindow:
+  days: [mon, tue]
+  start: "09:00"
+  end: "17:00"
+`,
+			repo: "github.com/sourcegraph/sourcegraph",
+			want: &AutoMergePolicy{
+				MergeMethod:         AutoMergeMethodSquash,
+				RequiredReviewState: AutoMergeReviewStateAny,
+				RequiredCheckState:  AutoMergeCheckStateAny,
+				MergeWindow:         &MergeWindow{Days: []string{"mon", "tue"}, Start: "09:00", End: "17:00"},
+			},
+		},
+		{
+			name: "disabled",
+			raw:  `enabled: false`,
+			repo: "github.com/sourcegraph/sourcegraph",
+			want: nil,
+		},
+		{
+			name: "enabled for matching repo",
+			raw: `
+enabled:
+  - github.com/sourcegraph/*: true
+  - github.com/sourcegraph/sourcegraph: false
+mergeMethod: rebase
+`,
+			repo: "github.com/sourcegraph/src-cli",
+			want: &AutoMergePolicy{
+				MergeMethod:         AutoMergeMethodRebase,
+				RequiredReviewState: AutoMergeReviewStateApproved,
+				RequiredCheckState:  AutoMergeCheckStatePassed,
+			},
+		},
+		{
+			name: "disabled for overridden repo",
+			raw: `
+enabled:
+  - github.com/sourcegraph/*: true
+  - github.com/sourcegraph/sourcegraph: false
+`,
+			repo: "github.com/sourcegraph/sourcegraph",
+			want: nil,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var a AutoMerge
+			if err := yaml.Unmarshal([]byte(tc.raw), &a); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, a.PolicyFor(tc.repo)); diff != "" {
+				t.Fatalf("wrong policy (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		var a *AutoMerge
+		if have := a.PolicyFor("github.com/sourcegraph/sourcegraph"); have != nil {
+			t.Fatalf("expected no policy, got %+v", have)
+		}
+	})
+}
@@ -45,6 +45,16 @@ type ChangesetTemplate struct {
 	Branch    string                       `json:"branch,omitempty" yaml:"branch"`
 	Commit    ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
 	Published *overridable.BoolOrString    `json:"published" yaml:"published"`
+	AutoMerge *AutoMerge                   `json:"autoMerge,omitempty" yaml:"autoMerge,omitempty"`
+
+	Reviewers     []string `json:"reviewers,omitempty" yaml:"reviewers,omitempty"`
+	TeamReviewers []string `json:"teamReviewers,omitempty" yaml:"teamReviewers,omitempty"`
+	Labels        []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Assignees     []string `json:"assignees,omitempty" yaml:"assignees,omitempty"`
+	Milestone     string   `json:"milestone,omitempty" yaml:"milestone,omitempty"`
+
+	DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+	Provides  []string `json:"provides,omitempty" yaml:"provides,omitempty"`
 }
 
 type GitCommitAuthor struct {
@@ -95,6 +105,8 @@ type Step struct {
 	Outputs   Outputs           `json:"outputs,omitempty" yaml:"outputs,omitempty"`
 	Mount     []Mount           `json:"mount,omitempty" yaml:"mount,omitempty"`
 	If        any               `json:"if,omitempty" yaml:"if,omitempty"`
+	Uses      NativeStep        `json:"uses,omitempty" yaml:"uses,omitempty"`
+	With      map[string]string `json:"with,omitempty" yaml:"with,omitempty"`
 }
 
 func (s *Step) IfCondition() string {
@@ -166,6 +178,11 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 	}
 
 	for i, step := range spec.Steps {
+		if step.Native() {
+			if err := step.validateNative(); err != nil {
+				errs = errors.Append(errs, NewValidationError(errors.Wrapf(err, "step %d", i+1)))
+			}
+		}
 		for _, mount := range step.Mount {
 			if strings.Contains(mount.Path, invalidMountCharacters) {
 				errs = errors.Append(errs, NewValidationError(errors.Newf("step %d mount path contains invalid characters", i+1)))
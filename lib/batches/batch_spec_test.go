@@ -35,6 +35,95 @@ changesetTemplate:
 		}
 	})
 
+	t.Run("valid with autoMerge", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: true
+  autoMerge:
+    enabled:
+      - github.com/sourcegraph/*: true
+    mergeMethod: squash
+    requiredCheckState: passed
+    mergeWindow:
+      days: [saturday]
+      start: "10:00"
+      end: "12:00"
+`
+
+		parsed, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+		if have, want := parsed.ChangesetTemplate.AutoMerge.MergeMethod, AutoMergeMethodSquash; have != want {
+			t.Fatalf("wrong merge method. want=%q, have=%q", want, have)
+		}
+	})
+
+	t.Run("valid with reviewers, labels, assignees and milestone", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: true
+  reviewers:
+    - ${{ outputs.owners }}
+  teamReviewers: [sourcegraph/batch-changes]
+  labels: [batch-change]
+  assignees: [alice]
+  milestone: "4.3"
+`
+
+		parsed, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+		if have, want := parsed.ChangesetTemplate.Milestone, "4.3"; have != want {
+			t.Fatalf("wrong milestone. want=%q, have=%q", want, have)
+		}
+		if have, want := parsed.ChangesetTemplate.Reviewers, []string{"${{ outputs.owners }}"}; !cmp.Equal(want, have) {
+			t.Fatalf("wrong reviewers. want=%q, have=%q", want, have)
+		}
+	})
+
+	t.Run("invalid autoMerge merge method", func(t *testing.T) {
+		const spec = `
+name: hello-world
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  autoMerge:
+    mergeMethod: fast-forward
+`
+
+		if _, err := ParseBatchSpec([]byte(spec)); err == nil {
+			t.Fatal("no error returned")
+		}
+	})
+
 	t.Run("missing changesetTemplate", func(t *testing.T) {
 		const spec = `
 name: hello-world
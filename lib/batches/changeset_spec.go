@@ -80,6 +80,27 @@ type ChangesetSpec struct {
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
 	Published PublishedValue `json:"published,omitempty"`
+
+	// AutoMerge is the policy that decides when the changeset is merged
+	// automatically. It is nil if the changeset is never merged automatically.
+	AutoMerge *AutoMergePolicy `json:"autoMerge,omitempty"`
+
+	// Reviewers and TeamReviewers are requested to review the changeset,
+	// Labels and Assignees are added to it and it is put into Milestone. Code
+	// hosts that don't support one of them ignore it.
+	Reviewers     []string `json:"reviewers,omitempty"`
+	TeamReviewers []string `json:"teamReviewers,omitempty"`
+	Labels        []string `json:"labels,omitempty"`
+	Assignees     []string `json:"assignees,omitempty"`
+	Milestone     string   `json:"milestone,omitempty"`
+
+	// DependsOn selects the changesets in the same batch change that have to
+	// be merged before this changeset is published: the ones in a repository
+	// of that name, and the ones that provide that name.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// Provides are the names that other changesets can depend on this
+	// changeset by, in addition to the name of its repository.
+	Provides []string `json:"provides,omitempty"`
 }
 
 // MarshalJSON overwrites the default behavior of the json lib while unmarshalling
@@ -102,6 +123,14 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Body           string                 `json:"body,omitempty"`
 		Commits        []GitCommitDescription `json:"commits,omitempty"`
 		Published      *PublishedValue        `json:"published,omitempty"`
+		AutoMerge      *AutoMergePolicy       `json:"autoMerge,omitempty"`
+		Reviewers      []string               `json:"reviewers,omitempty"`
+		TeamReviewers  []string               `json:"teamReviewers,omitempty"`
+		Labels         []string               `json:"labels,omitempty"`
+		Assignees      []string               `json:"assignees,omitempty"`
+		Milestone      string                 `json:"milestone,omitempty"`
+		DependsOn      []string               `json:"dependsOn,omitempty"`
+		Provides       []string               `json:"provides,omitempty"`
 	}{
 		BaseRepository: c.BaseRepository,
 		ExternalID:     c.ExternalID,
@@ -112,6 +141,14 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Title:          c.Title,
 		Body:           c.Body,
 		Commits:        c.Commits,
+		AutoMerge:      c.AutoMerge,
+		Reviewers:      c.Reviewers,
+		TeamReviewers:  c.TeamReviewers,
+		Labels:         c.Labels,
+		Assignees:      c.Assignees,
+		Milestone:      c.Milestone,
+		DependsOn:      c.DependsOn,
+		Provides:       c.Provides,
 	}
 	if !c.Published.Nil() {
 		v.Published = &c.Published
@@ -86,6 +86,48 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool) ([]*Change
 		return nil, err
 	}
 
+	reviewers, err := template.RenderChangesetTemplateList("reviewers", input.Template.Reviewers, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+	trimMentions(reviewers)
+
+	teamReviewers, err := template.RenderChangesetTemplateList("teamReviewers", input.Template.TeamReviewers, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+	trimMentions(teamReviewers)
+
+	labels, err := template.RenderChangesetTemplateList("labels", input.Template.Labels, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignees, err := template.RenderChangesetTemplateList("assignees", input.Template.Assignees, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+	trimMentions(assignees)
+
+	milestone, err := template.RenderChangesetTemplateField("milestone", input.Template.Milestone, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	dependsOn, err := template.RenderChangesetTemplateList("dependsOn", input.Template.DependsOn, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	provides, err := template.RenderChangesetTemplateList("provides", input.Template.Provides, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Changesets in the same repository never depend on each other, so that a
+	// template can list all prerequisites for every changeset.
+	dependsOn = removeStrings(dependsOn, append([]string{input.Repository.Name}, provides...))
+
 	// TODO: As a next step, we should extend the ChangesetTemplateContext to also include
 	// TransformChanges.Group and then change validateGroups and groupFileDiffs to, for each group,
 	// render the branch name *before* grouping the diffs.
@@ -124,6 +166,16 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool) ([]*Change
 				},
 			},
 			Published: PublishedValue{Val: published},
+			AutoMerge: input.Template.AutoMerge.PolicyFor(input.Repository.Name),
+
+			Reviewers:     reviewers,
+			TeamReviewers: teamReviewers,
+			Labels:        labels,
+			Assignees:     assignees,
+			Milestone:     milestone,
+
+			DependsOn: dependsOn,
+			Provides:  provides,
 		}
 	}
 
@@ -154,6 +206,32 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool) ([]*Change
 	return specs, nil
 }
 
+// trimMentions strips the leading "@" from user and team names, so that they
+// can be copied verbatim from CODEOWNERS files or code host mentions.
+func trimMentions(names []string) {
+	for i, name := range names {
+		names[i] = strings.TrimPrefix(name, "@")
+	}
+}
+
+// removeStrings returns values without any occurrence of the strings in remove.
+func removeStrings(values, remove []string) []string {
+	var out []string
+	for _, value := range values {
+		keep := true
+		for _, r := range remove {
+			if value == r {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
 type RepoFetcher func(context.Context, []string) (map[string]string, error)
 
 func BuildImportChangesetSpecs(ctx context.Context, importChangesets []ImportChangeset, repoFetcher RepoFetcher) (specs []*ChangesetSpec, errs error) {
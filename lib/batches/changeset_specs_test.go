@@ -145,6 +145,87 @@ func TestCreateChangesetSpecs(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "auto-merge",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Template.AutoMerge = &AutoMerge{MergeMethod: AutoMergeMethodRebase}
+			}),
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.AutoMerge = &AutoMergePolicy{
+						MergeMethod:         AutoMergeMethodRebase,
+						RequiredReviewState: AutoMergeReviewStateApproved,
+						RequiredCheckState:  AutoMergeCheckStatePassed,
+					}
+				}),
+			},
+			wantErr: "",
+		},
+		{
+			name: "reviewers, labels, assignees and milestone",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Template.Reviewers = []string{"@alice", "${{ outputs.owners }}"}
+				input.Template.TeamReviewers = []string{"@sourcegraph/batch-changes"}
+				input.Template.Labels = []string{"batch change", "${{ repository.branch }}"}
+				input.Template.Assignees = []string{"carol"}
+				input.Template.Milestone = "release-${{ outputs.release }}"
+				input.Result.Outputs = map[string]any{
+					"owners":  "@bob\n@alice",
+					"release": "4.3",
+				}
+			}),
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.Reviewers = []string{"alice", "bob"}
+					s.TeamReviewers = []string{"sourcegraph/batch-changes"}
+					s.Labels = []string{"batch change", "my-cool-base-ref"}
+					s.Assignees = []string{"carol"}
+					s.Milestone = "release-4.3"
+				}),
+			},
+			wantErr: "",
+		},
+		{
+			name: "provides",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Template.Provides = []string{"${{ outputs.role }}"}
+				input.Template.DependsOn = []string{"library", "api"}
+				input.Result.Outputs = map[string]any{
+					// A changeset never depends on itself.
+					"role": "library",
+				}
+			}),
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.DependsOn = []string{"api"}
+					s.Provides = []string{"library"}
+				}),
+			},
+			wantErr: "",
+		},
+		{
+			name: "dependsOn",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Template.DependsOn = []string{
+					"github.com/sourcegraph/lib",
+					"${{ outputs.prerequisites }}",
+				}
+				input.Result.Outputs = map[string]any{
+					// The changeset's own repository is ignored.
+					"prerequisites": "github.com/sourcegraph/src-cli\ngithub.com/sourcegraph/api, github.com/sourcegraph/lib",
+				}
+			}),
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.DependsOn = []string{"github.com/sourcegraph/lib", "github.com/sourcegraph/api"}
+				}),
+			},
+			wantErr: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,124 @@
+package batches
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches/env"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NativeStep is a built-in step kind that is run in-process on the workspace
+// instead of in a Docker container.
+type NativeStep string
+
+const (
+	// NativeStepReplace replaces all matches of a regular expression.
+	NativeStepReplace NativeStep = "replace"
+	// NativeStepComby rewrites code with a Comby match and rewrite template.
+	NativeStepComby NativeStep = "comby"
+	// NativeStepWriteFile writes a file to the workspace.
+	NativeStepWriteFile NativeStep = "write-file"
+)
+
+// nativeStepParams lists the `with` parameters each native step accepts,
+// mapped to whether they are required.
+var nativeStepParams = map[NativeStep]map[string]bool{
+	NativeStepReplace: {
+		"pattern":     true,
+		"replacement": true,
+		"files":       false,
+	},
+	NativeStepComby: {
+		"match":   true,
+		"rewrite": true,
+		"rule":    false,
+		"matcher": false,
+		"files":   false,
+	},
+	NativeStepWriteFile: {
+		"path":    true,
+		"content": true,
+	},
+}
+
+// Native returns true if the step is a built-in step that doesn't run in a
+// container.
+func (s *Step) Native() bool {
+	return s.Uses != ""
+}
+
+// validateNative checks that a native step has exactly the parameters its
+// kind requires and doesn't set any container-only fields.
+func (s *Step) validateNative() error {
+	params, ok := nativeStepParams[s.Uses]
+	if !ok {
+		return errors.Newf("unknown built-in step %q", s.Uses)
+	}
+
+	var errs error
+	if s.Run != "" || s.Container != "" {
+		errs = errors.Append(errs, errors.Newf("built-in step %q cannot be combined with run or container", s.Uses))
+	}
+	if len(s.Files) > 0 || len(s.Mount) > 0 || !s.Env.Equal(env.Environment{}) {
+		errs = errors.Append(errs, errors.Newf("built-in step %q does not run in a container and cannot set env, files or mount", s.Uses))
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := s.With[name]; params[name] && !ok {
+			errs = errors.Append(errs, errors.Newf("built-in step %q requires parameter %q", s.Uses, name))
+		}
+	}
+
+	unknown := make([]string, 0)
+	for name := range s.With {
+		if _, ok := params[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = errors.Append(errs, errors.Newf("built-in step %q has unknown parameter %q", s.Uses, name))
+	}
+
+	// Templated paths can only be checked once they are rendered during
+	// execution.
+	if p, ok := s.With["path"]; ok && s.Uses == NativeStepWriteFile && !strings.Contains(p, "${{") {
+		if _, err := WorkspaceFilePath("", p); err != nil {
+			errs = errors.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// WorkspaceFilePath joins p to the workspace directory dir and returns an
+// error if the result would lie outside of it.
+func WorkspaceFilePath(dir, p string) (string, error) {
+	if p == "" || filepath.IsAbs(p) {
+		return "", errors.Newf("path %q must be a relative path inside the workspace", p)
+	}
+	clean := filepath.Clean(p)
+	if clean == "." || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", errors.Newf("path %q must be a relative path inside the workspace", p)
+	}
+	return filepath.Join(dir, clean), nil
+}
+
+// NativeStepFilePatterns splits the comma-separated `files` parameter of a
+// native step into a list of file suffixes.
+func NativeStepFilePatterns(files string) []string {
+	var patterns []string
+	for _, p := range strings.Split(files, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
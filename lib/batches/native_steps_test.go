@@ -0,0 +1,184 @@
+package batches
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseBatchSpec_NativeSteps(t *testing.T) {
+	const header = `
+name: native
+description: Uses built-in steps
+on:
+  - repository: github.com/foo/bar
+changesetTemplate:
+  title: Native
+  body: Native steps
+  branch: native
+  commit:
+    message: Native
+steps:
+`
+
+	t.Run("valid", func(t *testing.T) {
+		spec, err := ParseBatchSpec([]byte(header + `
+  - uses: replace
+    with:
+      pattern: oldFunc\(
+      replacement: newFunc(
+      files: .go
+  - uses: comby
+    with:
+      match: fmt.Sprintf(":[x]")
+      rewrite: ":[x]"
+  - uses: write-file
+    with:
+      path: ${{ repository.name }}.txt
+      content: hello
+    if: ${{ eq repository.name "github.com/foo/bar" }}
+  - run: echo hello
+    container: alpine:3
+`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var uses []NativeStep
+		for _, step := range spec.Steps {
+			uses = append(uses, step.Uses)
+		}
+		if diff := cmp.Diff([]NativeStep{NativeStepReplace, NativeStepComby, NativeStepWriteFile, ""}, uses); diff != "" {
+			t.Fatal(diff)
+		}
+		if !spec.Steps[0].Native() || spec.Steps[3].Native() {
+			t.Fatal("wrong Native() result")
+		}
+		if have, want := spec.Steps[0].With["pattern"], `oldFunc\(`; have != want {
+			t.Fatalf("wrong pattern: have=%q want=%q", have, want)
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		steps   string
+		wantErr string
+	}{
+		"unknown kind": {
+			steps: `
+  - uses: sed
+    with:
+      script: s/a/b/
+`,
+			wantErr: "steps.0.uses: steps.0.uses must be one of the following",
+		},
+		"combined with run": {
+			steps: `
+  - uses: write-file
+    run: echo hello
+    container: alpine:3
+    with:
+      path: README.md
+      content: hello
+`,
+			wantErr: "Must validate one and only one schema (oneOf)",
+		},
+		"combined with container": {
+			steps: `
+  - uses: write-file
+    container: alpine:3
+    with:
+      path: README.md
+      content: hello
+`,
+			wantErr: `step 1: built-in step "write-file" cannot be combined with run or container`,
+		},
+		"sets files": {
+			steps: `
+  - uses: write-file
+    files:
+      /tmp/foo: bar
+    with:
+      path: README.md
+      content: hello
+`,
+			wantErr: `step 1: built-in step "write-file" does not run in a container and cannot set env, files or mount`,
+		},
+		"missing parameter": {
+			steps: `
+  - uses: comby
+    with:
+      match: foo
+`,
+			wantErr: `step 1: built-in step "comby" requires parameter "rewrite"`,
+		},
+		"unknown parameter": {
+			steps: `
+  - uses: replace
+    with:
+      pattern: foo
+      replacement: bar
+      flags: i
+`,
+			wantErr: `step 1: built-in step "replace" has unknown parameter "flags"`,
+		},
+		"path outside of workspace": {
+			steps: `
+  - uses: write-file
+    with:
+      path: ../../etc/passwd
+      content: hello
+`,
+			wantErr: `step 1: path "../../etc/passwd" must be a relative path inside the workspace`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseBatchSpec([]byte(header + tc.steps))
+			if err == nil {
+				t.Fatal("no error returned")
+			}
+			if have := err.Error(); !strings.Contains(have, tc.wantErr) {
+				t.Fatalf("wrong error. want=%q, have=%q", tc.wantErr, have)
+			}
+		})
+	}
+}
+
+func TestWorkspaceFilePath(t *testing.T) {
+	for p, want := range map[string]string{
+		"README.md":          "repository/README.md",
+		"./docs/../foo.txt":  "repository/foo.txt",
+		"a/b/c.go":           "repository/a/b/c.go",
+		"..foo":              "repository/..foo",
+		"":                   "",
+		".":                  "",
+		"..":                 "",
+		"../foo":             "",
+		"a/../../foo":        "",
+		"/etc/passwd":        "",
+		"docs/../../../root": "",
+	} {
+		have, err := WorkspaceFilePath("repository", p)
+		if want == "" {
+			if err == nil {
+				t.Errorf("%q: expected error, got %q", p, have)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", p, err)
+		} else if have != want {
+			t.Errorf("%q: have=%q want=%q", p, have, want)
+		}
+	}
+}
+
+func TestNativeStepFilePatterns(t *testing.T) {
+	have := NativeStepFilePatterns(" .go, .ts,,README.md ")
+	if diff := cmp.Diff([]string{".go", ".ts", "README.md"}, have); diff != "" {
+		t.Fatal(diff)
+	}
+	if have := NativeStepFilePatterns(""); len(have) != 0 {
+		t.Fatalf("expected no patterns, got %v", have)
+	}
+}
@@ -123,7 +123,7 @@ const BatchSpecJSON = `{
         "type": "object",
         "description": "A command to run (as part of a sequence) in a repository branch to produce the required changes.",
         "additionalProperties": false,
-        "required": ["run", "container"],
+        "oneOf": [{ "required": ["run", "container"] }, { "required": ["uses", "with"] }],
         "properties": {
           "run": {
             "type": "string",
@@ -134,6 +134,19 @@ const BatchSpecJSON = `{
             "description": "The Docker image used to launch the Docker container in which the shell command is run.",
             "examples": ["alpine:3"]
           },
+          "uses": {
+            "type": "string",
+            "description": "A built-in step that is run natively on the workspace instead of in a Docker container. ` + "`" + `replace` + "`" + ` replaces matches of a regular expression, ` + "`" + `comby` + "`" + ` rewrites code with a Comby match and rewrite template and ` + "`" + `write-file` + "`" + ` writes a file. Cannot be combined with ` + "`" + `run` + "`" + ` and ` + "`" + `container` + "`" + `.",
+            "enum": ["replace", "comby", "write-file"]
+          },
+          "with": {
+            "type": ["object", "null"],
+            "description": "The parameters of the built-in step set in ` + "`" + `uses` + "`" + `. Values support templating. ` + "`" + `replace` + "`" + ` takes ` + "`" + `pattern` + "`" + `, ` + "`" + `replacement` + "`" + ` and optionally ` + "`" + `files` + "`" + `; ` + "`" + `comby` + "`" + ` takes ` + "`" + `match` + "`" + `, ` + "`" + `rewrite` + "`" + ` and optionally ` + "`" + `rule` + "`" + `, ` + "`" + `matcher` + "`" + ` and ` + "`" + `files` + "`" + `; ` + "`" + `write-file` + "`" + ` takes ` + "`" + `path` + "`" + ` and ` + "`" + `content` + "`" + `. ` + "`" + `files` + "`" + ` is a comma-separated list of file suffixes to restrict the step to.",
+            "additionalProperties": {
+              "type": "string"
+            },
+            "examples": [{ "pattern": "oldFunc\\(", "replacement": "newFunc(", "files": ".go" }]
+          },
           "outputs": {
             "type": ["object", "null"],
             "description": "Output variables of this step that can be referenced in the changesetTemplate or other steps via outputs.<name-of-output>",
@@ -395,6 +408,117 @@ const BatchSpecJSON = `{
               }
             }
           ]
+        },
+        "autoMerge": {
+          "title": "AutoMerge",
+          "type": "object",
+          "description": "A policy to merge published changesets automatically once their reviews and checks are in the required state.",
+          "additionalProperties": false,
+          "properties": {
+            "enabled": {
+              "description": "Whether the policy applies to the changesets of a repository. If omitted, the policy applies to all changesets.",
+              "oneOf": [
+                {
+                  "type": "boolean",
+                  "description": "A single flag to enable or disable automatic merges for the entire batch change."
+                },
+                {
+                  "type": "array",
+                  "description": "A list of glob patterns to match repository names. In the event multiple patterns match, the last matching pattern in the list will be used.",
+                  "items": {
+                    "type": "object",
+                    "description": "An object with one field: the key is the glob pattern to match against repository names; the value will be used as the enabled flag for matching repositories.",
+                    "additionalProperties": {
+                      "type": "boolean"
+                    },
+                    "minProperties": 1,
+                    "maxProperties": 1
+                  }
+                }
+              ]
+            },
+            "mergeMethod": {
+              "type": "string",
+              "description": "The method used to merge the changesets. Defaults to merge.",
+              "enum": ["merge", "squash", "rebase"]
+            },
+            "requiredReviewState": {
+              "type": "string",
+              "description": "The review state changesets need to be in to be merged. Defaults to approved.",
+              "enum": ["approved", "any"]
+            },
+            "requiredCheckState": {
+              "type": "string",
+              "description": "The state the checks of changesets need to be in to be merged. Defaults to passed.",
+              "enum": ["passed", "any"]
+            },
+            "mergeWindow": {
+              "title": "MergeWindow",
+              "type": "object",
+              "description": "Restricts automatic merges to the given days and times. All times are in UTC. If omitted, changesets are merged as soon as they satisfy the policy.",
+              "additionalProperties": false,
+              "properties": {
+                "days": {
+                  "description": "Day(s) the window applies to. If omitted, the window applies to all days of the week.",
+                  "type": "array",
+                  "items": {
+                    "type": "string",
+                    "pattern": "^([mM]on(day)?|[tT]ue(s|sday)?|[wW]ed(nesday)?|[tT]hu(r|rs|rsday)?|[fF]ri(day)?|[sS]at(urday)?|[sS]un(day)?)$"
+                  }
+                },
+                "start": {
+                  "description": "Window start time. If omitted, no time window is applied to the day(s) that match this rule.",
+                  "type": "string",
+                  "pattern": "^[0-9]?[0-9]:[0-9]{2}$"
+                },
+                "end": {
+                  "description": "Window end time. If omitted, no time window is applied to the day(s) that match this rule.",
+                  "type": "string",
+                  "pattern": "^[0-9]?[0-9]:[0-9]{2}$"
+                }
+              },
+              "dependencies": {
+                "start": ["end"],
+                "end": ["start"]
+              }
+            }
+          }
+        },
+        "reviewers": {
+          "type": "array",
+          "description": "The users to request a review of the changeset from. Each entry is a template and can expand into multiple comma- or newline-separated users.",
+          "items": { "type": "string" }
+        },
+        "teamReviewers": {
+          "type": "array",
+          "description": "The teams to request a review of the changeset from, either as team or as organization/team. Each entry is a template and can expand into multiple comma- or newline-separated teams. Only supported on GitHub.",
+          "items": { "type": "string" }
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to add to the changeset. Each entry is a template and can expand into multiple comma- or newline-separated labels. Not supported on Bitbucket.",
+          "items": { "type": "string" }
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The users to assign the changeset to. Each entry is a template and can expand into multiple comma- or newline-separated users. Not supported on Bitbucket.",
+          "items": { "type": "string" }
+        },
+        "milestone": {
+          "type": "string",
+          "description": "The title of the milestone to put the changeset into. Not supported on Bitbucket."
+        },
+        "dependsOn": {
+          "type": "array",
+          "description": "The changesets in this batch change that have to be merged before the changeset is published, selected by the name of their repository or by a name they provide. Until then, the changeset is published as a draft if the code host supports it, and left unpublished otherwise. Each entry is a template and can expand into multiple comma- or newline-separated names, e.g. from a step output. Changesets in the changeset's own repository are ignored.",
+          "items": { "type": "string" },
+          "examples": [["github.com/sourcegraph/lib"], ["${{ outputs.prerequisites }}"], ["library"]]
+        },
+        "provides": {
+          "type": "array",
+          "description": "Names that other changesets in this batch change can list in dependsOn to depend on the changeset, in addition to the name of its repository. Each entry is a template and can expand into multiple comma- or newline-separated names, so that changesets can be selected by a step output.",
+          "items": { "type": "string" },
+          "examples": [["library"], ["${{ outputs.role }}"]]
         }
       }
     }
@@ -107,6 +107,75 @@ const ChangesetSpecJSON = `{
         "published": {
           "oneOf": [{ "type": "boolean" }, { "type": "string", "pattern": "^draft$" }, { "type": "null" }],
           "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host."
+        },
+        "autoMerge": {
+          "title": "AutoMergePolicy",
+          "type": "object",
+          "description": "The policy to merge the changeset automatically once its reviews and checks are in the required state. If omitted, the changeset is never merged automatically.",
+          "additionalProperties": false,
+          "required": ["mergeMethod", "requiredReviewState", "requiredCheckState"],
+          "properties": {
+            "mergeMethod": {
+              "type": "string",
+              "description": "The method used to merge the changeset.",
+              "enum": ["merge", "squash", "rebase"]
+            },
+            "requiredReviewState": {
+              "type": "string",
+              "description": "The review state the changeset needs to be in to be merged.",
+              "enum": ["approved", "any"]
+            },
+            "requiredCheckState": {
+              "type": "string",
+              "description": "The state the checks of the changeset need to be in to be merged.",
+              "enum": ["passed", "any"]
+            },
+            "mergeWindow": {
+              "title": "ChangesetMergeWindow",
+              "type": "object",
+              "description": "Restricts automatic merges to the given days and times. All times are in UTC.",
+              "additionalProperties": false,
+              "properties": {
+                "days": {
+                  "type": "array",
+                  "items": { "type": "string" }
+                },
+                "start": { "type": "string", "pattern": "^[0-9]?[0-9]:[0-9]{2}$" },
+                "end": { "type": "string", "pattern": "^[0-9]?[0-9]:[0-9]{2}$" }
+              }
+            }
+          }
+        },
+        "reviewers": {
+          "type": "array",
+          "description": "The users to request a review of the changeset from.",
+          "items": { "type": "string" }
+        },
+        "teamReviewers": {
+          "type": "array",
+          "description": "The teams to request a review of the changeset from.",
+          "items": { "type": "string" }
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to add to the changeset.",
+          "items": { "type": "string" }
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The users to assign the changeset to.",
+          "items": { "type": "string" }
+        },
+        "milestone": { "type": "string", "description": "The title of the milestone to put the changeset into." },
+        "dependsOn": {
+          "type": "array",
+          "description": "The changesets in the same batch change that have to be merged before the changeset is published, selected by the name of their repository or by a name they provide.",
+          "items": { "type": "string" }
+        },
+        "provides": {
+          "type": "array",
+          "description": "Names that other changesets in the same batch change can depend on the changeset by, in addition to the name of its repository.",
+          "items": { "type": "string" }
         }
       },
       "required": ["baseRepository", "baseRef", "baseRev", "headRepository", "headRef", "title", "body", "commits"],
@@ -332,3 +332,33 @@ func RenderChangesetTemplateField(name, tmpl string, tmplCtx *ChangesetTemplateC
 
 	return strings.TrimSpace(out.String()), nil
 }
+
+// RenderChangesetTemplateList renders each of the given templates with
+// RenderChangesetTemplateField and splits the results on commas and newlines,
+// so that a single template can expand into multiple values, e.g. the owners
+// produced by a step. Empty and duplicate values are dropped.
+func RenderChangesetTemplateList(name string, tmpls []string, tmplCtx *ChangesetTemplateContext) ([]string, error) {
+	var values []string
+	seen := make(map[string]struct{})
+
+	for i, tmpl := range tmpls {
+		out, err := RenderChangesetTemplateField(fmt.Sprintf("%s[%d]", name, i), tmpl, tmplCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range strings.FieldsFunc(out, func(r rune) bool { return r == ',' || r == '\n' }) {
+			v = strings.TrimSpace(v)
+			if v == "" {
+				continue
+			}
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			values = append(values, v)
+		}
+	}
+
+	return values, nil
+}
@@ -495,3 +495,69 @@ ${{ batch_change_link }}`,
 		})
 	}
 }
+
+func TestRenderChangesetTemplateList(t *testing.T) {
+	tmplCtx := &ChangesetTemplateContext{
+		Outputs: map[string]any{
+			"owners": "alice\nbob\n",
+			"labels": "needs review, dependencies",
+		},
+		Repository: *testRepo1,
+	}
+
+	tests := []struct {
+		name  string
+		tmpls []string
+		want  []string
+	}{
+		{
+			name:  "no templates",
+			tmpls: nil,
+			want:  nil,
+		},
+		{
+			name:  "static values",
+			tmpls: []string{"alice", "bob"},
+			want:  []string{"alice", "bob"},
+		},
+		{
+			name:  "split on newlines",
+			tmpls: []string{"${{ outputs.owners }}", "carol"},
+			want:  []string{"alice", "bob", "carol"},
+		},
+		{
+			name:  "split on commas",
+			tmpls: []string{"${{ outputs.labels }}"},
+			want:  []string{"needs review", "dependencies"},
+		},
+		{
+			name:  "drops empty and duplicate values",
+			tmpls: []string{"alice", "", "${{ outputs.owners }}", " , "},
+			want:  []string{"alice", "bob"},
+		},
+		{
+			name:  "repository",
+			tmpls: []string{"owner-of-${{ repository.name }}"},
+			want:  []string{"owner-of-github.com/sourcegraph/src-cli"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := RenderChangesetTemplateList("testing", tc.tmpls, tmplCtx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.want, out); diff != "" {
+				t.Fatalf("wrong output:\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("missing output", func(t *testing.T) {
+		if _, err := RenderChangesetTemplateList("testing", []string{"${{ outputs.missing.value }}"}, tmplCtx); err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+}
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS dashboard_report_subscriptions (
+    id serial PRIMARY KEY,
+    dashboard_id integer NOT NULL REFERENCES dashboard(id) ON DELETE CASCADE,
+    cadence text NOT NULL,
+    recipient_user_ids integer[] NOT NULL DEFAULT '{}'::integer[],
+    slack_webhook_url text,
+    created_by_user_id integer NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_sent_at timestamp with time zone,
+    next_send_at timestamp with time zone NOT NULL,
+    CONSTRAINT dashboard_report_subscriptions_cadence_valid CHECK (cadence IN ('DAILY', 'WEEKLY', 'MONTHLY'))
+);
+
+CREATE INDEX IF NOT EXISTS dashboard_report_subscriptions_dashboard_id ON dashboard_report_subscriptions (dashboard_id);
+CREATE INDEX IF NOT EXISTS dashboard_report_subscriptions_next_send_at ON dashboard_report_subscriptions (next_send_at);
+
+COMMENT ON TABLE dashboard_report_subscriptions IS 'Subscriptions to periodic digests of a dashboard, delivered by email and/or Slack.';
+COMMENT ON COLUMN dashboard_report_subscriptions.recipient_user_ids IS 'The users receiving the digest by email. Each recipient only receives the digest while they can view the dashboard.';
+COMMENT ON COLUMN dashboard_report_subscriptions.slack_webhook_url IS 'An incoming Slack webhook the digest is posted to, if any. The digest posted to Slack is rendered with the permissions of the creator of the subscription.';
+COMMENT ON COLUMN dashboard_report_subscriptions.created_by_user_id IS 'The user that created the subscription. This references the user table in the main application database.';
+COMMENT ON COLUMN dashboard_report_subscriptions.next_send_at IS 'The time at which the next digest is due.';
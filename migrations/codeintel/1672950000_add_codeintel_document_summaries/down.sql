@@ -0,0 +1 @@
+DROP TABLE IF EXISTS codeintel_document_summaries;
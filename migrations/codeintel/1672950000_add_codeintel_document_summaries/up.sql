@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS codeintel_document_summaries (
+    upload_id integer NOT NULL,
+    document_path text NOT NULL,
+    definition_count integer NOT NULL,
+    occurrence_count integer NOT NULL,
+    PRIMARY KEY (upload_id, document_path)
+);
+
+COMMENT ON TABLE codeintel_document_summaries IS 'The number of definitions and occurrences of each document of a processed upload, recorded when the upload is processed so that uploads can be compared without reading their documents.';
+COMMENT ON COLUMN codeintel_document_summaries.upload_id IS 'The identifier of the upload that provided this document.';
+COMMENT ON COLUMN codeintel_document_summaries.document_path IS 'The file path to the document relative to the root of the index.';
+COMMENT ON COLUMN codeintel_document_summaries.definition_count IS 'The number of non-local symbols (or exported monikers, for LSIF uploads) defined in the document.';
+COMMENT ON COLUMN codeintel_document_summaries.occurrence_count IS 'The number of symbol occurrences (or ranges, for LSIF uploads) in the document.';
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS trigger_sub_repo_permission_rule_repos_rename ON repo;
+DROP TRIGGER IF EXISTS trigger_sub_repo_permission_rule_repos_insert ON repo;
+DROP FUNCTION IF EXISTS func_sub_repo_permission_rule_repos_match();
+
+DROP TABLE IF EXISTS sub_repo_permission_rule_repos;
+DROP TABLE IF EXISTS sub_repo_permission_rules;
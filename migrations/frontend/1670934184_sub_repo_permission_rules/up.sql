@@ -0,0 +1,62 @@
+CREATE TABLE IF NOT EXISTS sub_repo_permission_rules (
+    id SERIAL PRIMARY KEY,
+    repo_pattern TEXT NOT NULL,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    org_id INTEGER REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+    paths TEXT[] NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+
+    CONSTRAINT repo_pattern_not_blank CHECK ((repo_pattern <> ''::text)),
+    -- Matching any string compiles the pattern, so that patterns Postgres
+    -- cannot evaluate are rejected when they are written rather than read.
+    CONSTRAINT repo_pattern_valid CHECK ((''::text ~ repo_pattern) IS NOT NULL),
+    CONSTRAINT single_subject CHECK ((user_id IS NULL OR org_id IS NULL))
+);
+
+COMMENT ON TABLE sub_repo_permission_rules IS 'Site-admin managed path rules that define sub-repo permissions for repositories not managed by a code host with native sub-repo permissions.';
+COMMENT ON COLUMN sub_repo_permission_rules.repo_pattern IS 'Regular expression matched against the names of the repositories the rule applies to.';
+COMMENT ON COLUMN sub_repo_permission_rules.user_id IS 'The user the rule applies to. When both user_id and org_id are NULL, the rule applies to all users.';
+COMMENT ON COLUMN sub_repo_permission_rules.org_id IS 'The organization whose members the rule applies to.';
+COMMENT ON COLUMN sub_repo_permission_rules.paths IS 'Paths that begin with a minus sign (-) are exclusion paths.';
+
+CREATE TABLE IF NOT EXISTS sub_repo_permission_rule_repos (
+    rule_id INTEGER NOT NULL REFERENCES sub_repo_permission_rules(id) ON DELETE CASCADE,
+    repo_id INTEGER NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    PRIMARY KEY (rule_id, repo_id)
+);
+
+CREATE INDEX IF NOT EXISTS sub_repo_permission_rule_repos_repo_id ON sub_repo_permission_rule_repos USING btree (repo_id);
+
+COMMENT ON TABLE sub_repo_permission_rule_repos IS 'The repositories whose names match the repo_pattern of a sub-repo permission rule. Rows are written when a rule is created or updated, and when a repository is created or renamed.';
+
+-- New and renamed repositories are matched against the existing rules here, so
+-- that reading permissions never evaluates rule patterns against every
+-- repository.
+CREATE OR REPLACE FUNCTION func_sub_repo_permission_rule_repos_match() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        DELETE FROM sub_repo_permission_rule_repos WHERE repo_id = NEW.id;
+    END IF;
+
+    INSERT INTO sub_repo_permission_rule_repos (rule_id, repo_id)
+    SELECT rl.id, NEW.id
+    FROM sub_repo_permission_rules rl
+    WHERE NEW.name ~ rl.repo_pattern;
+
+    RETURN NULL;
+END;
+$$;
+
+DROP TRIGGER IF EXISTS trigger_sub_repo_permission_rule_repos_insert ON repo;
+CREATE TRIGGER trigger_sub_repo_permission_rule_repos_insert
+AFTER INSERT ON repo
+FOR EACH ROW EXECUTE FUNCTION func_sub_repo_permission_rule_repos_match();
+
+DROP TRIGGER IF EXISTS trigger_sub_repo_permission_rule_repos_rename ON repo;
+CREATE TRIGGER trigger_sub_repo_permission_rule_repos_rename
+AFTER UPDATE OF name ON repo
+FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name) EXECUTE FUNCTION func_sub_repo_permission_rule_repos_match();
@@ -0,0 +1,7 @@
+DELETE FROM feature_flags WHERE flag_type::text = 'variant';
+
+ALTER TABLE feature_flags DROP CONSTRAINT IF EXISTS required_variant_fields;
+ALTER TABLE feature_flags DROP COLUMN IF EXISTS variant_value;
+ALTER TABLE feature_flags DROP COLUMN IF EXISTS rules;
+
+-- Enum values cannot be dropped, the unused 'variant' value of feature_flag_type is left in place.
@@ -0,0 +1,19 @@
+ALTER TYPE feature_flag_type ADD VALUE IF NOT EXISTS 'variant';
+
+ALTER TABLE feature_flags ADD COLUMN IF NOT EXISTS variant_value JSONB;
+ALTER TABLE feature_flags ADD COLUMN IF NOT EXISTS rules JSONB DEFAULT '[]'::jsonb NOT NULL;
+
+COMMENT ON COLUMN feature_flags.variant_value IS 'Variant value only defined when flag_type is variant. Any JSON value, for example a string or an object.';
+COMMENT ON COLUMN feature_flags.rules IS 'Ordered targeting rules. The value of the first rule whose predicates match the user is used instead of the default value of the flag.';
+
+-- The new enum value cannot be used in the same transaction it was added in,
+-- so we compare against its text representation instead.
+ALTER TABLE feature_flags DROP CONSTRAINT IF EXISTS required_variant_fields;
+ALTER TABLE feature_flags ADD CONSTRAINT required_variant_fields CHECK ((1 =
+CASE
+    WHEN ((flag_type::text = 'variant'::text) AND (variant_value IS NULL)) THEN 0
+    WHEN ((flag_type::text <> 'variant'::text) AND (variant_value IS NOT NULL)) THEN 0
+    ELSE 1
+END));
+
+COMMENT ON CONSTRAINT required_variant_fields ON feature_flags IS 'Checks that variant_value is set IFF flag_type = variant';
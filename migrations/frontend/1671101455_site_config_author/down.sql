@@ -0,0 +1 @@
+ALTER TABLE critical_and_site_config DROP COLUMN IF EXISTS author_user_id;
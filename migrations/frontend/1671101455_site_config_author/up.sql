@@ -0,0 +1,3 @@
+ALTER TABLE critical_and_site_config ADD COLUMN IF NOT EXISTS author_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL DEFERRABLE;
+
+COMMENT ON COLUMN critical_and_site_config.author_user_id IS 'The user who saved this version of the site configuration. NULL if it was saved by Sourcegraph itself, for example from SITE_CONFIG_FILE, or if it predates this column.';
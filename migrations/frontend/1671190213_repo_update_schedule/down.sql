@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS repo_update_scheduler_lease;
+DROP TABLE IF EXISTS repo_update_schedule_requests;
+DROP TABLE IF EXISTS repo_update_schedule;
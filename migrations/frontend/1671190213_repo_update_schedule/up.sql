@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS repo_update_schedule (
+    repo_id integer PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    interval_seconds integer,
+    due_at timestamp with time zone,
+    queue_priority integer,
+    queue_seq bigint,
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT repo_update_schedule_scheduled_or_queued CHECK (
+        (interval_seconds IS NULL) = (due_at IS NULL) AND
+        (due_at IS NOT NULL OR queue_priority IS NOT NULL)
+    )
+);
+
+CREATE INDEX IF NOT EXISTS repo_update_schedule_due_at ON repo_update_schedule (due_at, repo_id);
+
+COMMENT ON TABLE repo_update_schedule IS 'The persisted state of the repo-updater git update scheduler. It is written by the leading repo-updater replica and restored when another replica takes over.';
+COMMENT ON COLUMN repo_update_schedule.interval_seconds IS 'The update interval of the repository. NULL if the repository is queued for an update but not scheduled.';
+COMMENT ON COLUMN repo_update_schedule.due_at IS 'The time the repository is due for its next scheduled update. NULL if the repository is queued for an update but not scheduled.';
+COMMENT ON COLUMN repo_update_schedule.queue_priority IS 'The priority of the repository in the update queue. NULL if the repository is not queued for an update.';
+COMMENT ON COLUMN repo_update_schedule.queue_seq IS 'The position of the repository among queued repositories with the same priority.';
+
+CREATE TABLE IF NOT EXISTS repo_update_schedule_requests (
+    id bigserial PRIMARY KEY,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    action text NOT NULL,
+    priority integer,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT repo_update_schedule_requests_action_valid CHECK (action IN ('upsert', 'remove', 'update'))
+);
+
+COMMENT ON TABLE repo_update_schedule_requests IS 'Changes to the git update schedule received by repo-updater replicas that are not the scheduler leader. The leader applies and deletes them.';
+COMMENT ON COLUMN repo_update_schedule_requests.priority IS 'The priority the repository should be queued for an update with. NULL if it should not be queued.';
+
+CREATE TABLE IF NOT EXISTS repo_update_scheduler_lease (
+    id integer PRIMARY KEY DEFAULT 1,
+    holder text NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    CONSTRAINT repo_update_scheduler_lease_single_row CHECK (id = 1)
+);
+
+COMMENT ON TABLE repo_update_scheduler_lease IS 'The lease held by the repo-updater replica that is the git update scheduler leader. It has at most one row.';
+COMMENT ON COLUMN repo_update_scheduler_lease.holder IS 'Identifies the repo-updater process holding the lease.';
+COMMENT ON COLUMN repo_update_scheduler_lease.expires_at IS 'The time after which another replica may take over the lease unless the holder renews it.';
@@ -0,0 +1,14 @@
+DELETE FROM permissions
+WHERE (namespace, action) IN (
+    ('BATCH_CHANGES', 'SITE_CREDENTIALS_MANAGE'),
+    ('CODE_INSIGHTS', 'GLOBAL_DASHBOARDS_MANAGE'),
+    ('CODE_INTEL', 'UPLOADS_DELETE'),
+    ('CODE_INTEL', 'INDEXES_DELETE')
+);
+
+DELETE FROM roles
+WHERE readonly AND name IN (
+    'BATCH_CHANGES_ADMIN',
+    'CODE_INSIGHTS_ADMIN',
+    'CODE_INTEL_OPERATOR'
+);
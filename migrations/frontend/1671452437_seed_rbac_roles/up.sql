@@ -0,0 +1,27 @@
+INSERT INTO roles (name, readonly)
+VALUES
+    ('BATCH_CHANGES_ADMIN', TRUE),
+    ('CODE_INSIGHTS_ADMIN', TRUE),
+    ('CODE_INTEL_OPERATOR', TRUE)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO permissions (namespace, action)
+VALUES
+    ('BATCH_CHANGES', 'SITE_CREDENTIALS_MANAGE'),
+    ('CODE_INSIGHTS', 'GLOBAL_DASHBOARDS_MANAGE'),
+    ('CODE_INTEL', 'UPLOADS_DELETE'),
+    ('CODE_INTEL', 'INDEXES_DELETE')
+ON CONFLICT (namespace, action) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id
+FROM (
+    VALUES
+        ('BATCH_CHANGES_ADMIN', 'BATCH_CHANGES', 'SITE_CREDENTIALS_MANAGE'),
+        ('CODE_INSIGHTS_ADMIN', 'CODE_INSIGHTS', 'GLOBAL_DASHBOARDS_MANAGE'),
+        ('CODE_INTEL_OPERATOR', 'CODE_INTEL', 'UPLOADS_DELETE'),
+        ('CODE_INTEL_OPERATOR', 'CODE_INTEL', 'INDEXES_DELETE')
+) AS grants (role_name, namespace, action)
+JOIN roles ON roles.name = grants.role_name
+JOIN permissions ON permissions.namespace = grants.namespace AND permissions.action = grants.action
+ON CONFLICT DO NOTHING;
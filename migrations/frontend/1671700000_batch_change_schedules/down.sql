@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS batch_change_schedule_runs;
+DROP TABLE IF EXISTS batch_change_schedules;
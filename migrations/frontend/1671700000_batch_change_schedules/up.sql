@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS batch_change_schedules (
+    id bigserial PRIMARY KEY,
+    batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    cron text NOT NULL,
+    auto_apply text NOT NULL DEFAULT 'NEVER',
+    max_consecutive_failures integer NOT NULL DEFAULT 3,
+    consecutive_failures integer NOT NULL DEFAULT 0,
+    next_run_at timestamp with time zone NOT NULL,
+    paused_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    CONSTRAINT batch_change_schedules_max_consecutive_failures_positive CHECK (max_consecutive_failures > 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_change_schedules_batch_change_id ON batch_change_schedules(batch_change_id);
+CREATE INDEX IF NOT EXISTS batch_change_schedules_next_run_at ON batch_change_schedules(next_run_at) WHERE paused_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS batch_change_schedule_runs (
+    id bigserial PRIMARY KEY,
+    schedule_id bigint NOT NULL REFERENCES batch_change_schedules(id) ON DELETE CASCADE DEFERRABLE,
+    batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    batch_spec_id bigint REFERENCES batch_specs(id) ON DELETE SET NULL DEFERRABLE,
+    state text NOT NULL,
+    failure_message text,
+    applied boolean NOT NULL DEFAULT false,
+    started_at timestamp with time zone NOT NULL DEFAULT now(),
+    finished_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS batch_change_schedule_runs_schedule_id ON batch_change_schedule_runs(schedule_id);
+CREATE INDEX IF NOT EXISTS batch_change_schedule_runs_state ON batch_change_schedule_runs(state);
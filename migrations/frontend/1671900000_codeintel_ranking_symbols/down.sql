@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS codeintel_ranking_dirty_repositories;
+DROP TABLE IF EXISTS codeintel_ranking_references;
+DROP TABLE IF EXISTS codeintel_ranking_definitions;
@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS codeintel_ranking_definitions (
+    id bigserial PRIMARY KEY,
+    upload_id integer NOT NULL REFERENCES lsif_uploads(id) ON DELETE CASCADE,
+    graph_key text NOT NULL,
+    repository_name text NOT NULL,
+    document_path text NOT NULL,
+    symbol_name text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS codeintel_ranking_definitions_graph_key_symbol_name ON codeintel_ranking_definitions USING btree (graph_key, symbol_name);
+CREATE INDEX IF NOT EXISTS codeintel_ranking_definitions_graph_key_repository_name ON codeintel_ranking_definitions USING btree (graph_key, repository_name, created_at);
+CREATE INDEX IF NOT EXISTS codeintel_ranking_definitions_upload_id ON codeintel_ranking_definitions USING btree (upload_id);
+
+COMMENT ON TABLE codeintel_ranking_definitions IS 'Symbols defined by the documents of uploads visible at the tip of the default branch, used to compute document ranks in-process.';
+
+CREATE TABLE IF NOT EXISTS codeintel_ranking_references (
+    id bigserial PRIMARY KEY,
+    upload_id integer NOT NULL REFERENCES lsif_uploads(id) ON DELETE CASCADE,
+    graph_key text NOT NULL,
+    symbol_name text NOT NULL,
+    document_count integer NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS codeintel_ranking_references_graph_key_symbol_name ON codeintel_ranking_references USING btree (graph_key, symbol_name, created_at);
+CREATE INDEX IF NOT EXISTS codeintel_ranking_references_upload_id ON codeintel_ranking_references USING btree (upload_id);
+
+COMMENT ON TABLE codeintel_ranking_references IS 'Symbols referenced by the documents of uploads visible at the tip of the default branch, used to compute document ranks in-process.';
+COMMENT ON COLUMN codeintel_ranking_references.document_count IS 'The number of documents of the upload that reference the symbol without defining it.';
+
+CREATE TABLE IF NOT EXISTS codeintel_ranking_dirty_repositories (
+    graph_key text NOT NULL,
+    repository_name text NOT NULL,
+    dirty_token integer NOT NULL DEFAULT 0,
+    update_token integer NOT NULL DEFAULT 0,
+    updated_at timestamp with time zone,
+    PRIMARY KEY (graph_key, repository_name)
+);
+
+CREATE INDEX IF NOT EXISTS codeintel_ranking_dirty_repositories_graph_key_dirty ON codeintel_ranking_dirty_repositories USING btree (graph_key, repository_name) WHERE dirty_token > update_token;
+
+COMMENT ON TABLE codeintel_ranking_dirty_repositories IS 'Stores whether or not the in-process document ranks of a repository are out of date (when dirty_token > update_token).';
+COMMENT ON COLUMN codeintel_ranking_dirty_repositories.dirty_token IS 'This value is incremented each time the definitions of the repository, or the references to them, are inserted or deleted.';
+COMMENT ON COLUMN codeintel_ranking_dirty_repositories.update_token IS 'Set to the value of dirty_token visible to the transaction that computes the document ranks of the repository.';
+COMMENT ON COLUMN codeintel_ranking_dirty_repositories.updated_at IS 'The time the update_token value was last updated.';
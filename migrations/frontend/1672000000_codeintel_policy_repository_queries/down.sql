@@ -0,0 +1 @@
+ALTER TABLE lsif_configuration_policies DROP COLUMN IF EXISTS repository_query;
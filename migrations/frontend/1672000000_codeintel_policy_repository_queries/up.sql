@@ -0,0 +1,3 @@
+ALTER TABLE lsif_configuration_policies ADD COLUMN IF NOT EXISTS repository_query text;
+
+COMMENT ON COLUMN lsif_configuration_policies.repository_query IS 'If supplied, this configuration policy applies to repositories matching the repository filters (repo:, fork:, archived:, visibility:, context:) of this search query. If both repository_patterns and repository_query are supplied, a repository matching either of them is covered.';
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS trigger_search_contexts_query_changed ON search_contexts;
+DROP FUNCTION IF EXISTS func_search_contexts_query_changed();
+
+DROP TABLE IF EXISTS search_context_query_repo_changes;
+DROP TABLE IF EXISTS search_context_query_repos;
+
+ALTER TABLE search_contexts DROP COLUMN IF EXISTS query_repos_synced_at;
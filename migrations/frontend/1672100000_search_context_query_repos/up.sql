@@ -0,0 +1,41 @@
+ALTER TABLE search_contexts ADD COLUMN IF NOT EXISTS query_repos_synced_at timestamp with time zone;
+
+COMMENT ON COLUMN search_contexts.query_repos_synced_at IS 'When the repositories matched by the query of this search context were last evaluated. NULL if they must be re-evaluated.';
+
+CREATE TABLE IF NOT EXISTS search_context_query_repos (
+    search_context_id bigint NOT NULL REFERENCES search_contexts(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    PRIMARY KEY (search_context_id, repo_id)
+);
+
+COMMENT ON TABLE search_context_query_repos IS 'The repositories matched by the query of a search context as of its last evaluation.';
+
+CREATE TABLE IF NOT EXISTS search_context_query_repo_changes (
+    id bigserial PRIMARY KEY,
+    search_context_id bigint NOT NULL REFERENCES search_contexts(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    added boolean NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE search_context_query_repo_changes IS 'The history of repositories starting or stopping to match the query of a search context.';
+COMMENT ON COLUMN search_context_query_repo_changes.added IS 'True if the repository started matching the query, false if it stopped matching it.';
+
+CREATE INDEX IF NOT EXISTS search_context_query_repo_changes_search_context_id ON search_context_query_repo_changes(search_context_id, id DESC);
+
+CREATE OR REPLACE FUNCTION func_search_contexts_query_changed() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF NEW.query IS DISTINCT FROM OLD.query THEN
+        NEW.query_repos_synced_at = NULL;
+    END IF;
+
+    RETURN NEW;
+END;
+$$;
+
+DROP TRIGGER IF EXISTS trigger_search_contexts_query_changed ON search_contexts;
+CREATE TRIGGER trigger_search_contexts_query_changed
+BEFORE UPDATE OF query ON search_contexts
+FOR EACH ROW EXECUTE FUNCTION func_search_contexts_query_changed();
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS saved_search_runs;
+DROP TABLE IF EXISTS saved_search_schedules;
@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS saved_search_schedules (
+    saved_search_id integer PRIMARY KEY REFERENCES saved_searches(id) ON DELETE CASCADE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    interval_minutes integer NOT NULL CHECK (interval_minutes > 0),
+    notify_email boolean NOT NULL DEFAULT false,
+    slack_webhook_url text,
+    webhook_url text,
+    next_run_at timestamp with time zone NOT NULL DEFAULT now(),
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE saved_search_schedules IS 'Saved searches that are run periodically to notify about changes in their results.';
+COMMENT ON COLUMN saved_search_schedules.user_id IS 'The user the saved search is run as. Email notifications are sent to this user.';
+
+CREATE INDEX IF NOT EXISTS saved_search_schedules_next_run_at ON saved_search_schedules(next_run_at);
+
+CREATE TABLE IF NOT EXISTS saved_search_runs (
+    id bigserial PRIMARY KEY,
+    saved_search_id integer NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+    query text NOT NULL,
+    permalink_query text NOT NULL,
+    ran_at timestamp with time zone NOT NULL DEFAULT now(),
+    result_count integer NOT NULL,
+    fingerprint text[] NOT NULL,
+    added text[] NOT NULL,
+    removed text[] NOT NULL,
+    limit_hit boolean NOT NULL DEFAULT false,
+    added_count integer NOT NULL DEFAULT 0,
+    removed_count integer NOT NULL DEFAULT 0
+);
+
+COMMENT ON TABLE saved_search_runs IS 'The results of scheduled runs of saved searches.';
+COMMENT ON COLUMN saved_search_runs.permalink_query IS 'A query that reproduces the results of this run as closely as possible, with revisions pinned to the commits searched at the time of the run.';
+COMMENT ON COLUMN saved_search_runs.fingerprint IS 'The sorted keys identifying each match of this run. Only stored for the latest run of each saved search.';
+COMMENT ON COLUMN saved_search_runs.added IS 'The first keys of the matches that were not part of the previous run.';
+COMMENT ON COLUMN saved_search_runs.removed IS 'The first keys of the matches of the previous run that are no longer part of this run.';
+COMMENT ON COLUMN saved_search_runs.limit_hit IS 'Whether the run stopped before finding all matches. Such runs are not compared to other runs.';
+COMMENT ON COLUMN saved_search_runs.added_count IS 'The number of matches that were not part of the previous run.';
+COMMENT ON COLUMN saved_search_runs.removed_count IS 'The number of matches of the previous run that are no longer part of this run.';
+
+CREATE INDEX IF NOT EXISTS saved_search_runs_saved_search_id ON saved_search_runs(saved_search_id, id DESC);
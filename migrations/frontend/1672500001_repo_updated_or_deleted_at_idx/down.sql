@@ -0,0 +1 @@
+DROP INDEX IF EXISTS repo_updated_or_deleted_at_idx;
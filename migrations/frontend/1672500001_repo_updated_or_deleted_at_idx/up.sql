@@ -0,0 +1,2 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS repo_updated_or_deleted_at_idx
+    ON repo USING btree ((GREATEST(updated_at, deleted_at)));
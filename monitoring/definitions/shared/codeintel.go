@@ -116,6 +116,27 @@ func (codeIntelligence) NewUploadProcessorGroup(containerName string) monitoring
 		Panel:          monitoring.Panel().Unit(monitoring.Bytes).LegendFormat("{{instance}}"),
 	})
 
+	group.Rows = append(group.Rows, monitoring.Row{
+		{
+			Name:        "codeintel_upload_symbol_count_regressions",
+			Description: "uploads defining significantly fewer symbols than the previous upload every 1h",
+			Owner:       monitoring.ObservableOwnerCodeIntel,
+			Query:       `sum(increase(src_codeintel_upload_symbol_count_regressions_total{job=~"^precise-code-intel-worker.*"}[1h]))`,
+			Warning:     monitoring.Alert().Greater(0),
+			Panel:       monitoring.Panel().Unit(monitoring.Number),
+			Interpretation: `
+				The number of processed uploads whose symbol count dropped below the configured fraction of the previous
+				upload for the same repository, root, and indexer. This check is disabled unless
+				PRECISE_CODE_INTEL_WORKER_SYMBOL_COUNT_REGRESSION_THRESHOLD is set.
+			`,
+			NextSteps: `
+				- Check the precise-code-intel-worker logs for the upload identifiers of the affected uploads.
+				- Compare the affected uploads with their previous upload via the 'diff' field of the 'LSIFUpload' GraphQL type to find the documents that lost symbols.
+				- A drop is often caused by an indexer version change or by a build failure during indexing.
+			`,
+		},
+	})
+
 	return group
 }
 
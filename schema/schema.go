@@ -58,12 +58,20 @@ type AWSKMSEncryptionKey struct {
 type ApiRatelimit struct {
 	// Enabled description: Whether API rate limiting is enabled
 	Enabled bool `json:"enabled"`
+	// GitHTTP description: Limits for git fetches and clones over HTTP. Each request counts as one request. If omitted, git requests are not limited.
+	GitHTTP *GitHTTP `json:"gitHTTP,omitempty"`
 	// Overrides description: An array of rate limit overrides
 	Overrides []*Overrides `json:"overrides,omitempty"`
+	// PerAccessToken description: Limit granted per access token per hour. Requests authenticated with an access token count against both this limit and the limit of the user. If omitted, they only count against the limit of the user.
+	PerAccessToken int `json:"perAccessToken,omitempty"`
 	// PerIP description: Limit granted per IP per hour, only applied to anonymous users
 	PerIP int `json:"perIP"`
 	// PerUser description: Limit granted per user per hour
 	PerUser int `json:"perUser"`
+	// SearchStreams description: Limits for streaming search requests. Each search counts as one request. If omitted, streaming search requests are not limited. The maximum number of concurrent searches also applies to GraphQL search queries.
+	SearchStreams *SearchStreams `json:"searchStreams,omitempty"`
+	// TrustedProxies description: IPs or CIDR ranges of reverse proxies in front of Sourcegraph. The IP of anonymous requests is only taken from the X-Forwarded-For header of requests sent by these proxies; otherwise the address of the connection is used.
+	TrustedProxies []string `json:"trustedProxies,omitempty"`
 }
 
 // AuditLog description: EXPERIMENTAL: Configuration for audit logging (specially formatted log entries for tracking sensitive events)
@@ -165,6 +173,31 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
 }
 
+// AutoMerge description: A policy to merge published changesets automatically once their reviews and checks are in the required state.
+type AutoMerge struct {
+	// Enabled description: Whether the policy applies to the changesets of a repository. If omitted, the policy applies to all changesets.
+	Enabled interface{} `json:"enabled,omitempty"`
+	// MergeMethod description: The method used to merge the changesets. Defaults to merge.
+	MergeMethod string `json:"mergeMethod,omitempty"`
+	// MergeWindow description: Restricts automatic merges to the given days and times. All times are in UTC. If omitted, changesets are merged as soon as they satisfy the policy.
+	MergeWindow *MergeWindow `json:"mergeWindow,omitempty"`
+	// RequiredCheckState description: The state the checks of changesets need to be in to be merged. Defaults to passed.
+	RequiredCheckState string `json:"requiredCheckState,omitempty"`
+	// RequiredReviewState description: The review state changesets need to be in to be merged. Defaults to approved.
+	RequiredReviewState string `json:"requiredReviewState,omitempty"`
+}
+
+// AutoMergePolicy description: The policy to merge the changeset automatically once its reviews and checks are in the required state. If omitted, the changeset is never merged automatically.
+type AutoMergePolicy struct {
+	// MergeMethod description: The method used to merge the changeset.
+	MergeMethod string `json:"mergeMethod"`
+	// MergeWindow description: Restricts automatic merges to the given days and times. All times are in UTC.
+	MergeWindow *ChangesetMergeWindow `json:"mergeWindow,omitempty"`
+	// RequiredCheckState description: The state the checks of the changeset need to be in to be merged.
+	RequiredCheckState string `json:"requiredCheckState"`
+	// RequiredReviewState description: The review state the changeset needs to be in to be merged.
+	RequiredReviewState string `json:"requiredReviewState"`
+}
 type BackendInsight struct {
 	// Description description: The description of this insight
 	Description string          `json:"description,omitempty"`
@@ -213,12 +246,20 @@ type BatchSpec struct {
 	Workspaces []*WorkspaceConfiguration `json:"workspaces,omitempty"`
 }
 
+// BitbucketCloudAuthorization description: If non-null, enforces Bitbucket Cloud repository permissions. The configured "username" must be an administrator of each workspace in "teams", since workspace permissions are read with its app password.
+type BitbucketCloudAuthorization struct {
+	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Cloud identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Bitbucket Cloud (the Bitbucket Cloud nickname) and `auth.enableUsernameChanges` must be set to false for security reasons.
+	IdentityProvider BitbucketCloudIdentityProvider `json:"identityProvider"`
+}
+
 // BitbucketCloudConnection description: Configuration for a connection to Bitbucket Cloud.
 type BitbucketCloudConnection struct {
 	// ApiURL description: The API URL of Bitbucket Cloud, such as https://api.bitbucket.org. Generally, admin should not modify the value of this option because Bitbucket Cloud is a public hosting platform.
 	ApiURL string `json:"apiURL,omitempty"`
 	// AppPassword description: The app password to use when authenticating to the Bitbucket Cloud. Also set the corresponding "username" field.
 	AppPassword string `json:"appPassword"`
+	// Authorization description: If non-null, enforces Bitbucket Cloud repository permissions. The configured "username" must be an administrator of each workspace in "teams", since workspace permissions are read with its app password.
+	Authorization *BitbucketCloudAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from Bitbucket Cloud. Takes precedence over "teams" configuration.
 	//
 	// Supports excluding by name ({"name": "myorg/myrepo"}) or by UUID ({"uuid": "{fceb73c7-cef6-4abe-956d-e471281126bd}"}).
@@ -249,6 +290,31 @@ type BitbucketCloudConnection struct {
 	WebhookSecret string `json:"webhookSecret,omitempty"`
 }
 
+// BitbucketCloudIdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Cloud identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Bitbucket Cloud (the Bitbucket Cloud nickname) and `auth.enableUsernameChanges` must be set to false for security reasons.
+type BitbucketCloudIdentityProvider struct {
+	Username *BitbucketCloudUsernameIdentity
+}
+
+func (v BitbucketCloudIdentityProvider) MarshalJSON() ([]byte, error) {
+	if v.Username != nil {
+		return json.Marshal(v.Username)
+	}
+	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
+}
+func (v *BitbucketCloudIdentityProvider) UnmarshalJSON(data []byte) error {
+	var d struct {
+		DiscriminantProperty string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	switch d.DiscriminantProperty {
+	case "username":
+		return json.Unmarshal(data, &v.Username)
+	}
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"username"})
+}
+
 // BitbucketCloudRateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
 type BitbucketCloudRateLimit struct {
 	// Enabled description: true if rate limiting is enabled.
@@ -256,6 +322,9 @@ type BitbucketCloudRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+type BitbucketCloudUsernameIdentity struct {
+	Type string `json:"type"`
+}
 
 // BitbucketServerAuthorization description: If non-null, enforces Bitbucket Server / Bitbucket Data Center repository permissions.
 type BitbucketServerAuthorization struct {
@@ -380,6 +449,10 @@ type BitbucketServerUsernameIdentity struct {
 	Type string `json:"type"`
 }
 type BranchChangesetSpec struct {
+	// Assignees description: The users to assign the changeset to.
+	Assignees []string `json:"assignees,omitempty"`
+	// AutoMerge description: The policy to merge the changeset automatically once its reviews and checks are in the required state. If omitted, the changeset is never merged automatically.
+	AutoMerge *AutoMergePolicy `json:"autoMerge,omitempty"`
 	// BaseRef description: The full name of the Git ref in the base repository that this changeset is based on (and is proposing to be merged into). This ref must exist on the base repository.
 	BaseRef string `json:"baseRef"`
 	// BaseRepository description: The GraphQL ID of the repository that this changeset spec is proposing to change.
@@ -390,12 +463,24 @@ type BranchChangesetSpec struct {
 	Body string `json:"body"`
 	// Commits description: The Git commits with the proposed changes. These commits are pushed to the head ref.
 	Commits []*GitCommitDescription `json:"commits"`
+	// DependsOn description: The changesets in the same batch change that have to be merged before the changeset is published, selected by the name of their repository or by a name they provide.
+	DependsOn []string `json:"dependsOn,omitempty"`
 	// HeadRef description: The full name of the Git ref that holds the changes proposed by this changeset. This ref will be created or updated with the commits.
 	HeadRef string `json:"headRef"`
 	// HeadRepository description: The GraphQL ID of the repository that contains the branch with this changeset's changes. Fork repositories and cross-repository changesets are not yet supported. Therefore, headRepository must be equal to baseRepository.
 	HeadRepository string `json:"headRepository"`
+	// Labels description: The labels to add to the changeset.
+	Labels []string `json:"labels,omitempty"`
+	// Milestone description: The title of the milestone to put the changeset into.
+	Milestone string `json:"milestone,omitempty"`
+	// Provides description: Names that other changesets in the same batch change can depend on the changeset by, in addition to the name of its repository.
+	Provides []string `json:"provides,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host.
 	Published interface{} `json:"published,omitempty"`
+	// Reviewers description: The users to request a review of the changeset from.
+	Reviewers []string `json:"reviewers,omitempty"`
+	// TeamReviewers description: The teams to request a review of the changeset from.
+	TeamReviewers []string `json:"teamReviewers,omitempty"`
 	// Title description: The title of the changeset on the code host.
 	Title string `json:"title"`
 	// Version description: A field for versioning the payload.
@@ -431,16 +516,39 @@ type BuiltinAuthProvider struct {
 	Type        string `json:"type"`
 }
 
+// ChangesetMergeWindow description: Restricts automatic merges to the given days and times. All times are in UTC.
+type ChangesetMergeWindow struct {
+	Days  []string `json:"days,omitempty"`
+	End   string   `json:"end,omitempty"`
+	Start string   `json:"start,omitempty"`
+}
+
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
+	// Assignees description: The users to assign the changeset to. Each entry is a template and can expand into multiple comma- or newline-separated users. Not supported on Bitbucket.
+	Assignees []string `json:"assignees,omitempty"`
+	// AutoMerge description: A policy to merge published changesets automatically once their reviews and checks are in the required state.
+	AutoMerge *AutoMerge `json:"autoMerge,omitempty"`
 	// Body description: The body (description) of the changeset.
 	Body string `json:"body,omitempty"`
 	// Branch description: The name of the Git branch to create or update on each repository with the changes.
 	Branch string `json:"branch"`
 	// Commit description: The Git commit to create with the changes.
 	Commit ExpandedGitCommitDescription `json:"commit"`
+	// DependsOn description: The changesets in this batch change that have to be merged before the changeset is published, selected by the name of their repository or by a name they provide. Until then, the changeset is published as a draft if the code host supports it, and left unpublished otherwise. Each entry is a template and can expand into multiple comma- or newline-separated names, e.g. from a step output. Changesets in the changeset's own repository are ignored.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// Labels description: The labels to add to the changeset. Each entry is a template and can expand into multiple comma- or newline-separated labels. Not supported on Bitbucket.
+	Labels []string `json:"labels,omitempty"`
+	// Milestone description: The title of the milestone to put the changeset into. Not supported on Bitbucket.
+	Milestone string `json:"milestone,omitempty"`
+	// Provides description: Names that other changesets in this batch change can list in dependsOn to depend on the changeset, in addition to the name of its repository. Each entry is a template and can expand into multiple comma- or newline-separated names, so that changesets can be selected by a step output.
+	Provides []string `json:"provides,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.
 	Published interface{} `json:"published,omitempty"`
+	// Reviewers description: The users to request a review of the changeset from. Each entry is a template and can expand into multiple comma- or newline-separated users.
+	Reviewers []string `json:"reviewers,omitempty"`
+	// TeamReviewers description: The teams to request a review of the changeset from, either as team or as organization/team. Each entry is a template and can expand into multiple comma- or newline-separated teams. Only supported on GitHub.
+	TeamReviewers []string `json:"teamReviewers,omitempty"`
 	// Title description: The title of the changeset.
 	Title string `json:"title"`
 }
@@ -509,6 +617,7 @@ type EncryptionKey struct {
 	Cloudkms *CloudKMSEncryptionKey
 	Awskms   *AWSKMSEncryptionKey
 	Mounted  *MountedEncryptionKey
+	Vault    *VaultEncryptionKey
 	Noop     *NoOpEncryptionKey
 }
 
@@ -522,6 +631,9 @@ func (v EncryptionKey) MarshalJSON() ([]byte, error) {
 	if v.Mounted != nil {
 		return json.Marshal(v.Mounted)
 	}
+	if v.Vault != nil {
+		return json.Marshal(v.Vault)
+	}
 	if v.Noop != nil {
 		return json.Marshal(v.Noop)
 	}
@@ -543,8 +655,10 @@ func (v *EncryptionKey) UnmarshalJSON(data []byte) error {
 		return json.Unmarshal(data, &v.Mounted)
 	case "noop":
 		return json.Unmarshal(data, &v.Noop)
+	case "vault":
+		return json.Unmarshal(data, &v.Vault)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"cloudkms", "awskms", "mounted", "noop"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"cloudkms", "awskms", "mounted", "vault", "noop"})
 }
 
 // EncryptionKeys description: Configuration for encryption keys used to encrypt data at rest in the database.
@@ -791,6 +905,16 @@ type GitCommitDescription struct {
 	Version int `json:"version,omitempty"`
 }
 
+// GitHTTP description: Limits for git fetches and clones over HTTP. Each request counts as one request. If omitted, git requests are not limited.
+type GitHTTP struct {
+	// PerAccessToken description: Number of git requests granted per access token per hour, in addition to the limit of the user
+	PerAccessToken int `json:"perAccessToken,omitempty"`
+	// PerIP description: Number of git requests granted per IP per hour, only applied to anonymous users
+	PerIP int `json:"perIP"`
+	// PerUser description: Number of git requests granted per user per hour
+	PerUser int `json:"perUser"`
+}
+
 // GitHubApp description: The config options for Sourcegraph GitHub App.
 type GitHubApp struct {
 	// AppID description: The app ID of the GitHub App for Sourcegraph.
@@ -890,6 +1014,8 @@ type GitHubConnection struct {
 	//
 	// If you need to narrow the set of mirrored repositories further (and don't want to enumerate it with a list or query set as above), create a new bot/machine user on GitHub or GitHub Enterprise that is only affiliated with the desired repositories.
 	RepositoryQuery []string `json:"repositoryQuery,omitempty"`
+	// Teams description: An array of "org/team-slug" strings identifying GitHub teams whose repositories should be mirrored on Sourcegraph. Repositories are tagged with the teams they belong to (as `github.team:org/team-slug`), which can be used to define search contexts with `repo:has.tag(github.team:org/team-slug)`. Team membership of repositories mirrored through other options is only recorded if the team is listed here.
+	Teams []string `json:"teams,omitempty"`
 	// Token description: A GitHub personal access token. Create one for GitHub.com at https://github.com/settings/tokens/new?description=Sourcegraph (for GitHub Enterprise, replace github.com with your instance's hostname). See https://docs.sourcegraph.com/admin/external_service/github#github-api-token-and-access for which scopes are required for which use cases.
 	Token string `json:"token,omitempty"`
 	// Url description: URL of a GitHub instance, such as https://github.com or https://github-enterprise.example.com.
@@ -1185,6 +1311,16 @@ type MavenRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+
+// MergeWindow description: Restricts automatic merges to the given days and times. All times are in UTC. If omitted, changesets are merged as soon as they satisfy the policy.
+type MergeWindow struct {
+	// Days description: Day(s) the window applies to. If omitted, the window applies to all days of the week.
+	Days []string `json:"days,omitempty"`
+	// End description: Window end time. If omitted, no time window is applied to the day(s) that match this rule.
+	End string `json:"end,omitempty"`
+	// Start description: Window start time. If omitted, no time window is applied to the day(s) that match this rule.
+	Start string `json:"start,omitempty"`
+}
 type Mount struct {
 	// Mountpoint description: The path in the container to mount the path on the local machine to.
 	Mountpoint string `json:"mountpoint"`
@@ -1442,10 +1578,16 @@ type OutputVariable struct {
 	Value string `json:"value"`
 }
 type Overrides struct {
-	// Key description: The key that we want to override for example a username
+	// GitHTTP description: The number of git requests per hour, 'unlimited' or 'blocked'
+	GitHTTP interface{} `json:"gitHTTP,omitempty"`
+	// Key description: The key that we want to override: the ID of a user, or an IP address for anonymous requests
 	Key string `json:"key,omitempty"`
-	// Limit description: The limit per hour, 'unlimited' or 'blocked'
+	// Limit description: The limit of GraphQL query cost per hour, 'unlimited' or 'blocked'. 'unlimited' and 'blocked' also apply to search streams and git requests unless they are overridden separately.
 	Limit interface{} `json:"limit,omitempty"`
+	// MaxConcurrentSearchStreams description: The maximum number of searches that can run at the same time
+	MaxConcurrentSearchStreams int `json:"maxConcurrentSearchStreams,omitempty"`
+	// SearchStreams description: The number of searches per hour, 'unlimited' or 'blocked'
+	SearchStreams interface{} `json:"searchStreams,omitempty"`
 }
 
 // PagureConnection description: Configuration for a connection to Pagure.
@@ -1766,6 +1908,20 @@ type SearchScope struct {
 	Value string `json:"value"`
 }
 
+// SearchStreams description: Limits for streaming search requests. Each search counts as one request. If omitted, streaming search requests are not limited. The maximum number of concurrent searches also applies to GraphQL search queries.
+type SearchStreams struct {
+	// MaxConcurrentPerIP description: Maximum number of searches an anonymous IP can run at the same time, counting both streaming searches and GraphQL search queries. If omitted, concurrent searches are not limited.
+	MaxConcurrentPerIP int `json:"maxConcurrentPerIP,omitempty"`
+	// MaxConcurrentPerUser description: Maximum number of searches a user can run at the same time, counting both streaming searches and GraphQL search queries. If omitted, concurrent searches are not limited.
+	MaxConcurrentPerUser int `json:"maxConcurrentPerUser,omitempty"`
+	// PerAccessToken description: Number of searches granted per access token per hour, in addition to the limit of the user
+	PerAccessToken int `json:"perAccessToken,omitempty"`
+	// PerIP description: Number of searches granted per IP per hour, only applied to anonymous users
+	PerIP int `json:"perIP"`
+	// PerUser description: Number of searches granted per user per hour
+	PerUser int `json:"perUser"`
+}
+
 // Sentry description: Configuration for Sentry
 type Sentry struct {
 	// BackendDSN description: Sentry Data Source Name (DSN) for backend errors. Per the Sentry docs (https://docs.sentry.io/quickstart/#about-the-dsn), it should match the following pattern: '{PROTOCOL}://{PUBLIC_KEY}@{HOST}/{PATH}{PROJECT_ID}'.
@@ -2267,6 +2423,8 @@ type SiteConfiguration struct {
 	RedactOutboundRequestHeaders *bool `json:"redactOutboundRequestHeaders,omitempty"`
 	// RepoConcurrentExternalServiceSyncers description: The number of concurrent external service syncers that can run.
 	RepoConcurrentExternalServiceSyncers int `json:"repoConcurrentExternalServiceSyncers,omitempty"`
+	// RepoDeletionConfirmationThreshold description: The number of repositories a change to a code host connection's configuration may delete before the change has to be explicitly confirmed. The repositories that would be deleted are determined by previewing the sync with the new configuration. Set to 0 to confirm every deletion, or to -1 to never require confirmation.
+	RepoDeletionConfirmationThreshold *int `json:"repoDeletionConfirmationThreshold,omitempty"`
 	// RepoListUpdateInterval description: Interval (in minutes) for checking code hosts (such as GitHub, Gitolite, etc.) for new repositories.
 	RepoListUpdateInterval int `json:"repoListUpdateInterval,omitempty"`
 	// RepoPurgeWorker description: Configuration for repository purge worker.
@@ -2304,7 +2462,7 @@ type SrcCliVersionCache struct {
 // Step description: A command to run (as part of a sequence) in a repository branch to produce the required changes.
 type Step struct {
 	// Container description: The Docker image used to launch the Docker container in which the shell command is run.
-	Container string `json:"container"`
+	Container string `json:"container,omitempty"`
 	// Env description: Environment variables to set in the step environment.
 	Env interface{} `json:"env,omitempty"`
 	// Files description: Files that should be mounted into or be created inside the Docker container.
@@ -2316,7 +2474,11 @@ type Step struct {
 	// Outputs description: Output variables of this step that can be referenced in the changesetTemplate or other steps via outputs.<name-of-output>
 	Outputs map[string]OutputVariable `json:"outputs,omitempty"`
 	// Run description: The shell command to run in the container. It can also be a multi-line shell script. The working directory is the root directory of the repository checkout.
-	Run string `json:"run"`
+	Run string `json:"run,omitempty"`
+	// Uses description: A built-in step that is run natively on the workspace instead of in a Docker container. `replace` replaces matches of a regular expression, `comby` rewrites code with a Comby match and rewrite template and `write-file` writes a file. Cannot be combined with `run` and `container`.
+	Uses string `json:"uses,omitempty"`
+	// With description: The parameters of the built-in step set in `uses`. Values support templating. `replace` takes `pattern`, `replacement` and optionally `files`; `comby` takes `match`, `rewrite` and optionally `rule`, `matcher` and `files`; `write-file` takes `path` and `content`. `files` is a comma-separated list of file suffixes to restrict the step to.
+	With map[string]string `json:"with,omitempty"`
 }
 type SubRepoPermissions struct {
 	// Enabled description: Enables sub-repo permission checking
@@ -2383,6 +2545,41 @@ type UsernameIdentity struct {
 	Type string `json:"type"`
 }
 
+// VaultAuth description: How to authenticate to Vault. Tokens obtained from Vault are renewed before they expire.
+type VaultAuth struct {
+	Method string `json:"method"`
+	// Mount description: The path the auth method is mounted at. Defaults to the name of the method. Not used by the token method.
+	Mount string `json:"mount,omitempty"`
+	// Role description: The Vault role to log in as using the kubernetes method.
+	Role string `json:"role,omitempty"`
+	// RoleID description: The role ID to log in with using the approle method.
+	RoleID string `json:"roleID,omitempty"`
+	// SecretID description: The secret ID to log in with using the approle method.
+	SecretID string `json:"secretID,omitempty"`
+	// SecretIDFile description: A file containing the secret ID to log in with using the approle method.
+	SecretIDFile string `json:"secretIDFile,omitempty"`
+	// ServiceAccountTokenFile description: The service account token file to log in with using the kubernetes method.
+	ServiceAccountTokenFile string `json:"serviceAccountTokenFile,omitempty"`
+	// Token description: The Vault token to use with the token method.
+	Token string `json:"token,omitempty"`
+	// TokenFile description: A file containing the Vault token to use with the token method.
+	TokenFile string `json:"tokenFile,omitempty"`
+}
+
+// VaultEncryptionKey description: HashiCorp Vault Transit encryption key. Values are encrypted and decrypted by Vault's Transit secrets engine, and values encrypted with a previous version of the key are re-encrypted with the latest version after the key is rotated.
+type VaultEncryptionKey struct {
+	// Address description: The address of the Vault server.
+	Address string    `json:"address"`
+	Auth    VaultAuth `json:"auth"`
+	// KeyName description: The name of the Transit key.
+	KeyName string `json:"keyName"`
+	// Namespace description: The Vault Enterprise namespace the Transit engine is mounted in.
+	Namespace string `json:"namespace,omitempty"`
+	// TransitMount description: The path the Transit secrets engine is mounted at.
+	TransitMount string `json:"transitMount,omitempty"`
+	Type         string `json:"type"`
+}
+
 // VersionContext description: Configuration of the version context
 type VersionContext struct {
 	// Description description: Description of the version context